@@ -0,0 +1,94 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	log "github.com/golang/glog"
+	"github.com/openconfig/lemming/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	api "github.com/osrg/gobgp/v3/api"
+)
+
+// advertisedAfiSafis returns the AFI-SAFIs in the multiprotocol capabilities
+// of an OPEN message.
+//
+// A speaker that doesn't advertise any multiprotocol capability only supports
+// IPv4 unicast (RFC 4760).
+func advertisedAfiSafis(caps []*anypb.Any) map[oc.E_BgpTypes_AFI_SAFI_TYPE]bool {
+	afiSafis := map[oc.E_BgpTypes_AFI_SAFI_TYPE]bool{}
+	mpbgp := false
+	for _, c := range caps {
+		m, err := c.UnmarshalNew()
+		if err != nil {
+			log.Warningf("BGP: cannot unmarshal capability %v: %v", c, err)
+			continue
+		}
+		mp, ok := m.(*api.MultiProtocolCapability)
+		if !ok {
+			continue
+		}
+		mpbgp = true
+		switch f := mp.GetFamily(); {
+		case f.GetAfi() == api.Family_AFI_IP && f.GetSafi() == api.Family_SAFI_UNICAST:
+			afiSafis[oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST] = true
+		case f.GetAfi() == api.Family_AFI_IP6 && f.GetSafi() == api.Family_SAFI_UNICAST:
+			afiSafis[oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST] = true
+		}
+	}
+	if !mpbgp {
+		afiSafis[oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST] = true
+	}
+	return afiSafis
+}
+
+// negotiatedAfiSafis returns the AFI-SAFIs negotiated on the session with a
+// neighbour, which are those advertised by both ends of the session. No
+// AFI-SAFI is negotiated unless the session is established.
+func negotiatedAfiSafis(ps *api.PeerState) map[oc.E_BgpTypes_AFI_SAFI_TYPE]bool {
+	if ps.GetSessionState() != api.PeerState_ESTABLISHED {
+		return nil
+	}
+	local, remote := advertisedAfiSafis(ps.GetLocalCap()), advertisedAfiSafis(ps.GetRemoteCap())
+	for afiSafi := range local {
+		if !remote[afiSafi] {
+			delete(local, afiSafi)
+		}
+	}
+	return local
+}
+
+// recordActiveAfiSafis records whether each AFI-SAFI of each neighbour is
+// negotiated on its session.
+//
+// Enabling or disabling an AFI-SAFI changes the capabilities advertised to the
+// neighbour, so GoBGP resets the session to renegotiate them; the AFI-SAFI
+// becomes active once the session is re-established.
+func (t *bgpTask) recordActiveAfiSafis(peers map[string]*api.PeerState) {
+	for addr, neigh := range t.appliedBGP.Neighbor {
+		ps, ok := peers[addr]
+		if !ok {
+			continue
+		}
+		negotiated := negotiatedAfiSafis(ps)
+		for afiSafi := range negotiated {
+			neigh.GetOrCreateAfiSafi(afiSafi)
+		}
+		for name, afiSafi := range neigh.AfiSafi {
+			afiSafi.Active = ygot.Bool(negotiated[name])
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/lemming/gnmi/oc"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	api "github.com/osrg/gobgp/v3/api"
+)
+
+func mustCapabilities(t *testing.T, families ...*api.Family) []*anypb.Any {
+	t.Helper()
+	caps := []*anypb.Any{}
+	for _, f := range append([]*api.Family{nil}, families...) {
+		var c *anypb.Any
+		var err error
+		if f == nil {
+			c, err = anypb.New(&api.RouteRefreshCapability{})
+		} else {
+			c, err = anypb.New(&api.MultiProtocolCapability{Family: f})
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		caps = append(caps, c)
+	}
+	return caps
+}
+
+func TestNegotiatedAfiSafis(t *testing.T) {
+	v4 := &api.Family{Afi: api.Family_AFI_IP, Safi: api.Family_SAFI_UNICAST}
+	v6 := &api.Family{Afi: api.Family_AFI_IP6, Safi: api.Family_SAFI_UNICAST}
+	tests := []struct {
+		desc string
+		ps   *api.PeerState
+		want map[oc.E_BgpTypes_AFI_SAFI_TYPE]bool
+	}{{
+		desc: "not established",
+		ps: &api.PeerState{
+			SessionState: api.PeerState_ACTIVE,
+			LocalCap:     mustCapabilities(t, v4),
+			RemoteCap:    mustCapabilities(t, v4),
+		},
+	}, {
+		desc: "both ends",
+		ps: &api.PeerState{
+			SessionState: api.PeerState_ESTABLISHED,
+			LocalCap:     mustCapabilities(t, v4, v6),
+			RemoteCap:    mustCapabilities(t, v6, v4),
+		},
+		want: map[oc.E_BgpTypes_AFI_SAFI_TYPE]bool{
+			oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST: true,
+			oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST: true,
+		},
+	}, {
+		desc: "one end",
+		ps: &api.PeerState{
+			SessionState: api.PeerState_ESTABLISHED,
+			LocalCap:     mustCapabilities(t, v4, v6),
+			RemoteCap:    mustCapabilities(t, v4),
+		},
+		want: map[oc.E_BgpTypes_AFI_SAFI_TYPE]bool{
+			oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST: true,
+		},
+	}, {
+		desc: "no multiprotocol capability",
+		ps: &api.PeerState{
+			SessionState: api.PeerState_ESTABLISHED,
+			LocalCap:     mustCapabilities(t, v4, v6),
+			RemoteCap:    mustCapabilities(t),
+		},
+		want: map[oc.E_BgpTypes_AFI_SAFI_TYPE]bool{
+			oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST: true,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, negotiatedAfiSafis(tt.ps)); diff != "" {
+				t.Errorf("negotiatedAfiSafis() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
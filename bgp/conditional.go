@@ -0,0 +1,344 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"fmt"
+	"math"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/golang/glog"
+
+	"github.com/openconfig/lemming/gnmi/oc"
+
+	api "github.com/osrg/gobgp/v3/api"
+	gobgpoc "github.com/osrg/gobgp/v3/pkg/config/oc"
+)
+
+const (
+	// conditionalImportPolicy is the name of the import policy marking the
+	// routes whose advertisement is suppressed.
+	conditionalImportPolicy = "conditional-advertisement-import"
+	// conditionalExportPolicy is the name of the export policy rejecting the
+	// marked routes towards the neighbours they are suppressed for.
+	conditionalExportPolicy = "conditional-advertisement-export"
+)
+
+// ConditionalAdvertisement advertises the routes matching a prefix set to a
+// neighbour only while a route matching another prefix set exists, or
+// doesn't exist, in the BGP RIB.
+//
+// OpenConfig doesn't model conditional advertisement, so it is configured
+// when starting the device, with the prefix sets and the neighbour
+// configured using OpenConfig.
+//
+// If the advertised prefix set includes the default route, it also
+// conditions the origination of the default route to the neighbour when
+// send-default-route is enabled.
+type ConditionalAdvertisement struct {
+	// Neighbor is the address of the neighbour the routes are advertised to.
+	Neighbor string
+	// AdvertiseSet is the name of the prefix set of the conditionally
+	// advertised routes.
+	AdvertiseSet string
+	// ConditionSet is the name of the prefix set of the tracked routes.
+	ConditionSet string
+	// NonExist advertises the routes only while no tracked route exists,
+	// instead of only while one does.
+	NonExist bool
+}
+
+// prefixRange is an entry of a prefix set.
+type prefixRange struct {
+	prefix netip.Prefix
+	// minLen and maxLen are the range of accepted mask lengths.
+	minLen, maxLen int
+}
+
+// contains returns whether the range matches the route prefix p.
+func (r prefixRange) contains(p netip.Prefix) bool {
+	return r.prefix.Addr().Is4() == p.Addr().Is4() && r.prefix.Contains(p.Addr()) && p.Bits() >= r.minLen && p.Bits() <= r.maxLen
+}
+
+// conditionTracker tracks the best routes of the BGP RIB to find which
+// conditional advertisements must be suppressed.
+//
+// Suppressed routes are marked with a large community by an import policy and
+// rejected by an export policy towards the neighbour. Marking them, instead of
+// only rejecting them, changes the routes so that GoBGP withdraws the ones that
+// were already advertised. Only routes received from neighbours can be
+// suppressed since GoBGP doesn't apply import policies to locally-originated
+// routes.
+type conditionTracker struct {
+	conds []ConditionalAdvertisement
+	// onChange is called whenever the set of suppressed advertisements
+	// changes.
+	onChange func()
+
+	mu sync.Mutex
+	// routes is the set of prefixes having a best route.
+	routes map[netip.Prefix]bool
+	// prefixSets are the prefix sets of the last intended configuration.
+	prefixSets map[string][]prefixRange
+	// suppressed stores whether each advertisement is suppressed.
+	suppressed []bool
+}
+
+// newConditionTracker returns a tracker for the given advertisements.
+func newConditionTracker(conds []ConditionalAdvertisement, onChange func()) *conditionTracker {
+	t := &conditionTracker{
+		conds:    conds,
+		onChange: onChange,
+		routes:   map[netip.Prefix]bool{},
+	}
+	t.suppressed = t.evaluate()
+	return t
+}
+
+// setPrefixSets updates the prefix sets used to evaluate the conditions.
+// It doesn't call onChange since the caller is expected to apply the
+// result.
+func (t *conditionTracker) setPrefixSets(sets map[string]*oc.RoutingPolicy_DefinedSets_PrefixSet) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.prefixSets = map[string][]prefixRange{}
+	for name, set := range sets {
+		for _, p := range set.Prefix {
+			r, err := parsePrefixRange(p.GetIpPrefix(), p.GetMasklengthRange())
+			if err != nil {
+				log.Errorf("BGP: ignoring prefix of prefix set %q: %v", name, err)
+				continue
+			}
+			t.prefixSets[name] = append(t.prefixSets[name], r)
+		}
+	}
+	t.suppressed = t.evaluate()
+}
+
+// update applies the best path changes of the RIB, calling onChange if the
+// set of suppressed advertisements changed.
+func (t *conditionTracker) update(paths []*api.Path) {
+	t.mu.Lock()
+	for _, path := range paths {
+		p, ok := pathPrefix(path)
+		if !ok {
+			continue
+		}
+		if path.GetIsWithdraw() {
+			delete(t.routes, p)
+		} else {
+			t.routes[p] = true
+		}
+	}
+	suppressed := t.evaluate()
+	changed := !slices.Equal(suppressed, t.suppressed)
+	t.suppressed = suppressed
+	t.mu.Unlock()
+	if changed {
+		log.V(1).Infof("BGP: conditional advertisements suppressed: %v", suppressed)
+		t.onChange()
+	}
+}
+
+// evaluate returns whether each advertisement is suppressed. t.mu must be
+// held.
+func (t *conditionTracker) evaluate() []bool {
+	suppressed := make([]bool, len(t.conds))
+	for i, cond := range t.conds {
+		exists := false
+		for p := range t.routes {
+			if slices.ContainsFunc(t.prefixSets[cond.ConditionSet], func(r prefixRange) bool { return r.contains(p) }) {
+				exists = true
+				break
+			}
+		}
+		suppressed[i] = exists == cond.NonExist
+	}
+	return suppressed
+}
+
+// suppresses returns whether a suppressed advertisement to the neighbour
+// includes the prefix p.
+func (t *conditionTracker) suppresses(neighbor string, p netip.Prefix) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, cond := range t.conds {
+		if t.suppressed[i] && cond.Neighbor == neighbor && slices.ContainsFunc(t.prefixSets[cond.AdvertiseSet], func(r prefixRange) bool { return r.contains(p) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// suppressedIndices returns the indices of the suppressed advertisements.
+func (t *conditionTracker) suppressedIndices() []int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var indices []int
+	for i, suppressed := range t.suppressed {
+		if suppressed {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// parsePrefixRange parses an OpenConfig prefix set entry.
+func parsePrefixRange(prefix, masklengthRange string) (prefixRange, error) {
+	p, err := netip.ParsePrefix(prefix)
+	if err != nil {
+		return prefixRange{}, err
+	}
+	r := prefixRange{prefix: p.Masked(), minLen: p.Bits(), maxLen: p.Bits()}
+	if masklengthRange == "" || masklengthRange == "exact" {
+		return r, nil
+	}
+	minStr, maxStr, ok := strings.Cut(masklengthRange, "..")
+	if !ok {
+		return prefixRange{}, fmt.Errorf("invalid mask length range %q", masklengthRange)
+	}
+	if r.minLen, err = strconv.Atoi(minStr); err != nil {
+		return prefixRange{}, fmt.Errorf("invalid mask length range %q: %v", masklengthRange, err)
+	}
+	if r.maxLen, err = strconv.Atoi(maxStr); err != nil {
+		return prefixRange{}, fmt.Errorf("invalid mask length range %q: %v", masklengthRange, err)
+	}
+	if r.minLen < 0 || r.minLen > r.maxLen {
+		return prefixRange{}, fmt.Errorf("invalid mask length range %q: lower bound must be between 0 and the upper bound", masklengthRange)
+	}
+	if maxLen := p.Addr().BitLen(); r.maxLen > maxLen {
+		return prefixRange{}, fmt.Errorf("invalid mask length range %q: upper bound exceeds %d, the maximum length of prefix %q", masklengthRange, maxLen, prefix)
+	}
+	return r, nil
+}
+
+// pathPrefix returns the prefix of a GoBGP unicast path, or false if it
+// isn't one.
+func pathPrefix(path *api.Path) (netip.Prefix, bool) {
+	nlri := &api.IPAddressPrefix{}
+	if err := path.GetNlri().UnmarshalTo(nlri); err != nil {
+		return netip.Prefix{}, false
+	}
+	addr, err := netip.ParseAddr(nlri.GetPrefix())
+	if err != nil {
+		log.Errorf("BGP: Unable to parse prefix %q: %v", nlri.GetPrefix(), err)
+		return netip.Prefix{}, false
+	}
+	return netip.PrefixFrom(addr.Unmap(), int(nlri.GetPrefixLen())), true
+}
+
+// conditionalMarker returns the large community marking the routes of the
+// suppressed advertisement at index i. The reserved last ASN is used as the
+// global administrator so the marker can't clash with received communities.
+func conditionalMarker(i int) string {
+	return fmt.Sprintf("%d:0:%d", uint32(math.MaxUint32), i)
+}
+
+// applyConditionalAdvertisements adds the policies to bgpConfig that stop
+// advertising the routes of conds at the suppressed indices to their
+// neighbours. The policies are evaluated before any of the neighbours'
+// policies.
+//
+// The import policy first removes all markers since GoBGP stores the
+// post-policy routes in the Adj-RIB-In when it is queried, and the export
+// policy removes them before the routes are advertised.
+func applyConditionalAdvertisements(bgpConfig *gobgpoc.BgpConfigSet, conds []ConditionalAdvertisement, suppressed []int) {
+	if len(conds) == 0 {
+		return
+	}
+	var markers []string
+	for i := range conds {
+		markers = append(markers, conditionalMarker(i))
+	}
+	removeMarkers := gobgpoc.Actions{
+		BgpActions: gobgpoc.BgpActions{
+			SetLargeCommunity: gobgpoc.SetLargeCommunity{
+				SetLargeCommunityMethod: gobgpoc.SetLargeCommunityMethod{
+					CommunitiesList: markers,
+				},
+				Options: gobgpoc.BGP_SET_COMMUNITY_OPTION_TYPE_REMOVE,
+			},
+		},
+	}
+	importPolicy := gobgpoc.PolicyDefinition{
+		Name: conditionalImportPolicy,
+		Statements: []gobgpoc.Statement{{
+			Name:    conditionalImportPolicy,
+			Actions: removeMarkers,
+		}},
+	}
+	exportPolicy := gobgpoc.PolicyDefinition{Name: conditionalExportPolicy}
+	for _, i := range suppressed {
+		cond := conds[i]
+		if !slices.ContainsFunc(bgpConfig.DefinedSets.PrefixSets, func(s gobgpoc.PrefixSet) bool { return s.PrefixSetName == cond.AdvertiseSet }) {
+			log.Errorf("BGP: prefix set %q of conditional advertisement doesn't exist", cond.AdvertiseSet)
+			continue
+		}
+		if !slices.ContainsFunc(bgpConfig.DefinedSets.NeighborSets, func(s gobgpoc.NeighborSet) bool { return s.NeighborSetName == cond.Neighbor }) {
+			log.Errorf("BGP: neighbor %q of conditional advertisement doesn't exist", cond.Neighbor)
+			continue
+		}
+		markerSet := fmt.Sprintf("%s|%d", conditionalExportPolicy, i)
+		bgpConfig.DefinedSets.BgpDefinedSets.LargeCommunitySets = append(bgpConfig.DefinedSets.BgpDefinedSets.LargeCommunitySets, gobgpoc.LargeCommunitySet{
+			LargeCommunitySetName: markerSet,
+			LargeCommunityList:    []string{markers[i]},
+		})
+		importPolicy.Statements = append(importPolicy.Statements, gobgpoc.Statement{
+			Name: fmt.Sprintf("%s|%d", conditionalImportPolicy, i),
+			Conditions: gobgpoc.Conditions{
+				MatchPrefixSet: gobgpoc.MatchPrefixSet{
+					PrefixSet: cond.AdvertiseSet,
+				},
+			},
+			Actions: gobgpoc.Actions{
+				BgpActions: gobgpoc.BgpActions{
+					SetLargeCommunity: gobgpoc.SetLargeCommunity{
+						SetLargeCommunityMethod: gobgpoc.SetLargeCommunityMethod{
+							CommunitiesList: []string{markers[i]},
+						},
+						Options: gobgpoc.BGP_SET_COMMUNITY_OPTION_TYPE_ADD,
+					},
+				},
+			},
+		})
+		exportPolicy.Statements = append(exportPolicy.Statements, gobgpoc.Statement{
+			Name: markerSet,
+			Conditions: gobgpoc.Conditions{
+				MatchNeighborSet: gobgpoc.MatchNeighborSet{
+					NeighborSet: cond.Neighbor,
+				},
+				BgpConditions: gobgpoc.BgpConditions{
+					MatchLargeCommunitySet: gobgpoc.MatchLargeCommunitySet{
+						LargeCommunitySet: markerSet,
+					},
+				},
+			},
+			Actions: gobgpoc.Actions{
+				RouteDisposition: gobgpoc.ROUTE_DISPOSITION_REJECT_ROUTE,
+			},
+		})
+	}
+	exportPolicy.Statements = append(exportPolicy.Statements, gobgpoc.Statement{
+		Name:    conditionalExportPolicy,
+		Actions: removeMarkers,
+	})
+	bgpConfig.PolicyDefinitions = append(bgpConfig.PolicyDefinitions, importPolicy, exportPolicy)
+	bgpConfig.Global.ApplyPolicy.Config.ImportPolicyList = append([]string{conditionalImportPolicy}, bgpConfig.Global.ApplyPolicy.Config.ImportPolicyList...)
+	bgpConfig.Global.ApplyPolicy.Config.ExportPolicyList = append([]string{conditionalExportPolicy}, bgpConfig.Global.ApplyPolicy.Config.ExportPolicyList...)
+}
@@ -0,0 +1,250 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/lemming/gnmi/oc"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	api "github.com/osrg/gobgp/v3/api"
+	gobgpoc "github.com/osrg/gobgp/v3/pkg/config/oc"
+)
+
+func mustPrefixPath(t *testing.T, prefix string, prefixLen uint32, withdraw bool) *api.Path {
+	t.Helper()
+	nlri, err := anypb.New(&api.IPAddressPrefix{Prefix: prefix, PrefixLen: prefixLen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &api.Path{Nlri: nlri, IsWithdraw: withdraw}
+}
+
+func TestConditionTracker(t *testing.T) {
+	changes := 0
+	tracker := newConditionTracker([]ConditionalAdvertisement{{
+		AdvertiseSet: "backup",
+		ConditionSet: "primary",
+		NonExist:     true,
+	}, {
+		AdvertiseSet: "backup",
+		ConditionSet: "primary",
+	}}, func() { changes++ })
+
+	policy := &oc.RoutingPolicy{}
+	primary := policy.GetOrCreateDefinedSets().GetOrCreatePrefixSet("primary")
+	primary.GetOrCreatePrefix("10.0.0.0/8", "16..24")
+	primary.GetOrCreatePrefix("2001:db8::/32", "exact")
+	tracker.setPrefixSets(policy.GetDefinedSets().PrefixSet)
+
+	steps := []struct {
+		desc           string
+		inPaths        []*api.Path
+		wantSuppressed []int
+		wantChanges    int
+	}{{
+		desc:           "no-routes",
+		wantSuppressed: []int{1},
+	}, {
+		desc:           "mask-length-outside-range",
+		inPaths:        []*api.Path{mustPrefixPath(t, "10.0.0.0", 8, false), mustPrefixPath(t, "10.1.1.0", 25, false)},
+		wantSuppressed: []int{1},
+	}, {
+		desc:           "other-family",
+		inPaths:        []*api.Path{mustPrefixPath(t, "::", 0, false)},
+		wantSuppressed: []int{1},
+	}, {
+		desc:           "route-exists",
+		inPaths:        []*api.Path{mustPrefixPath(t, "10.1.0.0", 16, false)},
+		wantSuppressed: []int{0},
+		wantChanges:    1,
+	}, {
+		desc:           "another-route-exists",
+		inPaths:        []*api.Path{mustPrefixPath(t, "2001:db8::", 32, false)},
+		wantSuppressed: []int{0},
+		wantChanges:    1,
+	}, {
+		desc:           "one-route-withdrawn",
+		inPaths:        []*api.Path{mustPrefixPath(t, "10.1.0.0", 16, true)},
+		wantSuppressed: []int{0},
+		wantChanges:    1,
+	}, {
+		desc:           "all-routes-withdrawn",
+		inPaths:        []*api.Path{mustPrefixPath(t, "2001:db8::", 32, true)},
+		wantSuppressed: []int{1},
+		wantChanges:    2,
+	}}
+
+	for _, step := range steps {
+		tracker.update(step.inPaths)
+		if diff := cmp.Diff(step.wantSuppressed, tracker.suppressedIndices()); diff != "" {
+			t.Errorf("%s: suppressed advertisements (-want, +got):\n%s", step.desc, diff)
+		}
+		if changes != step.wantChanges {
+			t.Errorf("%s: got %d changes, want %d", step.desc, changes, step.wantChanges)
+		}
+	}
+}
+
+func TestApplyConditionalAdvertisements(t *testing.T) {
+	conds := []ConditionalAdvertisement{{
+		Neighbor:     "192.0.2.1",
+		AdvertiseSet: "backup",
+		ConditionSet: "primary",
+	}, {
+		Neighbor:     "192.0.2.2",
+		AdvertiseSet: "backup",
+		ConditionSet: "primary",
+	}}
+	removeMarkers := gobgpoc.Actions{
+		BgpActions: gobgpoc.BgpActions{
+			SetLargeCommunity: gobgpoc.SetLargeCommunity{
+				SetLargeCommunityMethod: gobgpoc.SetLargeCommunityMethod{
+					CommunitiesList: []string{"4294967295:0:0", "4294967295:0:1"},
+				},
+				Options: gobgpoc.BGP_SET_COMMUNITY_OPTION_TYPE_REMOVE,
+			},
+		},
+	}
+	definedSets := gobgpoc.DefinedSets{
+		PrefixSets:   []gobgpoc.PrefixSet{{PrefixSetName: "backup"}},
+		NeighborSets: []gobgpoc.NeighborSet{{NeighborSetName: "192.0.2.1"}},
+	}
+
+	tests := []struct {
+		desc         string
+		inConds      []ConditionalAdvertisement
+		inSuppressed []int
+		wantConfig   *gobgpoc.BgpConfigSet
+	}{{
+		desc: "no-conditional-advertisements",
+		wantConfig: &gobgpoc.BgpConfigSet{
+			DefinedSets: definedSets,
+			Global: gobgpoc.Global{
+				ApplyPolicy: gobgpoc.ApplyPolicy{
+					Config: gobgpoc.ApplyPolicyConfig{
+						ImportPolicyList: []string{"neigh-import"},
+						ExportPolicyList: []string{"neigh-export"},
+					},
+				},
+			},
+		},
+	}, {
+		desc:    "none-suppressed",
+		inConds: conds,
+		wantConfig: &gobgpoc.BgpConfigSet{
+			DefinedSets: definedSets,
+			Global: gobgpoc.Global{
+				ApplyPolicy: gobgpoc.ApplyPolicy{
+					Config: gobgpoc.ApplyPolicyConfig{
+						ImportPolicyList: []string{conditionalImportPolicy, "neigh-import"},
+						ExportPolicyList: []string{conditionalExportPolicy, "neigh-export"},
+					},
+				},
+			},
+			PolicyDefinitions: []gobgpoc.PolicyDefinition{{
+				Name: conditionalImportPolicy,
+				Statements: []gobgpoc.Statement{{
+					Name:    conditionalImportPolicy,
+					Actions: removeMarkers,
+				}},
+			}, {
+				Name: conditionalExportPolicy,
+				Statements: []gobgpoc.Statement{{
+					Name:    conditionalExportPolicy,
+					Actions: removeMarkers,
+				}},
+			}},
+		},
+	}, {
+		desc:         "suppressed",
+		inConds:      conds,
+		inSuppressed: []int{0, 1},
+		wantConfig: &gobgpoc.BgpConfigSet{
+			DefinedSets: gobgpoc.DefinedSets{
+				PrefixSets:   definedSets.PrefixSets,
+				NeighborSets: definedSets.NeighborSets,
+				BgpDefinedSets: gobgpoc.BgpDefinedSets{
+					LargeCommunitySets: []gobgpoc.LargeCommunitySet{{
+						LargeCommunitySetName: conditionalExportPolicy + "|0",
+						LargeCommunityList:    []string{"4294967295:0:0"},
+					}},
+				},
+			},
+			Global: gobgpoc.Global{
+				ApplyPolicy: gobgpoc.ApplyPolicy{
+					Config: gobgpoc.ApplyPolicyConfig{
+						ImportPolicyList: []string{conditionalImportPolicy, "neigh-import"},
+						ExportPolicyList: []string{conditionalExportPolicy, "neigh-export"},
+					},
+				},
+			},
+			// The second advertisement's neighbour doesn't exist.
+			PolicyDefinitions: []gobgpoc.PolicyDefinition{{
+				Name: conditionalImportPolicy,
+				Statements: []gobgpoc.Statement{{
+					Name:    conditionalImportPolicy,
+					Actions: removeMarkers,
+				}, {
+					Name: conditionalImportPolicy + "|0",
+					Conditions: gobgpoc.Conditions{
+						MatchPrefixSet: gobgpoc.MatchPrefixSet{PrefixSet: "backup"},
+					},
+					Actions: gobgpoc.Actions{
+						BgpActions: gobgpoc.BgpActions{
+							SetLargeCommunity: gobgpoc.SetLargeCommunity{
+								SetLargeCommunityMethod: gobgpoc.SetLargeCommunityMethod{
+									CommunitiesList: []string{"4294967295:0:0"},
+								},
+								Options: gobgpoc.BGP_SET_COMMUNITY_OPTION_TYPE_ADD,
+							},
+						},
+					},
+				}},
+			}, {
+				Name: conditionalExportPolicy,
+				Statements: []gobgpoc.Statement{{
+					Name: conditionalExportPolicy + "|0",
+					Conditions: gobgpoc.Conditions{
+						MatchNeighborSet: gobgpoc.MatchNeighborSet{NeighborSet: "192.0.2.1"},
+						BgpConditions: gobgpoc.BgpConditions{
+							MatchLargeCommunitySet: gobgpoc.MatchLargeCommunitySet{LargeCommunitySet: conditionalExportPolicy + "|0"},
+						},
+					},
+					Actions: gobgpoc.Actions{
+						RouteDisposition: gobgpoc.ROUTE_DISPOSITION_REJECT_ROUTE,
+					},
+				}, {
+					Name:    conditionalExportPolicy,
+					Actions: removeMarkers,
+				}},
+			}},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := &gobgpoc.BgpConfigSet{DefinedSets: definedSets}
+			got.Global.ApplyPolicy.Config.ImportPolicyList = []string{"neigh-import"}
+			got.Global.ApplyPolicy.Config.ExportPolicyList = []string{"neigh-export"}
+			applyConditionalAdvertisements(got, tt.inConds, tt.inSuppressed)
+			if diff := cmp.Diff(tt.wantConfig, got); diff != "" {
+				t.Errorf("(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ package bgp
 import (
 	"net/netip"
 	"slices"
+	"time"
 
 	log "github.com/golang/glog"
 	"github.com/openconfig/lemming/gnmi/oc"
@@ -30,7 +31,10 @@ import (
 // GoBGP's notion of config vs. state does not conform to OpenConfig (see
 // https://github.com/osrg/gobgp/issues/2584)
 // Therefore, we need a compatibility layer between the two configs.
-func intendedToGoBGP(bgpoc *oc.NetworkInstance_Protocol_Bgp, policyoc *oc.RoutingPolicy, zapiURL string, listenPort uint16) *gobgpoc.BgpConfigSet {
+//
+// llgrRestartTime is the long-lived graceful restart time advertised for the
+// AFI-SAFIs with graceful restart enabled, or zero to disable LLGR.
+func intendedToGoBGP(bgpoc *oc.NetworkInstance_Protocol_Bgp, policyoc *oc.RoutingPolicy, zapiURL string, listenPort uint16, llgrRestartTime time.Duration) *gobgpoc.BgpConfigSet {
 	bgpConfig := &gobgpoc.BgpConfigSet{}
 
 	// Global config
@@ -48,27 +52,44 @@ func intendedToGoBGP(bgpoc *oc.NetworkInstance_Protocol_Bgp, policyoc *oc.Routin
 
 	for neighAddr, neigh := range bgpoc.Neighbor {
 		// Add neighbour config.
-		bgpConfig.Neighbors = append(bgpConfig.Neighbors, gobgpoc.Neighbor{
-			Config: gobgpoc.NeighborConfig{
-				PeerAs:          neigh.GetPeerAs(),
-				NeighborAddress: neighAddr,
-			},
-			// This is needed because GoBGP's configuration diffing
-			// logic may check the state value instead of the
-			// config value.
-			State: gobgpoc.NeighborState{
-				PeerAs:          neigh.GetPeerAs(),
-				NeighborAddress: neighAddr,
-			},
-			Transport: gobgpoc.Transport{
-				Config: gobgpoc.TransportConfig{
-					LocalAddress: neigh.GetTransport().GetLocalAddress(),
-					RemotePort:   neigh.GetNeighborPort(),
-				},
+		bgpConfig.Neighbors = append(bgpConfig.Neighbors, convertNeighbor(neighAddr, neigh, bgpoc.PeerGroup[neigh.GetPeerGroup()]))
+	}
+
+	peerGroupNames := lemmingutil.Mapkeys(bgpoc.PeerGroup)
+	slices.Sort(peerGroupNames)
+	for _, pgName := range peerGroupNames {
+		bgpConfig.PeerGroups = append(bgpConfig.PeerGroups, convertPeerGroup(pgName, bgpoc.PeerGroup[pgName]))
+	}
+
+	// Dynamic neighbours are accepted from any address within the prefix
+	// and take their session parameters from the referenced peer-group.
+	// GoBGP never initiates sessions to them.
+	dynamicPrefixes := lemmingutil.Mapkeys(global.DynamicNeighborPrefix)
+	slices.Sort(dynamicPrefixes)
+	for _, prefix := range dynamicPrefixes {
+		pgName := global.DynamicNeighborPrefix[prefix].GetPeerGroup()
+		if _, ok := bgpoc.PeerGroup[pgName]; !ok {
+			// GoBGP requires the peer-group to exist before the range is added.
+			log.Errorf("Dynamic neighbor prefix %q references unknown peer-group %q", prefix, pgName)
+			continue
+		}
+		bgpConfig.DynamicNeighbors = append(bgpConfig.DynamicNeighbors, gobgpoc.DynamicNeighbor{
+			Config: gobgpoc.DynamicNeighborConfig{
+				Prefix:    prefix,
+				PeerGroup: pgName,
 			},
 		})
 	}
 
+	if llgrRestartTime > 0 {
+		for i := range bgpConfig.Neighbors {
+			enableLLGR(&bgpConfig.Neighbors[i].GracefulRestart, bgpConfig.Neighbors[i].AfiSafis, llgrRestartTime)
+		}
+		for i := range bgpConfig.PeerGroups {
+			enableLLGR(&bgpConfig.PeerGroups[i].GracefulRestart, bgpConfig.PeerGroups[i].AfiSafis, llgrRestartTime)
+		}
+	}
+
 	intendedToGoBGPPolicies(bgpoc, policyoc, bgpConfig)
 
 	bgpConfig.Zebra.Config = gobgpoc.ZebraConfig{
@@ -86,6 +107,155 @@ func intendedToGoBGP(bgpoc *oc.NetworkInstance_Protocol_Bgp, policyoc *oc.Routin
 	return bgpConfig
 }
 
+// convertNeighbor converts an OC neighbour to GoBGP config, inheriting any
+// leaves that aren't set on the neighbour from its peer-group.
+//
+// The peer-group is resolved here instead of being passed to GoBGP since
+// GoBGP overwrites all of a neighbour's config with its peer-group's config
+// rather than only the leaves that the neighbour doesn't set.
+func convertNeighbor(neighAddr string, neigh *oc.NetworkInstance_Protocol_Bgp_Neighbor, pg *oc.NetworkInstance_Protocol_Bgp_PeerGroup) gobgpoc.Neighbor {
+	// Work on shallow copies so that empty containers aren't added to
+	// the intended config.
+	n, p := *neigh, oc.NetworkInstance_Protocol_Bgp_PeerGroup{}
+	if pg != nil {
+		p = *pg
+	}
+	timers, pgTimers := n.GetOrCreateTimers(), p.GetOrCreateTimers()
+	transport, pgTransport := n.GetOrCreateTransport(), p.GetOrCreateTransport()
+	gr, pgGR := n.GetOrCreateGracefulRestart(), p.GetOrCreateGracefulRestart()
+
+	peerAs := inherit(n.PeerAs, p.PeerAs)
+	afiSafis := convertAfiSafis(n.AfiSafi)
+	if len(afiSafis) == 0 {
+		afiSafis = convertAfiSafis(p.AfiSafi)
+	}
+
+	return gobgpoc.Neighbor{
+		Config: gobgpoc.NeighborConfig{
+			PeerAs:          peerAs,
+			NeighborAddress: neighAddr,
+		},
+		// This is needed because GoBGP's configuration diffing
+		// logic may check the state value instead of the
+		// config value.
+		State: gobgpoc.NeighborState{
+			PeerAs:          peerAs,
+			NeighborAddress: neighAddr,
+		},
+		Timers: gobgpoc.Timers{
+			Config: gobgpoc.TimersConfig{
+				ConnectRetry:      float64(inherit(timers.ConnectRetry, pgTimers.ConnectRetry)),
+				HoldTime:          float64(inherit(timers.HoldTime, pgTimers.HoldTime)),
+				KeepaliveInterval: float64(inherit(timers.KeepaliveInterval, pgTimers.KeepaliveInterval)),
+			},
+		},
+		Transport: gobgpoc.Transport{
+			Config: gobgpoc.TransportConfig{
+				LocalAddress: inherit(transport.LocalAddress, pgTransport.LocalAddress),
+				RemotePort:   n.GetNeighborPort(),
+				PassiveMode:  inherit(transport.PassiveMode, pgTransport.PassiveMode),
+			},
+		},
+		GracefulRestart: convertGracefulRestart(
+			inherit(gr.Enabled, pgGR.Enabled),
+			inherit(gr.RestartTime, pgGR.RestartTime),
+			inherit(gr.StaleRoutesTime, pgGR.StaleRoutesTime),
+			inherit(gr.HelperOnly, pgGR.HelperOnly),
+		),
+		AfiSafis: afiSafis,
+	}
+}
+
+// convertPeerGroup converts an OC peer-group to GoBGP config.
+//
+// GoBGP only uses it for dynamic neighbours, since peer-group membership of
+// configured neighbours is resolved by convertNeighbor.
+func convertPeerGroup(pgName string, pg *oc.NetworkInstance_Protocol_Bgp_PeerGroup) gobgpoc.PeerGroup {
+	p := *pg
+	timers, transport, gr := p.GetOrCreateTimers(), p.GetOrCreateTransport(), p.GetOrCreateGracefulRestart()
+	return gobgpoc.PeerGroup{
+		Config: gobgpoc.PeerGroupConfig{
+			PeerAs:        p.GetPeerAs(),
+			PeerGroupName: pgName,
+		},
+		State: gobgpoc.PeerGroupState{
+			PeerAs:        p.GetPeerAs(),
+			PeerGroupName: pgName,
+		},
+		Timers: gobgpoc.Timers{
+			Config: gobgpoc.TimersConfig{
+				ConnectRetry:      float64(inherit(timers.ConnectRetry, nil)),
+				HoldTime:          float64(inherit(timers.HoldTime, nil)),
+				KeepaliveInterval: float64(inherit(timers.KeepaliveInterval, nil)),
+			},
+		},
+		Transport: gobgpoc.Transport{
+			Config: gobgpoc.TransportConfig{
+				LocalAddress: transport.GetLocalAddress(),
+				PassiveMode:  transport.GetPassiveMode(),
+			},
+		},
+		GracefulRestart: convertGracefulRestart(gr.GetEnabled(), gr.GetRestartTime(), gr.GetStaleRoutesTime(), gr.GetHelperOnly()),
+		AfiSafis:        convertAfiSafis(p.AfiSafi),
+	}
+}
+
+// convertGracefulRestart converts the graceful restart config of a neighbour
+// or peer-group to GoBGP config.
+//
+// NOTIFICATIONs other than a Hard Reset don't end a graceful restart
+// (RFC 8538), so that a session torn down by the restarting speaker still
+// keeps its routes.
+func convertGracefulRestart(enabled bool, restartTime, staleRoutesTime uint16, helperOnly bool) gobgpoc.GracefulRestart {
+	if !enabled {
+		return gobgpoc.GracefulRestart{}
+	}
+	return gobgpoc.GracefulRestart{
+		Config: gobgpoc.GracefulRestartConfig{
+			Enabled:             true,
+			RestartTime:         restartTime,
+			StaleRoutesTime:     float64(staleRoutesTime),
+			HelperOnly:          helperOnly,
+			NotificationEnabled: true,
+		},
+	}
+}
+
+// enableLLGR enables long-lived graceful restart (RFC 9494) with the given
+// restart time on the AFI-SAFIs that have graceful restart enabled.
+//
+// OpenConfig doesn't model LLGR, so the restart time is the same for all
+// neighbours.
+func enableLLGR(gr *gobgpoc.GracefulRestart, afiSafis []gobgpoc.AfiSafi, restartTime time.Duration) {
+	if !gr.Config.Enabled {
+		return
+	}
+	for i := range afiSafis {
+		if !afiSafis[i].MpGracefulRestart.Config.Enabled {
+			continue
+		}
+		gr.Config.LongLivedEnabled = true
+		afiSafis[i].LongLivedGracefulRestart.Config = gobgpoc.LongLivedGracefulRestartConfig{
+			Enabled:     true,
+			RestartTime: uint32(restartTime / time.Second),
+		}
+	}
+}
+
+// inherit returns the value of the neighbour's leaf if it is set, otherwise
+// the value of the peer-group's leaf, otherwise the zero value.
+func inherit[T any](neighLeaf, pgLeaf *T) T {
+	switch {
+	case neighLeaf != nil:
+		return *neighLeaf
+	case pgLeaf != nil:
+		return *pgLeaf
+	default:
+		var zero T
+		return zero
+	}
+}
+
 // intendedToGoBGPPolicies populates bgpConfig's policies from the OC configuration.
 func intendedToGoBGPPolicies(bgpoc *oc.NetworkInstance_Protocol_Bgp, policyoc *oc.RoutingPolicy, bgpConfig *gobgpoc.BgpConfigSet) {
 	var communitySetIndexMap map[string]int
@@ -122,7 +292,8 @@ func intendedToGoBGPPolicies(bgpoc *oc.NetworkInstance_Protocol_Bgp, policyoc *o
 			NeighborInfoList: []string{neighAddr},
 		})
 
-		applyPolicy := convertNeighborApplyPolicy(bgpoc.Neighbor[neighAddr])
+		neigh := bgpoc.Neighbor[neighAddr]
+		applyPolicy := convertNeighborApplyPolicy(neigh, bgpoc.PeerGroup[neigh.GetPeerGroup()])
 
 		// populatePolicies populates the global policy definitions and the ApplyPolicy
 		// list, and returns the list of converted policies' names.
@@ -142,7 +313,7 @@ func intendedToGoBGPPolicies(bgpoc *oc.NetworkInstance_Protocol_Bgp, policyoc *o
 					log.Errorf("Neighbour policy doesn't exist in policy definitions: %q", policyName)
 					continue
 				}
-				convertedPolicy := convertPolicyDefinition(policy, neighAddr, policyoc.GetOrCreateDefinedSets().GetOrCreateBgpDefinedSets().CommunitySet, bgpConfig.DefinedSets.BgpDefinedSets.CommunitySets, communitySetIndexMap)
+				convertedPolicy := convertPolicyDefinition(policy, neighAddr, policyoc.GetOrCreateDefinedSets().PrefixSet, policyoc.GetOrCreateDefinedSets().GetOrCreateBgpDefinedSets().CommunitySet, bgpConfig.DefinedSets.BgpDefinedSets.CommunitySets, communitySetIndexMap)
 				bgpConfig.PolicyDefinitions = append(bgpConfig.PolicyDefinitions, convertedPolicy)
 				applyPolicyList = append(applyPolicyList, convertedPolicyName)
 			}
@@ -16,10 +16,12 @@ package bgp
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/lemming/gnmi/fakedevice"
 	"github.com/openconfig/lemming/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
 	gobgpoc "github.com/osrg/gobgp/v3/pkg/config/oc"
 )
 
@@ -656,3 +658,504 @@ func TestIntendedToGoBGPPolicies(t *testing.T) {
 		})
 	}
 }
+
+func TestIntendedToGoBGPNeighbors(t *testing.T) {
+	tests := []struct {
+		desc                 string
+		inBGP                func() *oc.NetworkInstance_Protocol_Bgp
+		inLLGRRestartTime    time.Duration
+		wantNeighbors        []gobgpoc.Neighbor
+		wantPeerGroups       []gobgpoc.PeerGroup
+		wantDynamicNeighbors []gobgpoc.DynamicNeighbor
+	}{{
+		desc: "passive neighbor",
+		inBGP: func() *oc.NetworkInstance_Protocol_Bgp {
+			bgpoc := &oc.NetworkInstance_Protocol_Bgp{}
+			neigh := bgpoc.GetOrCreateNeighbor("192.0.2.1")
+			neigh.PeerAs = ygot.Uint32(64500)
+			neigh.GetOrCreateTransport().PassiveMode = ygot.Bool(true)
+			return bgpoc
+		},
+		wantNeighbors: []gobgpoc.Neighbor{{
+			Config: gobgpoc.NeighborConfig{
+				PeerAs:          64500,
+				NeighborAddress: "192.0.2.1",
+			},
+			State: gobgpoc.NeighborState{
+				PeerAs:          64500,
+				NeighborAddress: "192.0.2.1",
+			},
+			Transport: gobgpoc.Transport{
+				Config: gobgpoc.TransportConfig{
+					RemotePort:  179,
+					PassiveMode: true,
+				},
+			},
+		}},
+	}, {
+		desc: "dynamic neighbors",
+		inBGP: func() *oc.NetworkInstance_Protocol_Bgp {
+			bgpoc := &oc.NetworkInstance_Protocol_Bgp{}
+			bgpoc.GetOrCreatePeerGroup("clients").PeerAs = ygot.Uint32(64501)
+			bgpoc.GetOrCreatePeerGroup("clients").GetOrCreateTransport().PassiveMode = ygot.Bool(true)
+			bgpoc.GetOrCreateGlobal().GetOrCreateDynamicNeighborPrefix("198.51.100.0/24").PeerGroup = ygot.String("clients")
+			bgpoc.GetOrCreateGlobal().GetOrCreateDynamicNeighborPrefix("2001:db8::/64").PeerGroup = ygot.String("clients")
+			return bgpoc
+		},
+		wantPeerGroups: []gobgpoc.PeerGroup{{
+			Config: gobgpoc.PeerGroupConfig{
+				PeerAs:        64501,
+				PeerGroupName: "clients",
+			},
+			State: gobgpoc.PeerGroupState{
+				PeerAs:        64501,
+				PeerGroupName: "clients",
+			},
+			Transport: gobgpoc.Transport{
+				Config: gobgpoc.TransportConfig{
+					PassiveMode: true,
+				},
+			},
+		}},
+		wantDynamicNeighbors: []gobgpoc.DynamicNeighbor{{
+			Config: gobgpoc.DynamicNeighborConfig{
+				Prefix:    "198.51.100.0/24",
+				PeerGroup: "clients",
+			},
+		}, {
+			Config: gobgpoc.DynamicNeighborConfig{
+				Prefix:    "2001:db8::/64",
+				PeerGroup: "clients",
+			},
+		}},
+	}, {
+		desc: "neighbors inherit from peer group",
+		inBGP: func() *oc.NetworkInstance_Protocol_Bgp {
+			bgpoc := &oc.NetworkInstance_Protocol_Bgp{}
+			pg := bgpoc.GetOrCreatePeerGroup("group")
+			pg.PeerAs = ygot.Uint32(64500)
+			pg.GetOrCreateTimers().HoldTime = ygot.Uint16(9)
+			pg.GetOrCreateTimers().KeepaliveInterval = ygot.Uint16(3)
+			pg.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST).Enabled = ygot.Bool(true)
+			neigh := bgpoc.GetOrCreateNeighbor("192.0.2.1")
+			neigh.PeerGroup = ygot.String("group")
+			neigh.GetOrCreateTimers().HoldTime = ygot.Uint16(30)
+			neigh.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Enabled = ygot.Bool(true)
+			return bgpoc
+		},
+		wantNeighbors: []gobgpoc.Neighbor{{
+			Config: gobgpoc.NeighborConfig{
+				PeerAs:          64500,
+				NeighborAddress: "192.0.2.1",
+			},
+			State: gobgpoc.NeighborState{
+				PeerAs:          64500,
+				NeighborAddress: "192.0.2.1",
+			},
+			Timers: gobgpoc.Timers{
+				Config: gobgpoc.TimersConfig{
+					HoldTime:          30,
+					KeepaliveInterval: 3,
+				},
+			},
+			Transport: gobgpoc.Transport{
+				Config: gobgpoc.TransportConfig{
+					RemotePort: 179,
+				},
+			},
+			AfiSafis: []gobgpoc.AfiSafi{{
+				Config: gobgpoc.AfiSafiConfig{AfiSafiName: gobgpoc.AFI_SAFI_TYPE_IPV4_UNICAST, Enabled: true},
+				State:  gobgpoc.AfiSafiState{AfiSafiName: gobgpoc.AFI_SAFI_TYPE_IPV4_UNICAST, Enabled: true},
+			}},
+		}},
+		wantPeerGroups: []gobgpoc.PeerGroup{{
+			Config: gobgpoc.PeerGroupConfig{
+				PeerAs:        64500,
+				PeerGroupName: "group",
+			},
+			State: gobgpoc.PeerGroupState{
+				PeerAs:        64500,
+				PeerGroupName: "group",
+			},
+			Timers: gobgpoc.Timers{
+				Config: gobgpoc.TimersConfig{
+					HoldTime:          9,
+					KeepaliveInterval: 3,
+				},
+			},
+			AfiSafis: []gobgpoc.AfiSafi{{
+				Config: gobgpoc.AfiSafiConfig{AfiSafiName: gobgpoc.AFI_SAFI_TYPE_IPV6_UNICAST, Enabled: true},
+				State:  gobgpoc.AfiSafiState{AfiSafiName: gobgpoc.AFI_SAFI_TYPE_IPV6_UNICAST, Enabled: true},
+			}},
+		}},
+	}, {
+		desc: "graceful restart per afi-safi",
+		inBGP: func() *oc.NetworkInstance_Protocol_Bgp {
+			bgpoc := &oc.NetworkInstance_Protocol_Bgp{}
+			pg := bgpoc.GetOrCreatePeerGroup("group")
+			pg.GetOrCreateGracefulRestart().Enabled = ygot.Bool(true)
+			pg.GetOrCreateGracefulRestart().RestartTime = ygot.Uint16(60)
+			neigh := bgpoc.GetOrCreateNeighbor("192.0.2.1")
+			neigh.PeerAs = ygot.Uint32(64500)
+			neigh.PeerGroup = ygot.String("group")
+			neigh.GetOrCreateGracefulRestart().StaleRoutesTime = ygot.Uint16(300)
+			v4 := neigh.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST)
+			v4.Enabled = ygot.Bool(true)
+			v4.GetOrCreateGracefulRestart().Enabled = ygot.Bool(true)
+			neigh.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST).Enabled = ygot.Bool(true)
+			return bgpoc
+		},
+		wantNeighbors: []gobgpoc.Neighbor{{
+			Config: gobgpoc.NeighborConfig{
+				PeerAs:          64500,
+				NeighborAddress: "192.0.2.1",
+			},
+			State: gobgpoc.NeighborState{
+				PeerAs:          64500,
+				NeighborAddress: "192.0.2.1",
+			},
+			Transport: gobgpoc.Transport{
+				Config: gobgpoc.TransportConfig{
+					RemotePort: 179,
+				},
+			},
+			GracefulRestart: gobgpoc.GracefulRestart{
+				Config: gobgpoc.GracefulRestartConfig{
+					Enabled:             true,
+					RestartTime:         60,
+					StaleRoutesTime:     300,
+					NotificationEnabled: true,
+				},
+			},
+			AfiSafis: []gobgpoc.AfiSafi{{
+				Config:            gobgpoc.AfiSafiConfig{AfiSafiName: gobgpoc.AFI_SAFI_TYPE_IPV4_UNICAST, Enabled: true},
+				State:             gobgpoc.AfiSafiState{AfiSafiName: gobgpoc.AFI_SAFI_TYPE_IPV4_UNICAST, Enabled: true},
+				MpGracefulRestart: gobgpoc.MpGracefulRestart{Config: gobgpoc.MpGracefulRestartConfig{Enabled: true}},
+			}, {
+				Config: gobgpoc.AfiSafiConfig{AfiSafiName: gobgpoc.AFI_SAFI_TYPE_IPV6_UNICAST, Enabled: true},
+				State:  gobgpoc.AfiSafiState{AfiSafiName: gobgpoc.AFI_SAFI_TYPE_IPV6_UNICAST, Enabled: true},
+			}},
+		}},
+		wantPeerGroups: []gobgpoc.PeerGroup{{
+			Config: gobgpoc.PeerGroupConfig{
+				PeerGroupName: "group",
+			},
+			State: gobgpoc.PeerGroupState{
+				PeerGroupName: "group",
+			},
+			GracefulRestart: gobgpoc.GracefulRestart{
+				Config: gobgpoc.GracefulRestartConfig{
+					Enabled:             true,
+					RestartTime:         60,
+					NotificationEnabled: true,
+				},
+			},
+		}},
+	}, {
+		desc: "long-lived graceful restart",
+		inBGP: func() *oc.NetworkInstance_Protocol_Bgp {
+			bgpoc := &oc.NetworkInstance_Protocol_Bgp{}
+			neigh := bgpoc.GetOrCreateNeighbor("192.0.2.1")
+			neigh.PeerAs = ygot.Uint32(64500)
+			neigh.GetOrCreateGracefulRestart().Enabled = ygot.Bool(true)
+			v4 := neigh.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST)
+			v4.Enabled = ygot.Bool(true)
+			v4.GetOrCreateGracefulRestart().Enabled = ygot.Bool(true)
+			neigh.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST).Enabled = ygot.Bool(true)
+			// Graceful restart isn't enabled for the peer group itself.
+			pgv4 := bgpoc.GetOrCreatePeerGroup("group").GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST)
+			pgv4.Enabled = ygot.Bool(true)
+			pgv4.GetOrCreateGracefulRestart().Enabled = ygot.Bool(true)
+			return bgpoc
+		},
+		inLLGRRestartTime: time.Hour,
+		wantNeighbors: []gobgpoc.Neighbor{{
+			Config: gobgpoc.NeighborConfig{
+				PeerAs:          64500,
+				NeighborAddress: "192.0.2.1",
+			},
+			State: gobgpoc.NeighborState{
+				PeerAs:          64500,
+				NeighborAddress: "192.0.2.1",
+			},
+			Transport: gobgpoc.Transport{
+				Config: gobgpoc.TransportConfig{
+					RemotePort: 179,
+				},
+			},
+			GracefulRestart: gobgpoc.GracefulRestart{
+				Config: gobgpoc.GracefulRestartConfig{
+					Enabled:             true,
+					NotificationEnabled: true,
+					LongLivedEnabled:    true,
+				},
+			},
+			AfiSafis: []gobgpoc.AfiSafi{{
+				Config:                   gobgpoc.AfiSafiConfig{AfiSafiName: gobgpoc.AFI_SAFI_TYPE_IPV4_UNICAST, Enabled: true},
+				State:                    gobgpoc.AfiSafiState{AfiSafiName: gobgpoc.AFI_SAFI_TYPE_IPV4_UNICAST, Enabled: true},
+				MpGracefulRestart:        gobgpoc.MpGracefulRestart{Config: gobgpoc.MpGracefulRestartConfig{Enabled: true}},
+				LongLivedGracefulRestart: gobgpoc.LongLivedGracefulRestart{Config: gobgpoc.LongLivedGracefulRestartConfig{Enabled: true, RestartTime: 3600}},
+			}, {
+				Config: gobgpoc.AfiSafiConfig{AfiSafiName: gobgpoc.AFI_SAFI_TYPE_IPV6_UNICAST, Enabled: true},
+				State:  gobgpoc.AfiSafiState{AfiSafiName: gobgpoc.AFI_SAFI_TYPE_IPV6_UNICAST, Enabled: true},
+			}},
+		}},
+		wantPeerGroups: []gobgpoc.PeerGroup{{
+			Config: gobgpoc.PeerGroupConfig{
+				PeerGroupName: "group",
+			},
+			State: gobgpoc.PeerGroupState{
+				PeerGroupName: "group",
+			},
+			AfiSafis: []gobgpoc.AfiSafi{{
+				Config:            gobgpoc.AfiSafiConfig{AfiSafiName: gobgpoc.AFI_SAFI_TYPE_IPV4_UNICAST, Enabled: true},
+				State:             gobgpoc.AfiSafiState{AfiSafiName: gobgpoc.AFI_SAFI_TYPE_IPV4_UNICAST, Enabled: true},
+				MpGracefulRestart: gobgpoc.MpGracefulRestart{Config: gobgpoc.MpGracefulRestartConfig{Enabled: true}},
+			}},
+		}},
+	}, {
+		desc: "dynamic neighbors with unknown peer group",
+		inBGP: func() *oc.NetworkInstance_Protocol_Bgp {
+			bgpoc := &oc.NetworkInstance_Protocol_Bgp{}
+			bgpoc.GetOrCreateGlobal().GetOrCreateDynamicNeighborPrefix("198.51.100.0/24").PeerGroup = ygot.String("clients")
+			return bgpoc
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := intendedToGoBGP(tt.inBGP(), &oc.RoutingPolicy{}, "", 0, tt.inLLGRRestartTime)
+			if diff := cmp.Diff(tt.wantNeighbors, got.Neighbors); diff != "" {
+				t.Errorf("neighbors (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantPeerGroups, got.PeerGroups); diff != "" {
+				t.Errorf("peer groups (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantDynamicNeighbors, got.DynamicNeighbors); diff != "" {
+				t.Errorf("dynamic neighbors (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPeerGroupApplyPolicy(t *testing.T) {
+	root := &oc.Root{}
+	bgpoc := root.GetOrCreateNetworkInstance(fakedevice.DefaultNetworkInstance).GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, fakedevice.BGPRoutingProtocol).GetOrCreateBgp()
+	policyoc := root.GetOrCreateRoutingPolicy()
+
+	for _, policyName := range []string{"group-import", "override-import"} {
+		stmt, err := policyoc.GetOrCreatePolicyDefinition(policyName).AppendNewStatement("accept")
+		if err != nil {
+			t.Fatal(err)
+		}
+		stmt.GetOrCreateActions().SetPolicyResult(oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE)
+	}
+
+	pg := bgpoc.GetOrCreatePeerGroup("group")
+	pg.GetOrCreateApplyPolicy().SetImportPolicy([]string{"group-import"})
+	pg.GetOrCreateApplyPolicy().SetDefaultImportPolicy(oc.RoutingPolicy_DefaultPolicyType_REJECT_ROUTE)
+	pg.GetOrCreateApplyPolicy().SetDefaultExportPolicy(oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+
+	bgpoc.GetOrCreateNeighbor("192.0.2.1").SetPeerGroup("group")
+	overridden := bgpoc.GetOrCreateNeighbor("192.0.2.2")
+	overridden.SetPeerGroup("group")
+	overridden.GetOrCreateApplyPolicy().SetImportPolicy([]string{"override-import"})
+
+	got := &gobgpoc.BgpConfigSet{}
+	intendedToGoBGPPolicies(bgpoc, policyoc, got)
+
+	want := gobgpoc.ApplyPolicyConfig{
+		ImportPolicyList: []string{
+			"192.0.2.1|group-import",
+			"default-import|192.0.2.1",
+			"192.0.2.2|override-import",
+			"default-import|192.0.2.2",
+		},
+		ExportPolicyList: []string{
+			"default-export|192.0.2.1",
+			"default-export|192.0.2.2",
+		},
+	}
+	if diff := cmp.Diff(want, got.Global.ApplyPolicy.Config); diff != "" {
+		t.Errorf("global apply-policy (-want, +got):\n%s", diff)
+	}
+
+	wantDisposition := map[string]gobgpoc.RouteDisposition{
+		"default-import|192.0.2.1": gobgpoc.ROUTE_DISPOSITION_REJECT_ROUTE,
+		"default-export|192.0.2.1": gobgpoc.ROUTE_DISPOSITION_ACCEPT_ROUTE,
+		"default-import|192.0.2.2": gobgpoc.ROUTE_DISPOSITION_REJECT_ROUTE,
+		"default-export|192.0.2.2": gobgpoc.ROUTE_DISPOSITION_ACCEPT_ROUTE,
+	}
+	gotDisposition := map[string]gobgpoc.RouteDisposition{}
+	for _, policy := range got.PolicyDefinitions {
+		if _, ok := wantDisposition[policy.Name]; ok {
+			gotDisposition[policy.Name] = policy.Statements[0].Actions.RouteDisposition
+		}
+	}
+	if diff := cmp.Diff(wantDisposition, gotDisposition); diff != "" {
+		t.Errorf("default policies (-want, +got):\n%s", diff)
+	}
+}
+
+func TestDefaultPolicies(t *testing.T) {
+	pg := &oc.NetworkInstance_Protocol_Bgp_PeerGroup{}
+	pg.GetOrCreateApplyPolicy().SetDefaultExportPolicy(oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	overridden := &oc.NetworkInstance_Protocol_Bgp_Neighbor{}
+	overridden.GetOrCreateApplyPolicy().SetDefaultImportPolicy(oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	overridden.GetOrCreateApplyPolicy().SetDefaultExportPolicy(oc.RoutingPolicy_DefaultPolicyType_REJECT_ROUTE)
+
+	tests := []struct {
+		desc       string
+		inNeigh    *oc.NetworkInstance_Protocol_Bgp_Neighbor
+		inPG       *oc.NetworkInstance_Protocol_Bgp_PeerGroup
+		wantImport oc.E_RoutingPolicy_DefaultPolicyType
+		wantExport oc.E_RoutingPolicy_DefaultPolicyType
+	}{{
+		desc:       "unset",
+		inNeigh:    &oc.NetworkInstance_Protocol_Bgp_Neighbor{},
+		wantImport: oc.RoutingPolicy_DefaultPolicyType_REJECT_ROUTE,
+		wantExport: oc.RoutingPolicy_DefaultPolicyType_REJECT_ROUTE,
+	}, {
+		desc:       "inherited",
+		inNeigh:    &oc.NetworkInstance_Protocol_Bgp_Neighbor{},
+		inPG:       pg,
+		wantImport: oc.RoutingPolicy_DefaultPolicyType_REJECT_ROUTE,
+		wantExport: oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE,
+	}, {
+		desc:       "overridden",
+		inNeigh:    overridden,
+		inPG:       pg,
+		wantImport: oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE,
+		wantExport: oc.RoutingPolicy_DefaultPolicyType_REJECT_ROUTE,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			gotImport, gotExport := defaultPolicies(tt.inNeigh, tt.inPG)
+			if gotImport != tt.wantImport || gotExport != tt.wantExport {
+				t.Errorf("defaultPolicies() got (%v, %v), want (%v, %v)", gotImport, gotExport, tt.wantImport, tt.wantExport)
+			}
+		})
+	}
+}
+
+func TestConvertRouteType(t *testing.T) {
+	tests := []struct {
+		desc              string
+		inRouteType       oc.E_BgpConditions_RouteType
+		inInstallProtocol oc.E_PolicyTypes_INSTALL_PROTOCOL_TYPE
+		want              gobgpoc.RouteType
+	}{{
+		desc: "unset",
+		want: "",
+	}, {
+		desc:        "internal",
+		inRouteType: oc.BgpConditions_RouteType_INTERNAL,
+		want:        gobgpoc.ROUTE_TYPE_INTERNAL,
+	}, {
+		desc:        "external",
+		inRouteType: oc.BgpConditions_RouteType_EXTERNAL,
+		want:        gobgpoc.ROUTE_TYPE_EXTERNAL,
+	}, {
+		desc:              "local",
+		inInstallProtocol: oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC,
+		want:              gobgpoc.ROUTE_TYPE_LOCAL,
+	}, {
+		desc:              "bgp-unsupported",
+		inInstallProtocol: oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP,
+		want:              "",
+	}, {
+		desc:              "route-type-takes-precedence",
+		inRouteType:       oc.BgpConditions_RouteType_EXTERNAL,
+		inInstallProtocol: oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC,
+		want:              gobgpoc.ROUTE_TYPE_EXTERNAL,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			conds := &oc.RoutingPolicy_PolicyDefinition_Statement_Conditions{InstallProtocolEq: tt.inInstallProtocol}
+			if tt.inRouteType != oc.BgpConditions_RouteType_UNSET {
+				conds.GetOrCreateBgpConditions().SetRouteType(tt.inRouteType)
+			}
+			if got := convertRouteType(conds); got != tt.want {
+				t.Errorf("convertRouteType() got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertMixedPrefixSet(t *testing.T) {
+	prefixSets := map[string]*oc.RoutingPolicy_DefinedSets_PrefixSet{}
+	newPrefixSet := func(name string, prefixes ...string) {
+		ps := &oc.RoutingPolicy_DefinedSets_PrefixSet{Name: ygot.String(name), Mode: oc.PrefixSet_Mode_MIXED}
+		for _, p := range prefixes {
+			ps.GetOrCreatePrefix(p, "exact")
+		}
+		prefixSets[name] = ps
+	}
+	newPrefixSet("both", "10.1.0.0/16", "2001:db8::/32")
+	newPrefixSet("v6-only", "2001:db8::/32")
+
+	wantPrefixSets := []gobgpoc.PrefixSet{{
+		PrefixSetName: "both:ipv4-unicast",
+		PrefixList:    []gobgpoc.Prefix{{IpPrefix: "10.1.0.0/16"}},
+	}, {
+		PrefixSetName: "both:ipv6-unicast",
+		PrefixList:    []gobgpoc.Prefix{{IpPrefix: "2001:db8::/32"}},
+	}, {
+		PrefixSetName: "v6-only:ipv6-unicast",
+		PrefixList:    []gobgpoc.Prefix{{IpPrefix: "2001:db8::/32"}},
+	}}
+	if diff := cmp.Diff(wantPrefixSets, convertPrefixSets(prefixSets)); diff != "" {
+		t.Errorf("convertPrefixSets() (-want, +got):\n%s", diff)
+	}
+
+	matchStatement := func(name, prefixSet string, opt gobgpoc.MatchSetOptionsRestrictedType, afiSafis ...gobgpoc.AfiSafiType) gobgpoc.Statement {
+		s := gobgpoc.Statement{Name: name}
+		s.Conditions.MatchPrefixSet = gobgpoc.MatchPrefixSet{PrefixSet: prefixSet, MatchSetOptions: opt}
+		s.Conditions.BgpConditions.AfiSafiInList = afiSafis
+		return s
+	}
+	tests := []struct {
+		desc      string
+		inSet     string
+		inOptions gobgpoc.MatchSetOptionsRestrictedType
+		want      []gobgpoc.Statement
+	}{{
+		desc:      "both-families",
+		inSet:     "both",
+		inOptions: gobgpoc.MATCH_SET_OPTIONS_RESTRICTED_TYPE_ANY,
+		want: []gobgpoc.Statement{
+			matchStatement("s:ipv4-unicast", "both:ipv4-unicast", gobgpoc.MATCH_SET_OPTIONS_RESTRICTED_TYPE_ANY, gobgpoc.AFI_SAFI_TYPE_IPV4_UNICAST),
+			matchStatement("s:ipv6-unicast", "both:ipv6-unicast", gobgpoc.MATCH_SET_OPTIONS_RESTRICTED_TYPE_ANY, gobgpoc.AFI_SAFI_TYPE_IPV6_UNICAST),
+		},
+	}, {
+		desc:      "missing-family",
+		inSet:     "v6-only",
+		inOptions: gobgpoc.MATCH_SET_OPTIONS_RESTRICTED_TYPE_ANY,
+		want: []gobgpoc.Statement{
+			matchStatement("s:ipv6-unicast", "v6-only:ipv6-unicast", gobgpoc.MATCH_SET_OPTIONS_RESTRICTED_TYPE_ANY, gobgpoc.AFI_SAFI_TYPE_IPV6_UNICAST),
+		},
+	}, {
+		desc:      "missing-family-invert",
+		inSet:     "v6-only",
+		inOptions: gobgpoc.MATCH_SET_OPTIONS_RESTRICTED_TYPE_INVERT,
+		want: []gobgpoc.Statement{
+			matchStatement("s:ipv4-unicast", "", "", gobgpoc.AFI_SAFI_TYPE_IPV4_UNICAST),
+			matchStatement("s:ipv6-unicast", "v6-only:ipv6-unicast", gobgpoc.MATCH_SET_OPTIONS_RESTRICTED_TYPE_INVERT, gobgpoc.AFI_SAFI_TYPE_IPV6_UNICAST),
+		},
+	}, {
+		desc:      "not-mixed",
+		inSet:     "unknown",
+		inOptions: gobgpoc.MATCH_SET_OPTIONS_RESTRICTED_TYPE_ANY,
+		want: []gobgpoc.Statement{
+			matchStatement("s", "unknown", gobgpoc.MATCH_SET_OPTIONS_RESTRICTED_TYPE_ANY),
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := splitMixedPrefixSetStatement(matchStatement("s", tt.inSet, tt.inOptions), prefixSets[tt.inSet])
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("splitMixedPrefixSetStatement() (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
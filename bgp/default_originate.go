@@ -0,0 +1,184 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/netip"
+	"slices"
+
+	log "github.com/golang/glog"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/openconfig/lemming/gnmi/oc"
+
+	api "github.com/osrg/gobgp/v3/api"
+	gobgpoc "github.com/osrg/gobgp/v3/pkg/config/oc"
+)
+
+// defaultOriginateExportPolicy is the name of the export policy advertising
+// the originated default route only to the neighbours it is originated to,
+// and of the neighbour set of those neighbours.
+const defaultOriginateExportPolicy = "default-originate-export"
+
+var (
+	// defaultRoute is the IPv4 default route.
+	defaultRoute = netip.MustParsePrefix("0.0.0.0/0")
+	// defaultOriginateMarker is the large community marking the originated
+	// default route. The reserved last ASN is used as the global
+	// administrator like for the conditional advertisement markers, with
+	// another first local data part.
+	defaultOriginateMarker = fmt.Sprintf("%d:1:0", uint32(math.MaxUint32))
+)
+
+// defaultOriginateNeighbors returns the sorted addresses of the neighbours
+// the default route is originated to: those with send-default-route enabled
+// for IPv4 unicast, unless a suppressed conditional advertisement to the
+// neighbour includes the default route.
+func defaultOriginateNeighbors(bgpoc *oc.NetworkInstance_Protocol_Bgp, conditions *conditionTracker) []string {
+	var neighbors []string
+	for addr, neigh := range bgpoc.Neighbor {
+		if !neigh.GetAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).GetIpv4Unicast().GetSendDefaultRoute() {
+			continue
+		}
+		if conditions != nil && conditions.suppresses(addr, defaultRoute) {
+			continue
+		}
+		neighbors = append(neighbors, addr)
+	}
+	slices.Sort(neighbors)
+	return neighbors
+}
+
+// applyDefaultOriginate adds the policy to bgpConfig that only advertises
+// the originated default route to the given neighbours. The route is
+// accepted before any of their export policies are evaluated, like a
+// default route originated by a route map in other implementations.
+func applyDefaultOriginate(bgpConfig *gobgpoc.BgpConfigSet, neighbors []string) {
+	bgpConfig.DefinedSets.BgpDefinedSets.LargeCommunitySets = append(bgpConfig.DefinedSets.BgpDefinedSets.LargeCommunitySets, gobgpoc.LargeCommunitySet{
+		LargeCommunitySetName: defaultOriginateExportPolicy,
+		LargeCommunityList:    []string{defaultOriginateMarker},
+	})
+	isOriginated := gobgpoc.BgpConditions{
+		MatchLargeCommunitySet: gobgpoc.MatchLargeCommunitySet{
+			LargeCommunitySet: defaultOriginateExportPolicy,
+		},
+	}
+	policy := gobgpoc.PolicyDefinition{Name: defaultOriginateExportPolicy}
+	if len(neighbors) > 0 {
+		bgpConfig.DefinedSets.NeighborSets = append(bgpConfig.DefinedSets.NeighborSets, gobgpoc.NeighborSet{
+			NeighborSetName:  defaultOriginateExportPolicy,
+			NeighborInfoList: neighbors,
+		})
+		policy.Statements = append(policy.Statements, gobgpoc.Statement{
+			Name: defaultOriginateExportPolicy + "|accept",
+			Conditions: gobgpoc.Conditions{
+				MatchNeighborSet: gobgpoc.MatchNeighborSet{
+					NeighborSet: defaultOriginateExportPolicy,
+				},
+				BgpConditions: isOriginated,
+			},
+			Actions: gobgpoc.Actions{
+				RouteDisposition: gobgpoc.ROUTE_DISPOSITION_ACCEPT_ROUTE,
+				BgpActions: gobgpoc.BgpActions{
+					SetLargeCommunity: gobgpoc.SetLargeCommunity{
+						SetLargeCommunityMethod: gobgpoc.SetLargeCommunityMethod{
+							CommunitiesList: []string{defaultOriginateMarker},
+						},
+						Options: gobgpoc.BGP_SET_COMMUNITY_OPTION_TYPE_REMOVE,
+					},
+				},
+			},
+		})
+	}
+	policy.Statements = append(policy.Statements, gobgpoc.Statement{
+		Name: defaultOriginateExportPolicy,
+		Conditions: gobgpoc.Conditions{
+			BgpConditions: isOriginated,
+		},
+		Actions: gobgpoc.Actions{
+			RouteDisposition: gobgpoc.ROUTE_DISPOSITION_REJECT_ROUTE,
+		},
+	})
+	bgpConfig.PolicyDefinitions = append(bgpConfig.PolicyDefinitions, policy)
+	bgpConfig.Global.ApplyPolicy.Config.ExportPolicyList = append([]string{defaultOriginateExportPolicy}, bgpConfig.Global.ApplyPolicy.Config.ExportPolicyList...)
+}
+
+// defaultRoutePath returns the originated default route, marked so that it
+// is only advertised to the neighbours it is originated to. GoBGP replaces the
+// unspecified next hop with the local address of the session.
+func defaultRoutePath() (*api.Path, error) {
+	nlri, err := anypb.New(&api.IPAddressPrefix{Prefix: defaultRoute.Addr().String(), PrefixLen: uint32(defaultRoute.Bits())})
+	if err != nil {
+		return nil, err
+	}
+	origin, err := anypb.New(&api.OriginAttribute{Origin: 0})
+	if err != nil {
+		return nil, err
+	}
+	nexthop, err := anypb.New(&api.NextHopAttribute{NextHop: "0.0.0.0"})
+	if err != nil {
+		return nil, err
+	}
+	marker, err := anypb.New(&api.LargeCommunitiesAttribute{Communities: []*api.LargeCommunity{{GlobalAdmin: math.MaxUint32, LocalData1: 1}}})
+	if err != nil {
+		return nil, err
+	}
+	return &api.Path{
+		Family: &api.Family{Afi: api.Family_AFI_IP, Safi: api.Family_SAFI_UNICAST},
+		Nlri:   nlri,
+		Pattrs: []*anypb.Any{origin, nexthop, marker},
+	}, nil
+}
+
+// withdrawDefaultRoute stops originating the default route if the neighbours
+// it is originated to change. Since GoBGP doesn't withdraw routes rejected by
+// a changed export policy, the route is originated again once the policy
+// is updated.
+func (t *bgpTask) withdrawDefaultRoute(ctx context.Context, neighbors []string) error {
+	if t.defaultRouteUUID == nil || slices.Equal(neighbors, t.defaultOriginated) {
+		return nil
+	}
+	if err := t.bgpServer.DeletePath(ctx, &api.DeletePathRequest{
+		TableType: api.TableType_GLOBAL,
+		Family:    &api.Family{Afi: api.Family_AFI_IP, Safi: api.Family_SAFI_UNICAST},
+		Uuid:      t.defaultRouteUUID,
+	}); err != nil {
+		return fmt.Errorf("failed to withdraw originated default route: %v", err)
+	}
+	t.defaultRouteUUID, t.defaultOriginated = nil, nil
+	return nil
+}
+
+// originateDefaultRoute originates the default route to the neighbours, once
+// the export policy limiting it to them is applied.
+func (t *bgpTask) originateDefaultRoute(ctx context.Context, neighbors []string) error {
+	if t.defaultRouteUUID != nil || len(neighbors) == 0 {
+		return nil
+	}
+	path, err := defaultRoutePath()
+	if err != nil {
+		return err
+	}
+	resp, err := t.bgpServer.AddPath(ctx, &api.AddPathRequest{TableType: api.TableType_GLOBAL, Path: path})
+	if err != nil {
+		return fmt.Errorf("failed to originate default route: %v", err)
+	}
+	log.V(1).Infof("BGP: originating default route to %v", neighbors)
+	t.defaultRouteUUID, t.defaultOriginated = resp.GetUuid(), neighbors
+	return nil
+}
@@ -37,6 +37,7 @@ import (
 	api "github.com/osrg/gobgp/v3/api"
 	"github.com/osrg/gobgp/v3/pkg/config"
 	gobgpoc "github.com/osrg/gobgp/v3/pkg/config/oc"
+	gobgplog "github.com/osrg/gobgp/v3/pkg/log"
 	"github.com/osrg/gobgp/v3/pkg/server"
 )
 
@@ -52,12 +53,38 @@ var (
 )
 
 // NewGoBGPTask creates a new GoBGP task implementing OpenConfig BGP functionalities.
-func NewGoBGPTask(targetName, zapiURL string, listenPort uint16) *reconciler.BuiltReconciler {
-	gobgpTask := newBgpTask(targetName, zapiURL, listenPort)
+//
+// If nhResolver is not nil, routes received from neighbours are rejected
+// until their next hop is resolvable using nhResolver.
+//
+// condAdvs are advertised only while their conditions are met.
+func NewGoBGPTask(targetName, zapiURL string, listenPort uint16, nhResolver NexthopResolver, llgrRestartTime time.Duration, condAdvs []ConditionalAdvertisement) *reconciler.BuiltReconciler {
+	gobgpTask := newBgpTask(targetName, zapiURL, listenPort, nhResolver, llgrRestartTime, condAdvs)
 	return reconciler.NewBuilder("gobgp").WithStart(gobgpTask.start).WithStop(gobgpTask.stop).WithValidator(
 		[]ygnmi.PathStruct{
 			RoutingPolicyPath.DefinedSets().PrefixSetAny().Mode().Config().PathStruct(),
-		}, validatePrefixSetMode).Build()
+		}, validatePrefixSetMode).WithValidator(
+		[]ygnmi.PathStruct{
+			RoutingPolicyPath.DefinedSets().PrefixSetAny().PrefixAny().MasklengthRange().Config().PathStruct(),
+		}, validatePrefixSetMasklengthRanges).Build()
+}
+
+// validatePrefixSetMasklengthRanges checks that the mask length ranges of all
+// prefix sets are valid for the family of their prefix, e.g. that "24..32" is
+// only used for IPv4 prefixes and "64..128" for IPv6 prefixes.
+func validatePrefixSetMasklengthRanges(root *oc.Root) error {
+	definedSets := root.GetRoutingPolicy().GetDefinedSets()
+	if definedSets == nil {
+		return nil
+	}
+	for _, prefixSet := range definedSets.PrefixSet {
+		for _, pfx := range prefixSet.Prefix {
+			if _, err := parsePrefixRange(pfx.GetIpPrefix(), pfx.GetMasklengthRange()); err != nil {
+				return fmt.Errorf("invalid prefix %q in prefix set %q: %v", pfx.GetIpPrefix(), prefixSet.GetName(), err)
+			}
+		}
+	}
+	return nil
 }
 
 // validatePrefixSetMode check that all prefix sets have the correct mode.
@@ -114,20 +141,47 @@ type bgpTask struct {
 	listenPort    uint16
 
 	bgpStarted bool
+	// intended is the last intended configuration that was reconciled.
+	intended *oc.Root
+
+	nhResolver NexthopResolver
+	// nexthops is nil unless next-hop tracking is enabled.
+	nexthops *nexthopTracker
+	// condAdvs are the conditional advertisements.
+	condAdvs []ConditionalAdvertisement
+	// conditions is nil unless there are conditional advertisements.
+	conditions *conditionTracker
+	// defaultRouteUUID identifies the originated default route, it is nil
+	// unless the default route is originated.
+	defaultRouteUUID []byte
+	// defaultOriginated are the neighbours the default route is originated to.
+	defaultOriginated []string
+	// loggedMessages are the message counters of each neighbour that were
+	// last logged.
+	loggedMessages map[string]*api.Messages
+	// disabled is the set of neighbours that are administratively shut down.
+	disabled map[string]bool
+	// llgrRestartTime is how long stale routes are retained after the
+	// graceful restart time expires, or zero if LLGR is disabled.
+	llgrRestartTime time.Duration
 
 	yclient *ygnmi.Client
 
-	commAttrTracker *ocRIBAttrIndicesTracker[string]
-	attrSetTracker  *ocRIBAttrIndicesTracker[ribAttrSet]
+	commAttrTracker    *ocRIBAttrIndicesTracker[string]
+	extCommAttrTracker *ocRIBAttrIndicesTracker[string]
+	attrSetTracker     *ocRIBAttrIndicesTracker[ribAttrSet]
 
 	appliedStateMu       sync.Mutex
 	appliedState         *oc.Root
 	appliedBGP           *oc.NetworkInstance_Protocol_Bgp
 	appliedRoutingPolicy *oc.RoutingPolicy
+	// stopped is set, under appliedStateMu, once the GoBGP server is
+	// stopped so that the trackers stop reconciling.
+	stopped bool
 }
 
 // newBgpTask creates a new bgpTask.
-func newBgpTask(targetName, zapiURL string, listenPort uint16) *bgpTask {
+func newBgpTask(targetName, zapiURL string, listenPort uint16, nhResolver NexthopResolver, llgrRestartTime time.Duration, condAdvs []ConditionalAdvertisement) *bgpTask {
 	appliedState := &oc.Root{}
 	// appliedBGP is the SoT for BGP applied configuration. It is maintained locally by the task.
 	appliedBGP := appliedState.GetOrCreateNetworkInstance(fakedevice.DefaultNetworkInstance).GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, fakedevice.BGPRoutingProtocol).GetOrCreateBgp()
@@ -137,9 +191,14 @@ func newBgpTask(targetName, zapiURL string, listenPort uint16) *bgpTask {
 		targetName: targetName,
 		zapiURL:    zapiURL,
 		listenPort: listenPort,
+		nhResolver: nhResolver,
+		condAdvs:   condAdvs,
 
-		commAttrTracker: newOCRIBAttrIndices[string](),
-		attrSetTracker:  newOCRIBAttrIndices[ribAttrSet](),
+		llgrRestartTime: llgrRestartTime,
+
+		commAttrTracker:    newOCRIBAttrIndices[string](),
+		extCommAttrTracker: newOCRIBAttrIndices[string](),
+		attrSetTracker:     newOCRIBAttrIndices[ribAttrSet](),
 
 		appliedState:         appliedState,
 		appliedBGP:           appliedBGP,
@@ -149,6 +208,9 @@ func newBgpTask(targetName, zapiURL string, listenPort uint16) *bgpTask {
 
 // stop stops the GoBGP server.
 func (t *bgpTask) stop(context.Context) error {
+	t.appliedStateMu.Lock()
+	t.stopped = true
+	t.appliedStateMu.Unlock()
 	t.bgpServer.Stop()
 	return nil
 }
@@ -164,8 +226,41 @@ func (t *bgpTask) start(ctx context.Context, yclient *ygnmi.Client) error {
 		BGPPath.Global().RouterId().Config().PathStruct(),
 		BGPPath.NeighborAny().PeerAs().Config().PathStruct(),
 		BGPPath.NeighborAny().NeighborAddress().Config().PathStruct(),
+		BGPPath.NeighborAny().Enabled().Config().PathStruct(),
+		BGPPath.NeighborAny().Description().Config().PathStruct(),
 		BGPPath.NeighborAny().NeighborPort().Config().PathStruct(),
 		BGPPath.NeighborAny().Transport().LocalAddress().Config().PathStruct(),
+		BGPPath.NeighborAny().Transport().PassiveMode().Config().PathStruct(),
+		BGPPath.NeighborAny().PeerGroup().Config().PathStruct(),
+		BGPPath.NeighborAny().Timers().ConnectRetry().Config().PathStruct(),
+		BGPPath.NeighborAny().Timers().HoldTime().Config().PathStruct(),
+		BGPPath.NeighborAny().Timers().KeepaliveInterval().Config().PathStruct(),
+		BGPPath.NeighborAny().AfiSafiAny().AfiSafiName().Config().PathStruct(),
+		BGPPath.NeighborAny().AfiSafiAny().Enabled().Config().PathStruct(),
+		BGPPath.NeighborAny().AfiSafiAny().Ipv4Unicast().SendDefaultRoute().Config().PathStruct(),
+		// Graceful restart.
+		BGPPath.NeighborAny().GracefulRestart().Enabled().Config().PathStruct(),
+		BGPPath.NeighborAny().GracefulRestart().RestartTime().Config().PathStruct(),
+		BGPPath.NeighborAny().GracefulRestart().StaleRoutesTime().Config().PathStruct(),
+		BGPPath.NeighborAny().GracefulRestart().HelperOnly().Config().PathStruct(),
+		BGPPath.NeighborAny().AfiSafiAny().GracefulRestart().Enabled().Config().PathStruct(),
+		BGPPath.PeerGroupAny().GracefulRestart().Enabled().Config().PathStruct(),
+		BGPPath.PeerGroupAny().GracefulRestart().RestartTime().Config().PathStruct(),
+		BGPPath.PeerGroupAny().GracefulRestart().StaleRoutesTime().Config().PathStruct(),
+		BGPPath.PeerGroupAny().GracefulRestart().HelperOnly().Config().PathStruct(),
+		BGPPath.PeerGroupAny().AfiSafiAny().GracefulRestart().Enabled().Config().PathStruct(),
+		// Peer groups and dynamic neighbours.
+		BGPPath.PeerGroupAny().PeerGroupName().Config().PathStruct(),
+		BGPPath.PeerGroupAny().PeerAs().Config().PathStruct(),
+		BGPPath.PeerGroupAny().Transport().LocalAddress().Config().PathStruct(),
+		BGPPath.PeerGroupAny().Transport().PassiveMode().Config().PathStruct(),
+		BGPPath.PeerGroupAny().Timers().ConnectRetry().Config().PathStruct(),
+		BGPPath.PeerGroupAny().Timers().HoldTime().Config().PathStruct(),
+		BGPPath.PeerGroupAny().Timers().KeepaliveInterval().Config().PathStruct(),
+		BGPPath.PeerGroupAny().AfiSafiAny().AfiSafiName().Config().PathStruct(),
+		BGPPath.PeerGroupAny().AfiSafiAny().Enabled().Config().PathStruct(),
+		BGPPath.Global().DynamicNeighborPrefixAny().Prefix().Config().PathStruct(),
+		BGPPath.Global().DynamicNeighborPrefixAny().PeerGroup().Config().PathStruct(),
 		// BGP Policy statements
 		RoutingPolicyPath.PolicyDefinitionAny().Name().Config().PathStruct(),
 		RoutingPolicyPath.PolicyDefinitionAny().StatementMap().Config().PathStruct(),
@@ -173,6 +268,10 @@ func (t *bgpTask) start(ctx context.Context, yclient *ygnmi.Client) error {
 		BGPPath.NeighborAny().ApplyPolicy().DefaultExportPolicy().Config().PathStruct(),
 		BGPPath.NeighborAny().ApplyPolicy().ImportPolicy().Config().PathStruct(),
 		BGPPath.NeighborAny().ApplyPolicy().ExportPolicy().Config().PathStruct(),
+		BGPPath.PeerGroupAny().ApplyPolicy().DefaultImportPolicy().Config().PathStruct(),
+		BGPPath.PeerGroupAny().ApplyPolicy().DefaultExportPolicy().Config().PathStruct(),
+		BGPPath.PeerGroupAny().ApplyPolicy().ImportPolicy().Config().PathStruct(),
+		BGPPath.PeerGroupAny().ApplyPolicy().ExportPolicy().Config().PathStruct(),
 		// BGP defined sets
 		// -- prefix sets
 		RoutingPolicyPath.DefinedSets().PrefixSetAny().Name().Config().PathStruct(),
@@ -195,6 +294,17 @@ func (t *bgpTask) start(ctx context.Context, yclient *ygnmi.Client) error {
 	// Initialize values required for reconile to be called.
 	t.currentConfig = &gobgpoc.BgpConfigSet{}
 
+	if t.nhResolver != nil {
+		if err := t.startNexthopTracking(ctx); err != nil {
+			return err
+		}
+	}
+	if len(t.condAdvs) > 0 {
+		if err := t.startConditionTracking(ctx); err != nil {
+			return err
+		}
+	}
+
 	// Monitor changes to BGP intended config and apply them.
 	bgpWatcher := ygnmi.Watch(
 		ctx,
@@ -227,9 +337,24 @@ func (t *bgpTask) start(ctx context.Context, yclient *ygnmi.Client) error {
 // configuration, and makes GoBGP API calls accordingly to update the applied
 // configuration in the direction of intended configuration.
 func (t *bgpTask) reconcile(ctx context.Context, intended *oc.Root) error {
+	t.intended = intended
 	intendedBGP := intended.GetOrCreateNetworkInstance(fakedevice.DefaultNetworkInstance).GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, fakedevice.BGPRoutingProtocol).GetOrCreateBgp()
 	intendedPolicy := intended.GetOrCreateRoutingPolicy()
-	newConfig := intendedToGoBGP(intendedBGP, intendedPolicy, t.zapiURL, t.listenPort)
+	newConfig := intendedToGoBGP(intendedBGP, intendedPolicy, t.zapiURL, t.listenPort, t.llgrRestartTime)
+	if t.nexthops != nil {
+		rejectUnreachableNexthops(newConfig, t.nexthops.unreachable())
+	}
+	if t.conditions != nil {
+		t.conditions.setPrefixSets(intendedPolicy.GetOrCreateDefinedSets().PrefixSet)
+		applyConditionalAdvertisements(newConfig, t.condAdvs, t.conditions.suppressedIndices())
+	}
+	defaultOriginated := defaultOriginateNeighbors(intendedBGP, t.conditions)
+	if len(defaultOriginated) > 0 || t.defaultRouteUUID != nil {
+		applyDefaultOriginate(newConfig, defaultOriginated)
+	}
+	if err := t.withdrawDefaultRoute(ctx, defaultOriginated); err != nil {
+		return err
+	}
 
 	intendedGlobal := intendedBGP.GetOrCreateGlobal()
 	bgpShouldStart := intendedGlobal.As != nil && intendedGlobal.RouterId != nil
@@ -253,8 +378,18 @@ func (t *bgpTask) reconcile(ctx context.Context, intended *oc.Root) error {
 		// Waiting for BGP to be startable.
 		return nil
 	}
+	t.applyAdminState(ctx, intendedBGP)
+	if err := t.originateDefaultRoute(ctx, defaultOriginated); err != nil {
+		return err
+	}
 
 	err := ygot.MergeStructInto(t.appliedBGP, intendedBGP, &ygot.MergeOverwriteExistingFields{})
+	// Report the default policies in effect for each neighbour, including
+	// those inherited from its peer-group or left at the YANG default.
+	for addr, neigh := range intendedBGP.Neighbor {
+		applyPolicy := t.appliedBGP.GetOrCreateNeighbor(addr).GetOrCreateApplyPolicy()
+		applyPolicy.DefaultImportPolicy, applyPolicy.DefaultExportPolicy = defaultPolicies(neigh, intendedBGP.GetPeerGroup(neigh.GetPeerGroup()))
+	}
 	// TODO(wenbli): Since policy definitions is an atomic node,
 	// unsupported policy leaves will be merged as well. Therefore omitting
 	// them from the applied state until we find a way to to prune out
@@ -263,6 +398,74 @@ func (t *bgpTask) reconcile(ctx context.Context, intended *oc.Root) error {
 	return err
 }
 
+// startNexthopTracking tracks the next hops of all routes received from
+// neighbours, and reconciles the last intended configuration whenever the set
+// of unreachable next hops changes so that the routes using them are
+// re-evaluated.
+//
+// Routes using a newly-learned next hop may be briefly accepted until the
+// next hop is found to be unreachable.
+func (t *bgpTask) startNexthopTracking(ctx context.Context) error {
+	t.nexthops = newNexthopTracker(t.nhResolver, t.reconcileIntended(ctx))
+
+	if err := t.bgpServer.WatchEvent(ctx, &api.WatchEventRequest{
+		Table: &api.WatchEventRequest_Table{
+			Filters: []*api.WatchEventRequest_Table_Filter{{
+				Type: api.WatchEventRequest_Table_Filter_ADJIN,
+				Init: true,
+			}},
+		},
+	}, func(r *api.WatchEventResponse) {
+		var nhs []netip.Addr
+		for _, path := range r.GetTable().GetPaths() {
+			if path.GetIsWithdraw() {
+				continue
+			}
+			if nh, ok := pathNexthop(path); ok {
+				nhs = append(nhs, nh)
+			}
+		}
+		t.nexthops.add(nhs...)
+	}); err != nil {
+		return fmt.Errorf("goBgpTask failed to watch received routes: %v", err)
+	}
+	return nil
+}
+
+// startConditionTracking tracks the best routes of the RIB, and reconciles the
+// last intended configuration whenever the set of suppressed conditional
+// advertisements changes.
+func (t *bgpTask) startConditionTracking(ctx context.Context) error {
+	t.conditions = newConditionTracker(t.condAdvs, t.reconcileIntended(ctx))
+
+	if err := t.bgpServer.WatchEvent(ctx, &api.WatchEventRequest{
+		Table: &api.WatchEventRequest_Table{
+			Filters: []*api.WatchEventRequest_Table_Filter{{
+				Type: api.WatchEventRequest_Table_Filter_BEST,
+				Init: true,
+			}},
+		},
+	}, func(r *api.WatchEventResponse) {
+		t.conditions.update(r.GetTable().GetPaths())
+	}); err != nil {
+		return fmt.Errorf("goBgpTask failed to watch best routes: %v", err)
+	}
+	return nil
+}
+
+// reconcileIntended returns a function reconciling the last intended
+// configuration.
+func (t *bgpTask) reconcileIntended(ctx context.Context) func() {
+	return func() {
+		t.updateAppliedState(ctx, func() error {
+			if t.intended == nil || t.stopped {
+				return nil
+			}
+			return t.reconcile(ctx, t.intended)
+		})
+	}
+}
+
 // updateAppliedState is the ONLY function that's called when updating the appliedState.
 //
 // The input function is expected to make modifications to the applied state,
@@ -287,7 +490,14 @@ func updateAppliedStateHelper[T ygot.GoStruct](ctx context.Context, yclient *ygn
 
 // createNewGoBGPServer creates and starts a new GoBGP Server.
 func (t *bgpTask) createNewGoBGPServer(ctx context.Context) error {
-	t.bgpServer = server.NewBgpServer()
+	t.bgpServer = server.NewBgpServer(server.LoggerOption(&shutdownLogger{
+		Logger: gobgplog.NewDefaultLogger(),
+		onShutdown: func(neighAddr string, subcode uint8, communication string) {
+			// GoBGP logs while holding the lock of the neighbour, so
+			// don't wait for the applied state.
+			go t.recordShutdown(ctx, neighAddr, subcode, communication)
+		},
+	}))
 
 	if log.V(2) {
 		if err := t.bgpServer.SetLogLevel(ctx, &api.SetLogLevelRequest{
@@ -302,33 +512,7 @@ func (t *bgpTask) createNewGoBGPServer(ctx context.Context) error {
 	if err := t.bgpServer.WatchEvent(ctx, &api.WatchEventRequest{Peer: &api.WatchEventRequest_Peer{}}, func(r *api.WatchEventResponse) {
 		if p := r.GetPeer(); p != nil && p.Type == api.WatchEventResponse_PeerEvent_STATE {
 			log.V(1).Info("Got peer event update:", p)
-			ps := p.GetPeer().State
-
-			t.updateAppliedState(ctx, func() error {
-				neigh := t.appliedBGP.GetOrCreateNeighbor(ps.NeighborAddress)
-
-				found := false
-				if ps.SessionState.String() == "UNKNOWN" {
-					neigh.SessionState = oc.Bgp_Neighbor_SessionState_UNSET
-					found = true
-				} else {
-					for enumCode, v := range neigh.SessionState.ΛMap()[reflect.TypeOf(neigh.SessionState).Name()] {
-						if v.Name == ps.SessionState.String() {
-							newSessionState := oc.E_Bgp_Neighbor_SessionState(enumCode)
-							if neigh.SessionState != newSessionState {
-								log.V(1).Infof("Peer %s transitioned to session state %s", ps.NeighborAddress, v.Name)
-								neigh.SessionState = newSessionState
-							}
-							found = true
-							break
-						}
-					}
-				}
-				if !found {
-					log.Warningf("Unknown neighbor session-state value received: %v", ps.SessionState)
-				}
-				return nil
-			})
+			t.recordSessionState(ctx, p.GetPeer().State)
 		}
 	}); err != nil {
 		return fmt.Errorf("goBgpTask failed to initialize due to error: %v", err)
@@ -338,7 +522,7 @@ func (t *bgpTask) createNewGoBGPServer(ctx context.Context) error {
 		tick := time.NewTicker(5 * time.Second)
 		for range tick.C {
 			if err := t.updateRIBs(ctx); err != nil {
-				log.Warning("Error while updating BGP RIB data: %v", err)
+				log.Warningf("Error while updating BGP RIB data: %v", err)
 			}
 		}
 	}()
@@ -346,13 +530,73 @@ func (t *bgpTask) createNewGoBGPServer(ctx context.Context) error {
 	return nil
 }
 
+// recordSessionState records a transition of the FSM of a neighbour in its
+// state.
+//
+// Only the session-state leaf is published, rather than the whole applied
+// state, so that every transition is streamed to ON_CHANGE subscribers in
+// the order GoBGP reports them.
+func (t *bgpTask) recordSessionState(ctx context.Context, ps *api.PeerState) {
+	t.appliedStateMu.Lock()
+	defer t.appliedStateMu.Unlock()
+	neigh := t.appliedBGP.GetOrCreateNeighbor(ps.NeighborAddress)
+
+	newSessionState := oc.Bgp_Neighbor_SessionState_UNSET
+	if ps.SessionState != api.PeerState_UNKNOWN {
+		found := false
+		for enumCode, v := range neigh.SessionState.ΛMap()[reflect.TypeOf(neigh.SessionState).Name()] {
+			if v.Name == ps.SessionState.String() {
+				newSessionState = oc.E_Bgp_Neighbor_SessionState(enumCode)
+				found = true
+				break
+			}
+		}
+		if !found {
+			log.Warningf("Unknown neighbor session-state value received: %v", ps.SessionState)
+			return
+		}
+	}
+	if neigh.SessionState == newSessionState {
+		return
+	}
+	log.V(1).Infof("Peer %s transitioned to session state %s", ps.NeighborAddress, newSessionState)
+	neigh.SessionState = newSessionState
+
+	q := BGPPath.Neighbor(ps.NeighborAddress).SessionState().State()
+	var err error
+	if newSessionState == oc.Bgp_Neighbor_SessionState_UNSET {
+		_, err = gnmiclient.Delete(ctx, t.yclient, q)
+	} else {
+		_, err = gnmiclient.Replace(ctx, t.yclient, q, newSessionState)
+	}
+	if err != nil {
+		log.Errorf("BGP failed to update session state of neighbor %s: %v", ps.NeighborAddress, err)
+	}
+}
+
+// listPeerStates returns the state of each neighbour, keyed by the address of
+// the neighbour.
+func (t *bgpTask) listPeerStates(ctx context.Context) map[string]*api.PeerState {
+	peers := map[string]*api.PeerState{}
+	if err := t.bgpServer.ListPeer(ctx, &api.ListPeerRequest{}, func(p *api.Peer) {
+		peers[p.GetState().GetNeighborAddress()] = p.GetState()
+	}); err != nil && err.Error() != "bgp server hasn't started yet" {
+		log.Errorf("GoBGP ListPeer call failed: %v", err)
+	}
+	return peers
+}
+
 // updateRIBs updates the BGP RIBs.
 func (t *bgpTask) updateRIBs(ctx context.Context) error {
 	// Log global tables
 	t.queryTable(ctx, "", false, api.TableType_GLOBAL, api.Family_AFI_IP, nil)
 	t.queryTable(ctx, "", false, api.TableType_GLOBAL, api.Family_AFI_IP6, nil)
+	peers := t.listPeerStates(ctx)
 
 	return t.updateAppliedState(ctx, func() error {
+		t.recordMessageCounters(peers)
+		t.recordActiveAfiSafis(peers)
+
 		t.beginAttrPopulation()
 		defer t.completeAttrPopulation()
 
@@ -567,8 +811,10 @@ func (t *bgpTask) queryTable(ctx context.Context, neighbor string, postPolicy bo
 func (t *bgpTask) beginAttrPopulation() {
 	// Clear RIB attributes for fresh population.
 	t.appliedBGP.GetOrCreateRib().Community = nil
+	t.appliedBGP.GetOrCreateRib().ExtCommunity = nil
 	t.appliedBGP.GetOrCreateRib().AttrSet = nil
 	t.commAttrTracker.beginAllocation()
+	t.extCommAttrTracker.beginAllocation()
 	t.attrSetTracker.beginAllocation()
 }
 
@@ -580,6 +826,7 @@ func (t *bgpTask) beginAttrPopulation() {
 func (t *bgpTask) completeAttrPopulation() {
 	// Clear RIB attributes for fresh population.
 	t.commAttrTracker.completeAllocation()
+	t.extCommAttrTracker.completeAllocation()
 	t.attrSetTracker.completeAllocation()
 }
 
@@ -619,9 +866,13 @@ func (t *bgpTask) populateRIBAttrs(path *api.Path, rib *oc.NetworkInstance_Proto
 	var (
 		hasCommunity       bool
 		commIndex          uint64
+		hasExtCommunity    bool
+		extCommIndex       uint64
 		hasOrigin          bool
 		hasMED             bool
 		hasLocalPref       bool
+		hasAIGP            bool
+		hasNextHop         bool
 		hasASPathAttribute bool
 		asSegments         []*api.AsSegment
 		attrSet            ribAttrSet
@@ -640,6 +891,21 @@ func (t *bgpTask) populateRIBAttrs(path *api.Path, rib *oc.NetworkInstance_Proto
 				commIndex = t.commAttrTracker.getOrAllocIndex(commsToString(comms))
 				rib.GetOrCreateCommunity(commIndex).SetCommunity(communitiesToOC(comms))
 			}
+		case *api.ExtendedCommunitiesAttribute:
+			comms, err := extCommunitiesToOC(m)
+			if err != nil {
+				log.Errorf("BGP: Unable to convert extended communities: %v", err)
+				continue
+			}
+			if len(comms) > 0 {
+				hasExtCommunity = true
+				extCommIndex = t.extCommAttrTracker.getOrAllocIndex(strings.Join(comms, " "))
+				extComms := rib.GetOrCreateExtCommunity(extCommIndex)
+				extComms.ExtCommunity = nil
+				for _, comm := range comms {
+					extComms.ExtCommunity = append(extComms.ExtCommunity, oc.UnionString(comm))
+				}
+			}
 		case *api.OriginAttribute:
 			hasOrigin = true
 			switch origin := m.GetOrigin(); origin {
@@ -658,6 +924,29 @@ func (t *bgpTask) populateRIBAttrs(path *api.Path, rib *oc.NetworkInstance_Proto
 		case *api.LocalPrefAttribute:
 			hasLocalPref = true
 			attrSet.localPref = m.GetLocalPref()
+		case *api.AigpAttribute:
+			// TODO: AIGP is only reported. Selecting the best path by AIGP and accumulating
+			// the metric on advertisement (RFC 7311) need hooks into GoBGP's decision process
+			// and update generation that it doesn't offer yet.
+			for _, tlv := range m.GetTlvs() {
+				tm, err := tlv.UnmarshalNew()
+				if err != nil {
+					log.Errorf("BGP: Unable to unmarshal a GoBGP AIGP TLV")
+					continue
+				}
+				if metric, ok := tm.(*api.AigpTLVIGPMetric); ok {
+					hasAIGP = true
+					attrSet.aigp = metric.GetMetric()
+				}
+			}
+		case *api.NextHopAttribute:
+			hasNextHop = true
+			attrSet.nextHop = m.GetNextHop()
+		case *api.MpReachNLRIAttribute:
+			if nhs := m.GetNextHops(); len(nhs) > 0 {
+				hasNextHop = true
+				attrSet.nextHop = nhs[0]
+			}
 		case *api.AsPathAttribute:
 			hasASPathAttribute = true
 			asSegments = m.GetSegments()
@@ -667,7 +956,10 @@ func (t *bgpTask) populateRIBAttrs(path *api.Path, rib *oc.NetworkInstance_Proto
 	if hasCommunity {
 		route.SetCommunityIndex(commIndex)
 	}
-	if hasOrigin || hasMED || hasLocalPref || hasASPathAttribute {
+	if hasExtCommunity {
+		route.SetExtCommunityIndex(extCommIndex)
+	}
+	if hasOrigin || hasMED || hasLocalPref || hasAIGP || hasNextHop || hasASPathAttribute {
 		attrSetIndex := t.attrSetTracker.getOrAllocIndex(attrSet)
 		route.SetAttrIndex(attrSetIndex)
 		attrSetOC := rib.GetOrCreateAttrSet(attrSetIndex)
@@ -680,6 +972,12 @@ func (t *bgpTask) populateRIBAttrs(path *api.Path, rib *oc.NetworkInstance_Proto
 		if hasLocalPref {
 			attrSetOC.SetLocalPref(attrSet.localPref)
 		}
+		if hasAIGP {
+			attrSetOC.SetAigp(attrSet.aigp)
+		}
+		if hasNextHop {
+			attrSetOC.SetNextHop(attrSet.nextHop)
+		}
 		if hasASPathAttribute {
 			for i, s := range asSegments {
 				segmentOC := attrSetOC.GetOrCreateAsSegment(uint32(i))
@@ -692,6 +990,7 @@ func (t *bgpTask) populateRIBAttrs(path *api.Path, rib *oc.NetworkInstance_Proto
 
 type ocRIBRoute interface {
 	SetCommunityIndex(uint64)
+	SetExtCommunityIndex(uint64)
 	SetAttrIndex(uint64)
 }
 
@@ -699,6 +998,8 @@ type ribAttrSet struct {
 	origin    oc.E_BgpTypes_BgpOriginAttrType
 	med       uint32
 	localPref uint32
+	aigp      uint64
+	nextHop   string
 	asPath    string
 }
 
@@ -3,8 +3,14 @@ package bgp
 import (
 	"testing"
 
-	"github.com/openconfig/lemming/gnmi/oc"
+	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/openconfig/lemming/gnmi/oc"
+
+	api "github.com/osrg/gobgp/v3/api"
 )
 
 func TestValidatePrefixSetMode(t *testing.T) {
@@ -197,6 +203,63 @@ func TestValidatePrefixSetMode(t *testing.T) {
 	}
 }
 
+func TestValidatePrefixSetMasklengthRanges(t *testing.T) {
+	tests := []struct {
+		desc              string
+		inPrefix          string
+		inMasklengthRange string
+		wantErr           bool
+	}{{
+		desc:              "exact",
+		inPrefix:          "10.0.0.0/24",
+		inMasklengthRange: "exact",
+	}, {
+		desc:              "IPv4-open-ended",
+		inPrefix:          "10.0.0.0/24",
+		inMasklengthRange: "24..32",
+	}, {
+		desc:              "IPv4-exceeds-max",
+		inPrefix:          "10.0.0.0/24",
+		inMasklengthRange: "24..33",
+		wantErr:           true,
+	}, {
+		desc:              "IPv6-open-ended",
+		inPrefix:          "2001:db8::/64",
+		inMasklengthRange: "64..128",
+	}, {
+		desc:              "IPv6-exceeds-max",
+		inPrefix:          "2001:db8::/64",
+		inMasklengthRange: "64..129",
+		wantErr:           true,
+	}, {
+		desc:              "IPv6-range-on-IPv4",
+		inPrefix:          "10.0.0.0/24",
+		inMasklengthRange: "64..128",
+		wantErr:           true,
+	}, {
+		desc:              "reversed",
+		inPrefix:          "10.0.0.0/24",
+		inMasklengthRange: "32..24",
+		wantErr:           true,
+	}, {
+		desc:              "malformed",
+		inPrefix:          "10.0.0.0/24",
+		inMasklengthRange: "24-32",
+		wantErr:           true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			root := &oc.Root{}
+			root.GetOrCreateRoutingPolicy().GetOrCreateDefinedSets().GetOrCreatePrefixSet("foo").GetOrCreatePrefix(tt.inPrefix, tt.inMasklengthRange)
+			err := validatePrefixSetMasklengthRanges(root)
+			if gotErr := (err != nil); gotErr != tt.wantErr {
+				t.Errorf("gotErr %v, wantErr: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestPopulateAttrs(t *testing.T) {
 	r := newOCRIBAttrIndices[[5]uint32]()
 	r.beginAllocation()
@@ -244,3 +307,87 @@ func TestPopulateAttrs(t *testing.T) {
 	}
 	r.completeAllocation()
 }
+
+// pathWithAttrs returns a GoBGP path carrying the given path attributes.
+func pathWithAttrs(t *testing.T, attrs ...proto.Message) *api.Path {
+	t.Helper()
+	path := &api.Path{}
+	for _, attr := range attrs {
+		a, err := anypb.New(attr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path.Pattrs = append(path.Pattrs, a)
+	}
+	return path
+}
+
+func TestPopulateRIBAttrsAIGP(t *testing.T) {
+	aigp := func(t *testing.T, metric uint64) *api.AigpAttribute {
+		t.Helper()
+		tlv, err := anypb.New(&api.AigpTLVIGPMetric{Metric: metric})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &api.AigpAttribute{Tlvs: []*anypb.Any{tlv}}
+	}
+
+	task := newBgpTask("", "", 0, nil, 0, nil)
+	rib := task.appliedBGP.GetOrCreateRib()
+	adjRib := rib.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).GetOrCreateIpv4Unicast().GetOrCreateNeighbor("192.0.2.1").GetOrCreateAdjRibInPre()
+
+	task.beginAttrPopulation()
+	task.populateRIBAttrs(pathWithAttrs(t, &api.OriginAttribute{}, aigp(t, 10)), rib, adjRib.GetOrCreateRoute("10.0.0.0/8", 0))
+	task.populateRIBAttrs(pathWithAttrs(t, &api.OriginAttribute{}, aigp(t, 20)), rib, adjRib.GetOrCreateRoute("10.0.0.0/8", 1))
+	task.populateRIBAttrs(pathWithAttrs(t, &api.OriginAttribute{}), rib, adjRib.GetOrCreateRoute("10.0.0.0/8", 2))
+	task.completeAttrPopulation()
+
+	for _, tt := range []struct {
+		pathID   uint32
+		wantAIGP *uint64
+	}{
+		{pathID: 0, wantAIGP: ygot.Uint64(10)},
+		{pathID: 1, wantAIGP: ygot.Uint64(20)},
+		{pathID: 2},
+	} {
+		attrSet := rib.GetAttrSet(adjRib.GetRoute("10.0.0.0/8", tt.pathID).GetAttrIndex())
+		if diff := cmp.Diff(attrSet.Aigp, tt.wantAIGP); diff != "" {
+			t.Errorf("path %d: AIGP (-got, +want):\n%s", tt.pathID, diff)
+		}
+	}
+}
+
+func TestPopulateRIBAttrsNextHop(t *testing.T) {
+	task := newBgpTask("", "", 0, nil, 0, nil)
+	rib := task.appliedBGP.GetOrCreateRib()
+	v4Rib := rib.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).GetOrCreateIpv4Unicast().GetOrCreateLocRib()
+	v6Rib := rib.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST).GetOrCreateIpv6Unicast().GetOrCreateLocRib()
+
+	task.beginAttrPopulation()
+	task.populateRIBAttrs(pathWithAttrs(t, &api.OriginAttribute{}, &api.NextHopAttribute{NextHop: "192.0.2.1"}), rib, v4Rib.GetOrCreateRoute("10.0.0.0/8", oc.UnionString("192.0.2.1"), 0))
+	task.populateRIBAttrs(pathWithAttrs(t, &api.OriginAttribute{}, &api.NextHopAttribute{NextHop: "192.0.2.3"}), rib, v4Rib.GetOrCreateRoute("10.0.0.0/8", oc.UnionString("192.0.2.3"), 1))
+	task.populateRIBAttrs(pathWithAttrs(t, &api.OriginAttribute{}, &api.MpReachNLRIAttribute{NextHops: []string{"2001:db8::1"}}), rib, v6Rib.GetOrCreateRoute("2001:db8:1::/48", oc.UnionString("2001:db8::1"), 0))
+	task.completeAttrPopulation()
+
+	for _, tt := range []struct {
+		desc        string
+		attrIndex   uint64
+		wantNextHop string
+	}{{
+		desc:        "ipv4 path",
+		attrIndex:   v4Rib.GetRoute("10.0.0.0/8", oc.UnionString("192.0.2.1"), 0).GetAttrIndex(),
+		wantNextHop: "192.0.2.1",
+	}, {
+		desc:        "ipv4 path via other next hop",
+		attrIndex:   v4Rib.GetRoute("10.0.0.0/8", oc.UnionString("192.0.2.3"), 1).GetAttrIndex(),
+		wantNextHop: "192.0.2.3",
+	}, {
+		desc:        "ipv6 path",
+		attrIndex:   v6Rib.GetRoute("2001:db8:1::/48", oc.UnionString("2001:db8::1"), 0).GetAttrIndex(),
+		wantNextHop: "2001:db8::1",
+	}} {
+		if got := rib.GetAttrSet(tt.attrIndex).GetNextHop(); got != tt.wantNextHop {
+			t.Errorf("%s: next hop got %q, want %q", tt.desc, got, tt.wantNextHop)
+		}
+	}
+}
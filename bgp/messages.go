@@ -0,0 +1,60 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"fmt"
+
+	log "github.com/golang/glog"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/proto"
+
+	api "github.com/osrg/gobgp/v3/api"
+)
+
+// recordMessageCounters records the number of UPDATE and NOTIFICATION
+// messages sent to and received from each neighbour in its state.
+//
+// OpenConfig doesn't model the other message types, so the number of
+// messages of each type is logged instead whenever it changes. The messages
+// themselves are logged by GoBGP at its debug level.
+func (t *bgpTask) recordMessageCounters(peers map[string]*api.PeerState) {
+	counters := map[string]*api.Messages{}
+	for addr, neigh := range t.appliedBGP.Neighbor {
+		ps, ok := peers[addr]
+		if !ok {
+			continue
+		}
+		msgs := ps.GetMessages()
+		counters[addr] = msgs
+		sent := neigh.GetOrCreateMessages().GetOrCreateSent()
+		sent.UPDATE = ygot.Uint64(msgs.GetSent().GetUpdate())
+		sent.NOTIFICATION = ygot.Uint64(msgs.GetSent().GetNotification())
+		received := neigh.GetOrCreateMessages().GetOrCreateReceived()
+		received.UPDATE = ygot.Uint64(msgs.GetReceived().GetUpdate())
+		received.NOTIFICATION = ygot.Uint64(msgs.GetReceived().GetNotification())
+
+		if !proto.Equal(t.loggedMessages[addr], msgs) {
+			log.V(2).Infof("BGP: neighbor %s messages sent: %s, received: %s", addr, formatMessageCounters(msgs.GetSent()), formatMessageCounters(msgs.GetReceived()))
+		}
+	}
+	t.loggedMessages = counters
+}
+
+// formatMessageCounters returns the number of messages of each type.
+func formatMessageCounters(m *api.Message) string {
+	return fmt.Sprintf("OPEN %d, UPDATE %d, NOTIFICATION %d, KEEPALIVE %d, ROUTE-REFRESH %d",
+		m.GetOpen(), m.GetUpdate(), m.GetNotification(), m.GetKeepalive(), m.GetRefresh())
+}
@@ -0,0 +1,177 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"net/netip"
+	"slices"
+	"sync"
+
+	log "github.com/golang/glog"
+
+	"github.com/openconfig/lemming/gnmi/fakedevice"
+
+	api "github.com/osrg/gobgp/v3/api"
+	gobgpoc "github.com/osrg/gobgp/v3/pkg/config/oc"
+)
+
+// unreachableNexthopPolicy is the name of the import policy rejecting routes
+// whose next hop is not resolvable in the FIB.
+const unreachableNexthopPolicy = "reject-unreachable-nexthops"
+
+// NexthopResolver resolves the next hops of BGP routes against the FIB.
+type NexthopResolver interface {
+	// NexthopResolvable returns whether addr resolves in the FIB of the
+	// network instance niName.
+	NexthopResolvable(niName string, addr netip.Addr) bool
+	// OnFIBChange registers f to be called whenever the FIB changes.
+	OnFIBChange(f func())
+}
+
+// nexthopTracker tracks whether the next hops of routes received from
+// neighbours are resolvable in the FIB.
+type nexthopTracker struct {
+	resolver NexthopResolver
+	// onChange is called whenever the set of unreachable next hops changes.
+	onChange func()
+
+	mu sync.Mutex
+	// reachable stores the last known reachability of each tracked next hop.
+	reachable map[netip.Addr]bool
+}
+
+// newNexthopTracker returns a tracker that re-resolves its next hops
+// whenever the resolver's FIB changes.
+func newNexthopTracker(resolver NexthopResolver, onChange func()) *nexthopTracker {
+	t := &nexthopTracker{
+		resolver:  resolver,
+		onChange:  onChange,
+		reachable: map[netip.Addr]bool{},
+	}
+	resolver.OnFIBChange(t.refresh)
+	return t
+}
+
+// add starts tracking the given next hops, calling onChange if any of the
+// newly-tracked next hops are unreachable.
+//
+// TODO: Stop tracking next hops that are no longer used by any route.
+func (t *nexthopTracker) add(nhs ...netip.Addr) {
+	t.mu.Lock()
+	changed := false
+	for _, nh := range nhs {
+		if _, ok := t.reachable[nh]; ok {
+			continue
+		}
+		reachable := t.resolver.NexthopResolvable(fakedevice.DefaultNetworkInstance, nh)
+		log.V(1).Infof("BGP: tracking next hop %v, reachable: %v", nh, reachable)
+		t.reachable[nh] = reachable
+		changed = changed || !reachable
+	}
+	t.mu.Unlock()
+	if changed {
+		t.onChange()
+	}
+}
+
+// refresh re-resolves all tracked next hops, calling onChange if the
+// reachability of any of them changed.
+func (t *nexthopTracker) refresh() {
+	t.mu.Lock()
+	changed := false
+	for nh, wasReachable := range t.reachable {
+		reachable := t.resolver.NexthopResolvable(fakedevice.DefaultNetworkInstance, nh)
+		if reachable != wasReachable {
+			log.V(1).Infof("BGP: next hop %v reachability changed to %v", nh, reachable)
+			t.reachable[nh] = reachable
+			changed = true
+		}
+	}
+	t.mu.Unlock()
+	if changed {
+		t.onChange()
+	}
+}
+
+// unreachable returns the sorted list of tracked next hops that are not
+// resolvable in the FIB.
+func (t *nexthopTracker) unreachable() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var nhs []string
+	for nh, reachable := range t.reachable {
+		if !reachable {
+			nhs = append(nhs, nh.String())
+		}
+	}
+	slices.Sort(nhs)
+	return nhs
+}
+
+// pathNexthop returns the next hop of a GoBGP path, or false if it doesn't
+// have one.
+func pathNexthop(path *api.Path) (netip.Addr, bool) {
+	for _, attr := range path.GetPattrs() {
+		m, err := attr.UnmarshalNew()
+		if err != nil {
+			log.Errorf("BGP: Unable to unmarshal a GoBGP path attribute")
+			continue
+		}
+		var nh string
+		switch m := m.(type) {
+		case *api.NextHopAttribute:
+			nh = m.GetNextHop()
+		case *api.MpReachNLRIAttribute:
+			// Any further next hops are link-local addresses.
+			if nhs := m.GetNextHops(); len(nhs) > 0 {
+				nh = nhs[0]
+			}
+		default:
+			continue
+		}
+		addr, err := netip.ParseAddr(nh)
+		if err != nil {
+			log.Errorf("BGP: Unable to parse next hop %q: %v", nh, err)
+			return netip.Addr{}, false
+		}
+		return addr.Unmap(), true
+	}
+	return netip.Addr{}, false
+}
+
+// rejectUnreachableNexthops adds a global import policy to bgpConfig that
+// rejects routes whose next hop is in unreachable. The policy is evaluated
+// before any of the neighbours' import policies.
+func rejectUnreachableNexthops(bgpConfig *gobgpoc.BgpConfigSet, unreachable []string) {
+	if len(unreachable) == 0 {
+		// GoBGP matches all routes when the next-hop list is empty.
+		return
+	}
+	bgpConfig.PolicyDefinitions = append(bgpConfig.PolicyDefinitions, gobgpoc.PolicyDefinition{
+		Name: unreachableNexthopPolicy,
+		Statements: []gobgpoc.Statement{{
+			Name: unreachableNexthopPolicy,
+			Conditions: gobgpoc.Conditions{
+				BgpConditions: gobgpoc.BgpConditions{
+					NextHopInList: unreachable,
+				},
+			},
+			Actions: gobgpoc.Actions{
+				RouteDisposition: gobgpoc.ROUTE_DISPOSITION_REJECT_ROUTE,
+			},
+		}},
+	})
+	bgpConfig.Global.ApplyPolicy.Config.ImportPolicyList = append([]string{unreachableNexthopPolicy}, bgpConfig.Global.ApplyPolicy.Config.ImportPolicyList...)
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"net/netip"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	gobgpoc "github.com/osrg/gobgp/v3/pkg/config/oc"
+)
+
+// fakeResolver resolves the next hops that it has been told are reachable.
+type fakeResolver struct {
+	mu        sync.Mutex
+	reachable map[netip.Addr]bool
+	listener  func()
+}
+
+func (r *fakeResolver) NexthopResolvable(_ string, addr netip.Addr) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reachable[addr]
+}
+
+func (r *fakeResolver) OnFIBChange(f func()) {
+	r.listener = f
+}
+
+// set changes the reachability of addr and notifies the listener.
+func (r *fakeResolver) set(addr netip.Addr, reachable bool) {
+	r.mu.Lock()
+	r.reachable[addr] = reachable
+	r.mu.Unlock()
+	r.listener()
+}
+
+func TestNexthopTracker(t *testing.T) {
+	nh1 := netip.MustParseAddr("192.0.2.1")
+	nh2 := netip.MustParseAddr("2001:db8::1")
+	resolver := &fakeResolver{reachable: map[netip.Addr]bool{nh1: true}}
+	changes := 0
+	tracker := newNexthopTracker(resolver, func() { changes++ })
+
+	steps := []struct {
+		desc            string
+		do              func()
+		wantUnreachable []string
+		wantChanges     int
+	}{{
+		desc:        "add-reachable",
+		do:          func() { tracker.add(nh1) },
+		wantChanges: 0,
+	}, {
+		desc:            "add-unreachable",
+		do:              func() { tracker.add(nh2, nh1) },
+		wantUnreachable: []string{"2001:db8::1"},
+		wantChanges:     1,
+	}, {
+		desc:            "add-already-tracked",
+		do:              func() { tracker.add(nh2) },
+		wantUnreachable: []string{"2001:db8::1"},
+		wantChanges:     1,
+	}, {
+		desc:        "becomes-reachable",
+		do:          func() { resolver.set(nh2, true) },
+		wantChanges: 2,
+	}, {
+		desc:        "fib-change-without-reachability-change",
+		do:          func() { resolver.set(netip.MustParseAddr("198.51.100.1"), true) },
+		wantChanges: 2,
+	}, {
+		desc:            "becomes-unreachable",
+		do:              func() { resolver.set(nh1, false) },
+		wantUnreachable: []string{"192.0.2.1"},
+		wantChanges:     3,
+	}}
+
+	for _, step := range steps {
+		step.do()
+		if diff := cmp.Diff(step.wantUnreachable, tracker.unreachable()); diff != "" {
+			t.Errorf("%s: unreachable next hops (-want, +got):\n%s", step.desc, diff)
+		}
+		if changes != step.wantChanges {
+			t.Errorf("%s: got %d changes, want %d", step.desc, changes, step.wantChanges)
+		}
+	}
+}
+
+func TestRejectUnreachableNexthops(t *testing.T) {
+	tests := []struct {
+		desc          string
+		inUnreachable []string
+		wantConfig    *gobgpoc.BgpConfigSet
+	}{{
+		desc: "none-unreachable",
+		wantConfig: &gobgpoc.BgpConfigSet{
+			Global: gobgpoc.Global{
+				ApplyPolicy: gobgpoc.ApplyPolicy{
+					Config: gobgpoc.ApplyPolicyConfig{
+						ImportPolicyList: []string{"neigh-import"},
+					},
+				},
+			},
+		},
+	}, {
+		desc:          "unreachable",
+		inUnreachable: []string{"192.0.2.1", "2001:db8::1"},
+		wantConfig: &gobgpoc.BgpConfigSet{
+			Global: gobgpoc.Global{
+				ApplyPolicy: gobgpoc.ApplyPolicy{
+					Config: gobgpoc.ApplyPolicyConfig{
+						ImportPolicyList: []string{unreachableNexthopPolicy, "neigh-import"},
+					},
+				},
+			},
+			PolicyDefinitions: []gobgpoc.PolicyDefinition{{
+				Name: unreachableNexthopPolicy,
+				Statements: []gobgpoc.Statement{{
+					Name: unreachableNexthopPolicy,
+					Conditions: gobgpoc.Conditions{
+						BgpConditions: gobgpoc.BgpConditions{
+							NextHopInList: []string{"192.0.2.1", "2001:db8::1"},
+						},
+					},
+					Actions: gobgpoc.Actions{
+						RouteDisposition: gobgpoc.ROUTE_DISPOSITION_REJECT_ROUTE,
+					},
+				}},
+			}},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := &gobgpoc.BgpConfigSet{}
+			got.Global.ApplyPolicy.Config.ImportPolicyList = []string{"neigh-import"}
+			rejectUnreachableNexthops(got, tt.inUnreachable)
+			if diff := cmp.Diff(tt.wantConfig, got); diff != "" {
+				t.Errorf("(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
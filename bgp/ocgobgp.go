@@ -25,6 +25,7 @@ import (
 	"github.com/openconfig/lemming/internal/lemmingutil"
 	api "github.com/osrg/gobgp/v3/api"
 	gobgpoc "github.com/osrg/gobgp/v3/pkg/config/oc"
+	"github.com/osrg/gobgp/v3/pkg/packet/bgp"
 )
 
 // convertPolicyName converts from OC policy name to a neighbour-qualified
@@ -72,7 +73,7 @@ func convertSetCommunities(setCommunity *oc.RoutingPolicy_PolicyDefinition_State
 // It adds neighbour set to disambiguate it from another instance of the policy
 // for another neighbour. This is necessary since all policies will go into a
 // single apply-policy list.
-func convertPolicyDefinition(policy *oc.RoutingPolicy_PolicyDefinition, neighAddr string, occommset map[string]*oc.RoutingPolicy_DefinedSets_BgpDefinedSets_CommunitySet, convertedCommSets []gobgpoc.CommunitySet, commSetIndexMap map[string]int) gobgpoc.PolicyDefinition {
+func convertPolicyDefinition(policy *oc.RoutingPolicy_PolicyDefinition, neighAddr string, ocprefixsets map[string]*oc.RoutingPolicy_DefinedSets_PrefixSet, occommset map[string]*oc.RoutingPolicy_DefinedSets_BgpDefinedSets_CommunitySet, convertedCommSets []gobgpoc.CommunitySet, commSetIndexMap map[string]int) gobgpoc.PolicyDefinition {
 	convertedPolicyName := convertPolicyName(neighAddr, policy.GetName())
 	var statements []gobgpoc.Statement
 	for _, statement := range policy.Statement.Values() {
@@ -80,11 +81,20 @@ func convertPolicyDefinition(policy *oc.RoutingPolicy_PolicyDefinition, neighAdd
 		if err != nil {
 			log.Error(err)
 		}
+		var setExtCommunity gobgpoc.SetExtCommunity
+		setExtCommunitiesList, err := convertSetExtCommunities(statement.GetActions().GetBgpActions().GetSetExtCommunity())
+		if err != nil {
+			log.Errorf("Extended community not supported: %v", err)
+		}
+		if len(setExtCommunitiesList) > 0 {
+			setExtCommunity.SetExtCommunityMethod.CommunitiesList = setExtCommunitiesList
+			setExtCommunity.Options = strings.ToLower(statement.GetActions().GetBgpActions().GetSetExtCommunity().GetOptions().String())
+		}
 		setmed, err := convertMED(statement.GetActions().GetBgpActions().GetSetMed())
 		if err != nil {
 			log.Errorf("MED value not supported: %v", err)
 		}
-		statements = append(statements, gobgpoc.Statement{
+		stmt := gobgpoc.Statement{
 			// In GoBGP, statements must have globally-unique names.
 			// Ensure uniqueness by qualifying each one with the name of the converted policy.
 			Name: convertedPolicyName + ":" + statement.GetName(),
@@ -110,7 +120,7 @@ func convertPolicyDefinition(policy *oc.RoutingPolicy_PolicyDefinition, neighAdd
 						AsPathSet:       statement.Conditions.GetBgpConditions().GetMatchAsPathSet().GetAsPathSet(),
 						MatchSetOptions: convertMatchSetOptionsType(statement.GetConditions().GetBgpConditions().GetMatchAsPathSet().GetMatchSetOptions()),
 					},
-					RouteType: convertRouteType(statement.GetConditions().GetBgpConditions().GetRouteType()),
+					RouteType: convertRouteType(statement.GetConditions()),
 				},
 			},
 			Actions: gobgpoc.Actions{
@@ -122,15 +132,17 @@ func convertPolicyDefinition(policy *oc.RoutingPolicy_PolicyDefinition, neighAdd
 						},
 						Options: strings.ToLower(statement.GetActions().GetBgpActions().GetSetCommunity().GetOptions().String()),
 					},
-					SetLocalPref: statement.GetActions().GetBgpActions().GetSetLocalPref(),
-					SetMed:       gobgpoc.BgpSetMedType(setmed),
+					SetExtCommunity: setExtCommunity,
+					SetLocalPref:    statement.GetActions().GetBgpActions().GetSetLocalPref(),
+					SetMed:          gobgpoc.BgpSetMedType(setmed),
 					SetAsPathPrepend: gobgpoc.SetAsPathPrepend{
 						RepeatN: statement.GetActions().GetBgpActions().GetSetAsPathPrepend().GetRepeatN(),
 						As:      strconv.FormatUint(uint64(statement.GetActions().GetBgpActions().GetSetAsPathPrepend().GetAsn()), 10),
 					},
 				},
 			},
-		})
+		}
+		statements = append(statements, splitMixedPrefixSetStatement(stmt, ocprefixsets[statement.GetConditions().GetMatchPrefixSet().GetPrefixSet()])...)
 	}
 
 	return gobgpoc.PolicyDefinition{
@@ -139,17 +151,151 @@ func convertPolicyDefinition(policy *oc.RoutingPolicy_PolicyDefinition, neighAdd
 	}
 }
 
-func convertNeighborApplyPolicy(neigh *oc.NetworkInstance_Protocol_Bgp_Neighbor) gobgpoc.ApplyPolicy {
+// mixedPrefixSetFamilies are the families that the prefixes of a mixed prefix set are split into.
+var mixedPrefixSetFamilies = []gobgpoc.AfiSafiType{gobgpoc.AFI_SAFI_TYPE_IPV4_UNICAST, gobgpoc.AFI_SAFI_TYPE_IPV6_UNICAST}
+
+// mixedPrefixSetName returns the name of the GoBGP prefix set holding the
+// prefixes of the given family of a mixed prefix set.
+func mixedPrefixSetName(prefixSetName string, family gobgpoc.AfiSafiType) string {
+	return prefixSetName + ":" + string(family)
+}
+
+// prefixFamily returns the family of an IP prefix.
+func prefixFamily(prefix string) gobgpoc.AfiSafiType {
+	if strings.Contains(prefix, ":") {
+		return gobgpoc.AFI_SAFI_TYPE_IPV6_UNICAST
+	}
+	return gobgpoc.AFI_SAFI_TYPE_IPV4_UNICAST
+}
+
+// splitMixedPrefixSetStatement returns the GoBGP statements for a statement
+// matching the given prefix set.
+//
+// GoBGP prefix sets only hold prefixes of a single family, so a statement
+// matching a mixed prefix set is split into a statement per family, each
+// matching the family's prefix set and only applying to routes of the family.
+// If the set has no prefixes of a family, then all routes of that family match
+// an inverted condition and none match otherwise.
+func splitMixedPrefixSetStatement(stmt gobgpoc.Statement, prefixSet *oc.RoutingPolicy_DefinedSets_PrefixSet) []gobgpoc.Statement {
+	if prefixSet.GetMode() != oc.PrefixSet_Mode_MIXED {
+		return []gobgpoc.Statement{stmt}
+	}
+	families := map[gobgpoc.AfiSafiType]bool{}
+	for _, prefix := range prefixSet.Prefix {
+		families[prefixFamily(prefix.GetIpPrefix())] = true
+	}
+	var statements []gobgpoc.Statement
+	for _, family := range mixedPrefixSetFamilies {
+		s := stmt
+		s.Name = stmt.Name + ":" + string(family)
+		s.Conditions.BgpConditions.AfiSafiInList = []gobgpoc.AfiSafiType{family}
+		switch {
+		case families[family]:
+			s.Conditions.MatchPrefixSet.PrefixSet = mixedPrefixSetName(prefixSet.GetName(), family)
+		case stmt.Conditions.MatchPrefixSet.MatchSetOptions == gobgpoc.MATCH_SET_OPTIONS_RESTRICTED_TYPE_INVERT:
+			s.Conditions.MatchPrefixSet = gobgpoc.MatchPrefixSet{}
+		default:
+			continue
+		}
+		statements = append(statements, s)
+	}
+	return statements
+}
+
+// convertNeighborApplyPolicy converts the neighbour's apply-policy, inheriting
+// each leaf that the neighbour doesn't set from its peer-group.
+func convertNeighborApplyPolicy(neigh *oc.NetworkInstance_Protocol_Bgp_Neighbor, pg *oc.NetworkInstance_Protocol_Bgp_PeerGroup) gobgpoc.ApplyPolicy {
+	applyPolicy, pgApplyPolicy := neigh.GetApplyPolicy(), pg.GetApplyPolicy()
+	if applyPolicy == nil {
+		applyPolicy = &oc.NetworkInstance_Protocol_Bgp_Neighbor_ApplyPolicy{}
+	}
+
+	defaultImport, defaultExport := defaultPolicies(neigh, pg)
+	importPolicy := applyPolicy.GetImportPolicy()
+	if len(importPolicy) == 0 {
+		importPolicy = pgApplyPolicy.GetImportPolicy()
+	}
+	exportPolicy := applyPolicy.GetExportPolicy()
+	if len(exportPolicy) == 0 {
+		exportPolicy = pgApplyPolicy.GetExportPolicy()
+	}
+
 	return gobgpoc.ApplyPolicy{
 		Config: gobgpoc.ApplyPolicyConfig{
-			DefaultImportPolicy: convertDefaultPolicy(neigh.GetApplyPolicy().GetDefaultImportPolicy()),
-			DefaultExportPolicy: convertDefaultPolicy(neigh.GetApplyPolicy().GetDefaultExportPolicy()),
-			ImportPolicyList:    neigh.GetApplyPolicy().GetImportPolicy(),
-			ExportPolicyList:    neigh.GetApplyPolicy().GetExportPolicy(),
+			DefaultImportPolicy: convertDefaultPolicy(defaultImport),
+			DefaultExportPolicy: convertDefaultPolicy(defaultExport),
+			ImportPolicyList:    importPolicy,
+			ExportPolicyList:    exportPolicy,
 		},
 	}
 }
 
+// defaultPolicies returns the default import and export policies applied to
+// the neighbour's routes that no statement accepts or rejects: the
+// neighbour's own, else its peer-group's, else the YANG default of
+// REJECT_ROUTE.
+func defaultPolicies(neigh *oc.NetworkInstance_Protocol_Bgp_Neighbor, pg *oc.NetworkInstance_Protocol_Bgp_PeerGroup) (oc.E_RoutingPolicy_DefaultPolicyType, oc.E_RoutingPolicy_DefaultPolicyType) {
+	// The getters return the YANG default when unset, so check the leaves
+	// directly to tell whether the neighbour overrides its peer-group.
+	var defaultImport, defaultExport oc.E_RoutingPolicy_DefaultPolicyType
+	if applyPolicy := neigh.GetApplyPolicy(); applyPolicy != nil {
+		defaultImport, defaultExport = applyPolicy.DefaultImportPolicy, applyPolicy.DefaultExportPolicy
+	}
+	if defaultImport == oc.RoutingPolicy_DefaultPolicyType_UNSET {
+		defaultImport = pg.GetApplyPolicy().GetDefaultImportPolicy()
+	}
+	if defaultExport == oc.RoutingPolicy_DefaultPolicyType_UNSET {
+		defaultExport = pg.GetApplyPolicy().GetDefaultExportPolicy()
+	}
+	return defaultImport, defaultExport
+}
+
+// ocAfiSafi is the AFI-SAFI config of a neighbour or peer-group, whose
+// graceful restart config has type G.
+type ocAfiSafi[G interface{ GetEnabled() bool }] interface {
+	GetEnabled() bool
+	GetGracefulRestart() G
+}
+
+// convertAfiSafis converts the enabled AFI-SAFIs to their GoBGP
+// representation.
+func convertAfiSafis[T ocAfiSafi[G], G interface{ GetEnabled() bool }](ocafisafis map[oc.E_BgpTypes_AFI_SAFI_TYPE]T) []gobgpoc.AfiSafi {
+	var afiSafis []gobgpoc.AfiSafi
+	names := lemmingutil.Mapkeys(ocafisafis)
+	slices.Sort(names)
+	for _, name := range names {
+		if !ocafisafis[name].GetEnabled() {
+			continue
+		}
+		var afiSafiName gobgpoc.AfiSafiType
+		switch name {
+		case oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST:
+			afiSafiName = gobgpoc.AFI_SAFI_TYPE_IPV4_UNICAST
+		case oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST:
+			afiSafiName = gobgpoc.AFI_SAFI_TYPE_IPV6_UNICAST
+		default:
+			log.Warningf("Unsupported AFI-SAFI: %v", name)
+			continue
+		}
+		afiSafis = append(afiSafis, gobgpoc.AfiSafi{
+			Config: gobgpoc.AfiSafiConfig{
+				AfiSafiName: afiSafiName,
+				Enabled:     true,
+			},
+			State: gobgpoc.AfiSafiState{
+				AfiSafiName: afiSafiName,
+				Enabled:     true,
+			},
+			MpGracefulRestart: gobgpoc.MpGracefulRestart{
+				Config: gobgpoc.MpGracefulRestartConfig{
+					Enabled: ocafisafis[name].GetGracefulRestart().GetEnabled(),
+				},
+			},
+		})
+	}
+	return afiSafis
+}
+
 // TODO(wenbli): Add unit tests for these conversion functions.
 
 func convertDefaultPolicy(ocpolicy oc.E_RoutingPolicy_DefaultPolicyType) gobgpoc.DefaultPolicyType {
@@ -222,7 +368,26 @@ func convertAttributeComparison(ocpolicy oc.E_PolicyTypes_ATTRIBUTE_COMPARISON)
 	}
 }
 
-func convertRouteType(rt oc.E_BgpConditions_RouteType) gobgpoc.RouteType {
+// convertRouteType converts the statement's conditions on the source of the
+// route: whether it was learned over iBGP or eBGP, or originated locally.
+//
+// OC's route-type only distinguishes internal and external routes, so locally
+// originated routes are matched with install-protocol-eq instead: BGP only
+// originates routes redistributed from other protocols, so a route installed
+// by any protocol other than BGP is locally originated. GoBGP doesn't know
+// which protocol installed a local route, so any such protocol matches all
+// locally originated routes.
+func convertRouteType(conditions *oc.RoutingPolicy_PolicyDefinition_Statement_Conditions) gobgpoc.RouteType {
+	rt, installProtocol := conditions.GetBgpConditions().GetRouteType(), conditions.GetInstallProtocolEq()
+	switch {
+	case installProtocol == oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_UNSET:
+	case installProtocol == oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP:
+		log.Errorf("install-protocol-eq %v not supported, use route-type instead", installProtocol)
+	case rt != oc.BgpConditions_RouteType_UNSET:
+		log.Errorf("route-type %v can't be matched along with install-protocol-eq %v, ignoring install-protocol-eq", rt, installProtocol)
+	default:
+		return gobgpoc.ROUTE_TYPE_LOCAL
+	}
 	switch rt {
 	case oc.BgpConditions_RouteType_EXTERNAL:
 		return gobgpoc.ROUTE_TYPE_EXTERNAL
@@ -292,32 +457,128 @@ func communitiesToOC(communities []uint32) []oc.NetworkInstance_Protocol_Bgp_Rib
 	return occomms
 }
 
+// extCommunitiesToOC converts the GoBGP extended communities attribute to the
+// OC string representation of each route target and route origin.
+func extCommunitiesToOC(attr *api.ExtendedCommunitiesAttribute) ([]string, error) {
+	var occomms []string
+	for _, comm := range attr.GetCommunities() {
+		m, err := comm.UnmarshalNew()
+		if err != nil {
+			return nil, err
+		}
+		var subType uint32
+		var value string
+		switch c := m.(type) {
+		case *api.TwoOctetAsSpecificExtended:
+			subType, value = c.GetSubType(), fmt.Sprintf("%d:%d", c.GetAsn(), c.GetLocalAdmin())
+		case *api.FourOctetAsSpecificExtended:
+			subType, value = c.GetSubType(), fmt.Sprintf("%d:%d", c.GetAsn(), c.GetLocalAdmin())
+		case *api.IPv4AddressSpecificExtended:
+			subType, value = c.GetSubType(), fmt.Sprintf("%s:%d", c.GetAddress(), c.GetLocalAdmin())
+		default:
+			return nil, fmt.Errorf("unsupported extended community type: %T", m)
+		}
+		switch bgp.ExtendedCommunityAttrSubType(subType) {
+		case bgp.EC_SUBTYPE_ROUTE_TARGET:
+			occomms = append(occomms, "route-target:"+value)
+		case bgp.EC_SUBTYPE_ROUTE_ORIGIN:
+			occomms = append(occomms, "route-origin:"+value)
+		default:
+			return nil, fmt.Errorf("unsupported extended community subtype: %d", subType)
+		}
+	}
+	return occomms, nil
+}
+
+// convertExtCommunity converts an OC extended community to its string representation to be used in GoBGP.
+func convertExtCommunity(community any) (string, error) {
+	c, ok := community.(oc.UnionString)
+	if !ok {
+		return "", fmt.Errorf("unsupported extended community type: %T", community)
+	}
+	switch typ, value, _ := strings.Cut(string(c), ":"); typ {
+	case "route-target":
+		return "rt:" + value, nil
+	case "route-origin":
+		return "soo:" + value, nil
+	default:
+		return "", fmt.Errorf("unsupported extended community: %q", c)
+	}
+}
+
+// convertSetExtCommunities converts the inline extended communities of the set-ext-community action.
+func convertSetExtCommunities(setExtCommunity *oc.RoutingPolicy_PolicyDefinition_Statement_Actions_BgpActions_SetExtCommunity) ([]string, error) {
+	if setExtCommunity.GetMethod() != oc.SetCommunity_Method_INLINE {
+		return nil, nil
+	}
+	var comms []string
+	for _, comm := range setExtCommunity.GetInline().GetCommunities() {
+		c, err := convertExtCommunity(comm)
+		if err != nil {
+			return nil, err
+		}
+		comms = append(comms, c)
+	}
+	return comms, nil
+}
+
+// convertPrefixSets converts OC prefix sets to GoBGP prefix sets. Mixed prefix
+// sets are converted to a prefix set per family, see splitMixedPrefixSetStatement.
 func convertPrefixSets(ocprefixsets map[string]*oc.RoutingPolicy_DefinedSets_PrefixSet) []gobgpoc.PrefixSet {
 	var prefixSets []gobgpoc.PrefixSet
 	prefixSetNames := lemmingutil.Mapkeys(ocprefixsets)
 	slices.Sort(prefixSetNames)
 	for _, prefixSetName := range prefixSetNames {
-		var prefixList []gobgpoc.Prefix
-		for _, prefix := range ocprefixsets[prefixSetName].Prefix {
-			r := prefix.GetMasklengthRange()
-			if r == "exact" {
-				// GoBGP recognizes "" instead of "exact"
-				r = ""
-			}
-			prefixList = append(prefixList, gobgpoc.Prefix{
-				IpPrefix:        prefix.GetIpPrefix(),
-				MasklengthRange: r,
-			})
+		if ocprefixsets[prefixSetName].GetMode() == oc.PrefixSet_Mode_MIXED {
+			prefixSets = append(prefixSets, convertMixedPrefixSet(prefixSetName, ocprefixsets[prefixSetName])...)
+			continue
 		}
-
 		prefixSets = append(prefixSets, gobgpoc.PrefixSet{
 			PrefixSetName: prefixSetName,
-			PrefixList:    prefixList,
+			PrefixList:    convertPrefixes(ocprefixsets[prefixSetName].Prefix),
 		})
 	}
 	return prefixSets
 }
 
+// convertMixedPrefixSet converts a mixed OC prefix set to a GoBGP prefix set
+// for each family it has prefixes of.
+func convertMixedPrefixSet(prefixSetName string, ocprefixset *oc.RoutingPolicy_DefinedSets_PrefixSet) []gobgpoc.PrefixSet {
+	var prefixSets []gobgpoc.PrefixSet
+	for _, family := range mixedPrefixSetFamilies {
+		prefixes := map[oc.RoutingPolicy_DefinedSets_PrefixSet_Prefix_Key]*oc.RoutingPolicy_DefinedSets_PrefixSet_Prefix{}
+		for key, prefix := range ocprefixset.Prefix {
+			if prefixFamily(prefix.GetIpPrefix()) == family {
+				prefixes[key] = prefix
+			}
+		}
+		if len(prefixes) == 0 {
+			continue
+		}
+		prefixSets = append(prefixSets, gobgpoc.PrefixSet{
+			PrefixSetName: mixedPrefixSetName(prefixSetName, family),
+			PrefixList:    convertPrefixes(prefixes),
+		})
+	}
+	return prefixSets
+}
+
+func convertPrefixes(ocprefixes map[oc.RoutingPolicy_DefinedSets_PrefixSet_Prefix_Key]*oc.RoutingPolicy_DefinedSets_PrefixSet_Prefix) []gobgpoc.Prefix {
+	var prefixList []gobgpoc.Prefix
+	for _, prefix := range ocprefixes {
+		r := prefix.GetMasklengthRange()
+		if r == "exact" {
+			// GoBGP recognizes "" instead of "exact"
+			r = ""
+		}
+		prefixList = append(prefixList, gobgpoc.Prefix{
+			IpPrefix:        prefix.GetIpPrefix(),
+			MasklengthRange: r,
+		})
+	}
+	return prefixList
+}
+
 func convertASPathSets(ocpathset map[string]*oc.RoutingPolicy_DefinedSets_BgpDefinedSets_AsPathSet) []gobgpoc.AsPathSet {
 	var pathsets []gobgpoc.AsPathSet
 	for pathsetName, pathset := range ocpathset {
@@ -0,0 +1,118 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"context"
+	"time"
+
+	log "github.com/golang/glog"
+
+	"github.com/openconfig/lemming/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+
+	api "github.com/osrg/gobgp/v3/api"
+	gobgplog "github.com/osrg/gobgp/v3/pkg/log"
+	"github.com/osrg/gobgp/v3/pkg/packet/bgp"
+)
+
+// applyAdminState administratively shuts down the neighbours that are
+// disabled, and restarts the ones that are enabled again.
+//
+// OpenConfig doesn't model the shutdown communication of RFC 8203, so the
+// description of the neighbour is sent as the message of the NOTIFICATION.
+func (t *bgpTask) applyAdminState(ctx context.Context, intendedBGP *oc.NetworkInstance_Protocol_Bgp) {
+	if t.disabled == nil {
+		t.disabled = map[string]bool{}
+	}
+	for addr := range t.disabled {
+		if _, ok := intendedBGP.Neighbor[addr]; !ok {
+			delete(t.disabled, addr)
+		}
+	}
+	for addr, neigh := range intendedBGP.Neighbor {
+		disabled := !neigh.GetEnabled()
+		if disabled == t.disabled[addr] {
+			continue
+		}
+		var err error
+		if disabled {
+			log.V(1).Infof("Shutting down neighbor %s: %q", addr, neigh.GetDescription())
+			err = t.bgpServer.DisablePeer(ctx, &api.DisablePeerRequest{Address: addr, Communication: neigh.GetDescription()})
+		} else {
+			log.V(1).Infof("Enabling neighbor %s", addr)
+			err = t.bgpServer.EnablePeer(ctx, &api.EnablePeerRequest{Address: addr})
+		}
+		if err != nil {
+			log.Errorf("Failed to change admin state of neighbor %s: %v", addr, err)
+			continue
+		}
+		if disabled {
+			t.disabled[addr] = true
+		} else {
+			delete(t.disabled, addr)
+		}
+	}
+}
+
+// recordShutdown records an administrative shutdown or reset received from a
+// neighbour in its state.
+//
+// OpenConfig doesn't model the shutdown communication of RFC 8203, so it is
+// only logged.
+func (t *bgpTask) recordShutdown(ctx context.Context, neighAddr string, subcode uint8, communication string) {
+	log.Warningf("Neighbor %s shut down the session: %q", neighAddr, communication)
+	ocSubcode := oc.BgpTypes_BGP_ERROR_SUBCODE_ADMINISTRATIVE_SHUTDOWN
+	if subcode == bgp.BGP_ERROR_SUB_ADMINISTRATIVE_RESET {
+		ocSubcode = oc.BgpTypes_BGP_ERROR_SUBCODE_ADMINISTRATIVE_RESET
+	}
+	t.updateAppliedState(ctx, func() error {
+		received := t.appliedBGP.GetOrCreateNeighbor(neighAddr).GetOrCreateMessages().GetOrCreateReceived()
+		received.LastNotificationErrorCode = oc.BgpTypes_BGP_ERROR_CODE_CEASE
+		received.LastNotificationErrorSubcode = ocSubcode
+		received.LastNotificationTime = ygot.Uint64(uint64(time.Now().UnixNano()))
+		return nil
+	})
+}
+
+// shutdownLogger passes GoBGP's logs to another logger, and reports the
+// administrative shutdowns and resets received from neighbours.
+//
+// GoBGP doesn't expose the NOTIFICATIONs it receives other than by logging
+// them.
+type shutdownLogger struct {
+	gobgplog.Logger
+	// onShutdown is called with the address of the neighbour, the error
+	// subcode and the communicated message.
+	onShutdown func(neighAddr string, subcode uint8, communication string)
+}
+
+// Warn logs the message and reports it if it is a received shutdown.
+func (l *shutdownLogger) Warn(msg string, fields gobgplog.Fields) {
+	l.Logger.Warn(msg, fields)
+	if msg != "received notification" {
+		return
+	}
+	if code, _ := fields["Code"].(uint8); code != bgp.BGP_ERROR_CEASE {
+		return
+	}
+	subcode, _ := fields["Subcode"].(uint8)
+	if subcode != bgp.BGP_ERROR_SUB_ADMINISTRATIVE_SHUTDOWN && subcode != bgp.BGP_ERROR_SUB_ADMINISTRATIVE_RESET {
+		return
+	}
+	neighAddr, _ := fields["Key"].(string)
+	communication, _ := fields["Communicated-Reason"].(string)
+	l.onShutdown(neighAddr, subcode, communication)
+}
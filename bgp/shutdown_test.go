@@ -0,0 +1,100 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	gobgplog "github.com/osrg/gobgp/v3/pkg/log"
+	"github.com/osrg/gobgp/v3/pkg/packet/bgp"
+)
+
+func TestShutdownLogger(t *testing.T) {
+	type shutdown struct {
+		NeighAddr     string
+		Subcode       uint8
+		Communication string
+	}
+	tests := []struct {
+		desc     string
+		inMsg    string
+		inFields gobgplog.Fields
+		want     []shutdown
+	}{{
+		desc:  "shutdown",
+		inMsg: "received notification",
+		inFields: gobgplog.Fields{
+			"Key":                 "192.0.2.1",
+			"Code":                uint8(bgp.BGP_ERROR_CEASE),
+			"Subcode":             uint8(bgp.BGP_ERROR_SUB_ADMINISTRATIVE_SHUTDOWN),
+			"Communicated-Reason": "planned maintenance",
+		},
+		want: []shutdown{{NeighAddr: "192.0.2.1", Subcode: bgp.BGP_ERROR_SUB_ADMINISTRATIVE_SHUTDOWN, Communication: "planned maintenance"}},
+	}, {
+		desc:  "reset-without-communication",
+		inMsg: "received notification",
+		inFields: gobgplog.Fields{
+			"Key":                 "2001:db8::1",
+			"Code":                uint8(bgp.BGP_ERROR_CEASE),
+			"Subcode":             uint8(bgp.BGP_ERROR_SUB_ADMINISTRATIVE_RESET),
+			"Communicated-Reason": "",
+		},
+		want: []shutdown{{NeighAddr: "2001:db8::1", Subcode: bgp.BGP_ERROR_SUB_ADMINISTRATIVE_RESET}},
+	}, {
+		desc:  "other-cease",
+		inMsg: "received notification",
+		inFields: gobgplog.Fields{
+			"Key":     "192.0.2.1",
+			"Code":    uint8(bgp.BGP_ERROR_CEASE),
+			"Subcode": uint8(bgp.BGP_ERROR_SUB_PEER_DECONFIGURED),
+		},
+	}, {
+		desc:  "hold-timer-expired",
+		inMsg: "received notification",
+		inFields: gobgplog.Fields{
+			"Key":     "192.0.2.1",
+			"Code":    uint8(bgp.BGP_ERROR_HOLD_TIMER_EXPIRED),
+			"Subcode": uint8(0),
+		},
+	}, {
+		desc:  "sent",
+		inMsg: "sent notification",
+		inFields: gobgplog.Fields{
+			"Key":                 "192.0.2.1",
+			"Code":                uint8(bgp.BGP_ERROR_CEASE),
+			"Subcode":             uint8(bgp.BGP_ERROR_SUB_ADMINISTRATIVE_SHUTDOWN),
+			"Communicated-Reason": "planned maintenance",
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			logger := gobgplog.NewDefaultLogger()
+			logger.SetLevel(gobgplog.PanicLevel)
+			var got []shutdown
+			l := &shutdownLogger{
+				Logger: logger,
+				onShutdown: func(neighAddr string, subcode uint8, communication string) {
+					got = append(got, shutdown{NeighAddr: neighAddr, Subcode: subcode, Communication: communication})
+				},
+			}
+			l.Warn(tt.inMsg, tt.inFields)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("reported shutdowns (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local_test
+
+import (
+	"testing"
+
+	"github.com/openconfig/lemming/bgp"
+	"github.com/openconfig/lemming/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestEnableAfiSafi(t *testing.T) {
+	dut1, stop1 := newLemming(t, 1, 64500, []*AddIntfAction{{
+		name:    "eth1",
+		ifindex: 1,
+		enabled: true,
+		prefix:  "192.0.2.1/31",
+		niName:  "DEFAULT",
+	}, {
+		name:    "eth2",
+		ifindex: 2,
+		enabled: true,
+		prefix:  "2001:db8::1/127",
+		niName:  "DEFAULT",
+	}})
+	defer stop1()
+	dut2, stop2 := newLemming(t, 2, 64501, nil)
+	defer stop2()
+
+	staticRoute := func(prefix, nexthop string) *oc.NetworkInstance_Protocol_Static {
+		return &oc.NetworkInstance_Protocol_Static{
+			Prefix: ygot.String(prefix),
+			NextHop: map[string]*oc.NetworkInstance_Protocol_Static_NextHop{
+				"single": {
+					Index:   ygot.String("single"),
+					NextHop: oc.UnionString(nexthop),
+					Recurse: ygot.Bool(true),
+				},
+			},
+		}
+	}
+	v4Prefix, v6Prefix := "10.1.0.0/24", "2001:db8:1::/64"
+	installStaticRoute(t, dut1, staticRoute(v4Prefix, "192.0.2.0"))
+	installStaticRoute(t, dut1, staticRoute(v6Prefix, "2001:db8::"))
+
+	for _, pair := range [][2]*Device{{dut1, dut2}, {dut2, dut1}} {
+		neigh := bgp.BGPPath.Neighbor(pair[1].RouterID)
+		Replace(t, pair[0], neigh.ApplyPolicy().DefaultExportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+		Replace(t, pair[0], neigh.ApplyPolicy().DefaultImportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+		Replace(t, pair[0], neigh.AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Enabled().Config(), true)
+	}
+	establishSessionPairs(t, DevicePair{dut1, dut2})
+
+	v4uni := bgp.BGPPath.Rib().AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Ipv4Unicast()
+	v6uni := bgp.BGPPath.Rib().AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST).Ipv6Unicast()
+	Await(t, dut2, v4uni.LocRib().Route(v4Prefix, oc.UnionString(dut1.RouterID), 0).Prefix().State(), v4Prefix)
+	for _, pair := range [][2]*Device{{dut1, dut2}, {dut2, dut1}} {
+		neigh := bgp.BGPPath.Neighbor(pair[1].RouterID)
+		Await(t, pair[0], neigh.AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Active().State(), true)
+	}
+	awaitNotPresent(t, dut2, v6uni.LocRib().Route(v6Prefix, oc.UnionString(dut1.RouterID), 0).Prefix().State())
+
+	// Enabling IPv6 unicast on both ends renegotiates the session with the
+	// new capability, after which IPv6 routes are exchanged.
+	for _, pair := range [][2]*Device{{dut1, dut2}, {dut2, dut1}} {
+		neigh := bgp.BGPPath.Neighbor(pair[1].RouterID)
+		Replace(t, pair[0], neigh.AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST).Enabled().Config(), true)
+	}
+	awaitSessionEstablished(t, dut1, dut2)
+	for _, pair := range [][2]*Device{{dut1, dut2}, {dut2, dut1}} {
+		neigh := bgp.BGPPath.Neighbor(pair[1].RouterID)
+		Await(t, pair[0], neigh.AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST).Active().State(), true)
+	}
+	Await(t, dut2, v6uni.LocRib().Route(v6Prefix, oc.UnionString(dut1.RouterID), 0).Prefix().State(), v6Prefix)
+	Await(t, dut2, v4uni.LocRib().Route(v4Prefix, oc.UnionString(dut1.RouterID), 0).Prefix().State(), v4Prefix)
+}
@@ -521,3 +521,132 @@ func configureSetCommunityPolicy(t *testing.T, i int, dut *Device, stmt *oc.Rout
 		stmt.GetOrCreateActions().GetOrCreateBgpActions().GetOrCreateSetCommunity().SetMethod(oc.SetCommunity_Method_INLINE)
 	}
 }
+
+func TestImportAddCommunities(t *testing.T) {
+	route := "40.0.0.0/16"
+
+	installPolicy := func(t *testing.T, dut1, dut2, _, _, _ *Device) {
+		policyName := "add-communities"
+		prefixSetName := "accept-" + route
+		prefixSetPath := ocpath.Root().RoutingPolicy().DefinedSets().PrefixSet(prefixSetName)
+		Replace(t, dut2, prefixSetPath.Mode().Config(), oc.PrefixSet_Mode_IPV4)
+		Replace(t, dut2, prefixSetPath.Prefix(route, "exact").IpPrefix().Config(), route)
+
+		policy := &oc.RoutingPolicy_PolicyDefinition_Statement_OrderedMap{}
+		stmt, err := policy.AppendNew("add-communities")
+		if err != nil {
+			t.Fatalf("Cannot append new BGP policy statement: %v", err)
+		}
+		stmt.GetOrCreateConditions().GetOrCreateMatchPrefixSet().SetPrefixSet(prefixSetName)
+		stmt.GetOrCreateConditions().GetOrCreateMatchPrefixSet().SetMatchSetOptions(oc.PolicyTypes_MatchSetOptionsRestrictedType_ANY)
+		setComm := stmt.GetOrCreateActions().GetOrCreateBgpActions().GetOrCreateSetCommunity()
+		setComm.SetOptions(oc.BgpPolicy_BgpSetCommunityOptionType_ADD)
+		setComm.SetMethod(oc.SetCommunity_Method_INLINE)
+		setComm.GetOrCreateInline().SetCommunities([]oc.RoutingPolicy_PolicyDefinition_Statement_Actions_BgpActions_SetCommunity_Inline_Communities_Union{
+			oc.UnionString("33333:33333"),
+		})
+		setExtComm := stmt.GetOrCreateActions().GetOrCreateBgpActions().GetOrCreateSetExtCommunity()
+		setExtComm.SetOptions(oc.BgpPolicy_BgpSetCommunityOptionType_ADD)
+		setExtComm.SetMethod(oc.SetCommunity_Method_INLINE)
+		setExtComm.GetOrCreateInline().SetCommunities([]oc.RoutingPolicy_PolicyDefinition_Statement_Actions_BgpActions_SetExtCommunity_Inline_Communities_Union{
+			oc.UnionString("route-target:64500:100"),
+		})
+		stmt.GetOrCreateActions().SetPolicyResult(oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE)
+
+		Replace(t, dut2, ocpath.Root().RoutingPolicy().PolicyDefinition(policyName).Config(), &oc.RoutingPolicy_PolicyDefinition{Statement: policy})
+		Replace(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy().ImportPolicy().Config(), []string{policyName})
+		Await(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy().ImportPolicy().State(), []string{policyName})
+	}
+
+	testPolicy(t, &PolicyTestCase{
+		description: "Test that communities and extended communities added on import are visible post-policy.",
+		routeTests: []*policytest.RouteTestCase{{
+			Description: "communities-added-at-import",
+			Input: policytest.TestRoute{
+				ReachPrefix: route,
+			},
+			ExpectedResult:             policytest.RouteAccepted,
+			AdjRibInPostCommunities:    []string{"33333:33333"},
+			LocalRibCommunities:        []string{"33333:33333"},
+			AdjRibOutPreCommunities:    []string{"33333:33333"},
+			AdjRibOutPostCommunities:   []string{"33333:33333"},
+			NextAdjRibInPreCommunities: []string{"33333:33333"},
+			NextLocalRibCommunities:    []string{"33333:33333"},
+			AdjRibInPostExtCommunities: []string{"route-target:64500:100"},
+		}},
+		skipValidateAttrSet: true,
+		installPolicies:     installPolicy,
+	})
+}
+
+func TestRejectBlockedCommunity(t *testing.T) {
+	installPolicy := func(t *testing.T, dut1, dut2, _, _, _ *Device) {
+		policyName := "reject-blocked-communities"
+		commSetName := "blocked"
+		commSetPath := ocpath.Root().RoutingPolicy().DefinedSets().BgpDefinedSets().CommunitySet(commSetName)
+		Replace(t, dut2, commSetPath.CommunityMember().Config(), []oc.RoutingPolicy_DefinedSets_BgpDefinedSets_CommunitySet_CommunityMember_Union{
+			oc.UnionString("65000:666"),
+			oc.UnionString("65000:667"),
+		})
+		Replace(t, dut2, commSetPath.MatchSetOptions().Config(), oc.PolicyTypes_MatchSetOptionsType_ANY)
+
+		policy := &oc.RoutingPolicy_PolicyDefinition_Statement_OrderedMap{}
+		stmt, err := policy.AppendNew("reject-blocked")
+		if err != nil {
+			t.Fatalf("Cannot append new BGP policy statement: %v", err)
+		}
+		stmt.GetOrCreateConditions().GetOrCreateBgpConditions().SetCommunitySet(commSetName)
+		stmt.GetOrCreateActions().SetPolicyResult(oc.RoutingPolicy_PolicyResultType_REJECT_ROUTE)
+
+		Replace(t, dut2, ocpath.Root().RoutingPolicy().PolicyDefinition(policyName).Config(), &oc.RoutingPolicy_PolicyDefinition{Statement: policy})
+		Replace(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy().ImportPolicy().Config(), []string{policyName})
+		Await(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy().ImportPolicy().State(), []string{policyName})
+	}
+
+	testPolicy(t, &PolicyTestCase{
+		description: "Test that routes tagged with a blocked community are rejected on import.",
+		routeTests: []*policytest.RouteTestCase{{
+			Description: "blocked",
+			Input: policytest.TestRoute{
+				ReachPrefix: "50.1.0.0/16",
+				Communities: []string{"65000:666"},
+			},
+			ExpectedResult:               policytest.RouteDiscarded,
+			PrevAdjRibOutPostCommunities: []string{"65000:666"},
+			AdjRibInPreCommunities:       []string{"65000:666"},
+		}, {
+			Description: "blocked-among-others",
+			Input: policytest.TestRoute{
+				ReachPrefix: "50.2.0.0/16",
+				Communities: []string{"65000:100", "65000:667"},
+			},
+			ExpectedResult:               policytest.RouteDiscarded,
+			PrevAdjRibOutPostCommunities: []string{"65000:100", "65000:667"},
+			AdjRibInPreCommunities:       []string{"65000:100", "65000:667"},
+		}, {
+			Description: "not-blocked",
+			Input: policytest.TestRoute{
+				ReachPrefix: "50.3.0.0/16",
+				Communities: []string{"65000:100"},
+			},
+			ExpectedResult:               policytest.RouteAccepted,
+			PrevAdjRibOutPostCommunities: []string{"65000:100"},
+			AdjRibInPreCommunities:       []string{"65000:100"},
+			AdjRibInPostCommunities:      []string{"65000:100"},
+			LocalRibCommunities:          []string{"65000:100"},
+			AdjRibOutPreCommunities:      []string{"65000:100"},
+			AdjRibOutPostCommunities:     []string{"65000:100"},
+			NextAdjRibInPreCommunities:   []string{"65000:100"},
+			NextLocalRibCommunities:      []string{"65000:100"},
+		}, {
+			Description: "untagged",
+			Input: policytest.TestRoute{
+				ReachPrefix: "50.4.0.0/16",
+			},
+			ExpectedResult: policytest.RouteAccepted,
+		}},
+		skipValidateAttrSet: true,
+		attachCommunities:   true,
+		installPolicies:     installPolicy,
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local_test
+
+import (
+	"testing"
+
+	"github.com/openconfig/lemming"
+	"github.com/openconfig/lemming/bgp"
+	"github.com/openconfig/lemming/gnmi/fakedevice"
+	"github.com/openconfig/lemming/gnmi/oc"
+	"github.com/openconfig/lemming/gnmi/oc/ocpath"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestConditionalAdvertisement(t *testing.T) {
+	dut1, stop1 := newLemming(t, 1, 64500, []*AddIntfAction{{
+		name:    "eth1",
+		ifindex: 1,
+		enabled: true,
+		prefix:  "192.0.2.1/31",
+		niName:  "DEFAULT",
+	}})
+	defer stop1()
+	dut3, stop3 := newLemming(t, 3, 64502, nil)
+	defer stop3()
+	// dut2 only advertises the backup prefix to dut3 while it has no route
+	// to the primary prefix.
+	dut2, stop2 := newLemming(t, 2, 64501, nil, lemming.WithBGPConditionalAdvertisement(bgp.ConditionalAdvertisement{
+		Neighbor:     dut3.RouterID,
+		AdvertiseSet: "backup",
+		ConditionSet: "primary",
+		NonExist:     true,
+	}))
+	defer stop2()
+
+	primary := "10.10.10.0/24"
+	backup := "10.20.20.0/24"
+	for name, prefix := range map[string]string{"primary": primary, "backup": backup} {
+		prefixSetPath := ocpath.Root().RoutingPolicy().DefinedSets().PrefixSet(name)
+		Replace(t, dut2, prefixSetPath.Mode().Config(), oc.PrefixSet_Mode_IPV4)
+		Replace(t, dut2, prefixSetPath.Prefix(prefix, "exact").IpPrefix().Config(), prefix)
+	}
+
+	Replace(t, dut1, bgp.BGPPath.Neighbor(dut2.RouterID).ApplyPolicy().DefaultExportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Replace(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy().DefaultImportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Replace(t, dut2, bgp.BGPPath.Neighbor(dut3.RouterID).ApplyPolicy().DefaultExportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Replace(t, dut3, bgp.BGPPath.Neighbor(dut2.RouterID).ApplyPolicy().DefaultImportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+
+	establishSessionPairs(t, []DevicePair{{dut1, dut2}, {dut2, dut3}}...)
+
+	Await(t, dut1, bgp.BGPPath.Neighbor(dut2.RouterID).ApplyPolicy().DefaultExportPolicy().State(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Await(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy().DefaultImportPolicy().State(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Await(t, dut2, bgp.BGPPath.Neighbor(dut3.RouterID).ApplyPolicy().DefaultExportPolicy().State(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Await(t, dut3, bgp.BGPPath.Neighbor(dut2.RouterID).ApplyPolicy().DefaultImportPolicy().State(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+
+	installRoute := func(prefix string) {
+		installStaticRoute(t, dut1, &oc.NetworkInstance_Protocol_Static{
+			Prefix: ygot.String(prefix),
+			NextHop: map[string]*oc.NetworkInstance_Protocol_Static_NextHop{
+				"single": {
+					Index:   ygot.String("single"),
+					NextHop: oc.UnionString("192.0.2.1"),
+					Recurse: ygot.Bool(true),
+				},
+			},
+		})
+	}
+	v4uni := bgp.BGPPath.Rib().AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Ipv4Unicast()
+	dut3Route := func(prefix string) ygnmi.SingletonQuery[string] {
+		return v4uni.Neighbor(dut2.RouterID).AdjRibInPre().Route(prefix, 0).Prefix().State()
+	}
+
+	installRoute(primary)
+	installRoute(backup)
+	Await(t, dut3, dut3Route(primary), primary)
+	Await(t, dut2, v4uni.Neighbor(dut1.RouterID).AdjRibInPre().Route(backup, 0).Prefix().State(), backup)
+	awaitNotPresent(t, dut3, dut3Route(backup))
+
+	// Withdrawing the primary prefix advertises the backup prefix.
+	staticp := ocpath.Root().NetworkInstance(fakedevice.DefaultNetworkInstance).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, fakedevice.StaticRoutingProtocol)
+	Delete(t, dut1, staticp.Static(primary).Config())
+	awaitNotPresent(t, dut3, dut3Route(primary))
+	Await(t, dut3, dut3Route(backup), backup)
+
+	// The backup prefix is withdrawn again when the primary prefix returns.
+	installRoute(primary)
+	Await(t, dut3, dut3Route(primary), primary)
+	awaitNotPresent(t, dut3, dut3Route(backup))
+}
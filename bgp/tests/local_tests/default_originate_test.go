@@ -0,0 +1,135 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local_test
+
+import (
+	"testing"
+
+	"github.com/openconfig/lemming"
+	"github.com/openconfig/lemming/bgp"
+	"github.com/openconfig/lemming/gnmi/fakedevice"
+	"github.com/openconfig/lemming/gnmi/oc"
+	"github.com/openconfig/lemming/gnmi/oc/ocpath"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestDefaultOriginate(t *testing.T) {
+	dut1, stop1 := newLemming(t, 1, 64500, []*AddIntfAction{{
+		name:    "eth1",
+		ifindex: 1,
+		enabled: true,
+		prefix:  "192.0.2.1/31",
+		niName:  "DEFAULT",
+	}})
+	defer stop1()
+	dut3, stop3 := newLemming(t, 3, 64502, []*AddIntfAction{{
+		name:    "eth0",
+		ifindex: 0,
+		enabled: true,
+		prefix:  "198.51.100.0/31",
+		niName:  "DEFAULT",
+	}})
+	defer stop3()
+	// dut2 only originates the default route to dut3 while it has a route
+	// to the upstream prefix.
+	dut2, stop2 := newLemming(t, 2, 64501, nil, lemming.WithBGPConditionalAdvertisement(bgp.ConditionalAdvertisement{
+		Neighbor:     dut3.RouterID,
+		AdvertiseSet: "default",
+		ConditionSet: "upstream",
+	}))
+	defer stop2()
+
+	upstream := "10.10.10.0/24"
+	defaultRoute := "0.0.0.0/0"
+	for name, prefix := range map[string]string{"upstream": upstream, "default": defaultRoute} {
+		prefixSetPath := ocpath.Root().RoutingPolicy().DefinedSets().PrefixSet(name)
+		Replace(t, dut2, prefixSetPath.Mode().Config(), oc.PrefixSet_Mode_IPV4)
+		Replace(t, dut2, prefixSetPath.Prefix(prefix, "exact").IpPrefix().Config(), prefix)
+	}
+
+	Replace(t, dut1, bgp.BGPPath.Neighbor(dut2.RouterID).ApplyPolicy().DefaultExportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Replace(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy().DefaultImportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Replace(t, dut3, bgp.BGPPath.Neighbor(dut2.RouterID).ApplyPolicy().DefaultImportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+
+	establishSessionPairs(t, []DevicePair{{dut1, dut2}, {dut2, dut3}}...)
+
+	Await(t, dut1, bgp.BGPPath.Neighbor(dut2.RouterID).ApplyPolicy().DefaultExportPolicy().State(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Await(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy().DefaultImportPolicy().State(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Await(t, dut3, bgp.BGPPath.Neighbor(dut2.RouterID).ApplyPolicy().DefaultImportPolicy().State(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+
+	// dut3 resolves the next hop of the default route, dut2's session address.
+	installStaticRoute(t, dut3, &oc.NetworkInstance_Protocol_Static{
+		Prefix: ygot.String(dut2.RouterID + "/32"),
+		NextHop: map[string]*oc.NetworkInstance_Protocol_Static_NextHop{
+			"single": {
+				Index:   ygot.String("single"),
+				NextHop: oc.UnionString("198.51.100.1"),
+				Recurse: ygot.Bool(true),
+			},
+		},
+	})
+
+	sendDefaultRoute := bgp.BGPPath.Neighbor(dut3.RouterID).AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Ipv4Unicast().SendDefaultRoute()
+	Replace(t, dut2, bgp.BGPPath.Neighbor(dut3.RouterID).AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).AfiSafiName().Config(), oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST)
+	Replace(t, dut2, sendDefaultRoute.Config(), true)
+	Await(t, dut2, sendDefaultRoute.State(), true)
+
+	v4uni := bgp.BGPPath.Rib().AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Ipv4Unicast()
+	dut3Route := func(prefix string) ygnmi.SingletonQuery[string] {
+		return v4uni.Neighbor(dut2.RouterID).AdjRibInPre().Route(prefix, 0).Prefix().State()
+	}
+
+	// The default route isn't originated without the upstream route.
+	awaitNotPresent(t, dut3, dut3Route(defaultRoute))
+
+	installStaticRoute(t, dut1, &oc.NetworkInstance_Protocol_Static{
+		Prefix: ygot.String(upstream),
+		NextHop: map[string]*oc.NetworkInstance_Protocol_Static_NextHop{
+			"single": {
+				Index:   ygot.String("single"),
+				NextHop: oc.UnionString("192.0.2.1"),
+				Recurse: ygot.Bool(true),
+			},
+		},
+	})
+	Await(t, dut2, v4uni.Neighbor(dut1.RouterID).AdjRibInPre().Route(upstream, 0).Prefix().State(), upstream)
+	Await(t, dut3, dut3Route(defaultRoute), defaultRoute)
+	Await(t, dut3, v4uni.LocRib().Route(defaultRoute, oc.UnionString(dut2.RouterID), 0).Prefix().State(), defaultRoute)
+	// Only the default route is advertised, dut2 doesn't export its routes to dut3.
+	awaitNotPresent(t, dut3, dut3Route(upstream))
+	awaitFIBRoute(t, dut3, defaultRoute, true)
+
+	// Withdrawing the upstream route withdraws the default route.
+	staticp := ocpath.Root().NetworkInstance(fakedevice.DefaultNetworkInstance).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, fakedevice.StaticRoutingProtocol)
+	Delete(t, dut1, staticp.Static(upstream).Config())
+	awaitNotPresent(t, dut3, dut3Route(defaultRoute))
+	awaitFIBRoute(t, dut3, defaultRoute, false)
+
+	// Disabling send-default-route stops originating it even with the upstream route.
+	installStaticRoute(t, dut1, &oc.NetworkInstance_Protocol_Static{
+		Prefix: ygot.String(upstream),
+		NextHop: map[string]*oc.NetworkInstance_Protocol_Static_NextHop{
+			"single": {
+				Index:   ygot.String("single"),
+				NextHop: oc.UnionString("192.0.2.1"),
+				Recurse: ygot.Bool(true),
+			},
+		},
+	})
+	Await(t, dut3, dut3Route(defaultRoute), defaultRoute)
+	Replace(t, dut2, sendDefaultRoute.Config(), false)
+	awaitNotPresent(t, dut3, dut3Route(defaultRoute))
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local_test
+
+import (
+	"testing"
+
+	"github.com/openconfig/lemming/bgp"
+	"github.com/openconfig/lemming/gnmi/oc"
+	"github.com/openconfig/lemming/gnmi/oc/ocpath"
+	"github.com/openconfig/lemming/policytest"
+)
+
+func TestDefaultImportPolicyReject(t *testing.T) {
+	installPolicies := func(t *testing.T, dut1, dut2, _, _, _ *Device) {
+		prefixSetName := "accept-10.33.0.0/16"
+		prefixSetPath := ocpath.Root().RoutingPolicy().DefinedSets().PrefixSet(prefixSetName)
+		Replace(t, dut2, prefixSetPath.Mode().Config(), oc.PrefixSet_Mode_IPV4)
+		Replace(t, dut2, prefixSetPath.Prefix("10.33.0.0/16", "exact").IpPrefix().Config(), "10.33.0.0/16")
+
+		// The only statement accepts routes in the prefix set, no statement
+		// rejects any route.
+		policyName := "accept-listed"
+		policy := &oc.RoutingPolicy_PolicyDefinition_Statement_OrderedMap{}
+		stmt, err := policy.AppendNew("stmt1")
+		if err != nil {
+			t.Fatalf("Cannot append new BGP policy statement: %v", err)
+		}
+		stmt.GetOrCreateConditions().GetOrCreateMatchPrefixSet().SetPrefixSet(prefixSetName)
+		stmt.GetOrCreateConditions().GetOrCreateMatchPrefixSet().SetMatchSetOptions(oc.PolicyTypes_MatchSetOptionsRestrictedType_ANY)
+		stmt.GetOrCreateActions().SetPolicyResult(oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE)
+		Replace(t, dut2, ocpath.Root().RoutingPolicy().PolicyDefinition(policyName).Config(), &oc.RoutingPolicy_PolicyDefinition{Statement: policy})
+
+		applyPolicyPath := bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy()
+		Replace(t, dut2, applyPolicyPath.ImportPolicy().Config(), []string{policyName})
+		Replace(t, dut2, applyPolicyPath.DefaultImportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_REJECT_ROUTE)
+		Await(t, dut2, applyPolicyPath.ImportPolicy().State(), []string{policyName})
+		Await(t, dut2, applyPolicyPath.DefaultImportPolicy().State(), oc.RoutingPolicy_DefaultPolicyType_REJECT_ROUTE)
+	}
+
+	testPolicy(t, &PolicyTestCase{
+		description:         "Test that routes no statement accepts or rejects get the default import policy result.",
+		skipValidateAttrSet: true,
+		routeTests: []*policytest.RouteTestCase{{
+			Description: "Accepted by statement",
+			Input: policytest.TestRoute{
+				ReachPrefix: "10.33.0.0/16",
+			},
+			ExpectedResult: policytest.RouteAccepted,
+		}, {
+			Description: "Rejected by default policy",
+			Input: policytest.TestRoute{
+				ReachPrefix: "10.3.0.0/16",
+			},
+			ExpectedResult: policytest.RouteDiscarded,
+		}},
+		installPolicies: installPolicies,
+	})
+}
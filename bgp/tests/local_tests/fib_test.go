@@ -0,0 +1,435 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/local"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/openconfig/lemming/bgp"
+	"github.com/openconfig/lemming/dataplane/dplaneopts"
+	"github.com/openconfig/lemming/dataplane/dplanerc"
+	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdcontext"
+	"github.com/openconfig/lemming/dataplane/saiserver"
+	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
+	"github.com/openconfig/lemming/gnmi/oc"
+
+	diagpb "github.com/openconfig/lemming/dataplane/proto/diag"
+	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
+	dpb "github.com/openconfig/lemming/proto/dataplane"
+	fwdpb "github.com/openconfig/lemming/proto/forwarding"
+)
+
+// fib is an in-process forwarding plane programmed with the routes resolved
+// by a lemming. The local tests don't run lemming's dataplane, so the fib
+// stands in for it to check forwarding with the route lookup API.
+type fib struct {
+	diag     diagpb.DiagClient
+	switchID uint64
+	ports    saipb.PortClient
+	rifs     saipb.RouterInterfaceClient
+	routes   saipb.RouteClient
+	nhs      saipb.NextHopClient
+	nhgs     saipb.NextHopGroupClient
+	nbrs     saipb.NeighborClient
+
+	mu sync.Mutex
+	// err is the first error programming a route.
+	err error
+	// rifIDs are the router interfaces by interface name.
+	rifIDs map[string]uint64
+	// nhIDs are the next hops by router interface and IP.
+	nhIDs map[nextHopKey]uint64
+	// programmed are the route entries currently in the fib.
+	programmed map[string]*saipb.RouteEntry
+}
+
+type nextHopKey struct {
+	rif uint64
+	ip  netip.Addr
+}
+
+// startFIB starts a fib that is programmed with the routes of dut until the
+// test completes.
+func startFIB(t *testing.T, dut *Device) *fib {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr := attrmgr.New()
+	gsrv := grpc.NewServer(grpc.Creds(local.NewCredentials()), grpc.ChainUnaryInterceptor(mgr.Interceptor))
+	srv, err := saiserver.New(ctx, mgr, gsrv, dplaneopts.ResolveOpts(dplaneopts.WithPortType(fwdpb.PortType_PORT_TYPE_FAKE), dplaneopts.WithRemoteCPUPort(true)))
+	if err != nil {
+		t.Fatalf("failed to create dataplane: %v", err)
+	}
+	fwdCtx, err := srv.FindContext(&fwdpb.ContextId{Id: dplaneopts.DefaultContextID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fwdCtx.FakePortManager = nopPortManager{}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go gsrv.Serve(lis)
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(local.NewCredentials()))
+	if err != nil {
+		t.Fatalf("cannot dial dataplane: %v", err)
+	}
+	t.Cleanup(func() {
+		cancel()
+		conn.Close()
+		gsrv.Stop()
+	})
+
+	sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatalf("failed to create switch: %v", err)
+	}
+	f := &fib{
+		diag:       diagpb.NewDiagClient(conn),
+		switchID:   sw.GetOid(),
+		ports:      saipb.NewPortClient(conn),
+		rifs:       saipb.NewRouterInterfaceClient(conn),
+		routes:     saipb.NewRouteClient(conn),
+		nhs:        saipb.NewNextHopClient(conn),
+		nhgs:       saipb.NewNextHopGroupClient(conn),
+		nbrs:       saipb.NewNeighborClient(conn),
+		rifIDs:     map[string]uint64{},
+		nhIDs:      map[nextHopKey]uint64{},
+		programmed: map[string]*saipb.RouteEntry{},
+	}
+
+	w := ygnmi.WatchAll(ctx, dut.yc, dplanerc.MustWildcardQuery(), func(v *ygnmi.Value[*dpb.Route]) error {
+		route, present := v.Val()
+		if err := f.program(ctx, v.Path.GetElem()[2].GetKey()["prefix"], route, present); err != nil {
+			f.mu.Lock()
+			if f.err == nil {
+				f.err = err
+			}
+			f.mu.Unlock()
+		}
+		return ygnmi.Continue
+	})
+	go w.Await()
+	return f
+}
+
+// program replaces the route entry for prefix, removing it if the route isn't present.
+// Neighbors are resolved to a MAC derived from their IP, as the kernel would after ARP.
+func (f *fib) program(ctx context.Context, prefixStr string, route *dpb.Route, present bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	prefix, err := netip.ParsePrefix(prefixStr)
+	if err != nil {
+		return err
+	}
+	addr := prefix.Masked().Addr().AsSlice()
+	entry := &saipb.RouteEntry{
+		SwitchId: f.switchID,
+		Destination: &saipb.IpPrefix{
+			Addr: addr,
+			Mask: net.CIDRMask(prefix.Bits(), len(addr)*8),
+		},
+	}
+	if _, ok := f.programmed[prefixStr]; ok {
+		if _, err := f.routes.RemoveRouteEntry(ctx, &saipb.RemoveRouteEntryRequest{Entry: entry}); err != nil {
+			return fmt.Errorf("failed to remove route %v: %v", prefix, err)
+		}
+		delete(f.programmed, prefixStr)
+	}
+	if !present {
+		return nil
+	}
+
+	var nextID uint64
+	switch {
+	case route.GetInterface() != nil:
+		if nextID, err = f.rif(ctx, route.GetInterface().GetInterface()); err != nil {
+			return err
+		}
+	case len(route.GetNextHops().GetHops()) == 1:
+		if nextID, err = f.nextHop(ctx, route.GetNextHops().GetHops()[0]); err != nil {
+			return err
+		}
+	default:
+		group, err := f.nhgs.CreateNextHopGroup(ctx, &saipb.CreateNextHopGroupRequest{
+			Switch: f.switchID,
+			Type:   saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_DYNAMIC_UNORDERED_ECMP.Enum(),
+		})
+		if err != nil {
+			return err
+		}
+		for i, hop := range route.GetNextHops().GetHops() {
+			nhID, err := f.nextHop(ctx, hop)
+			if err != nil {
+				return err
+			}
+			if _, err := f.nhgs.CreateNextHopGroupMember(ctx, &saipb.CreateNextHopGroupMemberRequest{
+				Switch:         f.switchID,
+				NextHopGroupId: proto.Uint64(group.GetOid()),
+				NextHopId:      proto.Uint64(nhID),
+				Weight:         proto.Uint32(uint32(route.GetNextHops().GetWeights()[i])),
+			}); err != nil {
+				return err
+			}
+		}
+		nextID = group.GetOid()
+	}
+	if _, err := f.routes.CreateRouteEntry(ctx, &saipb.CreateRouteEntryRequest{
+		Entry:        entry,
+		PacketAction: saipb.PacketAction_PACKET_ACTION_FORWARD.Enum(),
+		NextHopId:    proto.Uint64(nextID),
+	}); err != nil {
+		return fmt.Errorf("failed to create route %v: %v", prefix, err)
+	}
+	f.programmed[prefixStr] = entry
+	return nil
+}
+
+// rif returns the router interface of the named interface, creating it and its port if needed.
+func (f *fib) rif(ctx context.Context, name string) (uint64, error) {
+	if id, ok := f.rifIDs[name]; ok {
+		return id, nil
+	}
+	port, err := f.ports.CreatePort(ctx, &saipb.CreatePortRequest{
+		Switch:     f.switchID,
+		HwLaneList: []uint32{uint32(len(f.rifIDs))},
+		AdminState: proto.Bool(true),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create port for %q: %v", name, err)
+	}
+	rif, err := f.rifs.CreateRouterInterface(ctx, &saipb.CreateRouterInterfaceRequest{
+		Switch:          f.switchID,
+		Type:            saipb.RouterInterfaceType_ROUTER_INTERFACE_TYPE_PORT.Enum(),
+		PortId:          proto.Uint64(port.GetOid()),
+		SrcMacAddress:   []byte{0x02, 0, 0, 0, 0, byte(len(f.rifIDs) + 1)},
+		VirtualRouterId: proto.Uint64(0),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create router interface for %q: %v", name, err)
+	}
+	f.rifIDs[name] = rif.GetOid()
+	return rif.GetOid(), nil
+}
+
+// nextHop returns the next hop for hop, creating it and its neighbor if needed.
+func (f *fib) nextHop(ctx context.Context, hop *dpb.NextHop) (uint64, error) {
+	ip, err := netip.ParseAddr(hop.GetNextHopIp())
+	if err != nil {
+		return 0, err
+	}
+	rifID, err := f.rif(ctx, hop.GetInterface().GetInterface())
+	if err != nil {
+		return 0, err
+	}
+	key := nextHopKey{rif: rifID, ip: ip}
+	if id, ok := f.nhIDs[key]; ok {
+		return id, nil
+	}
+	if _, err := f.nbrs.CreateNeighborEntry(ctx, &saipb.CreateNeighborEntryRequest{
+		Entry:         &saipb.NeighborEntry{SwitchId: f.switchID, RifId: rifID, IpAddress: ip.AsSlice()},
+		DstMacAddress: append([]byte{0x02, 0xff}, ip.AsSlice()[len(ip.AsSlice())-4:]...),
+	}); err != nil {
+		return 0, fmt.Errorf("failed to create neighbor %v: %v", ip, err)
+	}
+	nh, err := f.nhs.CreateNextHop(ctx, &saipb.CreateNextHopRequest{
+		Switch:            f.switchID,
+		Type:              saipb.NextHopType_NEXT_HOP_TYPE_IP.Enum(),
+		Ip:                ip.AsSlice(),
+		RouterInterfaceId: proto.Uint64(rifID),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create next hop %v: %v", ip, err)
+	}
+	f.nhIDs[key] = nh.GetOid()
+	return nh.GetOid(), nil
+}
+
+// lookup resolves the egress of dst in the default VRF.
+func (f *fib) lookup(dst netip.Addr) (*diagpb.LookupRouteResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.diag.LookupRoute(context.Background(), &diagpb.LookupRouteRequest{Dst: dst.AsSlice()})
+}
+
+// bestPathNextHop returns the next hop of the BGP best path to the IPv4 prefix on dut.
+// GoBGP lists the best path of a destination first, so it's the loc-rib path with ID 0.
+func bestPathNextHop(dut *Device, prefix string) (netip.Addr, error) {
+	v4uni := bgp.BGPPath.Rib().AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Ipv4Unicast()
+	routes, err := ygnmi.GetAll(context.Background(), dut.yc, v4uni.LocRib().RouteAny().WithPrefix(prefix).WithPathId(0).State())
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("no best path: %v", err)
+	}
+	if len(routes) != 1 {
+		return netip.Addr{}, fmt.Errorf("got %d best paths, want 1", len(routes))
+	}
+	nh, err := ygnmi.Get(context.Background(), dut.yc, bgp.BGPPath.Rib().AttrSet(routes[0].GetAttrIndex()).NextHop().State())
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("no next hop for attr-set %d: %v", routes[0].GetAttrIndex(), err)
+	}
+	return netip.ParseAddr(nh)
+}
+
+// checkFIBMatchesBestPath checks that the forwarding plane of dut sends traffic
+// to the IPv4 prefix along its BGP best path: the fib must resolve the prefix to
+// the same egress as the best path's (possibly recursive) next hop.
+// Since programming is asynchronous, it waits up to timeout for them to match.
+func checkFIBMatchesBestPath(t *testing.T, dut *Device, f *fib, prefix string, timeout time.Duration) {
+	t.Helper()
+	p, err := netip.ParsePrefix(prefix)
+	if err != nil {
+		t.Fatalf("invalid prefix %q: %v", prefix, err)
+	}
+	var mismatch error
+	for deadline := time.Now().Add(timeout); time.Now().Before(deadline); time.Sleep(100 * time.Millisecond) {
+		if mismatch = fibMismatch(dut, f, p); mismatch == nil {
+			return
+		}
+	}
+	t.Errorf("DUT %v: forwarding of %s doesn't match BGP best path: %v", dut.ID, prefix, mismatch)
+}
+
+// fibMismatch returns an error describing how forwarding of prefix diverges from its BGP best path,
+// or nil if they match.
+func fibMismatch(dut *Device, f *fib, prefix netip.Prefix) error {
+	nh, err := bestPathNextHop(dut, prefix.String())
+	if err != nil {
+		return err
+	}
+	got, err := f.lookup(prefix.Addr())
+	if err != nil {
+		return fmt.Errorf("lookup of prefix failed: %v", err)
+	}
+	want, err := f.lookup(nh)
+	if err != nil {
+		return fmt.Errorf("lookup of best path next hop %v failed: %v", nh, err)
+	}
+	gotIP, wantIP := net.IP(got.GetNextHopIp()), net.IP(want.GetNextHopIp())
+	if !gotIP.Equal(wantIP) || got.GetRouterInterface() != want.GetRouterInterface() {
+		return fmt.Errorf("egress is %v on router interface %d, best path next hop %v egresses to %v on router interface %d",
+			gotIP, got.GetRouterInterface(), nh, wantIP, want.GetRouterInterface())
+	}
+	return nil
+}
+
+func TestFIBMatchesBGPBestPath(t *testing.T) {
+	dut1, stop1 := newLemming(t, 1, 64500, []*AddIntfAction{{
+		name:    "eth1",
+		ifindex: 1,
+		enabled: true,
+		prefix:  "192.0.2.1/30",
+		niName:  "DEFAULT",
+	}})
+	defer stop1()
+	dut2, stop2 := newLemming(t, 2, 64501, []*AddIntfAction{{
+		name:    "eth0",
+		ifindex: 0,
+		enabled: true,
+		prefix:  "198.51.100.0/31",
+		niName:  "DEFAULT",
+	}, {
+		name:    "eth1",
+		ifindex: 1,
+		enabled: true,
+		prefix:  "198.51.100.2/31",
+		niName:  "DEFAULT",
+	}})
+	defer stop2()
+	dut2FIB := startFIB(t, dut2)
+
+	Replace(t, dut1, bgp.BGPPath.Neighbor(dut2.RouterID).ApplyPolicy().DefaultExportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Replace(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy().DefaultImportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	establishSessionPairs(t, DevicePair{dut1, dut2})
+	Await(t, dut1, bgp.BGPPath.Neighbor(dut2.RouterID).ApplyPolicy().DefaultExportPolicy().State(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Await(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy().DefaultImportPolicy().State(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+
+	staticRoute := func(prefix, nextHop string) *oc.NetworkInstance_Protocol_Static {
+		return &oc.NetworkInstance_Protocol_Static{
+			Prefix: ygot.String(prefix),
+			NextHop: map[string]*oc.NetworkInstance_Protocol_Static_NextHop{
+				"single": {
+					Index:   ygot.String("single"),
+					NextHop: oc.UnionString(nextHop),
+					Recurse: ygot.Bool(true),
+				},
+			},
+		}
+	}
+	// dut2 resolves each of dut1's next hops over a different interface.
+	installStaticRoute(t, dut2, staticRoute("192.0.2.0/31", "198.51.100.1"))
+	installStaticRoute(t, dut2, staticRoute("192.0.2.2/31", "198.51.100.3"))
+
+	routes := map[string]string{
+		"10.10.10.0/24": "192.0.2.1",
+		"10.10.20.0/24": "192.0.2.2",
+		"10.10.30.0/24": "192.0.2.1",
+	}
+	v4uni := bgp.BGPPath.Rib().AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Ipv4Unicast()
+	for prefix, nextHop := range routes {
+		installStaticRoute(t, dut1, staticRoute(prefix, nextHop))
+	}
+	for prefix := range routes {
+		Await(t, dut2, v4uni.LocRib().Route(prefix, oc.UnionString(dut1.RouterID), 0).Prefix().State(), prefix)
+		checkFIBMatchesBestPath(t, dut2, dut2FIB, prefix, rejectTimeout)
+	}
+
+	// Moving a route to another next hop must move its forwarding too.
+	moved := "10.10.30.0/24"
+	installStaticRoute(t, dut1, staticRoute(moved, "192.0.2.2"))
+	for deadline := time.Now().Add(rejectTimeout); ; time.Sleep(100 * time.Millisecond) {
+		nh, err := bestPathNextHop(dut2, moved)
+		if err == nil && nh == netip.MustParseAddr("192.0.2.2") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("best path next hop of %s got %v (err %v), want 192.0.2.2", moved, nh, err)
+		}
+	}
+	checkFIBMatchesBestPath(t, dut2, dut2FIB, moved, rejectTimeout)
+}
+
+type nopPortManager struct{}
+
+func (nopPortManager) CreatePort(string) (fwdcontext.Port, error) {
+	return nopPort{}, nil
+}
+
+// nopPort is a port that never receives packets and drops those sent to it.
+type nopPort struct{}
+
+func (nopPort) ReadPacketData() ([]byte, gopacket.CaptureInfo, error) {
+	select {}
+}
+
+func (nopPort) WritePacketData([]byte) error {
+	return nil
+}
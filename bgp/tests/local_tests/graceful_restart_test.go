@@ -0,0 +1,104 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/openconfig/lemming"
+	"github.com/openconfig/lemming/bgp"
+	"github.com/openconfig/lemming/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// llgrStaleCommunity is the LLGR_STALE well-known community (RFC 9494).
+const llgrStaleCommunity = "65535:6"
+
+func TestLongLivedGracefulRestart(t *testing.T) {
+	const llgrRestartTime = 15 * time.Second
+
+	dut1, stop1 := newLemming(t, 1, 64500, []*AddIntfAction{{
+		name:    "eth1",
+		ifindex: 1,
+		enabled: true,
+		prefix:  "192.0.2.1/31",
+		niName:  "DEFAULT",
+	}}, lemming.WithBGPLongLivedGracefulRestart(llgrRestartTime))
+	defer stop1()
+	dut2, stop2 := newLemming(t, 2, 64501, nil, lemming.WithBGPLongLivedGracefulRestart(llgrRestartTime))
+	defer stop2()
+
+	// Graceful restart is only enabled for IPv4 unicast.
+	for _, pair := range []DevicePair{{dut1, dut2}, {dut2, dut1}} {
+		nbr := bgp.BGPPath.Neighbor(pair.second.RouterID)
+		Replace(t, pair.first, nbr.GracefulRestart().Config(), &oc.NetworkInstance_Protocol_Bgp_Neighbor_GracefulRestart{
+			Enabled:     ygot.Bool(true),
+			RestartTime: ygot.Uint16(3),
+		})
+		Replace(t, pair.first, nbr.AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Config(), &oc.NetworkInstance_Protocol_Bgp_Neighbor_AfiSafi{
+			AfiSafiName: oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST,
+			Enabled:     ygot.Bool(true),
+			GracefulRestart: &oc.NetworkInstance_Protocol_Bgp_Neighbor_AfiSafi_GracefulRestart{
+				Enabled: ygot.Bool(true),
+			},
+		})
+	}
+	Replace(t, dut1, bgp.BGPPath.Neighbor(dut2.RouterID).ApplyPolicy().DefaultExportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Replace(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy().DefaultImportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+
+	establishSessionPairs(t, DevicePair{dut1, dut2})
+
+	prefix := "10.10.10.0/24"
+	installStaticRoute(t, dut1, &oc.NetworkInstance_Protocol_Static{
+		Prefix: ygot.String(prefix),
+		NextHop: map[string]*oc.NetworkInstance_Protocol_Static_NextHop{
+			"single": {
+				Index:   ygot.String("single"),
+				NextHop: oc.UnionString("192.0.2.1"),
+				Recurse: ygot.Bool(true),
+			},
+		},
+	})
+	route := bgp.BGPPath.Rib().AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Ipv4Unicast().Neighbor(dut1.RouterID).AdjRibInPre().Route(prefix, 0)
+	Await(t, dut2, route.Prefix().State(), prefix)
+
+	// dut1 going down is treated as a graceful restart, since it sends a
+	// NOTIFICATION other than a Hard Reset.
+	Replace(t, dut1, bgp.BGPPath.Neighbor(dut2.RouterID).Enabled().Config(), false)
+
+	// Once the restart time expires, the route is retained as LLGR_STALE.
+	start := time.Now()
+	for {
+		commMap, _ := Lookup(t, dut2, bgp.BGPPath.Rib().CommunityMap().State()).Val()
+		if slices.Contains(getCommunities(t, dut2, commMap, route.CommunityIndex().State()), llgrStaleCommunity) {
+			break
+		}
+		if time.Since(start) > awaitTimeLimit {
+			t.Fatalf("Route %s was not marked with the LLGR_STALE community", prefix)
+		}
+		time.Sleep(time.Second)
+	}
+	if got := Get(t, dut2, route.Prefix().State()); got != prefix {
+		t.Errorf("Stale route: got %v, want %v", got, prefix)
+	}
+
+	// The route is purged once the long-lived restart time expires.
+	awaitNotPresent(t, dut2, route.Prefix().State())
+	if elapsed := time.Since(start); elapsed < llgrRestartTime/2 {
+		t.Errorf("Stale route purged after %v, want it retained for the long-lived restart time of %v", elapsed, llgrRestartTime)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/openconfig/lemming/bgp"
+	"github.com/openconfig/lemming/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestMessageCounters(t *testing.T) {
+	dut1, stop1 := newLemming(t, 1, 64500, []*AddIntfAction{{
+		name:    "eth1",
+		ifindex: 1,
+		enabled: true,
+		prefix:  "192.0.2.1/31",
+		niName:  "DEFAULT",
+	}})
+	defer stop1()
+	dut2, stop2 := newLemming(t, 2, 64501, nil)
+	defer stop2()
+
+	staticRoute := func(prefix string) *oc.NetworkInstance_Protocol_Static {
+		return &oc.NetworkInstance_Protocol_Static{
+			Prefix: ygot.String(prefix),
+			NextHop: map[string]*oc.NetworkInstance_Protocol_Static_NextHop{
+				"single": {
+					Index:   ygot.String("single"),
+					NextHop: oc.UnionString("192.0.2.0"),
+					Recurse: ygot.Bool(true),
+				},
+			},
+		}
+	}
+	v4uni := bgp.BGPPath.Rib().AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Ipv4Unicast()
+
+	// The routes are originated before the session is established, so
+	// they're advertised together in the initial UPDATE.
+	Replace(t, dut1, bgp.BGPPath.Global().As().Config(), dut1.AS)
+	Replace(t, dut1, bgp.BGPPath.Global().RouterId().Config(), dut1.RouterID)
+	packed := []string{"10.1.0.0/24", "10.1.1.0/24", "10.1.2.0/24"}
+	for _, prefix := range packed {
+		installStaticRoute(t, dut1, staticRoute(prefix))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), awaitTimeLimit)
+	defer cancel()
+	for {
+		prefixes, err := ygnmi.LookupAll(ctx, dut1.yc, v4uni.LocRib().RouteAny().Prefix().State())
+		if err != nil {
+			t.Fatalf("failed to look up loc-rib of dut1: %v", err)
+		}
+		var got []string
+		for _, p := range prefixes {
+			if prefix, ok := p.Val(); ok {
+				got = append(got, prefix)
+			}
+		}
+		if !slices.ContainsFunc(packed, func(prefix string) bool { return !slices.Contains(got, prefix) }) {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+
+	Replace(t, dut1, bgp.BGPPath.Neighbor(dut2.RouterID).ApplyPolicy().DefaultExportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Replace(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy().DefaultImportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	establishSessionPairs(t, DevicePair{dut1, dut2})
+
+	for _, prefix := range packed {
+		Await(t, dut2, v4uni.LocRib().Route(prefix, oc.UnionString(dut1.RouterID), 0).Prefix().State(), prefix)
+	}
+	sent := bgp.BGPPath.Neighbor(dut2.RouterID).Messages().Sent()
+	received := bgp.BGPPath.Neighbor(dut1.RouterID).Messages().Received()
+	Await(t, dut1, sent.UPDATE().State(), 1)
+	Await(t, dut2, received.UPDATE().State(), 1)
+
+	// A route originated once the session is established is advertised in
+	// its own UPDATE.
+	unpacked := "10.2.0.0/24"
+	installStaticRoute(t, dut1, staticRoute(unpacked))
+	Await(t, dut2, v4uni.LocRib().Route(unpacked, oc.UnionString(dut1.RouterID), 0).Prefix().State(), unpacked)
+	Await(t, dut1, sent.UPDATE().State(), 2)
+	Await(t, dut2, received.UPDATE().State(), 2)
+
+	Await(t, dut1, sent.NOTIFICATION().State(), 0)
+	Await(t, dut2, received.NOTIFICATION().State(), 0)
+}
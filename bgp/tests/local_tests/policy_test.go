@@ -16,11 +16,13 @@ package local_test
 
 import (
 	"fmt"
+	"net/netip"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/lemming/bgp"
+	"github.com/openconfig/lemming/dataplane/dplanerc"
 	"github.com/openconfig/lemming/gnmi/fakedevice"
 	"github.com/openconfig/lemming/gnmi/oc"
 	"github.com/openconfig/lemming/gnmi/oc/ocpath"
@@ -28,6 +30,8 @@ import (
 	"github.com/openconfig/ygnmi/ygnmi"
 	"github.com/openconfig/ygot/ygot"
 	"google.golang.org/protobuf/testing/protocmp"
+
+	dpb "github.com/openconfig/lemming/proto/dataplane"
 )
 
 const (
@@ -55,6 +59,8 @@ type PolicyTestCase struct {
 	longerPathRouteTests    []*policytest.RouteTestCase
 	skipValidateAttrSet     bool // whether attr-sets are validated
 	dut1IsEBGP              bool // whether DUT1 and DUT2 are in different ASes
+	withdrawRoutes          bool // whether DUT1 withdraws routeTests after they're validated
+	attachCommunities       bool // whether DUT1 attaches the input communities of routeTests when advertising them
 	installPolicies         func(t *testing.T, dut1, dut2, dut3, dut4, dut5 *Device)
 }
 
@@ -74,7 +80,19 @@ func testPolicy(t *testing.T, testspec *PolicyTestCase) {
 		niName:  "DEFAULT",
 	}})
 	defer stop1()
-	dut2, stop2 := newLemming(t, 2, 64500, nil)
+	var dut2Intfs []*AddIntfAction
+	if testspec.withdrawRoutes {
+		// Resolve DUT1's next hop so that the removal of withdrawn
+		// routes from DUT2's FIB can be checked.
+		dut2Intfs = []*AddIntfAction{{
+			name:    "eth0",
+			ifindex: 0,
+			enabled: true,
+			prefix:  "192.0.2.0/31",
+			niName:  "DEFAULT",
+		}}
+	}
+	dut2, stop2 := newLemming(t, 2, 64500, dut2Intfs)
 	defer stop2()
 	dut3, stop3 := newLemming(t, 3, 64501, nil)
 	defer stop3()
@@ -206,6 +224,9 @@ func testPolicyAux(t *testing.T, testspec *PolicyTestCase, dut1, dut2, dut3, dut
 	if testspec.installPolicies != nil {
 		testspec.installPolicies(t, dut1, dut2, dut3, dut4, dut5)
 	}
+	if testspec.attachCommunities {
+		attachCommunities(t, testspec.routeTests, dut1, dut2)
+	}
 
 	for _, routeTest := range testspec.routeTests {
 		// Install all regular test routes into DUT1.
@@ -272,6 +293,108 @@ func testPolicyAux(t *testing.T, testspec *PolicyTestCase, dut1, dut2, dut3, dut
 			testAttrs(t, routeTest, dut5, dut2, dut3)
 		}
 	}
+
+	if testspec.withdrawRoutes {
+		for _, routeTest := range testspec.routeTests {
+			if routeTest.ExpectedResult == policytest.RouteAccepted {
+				awaitFIBRoute(t, dut2, routeTest.Input.ReachPrefix, true)
+			}
+		}
+		staticp := ocpath.Root().NetworkInstance(fakedevice.DefaultNetworkInstance).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, fakedevice.StaticRoutingProtocol)
+		for _, routeTest := range testspec.routeTests {
+			Delete(t, dut1, staticp.Static(routeTest.Input.ReachPrefix).Config())
+		}
+		for _, routeTest := range testspec.routeTests {
+			testWithdrawal(t, routeTest, dut1, dut2, dut3)
+		}
+	}
+}
+
+// attachCommunities attaches the input communities of routeTests to the
+// routes when DUT1 advertises them to DUT2. They're added by an export policy
+// evaluated before the ones installed by the test case, and which neither
+// accepts nor rejects routes.
+func attachCommunities(t *testing.T, routeTests []*policytest.RouteTestCase, dut1, dut2 *Device) {
+	t.Helper()
+	policyName := "attach-communities"
+	policy := &oc.RoutingPolicy_PolicyDefinition_Statement_OrderedMap{}
+	for i, routeTest := range routeTests {
+		if len(routeTest.Input.Communities) == 0 {
+			continue
+		}
+		route := routeTest.Input.ReachPrefix
+		prefixSetName := policyName + "-" + route
+		prefixSetPath := ocpath.Root().RoutingPolicy().DefinedSets().PrefixSet(prefixSetName)
+		pfx, err := netip.ParsePrefix(route)
+		if err != nil {
+			t.Fatalf("Cannot parse route prefix %q: %v", route, err)
+		}
+		mode := oc.PrefixSet_Mode_IPV4
+		if pfx.Addr().Is6() {
+			mode = oc.PrefixSet_Mode_IPV6
+		}
+		Replace(t, dut1, prefixSetPath.Mode().Config(), mode)
+		Replace(t, dut1, prefixSetPath.Prefix(route, "exact").IpPrefix().Config(), route)
+
+		stmt, err := policy.AppendNew(fmt.Sprintf("stmt%d", i))
+		if err != nil {
+			t.Fatalf("Cannot append new BGP policy statement: %v", err)
+		}
+		stmt.GetOrCreateConditions().GetOrCreateMatchPrefixSet().SetPrefixSet(prefixSetName)
+		stmt.GetOrCreateConditions().GetOrCreateMatchPrefixSet().SetMatchSetOptions(oc.PolicyTypes_MatchSetOptionsRestrictedType_ANY)
+		var comms []oc.RoutingPolicy_PolicyDefinition_Statement_Actions_BgpActions_SetCommunity_Inline_Communities_Union
+		for _, c := range routeTest.Input.Communities {
+			comms = append(comms, oc.UnionString(c))
+		}
+		setComm := stmt.GetOrCreateActions().GetOrCreateBgpActions().GetOrCreateSetCommunity()
+		setComm.SetOptions(oc.BgpPolicy_BgpSetCommunityOptionType_ADD)
+		setComm.SetMethod(oc.SetCommunity_Method_INLINE)
+		setComm.GetOrCreateInline().SetCommunities(comms)
+	}
+	if policy.Len() == 0 {
+		return
+	}
+	Replace(t, dut1, ocpath.Root().RoutingPolicy().PolicyDefinition(policyName).Config(), &oc.RoutingPolicy_PolicyDefinition{Statement: policy})
+
+	exportPolicyPath := bgp.BGPPath.Neighbor(dut2.RouterID).ApplyPolicy().ExportPolicy()
+	exportPolicies := []string{policyName}
+	if v, ok := Lookup(t, dut1, exportPolicyPath.Config()).Val(); ok {
+		exportPolicies = append(exportPolicies, v...)
+	}
+	Replace(t, dut1, exportPolicyPath.Config(), exportPolicies)
+	Await(t, dut1, exportPolicyPath.State(), exportPolicies)
+}
+
+// testWithdrawal checks that the withdrawal of a route by prevDUT removes it
+// from every RIB of currDUT and from its FIB, and is propagated to nextDUT.
+// The withdrawal of a route rejected by currDUT's import policy must not
+// change anything past its adj-rib-in-pre.
+func testWithdrawal(t *testing.T, routeTest *policytest.RouteTestCase, prevDUT, currDUT, nextDUT *Device) {
+	t.Helper()
+	v4uni := bgp.BGPPath.Rib().AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Ipv4Unicast()
+
+	prefix := routeTest.Input.ReachPrefix
+	t.Logf("Waiting for the withdrawal of %q (%s) to be propagated", prefix, routeTest.Description)
+	awaitNotPresent(t, prevDUT, v4uni.Neighbor(currDUT.RouterID).AdjRibOutPost().Route(prefix, 0).Prefix().State())
+	awaitNotPresent(t, currDUT, v4uni.Neighbor(prevDUT.RouterID).AdjRibInPre().Route(prefix, 0).Prefix().State())
+	awaitNotPresent(t, currDUT, v4uni.Neighbor(prevDUT.RouterID).AdjRibInPost().Route(prefix, 0).Prefix().State())
+	awaitNotPresent(t, currDUT, v4uni.LocRib().Route(prefix, oc.UnionString(prevDUT.RouterID), 0).Prefix().State())
+	awaitFIBRoute(t, currDUT, prefix, false)
+	awaitNotPresent(t, currDUT, v4uni.Neighbor(nextDUT.RouterID).AdjRibOutPost().Route(prefix, 0).Prefix().State())
+	awaitNotPresent(t, nextDUT, v4uni.Neighbor(currDUT.RouterID).AdjRibInPre().Route(prefix, 0).Prefix().State())
+	awaitNotPresent(t, nextDUT, v4uni.LocRib().Route(prefix, oc.UnionString(currDUT.RouterID), 0).Prefix().State())
+}
+
+// awaitFIBRoute waits for the route to the prefix to be programmed into, or
+// removed from, the dataplane of dut.
+func awaitFIBRoute(t *testing.T, dut *Device, prefix string, present bool) {
+	t.Helper()
+	w := Watch(t, dut, dplanerc.RouteQuery(fakedevice.DefaultNetworkInstance, prefix), rejectTimeout, func(val *ygnmi.Value[*dpb.Route]) bool {
+		return val.IsPresent() == present
+	})
+	if _, ok := w.Await(t); !ok {
+		t.Fatalf("route to %q was not present=%v in the FIB of %v within timeout.", prefix, present, dut)
+	}
 }
 
 func testCommunities(t *testing.T, routeTest *policytest.RouteTestCase, prevDUT, currDUT, nextDUT *Device) {
@@ -297,6 +420,12 @@ func testCommunities(t *testing.T, routeTest *policytest.RouteTestCase, prevDUT,
 	if diff := cmp.Diff(routeTest.AdjRibInPostCommunities, getCommunities(t, currDUT, currCommMap, v4uni.Neighbor(prevDUT.RouterID).AdjRibInPost().Route(prefix, 0).CommunityIndex().State())); diff != "" {
 		t.Errorf("DUT %v AdjRibInPost communities difference (prefix %s) (-want, +got):\n%s", currDUT.ID, prefix, diff)
 	}
+	if diff := cmp.Diff(routeTest.AdjRibInPreExtCommunities, getExtCommunities(t, currDUT, v4uni.Neighbor(prevDUT.RouterID).AdjRibInPre().Route(prefix, 0).ExtCommunityIndex().State())); diff != "" {
+		t.Errorf("DUT %v AdjRibInPre ext-communities difference (prefix %s) (-want, +got):\n%s", currDUT.ID, prefix, diff)
+	}
+	if diff := cmp.Diff(routeTest.AdjRibInPostExtCommunities, getExtCommunities(t, currDUT, v4uni.Neighbor(prevDUT.RouterID).AdjRibInPost().Route(prefix, 0).ExtCommunityIndex().State())); diff != "" {
+		t.Errorf("DUT %v AdjRibInPost ext-communities difference (prefix %s) (-want, +got):\n%s", currDUT.ID, prefix, diff)
+	}
 	if diff := cmp.Diff(routeTest.LocalRibCommunities, getCommunities(t, currDUT, currCommMap, v4uni.LocRib().Route(prefix, oc.UnionString(prevDUT.RouterID), 0).CommunityIndex().State())); diff != "" {
 		t.Errorf("DUT %v LocRib communities difference (prefix %s) (-want, +got):\n%s", currDUT.ID, prefix, diff)
 	}
@@ -340,6 +469,31 @@ func getCommunities(t *testing.T, dut *Device, commMap map[uint64]*oc.NetworkIns
 	return gotCommunities
 }
 
+// getExtCommunities gets the extended communities of the given route query to an extended community index.
+//
+// If the extended community index doesn't exist (e.g. the route doesn't exist), nil is returned.
+func getExtCommunities(t *testing.T, dut *Device, query ygnmi.SingletonQuery[uint64]) []string {
+	commIndex, ok := Lookup(t, dut, query).Val()
+	if !ok {
+		return nil
+	}
+	comms, ok := Lookup(t, dut, bgp.BGPPath.Rib().ExtCommunity(commIndex).State()).Val()
+	if !ok {
+		t.Errorf("RIB ext-communities does not have expected index: %v", commIndex)
+		return nil
+	}
+	var gotCommunities []string
+	for _, comm := range comms.GetExtCommunity() {
+		c, ok := comm.(oc.UnionString)
+		if !ok {
+			t.Errorf("Unexpected ext-community type: (%T, %v)", comm, comm)
+			continue
+		}
+		gotCommunities = append(gotCommunities, string(c))
+	}
+	return gotCommunities
+}
+
 // awaitNoDiff periodically checks diffFunc's output until empty or timeout.
 //
 //   - updateRIBFunc is called periodically to update any RIB data used in the
@@ -516,6 +670,8 @@ func getAttrs(t *testing.T, dut *Device, attrSetMap map[uint64]*oc.NetworkInstan
 		return nil, fmt.Errorf("RIB attributes does not have expected attribute index: %v", attrIndex)
 	}
 	attrs.Index = nil
+	// The next hop depends on the topology rather than the policy under test.
+	attrs.NextHop = nil
 	// Set default values.
 	if attrs.Med == nil {
 		attrs.Med = ygot.Uint32(0)
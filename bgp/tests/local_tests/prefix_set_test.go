@@ -16,12 +16,17 @@ package local_test
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/openconfig/lemming/bgp"
 	"github.com/openconfig/lemming/gnmi/oc"
 	"github.com/openconfig/lemming/gnmi/oc/ocpath"
+	"github.com/openconfig/lemming/internal/lemmingutil"
 	"github.com/openconfig/lemming/policytest"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
 )
 
 func TestPrefixSetMode(t *testing.T) {
@@ -204,3 +209,193 @@ func TestPrefixSet(t *testing.T) {
 		testPolicy(t, getspec(true))
 	})
 }
+
+// testDualStackPrefixSets tests that an import policy rejecting the routes
+// matching each of the given prefix sets rejects the expected IPv4 and IPv6
+// routes of the route tests.
+func testDualStackPrefixSets(t *testing.T, prefixSets map[string]oc.E_PrefixSet_Mode, prefixes map[string][2]string, invert bool, routeTests []*policytest.RouteTestCase) {
+	t.Helper()
+	dut1, stop1 := newLemming(t, 1, 64500, []*AddIntfAction{{
+		name:    "eth1",
+		ifindex: 1,
+		enabled: true,
+		prefix:  "192.0.2.1/31",
+		niName:  "DEFAULT",
+	}, {
+		name:    "eth2",
+		ifindex: 2,
+		enabled: true,
+		prefix:  "2001:db8::1/127",
+		niName:  "DEFAULT",
+	}})
+	defer stop1()
+	dut2, stop2 := newLemming(t, 2, 64501, nil)
+	defer stop2()
+
+	policyName := "def1"
+	policy := &oc.RoutingPolicy_PolicyDefinition_Statement_OrderedMap{}
+	prefixSetNames := lemmingutil.Mapkeys(prefixSets)
+	slices.Sort(prefixSetNames)
+	for _, prefixSetName := range prefixSetNames {
+		prefixSetPath := ocpath.Root().RoutingPolicy().DefinedSets().PrefixSet(prefixSetName)
+		Replace(t, dut2, prefixSetPath.Mode().Config(), prefixSets[prefixSetName])
+		for prefix, entry := range prefixes {
+			if entry[0] == prefixSetName {
+				Replace(t, dut2, prefixSetPath.Prefix(prefix, entry[1]).IpPrefix().Config(), prefix)
+			}
+		}
+
+		stmt, err := policy.AppendNew("reject-" + prefixSetName)
+		if err != nil {
+			t.Fatalf("Cannot append new BGP policy statement: %v", err)
+		}
+		stmt.GetOrCreateConditions().GetOrCreateMatchPrefixSet().SetPrefixSet(prefixSetName)
+		if invert {
+			stmt.GetOrCreateConditions().GetOrCreateMatchPrefixSet().SetMatchSetOptions(oc.PolicyTypes_MatchSetOptionsRestrictedType_INVERT)
+		} else {
+			stmt.GetOrCreateConditions().GetOrCreateMatchPrefixSet().SetMatchSetOptions(oc.PolicyTypes_MatchSetOptionsRestrictedType_ANY)
+		}
+		stmt.GetOrCreateActions().SetPolicyResult(oc.RoutingPolicy_PolicyResultType_REJECT_ROUTE)
+	}
+	Replace(t, dut2, ocpath.Root().RoutingPolicy().PolicyDefinition(policyName).Config(), &oc.RoutingPolicy_PolicyDefinition{Statement: policy})
+
+	for _, pair := range [][2]*Device{{dut1, dut2}, {dut2, dut1}} {
+		neigh := bgp.BGPPath.Neighbor(pair[1].RouterID)
+		Replace(t, pair[0], neigh.ApplyPolicy().DefaultExportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+		Replace(t, pair[0], neigh.ApplyPolicy().DefaultImportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+		Replace(t, pair[0], neigh.AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Enabled().Config(), true)
+		Replace(t, pair[0], neigh.AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST).Enabled().Config(), true)
+	}
+	Replace(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy().ImportPolicy().Config(), []string{policyName})
+
+	for _, routeTest := range routeTests {
+		nexthop := "192.0.2.0"
+		if strings.Contains(routeTest.Input.ReachPrefix, ":") {
+			nexthop = "2001:db8::"
+		}
+		installStaticRoute(t, dut1, &oc.NetworkInstance_Protocol_Static{
+			Prefix: ygot.String(routeTest.Input.ReachPrefix),
+			NextHop: map[string]*oc.NetworkInstance_Protocol_Static_NextHop{
+				"single": {
+					Index:   ygot.String("single"),
+					NextHop: oc.UnionString(nexthop),
+					Recurse: ygot.Bool(true),
+				},
+			},
+		})
+	}
+	establishSessionPairs(t, DevicePair{dut1, dut2})
+	Await(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy().ImportPolicy().State(), []string{policyName})
+
+	v4uni := bgp.BGPPath.Rib().AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Ipv4Unicast()
+	v6uni := bgp.BGPPath.Rib().AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST).Ipv6Unicast()
+	locRibPrefix := func(prefix string) ygnmi.SingletonQuery[string] {
+		if strings.Contains(prefix, ":") {
+			return v6uni.LocRib().Route(prefix, oc.UnionString(dut1.RouterID), 0).Prefix().State()
+		}
+		return v4uni.LocRib().Route(prefix, oc.UnionString(dut1.RouterID), 0).Prefix().State()
+	}
+	// Accepted routes are checked first so that the routes have been
+	// received before checking that the rejected ones are absent.
+	for _, checkRejected := range []bool{false, true} {
+		for _, routeTest := range routeTests {
+			rejected := (routeTest.ExpectedResult == policytest.RouteDiscarded) != invert
+			if rejected != checkRejected {
+				continue
+			}
+			prefix := routeTest.Input.ReachPrefix
+			if rejected {
+				t.Logf("Checking that %q (%s) is rejected", prefix, routeTest.Description)
+				awaitNotPresent(t, dut2, locRibPrefix(prefix))
+			} else {
+				t.Logf("Checking that %q (%s) is accepted", prefix, routeTest.Description)
+				Await(t, dut2, locRibPrefix(prefix), prefix)
+			}
+		}
+	}
+}
+
+func TestMixedPrefixSet(t *testing.T) {
+	// A single prefix set holding a prefix of each family rejects the matching routes of both.
+	prefixSets := map[string]oc.E_PrefixSet_Mode{"mixed": oc.PrefixSet_Mode_MIXED}
+	prefixes := map[string][2]string{
+		"10.33.0.0/16":     {"mixed", "exact"},
+		"2001:db8:33::/48": {"mixed", "exact"},
+	}
+	routeTests := []*policytest.RouteTestCase{{
+		Description:    "IPv4 match",
+		Input:          policytest.TestRoute{ReachPrefix: "10.33.0.0/16"},
+		ExpectedResult: policytest.RouteDiscarded,
+	}, {
+		Description:    "IPv4 no match",
+		Input:          policytest.TestRoute{ReachPrefix: "10.34.0.0/16"},
+		ExpectedResult: policytest.RouteAccepted,
+	}, {
+		Description:    "IPv6 match",
+		Input:          policytest.TestRoute{ReachPrefix: "2001:db8:33::/48"},
+		ExpectedResult: policytest.RouteDiscarded,
+	}, {
+		Description:    "IPv6 no match",
+		Input:          policytest.TestRoute{ReachPrefix: "2001:db8:34::/48"},
+		ExpectedResult: policytest.RouteAccepted,
+	}}
+
+	for _, invert := range []bool{false, true} {
+		t.Run(fmt.Sprintf("invert=%v", invert), func(t *testing.T) {
+			testDualStackPrefixSets(t, prefixSets, prefixes, invert, routeTests)
+		})
+	}
+}
+
+func TestPrefixSetOpenEndedRange(t *testing.T) {
+	// Ranges exceeding the maximum length of the family are rejected.
+	dut, stop := newLemming(t, 1, 64500, nil)
+	prefixSetPath := ocpath.Root().RoutingPolicy().DefinedSets().PrefixSet("invalid")
+	Replace(t, dut, prefixSetPath.Mode().Config(), oc.PrefixSet_Mode_IPV4)
+	ReplaceExpectFail(t, dut, prefixSetPath.Prefix("10.40.0.0/24", "24..33").IpPrefix().Config(), "10.40.0.0/24")
+	ReplaceExpectFail(t, dut, prefixSetPath.Prefix("10.40.0.0/24", "64..128").IpPrefix().Config(), "10.40.0.0/24")
+	stop()
+
+	// Ranges up to the maximum length of the family match all more-specific prefixes.
+	prefixSets := map[string]oc.E_PrefixSet_Mode{
+		"v4": oc.PrefixSet_Mode_IPV4,
+		"v6": oc.PrefixSet_Mode_IPV6,
+	}
+	prefixes := map[string][2]string{
+		"10.40.0.0/24":     {"v4", "24..32"},
+		"2001:db8:40::/64": {"v6", "64..128"},
+	}
+	testDualStackPrefixSets(t, prefixSets, prefixes, false, []*policytest.RouteTestCase{{
+		Description:    "IPv4 lower end of mask length",
+		Input:          policytest.TestRoute{ReachPrefix: "10.40.0.0/24"},
+		ExpectedResult: policytest.RouteDiscarded,
+	}, {
+		Description:    "IPv4 upper end of mask length",
+		Input:          policytest.TestRoute{ReachPrefix: "10.40.0.255/32"},
+		ExpectedResult: policytest.RouteDiscarded,
+	}, {
+		Description:    "IPv4 mask length too short",
+		Input:          policytest.TestRoute{ReachPrefix: "10.40.0.0/23"},
+		ExpectedResult: policytest.RouteAccepted,
+	}, {
+		Description:    "IPv4 no match",
+		Input:          policytest.TestRoute{ReachPrefix: "10.41.0.1/32"},
+		ExpectedResult: policytest.RouteAccepted,
+	}, {
+		Description:    "IPv6 lower end of mask length",
+		Input:          policytest.TestRoute{ReachPrefix: "2001:db8:40::/64"},
+		ExpectedResult: policytest.RouteDiscarded,
+	}, {
+		Description:    "IPv6 upper end of mask length",
+		Input:          policytest.TestRoute{ReachPrefix: "2001:db8:40::ffff/128"},
+		ExpectedResult: policytest.RouteDiscarded,
+	}, {
+		Description:    "IPv6 mask length too short",
+		Input:          policytest.TestRoute{ReachPrefix: "2001:db8:40::/63"},
+		ExpectedResult: policytest.RouteAccepted,
+	}, {
+		Description:    "IPv6 no match",
+		Input:          policytest.TestRoute{ReachPrefix: "2001:db8:41::1/128"},
+		ExpectedResult: policytest.RouteAccepted,
+	}})
+}
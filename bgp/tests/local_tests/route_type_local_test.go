@@ -0,0 +1,120 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openconfig/lemming/bgp"
+	"github.com/openconfig/lemming/gnmi/oc"
+	"github.com/openconfig/lemming/gnmi/oc/ocpath"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestRouteTypeLocal(t *testing.T) {
+	dut1, stop1 := newLemming(t, 1, 64500, []*AddIntfAction{{
+		name:    "eth1",
+		ifindex: 1,
+		enabled: true,
+		prefix:  "192.0.2.1/31",
+		niName:  "DEFAULT",
+	}})
+	defer stop1()
+	dut2, stop2 := newLemming(t, 2, 64501, []*AddIntfAction{{
+		name:    "eth1",
+		ifindex: 1,
+		enabled: true,
+		prefix:  "198.51.100.1/31",
+		niName:  "DEFAULT",
+	}})
+	defer stop2()
+	dut3, stop3 := newLemming(t, 3, 64501, nil)
+	defer stop3()
+
+	// dut2 lowers the local preference of eBGP-learned routes and advertises
+	// its locally originated routes unchanged. Any other route is rejected by
+	// the default export policy.
+	policyName := "by-route-type"
+	policy := &oc.RoutingPolicy_PolicyDefinition_Statement_OrderedMap{}
+	external, err := policy.AppendNew("external")
+	if err != nil {
+		t.Fatalf("Cannot append new BGP policy statement: %v", err)
+	}
+	external.GetOrCreateConditions().GetOrCreateBgpConditions().SetRouteType(oc.BgpConditions_RouteType_EXTERNAL)
+	external.GetOrCreateActions().GetOrCreateBgpActions().SetSetLocalPref(90)
+	external.GetOrCreateActions().SetPolicyResult(oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE)
+	local, err := policy.AppendNew("local")
+	if err != nil {
+		t.Fatalf("Cannot append new BGP policy statement: %v", err)
+	}
+	local.GetOrCreateConditions().SetInstallProtocolEq(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC)
+	local.GetOrCreateActions().SetPolicyResult(oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE)
+	Replace(t, dut2, ocpath.Root().RoutingPolicy().PolicyDefinition(policyName).Config(), &oc.RoutingPolicy_PolicyDefinition{Name: ygot.String(policyName), Statement: policy})
+
+	Replace(t, dut1, bgp.BGPPath.Neighbor(dut2.RouterID).ApplyPolicy().DefaultExportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Replace(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).ApplyPolicy().DefaultImportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_ACCEPT_ROUTE)
+	Replace(t, dut2, bgp.BGPPath.Neighbor(dut3.RouterID).ApplyPolicy().ExportPolicy().Config(), []string{policyName})
+	Replace(t, dut2, bgp.BGPPath.Neighbor(dut3.RouterID).ApplyPolicy().DefaultExportPolicy().Config(), oc.RoutingPolicy_DefaultPolicyType_REJECT_ROUTE)
+
+	establishSessionPairs(t, []DevicePair{{dut1, dut2}, {dut2, dut3}}...)
+
+	Await(t, dut2, bgp.BGPPath.Neighbor(dut3.RouterID).ApplyPolicy().ExportPolicy().State(), []string{policyName})
+
+	external1 := "10.1.0.0/16"
+	local2 := "10.2.0.0/16"
+	for dut, route := range map[*Device]struct{ prefix, nextHop string }{
+		dut1: {external1, "192.0.2.1"},
+		dut2: {local2, "198.51.100.1"},
+	} {
+		installStaticRoute(t, dut, &oc.NetworkInstance_Protocol_Static{
+			Prefix: ygot.String(route.prefix),
+			NextHop: map[string]*oc.NetworkInstance_Protocol_Static_NextHop{
+				"single": {
+					Index:   ygot.String("single"),
+					NextHop: oc.UnionString(route.nextHop),
+					Recurse: ygot.Bool(true),
+				},
+			},
+		})
+	}
+
+	attrSetMap, _ := Lookup(t, dut3, bgp.BGPPath.Rib().AttrSetMap().State()).Val()
+	updateAttrSetMap := func() {
+		attrSetMap, _ = Lookup(t, dut3, bgp.BGPPath.Rib().AttrSetMap().State()).Val()
+	}
+	v4uni := bgp.BGPPath.Rib().AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Ipv4Unicast()
+	for prefix, wantLocalPref := range map[string]uint32{
+		external1: 90,
+		local2:    100,
+	} {
+		Await(t, dut3, v4uni.Neighbor(dut2.RouterID).AdjRibInPre().Route(prefix, 0).Prefix().State(), prefix)
+		if diff := awaitNoDiff(func() string {
+			attrs, err := getAttrs(t, dut3, attrSetMap, v4uni.Neighbor(dut2.RouterID).AdjRibInPre().Route(prefix, 0).AttrIndex().State())
+			if err != nil {
+				return err.Error()
+			}
+			if attrs == nil {
+				return "route not present"
+			}
+			if got := attrs.GetLocalPref(); got != wantLocalPref {
+				return fmt.Sprintf("got local-pref %d, want %d", got, wantLocalPref)
+			}
+			return ""
+		}, updateAttrSetMap); diff != "" {
+			t.Errorf("DUT %v AdjRibInPre attributes (prefix %s): %s", dut3.ID, prefix, diff)
+		}
+	}
+}
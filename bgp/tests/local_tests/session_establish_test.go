@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net"
 	"net/netip"
+	"slices"
 	"sync/atomic"
 	"testing"
 
@@ -128,11 +129,12 @@ func bgpWithNbr(as uint32, routerID string, nbr *oc.NetworkInstance_Protocol_Bgp
 	return bgp
 }
 
-func newLemming(t *testing.T, id uint, as uint32, connectedIntfs []*AddIntfAction) (*Device, func()) {
+func newLemming(t *testing.T, id uint, as uint32, connectedIntfs []*AddIntfAction, extraOpts ...lemming.Option) (*Device, func()) {
 	routerID := nextLocalHostAddr()
 	gnmiTarget := net.JoinHostPort(routerID, "7339")
 	gribiTarget := net.JoinHostPort(routerID, "7340")
 	opts := []lemming.Option{lemming.WithTransportCreds(insecure.NewCredentials()), lemming.WithGRIBIAddr(gribiTarget), lemming.WithGNMIAddr(gnmiTarget), lemming.WithBGPPort(1111)}
+	opts = append(opts, extraOpts...)
 
 	target := fmt.Sprintf("dut%d", id)
 
@@ -219,3 +221,136 @@ func TestSessionEstablish(t *testing.T) {
 
 	establishSessionPairs(t, DevicePair{first: dut1, second: dut2})
 }
+
+func TestPassiveSessionEstablish(t *testing.T) {
+	dut1, stop1 := newLemming(t, 1, 64500, nil)
+	defer stop1()
+	dut2, stop2 := newLemming(t, 2, 64501, nil)
+	defer stop2()
+
+	// dut1 only accepts the session, so it must be initiated by dut2.
+	dut1Conf := bgpWithNbr(dut1.AS, dut1.RouterID, &oc.NetworkInstance_Protocol_Bgp_Neighbor{
+		PeerAs:          ygot.Uint32(dut2.AS),
+		NeighborAddress: ygot.String(dut2.RouterID),
+		NeighborPort:    ygot.Uint16(dut2.bgpPort),
+		Transport: &oc.NetworkInstance_Protocol_Bgp_Neighbor_Transport{
+			LocalAddress: ygot.String(dut1.RouterID),
+			PassiveMode:  ygot.Bool(true),
+		},
+	})
+	dut2Conf := bgpWithNbr(dut2.AS, dut2.RouterID, &oc.NetworkInstance_Protocol_Bgp_Neighbor{
+		PeerAs:          ygot.Uint32(dut1.AS),
+		NeighborAddress: ygot.String(dut1.RouterID),
+		NeighborPort:    ygot.Uint16(dut1.bgpPort),
+		Transport: &oc.NetworkInstance_Protocol_Bgp_Neighbor_Transport{
+			LocalAddress: ygot.String(dut2.RouterID),
+		},
+	})
+	Update(t, dut1, bgp.BGPPath.Config(), dut1Conf)
+	Update(t, dut2, bgp.BGPPath.Config(), dut2Conf)
+
+	awaitSessionEstablished(t, dut1, dut2)
+}
+
+func TestSessionStateTransitions(t *testing.T) {
+	dut1, stop1 := newLemming(t, 1, 64500, nil)
+	defer stop1()
+	dut2, stop2 := newLemming(t, 2, 64501, nil)
+	defer stop2()
+
+	// dut1 is passive, so that the session isn't reset by a connection collision.
+	Update(t, dut1, bgp.BGPPath.Config(), bgpWithNbr(dut1.AS, dut1.RouterID, &oc.NetworkInstance_Protocol_Bgp_Neighbor{
+		PeerAs:          ygot.Uint32(dut2.AS),
+		NeighborAddress: ygot.String(dut2.RouterID),
+		NeighborPort:    ygot.Uint16(dut2.bgpPort),
+		Transport: &oc.NetworkInstance_Protocol_Bgp_Neighbor_Transport{
+			LocalAddress: ygot.String(dut1.RouterID),
+			PassiveMode:  ygot.Bool(true),
+		},
+	}))
+
+	var states []oc.E_Bgp_Neighbor_SessionState
+	w := Watch(t, dut2, bgp.BGPPath.Neighbor(dut1.RouterID).SessionState().State(), awaitTimeLimit, func(v *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+		state, ok := v.Val()
+		if !ok {
+			return false
+		}
+		states = append(states, state)
+		return state == oc.Bgp_Neighbor_SessionState_ESTABLISHED
+	})
+	Update(t, dut2, bgp.BGPPath.Config(), bgpWithNbr(dut2.AS, dut2.RouterID, &oc.NetworkInstance_Protocol_Bgp_Neighbor{
+		PeerAs:          ygot.Uint32(dut1.AS),
+		NeighborAddress: ygot.String(dut1.RouterID),
+		NeighborPort:    ygot.Uint16(dut1.bgpPort),
+		Transport: &oc.NetworkInstance_Protocol_Bgp_Neighbor_Transport{
+			LocalAddress: ygot.String(dut2.RouterID),
+		},
+	}))
+	if _, ok := w.Await(t); !ok {
+		t.Fatalf("Session state never became ESTABLISHED, got states %v", states)
+	}
+
+	// Connection attempts may be retried before the session comes up, but
+	// the open exchange is always observed in order.
+	want := []oc.E_Bgp_Neighbor_SessionState{
+		oc.Bgp_Neighbor_SessionState_OPENSENT,
+		oc.Bgp_Neighbor_SessionState_OPENCONFIRM,
+		oc.Bgp_Neighbor_SessionState_ESTABLISHED,
+	}
+	if len(states) < len(want) || !slices.Equal(states[len(states)-len(want):], want) {
+		t.Errorf("Session state transitions: got %v, want them to end with %v", states, want)
+	}
+	if states[0] != oc.Bgp_Neighbor_SessionState_IDLE && states[0] != oc.Bgp_Neighbor_SessionState_ACTIVE {
+		t.Errorf("Initial session state: got %v, want IDLE or ACTIVE", states[0])
+	}
+}
+
+func TestDynamicNeighborSessionEstablish(t *testing.T) {
+	dut1, stop1 := newLemming(t, 1, 64500, nil)
+	defer stop1()
+	dut2, stop2 := newLemming(t, 2, 64501, nil)
+	defer stop2()
+
+	// dut1 has no explicit neighbours and accepts any peer on localhost.
+	dut1Conf := &oc.NetworkInstance_Protocol_Bgp{}
+	dut1Conf.GetOrCreateGlobal().As = ygot.Uint32(dut1.AS)
+	dut1Conf.GetOrCreateGlobal().RouterId = ygot.String(dut1.RouterID)
+	dut1Conf.GetOrCreatePeerGroup("route-server-clients").PeerAs = ygot.Uint32(dut2.AS)
+	dut1Conf.GetOrCreateGlobal().GetOrCreateDynamicNeighborPrefix("127.0.0.0/8").PeerGroup = ygot.String("route-server-clients")
+
+	dut2Conf := bgpWithNbr(dut2.AS, dut2.RouterID, &oc.NetworkInstance_Protocol_Bgp_Neighbor{
+		PeerAs:          ygot.Uint32(dut1.AS),
+		NeighborAddress: ygot.String(dut1.RouterID),
+		NeighborPort:    ygot.Uint16(dut1.bgpPort),
+		Transport: &oc.NetworkInstance_Protocol_Bgp_Neighbor_Transport{
+			LocalAddress: ygot.String(dut2.RouterID),
+		},
+	})
+	Update(t, dut1, bgp.BGPPath.Config(), dut1Conf)
+	Update(t, dut2, bgp.BGPPath.Config(), dut2Conf)
+
+	awaitSessionEstablished(t, dut1, dut2)
+}
+
+func TestAdminShutdown(t *testing.T) {
+	dut1, stop1 := newLemming(t, 1, 64500, nil)
+	defer stop1()
+	dut2, stop2 := newLemming(t, 2, 64501, nil)
+	defer stop2()
+
+	establishSessionPairs(t, DevicePair{first: dut1, second: dut2})
+
+	// The description of the neighbour is sent as the shutdown communication.
+	nbr := bgp.BGPPath.Neighbor(dut2.RouterID)
+	Replace(t, dut1, nbr.Description().Config(), "planned maintenance")
+	Replace(t, dut1, nbr.Enabled().Config(), false)
+
+	received := bgp.BGPPath.Neighbor(dut1.RouterID).Messages().Received()
+	Await(t, dut2, received.LastNotificationErrorCode().State(), oc.BgpTypes_BGP_ERROR_CODE_CEASE)
+	Await(t, dut2, received.LastNotificationErrorSubcode().State(), oc.BgpTypes_BGP_ERROR_SUBCODE_ADMINISTRATIVE_SHUTDOWN)
+	Await(t, dut1, nbr.SessionState().State(), oc.Bgp_Neighbor_SessionState_IDLE)
+
+	// Enabling the neighbour again restores the session.
+	Replace(t, dut1, nbr.Enabled().Config(), true)
+	awaitSessionEstablished(t, dut1, dut2)
+}
@@ -32,6 +32,7 @@ import (
 
 // Generates returns a map of files containing the generated code code.
 func Generate(doc *docparser.SAIInfo, sai *saiast.SAIAPI) (map[string]string, error) {
+	doc = withLemmingAttrs(doc)
 	files := map[string]string{}
 	common, err := generateCommonTypes(doc)
 	if err != nil {
@@ -57,6 +58,55 @@ func Generate(doc *docparser.SAIInfo, sai *saiast.SAIAPI) (map[string]string, er
 	return files, nil
 }
 
+// lemmingAttrs are attributes that lemming adds to SAI objects, they are appended after the SAI attributes of the type.
+// Their attribute IDs are in the custom range SAI reserves for each type, in the order listed here.
+// They are only available to gRPC clients.
+var lemmingAttrs = map[string]*docparser.Attr{
+	"HOSTIF_TRAP": {
+		CreateFields: []*docparser.AttrTypeName{hostifTrapRedirectPort},
+		SetFields:    []*docparser.AttrTypeName{hostifTrapRedirectPort},
+		ReadFields:   []*docparser.AttrTypeName{hostifTrapRedirectPort},
+	},
+}
+
+// hostifTrapRedirectPort is the port that packets matching a trap are transmitted to, instead of the CPU port.
+var hostifTrapRedirectPort = &docparser.AttrTypeName{
+	MemberName: "redirect_port",
+	SaiType:    "sai_object_id_t",
+	EnumName:   "SAI_HOSTIF_TRAP_ATTR_REDIRECT_PORT",
+}
+
+// saiAttrCustomRangeStart is the first attribute ID of the custom range of a type, SAI_<TYPE>_ATTR_CUSTOM_RANGE_START.
+const saiAttrCustomRangeStart = 0x10000000
+
+// attrEnumValue returns the value of the attribute enum of the i-th attribute of a type.
+// Like the enums of SAI types, the values are the SAI values offset by 1 to leave 0 unspecified.
+func attrEnumValue(typeName string, i int, attr *docparser.AttrTypeName) int {
+	if extra, ok := lemmingAttrs[typeName]; ok {
+		if j := slices.Index(extra.ReadFields, attr); j >= 0 {
+			return saiAttrCustomRangeStart + j + 1
+		}
+	}
+	return i + 1
+}
+
+// withLemmingAttrs returns a copy of doc with the lemmingAttrs appended to the attributes of their types.
+func withLemmingAttrs(doc *docparser.SAIInfo) *docparser.SAIInfo {
+	withAttrs := *doc
+	withAttrs.Attrs = map[string]*docparser.Attr{}
+	for name, attr := range doc.Attrs {
+		withAttrs.Attrs[name] = attr
+		if extra, ok := lemmingAttrs[name]; ok {
+			withAttrs.Attrs[name] = &docparser.Attr{
+				CreateFields: append(slices.Clone(attr.CreateFields), extra.CreateFields...),
+				SetFields:    append(slices.Clone(attr.SetFields), extra.SetFields...),
+				ReadFields:   append(slices.Clone(attr.ReadFields), extra.ReadFields...),
+			}
+		}
+	}
+	return &withAttrs
+}
+
 func rangeInOrder[T any](m map[string]T, pred func(key string, val T) error) error {
 	keys := lemmingutil.Mapkeys(m)
 	slices.Sort(keys)
@@ -236,7 +286,7 @@ func populateTmplDataFromFunc(apis map[string]*protoAPITmplData, docInfo *docpar
 		// For the attributes, generate code for the type if needed.
 		for i, attr := range docInfo.Attrs[meta.TypeName].ReadFields {
 			attrEnum.Values = append(attrEnum.Values, protoEnumValues{
-				Index: i + 1,
+				Index: attrEnumValue(meta.TypeName, i, attr),
 				Name:  strings.TrimPrefix(attr.EnumName, "SAI_"),
 			})
 			// Handle function pointers as streaming RPCs.
@@ -320,7 +370,7 @@ func createAttrs(startIdx int, typeName string, xmlInfo *docparser.SAIInfo, attr
 		}
 		for i, val := range xmlInfo.Attrs[typeName].ReadFields {
 			if val == attr {
-				field.Option = fmt.Sprintf("[(attr_enum_value) = %d]", i+1)
+				field.Option = fmt.Sprintf("[(attr_enum_value) = %d]", attrEnumValue(typeName, i, attr))
 			}
 		}
 		field.ProtoType = typ
@@ -373,8 +423,10 @@ syntax = "proto3";
 
 package lemming.dataplane.sai;
 	
+import "google/protobuf/any.proto";
 import "google/protobuf/timestamp.proto";
 import "google/protobuf/descriptor.proto";
+import "google/rpc/status.proto";
 
 option go_package = "github.com/openconfig/lemming/dataplane/proto/sai";
 
@@ -408,10 +460,21 @@ message UninitializeRequest {
 message UninitializeResponse {
 }
 
+message SetAttributesRequest {
+	// Set*AttributeRequest messages, for any number of objects.
+	repeated google.protobuf.Any reqs = 1;
+}
+
+message SetAttributesResponse {
+	// The status of each request, requests for the same object share its status.
+	repeated google.rpc.Status statuses = 1;
+}
+
 service Entrypoint {
   rpc ObjectTypeQuery(ObjectTypeQueryRequest) returns (ObjectTypeQueryResponse) {}
   rpc Initialize(InitializeRequest) returns (InitializeResponse) {}
   rpc Uninitialize(UninitializeRequest) returns (UninitializeResponse) {}
+  rpc SetAttributes(SetAttributesRequest) returns (SetAttributesResponse) {}
 }
 {{ range .Enums }}
 enum {{ .Name }} {
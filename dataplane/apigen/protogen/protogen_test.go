@@ -30,8 +30,10 @@ syntax = "proto3";
 
 package lemming.dataplane.sai;
 	
+import "google/protobuf/any.proto";
 import "google/protobuf/timestamp.proto";
 import "google/protobuf/descriptor.proto";
+import "google/rpc/status.proto";
 
 option go_package = "github.com/openconfig/lemming/dataplane/proto/sai";
 
@@ -304,10 +306,21 @@ message UninitializeRequest {
 message UninitializeResponse {
 }
 
+message SetAttributesRequest {
+	// Set*AttributeRequest messages, for any number of objects.
+	repeated google.protobuf.Any reqs = 1;
+}
+
+message SetAttributesResponse {
+	// The status of each request, requests for the same object share its status.
+	repeated google.rpc.Status statuses = 1;
+}
+
 service Entrypoint {
   rpc ObjectTypeQuery(ObjectTypeQueryRequest) returns (ObjectTypeQueryResponse) {}
   rpc Initialize(InitializeRequest) returns (InitializeResponse) {}
   rpc Uninitialize(UninitializeRequest) returns (UninitializeResponse) {}
+  rpc SetAttributes(SetAttributesRequest) returns (SetAttributesResponse) {}
 }
 `
 )
@@ -382,6 +395,27 @@ message FooAttribute {
 	repeated uint32 sample_list = 1 [(attr_enum_value) = 1];
 	optional uint32 sample_int = 2 [(attr_enum_value) = 2];
 }
+`,
+		},
+	}, {
+		desc:  "lemming attribute",
+		inAst: &saiast.SAIAPI{},
+		inInfo: &docparser.SAIInfo{
+			Attrs: map[string]*docparser.Attr{
+				"HOSTIF_TRAP": {
+					ReadFields: []*docparser.AttrTypeName{{
+						SaiType:    "sai_uint32_t",
+						MemberName: "trap_priority",
+					}},
+				},
+			},
+		},
+		want: map[string]string{
+			"common.proto": commonType + `
+message HostifTrapAttribute {
+	optional uint32 trap_priority = 1 [(attr_enum_value) = 1];
+	optional uint64 redirect_port = 2 [(attr_enum_value) = 268435457];
+}
 `,
 		},
 	}, {
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/vishvananda/netlink"
@@ -95,6 +96,8 @@ func (sink *Sink) ReceivePackets(ctx context.Context) error {
 		return err
 	}
 	ports := map[string]*kernel.GenetlinkPort{}
+	// Netdev hostifs may share a genetlink family and group, so reuse the sockets.
+	groups := map[string]*kernel.GenetlinkPort{}
 
 	for {
 		msg, err := subClient.Recv()
@@ -106,10 +109,15 @@ func (sink *Sink) ReceivePackets(ctx context.Context) error {
 			switch desc := resp.Port.Port; desc.PortType {
 			case fwdpb.PortType_PORT_TYPE_GENETLINK:
 				portDesc := desc.GetGenetlink()
-				p, err := kernel.NewGenetlinkPort(portDesc.FamilyName, portDesc.GroupName)
-				if err != nil {
-					log.Errorf("failed to create port: %v", err)
-					continue
+				key := portDesc.FamilyName + "/" + portDesc.GroupName
+				p, ok := groups[key]
+				if !ok {
+					p, err = kernel.NewGenetlinkPort(portDesc.FamilyName, portDesc.GroupName)
+					if err != nil {
+						log.Errorf("failed to create port: %v", err)
+						continue
+					}
+					groups[key] = p
 				}
 				ports[resp.Port.Port.PortId.ObjectId.Id] = p
 				log.Infof("add to new genetlink port: %v %v", portDesc.FamilyName, portDesc.GroupName)
@@ -150,12 +158,26 @@ func (sink *Sink) ReceivePackets(ctx context.Context) error {
 				log.Infof("add to new netdev port: %v", name)
 			}
 
+		case *fwdpb.PacketSinkResponse_PortRemoval:
+			id := resp.PortRemoval.GetPortId().GetObjectId().GetId()
+			// The genetlink socket may be shared with other hostifs, so it is left open.
+			delete(ports, id)
+			for name, portID := range sink.ethDevToPort {
+				if portID == id {
+					delete(sink.ethDevToPort, name)
+					delete(sink.ethDevToPortNID, name)
+				}
+			}
+			log.Infof("removed port: %v", id)
 		case *fwdpb.PacketSinkResponse_Packet:
 			p, ok := ports[resp.Packet.Egress.ObjectId.Id]
 			if !ok {
 				log.Infof("skipping port with id %v", resp.Packet.Egress.ObjectId.Id)
+				continue
 			}
-			if _, err := p.Write(resp.Packet.Bytes, &kernel.PacketMetadata{}); err != nil {
+			// The context identifies the hostif the packet is punted to.
+			hostifID, _ := strconv.Atoi(resp.Packet.Egress.ObjectId.Id)
+			if _, err := p.Write(resp.Packet.Bytes, &kernel.PacketMetadata{Context: hostifID}); err != nil {
 				log.Warningf("failed to write packet: %v", err)
 			}
 		}
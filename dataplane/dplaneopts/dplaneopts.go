@@ -14,16 +14,30 @@
 
 package dplaneopts
 
-import fwdpb "github.com/openconfig/lemming/proto/forwarding"
+import (
+	"time"
+
+	pktiopb "github.com/openconfig/lemming/dataplane/proto/packetio"
+	fwdpb "github.com/openconfig/lemming/proto/forwarding"
+)
+
+// DefaultContextID is the ID of the forwarding context if none is set.
+const DefaultContextID = "lucius"
 
 // Options configures the dataplane
 type Options struct {
 	// AddrPort is the address of the gRPC server.
 	AddrPort string
+	// ContextID is the ID of the forwarding context of the switch.
+	ContextID string
 	// Reconcilation enabes gNMI reconcilation.
 	Reconcilation bool
 	// HostifNetDevType is the fwdpb type for the saipb hostif netdev types.
 	HostifNetDevType fwdpb.PortType
+	// HostifGenetlinkFamily is the genetlink family netdev hostifs punt to, if HostifNetDevType is GENETLINK.
+	HostifGenetlinkFamily string
+	// HostifGenetlinkGroup is the multicast group netdev hostifs punt to, if HostifNetDevType is GENETLINK.
+	HostifGenetlinkGroup string
 	// PortType is the fwdpb type for the port type.
 	PortType fwdpb.PortType
 	// PortConfigFile is the path of the port config.
@@ -36,6 +50,43 @@ type Options struct {
 	// RemoteCPUPort enables sending all packets for the CPU over gRPC.
 	// TODO: In the future, only support this option.
 	RemoteCPUPort bool
+	// RemotePortRetries is the number of times a hostif create or remove is retried if the remote port control fails it.
+	RemotePortRetries int
+	// RemotePortRetryBackoff is the delay before the first retry, it doubles after every retry.
+	RemotePortRetryBackoff time.Duration
+	// RemotePortTimeout is the time the remote agent has to reply to a port control request, and the time spent retrying
+	// a failed request, zero waits forever.
+	RemotePortTimeout time.Duration
+	// RemotePortReplayBatch is the number of hostifs replayed to a reconnecting remote agent before waiting for its replies.
+	RemotePortReplayBatch int
+	// CPUPacketQueueDepth is the number of packets received from the remote CPU port that are queued before they are injected.
+	CPUPacketQueueDepth int
+	// DeterministicOIDs allocates SAI object ids per object type.
+	DeterministicOIDs bool
+	// ProgrammingDelay is the time taken to program each table entry or attribute update.
+	ProgrammingDelay time.Duration
+	// DryRun validates hostif operations without programming them in the dataplane.
+	DryRun bool
+	// ChecksumValidation drops ingress packets with incorrect L3/L4 checksums.
+	ChecksumValidation bool
+	// PortIngressACLGroups is the number of distinct ACL table groups that can be bound to ports at the ingress stage.
+	PortIngressACLGroups int
+	// PortEgressACLGroups is the number of distinct ACL table groups that can be bound to ports at the egress stage.
+	PortEgressACLGroups int
+	// StrictSerialization handles SAI create, remove and set requests one at a time.
+	StrictSerialization bool
+	// PortLinks connects pairs of ports by device name (eth1 -> eth2), linked ports auto-negotiate their speed.
+	PortLinks map[string]string
+	// HostPortControlMetrics observes the host port control messages sent to the remote agent.
+	HostPortControlMetrics HostPortControlMetrics
+}
+
+// HostPortControlMetrics observes the host port control messages sent to the remote agent.
+// It is called with the hostif lock held, so it must not block.
+type HostPortControlMetrics interface {
+	// RequestDone is called once for each message sent to the agent, including retries, when its reply is received
+	// or it fails. latency is the time from sending the message until then, err is nil if the agent applied the message.
+	RequestDone(msg *pktiopb.HostPortControlMessage, latency time.Duration, err error)
 }
 
 // Option exposes additional configuration for the dataplane.
@@ -49,6 +100,15 @@ func WithAddrPort(addrPort string) Option {
 	}
 }
 
+// WithContextID sets the ID of the forwarding context of the switch.
+// Each switch in a process, e.g. each ASIC of an emulated chassis, must use a different context.
+// Default: lucius
+func WithContextID(id string) Option {
+	return func(o *Options) {
+		o.ContextID = id
+	}
+}
+
 // WithReconcilation enables the gNMI reconcilation.
 // Default: true
 func WithReconcilation(rec bool) Option {
@@ -65,6 +125,17 @@ func WithHostifNetDevPortType(t fwdpb.PortType) Option {
 	}
 }
 
+// WithHostifNetDevGenetlink multiplexes the punts of all saipb hostif NETDEV onto the genetlink family and multicast group,
+// instead of creating a network device per hostif. The hostif of a punted packet is identified by its egress port.
+// Default: family "lucius", group "packets", used if the port type is set with WithHostifNetDevPortType.
+func WithHostifNetDevGenetlink(family, group string) Option {
+	return func(o *Options) {
+		o.HostifNetDevType = fwdpb.PortType_PORT_TYPE_GENETLINK
+		o.HostifGenetlinkFamily = family
+		o.HostifGenetlinkGroup = group
+	}
+}
+
 // WithPortType sets the lucius port type for saipb ports.
 // Default: fwdpb.PortType_PORT_TYPE_KERNEL
 func WithPortType(t fwdpb.PortType) Option {
@@ -105,6 +176,116 @@ func WithRemoteCPUPort(enable bool) Option {
 	}
 }
 
+// WithRemotePortRetry retries creating or removing a remote hostif up to retries times if the remote port control fails it,
+// waiting backoff before the first retry and doubling it after every retry.
+// Default: 3 retries, 100ms backoff
+func WithRemotePortRetry(retries int, backoff time.Duration) Option {
+	return func(o *Options) {
+		o.RemotePortRetries = retries
+		o.RemotePortRetryBackoff = backoff
+	}
+}
+
+// WithRemotePortTimeout fails a port control request, and the stream to the remote agent, if the agent doesn't reply within timeout.
+// The agent is expected to reconnect, all hostifs are replayed to it. Zero waits for the agent forever.
+// Default: 30s
+func WithRemotePortTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.RemotePortTimeout = timeout
+	}
+}
+
+// WithRemotePortReplayBatch limits the hostifs replayed to a reconnecting remote agent to size requests in flight:
+// a batch is sent, then all its replies are awaited before sending the next one. Larger batches replay faster,
+// but the agent's replies to a batch must fit in the stream's flow control window.
+// Default: 64
+func WithRemotePortReplayBatch(size int) Option {
+	return func(o *Options) {
+		o.RemotePortReplayBatch = size
+	}
+}
+
+// WithCPUPacketQueueDepth queues up to depth packets received from the remote CPU port while earlier packets are injected.
+// Once the queue is full, newly received packets are dropped, so a slow dataplane doesn't stall the stream.
+// Default: 1024
+func WithCPUPacketQueueDepth(depth int) Option {
+	return func(o *Options) {
+		o.CPUPacketQueueDepth = depth
+	}
+}
+
+// WithDeterministicOIDs allocates SAI object ids per object type, so an object's id only depends
+// on the order objects of the same type are created.
+// Default: false
+func WithDeterministicOIDs(enable bool) Option {
+	return func(o *Options) {
+		o.DeterministicOIDs = enable
+	}
+}
+
+// WithStrictSerialization handles SAI create, remove and set requests one at a time,
+// trading throughput for determinism. This helps isolate bugs caused by concurrent SAI operations racing in the dataplane.
+// Default: false
+func WithStrictSerialization(enable bool) Option {
+	return func(o *Options) {
+		o.StrictSerialization = enable
+	}
+}
+
+// WithProgrammingDelay emulates the latency of programming an ASIC: table entry adds and removes
+// and attribute updates are applied asynchronously, in order, each taking delay to complete.
+// Default: 0 (programmed synchronously)
+func WithProgrammingDelay(delay time.Duration) Option {
+	return func(o *Options) {
+		o.ProgrammingDelay = delay
+	}
+}
+
+// WithDryRun validates hostif create, set and remove requests and allocates their object ids,
+// but only logs the dataplane programming, remote port requests and port notifications they would make.
+// Default: false
+func WithDryRun(enable bool) Option {
+	return func(o *Options) {
+		o.DryRun = enable
+	}
+}
+
+// WithChecksumValidation drops and counts ingress packets with incorrect IPv4 header or TCP/UDP checksums.
+// Default: false
+func WithChecksumValidation(enable bool) Option {
+	return func(o *Options) {
+		o.ChecksumValidation = enable
+	}
+}
+
+// WithPortACLGroupLimits sets the number of distinct ACL table groups that can be bound to ports at the
+// ingress and egress stages. Binding the same group to several ports only uses it once.
+// Default: 16 ingress, 16 egress
+func WithPortACLGroupLimits(ingress, egress int) Option {
+	return func(o *Options) {
+		o.PortIngressACLGroups = ingress
+		o.PortEgressACLGroups = egress
+	}
+}
+
+// WithPortLinks connects pairs of the switch's ports by device name (eth1 -> eth2), modeling a cable between them.
+// Linked ports with auto-negotiation enabled negotiate the highest speed both advertise.
+// Default: none
+func WithPortLinks(links map[string]string) Option {
+	return func(o *Options) {
+		o.PortLinks = links
+	}
+}
+
+// WithHostPortControlMetrics observes the host port control messages sent to the remote agent,
+// e.g. to count the messages that succeed and fail, and to measure their latency.
+// Default: nil
+func WithHostPortControlMetrics(m HostPortControlMetrics) Option {
+	return func(o *Options) {
+		o.HostPortControlMetrics = m
+	}
+}
+
 // Port contains configuration data for a single port.
 type Port struct {
 	Lanes string `json:"lanes"`
@@ -120,11 +301,21 @@ type PortConfig struct {
 // ResolveOpts creates an option struct from the opts.
 func ResolveOpts(opts ...Option) *Options {
 	resolved := &Options{
-		AddrPort:         "127.0.0.1:0",
-		Reconcilation:    true,
-		HostifNetDevType: fwdpb.PortType_PORT_TYPE_TAP,
-		PortType:         fwdpb.PortType_PORT_TYPE_KERNEL,
-		PortMap:          map[string]string{},
+		AddrPort:               "127.0.0.1:0",
+		ContextID:              DefaultContextID,
+		Reconcilation:          true,
+		HostifNetDevType:       fwdpb.PortType_PORT_TYPE_TAP,
+		HostifGenetlinkFamily:  "lucius",
+		HostifGenetlinkGroup:   "packets",
+		PortType:               fwdpb.PortType_PORT_TYPE_KERNEL,
+		PortMap:                map[string]string{},
+		RemotePortRetries:      3,
+		RemotePortRetryBackoff: 100 * time.Millisecond,
+		RemotePortTimeout:      30 * time.Second,
+		RemotePortReplayBatch:  64,
+		CPUPacketQueueDepth:    1024,
+		PortIngressACLGroups:   16,
+		PortEgressACLGroups:    16,
 	}
 
 	for _, opt := range opts {
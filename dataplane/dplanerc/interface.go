@@ -915,7 +915,7 @@ func (ni *Reconciler) setupPorts(ctx context.Context) error {
 		if i.Attrs().Name == "lo" || i.Attrs().Name == "eth0" || strings.HasSuffix(i.Attrs().Name, internalSuffix) {
 			continue
 		}
-		log.Info("creating interfaces for %v", i.Attrs().Name)
+		log.Infof("creating interfaces for %v", i.Attrs().Name)
 		ocIntf := ocInterface{
 			name:    i.Attrs().Name,
 			subintf: 0,
@@ -16,10 +16,12 @@
 package forwarding
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"runtime"
 	"sync"
 
 	log "github.com/golang/glog"
@@ -64,16 +66,18 @@ type Server struct {
 	mu  sync.Mutex
 	ctx map[string]*fwdcontext.Context // forwarding contexts indexed by name
 
-	name string    // name of the forwarding engine
-	info *InfoList // list of info elements that can be queried
+	name    string      // name of the forwarding engine
+	info    *InfoList   // list of info elements that can be queried
+	workers flowWorkers // workers processing injected packets
 }
 
 // New creates a new forwarding instance using the specified name.
 func New(name string) *Server {
 	return &Server{
-		name: name,
-		ctx:  make(map[string]*fwdcontext.Context),
-		info: NewInfoList(),
+		name:    name,
+		ctx:     make(map[string]*fwdcontext.Context),
+		info:    NewInfoList(),
+		workers: newFlowWorkers(runtime.NumCPU()),
 	}
 }
 
@@ -143,6 +147,7 @@ func (e *Server) contextCreateByID(contextID *fwdpb.ContextId) error {
 
 	id := contextID.GetId()
 	ctx := fwdcontext.New(id, e.name)
+	ctx.SetIngress(e.workers.enqueue)
 	e.ctx[id] = ctx
 	e.info.AddContext(ctx)
 	return nil
@@ -742,6 +747,9 @@ func (e *Server) FlowCounterQuery(_ context.Context, request *fwdpb.FlowCounterQ
 }
 
 // InjectPacket inserts a packet in the forwarding pipeline, orginating from the specified port.
+// The packet is processed asynchronously on a copy of frame, so the caller may reuse frame once
+// InjectPacket returns. If too many packets of the flow of the packet are already queued, the
+// packet is dropped and a ResourceExhausted error is returned.
 func (e *Server) InjectPacket(contextID *fwdpb.ContextId, id *fwdpb.PortId, hid fwdpb.PacketHeaderId, frame []byte, preActions []*fwdpb.ActionDesc, debug bool, dir fwdpb.PortAction) error {
 	timer := deadlock.NewTimer(deadlock.Timeout, fmt.Sprintf("Processing packet"))
 	defer timer.Stop()
@@ -751,65 +759,63 @@ func (e *Server) InjectPacket(contextID *fwdpb.ContextId, id *fwdpb.PortId, hid
 		return fmt.Errorf("fwd: PacketInject failed, err %v", err)
 	}
 
-	// In a goroutine, acquire a RWLock on the context, create the preprocessing
-	// actions and process the packet. The RPC does not wait for the complete
-	// packet processing. It returns once it has validated the input parameters.
-	status := make(chan error)
+	// Validate the input parameters and create the preprocessing actions while
+	// holding a RLock on the context. The RPC does not wait for the packet
+	// processing. The packet is processed by the worker of its flow, so that the
+	// packets of a flow are processed in order and different flows in parallel.
+	ctx.RLock()
+	port, err := fwdport.Find(id, ctx)
+	if err != nil {
+		ctx.RUnlock()
+		return fmt.Errorf("fwd: PacketInject failed, err %v", err)
+	}
 
-	go func() {
+	packet, err := fwdpacket.New(hid, bytes.Clone(frame))
+	if err != nil {
+		ctx.RUnlock()
+		return fmt.Errorf("fwd: PacketInject failed, err %v", err)
+	}
+
+	pre, err := fwdaction.NewActions(preActions, ctx)
+	ctx.RUnlock()
+	if err != nil {
+		return fmt.Errorf("fwd: PacketInject failed to create preprocessing actions %v, err %v", preActions, err)
+	}
+
+	// Apply the preprocessing actions on the packet and inject it into the
+	// port while holding the context's RLock. After packet processing,
+	// cleanup the actions. The port is looked up again, since it may have been
+	// deleted while the packet was queued.
+	err = e.workers.enqueue(fwdport.FlowKey(port, packet), func() {
 		ctx.RLock()
 		defer ctx.RUnlock()
+		defer func() {
+			if pre != nil {
+				pre.Cleanup()
+			}
+		}()
 
 		port, err := fwdport.Find(id, ctx)
 		if err != nil {
-			status <- fmt.Errorf("fwd: PortInject failed, err %v", err)
-			return
-		}
-
-		packet, err := fwdpacket.New(hid, frame)
-		if err != nil {
-			status <- fmt.Errorf("fwd: PortInject failed, err %v", err)
-			return
-		}
-
-		pre, err := fwdaction.NewActions(preActions, ctx)
-		if err != nil {
-			status <- fmt.Errorf("fwd: PortInject failed to create preprocessing actions %v, err %v", preActions, err)
+			log.Errorf("fwd: PacketInject dropped packet, err %v", err)
 			return
 		}
-
-		// Apply the preprocessing actions on the packet and inject it into the
-		// port while holding the context's RLock. After packet processing,
-		// cleanup the port and actions. Publish a "no error" on the status
-		// channel so that the RPC can return. Note that this also serializes
-		// the packets arriving from the CPU.
-		status <- nil
-
-		func() {
-			defer func() {
-				if pre != nil {
-					pre.Cleanup()
-				}
-			}()
-
-			packet.Debug(debug)
-			if len(pre) != 0 {
-				packet.Log().WithValues("context", ctx.ID, "port", port.ID())
-				state, err := fwdaction.ProcessPacket(packet, pre, port)
-				if state != fwdaction.CONTINUE || err != nil {
-					log.Errorf("%v: preprocessing failed, state %v, err %v", port.ID(), state, err)
-					return
-				}
-				packet.Log().V(1).Info("injecting packet", "frame", fwdpacket.IncludeFrameInLog)
+		packet.Debug(debug)
+		if len(pre) != 0 {
+			packet.Log().WithValues("context", ctx.ID, "port", port.ID())
+			state, err := fwdaction.ProcessPacket(packet, pre, port)
+			if state != fwdaction.CONTINUE || err != nil {
+				log.Errorf("%v: preprocessing failed, state %v, err %v", port.ID(), state, err)
+				return
 			}
-			fwdport.Process(port, packet, dir, ctx, "Control")
-		}()
-	}()
-
-	if err = <-status; err != nil {
-		return fmt.Errorf("fwd: PacketInject failed, err %v", err)
+			packet.Log().V(1).Info("injecting packet", "frame", fwdpacket.IncludeFrameInLog)
+		}
+		fwdport.Process(port, packet, dir, ctx, "Control")
+	})
+	if err != nil && pre != nil {
+		pre.Cleanup()
 	}
-	return nil
+	return err
 }
 
 // PacketInject is a streaming RPC to inject a packets in the specified forwarding context and port.
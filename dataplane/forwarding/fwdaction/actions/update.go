@@ -70,10 +70,10 @@ func (u *update) String() string {
 }
 
 // Process updates packet by applying an operation on a field.
-// INC/DEC: These are rarely used operations only supported for select fields
+// INC/DEC: These are implemented within the protocol handlers for select fields
 //
-//	like IP TTL/HOP. They are implemented within the  protocol handlers
-//	as they are accompanied by optimized checksum adjustments.
+//	like IP TTL/HOP as they are accompanied by optimized checksum adjustments.
+//	Other fields are updated using the Get/Set of the packet field.
 //
 // SET:  This is a primitive operation which is implemented for all fields in the
 //
@@ -90,10 +90,10 @@ func (u *update) Process(packet fwdpacket.Packet, _ fwdobject.Counters) (fwdacti
 	}()
 	switch u.op {
 	case fwdpb.UpdateType_UPDATE_TYPE_INC:
-		e = packet.Update(u.fieldID, fwdpacket.OpInc, u.bytesArg)
+		e = u.arithmetic(packet, fwdpacket.OpInc)
 
 	case fwdpb.UpdateType_UPDATE_TYPE_DEC:
-		e = packet.Update(u.fieldID, fwdpacket.OpDec, u.bytesArg)
+		e = u.arithmetic(packet, fwdpacket.OpDec)
 
 	case fwdpb.UpdateType_UPDATE_TYPE_SET:
 		e = packet.Update(u.fieldID, fwdpacket.OpSet, u.bytesArg)
@@ -157,6 +157,40 @@ func (u *update) Process(packet fwdpacket.Packet, _ fwdobject.Counters) (fwdacti
 	return nil, fwdaction.CONTINUE
 }
 
+// arithmetic increments or decrements the field by the bytes argument. The
+// protocol handler performs the operation if it supports it for the field,
+// otherwise the field and argument are treated as big-endian unsigned
+// integers and the result wraps at the width of the field.
+func (u *update) arithmetic(packet fwdpacket.Packet, op int) error {
+	if err := packet.Update(u.fieldID, op, u.bytesArg); err == nil {
+		return nil
+	}
+	arg, err := packet.Field(u.fieldID)
+	if err != nil {
+		return err
+	}
+	borrow := op == fwdpacket.OpDec
+	carry := 0
+	for i := 1; i <= len(arg); i++ {
+		operand := 0
+		if i <= len(u.bytesArg) {
+			operand = int(u.bytesArg[len(u.bytesArg)-i])
+		}
+		v := int(arg[len(arg)-i])
+		if borrow {
+			v -= operand + carry
+		} else {
+			v += operand + carry
+		}
+		carry = 0
+		if v < 0 || v > 0xff {
+			carry = 1
+		}
+		arg[len(arg)-i] = byte(v)
+	}
+	return packet.Update(u.fieldID, fwdpacket.OpSet, arg)
+}
+
 // updateBuilder builds update actions.
 type updateBuilder struct{}
 
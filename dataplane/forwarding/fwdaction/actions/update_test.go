@@ -15,27 +15,32 @@
 package actions
 
 import (
+	"bytes"
 	"errors"
+	"slices"
 	"testing"
 
 	"go.uber.org/mock/gomock"
 
 	"github.com/openconfig/lemming/dataplane/forwarding/fwdaction"
 	"github.com/openconfig/lemming/dataplane/forwarding/fwdaction/mock_fwdpacket"
+	"github.com/openconfig/lemming/dataplane/forwarding/fwdconfig"
 	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdcontext"
 	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdpacket"
 	fwdpb "github.com/openconfig/lemming/proto/forwarding"
 
 	_ "github.com/openconfig/lemming/dataplane/forwarding/protocol/arp"
 	_ "github.com/openconfig/lemming/dataplane/forwarding/protocol/ethernet"
+	_ "github.com/openconfig/lemming/dataplane/forwarding/protocol/ip"
 	_ "github.com/openconfig/lemming/dataplane/forwarding/protocol/metadata"
 	_ "github.com/openconfig/lemming/dataplane/forwarding/protocol/opaque"
+	_ "github.com/openconfig/lemming/dataplane/forwarding/protocol/tcp"
 )
 
-// The update operations SET / INC / DEC are convered by the
-// corresponding protocol handler tests, as the actual operation occurs in
-// the corresponding protocol handler. The copy and bit operations are unit
-// tested here, as the operation happens within the action's process function.
+// The update operation SET is convered by the corresponding protocol handler
+// tests, as the actual operation occurs in the corresponding protocol handler.
+// The copy, bit and arithmetic operations are unit tested here, as the
+// operation happens within the action's process function.
 
 // TestCopy tests the copy update action.
 func TestCopy(t *testing.T) {
@@ -321,3 +326,62 @@ func TestBitAndOr(t *testing.T) {
 		}
 	}
 }
+
+// TestIncDec tests the increment and decrement update actions on packets.
+func TestIncDec(t *testing.T) {
+	ctx := fwdcontext.New("test", "fwd")
+
+	ethernet := []byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x08, 0x00}
+	ip4 := []byte{0x45, 0x01, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00, 0x40, 0x06, 0xa1, 0xad, 0x01, 0x02, 0x03, 0x04, 0x0a, 0x0b, 0x0c, 0x0d}
+	tcp := []byte{0x01, 0x02, 0x03, 0x04, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x51, 0x34, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0x0a, 0x0b, 0x0c, 0x0d}
+	frame := append(append(slices.Clone(ethernet), ip4...), tcp...)
+
+	tests := []struct {
+		desc   string
+		action *fwdconfig.UpdateActionBuilder
+		want   []byte
+	}{{
+		desc:   "decrement ttl",
+		action: fwdconfig.DecrementAction(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_HOP, 1),
+		want:   []byte{0x3f},
+	}, {
+		desc:   "decrement ttl by more than one",
+		action: fwdconfig.DecrementAction(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_HOP, 4),
+		want:   []byte{0x3c},
+	}, {
+		desc:   "increment ttl",
+		action: fwdconfig.IncrementAction(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_HOP, 1),
+		want:   []byte{0x41},
+	}, {
+		desc:   "decrement multi-byte field with borrow",
+		action: fwdconfig.DecrementAction(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_SRC, 0x0c),
+		want:   []byte{0x06, 0x07, 0x08, 0x09, 0x09, 0xff},
+	}, {
+		desc:   "increment multi-byte field with carry",
+		action: fwdconfig.IncrementAction(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_SRC, 0xf5),
+		want:   []byte{0x06, 0x07, 0x08, 0x09, 0x0b, 0x00},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			desc := fwdconfig.Action(tt.action).Build()
+			action, err := fwdaction.New(desc, ctx)
+			if err != nil {
+				t.Fatalf("fwdaction.New(%v) failed: %v", desc, err)
+			}
+			packet, err := fwdpacket.New(fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, slices.Clone(frame))
+			if err != nil {
+				t.Fatalf("fwdpacket.New() failed: %v", err)
+			}
+			if _, state := action.Process(packet, nil); state != fwdaction.CONTINUE {
+				t.Fatalf("%v processing returned bad result. Got %v want %v.", action, state, fwdaction.CONTINUE)
+			}
+			got, err := packet.Field(fwdpacket.NewFieldID(desc.GetUpdate().GetFieldId()))
+			if err != nil {
+				t.Fatalf("packet.Field() failed: %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("%s: got field %x, want %x", tt.desc, got, tt.want)
+			}
+		})
+	}
+}
@@ -81,6 +81,16 @@ func UpdateAction(t fwdpb.UpdateType, num fwdpb.PacketFieldNum) *UpdateActionBui
 	}
 }
 
+// IncrementAction returns a new update action builder that increments the field by v.
+func IncrementAction(num fwdpb.PacketFieldNum, v uint64) *UpdateActionBuilder {
+	return UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_INC, num).WithUint64Value(v)
+}
+
+// DecrementAction returns a new update action builder that decrements the field by v.
+func DecrementAction(num fwdpb.PacketFieldNum, v uint64) *UpdateActionBuilder {
+	return UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_DEC, num).WithUint64Value(v)
+}
+
 // WithFieldIDNum sets the packet field id enum.
 func (u *UpdateActionBuilder) WithFieldIDNum(num fwdpb.PacketFieldNum) *UpdateActionBuilder {
 	u.fieldIDNum = num
@@ -227,6 +237,35 @@ func (u *LookupActionBuilder) actionType() fwdpb.ActionType {
 	return fwdpb.ActionType_ACTION_TYPE_LOOKUP
 }
 
+// BridgeLearnActionBuilder is a builder for a bridge learn action.
+type BridgeLearnActionBuilder struct {
+	tableID string
+}
+
+// BridgeLearnAction returns a new bridge learn action builder, that learns the source MAC and input port
+// of packets in the bridge table.
+func BridgeLearnAction(tableID string) *BridgeLearnActionBuilder {
+	return &BridgeLearnActionBuilder{
+		tableID: tableID,
+	}
+}
+
+func (u *BridgeLearnActionBuilder) set(ad *fwdpb.ActionDesc) {
+	ad.Action = &fwdpb.ActionDesc_Bridge{
+		Bridge: &fwdpb.BridgeLearnActionDesc{
+			TableId: &fwdpb.TableId{
+				ObjectId: &fwdpb.ObjectId{
+					Id: u.tableID,
+				},
+			},
+		},
+	}
+}
+
+func (u *BridgeLearnActionBuilder) actionType() fwdpb.ActionType {
+	return fwdpb.ActionType_ACTION_TYPE_BRIDGE_LEARN
+}
+
 // EncapActionBuilder is a builder for a lookup action.
 type EncapActionBuilder struct {
 	header fwdpb.PacketHeaderId
@@ -331,3 +370,56 @@ func (u *FlowCounterActionBuilder) set(a *fwdpb.ActionDesc) {
 func (u *FlowCounterActionBuilder) actionType() fwdpb.ActionType {
 	return fwdpb.ActionType_ACTION_TYPE_FLOW_COUNTER
 }
+
+// RateActionBuilder is a builder for a rate limit action.
+type RateActionBuilder struct {
+	burstBytes int32
+	rateBps    int32
+}
+
+// RateAction returns a new rate limit action builder, rate is in bytes per second.
+func RateAction(burstBytes, rateBps int32) *RateActionBuilder {
+	return &RateActionBuilder{
+		burstBytes: burstBytes,
+		rateBps:    rateBps,
+	}
+}
+
+func (u *RateActionBuilder) set(a *fwdpb.ActionDesc) {
+	a.Action = &fwdpb.ActionDesc_Rate{
+		Rate: &fwdpb.RateActionDesc{
+			BurstBytes: u.burstBytes,
+			RateBps:    u.rateBps,
+		},
+	}
+}
+
+func (u *RateActionBuilder) actionType() fwdpb.ActionType {
+	return fwdpb.ActionType_ACTION_TYPE_RATE
+}
+
+// MirrorActionBuilder is a builder for a mirror action.
+type MirrorActionBuilder struct {
+	actions []*ActionBuilder
+}
+
+// MirrorAction returns a new mirror action builder, the actions are applied to the mirrored packet.
+func MirrorAction(actions ...*ActionBuilder) *MirrorActionBuilder {
+	return &MirrorActionBuilder{
+		actions: actions,
+	}
+}
+
+func (u *MirrorActionBuilder) set(a *fwdpb.ActionDesc) {
+	mirror := &fwdpb.MirrorActionDesc{}
+	for _, action := range u.actions {
+		mirror.Actions = append(mirror.Actions, action.Build())
+	}
+	a.Action = &fwdpb.ActionDesc_Mirror{
+		Mirror: mirror,
+	}
+}
+
+func (u *MirrorActionBuilder) actionType() fwdpb.ActionType {
+	return fwdpb.ActionType_ACTION_TYPE_MIRROR
+}
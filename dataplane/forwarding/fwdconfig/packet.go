@@ -77,6 +77,7 @@ type PacketFieldMaskedBytesBuilder struct {
 	bytes    []byte
 	mask     []byte
 	field    fwdpb.PacketFieldNum
+	udf      *fwdpb.PacketBytes
 	instance uint32
 }
 
@@ -87,6 +88,18 @@ func PacketFieldMaskedBytes(field fwdpb.PacketFieldNum) *PacketFieldMaskedBytesB
 	}
 }
 
+// PacketUDFMaskedBytes creates a new PacketFieldMaskedBytesBuilder for a user defined field:
+// the size bytes at offset within the header group.
+func PacketUDFMaskedBytes(group fwdpb.PacketHeaderGroup, offset, size uint32) *PacketFieldMaskedBytesBuilder {
+	return &PacketFieldMaskedBytesBuilder{
+		udf: &fwdpb.PacketBytes{
+			HeaderGroup: group,
+			Offset:      offset,
+			Size:        size,
+		},
+	}
+}
+
 // WithBytes sets the bytes and mask value.
 func (b *PacketFieldMaskedBytesBuilder) WithBytes(bytes, mask []byte) *PacketFieldMaskedBytesBuilder {
 	b.bytes = bytes
@@ -117,6 +130,13 @@ func (b *PacketFieldMaskedBytesBuilder) WithUint16(d uint16) *PacketFieldMaskedB
 
 // Build returns a new PacketFieldMaskedBytes.
 func (b *PacketFieldMaskedBytesBuilder) Build() *fwdpb.PacketFieldMaskedBytes {
+	if b.udf != nil {
+		return &fwdpb.PacketFieldMaskedBytes{
+			Bytes:   b.bytes,
+			Masks:   b.mask,
+			FieldId: &fwdpb.PacketFieldId{Bytes: b.udf},
+		}
+	}
 	return &fwdpb.PacketFieldMaskedBytes{
 		Bytes: b.bytes,
 		Masks: b.mask,
@@ -26,9 +26,11 @@
 package fwdport
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"strconv"
 
 	log "github.com/golang/glog"
 
@@ -69,6 +71,23 @@ var CounterList = []fwdpb.CounterId{
 	fwdpb.CounterId_COUNTER_ID_RX_NON_UCAST_PACKETS,
 }
 
+// AttrLoopback is the port attribute that reflects packets written out of the
+// port back into it as input.
+var AttrLoopback = fwdattribute.ID("Loopback")
+
+// attrLoopbackHops is the packet attribute counting the times a packet was
+// looped back.
+var attrLoopbackHops = fwdattribute.ID("LoopbackHops")
+
+// MaxLoopbackHops is the number of times a packet is looped back before it is
+// dropped.
+const MaxLoopbackHops = 16
+
+func init() {
+	fwdattribute.Register(AttrLoopback, "Reflects packets output on the port back as input if set to true")
+	fwdattribute.Register(attrLoopbackHops, "Number of times a packet was looped back")
+}
+
 // A Port is an entry or exit point within the forwarding plane. Each port
 // has a set of actions to apply for incoming and outgoing packets.
 //
@@ -243,6 +262,14 @@ func Input(port Port, packet fwdpacket.Packet, dir fwdpb.PortAction, ctx *fwdcon
 
 	Increment(port, packet.Length(), fwdpb.CounterId_COUNTER_ID_RX_PACKETS, fwdpb.CounterId_COUNTER_ID_RX_OCTETS)
 	SetInputPort(packet, port)
+	if value, ok := packet.Attributes().Get(fwdpacket.AttrChecksumValidation); ok && value == "true" {
+		if v, ok := packet.(fwdpacket.ChecksumValidator); ok && !v.ValidChecksums() {
+			packet.Log().V(1).Info("input dropped frame with bad checksum", "port", port.ID(), "frame", fwdpacket.IncludeFrameInLog)
+			Increment(port, packet.Length(), fwdpb.CounterId_COUNTER_ID_RX_BAD_PACKETS, fwdpb.CounterId_COUNTER_ID_RX_BAD_OCTETS)
+			Increment(port, packet.Length(), fwdpb.CounterId_COUNTER_ID_RX_DROP_PACKETS, fwdpb.CounterId_COUNTER_ID_RX_DROP_OCTETS)
+			return nil
+		}
+	}
 	mac, err := packet.Field(fwdpacket.NewFieldIDFromNum(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST, 0))
 	if err != nil {
 		Increment(port, packet.Length(), fwdpb.CounterId_COUNTER_ID_RX_ERROR_PACKETS, fwdpb.CounterId_COUNTER_ID_RX_ERROR_OCTETS)
@@ -288,7 +315,7 @@ func Input(port Port, packet fwdpacket.Packet, dir fwdpb.PortAction, ctx *fwdcon
 // Output processes an outgoing packet. The specified port actions are applied
 // to the packet, and if allowed the packet is written out of the port. All
 // appropriate counters are incremented.
-func Output(port Port, packet fwdpacket.Packet, dir fwdpb.PortAction, _ *fwdcontext.Context) (err error) {
+func Output(port Port, packet fwdpacket.Packet, dir fwdpb.PortAction, ctx *fwdcontext.Context) (err error) {
 	defer func() {
 		if err != nil {
 			packet.Log().Error(err, "output processing failed")
@@ -310,7 +337,11 @@ func Output(port Port, packet fwdpacket.Packet, dir fwdpb.PortAction, _ *fwdcont
 	packet.Log().V(3).Info("output packet", "frame", fwdpacket.IncludeFrameInLog)
 	state, err := fwdaction.ProcessPacket(packet, port.Actions(dir), port)
 	if err == nil && state == fwdaction.CONTINUE {
-		state, err = port.Write(packet)
+		if value, ok := port.Attributes().Get(AttrLoopback); ok && value == "true" {
+			state, err = loopback(port, packet, ctx)
+		} else {
+			state, err = port.Write(packet)
+		}
 	}
 	if err != nil {
 		Increment(port, packet.Length(), fwdpb.CounterId_COUNTER_ID_TX_ERROR_PACKETS, fwdpb.CounterId_COUNTER_ID_TX_ERROR_OCTETS)
@@ -331,6 +362,66 @@ func Output(port Port, packet fwdpacket.Packet, dir fwdpb.PortAction, _ *fwdcont
 	return fmt.Errorf("fwdport: unknown state %v", state)
 }
 
+// loopback reflects an output packet back into the port as a new input packet.
+// The packet is queued for processing, as if it was received on the wire, so
+// that a loop does not recurse within the current packet's processing. A
+// packet looped back too many times is dropped, so a loop does not process it
+// forever.
+func loopback(port Port, packet fwdpacket.Packet, ctx *fwdcontext.Context) (fwdaction.State, error) {
+	ingress := ctx.Ingress()
+	if ingress == nil {
+		return fwdaction.DROP, fmt.Errorf("fwdport: failed to loop back packet, context %v has no ingress", ctx.ID)
+	}
+	hops := 0
+	if value, ok := packet.Attributes().Get(attrLoopbackHops); ok {
+		hops, _ = strconv.Atoi(value)
+	}
+	if hops >= MaxLoopbackHops {
+		packet.Log().V(1).Info("dropping packet looped back too many times", "port", port.ID(), "hops", hops)
+		return fwdaction.DROP, nil
+	}
+	in, err := fwdpacket.New(fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, bytes.Clone(packet.Frame()))
+	if err != nil {
+		return fwdaction.DROP, fmt.Errorf("fwdport: failed to loop back packet: %v", err)
+	}
+	in.Attributes().Add(attrLoopbackHops, strconv.Itoa(hops+1))
+	packet.Log().V(1).Info("loopback packet", "port", port.ID(), "frame", fwdpacket.IncludeFrameInLog)
+	err = ingress(FlowKey(port, in), func() {
+		ctx.RLock()
+		defer ctx.RUnlock()
+		Process(port, in, fwdpb.PortAction_PORT_ACTION_INPUT, ctx, "Loopback")
+	})
+	if err != nil {
+		packet.Log().V(1).Info("dropping looped back packet", "port", port.ID(), "err", err)
+		return fwdaction.DROP, nil
+	}
+	return fwdaction.CONSUME, nil
+}
+
+// flowFields are the packet fields identifying the flow of a packet. Fields
+// missing from the packet are ignored.
+var flowFields = []fwdpacket.FieldID{
+	fwdpacket.NewFieldIDFromNum(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_SRC, 0),
+	fwdpacket.NewFieldIDFromNum(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST, 0),
+	fwdpacket.NewFieldIDFromNum(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_SRC, 0),
+	fwdpacket.NewFieldIDFromNum(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_DST, 0),
+	fwdpacket.NewFieldIDFromNum(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_PROTO, 0),
+	fwdpacket.NewFieldIDFromNum(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_L4_PORT_SRC, 0),
+	fwdpacket.NewFieldIDFromNum(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_L4_PORT_DST, 0),
+}
+
+// FlowKey returns the key of the flow of a packet received on the port, the
+// packets of a flow are processed in order.
+func FlowKey(port Port, packet fwdpacket.Packet) []byte {
+	key := []byte(port.ID())
+	for _, id := range flowFields {
+		if f, err := packet.Field(id); err == nil {
+			key = append(key, f...)
+		}
+	}
+	return key
+}
+
 // Write writes out a packet through a port without changing it. No actions are applied.
 func Write(port Port, packet fwdpacket.Packet) {
 	packet.Log().V(1).Info("write packet", "port", port.ID(), "frame", fwdpacket.IncludeFrameInLog)
@@ -343,6 +434,8 @@ func Write(port Port, packet fwdpacket.Packet) {
 
 // Process processes a packet on the specified port, action direction and context.
 // If the attribute "PacketDebug" is set, then the packet debugging is enabled.
+// If the attribute "ChecksumValidation" is set, then input packets with
+// incorrect checksums are dropped.
 func Process(port Port, packet fwdpacket.Packet, dir fwdpb.PortAction, ctx *fwdcontext.Context, prefix string) {
 	// Update the prefix if the port and context are not nil
 	if port != nil && ctx != nil {
@@ -0,0 +1,166 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ports
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/openconfig/lemming/dataplane/forwarding/fwdaction"
+	"github.com/openconfig/lemming/dataplane/forwarding/fwdport"
+	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdcontext"
+	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdobject"
+	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdpacket"
+	fwdpb "github.com/openconfig/lemming/proto/forwarding"
+)
+
+// defaultChannelQueueLength is the length of a channel port's channel if its descriptor doesn't set one.
+const defaultChannelQueueLength = 64
+
+func init() {
+	fwdport.Register(fwdpb.PortType_PORT_TYPE_CHANNEL, channelBuilder{})
+}
+
+// channelPort is a port that writes frames to an in-process channel, e.g. for a file descriptor hostif.
+// Packets are received by injecting them to the port.
+type channelPort struct {
+	fwdobject.Base
+	input  fwdaction.Actions
+	output fwdaction.Actions
+	ctx    *fwdcontext.Context // Forwarding context containing the port
+	frames chan []byte
+
+	mu    sync.Mutex
+	state fwdpb.PortState
+}
+
+func (p *channelPort) String() string {
+	desc := fmt.Sprintf("Type=%v;<Input=%v>;<Output=%v>", fwdpb.PortType_PORT_TYPE_CHANNEL, p.input, p.output)
+	if state, err := p.State(nil); err == nil {
+		desc += fmt.Sprintf("<State=%v>;", state)
+	}
+	return desc
+}
+
+func (p *channelPort) Type() fwdpb.PortType {
+	return fwdpb.PortType_PORT_TYPE_CHANNEL
+}
+
+func (p *channelPort) Cleanup() {
+	p.input.Cleanup()
+	p.output.Cleanup()
+	p.input = nil
+	p.output = nil
+}
+
+// Update updates the actions of the port.
+func (p *channelPort) Update(upd *fwdpb.PortUpdateDesc) error {
+	var err error
+	defer func() {
+		if err != nil {
+			p.Cleanup()
+		}
+	}()
+	chUpd, ok := upd.Port.(*fwdpb.PortUpdateDesc_Channel)
+	if !ok {
+		return fmt.Errorf("invalid type for port update")
+	}
+
+	// Acquire new actions before releasing the old ones.
+	if p.input, err = fwdaction.NewActions(chUpd.Channel.GetInputs(), p.ctx); err != nil {
+		return fmt.Errorf("ports: input actions for port %v failed, err %v", p, err)
+	}
+	if p.output, err = fwdaction.NewActions(chUpd.Channel.GetOutputs(), p.ctx); err != nil {
+		return fmt.Errorf("ports: output actions for port %v failed, err %v", p, err)
+	}
+	return nil
+}
+
+// Write writes a packet to the channel. If successful, the port returns
+// fwdaction.CONSUME. Packets are dropped if the port is down or the channel is full.
+func (p *channelPort) Write(packet fwdpacket.Packet) (fwdaction.State, error) {
+	p.mu.Lock()
+	state := p.state
+	p.mu.Unlock()
+	if state != fwdpb.PortState_PORT_STATE_ENABLED_UP {
+		return fwdaction.DROP, fmt.Errorf("ports: port %v is down", p.ID())
+	}
+	select {
+	case p.frames <- append([]byte(nil), packet.Frame()...):
+		return fwdaction.CONSUME, nil
+	default:
+		return fwdaction.DROP, fmt.Errorf("ports: channel of port %v is full", p.ID())
+	}
+}
+
+// Actions returns the port actions of the specified type
+func (p *channelPort) Actions(dir fwdpb.PortAction) fwdaction.Actions {
+	switch dir {
+	case fwdpb.PortAction_PORT_ACTION_INPUT:
+		return p.input
+	case fwdpb.PortAction_PORT_ACTION_OUTPUT:
+		return p.output
+	}
+	return nil
+}
+
+// State sets the admin status of the port, if specified, and returns the state of the port.
+// The port is operationally up whenever it is administratively up.
+func (p *channelPort) State(pi *fwdpb.PortInfo) (*fwdpb.PortStateReply, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if st := pi.GetAdminStatus(); st != fwdpb.PortState_PORT_STATE_UNSPECIFIED {
+		p.state = st
+	}
+	return &fwdpb.PortStateReply{
+		Status: &fwdpb.PortInfo{
+			OperStatus:  p.state,
+			AdminStatus: p.state,
+		},
+	}, nil
+}
+
+// Frames returns the channel that the frames written to a channel port are sent to.
+func Frames(port fwdport.Port) (<-chan []byte, error) {
+	p, ok := port.(*channelPort)
+	if !ok {
+		return nil, fmt.Errorf("ports: port %v is a %v port, not a channel port", port.ID(), port.Type())
+	}
+	return p.frames, nil
+}
+
+type channelBuilder struct{}
+
+// Build creates a new port.
+func (channelBuilder) Build(portDesc *fwdpb.PortDesc, ctx *fwdcontext.Context) (fwdport.Port, error) {
+	cp, ok := portDesc.Port.(*fwdpb.PortDesc_Channel)
+	if !ok {
+		return nil, fmt.Errorf("invalid port type in proto, got %T, expected *fwdpb.PortDesc_Channel", portDesc.Port)
+	}
+	queueLen := int(cp.Channel.GetQueueLength())
+	if queueLen == 0 {
+		queueLen = defaultChannelQueueLength
+	}
+	p := &channelPort{
+		ctx:    ctx,
+		frames: make(chan []byte, queueLen),
+		state:  fwdpb.PortState_PORT_STATE_ENABLED_UP,
+	}
+	list := append(fwdport.CounterList, fwdaction.CounterList...)
+	if err := p.InitCounters("", list...); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
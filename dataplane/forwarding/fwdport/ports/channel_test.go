@@ -0,0 +1,153 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ports
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+
+	"github.com/openconfig/lemming/dataplane/forwarding/fwdport"
+	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdcontext"
+	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdobject"
+	fwdpb "github.com/openconfig/lemming/proto/forwarding"
+
+	_ "github.com/openconfig/lemming/dataplane/forwarding/fwdaction/actions"
+)
+
+func TestChannelWrite(t *testing.T) {
+	tests := []struct {
+		desc       string
+		adminState fwdpb.PortState
+		writes     int
+		wantErr    string
+	}{{
+		desc:   "success",
+		writes: 1,
+	}, {
+		desc:       "port down",
+		adminState: fwdpb.PortState_PORT_STATE_DISABLED_DOWN,
+		writes:     1,
+		wantErr:    "is down",
+	}, {
+		desc:    "channel full",
+		writes:  2,
+		wantErr: "is full",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			port, err := (channelBuilder{}).Build(&fwdpb.PortDesc{Port: &fwdpb.PortDesc_Channel{Channel: &fwdpb.ChannelPortDesc{QueueLength: 1}}}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := port.State(&fwdpb.PortInfo{AdminStatus: tt.adminState}); err != nil {
+				t.Fatal(err)
+			}
+			pkt := createEthPacket(t)
+			for i := 0; i < tt.writes; i++ {
+				_, err = port.Write(pkt)
+			}
+			if d := errdiff.Check(err, tt.wantErr); d != "" {
+				t.Fatalf("Write() unexpected error diff: %s", d)
+			}
+			frames, err := Frames(port)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.adminState == fwdpb.PortState_PORT_STATE_DISABLED_DOWN {
+				if len(frames) != 0 {
+					t.Fatalf("Write() to a down port got %d frames, want none", len(frames))
+				}
+				return
+			}
+			if d := cmp.Diff(<-frames, pkt.Frame()); d != "" {
+				t.Errorf("Write() unexpected frame diff(-got,+want)\n:%s", d)
+			}
+		})
+	}
+}
+
+func TestChannelBuild(t *testing.T) {
+	if _, err := (channelBuilder{}).Build(&fwdpb.PortDesc{Port: &fwdpb.PortDesc_Cpu{}}, nil); err == nil {
+		t.Errorf("Build() with a CPU port desc succeeded, want error")
+	}
+	port, err := (channelBuilder{}).Build(&fwdpb.PortDesc{Port: &fwdpb.PortDesc_Channel{Channel: &fwdpb.ChannelPortDesc{}}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frames, err := Frames(port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cap(frames); got != defaultChannelQueueLength {
+		t.Errorf("Build() got channel length %d, want %d", got, defaultChannelQueueLength)
+	}
+}
+
+func TestChannelLoopbackLoop(t *testing.T) {
+	ctx := fwdcontext.New("test", "fwd")
+	var queue []func()
+	ctx.SetIngress(func(_ []byte, fn func()) error {
+		queue = append(queue, fn)
+		return nil
+	})
+	desc := &fwdpb.PortDesc{
+		PortType: fwdpb.PortType_PORT_TYPE_CHANNEL,
+		PortId:   fwdport.MakeID(fwdobject.NewID("loop")),
+		Port:     &fwdpb.PortDesc_Channel{Channel: &fwdpb.ChannelPortDesc{}},
+	}
+	port, err := fwdport.New(desc, ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Packets received by the port are transmitted out of it, and looped back into it.
+	if err := port.Update(&fwdpb.PortUpdateDesc{Port: &fwdpb.PortUpdateDesc_Channel{Channel: &fwdpb.ChannelPortUpdateDesc{
+		Inputs: []*fwdpb.ActionDesc{{
+			ActionType: fwdpb.ActionType_ACTION_TYPE_TRANSMIT,
+			Action:     &fwdpb.ActionDesc_Transmit{Transmit: &fwdpb.TransmitActionDesc{PortId: desc.GetPortId()}},
+		}},
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	port.Attributes().Add(fwdport.AttrLoopback, "true")
+
+	ctx.RLock()
+	fwdport.Process(port, createEthPacket(t), fwdpb.PortAction_PORT_ACTION_OUTPUT, ctx, "test")
+	ctx.RUnlock()
+	for len(queue) > 0 {
+		if len(queue) > 1 {
+			t.Fatalf("loopback queued %d packets, want 1", len(queue))
+		}
+		fn := queue[0]
+		queue = queue[1:]
+		fn()
+	}
+
+	counters := port.Counters()
+	if got, want := counters[fwdpb.CounterId_COUNTER_ID_RX_PACKETS].Value, uint64(fwdport.MaxLoopbackHops); got != want {
+		t.Errorf("looping packet received %d times, want %d", got, want)
+	}
+	if got := counters[fwdpb.CounterId_COUNTER_ID_TX_DROP_PACKETS].Value; got != 1 {
+		t.Errorf("looping packet dropped %d times, want 1", got)
+	}
+	frames, err := Frames(port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != 0 {
+		t.Errorf("looping packet written %d times, want none", len(frames))
+	}
+}
@@ -199,16 +199,19 @@ func (p *CPUPort) puntRemotePort(v any) {
 	}
 	hostPort, err := packet.Field(fwdpacket.NewFieldIDFromNum(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_HOST_PORT_ID, 0))
 	if err != nil {
+		p.ctx.RUnlock()
 		fwdport.Increment(p, packet.Length(), fwdpb.CounterId_COUNTER_ID_TX_ERROR_PACKETS, fwdpb.CounterId_COUNTER_ID_TX_ERROR_OCTETS)
 		return
 	}
 
 	ingressID, err := strconv.Atoi(ingressPID.GetObjectId().GetId())
 	if err != nil {
+		p.ctx.RUnlock()
 		return
 	}
 	egressID, err := strconv.Atoi(egressPID.GetObjectId().GetId())
 	if err != nil {
+		p.ctx.RUnlock()
 		return
 	}
 
@@ -232,9 +235,8 @@ func (p *CPUPort) puntRemotePort(v any) {
 	defer timer.Stop()
 	if err := ps(response); err != nil {
 		fwdport.Increment(p, packet.Length(), fwdpb.CounterId_COUNTER_ID_TX_ERROR_PACKETS, fwdpb.CounterId_COUNTER_ID_TX_ERROR_OCTETS)
-		return
+		log.Errorf("ports: Unable to punt packet, request %+v, err %v.", response, err)
 	}
-	log.Errorf("ports: Unable to punt packet, request %+v, err %v.", response, err)
 }
 
 // Actions returns the port actions of the specified type.
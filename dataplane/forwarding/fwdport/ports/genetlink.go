@@ -112,7 +112,12 @@ type genetlinkBuilder struct{}
 
 // Build creates a new port.
 func (genetlinkBuilder) Build(_ *fwdpb.PortDesc, ctx *fwdcontext.Context) (fwdport.Port, error) {
-	return &genetlinkPort{
+	p := &genetlinkPort{
 		ctx: ctx,
-	}, nil
+	}
+	list := append(fwdport.CounterList, fwdaction.CounterList...)
+	if err := p.InitCounters("", list...); err != nil {
+		return nil, err
+	}
+	return p, nil
 }
@@ -223,7 +223,7 @@ func startStateWatch(updCh chan netlink.LinkUpdate, doneCh chan struct{}, devNam
 				return
 			case upd, ok := <-updCh:
 				if !ok {
-					log.Info("update chan close for dev: %v", devName)
+					log.Infof("update chan close for dev: %v", devName)
 					return
 				}
 				if upd.Attrs() == nil || upd.Attrs().Name != devName {
@@ -80,6 +80,7 @@ type Context struct {
 	FakePortManager FakePortManager
 	cpuPortSink     CPUPortSink
 	cpuPortSinkDone func()
+	ingress         Ingress
 }
 
 // New creates a new forwarding context with the specified id and fwd engine
@@ -199,6 +200,21 @@ func (ctx *Context) CPUPortSink() CPUPortSink {
 	return ctx.cpuPortSink
 }
 
+// Ingress queues fn, which processes a packet received in the context, for
+// the flow identified by key. It returns an error if the packet is dropped
+// because it cannot be queued.
+type Ingress func(key []byte, fn func()) error
+
+// SetIngress sets the queues processing the packets received in the context.
+func (ctx *Context) SetIngress(fn Ingress) {
+	ctx.ingress = fn
+}
+
+// Ingress returns the queues processing the packets received in the context.
+func (ctx *Context) Ingress() Ingress {
+	return ctx.ingress
+}
+
 // Cleanup cleans up the context.
 // It first cleans up the objects that satisfy isPort.
 // Then it unblocks the caller by sending a message on the channel.
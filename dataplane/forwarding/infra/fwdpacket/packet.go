@@ -44,8 +44,12 @@ const IncludeFrameInLog LogFrameValue = 0
 // AttrPacketDebug controls packet debugging.
 var AttrPacketDebug = fwdattribute.ID("PacketDebug")
 
+// AttrChecksumValidation controls the validation of checksums of ingress packets.
+var AttrChecksumValidation = fwdattribute.ID("ChecksumValidation")
+
 func init() {
 	fwdattribute.Register(AttrPacketDebug, "Enables packet debugging if set to true")
+	fwdattribute.Register(AttrChecksumValidation, "Drops ingress packets with incorrect L3/L4 checksums if set to true")
 }
 
 // Packet is a network packet that can be queried and manipulated.
@@ -107,6 +111,13 @@ type Packet interface {
 	StartHeader() fwdpb.PacketHeaderId
 }
 
+// ChecksumValidator is a Packet that can verify the checksums of its headers.
+type ChecksumValidator interface {
+	// ValidChecksums returns true if the checksums of all headers in the
+	// packet that carry a checksum are correct.
+	ValidChecksums() bool
+}
+
 // Parser can create a Packet from a slice of bytes containing the packet.
 type Parser interface {
 	// New creates a new packet using the specified first header id.
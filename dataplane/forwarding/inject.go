@@ -0,0 +1,93 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwarding
+
+import (
+	"hash/crc32"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxFlowQueue is the number of packets queued by a worker before more
+// packets of its flows are dropped.
+const maxFlowQueue = 256
+
+// A flowWorker processes the packets of the flows hashed to it, one at a time
+// and in the order they were injected. The worker only runs while its queue is
+// not empty.
+type flowWorker struct {
+	mu      sync.Mutex
+	queue   []func()
+	running bool
+}
+
+// enqueue appends fn to the queue of the worker and starts the worker if it is
+// not running. It returns a ResourceExhausted error if the queue is full.
+func (w *flowWorker) enqueue(fn func()) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.queue) >= maxFlowQueue {
+		return status.Errorf(codes.ResourceExhausted, "fwd: flow queue is full, %d packets queued", len(w.queue))
+	}
+	w.queue = append(w.queue, fn)
+	if !w.running {
+		w.running = true
+		go w.run()
+	}
+	return nil
+}
+
+// run processes the queue until it is empty.
+func (w *flowWorker) run() {
+	for {
+		w.mu.Lock()
+		if len(w.queue) == 0 {
+			w.running = false
+			w.mu.Unlock()
+			return
+		}
+		fn := w.queue[0]
+		w.queue[0] = nil
+		w.queue = w.queue[1:]
+		w.mu.Unlock()
+		fn()
+	}
+}
+
+// flowWorkers distribute injected packets by flow: the packets of a flow are
+// always processed by the same worker so they are not reordered, while the
+// packets of different flows are processed in parallel.
+type flowWorkers []*flowWorker
+
+// newFlowWorkers returns n workers.
+func newFlowWorkers(n int) flowWorkers {
+	w := make(flowWorkers, n)
+	for i := range w {
+		w[i] = &flowWorker{}
+	}
+	return w
+}
+
+// worker returns the worker of the flow identified by key.
+func (w flowWorkers) worker(key []byte) *flowWorker {
+	return w[crc32.ChecksumIEEE(key)%uint32(len(w))]
+}
+
+// enqueue queues fn on the worker of the flow identified by key.
+func (w flowWorkers) enqueue(key []byte, fn func()) error {
+	return w.worker(key).enqueue(fn)
+}
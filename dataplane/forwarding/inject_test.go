@@ -0,0 +1,93 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwarding
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFlowWorkersOrder(t *testing.T) {
+	w := newFlowWorkers(4)
+	worker := w.worker([]byte("flow"))
+
+	// Hold the worker until the queue is full.
+	started, release := make(chan struct{}), make(chan struct{})
+	if err := worker.enqueue(func() {
+		close(started)
+		<-release
+	}); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	var mu sync.Mutex
+	var got []int
+	var wg sync.WaitGroup
+	wg.Add(maxFlowQueue)
+	for i := 0; i < maxFlowQueue; i++ {
+		i := i
+		if err := worker.enqueue(func() {
+			defer wg.Done()
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, i)
+		}); err != nil {
+			t.Fatalf("enqueue() of packet %d unexpected err: %v", i, err)
+		}
+	}
+	if err := worker.enqueue(func() {}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("enqueue() to a full queue got err %v, want ResourceExhausted", err)
+	}
+	close(release)
+	wg.Wait()
+	for i, seq := range got {
+		if seq != i {
+			t.Fatalf("packet %d of the flow processed at position %d, want in order", seq, i)
+		}
+	}
+}
+
+func TestFlowWorkersParallel(t *testing.T) {
+	w := newFlowWorkers(4)
+	blocked := []byte("flow-0")
+	var other []byte
+	for i := 1; other == nil; i++ {
+		if key := []byte(fmt.Sprintf("flow-%d", i)); w.worker(key) != w.worker(blocked) {
+			other = key
+		}
+	}
+
+	// A flow stuck processing a packet does not hold back the flows of other workers.
+	release := make(chan struct{})
+	defer close(release)
+	if err := w.worker(blocked).enqueue(func() { <-release }); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	if err := w.worker(other).enqueue(func() { close(done) }); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("packet of flow %q not processed while flow %q is blocked", other, blocked)
+	}
+}
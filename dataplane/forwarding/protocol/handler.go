@@ -54,6 +54,25 @@ type Handler interface {
 	Rebuild() error
 }
 
+// A ChecksumHandler is a Handler whose protocol header carries a checksum
+// that can be verified.
+type ChecksumHandler interface {
+	Handler
+
+	// ValidChecksum returns true if the checksum in the header is correct.
+	ValidChecksum() bool
+}
+
+// A LengthHandler is a Handler whose protocol header records the length of
+// its payload. The recorded length excludes any padding added to the frame.
+type LengthHandler interface {
+	Handler
+
+	// RecordedPayloadLength returns the length of the payload recorded in
+	// the header, and false if the header does not record it.
+	RecordedPayloadLength() (int, bool)
+}
+
 // A Desc is a set of attributes that describe a network protocol in a packet.
 // The desc in the packet are maintained in a doubly linked list in the
 // order in which they occur within the packet. By default, all protocol
@@ -152,6 +171,32 @@ func (d *Desc) EnvelopeDesc() *Desc {
 	return d.prev
 }
 
+// RecordedLength returns the length of the desc (including its payload) as
+// recorded in the header of its envelope, and false if the envelope does not
+// record it.
+func (d *Desc) RecordedLength() (int, bool) {
+	e := d.EnvelopeDesc()
+	if e == nil {
+		return 0, false
+	}
+	h, ok := e.handler.(LengthHandler)
+	if !ok {
+		return 0, false
+	}
+	return h.RecordedPayloadLength()
+}
+
+// Segment returns the header and payload of the desc. If the envelope records
+// the length of the desc, any bytes beyond it (e.g. ethernet padding) are
+// excluded.
+func (d *Desc) Segment() []byte {
+	segment := append(append([]byte{}, d.handler.Header()...), d.Payload()...)
+	if n, ok := d.RecordedLength(); ok && n >= 0 && n <= len(segment) {
+		segment = segment[:n]
+	}
+	return segment
+}
+
 // Dirty returns true if the header is dirty.
 func (d *Desc) Dirty() bool {
 	return d.dirty
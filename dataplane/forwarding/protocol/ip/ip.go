@@ -64,6 +64,30 @@ var headerProto = map[fwdpb.PacketHeaderId]uint8{
 	fwdpb.PacketHeaderId_PACKET_HEADER_ID_OPAQUE: protoReserved,
 }
 
+// payloadProto returns the protocol number describing a payload id, given
+// the current protocol number of the header. Opaque payloads keep the current
+// protocol number unless it describes a known header, so that the protocols
+// which aren't parsed (e.g. IGMP) are preserved.
+func payloadProto(id fwdpb.PacketHeaderId, current uint8) uint8 {
+	if _, known := protoHeader[current]; id == fwdpb.PacketHeaderId_PACKET_HEADER_ID_OPAQUE && !known {
+		return current
+	}
+	if proto, ok := headerProto[id]; ok {
+		return proto
+	}
+	return protoReserved
+}
+
+// hopDelta returns the amount by which an update adjusts the hop limit. As
+// the hop limit is a single byte, only the least significant byte of the
+// big-endian argument is relevant. An empty argument adjusts it by one.
+func hopDelta(arg []byte) uint {
+	if len(arg) == 0 {
+		return 1
+	}
+	return uint(arg[len(arg)-1])
+}
+
 // ipVersion extracts the IP version from a byte.
 func ipVersion(b []byte) frame.Field {
 	if version := frame.Header(b).Field(versionByteOffset, versionByteSize); version != nil {
@@ -243,6 +267,30 @@ func (ip *IP) Rebuild() error {
 	return nil
 }
 
+// RecordedPayloadLength returns the length of the payload of the innermost IP
+// header as recorded in its length field.
+func (ip *IP) RecordedPayloadLength() (int, bool) {
+	switch h := ip.headers[len(ip.headers)-1].(type) {
+	case *IP4:
+		return int(h.header.Field(ip4LengthPos, ip4LengthBytes).Value()) - len(h.header), true
+	case *IP6:
+		return int(h.header.Field(ip6LengthPos, ip6LengthBytes).Value()), true
+	default:
+		return 0, false
+	}
+}
+
+// ValidChecksum returns true if the checksums of all IPv4 headers are correct.
+// IPv6 and GRE headers do not carry a header checksum.
+func (ip *IP) ValidChecksum() bool {
+	for _, h := range ip.headers {
+		if ip4, ok := h.(*IP4); ok && !ip4.validChecksum() {
+			return false
+		}
+	}
+	return true
+}
+
 // add adds an IP header to an empty L3.
 // Note that the L3 cannot contain a stand-alone GRE header.
 func add(id fwdpb.PacketHeaderId, _ *protocol.Desc) (protocol.Handler, error) {
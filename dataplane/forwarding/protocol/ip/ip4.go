@@ -114,16 +114,13 @@ func (ip *IP4) Update(id fwdpacket.FieldID, op int, arg []byte) (bool, error) {
 	}
 	switch op {
 	case fwdpacket.OpDec:
-		// When decrementing the TTL, adjust the checksum and report
-		// the header as clean.
+		// When decrementing the TTL, recompute the checksum and report
+		// the header as clean. The checksum is recomputed rather than
+		// adjusted so that a stale checksum (e.g. on a packet injected by
+		// the CPU) is not carried forward.
 		if !id.IsUDF && id.Num == fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_HOP {
-			pttl := uint16(field.Value())
-			nttl := pttl - 1
-			field.SetValue(uint(nttl))
-
-			psum := uint16(ip.header.Field(ip4CSumPos, ip4CSumBytes).Value())
-			nsum := ^(^psum + ^pttl + nttl)
-			ip.header.Field(ip4CSumPos, ip4CSumBytes).SetValue(uint(nsum))
+			field.SetValue(field.Value() - hopDelta(arg))
+			ip.updateChecksum()
 			return false, nil
 		}
 
@@ -140,20 +137,29 @@ func (ip *IP4) Payload() (fwdpb.PacketHeaderId, int64) {
 
 // SetPayload sets the payload.
 func (ip *IP4) SetPayload(id fwdpb.PacketHeaderId, length int64) {
-	proto, ok := headerProto[id]
-	if !ok {
-		proto = protoReserved
-	}
-	ip.header.Field(ip4ProtoPos, ip4ProtoBytes).SetValue(uint(proto))
+	field := ip.header.Field(ip4ProtoPos, ip4ProtoBytes)
+	field.SetValue(uint(payloadProto(id, uint8(field.Value()))))
 	ip.header.Field(ip4LengthPos, ip4LengthBytes).SetValue(uint(length) + uint(len(ip.header)))
 	ip.payload = length
+	ip.updateChecksum()
+}
 
+// updateChecksum recomputes the header checksum.
+func (ip *IP4) updateChecksum() {
 	ip.header.Field(ip4CSumPos, ip4CSumBytes).SetValue(0)
 	var sum csum16.Sum
 	sum.Write(ip.header)
 	ip.header.Field(ip4CSumPos, ip4CSumBytes).SetValue(uint(sum))
 }
 
+// validChecksum returns true if the header checksum is correct. The
+// checksum of a header that includes a correct checksum is zero.
+func (ip *IP4) validChecksum() bool {
+	var sum csum16.Sum
+	sum.Write(ip.header)
+	return sum == 0
+}
+
 // newIP4 creates a new IPv4 header.
 func newIP4() header {
 	ip := &IP4{}
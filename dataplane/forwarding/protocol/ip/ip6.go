@@ -130,7 +130,7 @@ func (ip *IP6) Update(id fwdpacket.FieldID, op int, arg []byte) (bool, error) {
 			return false, fmt.Errorf("ip6: update failed, unsupported op %v for field %v", op, id)
 		}
 		ttl := field.Value()
-		field.SetValue(ttl - 1)
+		field.SetValue(ttl - hopDelta(arg))
 		return false, nil
 
 	case fwdpacket.OpSet:
@@ -159,11 +159,8 @@ func (ip *IP6) Payload() (fwdpb.PacketHeaderId, int64) {
 
 // SetPayload sets the payload.
 func (ip *IP6) SetPayload(id fwdpb.PacketHeaderId, length int64) {
-	proto, ok := headerProto[id]
-	if !ok {
-		proto = protoReserved
-	}
-	ip.header.Field(ip6ProtoPos, ip6ProtoBytes).SetValue(uint(proto))
+	field := ip.header.Field(ip6ProtoPos, ip6ProtoBytes)
+	field.SetValue(uint(payloadProto(id, uint8(field.Value()))))
 	ip.header.Field(ip6LengthPos, ip6LengthBytes).SetValue(uint(length))
 	ip.payload = length
 }
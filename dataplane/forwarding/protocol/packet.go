@@ -61,7 +61,7 @@ import (
 // 3. Headers are marked as clean only once the entire packet has been rebuilt.
 //
 // Note: The IP header is always modified on each hop as the TTL is decremented.
-// The IP TTL decrement code always adjusts the IP checksum and reports the IP
+// The IP TTL decrement code always recomputes the IP checksum and reports the IP
 // header as clean. This is essential to avoid recomputing TCP/UDP checksums
 // on each hop.
 
@@ -164,6 +164,17 @@ func (p *Packet) Frame() []byte {
 	return []byte{}
 }
 
+// ValidChecksums returns true if the checksums of all headers in the packet
+// that carry a checksum are correct.
+func (p *Packet) ValidChecksums() bool {
+	for d := p.headers[Sequence[0]]; d != nil; d = d.next {
+		if h, ok := d.handler.(ChecksumHandler); ok && !h.ValidChecksum() {
+			return false
+		}
+	}
+	return true
+}
+
 // Field returns the bytes associated with a field ID.
 func (p *Packet) Field(id fwdpacket.FieldID) ([]byte, error) {
 	header, id := p.fieldDesc(id)
@@ -167,3 +167,53 @@ func TestTCPchecksum(t *testing.T) {
 		}
 	}
 }
+
+func TestTCPValidChecksums(t *testing.T) {
+	// TCP segment with a correct checksum.
+	segment := append([]byte{}, tcpSegment...)
+	csum := tcp.ChecksumIPv4(segment, nil, ip4tcp[12:16], ip4tcp[16:20])
+	segment[16], segment[17] = byte(csum>>8), byte(csum)
+
+	frame := func(ip, segment, padding []byte) []byte {
+		var f []byte
+		for _, b := range [][]byte{ethernetIP4, ip, segment, padding} {
+			f = append(f, b...)
+		}
+		return f
+	}
+	badIP := append([]byte{}, ip4tcp...)
+	badIP[10] ^= 0xff
+	badTCP := append([]byte{}, segment...)
+	badTCP[17] ^= 0xff
+
+	tests := []struct {
+		desc  string
+		frame []byte
+		want  bool
+	}{{
+		desc:  "valid",
+		frame: frame(ip4tcp, segment, nil),
+		want:  true,
+	}, {
+		desc:  "valid with padding",
+		frame: frame(ip4tcp, segment, make([]byte, 6)),
+		want:  true,
+	}, {
+		desc:  "bad ip checksum",
+		frame: frame(badIP, segment, nil),
+	}, {
+		desc:  "bad tcp checksum",
+		frame: frame(ip4tcp, badTCP, nil),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			packet, err := fwdpacket.New(fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, tt.frame)
+			if err != nil {
+				t.Fatalf("fwdpacket.New() unexpected err: %v", err)
+			}
+			if got := packet.(fwdpacket.ChecksumValidator).ValidChecksums(); got != tt.want {
+				t.Errorf("ValidChecksums() got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
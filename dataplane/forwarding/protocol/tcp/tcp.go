@@ -214,6 +214,33 @@ func (tcp *TCP) Rebuild() error {
 	return nil
 }
 
+// ValidChecksum returns true if the TCP checksum is correct. Only TCP headers
+// over IP are verified. When the checksum in the TCP header is included in the
+// computation, a correct checksum results in a sum of zero.
+func (tcp *TCP) ValidChecksum() bool {
+	envelopeID := tcp.desc.EnvelopeID()
+	switch envelopeID {
+	case fwdpb.PacketHeaderId_PACKET_HEADER_ID_IP4, fwdpb.PacketHeaderId_PACKET_HEADER_ID_IP6:
+	default:
+		return true
+	}
+
+	ipSrc, err := tcp.desc.Packet.Field(fwdpacket.NewFieldIDFromNum(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_SRC, fwdpacket.LastField))
+	if err != nil {
+		return false
+	}
+	ipDst, err := tcp.desc.Packet.Field(fwdpacket.NewFieldIDFromNum(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_DST, fwdpacket.LastField))
+	if err != nil {
+		return false
+	}
+
+	segment := tcp.desc.Segment()
+	if envelopeID == fwdpb.PacketHeaderId_PACKET_HEADER_ID_IP4 {
+		return ChecksumIPv4(segment, nil, ipSrc, ipDst) == 0
+	}
+	return ChecksumIPv6(segment, nil, ipSrc, ipDst) == 0
+}
+
 // parse parses a TCP header in the packet.
 // It peeks into the TCP header to determine TCP header size (with extensions).
 // The payload of TCP is handled as an OPAQUE header.
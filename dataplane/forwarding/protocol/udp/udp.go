@@ -151,6 +151,52 @@ func (udp *UDP) Rebuild() error {
 	return nil
 }
 
+// ValidChecksum returns true if the UDP checksum is correct. A zero checksum
+// over IP4 indicates that the checksum was not computed and is always valid.
+func (udp *UDP) ValidChecksum() bool {
+	envelopeID := udp.desc.EnvelopeID()
+	switch envelopeID {
+	case fwdpb.PacketHeaderId_PACKET_HEADER_ID_IP4:
+		if udp.header.Field(csumOffset, csumBytes).Value() == 0 {
+			return true
+		}
+	case fwdpb.PacketHeaderId_PACKET_HEADER_ID_IP6:
+	default:
+		return true
+	}
+
+	src, err := udp.desc.Packet.Field(fwdpacket.NewFieldIDFromNum(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_SRC, fwdpacket.LastField))
+	if err != nil {
+		return false
+	}
+	dst, err := udp.desc.Packet.Field(fwdpacket.NewFieldIDFromNum(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_DST, fwdpacket.LastField))
+	if err != nil {
+		return false
+	}
+
+	// The checksum is computed over the IP pseudo header, the UDP header
+	// (including the checksum) and the payload.
+	segment := udp.desc.Segment()
+	var sum csum16.Sum
+	if envelopeID == fwdpb.PacketHeaderId_PACKET_HEADER_ID_IP4 {
+		sum.Write(fwdpacket.Truncate(src, protocol.SizeIP4))
+		sum.Write(fwdpacket.Truncate(dst, protocol.SizeIP4))
+		f := make([]byte, protocol.SizeUint16)
+		binary.BigEndian.PutUint16(f, uint16(len(segment)))
+		sum.Write([]byte{0, protoUDP})
+		sum.Write(f)
+	} else {
+		sum.Write(fwdpacket.Truncate(src, protocol.SizeIP6))
+		sum.Write(fwdpacket.Truncate(dst, protocol.SizeIP6))
+		f := make([]byte, protocol.SizeUint32)
+		binary.BigEndian.PutUint32(f, uint32(len(segment)))
+		sum.Write(f)
+		sum.Write([]byte{0, 0, 0, protoUDP})
+	}
+	sum.Write(segment)
+	return sum == 0
+}
+
 // add adds a UDP header to the packet.
 func add(_ fwdpb.PacketHeaderId, desc *protocol.Desc) (protocol.Handler, error) {
 	return &UDP{
@@ -28,6 +28,7 @@ import (
 type GenetlinkPort struct {
 	conn     *genetlink.Conn
 	familyID uint16
+	groupID  uint32
 }
 
 // NewGenetlinkPort creates netlink socket for the given family and multicast group.
@@ -54,10 +55,22 @@ func NewGenetlinkPort(family, group string) (*GenetlinkPort, error) {
 		return nil, err
 	}
 	return &GenetlinkPort{
-		conn: conn,
+		conn:     conn,
+		familyID: fam.ID,
+		groupID:  uint32(grpID),
 	}, nil
 }
 
+// FamilyID returns the ID the kernel assigned to the genetlink family.
+func (p GenetlinkPort) FamilyID() uint16 {
+	return p.familyID
+}
+
+// GroupID returns the ID the kernel assigned to the multicast group.
+func (p GenetlinkPort) GroupID() uint32 {
+	return p.groupID
+}
+
 type PacketMetadata struct {
 	SrcIfIndex int
 	DstIfIndex int
@@ -0,0 +1,2404 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.21.12
+// source: dataplane/proto/diag/diag.proto
+
+package diag
+
+import (
+	context "context"
+	packetio "github.com/openconfig/lemming/dataplane/proto/packetio"
+	sai "github.com/openconfig/lemming/dataplane/proto/sai"
+	forwarding "github.com/openconfig/lemming/proto/forwarding"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type HostifStat int32
+
+const (
+	HostifStat_HOSTIF_STAT_UNSPECIFIED HostifStat = 0
+	HostifStat_HOSTIF_STAT_IN_PACKETS  HostifStat = 1
+	HostifStat_HOSTIF_STAT_OUT_PACKETS HostifStat = 2
+	HostifStat_HOSTIF_STAT_IN_BYTES    HostifStat = 3
+	HostifStat_HOSTIF_STAT_OUT_BYTES   HostifStat = 4
+)
+
+// Enum value maps for HostifStat.
+var (
+	HostifStat_name = map[int32]string{
+		0: "HOSTIF_STAT_UNSPECIFIED",
+		1: "HOSTIF_STAT_IN_PACKETS",
+		2: "HOSTIF_STAT_OUT_PACKETS",
+		3: "HOSTIF_STAT_IN_BYTES",
+		4: "HOSTIF_STAT_OUT_BYTES",
+	}
+	HostifStat_value = map[string]int32{
+		"HOSTIF_STAT_UNSPECIFIED": 0,
+		"HOSTIF_STAT_IN_PACKETS":  1,
+		"HOSTIF_STAT_OUT_PACKETS": 2,
+		"HOSTIF_STAT_IN_BYTES":    3,
+		"HOSTIF_STAT_OUT_BYTES":   4,
+	}
+)
+
+func (x HostifStat) Enum() *HostifStat {
+	p := new(HostifStat)
+	*p = x
+	return p
+}
+
+func (x HostifStat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HostifStat) Descriptor() protoreflect.EnumDescriptor {
+	return file_dataplane_proto_diag_diag_proto_enumTypes[0].Descriptor()
+}
+
+func (HostifStat) Type() protoreflect.EnumType {
+	return &file_dataplane_proto_diag_diag_proto_enumTypes[0]
+}
+
+func (x HostifStat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HostifStat.Descriptor instead.
+func (HostifStat) EnumDescriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{0}
+}
+
+type RemoveAllRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type sai.ObjectType `protobuf:"varint,1,opt,name=type,proto3,enum=lemming.dataplane.sai.ObjectType" json:"type,omitempty"`
+}
+
+func (x *RemoveAllRequest) Reset() {
+	*x = RemoveAllRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveAllRequest) ProtoMessage() {}
+
+func (x *RemoveAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveAllRequest.ProtoReflect.Descriptor instead.
+func (*RemoveAllRequest) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RemoveAllRequest) GetType() sai.ObjectType {
+	if x != nil {
+		return x.Type
+	}
+	return sai.ObjectType(0)
+}
+
+type RemoveAllResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RemoveAllResponse) Reset() {
+	*x = RemoveAllResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveAllResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveAllResponse) ProtoMessage() {}
+
+func (x *RemoveAllResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveAllResponse.ProtoReflect.Descriptor instead.
+func (*RemoveAllResponse) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{1}
+}
+
+type LookupRouteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vrf uint64 `protobuf:"varint,1,opt,name=vrf,proto3" json:"vrf,omitempty"`
+	Dst []byte `protobuf:"bytes,2,opt,name=dst,proto3" json:"dst,omitempty"`
+}
+
+func (x *LookupRouteRequest) Reset() {
+	*x = LookupRouteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LookupRouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupRouteRequest) ProtoMessage() {}
+
+func (x *LookupRouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupRouteRequest.ProtoReflect.Descriptor instead.
+func (*LookupRouteRequest) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LookupRouteRequest) GetVrf() uint64 {
+	if x != nil {
+		return x.Vrf
+	}
+	return 0
+}
+
+func (x *LookupRouteRequest) GetDst() []byte {
+	if x != nil {
+		return x.Dst
+	}
+	return nil
+}
+
+type LookupRouteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Route           *sai.RouteEntry `protobuf:"bytes,1,opt,name=route,proto3" json:"route,omitempty"`
+	NextHop         uint64          `protobuf:"varint,2,opt,name=next_hop,json=nextHop,proto3" json:"next_hop,omitempty"`
+	NextHopIp       []byte          `protobuf:"bytes,3,opt,name=next_hop_ip,json=nextHopIp,proto3" json:"next_hop_ip,omitempty"`
+	RouterInterface uint64          `protobuf:"varint,4,opt,name=router_interface,json=routerInterface,proto3" json:"router_interface,omitempty"`
+	Port            uint64          `protobuf:"varint,5,opt,name=port,proto3" json:"port,omitempty"`
+	DstMac          []byte          `protobuf:"bytes,6,opt,name=dst_mac,json=dstMac,proto3" json:"dst_mac,omitempty"`
+}
+
+func (x *LookupRouteResponse) Reset() {
+	*x = LookupRouteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LookupRouteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupRouteResponse) ProtoMessage() {}
+
+func (x *LookupRouteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupRouteResponse.ProtoReflect.Descriptor instead.
+func (*LookupRouteResponse) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LookupRouteResponse) GetRoute() *sai.RouteEntry {
+	if x != nil {
+		return x.Route
+	}
+	return nil
+}
+
+func (x *LookupRouteResponse) GetNextHop() uint64 {
+	if x != nil {
+		return x.NextHop
+	}
+	return 0
+}
+
+func (x *LookupRouteResponse) GetNextHopIp() []byte {
+	if x != nil {
+		return x.NextHopIp
+	}
+	return nil
+}
+
+func (x *LookupRouteResponse) GetRouterInterface() uint64 {
+	if x != nil {
+		return x.RouterInterface
+	}
+	return 0
+}
+
+func (x *LookupRouteResponse) GetPort() uint64 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *LookupRouteResponse) GetDstMac() []byte {
+	if x != nil {
+		return x.DstMac
+	}
+	return nil
+}
+
+type TrapStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Packets uint64 `protobuf:"varint,1,opt,name=packets,proto3" json:"packets,omitempty"`
+	Bytes   uint64 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+}
+
+func (x *TrapStats) Reset() {
+	*x = TrapStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrapStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrapStats) ProtoMessage() {}
+
+func (x *TrapStats) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrapStats.ProtoReflect.Descriptor instead.
+func (*TrapStats) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TrapStats) GetPackets() uint64 {
+	if x != nil {
+		return x.Packets
+	}
+	return 0
+}
+
+func (x *TrapStats) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+type GetHostifTrapStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Oid uint64 `protobuf:"varint,1,opt,name=oid,proto3" json:"oid,omitempty"`
+}
+
+func (x *GetHostifTrapStatsRequest) Reset() {
+	*x = GetHostifTrapStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHostifTrapStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHostifTrapStatsRequest) ProtoMessage() {}
+
+func (x *GetHostifTrapStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHostifTrapStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetHostifTrapStatsRequest) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetHostifTrapStatsRequest) GetOid() uint64 {
+	if x != nil {
+		return x.Oid
+	}
+	return 0
+}
+
+type GetHostifTrapStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stats *TrapStats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *GetHostifTrapStatsResponse) Reset() {
+	*x = GetHostifTrapStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHostifTrapStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHostifTrapStatsResponse) ProtoMessage() {}
+
+func (x *GetHostifTrapStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHostifTrapStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetHostifTrapStatsResponse) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetHostifTrapStatsResponse) GetStats() *TrapStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type GetHostifTrapGroupStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Oid uint64 `protobuf:"varint,1,opt,name=oid,proto3" json:"oid,omitempty"`
+}
+
+func (x *GetHostifTrapGroupStatsRequest) Reset() {
+	*x = GetHostifTrapGroupStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHostifTrapGroupStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHostifTrapGroupStatsRequest) ProtoMessage() {}
+
+func (x *GetHostifTrapGroupStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHostifTrapGroupStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetHostifTrapGroupStatsRequest) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetHostifTrapGroupStatsRequest) GetOid() uint64 {
+	if x != nil {
+		return x.Oid
+	}
+	return 0
+}
+
+type GetHostifTrapGroupStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stats *TrapStats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *GetHostifTrapGroupStatsResponse) Reset() {
+	*x = GetHostifTrapGroupStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHostifTrapGroupStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHostifTrapGroupStatsResponse) ProtoMessage() {}
+
+func (x *GetHostifTrapGroupStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHostifTrapGroupStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetHostifTrapGroupStatsResponse) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetHostifTrapGroupStatsResponse) GetStats() *TrapStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type GetCPUPuntStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetCPUPuntStatsRequest) Reset() {
+	*x = GetCPUPuntStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCPUPuntStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCPUPuntStatsRequest) ProtoMessage() {}
+
+func (x *GetCPUPuntStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCPUPuntStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetCPUPuntStatsRequest) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{9}
+}
+
+type GetCPUPuntStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Trapped      uint64 `protobuf:"varint,1,opt,name=trapped,proto3" json:"trapped,omitempty"`
+	PolicerDrops uint64 `protobuf:"varint,2,opt,name=policer_drops,json=policerDrops,proto3" json:"policer_drops,omitempty"`
+	QueueDrops   uint64 `protobuf:"varint,3,opt,name=queue_drops,json=queueDrops,proto3" json:"queue_drops,omitempty"`
+	Delivered    uint64 `protobuf:"varint,4,opt,name=delivered,proto3" json:"delivered,omitempty"`
+}
+
+func (x *GetCPUPuntStatsResponse) Reset() {
+	*x = GetCPUPuntStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCPUPuntStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCPUPuntStatsResponse) ProtoMessage() {}
+
+func (x *GetCPUPuntStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCPUPuntStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetCPUPuntStatsResponse) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetCPUPuntStatsResponse) GetTrapped() uint64 {
+	if x != nil {
+		return x.Trapped
+	}
+	return 0
+}
+
+func (x *GetCPUPuntStatsResponse) GetPolicerDrops() uint64 {
+	if x != nil {
+		return x.PolicerDrops
+	}
+	return 0
+}
+
+func (x *GetCPUPuntStatsResponse) GetQueueDrops() uint64 {
+	if x != nil {
+		return x.QueueDrops
+	}
+	return 0
+}
+
+func (x *GetCPUPuntStatsResponse) GetDelivered() uint64 {
+	if x != nil {
+		return x.Delivered
+	}
+	return 0
+}
+
+type GetHostifStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Oid        uint64       `protobuf:"varint,1,opt,name=oid,proto3" json:"oid,omitempty"`
+	CounterIds []HostifStat `protobuf:"varint,2,rep,packed,name=counter_ids,json=counterIds,proto3,enum=lucius.dataplane.diag.HostifStat" json:"counter_ids,omitempty"`
+}
+
+func (x *GetHostifStatsRequest) Reset() {
+	*x = GetHostifStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHostifStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHostifStatsRequest) ProtoMessage() {}
+
+func (x *GetHostifStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHostifStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetHostifStatsRequest) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetHostifStatsRequest) GetOid() uint64 {
+	if x != nil {
+		return x.Oid
+	}
+	return 0
+}
+
+func (x *GetHostifStatsRequest) GetCounterIds() []HostifStat {
+	if x != nil {
+		return x.CounterIds
+	}
+	return nil
+}
+
+type GetHostifStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []uint64 `protobuf:"varint,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *GetHostifStatsResponse) Reset() {
+	*x = GetHostifStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHostifStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHostifStatsResponse) ProtoMessage() {}
+
+func (x *GetHostifStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHostifStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetHostifStatsResponse) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetHostifStatsResponse) GetValues() []uint64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type ExcludeHostifTrapSourceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Oid          uint64           `protobuf:"varint,1,opt,name=oid,proto3" json:"oid,omitempty"`
+	Src          *sai.IpPrefix    `protobuf:"bytes,2,opt,name=src,proto3" json:"src,omitempty"`
+	PacketAction sai.PacketAction `protobuf:"varint,3,opt,name=packet_action,json=packetAction,proto3,enum=lemming.dataplane.sai.PacketAction" json:"packet_action,omitempty"`
+}
+
+func (x *ExcludeHostifTrapSourceRequest) Reset() {
+	*x = ExcludeHostifTrapSourceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExcludeHostifTrapSourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExcludeHostifTrapSourceRequest) ProtoMessage() {}
+
+func (x *ExcludeHostifTrapSourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExcludeHostifTrapSourceRequest.ProtoReflect.Descriptor instead.
+func (*ExcludeHostifTrapSourceRequest) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExcludeHostifTrapSourceRequest) GetOid() uint64 {
+	if x != nil {
+		return x.Oid
+	}
+	return 0
+}
+
+func (x *ExcludeHostifTrapSourceRequest) GetSrc() *sai.IpPrefix {
+	if x != nil {
+		return x.Src
+	}
+	return nil
+}
+
+func (x *ExcludeHostifTrapSourceRequest) GetPacketAction() sai.PacketAction {
+	if x != nil {
+		return x.PacketAction
+	}
+	return sai.PacketAction(0)
+}
+
+type ExcludeHostifTrapSourceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ExcludeHostifTrapSourceResponse) Reset() {
+	*x = ExcludeHostifTrapSourceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExcludeHostifTrapSourceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExcludeHostifTrapSourceResponse) ProtoMessage() {}
+
+func (x *ExcludeHostifTrapSourceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExcludeHostifTrapSourceResponse.ProtoReflect.Descriptor instead.
+func (*ExcludeHostifTrapSourceResponse) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{14}
+}
+
+type LookupHostifTableEntryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrapId uint64 `protobuf:"varint,1,opt,name=trap_id,json=trapId,proto3" json:"trap_id,omitempty"`
+}
+
+func (x *LookupHostifTableEntryRequest) Reset() {
+	*x = LookupHostifTableEntryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LookupHostifTableEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupHostifTableEntryRequest) ProtoMessage() {}
+
+func (x *LookupHostifTableEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupHostifTableEntryRequest.ProtoReflect.Descriptor instead.
+func (*LookupHostifTableEntryRequest) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *LookupHostifTableEntryRequest) GetTrapId() uint64 {
+	if x != nil {
+		return x.TrapId
+	}
+	return 0
+}
+
+type LookupHostifTableEntryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hostif   uint64 `protobuf:"varint,1,opt,name=hostif,proto3" json:"hostif,omitempty"`
+	Wildcard bool   `protobuf:"varint,2,opt,name=wildcard,proto3" json:"wildcard,omitempty"`
+}
+
+func (x *LookupHostifTableEntryResponse) Reset() {
+	*x = LookupHostifTableEntryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LookupHostifTableEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupHostifTableEntryResponse) ProtoMessage() {}
+
+func (x *LookupHostifTableEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupHostifTableEntryResponse.ProtoReflect.Descriptor instead.
+func (*LookupHostifTableEntryResponse) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *LookupHostifTableEntryResponse) GetHostif() uint64 {
+	if x != nil {
+		return x.Hostif
+	}
+	return 0
+}
+
+func (x *LookupHostifTableEntryResponse) GetWildcard() bool {
+	if x != nil {
+		return x.Wildcard
+	}
+	return false
+}
+
+type GetGenetlinkHostifIdsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Oid uint64 `protobuf:"varint,1,opt,name=oid,proto3" json:"oid,omitempty"`
+}
+
+func (x *GetGenetlinkHostifIdsRequest) Reset() {
+	*x = GetGenetlinkHostifIdsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGenetlinkHostifIdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGenetlinkHostifIdsRequest) ProtoMessage() {}
+
+func (x *GetGenetlinkHostifIdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGenetlinkHostifIdsRequest.ProtoReflect.Descriptor instead.
+func (*GetGenetlinkHostifIdsRequest) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetGenetlinkHostifIdsRequest) GetOid() uint64 {
+	if x != nil {
+		return x.Oid
+	}
+	return 0
+}
+
+type GetGenetlinkHostifIdsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids *packetio.GenetlinkPortIds `protobuf:"bytes,1,opt,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *GetGenetlinkHostifIdsResponse) Reset() {
+	*x = GetGenetlinkHostifIdsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGenetlinkHostifIdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGenetlinkHostifIdsResponse) ProtoMessage() {}
+
+func (x *GetGenetlinkHostifIdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGenetlinkHostifIdsResponse.ProtoReflect.Descriptor instead.
+func (*GetGenetlinkHostifIdsResponse) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetGenetlinkHostifIdsResponse) GetIds() *packetio.GenetlinkPortIds {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type HostifStream struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind    string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Id      uint64                 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Started *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=started,proto3" json:"started,omitempty"`
+	Alive   bool                   `protobuf:"varint,4,opt,name=alive,proto3" json:"alive,omitempty"`
+	Dropped uint64                 `protobuf:"varint,5,opt,name=dropped,proto3" json:"dropped,omitempty"`
+}
+
+func (x *HostifStream) Reset() {
+	*x = HostifStream{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostifStream) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostifStream) ProtoMessage() {}
+
+func (x *HostifStream) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostifStream.ProtoReflect.Descriptor instead.
+func (*HostifStream) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *HostifStream) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *HostifStream) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *HostifStream) GetStarted() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Started
+	}
+	return nil
+}
+
+func (x *HostifStream) GetAlive() bool {
+	if x != nil {
+		return x.Alive
+	}
+	return false
+}
+
+func (x *HostifStream) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+type ListHostifStreamsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListHostifStreamsRequest) Reset() {
+	*x = ListHostifStreamsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListHostifStreamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHostifStreamsRequest) ProtoMessage() {}
+
+func (x *ListHostifStreamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHostifStreamsRequest.ProtoReflect.Descriptor instead.
+func (*ListHostifStreamsRequest) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{20}
+}
+
+type ListHostifStreamsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Streams []*HostifStream `protobuf:"bytes,1,rep,name=streams,proto3" json:"streams,omitempty"`
+}
+
+func (x *ListHostifStreamsResponse) Reset() {
+	*x = ListHostifStreamsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListHostifStreamsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHostifStreamsResponse) ProtoMessage() {}
+
+func (x *ListHostifStreamsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHostifStreamsResponse.ProtoReflect.Descriptor instead.
+func (*ListHostifStreamsResponse) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListHostifStreamsResponse) GetStreams() []*HostifStream {
+	if x != nil {
+		return x.Streams
+	}
+	return nil
+}
+
+type GetHostifActionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Oid uint64 `protobuf:"varint,1,opt,name=oid,proto3" json:"oid,omitempty"`
+}
+
+func (x *GetHostifActionsRequest) Reset() {
+	*x = GetHostifActionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHostifActionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHostifActionsRequest) ProtoMessage() {}
+
+func (x *GetHostifActionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHostifActionsRequest.ProtoReflect.Descriptor instead.
+func (*GetHostifActionsRequest) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetHostifActionsRequest) GetOid() uint64 {
+	if x != nil {
+		return x.Oid
+	}
+	return 0
+}
+
+type GetHostifActionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Inputs []*forwarding.ActionDesc `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+}
+
+func (x *GetHostifActionsResponse) Reset() {
+	*x = GetHostifActionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_diag_diag_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHostifActionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHostifActionsResponse) ProtoMessage() {}
+
+func (x *GetHostifActionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_diag_diag_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHostifActionsResponse.ProtoReflect.Descriptor instead.
+func (*GetHostifActionsResponse) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_diag_diag_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetHostifActionsResponse) GetInputs() []*forwarding.ActionDesc {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+var File_dataplane_proto_diag_diag_proto protoreflect.FileDescriptor
+
+var file_dataplane_proto_diag_diag_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x64, 0x69, 0x61, 0x67, 0x2f, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x15, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c,
+	0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x1a, 0x27, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c,
+	0x61, 0x6e, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x69, 0x6f, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x20, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x73, 0x61, 0x69, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x28, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x66, 0x6f, 0x72, 0x77,
+	0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x49,
+	0x0a, 0x10, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x35, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x21, 0x2e, 0x6c, 0x65, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70,
+	0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x73, 0x61, 0x69, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x38,
+	0x0a, 0x12, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x72, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x03, 0x76, 0x72, 0x66, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x73, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x64, 0x73, 0x74, 0x22, 0xe1, 0x01, 0x0a, 0x13, 0x4c, 0x6f, 0x6f,
+	0x6b, 0x75, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x37, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x6c, 0x65, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c,
+	0x61, 0x6e, 0x65, 0x2e, 0x73, 0x61, 0x69, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x65, 0x78,
+	0x74, 0x5f, 0x68, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x6e, 0x65, 0x78,
+	0x74, 0x48, 0x6f, 0x70, 0x12, 0x1e, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x68, 0x6f, 0x70,
+	0x5f, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x48,
+	0x6f, 0x70, 0x49, 0x70, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x5f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x73, 0x74, 0x5f, 0x6d, 0x61, 0x63, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x73, 0x74, 0x4d, 0x61, 0x63, 0x22, 0x3b, 0x0a, 0x09,
+	0x54, 0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x22, 0x2d, 0x0a, 0x19, 0x47, 0x65, 0x74,
+	0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x54, 0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x03, 0x6f, 0x69, 0x64, 0x22, 0x54, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x48,
+	0x6f, 0x73, 0x74, 0x69, 0x66, 0x54, 0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x54, 0x72,
+	0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0x32,
+	0x0a, 0x1e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x54, 0x72, 0x61, 0x70, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x6f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6f,
+	0x69, 0x64, 0x22, 0x59, 0x0a, 0x1f, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x54,
+	0x72, 0x61, 0x70, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x54, 0x72, 0x61,
+	0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0x18, 0x0a,
+	0x16, 0x47, 0x65, 0x74, 0x43, 0x50, 0x55, 0x50, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x97, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x43,
+	0x50, 0x55, 0x50, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x70, 0x70, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x72, 0x61, 0x70, 0x70, 0x65, 0x64, 0x12, 0x23, 0x0a,
+	0x0d, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x65, 0x72, 0x5f, 0x64, 0x72, 0x6f, 0x70, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x65, 0x72, 0x44, 0x72, 0x6f,
+	0x70, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x64, 0x72, 0x6f, 0x70,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x44, 0x72,
+	0x6f, 0x70, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65,
+	0x64, 0x22, 0x6d, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6f, 0x69, 0x64, 0x12, 0x42, 0x0a, 0x0b,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0e, 0x32, 0x21, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70,
+	0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66,
+	0x53, 0x74, 0x61, 0x74, 0x52, 0x0a, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x49, 0x64, 0x73,
+	0x22, 0x30, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x22, 0xaf, 0x01, 0x0a, 0x1e, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x48, 0x6f,
+	0x73, 0x74, 0x69, 0x66, 0x54, 0x72, 0x61, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x03, 0x6f, 0x69, 0x64, 0x12, 0x31, 0x0a, 0x03, 0x73, 0x72, 0x63, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c, 0x65, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x73, 0x61, 0x69, 0x2e, 0x49, 0x70, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x48, 0x0a, 0x0d, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x23, 0x2e, 0x6c, 0x65, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x73, 0x61, 0x69, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x21, 0x0a, 0x1f, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x48,
+	0x6f, 0x73, 0x74, 0x69, 0x66, 0x54, 0x72, 0x61, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x38, 0x0a, 0x1d, 0x4c, 0x6f, 0x6f, 0x6b, 0x75,
+	0x70, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x70,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x74, 0x72, 0x61, 0x70, 0x49,
+	0x64, 0x22, 0x54, 0x0a, 0x1e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x48, 0x6f, 0x73, 0x74, 0x69,
+	0x66, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x12, 0x1a, 0x0a, 0x08, 0x77,
+	0x69, 0x6c, 0x64, 0x63, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x77,
+	0x69, 0x6c, 0x64, 0x63, 0x61, 0x72, 0x64, 0x22, 0x30, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x47, 0x65,
+	0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x49, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6f, 0x69, 0x64, 0x22, 0x5e, 0x0a, 0x1d, 0x47, 0x65, 0x74,
+	0x47, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x49,
+	0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x03, 0x69, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x69, 0x6f, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72,
+	0x74, 0x49, 0x64, 0x73, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x98, 0x01, 0x0a, 0x0c, 0x48, 0x6f,
+	0x73, 0x74, 0x69, 0x66, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x34,
+	0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72,
+	0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x64, 0x72, 0x6f,
+	0x70, 0x70, 0x65, 0x64, 0x22, 0x1a, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74,
+	0x69, 0x66, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x5a, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a,
+	0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e,
+	0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x22, 0x2b, 0x0a, 0x17,
+	0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6f, 0x69, 0x64, 0x22, 0x4a, 0x0a, 0x18, 0x47, 0x65, 0x74,
+	0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69,
+	0x6e, 0x67, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x06, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x73, 0x2a, 0x97, 0x01, 0x0a, 0x0a, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66,
+	0x53, 0x74, 0x61, 0x74, 0x12, 0x1b, 0x0a, 0x17, 0x48, 0x4f, 0x53, 0x54, 0x49, 0x46, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x1a, 0x0a, 0x16, 0x48, 0x4f, 0x53, 0x54, 0x49, 0x46, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x5f, 0x49, 0x4e, 0x5f, 0x50, 0x41, 0x43, 0x4b, 0x45, 0x54, 0x53, 0x10, 0x01, 0x12, 0x1b, 0x0a,
+	0x17, 0x48, 0x4f, 0x53, 0x54, 0x49, 0x46, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x5f, 0x4f, 0x55, 0x54,
+	0x5f, 0x50, 0x41, 0x43, 0x4b, 0x45, 0x54, 0x53, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x48, 0x4f,
+	0x53, 0x54, 0x49, 0x46, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x5f, 0x49, 0x4e, 0x5f, 0x42, 0x59, 0x54,
+	0x45, 0x53, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x48, 0x4f, 0x53, 0x54, 0x49, 0x46, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x5f, 0x4f, 0x55, 0x54, 0x5f, 0x42, 0x59, 0x54, 0x45, 0x53, 0x10, 0x04, 0x32,
+	0xce, 0x0a, 0x0a, 0x04, 0x44, 0x69, 0x61, 0x67, 0x12, 0x60, 0x0a, 0x09, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x41, 0x6c, 0x6c, 0x12, 0x27, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28,
+	0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e,
+	0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x41, 0x6c, 0x6c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x66, 0x0a, 0x0b, 0x4c, 0x6f,
+	0x6f, 0x6b, 0x75, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x29, 0x2e, 0x6c, 0x75, 0x63, 0x69,
+	0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61,
+	0x67, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x4c, 0x6f, 0x6f,
+	0x6b, 0x75, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x7b, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x54,
+	0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x30, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75,
+	0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67,
+	0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x54, 0x72, 0x61, 0x70, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x6c, 0x75, 0x63,
+	0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69,
+	0x61, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x54, 0x72, 0x61, 0x70,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x8a, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x54, 0x72, 0x61,
+	0x70, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x35, 0x2e, 0x6c, 0x75,
+	0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64,
+	0x69, 0x61, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x54, 0x72, 0x61,
+	0x70, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x36, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f,
+	0x73, 0x74, 0x69, 0x66, 0x54, 0x72, 0x61, 0x70, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x72, 0x0a, 0x0f,
+	0x47, 0x65, 0x74, 0x43, 0x50, 0x55, 0x50, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x2d, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61,
+	0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x50, 0x55, 0x50, 0x75,
+	0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e,
+	0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e,
+	0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x50, 0x55, 0x50, 0x75, 0x6e,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x6f, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x2c, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f,
+	0x73, 0x74, 0x69, 0x66, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2d, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c,
+	0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74,
+	0x69, 0x66, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x8a, 0x01, 0x0a, 0x17, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x48, 0x6f, 0x73,
+	0x74, 0x69, 0x66, 0x54, 0x72, 0x61, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x35, 0x2e,
+	0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x48, 0x6f, 0x73,
+	0x74, 0x69, 0x66, 0x54, 0x72, 0x61, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x36, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x45, 0x78, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x54, 0x72, 0x61, 0x70, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x87,
+	0x01, 0x0a, 0x16, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x34, 0x2e, 0x6c, 0x75, 0x63, 0x69,
+	0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61,
+	0x67, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x35, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61,
+	0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x48, 0x6f,
+	0x73, 0x74, 0x69, 0x66, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x84, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74,
+	0x47, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x49,
+	0x64, 0x73, 0x12, 0x33, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x65,
+	0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x49, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e,
+	0x47, 0x65, 0x74, 0x47, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x48, 0x6f, 0x73, 0x74,
+	0x69, 0x66, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x78, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x73, 0x12, 0x2f, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x75, 0x0a, 0x10, 0x47, 0x65, 0x74,
+	0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2e, 0x2e,
+	0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e,
+	0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x2e, 0x64, 0x69, 0x61, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x69, 0x66, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f,
+	0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x6c, 0x65, 0x6d, 0x6d, 0x69, 0x6e,
+	0x67, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x64, 0x69, 0x61, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_dataplane_proto_diag_diag_proto_rawDescOnce sync.Once
+	file_dataplane_proto_diag_diag_proto_rawDescData = file_dataplane_proto_diag_diag_proto_rawDesc
+)
+
+func file_dataplane_proto_diag_diag_proto_rawDescGZIP() []byte {
+	file_dataplane_proto_diag_diag_proto_rawDescOnce.Do(func() {
+		file_dataplane_proto_diag_diag_proto_rawDescData = protoimpl.X.CompressGZIP(file_dataplane_proto_diag_diag_proto_rawDescData)
+	})
+	return file_dataplane_proto_diag_diag_proto_rawDescData
+}
+
+var file_dataplane_proto_diag_diag_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_dataplane_proto_diag_diag_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_dataplane_proto_diag_diag_proto_goTypes = []interface{}{
+	(HostifStat)(0),                         // 0: lucius.dataplane.diag.HostifStat
+	(*RemoveAllRequest)(nil),                // 1: lucius.dataplane.diag.RemoveAllRequest
+	(*RemoveAllResponse)(nil),               // 2: lucius.dataplane.diag.RemoveAllResponse
+	(*LookupRouteRequest)(nil),              // 3: lucius.dataplane.diag.LookupRouteRequest
+	(*LookupRouteResponse)(nil),             // 4: lucius.dataplane.diag.LookupRouteResponse
+	(*TrapStats)(nil),                       // 5: lucius.dataplane.diag.TrapStats
+	(*GetHostifTrapStatsRequest)(nil),       // 6: lucius.dataplane.diag.GetHostifTrapStatsRequest
+	(*GetHostifTrapStatsResponse)(nil),      // 7: lucius.dataplane.diag.GetHostifTrapStatsResponse
+	(*GetHostifTrapGroupStatsRequest)(nil),  // 8: lucius.dataplane.diag.GetHostifTrapGroupStatsRequest
+	(*GetHostifTrapGroupStatsResponse)(nil), // 9: lucius.dataplane.diag.GetHostifTrapGroupStatsResponse
+	(*GetCPUPuntStatsRequest)(nil),          // 10: lucius.dataplane.diag.GetCPUPuntStatsRequest
+	(*GetCPUPuntStatsResponse)(nil),         // 11: lucius.dataplane.diag.GetCPUPuntStatsResponse
+	(*GetHostifStatsRequest)(nil),           // 12: lucius.dataplane.diag.GetHostifStatsRequest
+	(*GetHostifStatsResponse)(nil),          // 13: lucius.dataplane.diag.GetHostifStatsResponse
+	(*ExcludeHostifTrapSourceRequest)(nil),  // 14: lucius.dataplane.diag.ExcludeHostifTrapSourceRequest
+	(*ExcludeHostifTrapSourceResponse)(nil), // 15: lucius.dataplane.diag.ExcludeHostifTrapSourceResponse
+	(*LookupHostifTableEntryRequest)(nil),   // 16: lucius.dataplane.diag.LookupHostifTableEntryRequest
+	(*LookupHostifTableEntryResponse)(nil),  // 17: lucius.dataplane.diag.LookupHostifTableEntryResponse
+	(*GetGenetlinkHostifIdsRequest)(nil),    // 18: lucius.dataplane.diag.GetGenetlinkHostifIdsRequest
+	(*GetGenetlinkHostifIdsResponse)(nil),   // 19: lucius.dataplane.diag.GetGenetlinkHostifIdsResponse
+	(*HostifStream)(nil),                    // 20: lucius.dataplane.diag.HostifStream
+	(*ListHostifStreamsRequest)(nil),        // 21: lucius.dataplane.diag.ListHostifStreamsRequest
+	(*ListHostifStreamsResponse)(nil),       // 22: lucius.dataplane.diag.ListHostifStreamsResponse
+	(*GetHostifActionsRequest)(nil),         // 23: lucius.dataplane.diag.GetHostifActionsRequest
+	(*GetHostifActionsResponse)(nil),        // 24: lucius.dataplane.diag.GetHostifActionsResponse
+	(sai.ObjectType)(0),                     // 25: lemming.dataplane.sai.ObjectType
+	(*sai.RouteEntry)(nil),                  // 26: lemming.dataplane.sai.RouteEntry
+	(*sai.IpPrefix)(nil),                    // 27: lemming.dataplane.sai.IpPrefix
+	(sai.PacketAction)(0),                   // 28: lemming.dataplane.sai.PacketAction
+	(*packetio.GenetlinkPortIds)(nil),       // 29: lucius.dataplane.packetio.GenetlinkPortIds
+	(*timestamppb.Timestamp)(nil),           // 30: google.protobuf.Timestamp
+	(*forwarding.ActionDesc)(nil),           // 31: forwarding.ActionDesc
+}
+var file_dataplane_proto_diag_diag_proto_depIdxs = []int32{
+	25, // 0: lucius.dataplane.diag.RemoveAllRequest.type:type_name -> lemming.dataplane.sai.ObjectType
+	26, // 1: lucius.dataplane.diag.LookupRouteResponse.route:type_name -> lemming.dataplane.sai.RouteEntry
+	5,  // 2: lucius.dataplane.diag.GetHostifTrapStatsResponse.stats:type_name -> lucius.dataplane.diag.TrapStats
+	5,  // 3: lucius.dataplane.diag.GetHostifTrapGroupStatsResponse.stats:type_name -> lucius.dataplane.diag.TrapStats
+	0,  // 4: lucius.dataplane.diag.GetHostifStatsRequest.counter_ids:type_name -> lucius.dataplane.diag.HostifStat
+	27, // 5: lucius.dataplane.diag.ExcludeHostifTrapSourceRequest.src:type_name -> lemming.dataplane.sai.IpPrefix
+	28, // 6: lucius.dataplane.diag.ExcludeHostifTrapSourceRequest.packet_action:type_name -> lemming.dataplane.sai.PacketAction
+	29, // 7: lucius.dataplane.diag.GetGenetlinkHostifIdsResponse.ids:type_name -> lucius.dataplane.packetio.GenetlinkPortIds
+	30, // 8: lucius.dataplane.diag.HostifStream.started:type_name -> google.protobuf.Timestamp
+	20, // 9: lucius.dataplane.diag.ListHostifStreamsResponse.streams:type_name -> lucius.dataplane.diag.HostifStream
+	31, // 10: lucius.dataplane.diag.GetHostifActionsResponse.inputs:type_name -> forwarding.ActionDesc
+	1,  // 11: lucius.dataplane.diag.Diag.RemoveAll:input_type -> lucius.dataplane.diag.RemoveAllRequest
+	3,  // 12: lucius.dataplane.diag.Diag.LookupRoute:input_type -> lucius.dataplane.diag.LookupRouteRequest
+	6,  // 13: lucius.dataplane.diag.Diag.GetHostifTrapStats:input_type -> lucius.dataplane.diag.GetHostifTrapStatsRequest
+	8,  // 14: lucius.dataplane.diag.Diag.GetHostifTrapGroupStats:input_type -> lucius.dataplane.diag.GetHostifTrapGroupStatsRequest
+	10, // 15: lucius.dataplane.diag.Diag.GetCPUPuntStats:input_type -> lucius.dataplane.diag.GetCPUPuntStatsRequest
+	12, // 16: lucius.dataplane.diag.Diag.GetHostifStats:input_type -> lucius.dataplane.diag.GetHostifStatsRequest
+	14, // 17: lucius.dataplane.diag.Diag.ExcludeHostifTrapSource:input_type -> lucius.dataplane.diag.ExcludeHostifTrapSourceRequest
+	16, // 18: lucius.dataplane.diag.Diag.LookupHostifTableEntry:input_type -> lucius.dataplane.diag.LookupHostifTableEntryRequest
+	18, // 19: lucius.dataplane.diag.Diag.GetGenetlinkHostifIds:input_type -> lucius.dataplane.diag.GetGenetlinkHostifIdsRequest
+	21, // 20: lucius.dataplane.diag.Diag.ListHostifStreams:input_type -> lucius.dataplane.diag.ListHostifStreamsRequest
+	23, // 21: lucius.dataplane.diag.Diag.GetHostifActions:input_type -> lucius.dataplane.diag.GetHostifActionsRequest
+	2,  // 22: lucius.dataplane.diag.Diag.RemoveAll:output_type -> lucius.dataplane.diag.RemoveAllResponse
+	4,  // 23: lucius.dataplane.diag.Diag.LookupRoute:output_type -> lucius.dataplane.diag.LookupRouteResponse
+	7,  // 24: lucius.dataplane.diag.Diag.GetHostifTrapStats:output_type -> lucius.dataplane.diag.GetHostifTrapStatsResponse
+	9,  // 25: lucius.dataplane.diag.Diag.GetHostifTrapGroupStats:output_type -> lucius.dataplane.diag.GetHostifTrapGroupStatsResponse
+	11, // 26: lucius.dataplane.diag.Diag.GetCPUPuntStats:output_type -> lucius.dataplane.diag.GetCPUPuntStatsResponse
+	13, // 27: lucius.dataplane.diag.Diag.GetHostifStats:output_type -> lucius.dataplane.diag.GetHostifStatsResponse
+	15, // 28: lucius.dataplane.diag.Diag.ExcludeHostifTrapSource:output_type -> lucius.dataplane.diag.ExcludeHostifTrapSourceResponse
+	17, // 29: lucius.dataplane.diag.Diag.LookupHostifTableEntry:output_type -> lucius.dataplane.diag.LookupHostifTableEntryResponse
+	19, // 30: lucius.dataplane.diag.Diag.GetGenetlinkHostifIds:output_type -> lucius.dataplane.diag.GetGenetlinkHostifIdsResponse
+	22, // 31: lucius.dataplane.diag.Diag.ListHostifStreams:output_type -> lucius.dataplane.diag.ListHostifStreamsResponse
+	24, // 32: lucius.dataplane.diag.Diag.GetHostifActions:output_type -> lucius.dataplane.diag.GetHostifActionsResponse
+	22, // [22:33] is the sub-list for method output_type
+	11, // [11:22] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_dataplane_proto_diag_diag_proto_init() }
+func file_dataplane_proto_diag_diag_proto_init() {
+	if File_dataplane_proto_diag_diag_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_dataplane_proto_diag_diag_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveAllRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveAllResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookupRouteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookupRouteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrapStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHostifTrapStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHostifTrapStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHostifTrapGroupStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHostifTrapGroupStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCPUPuntStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCPUPuntStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHostifStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHostifStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExcludeHostifTrapSourceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExcludeHostifTrapSourceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookupHostifTableEntryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookupHostifTableEntryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGenetlinkHostifIdsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGenetlinkHostifIdsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostifStream); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListHostifStreamsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListHostifStreamsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHostifActionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_diag_diag_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHostifActionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_dataplane_proto_diag_diag_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_dataplane_proto_diag_diag_proto_goTypes,
+		DependencyIndexes: file_dataplane_proto_diag_diag_proto_depIdxs,
+		EnumInfos:         file_dataplane_proto_diag_diag_proto_enumTypes,
+		MessageInfos:      file_dataplane_proto_diag_diag_proto_msgTypes,
+	}.Build()
+	File_dataplane_proto_diag_diag_proto = out.File
+	file_dataplane_proto_diag_diag_proto_rawDesc = nil
+	file_dataplane_proto_diag_diag_proto_goTypes = nil
+	file_dataplane_proto_diag_diag_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// DiagClient is the client API for Diag service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DiagClient interface {
+	RemoveAll(ctx context.Context, in *RemoveAllRequest, opts ...grpc.CallOption) (*RemoveAllResponse, error)
+	LookupRoute(ctx context.Context, in *LookupRouteRequest, opts ...grpc.CallOption) (*LookupRouteResponse, error)
+	GetHostifTrapStats(ctx context.Context, in *GetHostifTrapStatsRequest, opts ...grpc.CallOption) (*GetHostifTrapStatsResponse, error)
+	GetHostifTrapGroupStats(ctx context.Context, in *GetHostifTrapGroupStatsRequest, opts ...grpc.CallOption) (*GetHostifTrapGroupStatsResponse, error)
+	GetCPUPuntStats(ctx context.Context, in *GetCPUPuntStatsRequest, opts ...grpc.CallOption) (*GetCPUPuntStatsResponse, error)
+	GetHostifStats(ctx context.Context, in *GetHostifStatsRequest, opts ...grpc.CallOption) (*GetHostifStatsResponse, error)
+	ExcludeHostifTrapSource(ctx context.Context, in *ExcludeHostifTrapSourceRequest, opts ...grpc.CallOption) (*ExcludeHostifTrapSourceResponse, error)
+	LookupHostifTableEntry(ctx context.Context, in *LookupHostifTableEntryRequest, opts ...grpc.CallOption) (*LookupHostifTableEntryResponse, error)
+	GetGenetlinkHostifIds(ctx context.Context, in *GetGenetlinkHostifIdsRequest, opts ...grpc.CallOption) (*GetGenetlinkHostifIdsResponse, error)
+	ListHostifStreams(ctx context.Context, in *ListHostifStreamsRequest, opts ...grpc.CallOption) (*ListHostifStreamsResponse, error)
+	GetHostifActions(ctx context.Context, in *GetHostifActionsRequest, opts ...grpc.CallOption) (*GetHostifActionsResponse, error)
+}
+
+type diagClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDiagClient(cc grpc.ClientConnInterface) DiagClient {
+	return &diagClient{cc}
+}
+
+func (c *diagClient) RemoveAll(ctx context.Context, in *RemoveAllRequest, opts ...grpc.CallOption) (*RemoveAllResponse, error) {
+	out := new(RemoveAllResponse)
+	err := c.cc.Invoke(ctx, "/lucius.dataplane.diag.Diag/RemoveAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagClient) LookupRoute(ctx context.Context, in *LookupRouteRequest, opts ...grpc.CallOption) (*LookupRouteResponse, error) {
+	out := new(LookupRouteResponse)
+	err := c.cc.Invoke(ctx, "/lucius.dataplane.diag.Diag/LookupRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagClient) GetHostifTrapStats(ctx context.Context, in *GetHostifTrapStatsRequest, opts ...grpc.CallOption) (*GetHostifTrapStatsResponse, error) {
+	out := new(GetHostifTrapStatsResponse)
+	err := c.cc.Invoke(ctx, "/lucius.dataplane.diag.Diag/GetHostifTrapStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagClient) GetHostifTrapGroupStats(ctx context.Context, in *GetHostifTrapGroupStatsRequest, opts ...grpc.CallOption) (*GetHostifTrapGroupStatsResponse, error) {
+	out := new(GetHostifTrapGroupStatsResponse)
+	err := c.cc.Invoke(ctx, "/lucius.dataplane.diag.Diag/GetHostifTrapGroupStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagClient) GetCPUPuntStats(ctx context.Context, in *GetCPUPuntStatsRequest, opts ...grpc.CallOption) (*GetCPUPuntStatsResponse, error) {
+	out := new(GetCPUPuntStatsResponse)
+	err := c.cc.Invoke(ctx, "/lucius.dataplane.diag.Diag/GetCPUPuntStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagClient) GetHostifStats(ctx context.Context, in *GetHostifStatsRequest, opts ...grpc.CallOption) (*GetHostifStatsResponse, error) {
+	out := new(GetHostifStatsResponse)
+	err := c.cc.Invoke(ctx, "/lucius.dataplane.diag.Diag/GetHostifStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagClient) ExcludeHostifTrapSource(ctx context.Context, in *ExcludeHostifTrapSourceRequest, opts ...grpc.CallOption) (*ExcludeHostifTrapSourceResponse, error) {
+	out := new(ExcludeHostifTrapSourceResponse)
+	err := c.cc.Invoke(ctx, "/lucius.dataplane.diag.Diag/ExcludeHostifTrapSource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagClient) LookupHostifTableEntry(ctx context.Context, in *LookupHostifTableEntryRequest, opts ...grpc.CallOption) (*LookupHostifTableEntryResponse, error) {
+	out := new(LookupHostifTableEntryResponse)
+	err := c.cc.Invoke(ctx, "/lucius.dataplane.diag.Diag/LookupHostifTableEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagClient) GetGenetlinkHostifIds(ctx context.Context, in *GetGenetlinkHostifIdsRequest, opts ...grpc.CallOption) (*GetGenetlinkHostifIdsResponse, error) {
+	out := new(GetGenetlinkHostifIdsResponse)
+	err := c.cc.Invoke(ctx, "/lucius.dataplane.diag.Diag/GetGenetlinkHostifIds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagClient) ListHostifStreams(ctx context.Context, in *ListHostifStreamsRequest, opts ...grpc.CallOption) (*ListHostifStreamsResponse, error) {
+	out := new(ListHostifStreamsResponse)
+	err := c.cc.Invoke(ctx, "/lucius.dataplane.diag.Diag/ListHostifStreams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagClient) GetHostifActions(ctx context.Context, in *GetHostifActionsRequest, opts ...grpc.CallOption) (*GetHostifActionsResponse, error) {
+	out := new(GetHostifActionsResponse)
+	err := c.cc.Invoke(ctx, "/lucius.dataplane.diag.Diag/GetHostifActions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DiagServer is the server API for Diag service.
+type DiagServer interface {
+	RemoveAll(context.Context, *RemoveAllRequest) (*RemoveAllResponse, error)
+	LookupRoute(context.Context, *LookupRouteRequest) (*LookupRouteResponse, error)
+	GetHostifTrapStats(context.Context, *GetHostifTrapStatsRequest) (*GetHostifTrapStatsResponse, error)
+	GetHostifTrapGroupStats(context.Context, *GetHostifTrapGroupStatsRequest) (*GetHostifTrapGroupStatsResponse, error)
+	GetCPUPuntStats(context.Context, *GetCPUPuntStatsRequest) (*GetCPUPuntStatsResponse, error)
+	GetHostifStats(context.Context, *GetHostifStatsRequest) (*GetHostifStatsResponse, error)
+	ExcludeHostifTrapSource(context.Context, *ExcludeHostifTrapSourceRequest) (*ExcludeHostifTrapSourceResponse, error)
+	LookupHostifTableEntry(context.Context, *LookupHostifTableEntryRequest) (*LookupHostifTableEntryResponse, error)
+	GetGenetlinkHostifIds(context.Context, *GetGenetlinkHostifIdsRequest) (*GetGenetlinkHostifIdsResponse, error)
+	ListHostifStreams(context.Context, *ListHostifStreamsRequest) (*ListHostifStreamsResponse, error)
+	GetHostifActions(context.Context, *GetHostifActionsRequest) (*GetHostifActionsResponse, error)
+}
+
+// UnimplementedDiagServer can be embedded to have forward compatible implementations.
+type UnimplementedDiagServer struct {
+}
+
+func (*UnimplementedDiagServer) RemoveAll(context.Context, *RemoveAllRequest) (*RemoveAllResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAll not implemented")
+}
+func (*UnimplementedDiagServer) LookupRoute(context.Context, *LookupRouteRequest) (*LookupRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupRoute not implemented")
+}
+func (*UnimplementedDiagServer) GetHostifTrapStats(context.Context, *GetHostifTrapStatsRequest) (*GetHostifTrapStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostifTrapStats not implemented")
+}
+func (*UnimplementedDiagServer) GetHostifTrapGroupStats(context.Context, *GetHostifTrapGroupStatsRequest) (*GetHostifTrapGroupStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostifTrapGroupStats not implemented")
+}
+func (*UnimplementedDiagServer) GetCPUPuntStats(context.Context, *GetCPUPuntStatsRequest) (*GetCPUPuntStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCPUPuntStats not implemented")
+}
+func (*UnimplementedDiagServer) GetHostifStats(context.Context, *GetHostifStatsRequest) (*GetHostifStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostifStats not implemented")
+}
+func (*UnimplementedDiagServer) ExcludeHostifTrapSource(context.Context, *ExcludeHostifTrapSourceRequest) (*ExcludeHostifTrapSourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExcludeHostifTrapSource not implemented")
+}
+func (*UnimplementedDiagServer) LookupHostifTableEntry(context.Context, *LookupHostifTableEntryRequest) (*LookupHostifTableEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupHostifTableEntry not implemented")
+}
+func (*UnimplementedDiagServer) GetGenetlinkHostifIds(context.Context, *GetGenetlinkHostifIdsRequest) (*GetGenetlinkHostifIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGenetlinkHostifIds not implemented")
+}
+func (*UnimplementedDiagServer) ListHostifStreams(context.Context, *ListHostifStreamsRequest) (*ListHostifStreamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHostifStreams not implemented")
+}
+func (*UnimplementedDiagServer) GetHostifActions(context.Context, *GetHostifActionsRequest) (*GetHostifActionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostifActions not implemented")
+}
+
+func RegisterDiagServer(s *grpc.Server, srv DiagServer) {
+	s.RegisterService(&_Diag_serviceDesc, srv)
+}
+
+func _Diag_RemoveAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagServer).RemoveAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lucius.dataplane.diag.Diag/RemoveAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagServer).RemoveAll(ctx, req.(*RemoveAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Diag_LookupRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagServer).LookupRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lucius.dataplane.diag.Diag/LookupRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagServer).LookupRoute(ctx, req.(*LookupRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Diag_GetHostifTrapStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHostifTrapStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagServer).GetHostifTrapStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lucius.dataplane.diag.Diag/GetHostifTrapStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagServer).GetHostifTrapStats(ctx, req.(*GetHostifTrapStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Diag_GetHostifTrapGroupStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHostifTrapGroupStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagServer).GetHostifTrapGroupStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lucius.dataplane.diag.Diag/GetHostifTrapGroupStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagServer).GetHostifTrapGroupStats(ctx, req.(*GetHostifTrapGroupStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Diag_GetCPUPuntStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCPUPuntStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagServer).GetCPUPuntStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lucius.dataplane.diag.Diag/GetCPUPuntStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagServer).GetCPUPuntStats(ctx, req.(*GetCPUPuntStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Diag_GetHostifStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHostifStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagServer).GetHostifStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lucius.dataplane.diag.Diag/GetHostifStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagServer).GetHostifStats(ctx, req.(*GetHostifStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Diag_ExcludeHostifTrapSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExcludeHostifTrapSourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagServer).ExcludeHostifTrapSource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lucius.dataplane.diag.Diag/ExcludeHostifTrapSource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagServer).ExcludeHostifTrapSource(ctx, req.(*ExcludeHostifTrapSourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Diag_LookupHostifTableEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupHostifTableEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagServer).LookupHostifTableEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lucius.dataplane.diag.Diag/LookupHostifTableEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagServer).LookupHostifTableEntry(ctx, req.(*LookupHostifTableEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Diag_GetGenetlinkHostifIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGenetlinkHostifIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagServer).GetGenetlinkHostifIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lucius.dataplane.diag.Diag/GetGenetlinkHostifIds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagServer).GetGenetlinkHostifIds(ctx, req.(*GetGenetlinkHostifIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Diag_ListHostifStreams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHostifStreamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagServer).ListHostifStreams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lucius.dataplane.diag.Diag/ListHostifStreams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagServer).ListHostifStreams(ctx, req.(*ListHostifStreamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Diag_GetHostifActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHostifActionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagServer).GetHostifActions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lucius.dataplane.diag.Diag/GetHostifActions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagServer).GetHostifActions(ctx, req.(*GetHostifActionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Diag_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "lucius.dataplane.diag.Diag",
+	HandlerType: (*DiagServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RemoveAll",
+			Handler:    _Diag_RemoveAll_Handler,
+		},
+		{
+			MethodName: "LookupRoute",
+			Handler:    _Diag_LookupRoute_Handler,
+		},
+		{
+			MethodName: "GetHostifTrapStats",
+			Handler:    _Diag_GetHostifTrapStats_Handler,
+		},
+		{
+			MethodName: "GetHostifTrapGroupStats",
+			Handler:    _Diag_GetHostifTrapGroupStats_Handler,
+		},
+		{
+			MethodName: "GetCPUPuntStats",
+			Handler:    _Diag_GetCPUPuntStats_Handler,
+		},
+		{
+			MethodName: "GetHostifStats",
+			Handler:    _Diag_GetHostifStats_Handler,
+		},
+		{
+			MethodName: "ExcludeHostifTrapSource",
+			Handler:    _Diag_ExcludeHostifTrapSource_Handler,
+		},
+		{
+			MethodName: "LookupHostifTableEntry",
+			Handler:    _Diag_LookupHostifTableEntry_Handler,
+		},
+		{
+			MethodName: "GetGenetlinkHostifIds",
+			Handler:    _Diag_GetGenetlinkHostifIds_Handler,
+		},
+		{
+			MethodName: "ListHostifStreams",
+			Handler:    _Diag_ListHostifStreams_Handler,
+		},
+		{
+			MethodName: "GetHostifActions",
+			Handler:    _Diag_GetHostifActions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dataplane/proto/diag/diag.proto",
+}
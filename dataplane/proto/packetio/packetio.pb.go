@@ -72,7 +72,8 @@ type HostPortControlRequest struct {
 	//
 	//	*HostPortControlRequest_Init
 	//	*HostPortControlRequest_Status
-	Msg isHostPortControlRequest_Msg `protobuf_oneof:"msg"`
+	Msg          isHostPortControlRequest_Msg `protobuf_oneof:"msg"`
+	GenetlinkIds *GenetlinkPortIds            `protobuf:"bytes,3,opt,name=genetlink_ids,json=genetlinkIds,proto3" json:"genetlink_ids,omitempty"`
 }
 
 func (x *HostPortControlRequest) Reset() {
@@ -128,6 +129,13 @@ func (x *HostPortControlRequest) GetStatus() *status.Status {
 	return nil
 }
 
+func (x *HostPortControlRequest) GetGenetlinkIds() *GenetlinkPortIds {
+	if x != nil {
+		return x.GenetlinkIds
+	}
+	return nil
+}
+
 type isHostPortControlRequest_Msg interface {
 	isHostPortControlRequest_Msg()
 }
@@ -246,6 +254,61 @@ func (x *GenetlinkPort) GetGroup() string {
 	return ""
 }
 
+type GenetlinkPortIds struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FamilyId uint32 `protobuf:"varint,1,opt,name=family_id,json=familyId,proto3" json:"family_id,omitempty"`
+	GroupId  uint32 `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+}
+
+func (x *GenetlinkPortIds) Reset() {
+	*x = GenetlinkPortIds{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenetlinkPortIds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenetlinkPortIds) ProtoMessage() {}
+
+func (x *GenetlinkPortIds) ProtoReflect() protoreflect.Message {
+	mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenetlinkPortIds.ProtoReflect.Descriptor instead.
+func (*GenetlinkPortIds) Descriptor() ([]byte, []int) {
+	return file_dataplane_proto_packetio_packetio_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GenetlinkPortIds) GetFamilyId() uint32 {
+	if x != nil {
+		return x.FamilyId
+	}
+	return 0
+}
+
+func (x *GenetlinkPortIds) GetGroupId() uint32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
 type HostPortControlMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -264,7 +327,7 @@ type HostPortControlMessage struct {
 func (x *HostPortControlMessage) Reset() {
 	*x = HostPortControlMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[4]
+		mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -277,7 +340,7 @@ func (x *HostPortControlMessage) String() string {
 func (*HostPortControlMessage) ProtoMessage() {}
 
 func (x *HostPortControlMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[4]
+	mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -290,7 +353,7 @@ func (x *HostPortControlMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HostPortControlMessage.ProtoReflect.Descriptor instead.
 func (*HostPortControlMessage) Descriptor() ([]byte, []int) {
-	return file_dataplane_proto_packetio_packetio_proto_rawDescGZIP(), []int{4}
+	return file_dataplane_proto_packetio_packetio_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *HostPortControlMessage) GetPortId() uint64 {
@@ -365,7 +428,7 @@ type Packet struct {
 func (x *Packet) Reset() {
 	*x = Packet{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[5]
+		mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -378,7 +441,7 @@ func (x *Packet) String() string {
 func (*Packet) ProtoMessage() {}
 
 func (x *Packet) ProtoReflect() protoreflect.Message {
-	mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[5]
+	mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -391,7 +454,7 @@ func (x *Packet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Packet.ProtoReflect.Descriptor instead.
 func (*Packet) Descriptor() ([]byte, []int) {
-	return file_dataplane_proto_packetio_packetio_proto_rawDescGZIP(), []int{5}
+	return file_dataplane_proto_packetio_packetio_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Packet) GetHostPort() uint64 {
@@ -431,7 +494,7 @@ type PacketStreamInit struct {
 func (x *PacketStreamInit) Reset() {
 	*x = PacketStreamInit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[6]
+		mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -444,7 +507,7 @@ func (x *PacketStreamInit) String() string {
 func (*PacketStreamInit) ProtoMessage() {}
 
 func (x *PacketStreamInit) ProtoReflect() protoreflect.Message {
-	mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[6]
+	mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,7 +520,7 @@ func (x *PacketStreamInit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PacketStreamInit.ProtoReflect.Descriptor instead.
 func (*PacketStreamInit) Descriptor() ([]byte, []int) {
-	return file_dataplane_proto_packetio_packetio_proto_rawDescGZIP(), []int{6}
+	return file_dataplane_proto_packetio_packetio_proto_rawDescGZIP(), []int{7}
 }
 
 type PacketIn struct {
@@ -475,7 +538,7 @@ type PacketIn struct {
 func (x *PacketIn) Reset() {
 	*x = PacketIn{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[7]
+		mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -488,7 +551,7 @@ func (x *PacketIn) String() string {
 func (*PacketIn) ProtoMessage() {}
 
 func (x *PacketIn) ProtoReflect() protoreflect.Message {
-	mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[7]
+	mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -501,7 +564,7 @@ func (x *PacketIn) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PacketIn.ProtoReflect.Descriptor instead.
 func (*PacketIn) Descriptor() ([]byte, []int) {
-	return file_dataplane_proto_packetio_packetio_proto_rawDescGZIP(), []int{7}
+	return file_dataplane_proto_packetio_packetio_proto_rawDescGZIP(), []int{8}
 }
 
 func (m *PacketIn) GetMsg() isPacketIn_Msg {
@@ -552,7 +615,7 @@ type PacketOut struct {
 func (x *PacketOut) Reset() {
 	*x = PacketOut{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[8]
+		mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -565,7 +628,7 @@ func (x *PacketOut) String() string {
 func (*PacketOut) ProtoMessage() {}
 
 func (x *PacketOut) ProtoReflect() protoreflect.Message {
-	mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[8]
+	mi := &file_dataplane_proto_packetio_packetio_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -578,7 +641,7 @@ func (x *PacketOut) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PacketOut.ProtoReflect.Descriptor instead.
 func (*PacketOut) Descriptor() ([]byte, []int) {
-	return file_dataplane_proto_packetio_packetio_proto_rawDescGZIP(), []int{8}
+	return file_dataplane_proto_packetio_packetio_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *PacketOut) GetPacket() *Packet {
@@ -598,7 +661,7 @@ var file_dataplane_proto_packetio_packetio_proto_rawDesc = []byte{
 	0x65, 0x74, 0x69, 0x6f, 0x1a, 0x17, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x72, 0x70, 0x63,
 	0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x15, 0x0a,
 	0x13, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
-	0x49, 0x6e, 0x69, 0x74, 0x22, 0x93, 0x01, 0x0a, 0x16, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x72,
+	0x49, 0x6e, 0x69, 0x74, 0x22, 0xe5, 0x01, 0x0a, 0x16, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x72,
 	0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
 	0x44, 0x0a, 0x04, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e,
 	0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65,
@@ -607,71 +670,81 @@ var file_dataplane_proto_packetio_packetio_proto_rawDesc = []byte{
 	0x04, 0x69, 0x6e, 0x69, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x72,
 	0x70, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x42, 0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x20, 0x0a, 0x0a, 0x4e, 0x65,
-	0x74, 0x64, 0x65, 0x76, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3d, 0x0a, 0x0d,
-	0x47, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a,
-	0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66,
-	0x61, 0x6d, 0x69, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x83, 0x02, 0x0a, 0x16,
-	0x48, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12,
-	0x25, 0x0a, 0x0e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x5f, 0x70, 0x6f, 0x72,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61,
-	0x6e, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x3f,
-	0x0a, 0x06, 0x6e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25,
-	0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e,
-	0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x4e, 0x65, 0x74, 0x64, 0x65,
-	0x76, 0x50, 0x6f, 0x72, 0x74, 0x48, 0x00, 0x52, 0x06, 0x6e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x12,
-	0x48, 0x0a, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61,
-	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x47,
-	0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x48, 0x00, 0x52, 0x09,
-	0x67, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x42, 0x06, 0x0a, 0x04, 0x70, 0x6f, 0x72,
-	0x74, 0x22, 0x7b, 0x0a, 0x06, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x68,
-	0x6f, 0x73, 0x74, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
-	0x68, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x70, 0x75,
-	0x74, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x69, 0x6e,
-	0x70, 0x75, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75,
-	0x74, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6f, 0x75,
-	0x74, 0x70, 0x75, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x72, 0x61, 0x6d,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x22, 0x12,
-	0x0a, 0x10, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e,
-	0x69, 0x74, 0x22, 0x91, 0x01, 0x0a, 0x08, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x49, 0x6e, 0x12,
-	0x41, 0x0a, 0x04, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e,
-	0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65,
-	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
-	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x69, 0x74, 0x48, 0x00, 0x52, 0x04, 0x69, 0x6e,
-	0x69, 0x74, 0x12, 0x3b, 0x0a, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61,
-	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x50,
-	0x61, 0x63, 0x6b, 0x65, 0x74, 0x48, 0x00, 0x52, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x42,
-	0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x46, 0x0a, 0x09, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
-	0x4f, 0x75, 0x74, 0x12, 0x39, 0x0a, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74,
-	0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e,
-	0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x32, 0xed,
-	0x01, 0x0a, 0x08, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x49, 0x4f, 0x12, 0x7d, 0x0a, 0x0f, 0x48,
-	0x6f, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x31,
-	0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e,
-	0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x50,
-	0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x31, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70,
-	0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x48, 0x6f,
+	0x74, 0x75, 0x73, 0x12, 0x50, 0x0a, 0x0d, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6c, 0x75, 0x63,
+	0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b,
+	0x50, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x73, 0x52, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69,
+	0x6e, 0x6b, 0x49, 0x64, 0x73, 0x42, 0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x20, 0x0a, 0x0a,
+	0x4e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3d,
+	0x0a, 0x0d, 0x47, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x4a, 0x0a,
+	0x10, 0x47, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x64,
+	0x73, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x49, 0x64, 0x12, 0x19,
+	0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x22, 0x83, 0x02, 0x0a, 0x16, 0x48, 0x6f,
 	0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x62, 0x0a, 0x0f, 0x43, 0x50,
-	0x55, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x23, 0x2e,
-	0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65,
-	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
-	0x49, 0x6e, 0x1a, 0x24, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61,
-	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x50,
-	0x61, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x42, 0x38,
-	0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65,
-	0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x6c, 0x65, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x2f,
-	0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
-	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x25, 0x0a,
+	0x0e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x50, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x3f, 0x0a, 0x06,
+	0x6e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6c,
+	0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x4e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x50,
+	0x6f, 0x72, 0x74, 0x48, 0x00, 0x52, 0x06, 0x6e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x12, 0x48, 0x0a,
+	0x09, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c,
+	0x61, 0x6e, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x47, 0x65, 0x6e,
+	0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x48, 0x00, 0x52, 0x09, 0x67, 0x65,
+	0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x42, 0x06, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x22,
+	0x7b, 0x0a, 0x06, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x6f, 0x73,
+	0x74, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x68, 0x6f,
+	0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x22, 0x12, 0x0a, 0x10,
+	0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x69, 0x74,
+	0x22, 0x91, 0x01, 0x0a, 0x08, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x49, 0x6e, 0x12, 0x41, 0x0a,
+	0x04, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6c, 0x75,
+	0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x69, 0x74, 0x48, 0x00, 0x52, 0x04, 0x69, 0x6e, 0x69, 0x74,
+	0x12, 0x3b, 0x0a, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c,
+	0x61, 0x6e, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x50, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x48, 0x00, 0x52, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x42, 0x05, 0x0a,
+	0x03, 0x6d, 0x73, 0x67, 0x22, 0x46, 0x0a, 0x09, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x75,
+	0x74, 0x12, 0x39, 0x0a, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70,
+	0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x50, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x52, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x32, 0xed, 0x01, 0x0a,
+	0x08, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x49, 0x4f, 0x12, 0x7d, 0x0a, 0x0f, 0x48, 0x6f, 0x73,
+	0x74, 0x50, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x31, 0x2e, 0x6c,
+	0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x72,
+	0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x31, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61,
+	0x6e, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x48, 0x6f, 0x73, 0x74,
+	0x50, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x62, 0x0a, 0x0f, 0x43, 0x50, 0x55, 0x50,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x23, 0x2e, 0x6c, 0x75,
+	0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x49, 0x6e,
+	0x1a, 0x24, 0x2e, 0x6c, 0x75, 0x63, 0x69, 0x75, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c,
+	0x61, 0x6e, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x2e, 0x50, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x42, 0x38, 0x5a, 0x36,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x6c, 0x65, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x2f, 0x64, 0x61,
+	0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x69, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -686,36 +759,38 @@ func file_dataplane_proto_packetio_packetio_proto_rawDescGZIP() []byte {
 	return file_dataplane_proto_packetio_packetio_proto_rawDescData
 }
 
-var file_dataplane_proto_packetio_packetio_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_dataplane_proto_packetio_packetio_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_dataplane_proto_packetio_packetio_proto_goTypes = []interface{}{
 	(*HostPortControlInit)(nil),    // 0: lucius.dataplane.packetio.HostPortControlInit
 	(*HostPortControlRequest)(nil), // 1: lucius.dataplane.packetio.HostPortControlRequest
 	(*NetdevPort)(nil),             // 2: lucius.dataplane.packetio.NetdevPort
 	(*GenetlinkPort)(nil),          // 3: lucius.dataplane.packetio.GenetlinkPort
-	(*HostPortControlMessage)(nil), // 4: lucius.dataplane.packetio.HostPortControlMessage
-	(*Packet)(nil),                 // 5: lucius.dataplane.packetio.Packet
-	(*PacketStreamInit)(nil),       // 6: lucius.dataplane.packetio.PacketStreamInit
-	(*PacketIn)(nil),               // 7: lucius.dataplane.packetio.PacketIn
-	(*PacketOut)(nil),              // 8: lucius.dataplane.packetio.PacketOut
-	(*status.Status)(nil),          // 9: google.rpc.Status
+	(*GenetlinkPortIds)(nil),       // 4: lucius.dataplane.packetio.GenetlinkPortIds
+	(*HostPortControlMessage)(nil), // 5: lucius.dataplane.packetio.HostPortControlMessage
+	(*Packet)(nil),                 // 6: lucius.dataplane.packetio.Packet
+	(*PacketStreamInit)(nil),       // 7: lucius.dataplane.packetio.PacketStreamInit
+	(*PacketIn)(nil),               // 8: lucius.dataplane.packetio.PacketIn
+	(*PacketOut)(nil),              // 9: lucius.dataplane.packetio.PacketOut
+	(*status.Status)(nil),          // 10: google.rpc.Status
 }
 var file_dataplane_proto_packetio_packetio_proto_depIdxs = []int32{
-	0, // 0: lucius.dataplane.packetio.HostPortControlRequest.init:type_name -> lucius.dataplane.packetio.HostPortControlInit
-	9, // 1: lucius.dataplane.packetio.HostPortControlRequest.status:type_name -> google.rpc.Status
-	2, // 2: lucius.dataplane.packetio.HostPortControlMessage.netdev:type_name -> lucius.dataplane.packetio.NetdevPort
-	3, // 3: lucius.dataplane.packetio.HostPortControlMessage.genetlink:type_name -> lucius.dataplane.packetio.GenetlinkPort
-	6, // 4: lucius.dataplane.packetio.PacketIn.init:type_name -> lucius.dataplane.packetio.PacketStreamInit
-	5, // 5: lucius.dataplane.packetio.PacketIn.packet:type_name -> lucius.dataplane.packetio.Packet
-	5, // 6: lucius.dataplane.packetio.PacketOut.packet:type_name -> lucius.dataplane.packetio.Packet
-	1, // 7: lucius.dataplane.packetio.PacketIO.HostPortControl:input_type -> lucius.dataplane.packetio.HostPortControlRequest
-	7, // 8: lucius.dataplane.packetio.PacketIO.CPUPacketStream:input_type -> lucius.dataplane.packetio.PacketIn
-	4, // 9: lucius.dataplane.packetio.PacketIO.HostPortControl:output_type -> lucius.dataplane.packetio.HostPortControlMessage
-	8, // 10: lucius.dataplane.packetio.PacketIO.CPUPacketStream:output_type -> lucius.dataplane.packetio.PacketOut
-	9, // [9:11] is the sub-list for method output_type
-	7, // [7:9] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	0,  // 0: lucius.dataplane.packetio.HostPortControlRequest.init:type_name -> lucius.dataplane.packetio.HostPortControlInit
+	10, // 1: lucius.dataplane.packetio.HostPortControlRequest.status:type_name -> google.rpc.Status
+	4,  // 2: lucius.dataplane.packetio.HostPortControlRequest.genetlink_ids:type_name -> lucius.dataplane.packetio.GenetlinkPortIds
+	2,  // 3: lucius.dataplane.packetio.HostPortControlMessage.netdev:type_name -> lucius.dataplane.packetio.NetdevPort
+	3,  // 4: lucius.dataplane.packetio.HostPortControlMessage.genetlink:type_name -> lucius.dataplane.packetio.GenetlinkPort
+	7,  // 5: lucius.dataplane.packetio.PacketIn.init:type_name -> lucius.dataplane.packetio.PacketStreamInit
+	6,  // 6: lucius.dataplane.packetio.PacketIn.packet:type_name -> lucius.dataplane.packetio.Packet
+	6,  // 7: lucius.dataplane.packetio.PacketOut.packet:type_name -> lucius.dataplane.packetio.Packet
+	1,  // 8: lucius.dataplane.packetio.PacketIO.HostPortControl:input_type -> lucius.dataplane.packetio.HostPortControlRequest
+	8,  // 9: lucius.dataplane.packetio.PacketIO.CPUPacketStream:input_type -> lucius.dataplane.packetio.PacketIn
+	5,  // 10: lucius.dataplane.packetio.PacketIO.HostPortControl:output_type -> lucius.dataplane.packetio.HostPortControlMessage
+	9,  // 11: lucius.dataplane.packetio.PacketIO.CPUPacketStream:output_type -> lucius.dataplane.packetio.PacketOut
+	10, // [10:12] is the sub-list for method output_type
+	8,  // [8:10] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_dataplane_proto_packetio_packetio_proto_init() }
@@ -773,7 +848,7 @@ func file_dataplane_proto_packetio_packetio_proto_init() {
 			}
 		}
 		file_dataplane_proto_packetio_packetio_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HostPortControlMessage); i {
+			switch v := v.(*GenetlinkPortIds); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -785,7 +860,7 @@ func file_dataplane_proto_packetio_packetio_proto_init() {
 			}
 		}
 		file_dataplane_proto_packetio_packetio_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Packet); i {
+			switch v := v.(*HostPortControlMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -797,7 +872,7 @@ func file_dataplane_proto_packetio_packetio_proto_init() {
 			}
 		}
 		file_dataplane_proto_packetio_packetio_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PacketStreamInit); i {
+			switch v := v.(*Packet); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -809,7 +884,7 @@ func file_dataplane_proto_packetio_packetio_proto_init() {
 			}
 		}
 		file_dataplane_proto_packetio_packetio_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PacketIn); i {
+			switch v := v.(*PacketStreamInit); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -821,6 +896,18 @@ func file_dataplane_proto_packetio_packetio_proto_init() {
 			}
 		}
 		file_dataplane_proto_packetio_packetio_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PacketIn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataplane_proto_packetio_packetio_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PacketOut); i {
 			case 0:
 				return &v.state
@@ -837,11 +924,11 @@ func file_dataplane_proto_packetio_packetio_proto_init() {
 		(*HostPortControlRequest_Init)(nil),
 		(*HostPortControlRequest_Status)(nil),
 	}
-	file_dataplane_proto_packetio_packetio_proto_msgTypes[4].OneofWrappers = []interface{}{
+	file_dataplane_proto_packetio_packetio_proto_msgTypes[5].OneofWrappers = []interface{}{
 		(*HostPortControlMessage_Netdev)(nil),
 		(*HostPortControlMessage_Genetlink)(nil),
 	}
-	file_dataplane_proto_packetio_packetio_proto_msgTypes[7].OneofWrappers = []interface{}{
+	file_dataplane_proto_packetio_packetio_proto_msgTypes[8].OneofWrappers = []interface{}{
 		(*PacketIn_Init)(nil),
 		(*PacketIn_Packet)(nil),
 	}
@@ -851,7 +938,7 @@ func file_dataplane_proto_packetio_packetio_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_dataplane_proto_packetio_packetio_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
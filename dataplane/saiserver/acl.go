@@ -23,6 +23,7 @@ import (
 	"sync"
 
 	"github.com/openconfig/lemming/dataplane/forwarding/fwdconfig"
+	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdpacket"
 	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
 
 	"google.golang.org/grpc"
@@ -49,6 +50,7 @@ type acl struct {
 	groupNextFreeBankMu sync.Mutex
 	// groupNextFreeBank contains the next free bank for a group.
 	groupNextFreeBank map[uint64]int
+	cpuPort           func() (uint64, error) // cpuPort returns the ID of the CPU port.
 }
 
 func newACL(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server) *acl {
@@ -64,7 +66,7 @@ func newACL(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server)
 
 // CreateAclTableGroup creates a lucius flow table, where the group members are banks in the flow table.
 func (a *acl) CreateAclTableGroup(ctx context.Context, req *saipb.CreateAclTableGroupRequest) (*saipb.CreateAclTableGroupResponse, error) {
-	id := a.mgr.NextID()
+	id := a.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_ACL_TABLE_GROUP)
 
 	stage := req.GetAclStage()
 	typ := req.GetType()
@@ -102,7 +104,7 @@ func (a *acl) CreateAclTableGroup(ctx context.Context, req *saipb.CreateAclTable
 func (a *acl) CreateAclTableGroupMember(_ context.Context, req *saipb.CreateAclTableGroupMemberRequest) (*saipb.CreateAclTableGroupMemberResponse, error) {
 	groupID := req.GetAclTableGroupId()
 	tableID := req.GetAclTableId()
-	memberID := a.mgr.NextID()
+	memberID := a.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_ACL_TABLE_GROUP_MEMBER)
 
 	a.groupNextFreeBankMu.Lock()
 	bank := a.groupNextFreeBank[groupID]
@@ -119,13 +121,13 @@ func (a *acl) CreateAclTableGroupMember(_ context.Context, req *saipb.CreateAclT
 
 // CreateAclTable is noop as the table is already created in the group.
 func (a *acl) CreateAclTable(context.Context, *saipb.CreateAclTableRequest) (*saipb.CreateAclTableResponse, error) {
-	id := a.mgr.NextID()
+	id := a.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_ACL_TABLE)
 	return &saipb.CreateAclTableResponse{Oid: id}, nil
 }
 
 // CreateAclEntry adds an entry in the a bank.
 func (a *acl) CreateAclEntry(ctx context.Context, req *saipb.CreateAclEntryRequest) (*saipb.CreateAclEntryResponse, error) {
-	id := a.mgr.NextID()
+	id := a.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_ACL_ENTRY)
 	gb, ok := a.tableToLocation[req.GetTableId()]
 	if !ok {
 		return nil, status.Errorf(codes.FailedPrecondition, "table is not member of a group")
@@ -167,6 +169,20 @@ func (a *acl) CreateAclEntry(ctx context.Context, req *saipb.CreateAclEntryReque
 			Masks:   binary.BigEndian.AppendUint64(nil, math.MaxUint64),
 		})
 	}
+	if req.GetFieldInPorts() != nil {
+		q, err := a.portSetQualifier(ctx, id, "in-ports", fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT, req.GetFieldInPorts().GetDataList().GetList())
+		if err != nil {
+			return nil, err
+		}
+		aReq.EntryDesc.GetFlow().Qualifiers = append(aReq.EntryDesc.GetFlow().Qualifiers, q)
+	}
+	if req.GetFieldOutPorts() != nil {
+		q, err := a.portSetQualifier(ctx, id, "out-ports", fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_OUTPUT, req.GetFieldOutPorts().GetDataList().GetList())
+		if err != nil {
+			return nil, err
+		}
+		aReq.EntryDesc.GetFlow().Qualifiers = append(aReq.EntryDesc.GetFlow().Qualifiers, q)
+	}
 	if req.GetFieldAclIpType() != nil { // Use the EtherType header to match against specific protocols.
 		fieldMask := &fwdpb.PacketFieldMaskedBytes{
 			FieldId: &fwdpb.PacketFieldId{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_TYPE}},
@@ -257,9 +273,12 @@ func (a *acl) CreateAclEntry(ctx context.Context, req *saipb.CreateAclEntryReque
 			Masks:   []byte{byte(req.GetFieldTtl().GetMaskUint())},
 		})
 	}
-	if len(aReq.EntryDesc.GetFlow().Fields) == 0 {
+	if len(aReq.EntryDesc.GetFlow().Fields) == 0 && len(aReq.EntryDesc.GetFlow().Qualifiers) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "either no fields or not unsupports fields in entry req")
 	}
+	if err := validateFlowFields(aReq.EntryDesc.GetFlow().Fields); err != nil {
+		return nil, err
+	}
 	if req.ActionSetVrf != nil {
 		aReq.Actions = append(aReq.Actions,
 			fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_VRF).
@@ -270,14 +289,28 @@ func (a *acl) CreateAclEntry(ctx context.Context, req *saipb.CreateAclEntryReque
 			fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_TRAP_ID).
 				WithUint64Value(req.GetActionSetUserTrapId().GetOid())).Build())
 	}
+	// Packets are punted to the CPU port, where the trap ID set by the entry selects the hostif they are delivered to.
+	var punt *fwdpb.ActionDesc
 	if req.ActionPacketAction != nil {
-		switch req.GetActionPacketAction().GetPacketAction() {
+		switch action := req.GetActionPacketAction().GetPacketAction(); action {
 		case saipb.PacketAction_PACKET_ACTION_DROP,
-			saipb.PacketAction_PACKET_ACTION_TRAP, // COPY and DROP
 			saipb.PacketAction_PACKET_ACTION_DENY: // COPY_CANCEL and DROP
 			aReq.Actions = append(aReq.Actions, &fwdpb.ActionDesc{ActionType: fwdpb.ActionType_ACTION_TYPE_DROP})
+		case saipb.PacketAction_PACKET_ACTION_TRAP, // COPY and DROP
+			saipb.PacketAction_PACKET_ACTION_LOG: // COPY and FORWARD
+			cpuPortID, err := a.cpuPort()
+			if err != nil {
+				return nil, err
+			}
+			transmit := fwdconfig.Action(fwdconfig.TransmitAction(fmt.Sprint(cpuPortID)).WithImmediate(true))
+			if action == saipb.PacketAction_PACKET_ACTION_TRAP {
+				// The immediate transmit interrupts the pending actions, so it is added last.
+				punt = transmit.Build()
+				break
+			}
+			aReq.Actions = append(aReq.Actions, fwdconfig.Action(fwdconfig.MirrorAction(transmit)).Build(),
+				&fwdpb.ActionDesc{ActionType: fwdpb.ActionType_ACTION_TYPE_CONTINUE})
 		case saipb.PacketAction_PACKET_ACTION_FORWARD,
-			saipb.PacketAction_PACKET_ACTION_LOG,     // COPY and FORWARD
 			saipb.PacketAction_PACKET_ACTION_TRANSIT: // COPY_CANCEL and FORWARD
 			aReq.Actions = append(aReq.Actions, &fwdpb.ActionDesc{ActionType: fwdpb.ActionType_ACTION_TYPE_CONTINUE}) // Packets are forwarded by default so continue.
 		default:
@@ -296,6 +329,9 @@ func (a *acl) CreateAclEntry(ctx context.Context, req *saipb.CreateAclEntryReque
 			},
 		})
 	}
+	if punt != nil {
+		aReq.Actions = append(aReq.Actions, punt)
+	}
 
 	if _, err := a.dataplane.TableEntryAdd(ctx, aReq); err != nil {
 		return nil, err
@@ -304,8 +340,63 @@ func (a *acl) CreateAclEntry(ctx context.Context, req *saipb.CreateAclEntryReque
 	return &saipb.CreateAclEntryResponse{Oid: id}, nil
 }
 
+// validateFlowFields returns an InvalidArgument error if a field matched by a flow entry is wider than the field in the flow table key.
+// The dataplane pads each field to its maximum size to build the key, so a wider field makes a key that never matches any packet.
+func validateFlowFields(fields []*fwdpb.PacketFieldMaskedBytes) error {
+	for _, field := range fields {
+		id := fwdpacket.NewFieldID(field.GetFieldId())
+		name := id.Num.String()
+		if id.IsUDF {
+			name = fmt.Sprintf("%v offset %d", id.Header, id.Offset)
+		}
+		width := fwdpacket.MaxSize(id)
+		if width == 0 {
+			return status.Errorf(codes.InvalidArgument, "field %s can't be matched by the flow table", name)
+		}
+		if size := max(len(field.GetBytes()), len(field.GetMasks())); size > width {
+			return status.Errorf(codes.InvalidArgument, "match of %d bytes on field %s exceeds its %d byte flow table key width", size, name, width)
+		}
+	}
+	return nil
+}
+
+// portSetQualifier creates a set of the ports' NIDs and returns a qualifier that matches the field against the set.
+// This matches a list of ports with a single flow entry, instead of an entry per port.
+func (a *acl) portSetQualifier(ctx context.Context, entryID uint64, name string, field fwdpb.PacketFieldNum, ports []uint64) (*fwdpb.PacketFieldSet, error) {
+	fwdCtx, err := a.dataplane.FindContext(&fwdpb.ContextId{Id: a.dataplane.ID()})
+	if err != nil {
+		return nil, err
+	}
+	var nids [][]byte
+	for _, port := range ports {
+		obj, err := fwdCtx.Objects.FindID(&fwdpb.ObjectId{Id: fmt.Sprint(port)})
+		if err != nil {
+			return nil, err
+		}
+		nids = append(nids, binary.BigEndian.AppendUint64(nil, uint64(obj.NID())))
+	}
+	setID := &fwdpb.SetId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprintf("acl-entry-%d-%s", entryID, name)}}
+	if _, err := a.dataplane.SetCreate(ctx, &fwdpb.SetCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: a.dataplane.ID()},
+		SetId:     setID,
+	}); err != nil {
+		return nil, err
+	}
+	if _, err := a.dataplane.SetUpdate(ctx, &fwdpb.SetUpdateRequest{
+		ContextId: &fwdpb.ContextId{Id: a.dataplane.ID()},
+		SetId:     setID,
+		Bytes:     nids,
+	}); err != nil {
+		return nil, err
+	}
+	return &fwdpb.PacketFieldSet{
+		FieldId: &fwdpb.PacketFieldId{Field: &fwdpb.PacketField{FieldNum: field}},
+		SetId:   setID,
+	}, nil
+}
+
 func (a *acl) CreateAclCounter(ctx context.Context, req *saipb.CreateAclCounterRequest) (*saipb.CreateAclCounterResponse, error) {
-	id := a.mgr.NextID()
+	id := a.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_ACL_COUNTER)
 
 	_, err := a.dataplane.FlowCounterCreate(ctx, &fwdpb.FlowCounterCreateRequest{
 		ContextId: &fwdpb.ContextId{Id: a.dataplane.ID()},
@@ -404,6 +495,7 @@ type myMac struct {
 	saipb.UnimplementedMyMacServer
 	mgr       *attrmgr.AttrMgr
 	dataplane switchDataplaneAPI
+	switchID  func() uint64 // switchID returns the ID of the switch.
 }
 
 func newMyMac(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server) *myMac {
@@ -419,7 +511,7 @@ func (m *myMac) CreateMyMac(ctx context.Context, req *saipb.CreateMyMacRequest)
 	if req.MacAddress == nil || req.MacAddressMask == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "MAC address and MAC address mask cannot be empty")
 	}
-	id := m.mgr.NextID()
+	id := m.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_MY_MAC)
 	ed, err := entryDescFromReq(m, req)
 	if err != nil {
 		return nil, status.Errorf(codes.FailedPrecondition, "failed to create entry descriptor: %v", err)
@@ -435,7 +527,7 @@ func (m *myMac) CreateMyMac(ctx context.Context, req *saipb.CreateMyMacRequest)
 	}
 	// Populate the switch attribute.
 	saReq := &saipb.GetSwitchAttributeRequest{
-		Oid: switchID,
+		Oid: m.switchID(),
 		AttrType: []saipb.SwitchAttr{
 			saipb.SwitchAttr_SWITCH_ATTR_MY_MAC_LIST,
 		},
@@ -445,7 +537,7 @@ func (m *myMac) CreateMyMac(ctx context.Context, req *saipb.CreateMyMacRequest)
 		return nil, fmt.Errorf("Failed to populate switch attributes: %v", err)
 	}
 	mml := append(saResp.GetAttr().MyMacList, id)
-	m.mgr.StoreAttributes(switchID, &saipb.SwitchAttribute{
+	m.mgr.StoreAttributes(m.switchID(), &saipb.SwitchAttribute{
 		MyMacList: mml,
 	})
 	return &saipb.CreateMyMacResponse{Oid: id}, nil
@@ -472,7 +564,7 @@ func (m *myMac) RemoveMyMac(ctx context.Context, req *saipb.RemoveMyMacRequest)
 
 	// Populate the switch attribute.
 	saReq := &saipb.GetSwitchAttributeRequest{
-		Oid: switchID,
+		Oid: m.switchID(),
 		AttrType: []saipb.SwitchAttr{
 			saipb.SwitchAttr_SWITCH_ATTR_MY_MAC_LIST,
 		},
@@ -490,7 +582,7 @@ func (m *myMac) RemoveMyMac(ctx context.Context, req *saipb.RemoveMyMacRequest)
 		return -1
 	}
 	if loc := locate(req.GetOid()); loc != -1 {
-		m.mgr.StoreAttributes(switchID, &saipb.SwitchAttribute{
+		m.mgr.StoreAttributes(m.switchID(), &saipb.SwitchAttribute{
 			MyMacList: append(saResp.GetAttr().MyMacList[:loc], saResp.GetAttr().MyMacList[loc+1:]...),
 		})
 	} else {
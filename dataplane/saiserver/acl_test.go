@@ -21,6 +21,7 @@ import (
 	"math"
 	"net/netip"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/gnmi/errdiff"
@@ -28,10 +29,12 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 
+	"github.com/openconfig/lemming/dataplane/dplaneopts"
 	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdcontext"
 	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdobject"
 	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
 	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
+	"github.com/openconfig/lemming/internal/packetutil"
 	fwdpb "github.com/openconfig/lemming/proto/forwarding"
 )
 
@@ -51,6 +54,20 @@ func TestCreateAclEntry(t *testing.T) {
 		req: &saipb.CreateAclEntryRequest{
 			TableId: proto.Uint64(1),
 		},
+	}, {
+		desc:    "field wider than key",
+		wantErr: "InvalidArgument desc = match of 8 bytes on field PACKET_FIELD_NUM_ETHER_MAC_DST exceeds its 6 byte flow table key width",
+		req: &saipb.CreateAclEntryRequest{
+			TableId: proto.Uint64(1),
+			FieldDstMac: &saipb.AclFieldData{
+				Data: &saipb.AclFieldData_DataMac{
+					DataMac: []byte{0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8},
+				},
+				Mask: &saipb.AclFieldData_MaskMac{
+					MaskMac: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+				},
+			},
+		},
 	}, {
 		desc: "all fields",
 		req: &saipb.CreateAclEntryRequest{
@@ -318,6 +335,37 @@ func TestCreateAclEntry(t *testing.T) {
 				ActionType: fwdpb.ActionType_ACTION_TYPE_DROP,
 			}},
 		},
+	}, {
+		desc: "in ports",
+		req: &saipb.CreateAclEntryRequest{
+			TableId: proto.Uint64(1),
+			FieldInPorts: &saipb.AclFieldData{
+				Data: &saipb.AclFieldData_DataList{DataList: &saipb.Uint64List{List: []uint64{1}}},
+			},
+			ActionPacketAction: &saipb.AclActionData{
+				Parameter: &saipb.AclActionData_PacketAction{
+					PacketAction: saipb.PacketAction_PACKET_ACTION_DROP,
+				},
+			},
+		},
+		want: &fwdpb.TableEntryAddRequest{
+			ContextId: &fwdpb.ContextId{Id: "foo"},
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: "1"}},
+			EntryDesc: &fwdpb.EntryDesc{
+				Entry: &fwdpb.EntryDesc_Flow{
+					Flow: &fwdpb.FlowEntryDesc{
+						Id: 1,
+						Qualifiers: []*fwdpb.PacketFieldSet{{
+							FieldId: &fwdpb.PacketFieldId{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT}},
+							SetId:   &fwdpb.SetId{ObjectId: &fwdpb.ObjectId{Id: "acl-entry-1-in-ports"}},
+						}},
+					},
+				},
+			},
+			Actions: []*fwdpb.ActionDesc{{
+				ActionType: fwdpb.ActionType_ACTION_TYPE_DROP,
+			}},
+		},
 	}, {
 		desc: "forward action",
 		req: &saipb.CreateAclEntryRequest{
@@ -426,6 +474,190 @@ func TestCreateAclEntry(t *testing.T) {
 	}
 }
 
+func TestAclEntryInPorts(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sink := packetutil.NewSink(3)
+	fwdCtx.FakePortManager = nopPortManager{}
+	fwdCtx.SetCPUPortSink(sink.CPUPortSink, func() {})
+
+	sc := saipb.NewSwitchClient(conn)
+	sw, err := sc.CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	var ports []uint64
+	for i := uint32(1); i <= 3; i++ {
+		ports = append(ports, createTestPort(t, conn, i))
+	}
+	// Trap ARP, so packets that aren't dropped by the ACL are punted.
+	if _, err := saipb.NewHostifClient(conn).CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+		TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_ARP_REQUEST.Enum(),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ac := saipb.NewAclClient(conn)
+	group, err := ac.CreateAclTableGroup(ctx, &saipb.CreateAclTableGroupRequest{
+		AclStage: saipb.AclStage_ACL_STAGE_PRE_INGRESS.Enum(),
+		Type:     saipb.AclTableGroupType_ACL_TABLE_GROUP_TYPE_PARALLEL.Enum(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := ac.CreateAclTable(ctx, &saipb.CreateAclTableRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ac.CreateAclTableGroupMember(ctx, &saipb.CreateAclTableGroupMemberRequest{
+		AclTableGroupId: proto.Uint64(group.GetOid()),
+		AclTableId:      proto.Uint64(table.GetOid()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ac.CreateAclEntry(ctx, &saipb.CreateAclEntryRequest{
+		TableId:  proto.Uint64(table.GetOid()),
+		Priority: proto.Uint32(1),
+		FieldInPorts: &saipb.AclFieldData{
+			Data: &saipb.AclFieldData_DataList{DataList: &saipb.Uint64List{List: []uint64{ports[0], ports[2]}}},
+		},
+		ActionPacketAction: &saipb.AclActionData{
+			Parameter: &saipb.AclActionData_PacketAction{PacketAction: saipb.PacketAction_PACKET_ACTION_DROP},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sc.SetSwitchAttribute(ctx, &saipb.SetSwitchAttributeRequest{
+		Oid:           sw.GetOid(),
+		PreIngressAcl: proto.Uint64(group.GetOid()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	frame := arpRequestFrame(t, 64)
+	for _, port := range ports {
+		err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+			fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, frame, nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	pkt, err := sink.Next(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pkt.Out.GetPacket().GetInputPort(); got != ports[1] {
+		t.Errorf("punted packet from port %d, want only port %d", got, ports[1])
+	}
+	checkARPRequest(t, pkt)
+	if pkt, err := sink.Next(100 * time.Millisecond); err == nil {
+		t.Errorf("punted packet from port %d, want only port %d", pkt.Out.GetPacket().GetInputPort(), ports[1])
+	}
+}
+
+func TestAclUserDefinedTrap(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sink := packetutil.NewSink(2)
+	fwdCtx.FakePortManager = nopPortManager{}
+	fwdCtx.SetCPUPortSink(sink.CPUPortSink, func() {})
+
+	sc := saipb.NewSwitchClient(conn)
+	sw, err := sc.CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	port := createTestPort(t, conn, 1)
+
+	// Packets trapped with the user-defined trap are delivered to its hostif.
+	const hostifID = 100
+	hc := saipb.NewHostifClient(conn)
+	trap, err := hc.CreateHostifUserDefinedTrap(ctx, &saipb.CreateHostifUserDefinedTrapRequest{
+		Type: saipb.HostifUserDefinedTrapType_HOSTIF_USER_DEFINED_TRAP_TYPE_ACL.Enum(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hc.CreateHostifTableEntry(ctx, &saipb.CreateHostifTableEntryRequest{
+		Type:   saipb.HostifTableEntryType_HOSTIF_TABLE_ENTRY_TYPE_TRAP_ID.Enum(),
+		TrapId: proto.Uint64(trap.GetOid()),
+		HostIf: proto.Uint64(hostifID),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ac := saipb.NewAclClient(conn)
+	group, err := ac.CreateAclTableGroup(ctx, &saipb.CreateAclTableGroupRequest{
+		AclStage: saipb.AclStage_ACL_STAGE_PRE_INGRESS.Enum(),
+		Type:     saipb.AclTableGroupType_ACL_TABLE_GROUP_TYPE_PARALLEL.Enum(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := ac.CreateAclTable(ctx, &saipb.CreateAclTableRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ac.CreateAclTableGroupMember(ctx, &saipb.CreateAclTableGroupMemberRequest{
+		AclTableGroupId: proto.Uint64(group.GetOid()),
+		AclTableId:      proto.Uint64(table.GetOid()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ac.CreateAclEntry(ctx, &saipb.CreateAclEntryRequest{
+		TableId:  proto.Uint64(table.GetOid()),
+		Priority: proto.Uint32(1),
+		FieldEtherType: &saipb.AclFieldData{
+			Data: &saipb.AclFieldData_DataUint{DataUint: 0x88b5},
+			Mask: &saipb.AclFieldData_MaskUint{MaskUint: 0xffff},
+		},
+		ActionSetUserTrapId: &saipb.AclActionData{
+			Parameter: &saipb.AclActionData_Oid{Oid: trap.GetOid()},
+		},
+		ActionPacketAction: &saipb.AclActionData{
+			Parameter: &saipb.AclActionData_PacketAction{PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sc.SetSwitchAttribute(ctx, &saipb.SetSwitchAttributeRequest{
+		Oid:           sw.GetOid(),
+		PreIngressAcl: proto.Uint64(group.GetOid()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	inject := func(etherType uint16) {
+		t.Helper()
+		frame := make([]byte, 64)
+		copy(frame, []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x02, 0x02, 0x00, 0x00, 0x00, 0x00, 0x01})
+		binary.BigEndian.PutUint16(frame[12:], etherType)
+		err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+			fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, frame, nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	inject(0x88b5)
+	pkt, err := sink.Next(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pkt.Out.GetPacket().GetHostPort(); got != hostifID {
+		t.Errorf("trapped packet delivered to hostif %d, want %d", got, hostifID)
+	}
+
+	// Packets not matched by the ACL entry are not trapped.
+	inject(0x88b6)
+	if pkt, err := sink.Next(100 * time.Millisecond); err == nil {
+		t.Errorf("packet not matched by the ACL entry unexpectedly punted to hostif %d", pkt.Out.GetPacket().GetHostPort())
+	}
+}
+
 func TestCreateAclCounter(t *testing.T) {
 	tests := []struct {
 		desc    string
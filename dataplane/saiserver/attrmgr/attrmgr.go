@@ -21,9 +21,11 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"unicode"
 
 	log "github.com/golang/glog"
 	"google.golang.org/grpc"
@@ -43,11 +45,17 @@ type AttrMgr struct {
 	// attrs is a map of object id (string) to a map of attributes (key: attr id, some enum value).
 	attrs   map[string]map[int32]*protoreflect.Value
 	nextOid atomic.Uint64
+	// typedIDs enables allocating ids per object type, typedOids is the last allocated id for each type.
+	typedIDs  bool
+	typedOids map[saipb.ObjectType]uint64
 	// idToType maps an object id to its SAI type.
 	idToType map[string]saipb.ObjectType
 	// msgEnumToFieldNum maps a proto message name to a map of an attribute enum to its corresponding proto field.
 	// For example, for SwitchAttribute SWITCH_ATTR_MAX_SYSTEM_CORES (enum val 182) -> field max_system_cores (num 172)
 	msgEnumToFieldNum map[string]map[int32]int
+	// serialize enables serializing mutating requests, opMu is held while one is handled.
+	serialize bool
+	opMu      sync.Mutex
 }
 
 func deleteOID(mgr *AttrMgr, oid string) error {
@@ -57,15 +65,46 @@ func deleteOID(mgr *AttrMgr, oid string) error {
 		return fmt.Errorf("OID not found: %s", oid)
 	}
 	delete(mgr.attrs, oid)
+	delete(mgr.idToType, oid)
 	return nil
 }
 
+// Option configures an AttrMgr.
+type Option func(*AttrMgr)
+
+// WithTypedIDs allocates object ids from a separate counter for each object type, instead of a single shared counter.
+// The object type is stored in the upper bits of the id, so the id of an object only depends on the
+// order objects of the same type are created. This is useful for tests that assert exact ids.
+func WithTypedIDs() Option {
+	return func(mgr *AttrMgr) {
+		mgr.typedIDs = true
+	}
+}
+
+// WithSerializedMutations handles the Create, Remove and Set requests intercepted by the AttrMgr one at a time,
+// trading throughput for determinism. This helps isolate bugs caused by concurrent SAI operations racing in the dataplane.
+func WithSerializedMutations() Option {
+	return func(mgr *AttrMgr) {
+		mgr.serialize = true
+	}
+}
+
+// isMutation returns whether the gRPC method creates, removes or sets objects.
+func isMutation(fullMethod string) bool {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	return strings.HasPrefix(method, "Create") || strings.HasPrefix(method, "Remove") || strings.HasPrefix(method, "Set")
+}
+
 // New returns a new AttrMgr.
-func New() *AttrMgr {
+func New(opts ...Option) *AttrMgr {
 	mgr := &AttrMgr{
 		attrs:             make(map[string]map[int32]*protoreflect.Value),
 		idToType:          make(map[string]saipb.ObjectType),
 		msgEnumToFieldNum: make(map[string]map[int32]int),
+		typedOids:         make(map[saipb.ObjectType]uint64),
+	}
+	for _, opt := range opts {
+		opt(mgr)
 	}
 	return mgr
 }
@@ -105,7 +144,15 @@ func (mgr *AttrMgr) Interceptor(ctx context.Context, req any, info *grpc.UnarySe
 	if !strings.Contains(info.FullMethod, protoNS) {
 		return handler(ctx, req)
 	}
+	if mgr.serialize && isMutation(info.FullMethod) {
+		mgr.opMu.Lock()
+		defer mgr.opMu.Unlock()
+	}
 	resp, err := handler(ctx, req)
+	// Bulk sets store the attributes of each of their requests themselves.
+	if strings.HasSuffix(info.FullMethod, "/SetAttributes") {
+		return resp, err
+	}
 	// Ignore unimplemented error for Get*Attribute.
 	if err != nil {
 		if st, _ := status.FromError(err); st.Code() != codes.Unimplemented || !strings.Contains(info.FullMethod, "Get") {
@@ -151,6 +198,7 @@ func (mgr *AttrMgr) Reset() {
 	mgr.attrs = make(map[string]map[int32]*protoreflect.Value)
 	mgr.idToType = make(map[string]saipb.ObjectType)
 	mgr.msgEnumToFieldNum = make(map[string]map[int32]int)
+	mgr.typedOids = make(map[saipb.ObjectType]uint64)
 	mgr.nextOid.Store(0)
 }
 
@@ -264,6 +312,28 @@ func (mgr *AttrMgr) SetType(id string, t saipb.ObjectType) {
 	mgr.idToType[id] = t
 }
 
+// GetObjectsOfType returns the sorted ids of all objects of type t.
+func (mgr *AttrMgr) GetObjectsOfType(t saipb.ObjectType) []string {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	var ids []string
+	for id, ty := range mgr.idToType {
+		if ty == t {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// DeleteObject removes the object's attributes and type.
+func (mgr *AttrMgr) DeleteObject(id string) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	delete(mgr.attrs, id)
+	delete(mgr.idToType, id)
+}
+
 // storeAttributes stores all the attributes in the message.
 func (mgr *AttrMgr) storeAttributes(id string, msg proto.Message) {
 	mgr.mu.Lock()
@@ -311,14 +381,30 @@ func (mgr *AttrMgr) NextID() uint64 {
 	return mgr.nextOid.Add(1)
 }
 
-// getID returns the id from either the request or response. If the id is unset, it allocates a new one.
+// typedIDShift is the bit offset of the object type in ids allocated by NextTypedID.
+const typedIDShift = 48
+
+// NextTypedID returns the next available object id for an object of type t.
+// Unless the manager was created WithTypedIDs, this is the same as NextID.
+func (mgr *AttrMgr) NextTypedID(t saipb.ObjectType) uint64 {
+	if !mgr.typedIDs {
+		return mgr.NextID()
+	}
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	mgr.typedOids[t]++
+	return uint64(t)<<typedIDShift | mgr.typedOids[t]
+}
+
+// getID returns the id from either the request or response. If the id is unset, it allocates a new one
+// for the type of object created by the request.
 func (mgr *AttrMgr) getID(req, resp proto.Message) (string, error) {
 	msgs := []proto.Message{req, resp}
 	for _, msg := range msgs {
 		if fd := msg.ProtoReflect().Descriptor().Fields().ByTextName("oid"); fd != nil {
 			v := msg.ProtoReflect().Get(fd).Uint()
 			if v == 0 {
-				id := mgr.NextID()
+				id := mgr.NextTypedID(createdObjectType(req))
 				msg.ProtoReflect().Set(fd, protoreflect.ValueOfUint64(id))
 				return fmt.Sprint(id), nil
 			}
@@ -336,3 +422,17 @@ func (mgr *AttrMgr) getID(req, resp proto.Message) (string, error) {
 	}
 	return string(pBytes), nil
 }
+
+// createdObjectType returns the type of the object created by req, a Create<Type>Request,
+// or OBJECT_TYPE_UNSPECIFIED if req doesn't create an object of a known type.
+func createdObjectType(req proto.Message) saipb.ObjectType {
+	name := strings.TrimSuffix(strings.TrimPrefix(string(req.ProtoReflect().Descriptor().Name()), "Create"), "Request")
+	var typ strings.Builder
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			typ.WriteByte('_')
+		}
+		typ.WriteRune(unicode.ToUpper(r))
+	}
+	return saipb.ObjectType(saipb.ObjectType_value["OBJECT_TYPE_"+typ.String()])
+}
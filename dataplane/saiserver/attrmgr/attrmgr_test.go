@@ -17,7 +17,9 @@ package attrmgr
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/gnmi/errdiff"
@@ -225,3 +227,141 @@ func TestGetType(t *testing.T) {
 		})
 	}
 }
+
+func TestSerializedMutations(t *testing.T) {
+	mgr := New(WithSerializedMutations())
+	info := &grpc.UnaryServerInfo{FullMethod: "/lemming.dataplane.sai.Port/CreatePort"}
+	// The handler increments the count without synchronization, concurrent calls lose updates unless serialized.
+	count := 0
+	handler := func(context.Context, any) (any, error) {
+		v := count
+		time.Sleep(time.Millisecond)
+		count = v + 1
+		return &saipb.CreatePortResponse{}, nil
+	}
+	const n = 20
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := mgr.Interceptor(context.Background(), &saipb.CreatePortRequest{}, info, handler); err != nil {
+				t.Errorf("Interceptor() unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if count != n {
+		t.Errorf("Interceptor() serialized %d concurrent creates, got count %d, want %d", n, count, n)
+	}
+}
+
+func TestIsMutation(t *testing.T) {
+	tests := []struct {
+		method string
+		want   bool
+	}{{
+		method: "/lemming.dataplane.sai.Port/CreatePort",
+		want:   true,
+	}, {
+		method: "/lemming.dataplane.sai.Route/CreateRouteEntries",
+		want:   true,
+	}, {
+		method: "/lemming.dataplane.sai.Port/RemovePort",
+		want:   true,
+	}, {
+		method: "/lemming.dataplane.sai.Port/SetPortAttribute",
+		want:   true,
+	}, {
+		method: "/lemming.dataplane.sai.Port/GetPortAttribute",
+	}, {
+		method: "/lemming.dataplane.sai.Port/GetPortStats",
+	}}
+	for _, tt := range tests {
+		if got := isMutation(tt.method); got != tt.want {
+			t.Errorf("isMutation(%q) got %v, want %v", tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestNextTypedID(t *testing.T) {
+	create := func(mgr *AttrMgr, types []saipb.ObjectType) map[saipb.ObjectType][]uint64 {
+		ids := map[saipb.ObjectType][]uint64{}
+		for _, ty := range types {
+			ids[ty] = append(ids[ty], mgr.NextTypedID(ty))
+		}
+		return ids
+	}
+	port, vlan := saipb.ObjectType_OBJECT_TYPE_PORT, saipb.ObjectType_OBJECT_TYPE_VLAN
+	seq := []saipb.ObjectType{port, vlan, port, vlan}
+	interleaved := []saipb.ObjectType{vlan, vlan, port, port}
+
+	tests := []struct {
+		desc     string
+		opts     []Option
+		wantSame bool
+	}{{
+		desc:     "shared counter",
+		wantSame: false,
+	}, {
+		desc:     "typed ids",
+		opts:     []Option{WithTypedIDs()},
+		wantSame: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := create(New(tt.opts...), seq)
+			if d := cmp.Diff(got, create(New(tt.opts...), seq)); d != "" {
+				t.Errorf("NextTypedID() identical sequences differ: diff(-got,+want)\n:%s", d)
+			}
+			d := cmp.Diff(got, create(New(tt.opts...), interleaved))
+			if gotSame := d == ""; gotSame != tt.wantSame {
+				t.Errorf("NextTypedID() interleaved sequence same ids got %v, want %v: diff(-got,+want)\n:%s", gotSame, tt.wantSame, d)
+			}
+		})
+	}
+
+	mgr := New(WithTypedIDs())
+	first := mgr.NextTypedID(port)
+	mgr.NextTypedID(vlan)
+	mgr.Reset()
+	if got := mgr.NextTypedID(port); got != first {
+		t.Errorf("NextTypedID() after Reset got %d, want %d", got, first)
+	}
+}
+
+func TestGetIDTyped(t *testing.T) {
+	tests := []struct {
+		desc     string
+		req      proto.Message
+		resp     proto.Message
+		wantType saipb.ObjectType
+	}{{
+		desc:     "port",
+		req:      &saipb.CreatePortRequest{},
+		resp:     &saipb.CreatePortResponse{},
+		wantType: saipb.ObjectType_OBJECT_TYPE_PORT,
+	}, {
+		desc:     "multi-word type",
+		req:      &saipb.CreateHostifTrapGroupRequest{},
+		resp:     &saipb.CreateHostifTrapGroupResponse{},
+		wantType: saipb.ObjectType_OBJECT_TYPE_HOSTIF_TRAP_GROUP,
+	}, {
+		desc:     "not a create request",
+		req:      &saipb.GetPortAttributeRequest{},
+		resp:     &saipb.CreatePortResponse{},
+		wantType: saipb.ObjectType_OBJECT_TYPE_UNSPECIFIED,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mgr := New(WithTypedIDs())
+			got, err := mgr.getID(tt.req, tt.resp)
+			if err != nil {
+				t.Fatalf("getID() unexpected err: %v", err)
+			}
+			if want := fmt.Sprint(uint64(tt.wantType)<<typedIDShift | 1); got != want {
+				t.Errorf("getID() got id %s, want %s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saiserver
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	log "github.com/golang/glog"
+
+	fwdpb "github.com/openconfig/lemming/proto/forwarding"
+)
+
+// dryRunDataplane validates SAI operations without programming them.
+// Operations that would change the underlying dataplane are logged and succeed without being applied,
+// while lookups are served by the underlying dataplane.
+type dryRunDataplane struct {
+	switchDataplaneAPI
+}
+
+// logDryRun logs an operation that is not applied.
+func logDryRun(op string, req proto.Message) {
+	log.Infof("dry run, not programming %s: %v", op, req)
+}
+
+func (d dryRunDataplane) TableCreate(_ context.Context, req *fwdpb.TableCreateRequest) (*fwdpb.TableCreateReply, error) {
+	logDryRun("TableCreate", req)
+	return &fwdpb.TableCreateReply{}, nil
+}
+
+func (d dryRunDataplane) TableEntryAdd(_ context.Context, req *fwdpb.TableEntryAddRequest) (*fwdpb.TableEntryAddReply, error) {
+	logDryRun("TableEntryAdd", req)
+	return &fwdpb.TableEntryAddReply{}, nil
+}
+
+func (d dryRunDataplane) TableEntryRemove(_ context.Context, req *fwdpb.TableEntryRemoveRequest) (*fwdpb.TableEntryRemoveReply, error) {
+	logDryRun("TableEntryRemove", req)
+	return &fwdpb.TableEntryRemoveReply{}, nil
+}
+
+func (d dryRunDataplane) PortCreate(_ context.Context, req *fwdpb.PortCreateRequest) (*fwdpb.PortCreateReply, error) {
+	logDryRun("PortCreate", req)
+	return &fwdpb.PortCreateReply{}, nil
+}
+
+func (d dryRunDataplane) PortUpdate(_ context.Context, req *fwdpb.PortUpdateRequest) (*fwdpb.PortUpdateReply, error) {
+	logDryRun("PortUpdate", req)
+	return &fwdpb.PortUpdateReply{}, nil
+}
+
+func (d dryRunDataplane) PortState(_ context.Context, req *fwdpb.PortStateRequest) (*fwdpb.PortStateReply, error) {
+	logDryRun("PortState", req)
+	return &fwdpb.PortStateReply{}, nil
+}
+
+func (d dryRunDataplane) AttributeUpdate(_ context.Context, req *fwdpb.AttributeUpdateRequest) (*fwdpb.AttributeUpdateReply, error) {
+	logDryRun("AttributeUpdate", req)
+	return &fwdpb.AttributeUpdateReply{}, nil
+}
+
+func (d dryRunDataplane) ObjectDelete(_ context.Context, req *fwdpb.ObjectDeleteRequest) (*fwdpb.ObjectDeleteReply, error) {
+	logDryRun("ObjectDelete", req)
+	return &fwdpb.ObjectDeleteReply{}, nil
+}
+
+func (d dryRunDataplane) FlowCounterCreate(_ context.Context, req *fwdpb.FlowCounterCreateRequest) (*fwdpb.FlowCounterCreateReply, error) {
+	logDryRun("FlowCounterCreate", req)
+	return &fwdpb.FlowCounterCreateReply{}, nil
+}
+
+func (d dryRunDataplane) SetCreate(_ context.Context, req *fwdpb.SetCreateRequest) (*fwdpb.SetCreateReply, error) {
+	logDryRun("SetCreate", req)
+	return &fwdpb.SetCreateReply{}, nil
+}
+
+func (d dryRunDataplane) SetUpdate(_ context.Context, req *fwdpb.SetUpdateRequest) (*fwdpb.SetUpdateReply, error) {
+	logDryRun("SetUpdate", req)
+	return &fwdpb.SetUpdateReply{}, nil
+}
@@ -15,35 +15,64 @@
 package saiserver
 
 import (
+	"cmp"
 	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"math"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/openconfig/lemming/dataplane/dplaneopts"
 	"github.com/openconfig/lemming/dataplane/forwarding/attributes"
 	"github.com/openconfig/lemming/dataplane/forwarding/fwdconfig"
+	"github.com/openconfig/lemming/dataplane/forwarding/fwdport"
 	"github.com/openconfig/lemming/dataplane/forwarding/fwdport/ports"
+	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdcontext"
 	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
 
 	log "github.com/golang/glog"
 
+	diagpb "github.com/openconfig/lemming/dataplane/proto/diag"
 	pktiopb "github.com/openconfig/lemming/dataplane/proto/packetio"
 	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
 	fwdpb "github.com/openconfig/lemming/proto/forwarding"
 )
 
 func newHostif(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server, opts *dplaneopts.Options) *hostif {
+	if opts.DryRun {
+		dataplane = dryRunDataplane{switchDataplaneAPI: dataplane}
+	}
 	hostif := &hostif{
 		mgr:              mgr,
 		dataplane:        dataplane,
 		trapIDToHostifID: map[uint64]uint64{},
 		groupIDToQueue:   map[uint64]uint32{},
+		trapEntries:      map[uint64][]*fwdpb.EntryDesc{},
+		hostifQueues:     map[uint64]uint32{},
+		subPorts:         map[uint64]subPort{},
+		localHostifs:     map[uint64]uint64{},
+		hostifInputs:     map[uint64][]*fwdpb.ActionDesc{},
 		remoteHostifs:    map[uint64]*pktiopb.HostPortControlMessage{},
+		remoteStats:      map[uint64]*hostifStats{},
+		genetlinkIDs:     map[uint64]*pktiopb.GenetlinkPortIds{},
+		pipelineHostifs:  map[uint64]bool{},
+		traps:            map[uint64]trapConfig{},
+		trapSeqs:         map[uint32]uint32{},
+		streams:          map[string]activeStream{},
+		cpuSinks:         map[uint64]cpuSink{},
 		opts:             opts,
 	}
 
@@ -58,33 +87,105 @@ type hostif struct {
 	dataplane        switchDataplaneAPI
 	trapIDToHostifID map[uint64]uint64
 	groupIDToQueue   map[uint64]uint32
+	trapEntries      map[uint64][]*fwdpb.EntryDesc  // trapEntries maps a trap ID to its entries in the trap table.
+	hostifQueues     map[uint64]uint32              // hostifQueues maps a genetlink hostif ID to its CPU queue.
+	subPorts         map[uint64]subPort             // subPorts maps a sub-interface hostif ID to its parent port and VLAN.
+	localHostifs     map[uint64]uint64              // localHostifs maps a local hostif ID to the port it is swapped with, or 0 if there is none.
+	hostifInputs     map[uint64][]*fwdpb.ActionDesc // hostifInputs maps a local hostif ID to the input actions installed on its port.
+	pipelineHostifs  map[uint64]bool                // pipelineHostifs is the set of netdev hostifs whose packets run the forwarding pipeline.
+	traps            map[uint64]trapConfig          // traps maps a trap ID to its configuration.
+	trapSeqs         map[uint32]uint32              // trapSeqs counts the traps created at each trap priority, see trapFlowPriority.
+	hasIP2MERoutes   func() bool                    // hasIP2MERoutes returns whether any route punts packets to the CPU port.
+	localPrefixes    func() []*saipb.IpPrefix       // localPrefixes returns the destinations of the routes that punt packets to the CPU port.
+	localAddrs       atomic.Pointer[[]net.IP]       // localAddrs are the local addresses, read when answering punted packets.
 	opts             *dplaneopts.Options
 	remoteMu         sync.Mutex
 	remoteHostifs    map[uint64]*pktiopb.HostPortControlMessage
-	remoteClosers    []func()
-	remotePortReq    func(msg *pktiopb.HostPortControlMessage) error
+	genetlinkIDs     map[uint64]*pktiopb.GenetlinkPortIds // genetlinkIDs maps a remote genetlink hostif ID to the family and group IDs resolved by the agent.
+	statsMu          sync.Mutex
+	remoteStats      map[uint64]*hostifStats // remoteStats counts the packets remote hostifs exchange on the CPU packet stream.
+	remoteClosers    []func()                // remoteClosers cancel the open host port control stream, there is at most one.
+	remotePortReq    func(msg *pktiopb.HostPortControlMessage) (*pktiopb.HostPortControlRequest, error)
+	remoteStreams    uint64 // remoteStreams counts the host port control streams, the last one sets remotePortReq.
+	cpuStreams       uint64 // cpuStreams counts the CPU packet streams, the count is the ID of the stream's sink.
+	streamsMu        sync.Mutex
+	streams          map[string]activeStream // streams are the open CPU packet and host port control streams, they outlive resets.
+	cpuSinksMu       sync.Mutex
+	cpuSinks         map[uint64]cpuSink // cpuSinks maps a CPU packet stream ID to its sink, punted packets are sent to all of them.
+	cpuPortID        atomic.Uint64
+	switchID         atomic.Uint64
 }
 
 func (hostif *hostif) Reset() {
-	log.Info("resetting hostif")
-	for _, closeFn := range hostif.remoteClosers {
-		closeFn()
-	}
-	hostif.remoteClosers = nil
+	log.V(hostifLogLevel).Info("resetting hostif")
+	hostif.remoteMu.Lock()
+	hostif.closeRemoteStreams()
+	hostif.remoteMu.Unlock()
 	hostif.trapIDToHostifID = map[uint64]uint64{}
 	hostif.groupIDToQueue = map[uint64]uint32{}
+	hostif.trapEntries = map[uint64][]*fwdpb.EntryDesc{}
+	hostif.hostifQueues = map[uint64]uint32{}
+	hostif.subPorts = map[uint64]subPort{}
+	hostif.localHostifs = map[uint64]uint64{}
+	hostif.hostifInputs = map[uint64][]*fwdpb.ActionDesc{}
+	hostif.pipelineHostifs = map[uint64]bool{}
+	hostif.traps = map[uint64]trapConfig{}
+	hostif.trapSeqs = map[uint32]uint32{}
 	hostif.remoteHostifs = map[uint64]*pktiopb.HostPortControlMessage{}
+	hostif.genetlinkIDs = map[uint64]*pktiopb.GenetlinkPortIds{}
+	hostif.statsMu.Lock()
+	hostif.remoteStats = map[uint64]*hostifStats{}
+	hostif.statsMu.Unlock()
 	hostif.remotePortReq = nil
+	hostif.localAddrs.Store(nil)
+	hostif.cpuPortID.Store(0)
+	hostif.switchID.Store(0)
+}
+
+// initSwitch records the switch and the CPU port created by it.
+// It must be called before any hostif or trap is created, these depend on the CPU port existing.
+func (hostif *hostif) initSwitch(switchID, cpuPortID uint64) {
+	hostif.switchID.Store(switchID)
+	hostif.cpuPortID.Store(cpuPortID)
+}
+
+// cpuPort returns the ID of the CPU port or an error if the switch has not been initialized.
+func (hostif *hostif) cpuPort() (uint64, error) {
+	id := hostif.cpuPortID.Load()
+	if id == 0 {
+		return 0, status.Error(codes.FailedPrecondition, "cpu port not initialized, switch must be created first")
+	}
+	return id, nil
 }
 
-const switchID = 1
+// cpuQueueAttrInstance is the instance of the 32-bit packet attribute that holds the CPU queue of a punted packet.
+const cpuQueueAttrInstance = 0
+
+// cpuQueueFieldID returns the ID of the packet field that holds the CPU queue of a punted packet.
+// The CPU port exports this field, so punts to a hostif with a queue carry the queue.
+func cpuQueueFieldID() *fwdpb.PacketFieldId {
+	return &fwdpb.PacketFieldId{
+		Field: &fwdpb.PacketField{
+			FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_ATTRIBUTE_32,
+			Instance: cpuQueueAttrInstance,
+		},
+	}
+}
+
+// hostifLogLevel is the verbosity of the hostif and host port control lifecycle logs.
+// These are chatty during normal operation, enable them with -vmodule=hostif=2.
+const hostifLogLevel = 2
 
 // CreateHostif creates a hostif interface (usually a tap interface).
 func (hostif *hostif) CreateHostif(ctx context.Context, req *saipb.CreateHostifRequest) (*saipb.CreateHostifResponse, error) {
 	if hostif.opts.RemoteCPUPort {
 		return hostif.createRemoteHostif(ctx, req)
 	}
-	id := hostif.mgr.NextID()
+	cpuPortID, err := hostif.cpuPort()
+	if err != nil {
+		return nil, err
+	}
+	id := hostif.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_HOSTIF)
 
 	switch req.GetType() {
 	case saipb.HostifType_HOSTIF_TYPE_GENETLINK: // For genetlink device, pass the port description to the cpu sink.
@@ -107,24 +208,32 @@ func (hostif *hostif) CreateHostif(ctx context.Context, req *saipb.CreateHostifR
 			return nil, err
 		}
 		// Notify the cpu sink about these port types.
-		fwdCtx, err := hostif.dataplane.FindContext(&fwdpb.ContextId{Id: hostif.dataplane.ID()})
-		if err != nil {
-			return nil, err
-		}
-		fwdCtx.RLock()
-		ps := fwdCtx.PacketSink()
-		fwdCtx.RUnlock()
-		ps(&fwdpb.PacketSinkResponse{
+		if err := hostif.notifyPacketSink(&fwdpb.PacketSinkResponse{
 			Resp: &fwdpb.PacketSinkResponse_Port{
 				Port: &fwdpb.PacketSinkPortInfo{
 					Port: portReq.Port,
 				},
 			},
-		})
+		}); err != nil {
+			return nil, err
+		}
+		if req.Queue != nil {
+			hostif.hostifQueues[id] = req.GetQueue()
+		}
+		hostif.localHostifs[id] = 0
 
 		return &saipb.CreateHostifResponse{Oid: id}, nil
-	case saipb.HostifType_HOSTIF_TYPE_NETDEV:
+	case saipb.HostifType_HOSTIF_TYPE_NETDEV, saipb.HostifType_HOSTIF_TYPE_FD:
+		if _, isSubPort, err := hostif.lookupSubPort(req.GetObjId()); err != nil {
+			return nil, err
+		} else if isSubPort {
+			return nil, status.Errorf(codes.Unimplemented, "sub-interface hostifs are only supported with a remote CPU port")
+		}
 		portType := hostif.opts.HostifNetDevType
+		// File descriptor hostifs are read in process, see fdHostifFrames.
+		if req.GetType() == saipb.HostifType_HOSTIF_TYPE_FD {
+			portType = fwdpb.PortType_PORT_TYPE_CHANNEL
+		}
 		port := &fwdpb.PortCreateRequest{
 			ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
 			Port: &fwdpb.PortDesc{
@@ -147,15 +256,54 @@ func (hostif *hostif) CreateHostif(ctx context.Context, req *saipb.CreateHostifR
 					DeviceName: string(req.GetName()),
 				},
 			}
+		case fwdpb.PortType_PORT_TYPE_GENETLINK:
+			// The punts of all netdev hostifs are multiplexed onto the same genetlink family and group,
+			// the hostif is identified by the egress port of the punted packet.
+			port.Port.Port = &fwdpb.PortDesc_Genetlink{
+				Genetlink: &fwdpb.GenetlinkPortDesc{
+					FamilyName: hostif.opts.HostifGenetlinkFamily,
+					GroupName:  hostif.opts.HostifGenetlinkGroup,
+				},
+			}
+		case fwdpb.PortType_PORT_TYPE_CHANNEL:
+			port.Port.Port = &fwdpb.PortDesc_Channel{
+				Channel: &fwdpb.ChannelPortDesc{},
+			}
 		default:
-			return nil, fmt.Errorf("unkown port type: %v", portType)
+			if _, ok := fwdpb.PortType_name[int32(portType)]; !ok {
+				return nil, status.Errorf(codes.InvalidArgument, "unknown netdev hostif port type: %v", portType)
+			}
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported netdev hostif port type: %v", portType)
 		}
 
 		if _, err := hostif.dataplane.PortCreate(ctx, port); err != nil {
+			return nil, err
+		}
+		// Don't leave a partially configured port behind if any of the following calls fail.
+		created := false
+		var swapPort uint64
+		defer func() {
+			if created {
+				return
+			}
+			if swapPort != 0 {
+				_, err := hostif.dataplane.AttributeUpdate(ctx, &fwdpb.AttributeUpdateRequest{
+					ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+					ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(swapPort)},
+					AttrId:    attributes.SwapActionRelatedPort,
+				})
+				if err != nil {
+					log.Warningf("failed to unlink port %d from hostif %d: %v", swapPort, id, err)
+				}
+			}
+			_, err := hostif.dataplane.ObjectDelete(ctx, &fwdpb.ObjectDeleteRequest{
+				ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+				ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(id)},
+			})
 			if err != nil {
-				return nil, err
+				log.Warningf("failed to delete port of hostif %d: %v", id, err)
 			}
-		}
+		}()
 
 		attrReq := &saipb.GetPortAttributeRequest{Oid: req.GetObjId(), AttrType: []saipb.PortAttr{saipb.PortAttr_PORT_ATTR_OPER_STATUS}}
 		p := &saipb.GetPortAttributeResponse{}
@@ -182,12 +330,7 @@ func (hostif *hostif) CreateHostif(ctx context.Context, req *saipb.CreateHostifR
 			if err != nil {
 				return nil, err
 			}
-		}
-
-		cpuPortReq := &saipb.GetSwitchAttributeRequest{Oid: switchID, AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_CPU_PORT}}
-		resp := &saipb.GetSwitchAttributeResponse{}
-		if err := hostif.mgr.PopulateAttributes(cpuPortReq, resp); err != nil {
-			return nil, err
+			swapPort = req.GetObjId()
 		}
 
 		// Packets received from hostif are sent to their corresponding port.
@@ -208,50 +351,74 @@ func (hostif *hostif) CreateHostif(ctx context.Context, req *saipb.CreateHostifR
 		}
 
 		// Unless, the corresponding port for this hostif is the CPU port, then run the normal forwarding pipeline.
-		if resp.GetAttr().GetCpuPort() == req.GetObjId() {
+		if cpuPortID == req.GetObjId() {
 			update.Update.GetKernel().Inputs = getForwardingPipeline()
 		}
+		inputs := update.Update.GetKernel().GetInputs()
+		switch portType {
+		case fwdpb.PortType_PORT_TYPE_GENETLINK:
+			update.Update.Port = &fwdpb.PortUpdateDesc_Genetlink{
+				Genetlink: &fwdpb.GenetlinkPortUpdateDesc{
+					Inputs: inputs,
+				},
+			}
+		case fwdpb.PortType_PORT_TYPE_CHANNEL:
+			update.Update.Port = &fwdpb.PortUpdateDesc_Channel{
+				Channel: &fwdpb.ChannelPortUpdateDesc{
+					Inputs: inputs,
+				},
+			}
+		}
 
 		if _, err := hostif.dataplane.PortUpdate(ctx, update); err != nil {
 			return nil, err
 		}
 
-		attr := &saipb.HostifAttribute{
-			OperStatus: proto.Bool(true),
-		}
-		hostif.mgr.StoreAttributes(id, attr)
-
-		// Notify the cpu sink about these port types, if there is one configured.
-		fwdCtx, err := hostif.dataplane.FindContext(&fwdpb.ContextId{Id: hostif.dataplane.ID()})
-		if err != nil {
-			return nil, err
-		}
-		fwdCtx.RLock()
-		ps := fwdCtx.PacketSink()
-		fwdCtx.RUnlock()
-		if ps != nil {
-			ps(&fwdpb.PacketSinkResponse{
+		// Notify the cpu sink about netdev port types, if there is one configured.
+		if portType != fwdpb.PortType_PORT_TYPE_CHANNEL {
+			desc := &fwdpb.PortDesc{
+				PortType: fwdpb.PortType_PORT_TYPE_KERNEL,
+				PortId:   &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(id)}},
+				Port: &fwdpb.PortDesc_Kernel{
+					Kernel: &fwdpb.KernelPortDesc{DeviceName: string(req.GetName())},
+				},
+			}
+			if portType == fwdpb.PortType_PORT_TYPE_GENETLINK {
+				desc = port.Port
+			}
+			if err := hostif.notifyPacketSink(&fwdpb.PacketSinkResponse{
 				Resp: &fwdpb.PacketSinkResponse_Port{
 					Port: &fwdpb.PacketSinkPortInfo{
-						Port: &fwdpb.PortDesc{
-							PortType: fwdpb.PortType_PORT_TYPE_KERNEL,
-							PortId:   &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(id)}},
-							Port: &fwdpb.PortDesc_Kernel{
-								Kernel: &fwdpb.KernelPortDesc{DeviceName: string(req.GetName())},
-							},
-						},
+						Port: desc,
 					},
 				},
-			})
+			}); err != nil {
+				return nil, err
+			}
+		}
+		created = true
+		hostif.localHostifs[id] = swapPort
+		hostif.hostifInputs[id] = inputs
+		if cpuPortID == req.GetObjId() {
+			hostif.addPipelineHostif(id)
+		}
+
+		attr := &saipb.HostifAttribute{
+			OperStatus: proto.Bool(true),
 		}
+		hostif.mgr.StoreAttributes(id, attr)
 	default:
-		return nil, status.Errorf(codes.InvalidArgument, "unknown type %v", req.GetType())
+		return nil, status.Errorf(codes.InvalidArgument, "unknown type: %v", req.GetType())
 	}
 	return &saipb.CreateHostifResponse{Oid: id}, nil
 }
 
 func (hostif *hostif) createRemoteHostif(ctx context.Context, req *saipb.CreateHostifRequest) (*saipb.CreateHostifResponse, error) {
-	id := hostif.mgr.NextID()
+	cpuPortID, err := hostif.cpuPort()
+	if err != nil {
+		return nil, err
+	}
+	id := hostif.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_HOSTIF)
 
 	ctlReq := &pktiopb.HostPortControlMessage{
 		Create:        true,
@@ -267,6 +434,11 @@ func (hostif *hostif) createRemoteHostif(ctx context.Context, req *saipb.CreateH
 				Group:  string(req.GetGenetlinkMcgrpName()),
 			},
 		}
+		if req.Queue != nil {
+			hostif.hostifQueues[id] = req.GetQueue()
+		}
+	case saipb.HostifType_HOSTIF_TYPE_FD:
+		return nil, status.Errorf(codes.Unimplemented, "file descriptor hostifs are only supported without a remote CPU port")
 	case saipb.HostifType_HOSTIF_TYPE_NETDEV:
 		ctlReq.Port = &pktiopb.HostPortControlMessage_Netdev{
 			Netdev: &pktiopb.NetdevPort{
@@ -274,109 +446,338 @@ func (hostif *hostif) createRemoteHostif(ctx context.Context, req *saipb.CreateH
 			},
 		}
 
-		// For packets coming from a netdev hostif, send them out its corresponding port.
-		cpuPortReq := &saipb.GetSwitchAttributeRequest{Oid: switchID, AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_CPU_PORT}}
-		resp := &saipb.GetSwitchAttributeResponse{}
-		if err := hostif.mgr.PopulateAttributes(cpuPortReq, resp); err != nil {
+		sp, isSubPort, err := hostif.lookupSubPort(req.GetObjId())
+		if err != nil {
 			return nil, err
 		}
+		if isSubPort {
+			if err := hostif.addSubPortEntries(ctx, id, sp); err != nil {
+				return nil, err
+			}
+			hostif.subPorts[id] = sp
+			break
+		}
+
+		// For packets coming from a netdev hostif, send them out its corresponding port.
 		entry := fwdconfig.TableEntryAddRequest(hostif.dataplane.ID(), hostifToPortTable).
 			AppendEntry(fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_HOST_PORT_ID).WithUint64(id))),
 				fwdconfig.Action(fwdconfig.TransmitAction(fmt.Sprint(req.GetObjId())))).Build()
 
-		if req.GetObjId() == resp.GetAttr().GetCpuPort() {
+		if req.GetObjId() == cpuPortID {
 			entry.Entries[0].Actions = getForwardingPipeline()
 		}
 
 		if _, err := hostif.dataplane.TableEntryAdd(ctx, entry); err != nil {
 			return nil, err
 		}
-
-		nid, err := hostif.dataplane.ObjectNID(ctx, &fwdpb.ObjectNIDRequest{
-			ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
-			ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(req.GetObjId())},
-		})
-		if err != nil {
-			return nil, err
+		if req.GetObjId() == cpuPortID {
+			hostif.addPipelineHostif(id)
 		}
 
-		entry = fwdconfig.TableEntryAddRequest(hostif.dataplane.ID(), portToHostifTable).
-			AppendEntry(fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT).WithUint64(nid.GetNid()))),
-				fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_HOST_PORT_ID).WithUint64Value(id))).Build()
-
-		if _, err := hostif.dataplane.TableEntryAdd(ctx, entry); err != nil {
+		if err := hostif.addPortToHostifEntry(ctx, id, req.GetObjId(), req.GetVlanTag()); err != nil {
 			return nil, err
 		}
 	default:
-		return nil, status.Errorf(codes.InvalidArgument, "unknown type %v", req.GetType())
+		return nil, status.Errorf(codes.InvalidArgument, "unknown type: %v", req.GetType())
 	}
 
 	hostif.remoteMu.Lock()
 	defer hostif.remoteMu.Unlock()
 
-	if hostif.remotePortReq == nil {
-		return nil, status.Error(codes.FailedPrecondition, "remote port control not configured")
-	}
-	if err := hostif.remotePortReq(ctlReq); err != nil {
+	resp, err := hostif.sendRemotePortReq(ctx, ctlReq)
+	if err != nil {
 		return nil, err
 	}
+	hostif.recordGenetlinkIDs(id, ctlReq, resp)
 
 	attr := &saipb.HostifAttribute{
 		OperStatus: proto.Bool(true),
 	}
 	hostif.mgr.StoreAttributes(id, attr)
 	hostif.remoteHostifs[id] = ctlReq
+	hostif.statsMu.Lock()
+	hostif.remoteStats[id] = &hostifStats{}
+	hostif.statsMu.Unlock()
 
 	return &saipb.CreateHostifResponse{Oid: id}, nil
 }
 
 func (hostif *hostif) RemoveHostif(ctx context.Context, req *saipb.RemoveHostifRequest) (*saipb.RemoveHostifResponse, error) {
 	if !hostif.opts.RemoteCPUPort {
-		return nil, status.Error(codes.FailedPrecondition, "only remote cpu port is supported")
+		return hostif.removeLocalHostif(ctx, req)
 	}
 	hostif.remoteMu.Lock()
 	defer hostif.remoteMu.Unlock()
 
+	// Genetlink hostifs have no table entries, their packets are delivered by trap ID.
+	if hostif.remoteHostifs[req.GetOid()].GetGenetlink() == nil {
+		if err := hostif.removeNetdevEntries(ctx, req.GetOid()); err != nil {
+			return nil, err
+		}
+	}
+
+	ctlReq := &pktiopb.HostPortControlMessage{
+		Create: false,
+		PortId: req.Oid,
+	}
+
+	if _, err := hostif.sendRemotePortReq(ctx, ctlReq); err != nil {
+		return nil, err
+	}
+	delete(hostif.remoteHostifs, req.Oid)
+	hostif.statsMu.Lock()
+	delete(hostif.remoteStats, req.Oid)
+	hostif.statsMu.Unlock()
+	delete(hostif.genetlinkIDs, req.Oid)
+	delete(hostif.hostifQueues, req.Oid)
+	delete(hostif.subPorts, req.Oid)
+	delete(hostif.pipelineHostifs, req.Oid)
+
+	return &saipb.RemoveHostifResponse{}, nil
+}
+
+// removeNetdevEntries removes the table entries mapping a remote netdev hostif to its port, in both directions.
+// remoteMu must be held.
+func (hostif *hostif) removeNetdevEntries(ctx context.Context, id uint64) error {
+	portID := hostif.remoteHostifs[id].GetDataplanePort()
+	sp, isSubPort := hostif.subPorts[id]
+	if isSubPort {
+		portID = sp.parent
+	}
 	nid, err := hostif.dataplane.ObjectNID(ctx, &fwdpb.ObjectNIDRequest{
 		ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
-		ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(hostif.remoteHostifs[req.GetOid()].GetDataplanePort())},
+		ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(portID)},
 	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	delReq := fwdconfig.TableEntryRemoveRequest(hostif.dataplane.ID(), hostifToPortTable).AppendEntry(
-		fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_HOST_PORT_ID).WithUint64(req.GetOid()))),
+		fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_HOST_PORT_ID).WithUint64(id))),
 	).Build()
 	if _, err := hostif.dataplane.TableEntryRemove(ctx, delReq); err != nil {
-		return nil, err
+		return err
 	}
 
 	delReq = fwdconfig.TableEntryRemoveRequest(hostif.dataplane.ID(), portToHostifTable).AppendEntry(
 		fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT).WithUint64(nid.GetNid()))),
 	).Build()
-	if _, err := hostif.dataplane.TableEntryRemove(ctx, delReq); err != nil {
+	if isSubPort {
+		delReq = fwdconfig.TableEntryRemoveRequest(hostif.dataplane.ID(), portVlanToHostifTable).AppendEntry(
+			fwdconfig.EntryDesc(fwdconfig.ExactEntry(
+				fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT).WithUint64(nid.GetNid()),
+				fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_VLAN_TAG).WithUint16(sp.vlan),
+			)),
+		).Build()
+	}
+	_, err = hostif.dataplane.TableEntryRemove(ctx, delReq)
+	return err
+}
+
+// removeLocalHostif deletes the dataplane port of a hostif created without a remote CPU port,
+// and unlinks the port it was swapped with.
+func (hostif *hostif) removeLocalHostif(ctx context.Context, req *saipb.RemoveHostifRequest) (*saipb.RemoveHostifResponse, error) {
+	swapPort, ok := hostif.localHostifs[req.GetOid()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown hostif: %d", req.GetOid())
+	}
+	if swapPort != 0 {
+		_, err := hostif.dataplane.AttributeUpdate(ctx, &fwdpb.AttributeUpdateRequest{
+			ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+			ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(swapPort)},
+			AttrId:    attributes.SwapActionRelatedPort,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	_, err := hostif.dataplane.ObjectDelete(ctx, &fwdpb.ObjectDeleteRequest{
+		ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+		ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(req.GetOid())},
+	})
+	if err != nil {
 		return nil, err
 	}
+	delete(hostif.localHostifs, req.GetOid())
+	delete(hostif.hostifInputs, req.GetOid())
+	delete(hostif.hostifQueues, req.GetOid())
+	delete(hostif.pipelineHostifs, req.GetOid())
+
+	// The cpu sink was notified about the port when the hostif was created, notify it about its removal too.
+	if err := hostif.notifyPacketSink(&fwdpb.PacketSinkResponse{
+		Resp: &fwdpb.PacketSinkResponse_PortRemoval{
+			PortRemoval: &fwdpb.PacketSinkPortRemoval{
+				PortId: &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(req.GetOid())}},
+			},
+		},
+	}); err != nil {
+		return nil, err
+	}
+	return &saipb.RemoveHostifResponse{}, nil
+}
 
-	ctlReq := &pktiopb.HostPortControlMessage{
-		Create: false,
-		PortId: req.Oid,
+// fdHostifFrames returns the channel that the frames sent to a file descriptor hostif are written to.
+func (hostif *hostif) fdHostifFrames(id uint64) (<-chan []byte, error) {
+	fwdCtx, err := hostif.dataplane.FindContext(&fwdpb.ContextId{Id: hostif.dataplane.ID()})
+	if err != nil {
+		return nil, err
+	}
+	fwdCtx.RLock()
+	defer fwdCtx.RUnlock()
+	port, err := fwdport.Find(&fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(id)}}, fwdCtx)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "unknown hostif %d: %v", id, err)
+	}
+	frames, err := ports.Frames(port)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "hostif %d is not a file descriptor hostif: %v", id, err)
 	}
+	return frames, nil
+}
 
-	if hostif.remotePortReq == nil {
-		return nil, status.Error(codes.FailedPrecondition, "remote port control not configured")
+// hostifActions returns the input actions installed on the port of a local hostif.
+func (hostif *hostif) hostifActions(id uint64) (*diagpb.GetHostifActionsResponse, error) {
+	inputs, ok := hostif.hostifInputs[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown local hostif %d", id)
 	}
-	if err := hostif.remotePortReq(ctlReq); err != nil {
-		return nil, err
+	return &diagpb.GetHostifActionsResponse{Inputs: inputs}, nil
+}
+
+// notifyPacketSink sends the port notification to the cpu sink, if there is one configured.
+// Nothing is sent in dry run mode, since the port was not created.
+func (hostif *hostif) notifyPacketSink(resp *fwdpb.PacketSinkResponse) error {
+	if hostif.opts.DryRun {
+		log.Infof("dry run, not notifying the packet sink: %v", resp)
+		return nil
 	}
-	delete(hostif.remoteHostifs, req.Oid)
+	fwdCtx, err := hostif.dataplane.FindContext(&fwdpb.ContextId{Id: hostif.dataplane.ID()})
+	if err != nil {
+		return err
+	}
+	fwdCtx.RLock()
+	ps := fwdCtx.PacketSink()
+	fwdCtx.RUnlock()
+	if ps != nil {
+		ps(resp)
+	}
+	return nil
+}
 
-	return &saipb.RemoveHostifResponse{}, nil
+// subPort is the parent port and VLAN of a sub-interface hostif.
+type subPort struct {
+	parent uint64
+	vlan   uint16
+}
+
+// lookupSubPort returns the parent port and VLAN of a sub-port router interface.
+// isSubPort is false if the object is not a sub-port router interface.
+func (hostif *hostif) lookupSubPort(objID uint64) (sp subPort, isSubPort bool, err error) {
+	if hostif.mgr.GetType(fmt.Sprint(objID)) != saipb.ObjectType_OBJECT_TYPE_ROUTER_INTERFACE {
+		return subPort{}, false, nil
+	}
+	attrReq := &saipb.GetRouterInterfaceAttributeRequest{Oid: objID, AttrType: []saipb.RouterInterfaceAttr{saipb.RouterInterfaceAttr_ROUTER_INTERFACE_ATTR_TYPE}}
+	resp := &saipb.GetRouterInterfaceAttributeResponse{}
+	if err := hostif.mgr.PopulateAttributes(attrReq, resp); err != nil {
+		return subPort{}, false, err
+	}
+	if resp.GetAttr().GetType() != saipb.RouterInterfaceType_ROUTER_INTERFACE_TYPE_SUB_PORT {
+		return subPort{}, false, nil
+	}
+	attrReq.AttrType = []saipb.RouterInterfaceAttr{saipb.RouterInterfaceAttr_ROUTER_INTERFACE_ATTR_PORT_ID, saipb.RouterInterfaceAttr_ROUTER_INTERFACE_ATTR_OUTER_VLAN_ID}
+	if err := hostif.mgr.PopulateAttributes(attrReq, resp); err != nil {
+		return subPort{}, false, err
+	}
+	return subPort{parent: resp.GetAttr().GetPortId(), vlan: uint16(resp.GetAttr().GetOuterVlanId())}, true, nil
+}
+
+// addSubPortEntries adds the CPU port entries of a VLAN sub-interface hostif.
+// Frames from the hostif are tagged before being sent out the parent port,
+// and frames punted from the parent port with the VLAN are untagged and sent to the hostif.
+func (hostif *hostif) addSubPortEntries(ctx context.Context, id uint64, sp subPort) error {
+	entry := fwdconfig.TableEntryAddRequest(hostif.dataplane.ID(), hostifToPortTable).
+		AppendEntry(fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_HOST_PORT_ID).WithUint64(id))),
+			fwdconfig.Action(fwdconfig.EncapAction(fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET_VLAN)),
+			fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_VLAN_TAG).WithValue(binary.BigEndian.AppendUint16(nil, sp.vlan))),
+			fwdconfig.Action(fwdconfig.TransmitAction(fmt.Sprint(sp.parent)))).Build()
+	if _, err := hostif.dataplane.TableEntryAdd(ctx, entry); err != nil {
+		return err
+	}
+
+	nid, err := hostif.dataplane.ObjectNID(ctx, &fwdpb.ObjectNIDRequest{
+		ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+		ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(sp.parent)},
+	})
+	if err != nil {
+		return err
+	}
+	entry = fwdconfig.TableEntryAddRequest(hostif.dataplane.ID(), portVlanToHostifTable).
+		AppendEntry(fwdconfig.EntryDesc(fwdconfig.ExactEntry(
+			fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT).WithUint64(nid.GetNid()),
+			fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_VLAN_TAG).WithUint16(sp.vlan),
+		)),
+			fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_HOST_PORT_ID).WithUint64Value(id)),
+			fwdconfig.Action(fwdconfig.DecapAction(fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET_VLAN))).Build()
+	_, err = hostif.dataplane.TableEntryAdd(ctx, entry)
+	return err
+}
+
+// setVlanTag updates the VLAN tag mode of a remote netdev hostif.
+func (hostif *hostif) setVlanTag(ctx context.Context, id uint64, vlanTag saipb.HostifVlanTag) error {
+	if !hostif.opts.RemoteCPUPort {
+		return status.Errorf(codes.Unimplemented, "VLAN tag mode is only supported with a remote CPU port")
+	}
+	hostif.remoteMu.Lock()
+	defer hostif.remoteMu.Unlock()
+	ctlReq, ok := hostif.remoteHostifs[id]
+	if !ok {
+		return status.Errorf(codes.NotFound, "unknown hostif: %d", id)
+	}
+	if ctlReq.GetNetdev() == nil {
+		return status.Errorf(codes.InvalidArgument, "VLAN tag mode is only supported for netdev hostifs")
+	}
+	if _, isSubPort := hostif.subPorts[id]; isSubPort {
+		return nil
+	}
+	return hostif.addPortToHostifEntry(ctx, id, ctlReq.GetDataplanePort(), vlanTag)
+}
+
+// addPortToHostifEntry punts the packets received by the port to the netdev hostif, adding or replacing its entry.
+// The hostif's VLAN tag mode decides if the VLAN tag of punted frames is stripped, the default is to punt frames as received.
+// Sub-interface hostifs always strip the VLAN tag, since it is implied by the sub-interface.
+// TODO: Support HOSTIF_VLAN_TAG_KEEP for untagged frames, this requires modeling the port's VLAN.
+func (hostif *hostif) addPortToHostifEntry(ctx context.Context, id, portID uint64, vlanTag saipb.HostifVlanTag) error {
+	nid, err := hostif.dataplane.ObjectNID(ctx, &fwdpb.ObjectNIDRequest{
+		ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+		ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(portID)},
+	})
+	if err != nil {
+		return err
+	}
+	actions := []*fwdconfig.ActionBuilder{
+		fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_HOST_PORT_ID).WithUint64Value(id)),
+	}
+	switch vlanTag {
+	case saipb.HostifVlanTag_HOSTIF_VLAN_TAG_STRIP:
+		// Untagged frames are left unchanged.
+		actions = append(actions, fwdconfig.Action(fwdconfig.DecapAction(fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET_VLAN)))
+	case saipb.HostifVlanTag_HOSTIF_VLAN_TAG_KEEP:
+		log.Warningf("hostif %d: VLAN tag mode %v is treated as %v, untagged frames are not tagged", id, vlanTag, saipb.HostifVlanTag_HOSTIF_VLAN_TAG_ORIGINAL)
+	}
+	entry := fwdconfig.TableEntryAddRequest(hostif.dataplane.ID(), portToHostifTable).
+		AppendEntry(fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT).WithUint64(nid.GetNid()))),
+			actions...).Build()
+	_, err = hostif.dataplane.TableEntryAdd(ctx, entry)
+	return err
 }
 
 // SetHostifAttribute sets the attributes in the request.
 func (hostif *hostif) SetHostifAttribute(ctx context.Context, req *saipb.SetHostifAttributeRequest) (*saipb.SetHostifAttributeResponse, error) {
+	if req.VlanTag != nil {
+		if err := hostif.setVlanTag(ctx, req.GetOid(), req.GetVlanTag()); err != nil {
+			return nil, err
+		}
+	}
 	if req.OperStatus != nil {
 		if hostif.opts.RemoteCPUPort {
 			return nil, nil
@@ -399,12 +800,84 @@ func (hostif *hostif) SetHostifAttribute(ctx context.Context, req *saipb.SetHost
 	return nil, nil
 }
 
+// GetHostifAttribute returns the hostif attributes.
+// The name, type and port of remote hostifs are recovered from their host port control message.
+func (hostif *hostif) GetHostifAttribute(_ context.Context, req *saipb.GetHostifAttributeRequest) (*saipb.GetHostifAttributeResponse, error) {
+	hostif.remoteMu.Lock()
+	msg, ok := hostif.remoteHostifs[req.GetOid()]
+	hostif.remoteMu.Unlock()
+	if ok {
+		hostif.mgr.StoreAttributes(req.GetOid(), remoteHostifAttributes(msg))
+	}
+	attr := &saipb.HostifAttribute{}
+	if err := hostif.mgr.PopulateAllAttributes(fmt.Sprint(req.GetOid()), attr); err != nil {
+		return nil, err
+	}
+	if attr.OperStatus == nil {
+		return nil, status.Errorf(codes.NotFound, "unknown hostif: %d", req.GetOid())
+	}
+	return &saipb.GetHostifAttributeResponse{Attr: attr}, nil
+}
+
+// remoteHostifAttributes returns the attributes of a remote hostif described by its host port control message.
+func remoteHostifAttributes(msg *pktiopb.HostPortControlMessage) *saipb.HostifAttribute {
+	attr := &saipb.HostifAttribute{
+		ObjId: proto.Uint64(msg.GetDataplanePort()),
+	}
+	switch p := msg.GetPort().(type) {
+	case *pktiopb.HostPortControlMessage_Netdev:
+		attr.Type = saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum()
+		attr.Name = []byte(p.Netdev.GetName())
+	case *pktiopb.HostPortControlMessage_Genetlink:
+		attr.Type = saipb.HostifType_HOSTIF_TYPE_GENETLINK.Enum()
+		attr.Name = []byte(p.Genetlink.GetFamily())
+		attr.GenetlinkMcgrpName = []byte(p.Genetlink.GetGroup())
+	}
+	return attr
+}
+
 // SetHostifTrapGroupAttribute sets the trap group attribute.
-func (*hostif) SetHostifTrapGroupAttribute(context.Context, *saipb.SetHostifTrapGroupAttributeRequest) (*saipb.SetHostifTrapGroupAttributeResponse, error) {
-	// TODO: provide implementation.
+// The queue is only applied to traps created after it is set, while the policer applies to all the traps of the group.
+func (hostif *hostif) SetHostifTrapGroupAttribute(ctx context.Context, req *saipb.SetHostifTrapGroupAttributeRequest) (*saipb.SetHostifTrapGroupAttributeResponse, error) {
+	if _, ok := hostif.groupIDToQueue[req.GetOid()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown trap group: %d", req.GetOid())
+	}
+	if req.Policer != nil {
+		attr := &saipb.HostifTrapGroupAttribute{}
+		if err := hostif.mgr.PopulateAllAttributes(fmt.Sprint(req.GetOid()), attr); err != nil {
+			return nil, err
+		}
+		if err := hostif.setTrapGroupPolicer(ctx, req.GetOid(), attr.GetPolicer(), req.GetPolicer()); err != nil {
+			return nil, err
+		}
+	}
+	if req.Queue != nil {
+		hostif.groupIDToQueue[req.GetOid()] = req.GetQueue()
+	}
 	return &saipb.SetHostifTrapGroupAttributeResponse{}, nil
 }
 
+// GetHostifTrapGroupAttribute returns the trap group attributes.
+// Attributes that were never set are reported with their SAI defaults: the group is enabled and has no policer.
+func (hostif *hostif) GetHostifTrapGroupAttribute(_ context.Context, req *saipb.GetHostifTrapGroupAttributeRequest) (*saipb.GetHostifTrapGroupAttributeResponse, error) {
+	queue, ok := hostif.groupIDToQueue[req.GetOid()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown trap group: %d", req.GetOid())
+	}
+	attr := &saipb.HostifTrapGroupAttribute{}
+	if err := hostif.mgr.PopulateAllAttributes(fmt.Sprint(req.GetOid()), attr); err != nil {
+		return nil, err
+	}
+	attr.Queue = proto.Uint32(queue)
+	if attr.AdminState == nil {
+		attr.AdminState = proto.Bool(true)
+	}
+	if attr.Policer == nil {
+		attr.Policer = proto.Uint64(0)
+	}
+	return &saipb.GetHostifTrapGroupAttributeResponse{Attr: attr}, nil
+}
+
 var (
 	etherTypeARP  = []byte{0x08, 0x06}
 	udldDstMAC    = []byte{0x01, 0x00, 0x0C, 0xCC, 0xCC, 0xCC}
@@ -412,26 +885,79 @@ var (
 	ndDstMAC      = []byte{0x33, 0x33, 0x00, 0x00, 0x00, 0x00} // ND is generic IPv6 multicast MAC.
 	ndDstMACMask  = []byte{0xFF, 0xFF, 0x00, 0x00, 0x00, 0x00}
 	lacpDstMAC    = []byte{0x01, 0x80, 0xC2, 0x00, 0x00, 0x02}
+	stpDstMAC     = []byte{0x01, 0x80, 0xC2, 0x00, 0x00, 0x00} // IEEE 802.1D bridge group address.
+	pvrstDstMAC   = []byte{0x01, 0x00, 0x0C, 0xCC, 0xCC, 0xCD} // Cisco shared spanning tree protocol address.
+	// OSPFv2 multicasts to AllSPFRouters (224.0.0.5) and AllDRouters (224.0.0.6).
+	ospfDstMACs = [][]byte{{0x01, 0x00, 0x5E, 0x00, 0x00, 0x05}, {0x01, 0x00, 0x5E, 0x00, 0x00, 0x06}}
+	// igmpTypes are the IGMP message types trapped by each IGMP trap type.
+	igmpTypes = map[saipb.HostifTrapType]byte{
+		saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_QUERY:     0x11,
+		saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_V1_REPORT: 0x12,
+		saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_V2_REPORT: 0x16,
+		saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_LEAVE:     0x17,
+		saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_V3_REPORT: 0x22,
+	}
+	// VRRP advertisements are multicast to 224.0.0.18 and ff02::12.
+	vrrpDstMAC   = []byte{0x01, 0x00, 0x5E, 0x00, 0x00, 0x12}
+	vrrpv6DstMAC = []byte{0x33, 0x33, 0x00, 0x00, 0x00, 0x12}
 )
 
 const (
-	bgpPort        = 179
-	trapTableID    = "trap-table"
-	wildcardPortID = 0
+	bgpPort          = 179
+	dhcpServerPort   = 67
+	dhcpClientPort   = 68
+	dhcpv6ClientPort = 546
+	dhcpv6ServerPort = 547
+	ipProtoIGMP      = 2
+	ipProtoUDP       = 17
+	ipProtoOSPF      = 89
+	ipProtoVRRP      = 112
+	ipProtoPIM       = 103
+	ipProtoHopOpts   = 0 // IPv6 hop-by-hop options, which MLD messages carry for the router alert.
+	trapTableID      = "trap-table"
+	// trapGroupPolicerEntry is the entry of the trap group's policer in its action table, see trapGroupPolicerTable.
+	trapGroupPolicerEntry = "policer"
+	wildcardPortID        = 0
+	// Entries in the trap table are matched in order of priority, lower values first, while traps with higher
+	// SAI trap priorities take precedence. Trap priorities above maxTrapPriority are treated as maxTrapPriority.
+	// Each SAI trap priority has a band of flow priorities, so traps with equal priorities match in the order
+	// they were created. Each trap's exclusions are one priority ahead of its entries, so they take precedence over the trap.
+	maxTrapPriority = math.MaxUint16
+	// maxTrapSeq is the number of traps with equal priorities that are ordered by creation, later traps
+	// share the last flow priority of the band and match most recent first.
+	maxTrapSeq = 1<<15 - 1
+	// trapPriority is the priority of the IP2ME routes, after all traps with the default trap priority.
+	trapPriority = math.MaxUint32
+	// gleanTrapType punts routed packets whose next hop has no neighbor entry, so the host resolves it.
+	// SAI has no trap type for glean, so it's the first trap type of the router custom range.
+	gleanTrapType = saipb.HostifTrapType_HOSTIF_TRAP_TYPE_ROUTER_CUSTOM_RANGE_BASE
 )
 
-func (hostif *hostif) CreateHostifTrap(ctx context.Context, req *saipb.CreateHostifTrapRequest) (*saipb.CreateHostifTrapResponse, error) {
-	id := hostif.mgr.NextID()
-	fwdReq := fwdconfig.TableEntryAddRequest(hostif.dataplane.ID(), trapTableID)
+// trapFlowPriority returns the priority of the entries of the seq-th trap created with the SAI trap priority.
+func trapFlowPriority(priority, seq uint32) uint32 {
+	return (maxTrapPriority-min(priority, maxTrapPriority))<<16 + 2*min(seq, maxTrapSeq) + 1
+}
 
-	swReq := &saipb.GetSwitchAttributeRequest{
-		Oid:      req.GetSwitch(),
-		AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_CPU_PORT},
+// trapTable returns the table the entries of traps of the trap type are added to.
+func trapTable(trapType saipb.HostifTrapType) string {
+	if trapType == gleanTrapType {
+		return gleanTable
 	}
-	swAttr := &saipb.GetSwitchAttributeResponse{}
-	if err := hostif.mgr.PopulateAttributes(swReq, swAttr); err != nil {
+	return trapTableID
+}
+
+func (hostif *hostif) CreateHostifTrap(ctx context.Context, req *saipb.CreateHostifTrapRequest) (*saipb.CreateHostifTrapResponse, error) {
+	cpuPortID, err := hostif.cpuPort()
+	if err != nil {
 		return nil, err
 	}
+	id := hostif.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_HOSTIF_TRAP)
+	fwdReq := fwdconfig.TableEntryAddRequest(hostif.dataplane.ID(), trapTable(req.GetTrapType()))
+	group := req.GetTrapGroup()
+	if group == 0 {
+		group = hostif.defaultTrapGroup()
+	}
+
 	entriesAdded := 1
 	switch tType := req.GetTrapType(); tType {
 	case saipb.HostifTrapType_HOSTIF_TRAP_TYPE_ARP_REQUEST, saipb.HostifTrapType_HOSTIF_TRAP_TYPE_ARP_RESPONSE:
@@ -454,80 +980,997 @@ func (hostif *hostif) CreateHostifTrap(ctx context.Context, req *saipb.CreateHos
 		fwdReq.AppendEntry(fwdconfig.EntryDesc(fwdconfig.FlowEntry(
 			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST).
 				WithBytes(lacpDstMAC, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}))))
+	case saipb.HostifTrapType_HOSTIF_TRAP_TYPE_STP:
+		fwdReq.AppendEntry(fwdconfig.EntryDesc(fwdconfig.FlowEntry(
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST).
+				WithBytes(stpDstMAC, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}))))
+	case saipb.HostifTrapType_HOSTIF_TRAP_TYPE_PVRST:
+		fwdReq.AppendEntry(fwdconfig.EntryDesc(fwdconfig.FlowEntry(
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST).
+				WithBytes(pvrstDstMAC, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}))))
+	case saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_QUERY, saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_LEAVE,
+		saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_V1_REPORT, saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_V2_REPORT,
+		saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_V3_REPORT:
+		// The IGMP header isn't parsed, the message type is the first byte of the IP payload.
+		fwdReq.AppendEntry(fwdconfig.EntryDesc(fwdconfig.FlowEntry(
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_VERSION).WithBytes([]byte{4}, []byte{0xFF}),
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_PROTO).WithBytes([]byte{ipProtoIGMP}, []byte{0xFF}),
+			fwdconfig.PacketUDFMaskedBytes(fwdpb.PacketHeaderGroup_PACKET_HEADER_GROUP_PAYLOAD, 0, 1).WithBytes([]byte{igmpTypes[tType]}, []byte{0xFF}))))
+	case saipb.HostifTrapType_HOSTIF_TRAP_TYPE_PIM:
+		// PIM hellos are multicast to 224.0.0.13 or ff02::d, register and other unicast messages are sent to the RP.
+		fwdReq.AppendEntry(fwdconfig.EntryDesc(fwdconfig.FlowEntry(
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_PROTO).WithBytes([]byte{ipProtoPIM}, []byte{0xFF}))))
+	case saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IPV6_MLD_V1_V2, saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IPV6_MLD_V1_REPORT,
+		saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IPV6_MLD_V1_DONE, saipb.HostifTrapType_HOSTIF_TRAP_TYPE_MLD_V2_REPORT:
+		// TODO: IPv6 extension headers aren't parsed, so all multicast packets with
+		// hop-by-hop options are trapped regardless of the MLD message type.
+		fwdReq.AppendEntry(fwdconfig.EntryDesc(fwdconfig.FlowEntry(
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST).
+				WithBytes(ndDstMAC, ndDstMACMask),
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_VERSION).WithBytes([]byte{6}, []byte{0xFF}),
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_PROTO).WithBytes([]byte{ipProtoHopOpts}, []byte{0xFF}))))
 	case saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IP2ME:
 		// IP2ME routes are added to the FIB, do nothing here.
+		// The routes punt packets whether they are added before or after the trap, but without any the trap never matches.
+		if req.GetRedirectPort() != 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "IP2ME traps can't be redirected")
+		}
+		if hostif.hasIP2MERoutes != nil && !hostif.hasIP2MERoutes() {
+			log.Warningf("trap %d (%v): no routes to the CPU port exist, packets to local addresses are only punted once they are added", id, tType)
+		}
+		hostif.trapEntries[id] = nil
+		hostif.storeTrapAttributes(id, req, group)
 		return &saipb.CreateHostifTrapResponse{
 			Oid: id,
 		}, nil
 	case saipb.HostifTrapType_HOSTIF_TRAP_TYPE_BGP, saipb.HostifTrapType_HOSTIF_TRAP_TYPE_BGPV6:
-		// TODO: This should only match for packets destined to the management IP.
+		// Only BGP packets to the local addresses are trapped, BGP sessions between other routers are forwarded.
+		// The entries are reprogrammed as local addresses are added and removed.
+		entries := hostif.bgpTrapEntries(tType)
+		for _, ed := range entries {
+			fwdReq.AppendEntry(ed)
+		}
+		entriesAdded = len(entries)
+	case saipb.HostifTrapType_HOSTIF_TRAP_TYPE_DHCP, saipb.HostifTrapType_HOSTIF_TRAP_TYPE_DHCPV6:
+		// Requests and replies, including relayed ones, are destined to either the server or the client port.
+		ipVersion, ports := byte(4), []uint16{dhcpServerPort, dhcpClientPort}
+		if tType == saipb.HostifTrapType_HOSTIF_TRAP_TYPE_DHCPV6 {
+			ipVersion, ports = 6, []uint16{dhcpv6ClientPort, dhcpv6ServerPort}
+		}
+		for _, port := range ports {
+			fwdReq.AppendEntry(fwdconfig.EntryDesc(fwdconfig.FlowEntry(
+				fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_VERSION).WithBytes([]byte{ipVersion}, []byte{0xFF}),
+				fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_PROTO).WithBytes([]byte{ipProtoUDP}, []byte{0xFF}),
+				fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_L4_PORT_DST).WithUint16(port))),
+			)
+		}
+		entriesAdded = len(ports)
+	case saipb.HostifTrapType_HOSTIF_TRAP_TYPE_OSPF:
+		// Unicast OSPF packets are sent to local addresses and punted by the IP2ME routes.
+		for _, mac := range ospfDstMACs {
+			fwdReq.AppendEntry(fwdconfig.EntryDesc(fwdconfig.FlowEntry(
+				fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST).
+					WithBytes(mac, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}),
+				fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_VERSION).WithBytes([]byte{4}, []byte{0xFF}),
+				fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_PROTO).WithBytes([]byte{ipProtoOSPF}, []byte{0xFF}))),
+			)
+		}
+		entriesAdded = len(ospfDstMACs)
+	case saipb.HostifTrapType_HOSTIF_TRAP_TYPE_OSPFV6:
 		fwdReq.AppendEntry(fwdconfig.EntryDesc(fwdconfig.FlowEntry(
-			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_L4_PORT_SRC).
-				WithUint16(bgpPort))),
-		)
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_VERSION).WithBytes([]byte{6}, []byte{0xFF}),
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_PROTO).WithBytes([]byte{ipProtoOSPF}, []byte{0xFF}))))
+	case saipb.HostifTrapType_HOSTIF_TRAP_TYPE_VRRP, saipb.HostifTrapType_HOSTIF_TRAP_TYPE_VRRPV6:
+		// Advertisements are always multicast, their source MAC is the virtual router MAC (00:00:5E:00:01:xx or 00:00:5E:00:02:xx).
+		ipVersion, dstMAC := byte(4), vrrpDstMAC
+		if tType == saipb.HostifTrapType_HOSTIF_TRAP_TYPE_VRRPV6 {
+			ipVersion, dstMAC = 6, vrrpv6DstMAC
+		}
 		fwdReq.AppendEntry(fwdconfig.EntryDesc(fwdconfig.FlowEntry(
-			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_L4_PORT_DST).
-				WithUint16(bgpPort))),
-		)
-		entriesAdded = 2
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST).
+				WithBytes(dstMAC, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}),
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_VERSION).WithBytes([]byte{ipVersion}, []byte{0xFF}),
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_PROTO).WithBytes([]byte{ipProtoVRRP}, []byte{0xFF}))))
+	case gleanTrapType:
+		// The glean table is only looked up on a neighbor table miss, so its entry matches every packet.
+		// Packets above the trap group's rate are dropped, like any other packets to unresolved neighbors.
+		if act := req.GetPacketAction(); act != saipb.PacketAction_PACKET_ACTION_TRAP {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported action for glean trap: %v", act)
+		}
+		fwdReq.AppendEntry(fwdconfig.EntryDesc(fwdconfig.FlowEntry()))
 	default:
 		return nil, status.Errorf(codes.InvalidArgument, "unknown trap type: %v", tType)
 	}
 
-	switch act := req.GetPacketAction(); act { // TODO: Support copy
-	case saipb.PacketAction_PACKET_ACTION_TRAP, saipb.PacketAction_PACKET_ACTION_COPY: // TRAP means COPY to CPU and DROP, just transmit immediately, which interrupts any pending actions.
-		for i := 0; i < entriesAdded; i++ {
-			fwdReq.AppendActions(fwdconfig.Action(fwdconfig.TransmitAction(fmt.Sprint(swAttr.GetAttr().GetCpuPort())).WithImmediate(true)))
-		}
-	default:
-		return nil, status.Errorf(codes.InvalidArgument, "unknown action type: %v", act)
-	}
-	if _, err := hostif.dataplane.TableEntryAdd(ctx, fwdReq.Build()); err != nil {
+	dstPort, err := hostif.trapDstPort(req.GetRedirectPort(), cpuPortID)
+	if err != nil {
 		return nil, err
 	}
-	// TODO: Support multiple queues, by using the group ID.
+	actions, err := hostif.trapActions(id, req.GetPacketAction(), group, dstPort, cpuPortID)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < entriesAdded; i++ {
+		fwdReq.AppendActions(actions...)
+	}
+	entryReq := fwdReq.Build()
+	for _, entry := range entryReq.GetEntries() {
+		if err := validateFlowFields(entry.GetEntryDesc().GetFlow().GetFields()); err != nil {
+			return nil, err
+		}
+	}
+	_, err = hostif.dataplane.FlowCounterCreate(ctx, &fwdpb.FlowCounterCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+		Id:        &fwdpb.FlowCounterId{ObjectId: &fwdpb.ObjectId{Id: trapCounterID(id)}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	priority := trapFlowPriority(req.GetTrapPriority(), hostif.trapSeqs[min(req.GetTrapPriority(), maxTrapPriority)])
+	for _, entry := range entryReq.GetEntries() {
+		entry.GetEntryDesc().GetFlow().Priority = priority
+	}
+	// BGP traps have no entries until there are local addresses.
+	if len(entryReq.GetEntries()) > 0 {
+		if _, err := hostif.dataplane.TableEntryAdd(ctx, entryReq); err != nil {
+			if err := hostif.deleteTrapCounter(ctx, id); err != nil {
+				log.Warningf("failed to delete counter of trap %d: %v", id, err)
+			}
+			return nil, err
+		}
+	}
+	hostif.trapEntries[id] = nil
+	for _, entry := range entryReq.GetEntries() {
+		hostif.trapEntries[id] = append(hostif.trapEntries[id], entry.GetEntryDesc())
+	}
+	trap := trapConfig{trapType: req.GetTrapType(), action: req.GetPacketAction(), dstPort: dstPort, group: group, priority: priority}
+	for _, action := range actions {
+		trap.actions = append(trap.actions, action.Build())
+	}
+	hostif.traps[id] = trap
+	hostif.trapSeqs[min(req.GetTrapPriority(), maxTrapPriority)]++
+	hostif.storeTrapAttributes(id, req, group)
+	for _, w := range hostif.trapWarnings(id, cpuPortID) {
+		log.Warning(w)
+	}
 	return &saipb.CreateHostifTrapResponse{
 		Oid: id,
 	}, nil
 }
 
-func (hostif *hostif) CreateHostifTrapGroup(_ context.Context, req *saipb.CreateHostifTrapGroupRequest) (*saipb.CreateHostifTrapGroupResponse, error) {
-	id := hostif.mgr.NextID()
+// trapDstPort returns the port that packets matching a trap with the redirect port are transmitted to:
+// the CPU port, unless the trap redirects them to another port.
+func (hostif *hostif) trapDstPort(redirectPort, cpuPortID uint64) (uint64, error) {
+	if redirectPort == 0 {
+		return cpuPortID, nil
+	}
+	if t := hostif.mgr.GetType(fmt.Sprint(redirectPort)); t != saipb.ObjectType_OBJECT_TYPE_PORT {
+		return 0, status.Errorf(codes.InvalidArgument, "redirect target %d is not a port: %v", redirectPort, t)
+	}
+	return redirectPort, nil
+}
+
+// trapActions returns the actions of the entries of a trap, which transmit matching packets to dstPort.
+func (hostif *hostif) trapActions(id uint64, action saipb.PacketAction, group, dstPort, cpuPortID uint64) ([]*fwdconfig.ActionBuilder, error) {
+	// Punted packets are rate limited by the policer of the trap group, if it has one, shared by all the traps of the group.
+	// Packets punted to the CPU port land on the queue of the trap group, or queue 0 if the group is unknown.
+	// A hostif table entry for a hostif with its own queue overrides it.
+	// Packets punted to the CPU port are also counted by the aggregate punt counters, before and after the policer.
+	var punt []*fwdconfig.ActionBuilder
+	if dstPort == cpuPortID {
+		punt = append(punt, fwdconfig.Action(fwdconfig.FlowCounterAction(cpuPuntTrappedCounter)))
+	}
+	if _, ok := hostif.groupIDToQueue[group]; ok {
+		punt = append(punt, fwdconfig.Action(fwdconfig.LookupAction(trapGroupPolicerTable(group))))
+	}
+	if dstPort == cpuPortID {
+		punt = append(punt,
+			fwdconfig.Action(fwdconfig.FlowCounterAction(cpuPuntAdmittedCounter)),
+			fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_ATTRIBUTE_32).
+				WithFieldIDInstance(cpuQueueAttrInstance).WithValue(binary.BigEndian.AppendUint32(nil, hostif.groupIDToQueue[group]))))
+	}
+	punt = append(punt, fwdconfig.Action(fwdconfig.TransmitAction(fmt.Sprint(dstPort)).WithImmediate(true)))
+
+	switch action {
+	case saipb.PacketAction_PACKET_ACTION_TRAP: // TRAP means COPY to CPU and DROP, just transmit immediately, which interrupts any pending actions.
+		return append([]*fwdconfig.ActionBuilder{fwdconfig.Action(fwdconfig.FlowCounterAction(trapCounterID(id)))}, punt...), nil
+	case saipb.PacketAction_PACKET_ACTION_COPY: // COPY punts a mirror of the packet, the original continues through the pipeline.
+		return []*fwdconfig.ActionBuilder{
+			fwdconfig.Action(fwdconfig.FlowCounterAction(trapCounterID(id))),
+			fwdconfig.Action(fwdconfig.MirrorAction(punt...)),
+		}, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown action type: %v", action)
+	}
+}
+
+// storeTrapAttributes stores the attributes of the trap, including the ones that weren't set in the request,
+// so they can be read back: traps without a trap group are members of the default trap group.
+func (hostif *hostif) storeTrapAttributes(id uint64, req *saipb.CreateHostifTrapRequest, group uint64) {
+	hostif.mgr.StoreAttributes(id, &saipb.HostifTrapAttribute{
+		TrapType:     req.TrapType,
+		PacketAction: req.PacketAction,
+		TrapGroup:    proto.Uint64(group),
+		TrapPriority: proto.Uint32(req.GetTrapPriority()),
+	})
+}
+
+// GetHostifTrapAttribute returns the trap attributes.
+func (hostif *hostif) GetHostifTrapAttribute(_ context.Context, req *saipb.GetHostifTrapAttributeRequest) (*saipb.GetHostifTrapAttributeResponse, error) {
+	if _, ok := hostif.trapEntries[req.GetOid()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown trap: %d", req.GetOid())
+	}
+	attr := &saipb.HostifTrapAttribute{}
+	if err := hostif.mgr.PopulateAllAttributes(fmt.Sprint(req.GetOid()), attr); err != nil {
+		return nil, err
+	}
+	return &saipb.GetHostifTrapAttributeResponse{Attr: attr}, nil
+}
+
+// SetHostifTrapAttribute sets the trap attributes, only the redirect port can be changed.
+// The trap's entries are reprogrammed to transmit matching packets to the new port, or the CPU port if it is 0.
+func (hostif *hostif) SetHostifTrapAttribute(ctx context.Context, req *saipb.SetHostifTrapAttributeRequest) (*saipb.SetHostifTrapAttributeResponse, error) {
+	if _, ok := hostif.trapEntries[req.GetOid()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown trap: %d", req.GetOid())
+	}
+	if req.PacketAction != nil || req.TrapPriority != nil || req.ExcludePortList != nil || req.TrapGroup != nil || req.MirrorSession != nil || req.CounterId != nil {
+		return nil, status.Errorf(codes.Unimplemented, "only the redirect port of a trap can be set")
+	}
+	if req.RedirectPort == nil {
+		return &saipb.SetHostifTrapAttributeResponse{}, nil
+	}
+	trap, ok := hostif.traps[req.GetOid()]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "trap %d is applied by routes and can't be redirected", req.GetOid())
+	}
+	cpuPortID, err := hostif.cpuPort()
+	if err != nil {
+		return nil, err
+	}
+	dstPort, err := hostif.trapDstPort(req.GetRedirectPort(), cpuPortID)
+	if err != nil {
+		return nil, err
+	}
+	actions, err := hostif.trapActions(req.GetOid(), trap.action, trap.group, dstPort, cpuPortID)
+	if err != nil {
+		return nil, err
+	}
+	var actDescs []*fwdpb.ActionDesc
+	for _, action := range actions {
+		actDescs = append(actDescs, action.Build())
+	}
+	// Adding an existing entry replaces its actions, exclusions have a lower priority and keep theirs.
+	entryReq := &fwdpb.TableEntryAddRequest{
+		ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+		TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: trapTable(trap.trapType)}},
+	}
+	for _, entry := range hostif.trapEntries[req.GetOid()] {
+		if entry.GetFlow().GetPriority() == trap.priority {
+			entryReq.Entries = append(entryReq.Entries, &fwdpb.TableEntryAddRequest_Entry{EntryDesc: entry, Actions: actDescs})
+		}
+	}
+	if len(entryReq.GetEntries()) > 0 {
+		if _, err := hostif.dataplane.TableEntryAdd(ctx, entryReq); err != nil {
+			return nil, err
+		}
+	}
+	trap.dstPort = dstPort
+	trap.actions = actDescs
+	hostif.traps[req.GetOid()] = trap
+	for _, w := range hostif.trapWarnings(req.GetOid(), cpuPortID) {
+		log.Warning(w)
+	}
+	return &saipb.SetHostifTrapAttributeResponse{}, nil
+}
+
+// RemoveHostifTrap removes the trap's entries from the trap table, or the glean table for glean traps.
+func (hostif *hostif) RemoveHostifTrap(ctx context.Context, req *saipb.RemoveHostifTrapRequest) (*saipb.RemoveHostifTrapResponse, error) {
+	if err := hostif.removeHostifTraps(ctx, []uint64{req.GetOid()}); err != nil {
+		return nil, err
+	}
+	return &saipb.RemoveHostifTrapResponse{}, nil
+}
+
+// removeHostifTraps removes the traps, with a single dataplane call for the entries of each trap table.
+func (hostif *hostif) removeHostifTraps(ctx context.Context, oids []uint64) error {
+	var tables []string
+	entries := map[string][]*fwdpb.EntryDesc{}
+	for _, oid := range oids {
+		trapEntries, ok := hostif.trapEntries[oid]
+		if !ok {
+			return status.Errorf(codes.NotFound, "unknown trap: %d", oid)
+		}
+		table := trapTable(hostif.traps[oid].trapType)
+		if _, ok := entries[table]; !ok {
+			tables = append(tables, table)
+		}
+		entries[table] = append(entries[table], trapEntries...)
+	}
+	for _, table := range tables {
+		if err := removeTableEntries(ctx, hostif.dataplane, table, entries[table]); err != nil {
+			return err
+		}
+	}
+	for _, oid := range oids {
+		// IP2ME traps don't have a counter, see sumTrapStats.
+		if _, ok := hostif.traps[oid]; ok {
+			if err := hostif.deleteTrapCounter(ctx, oid); err != nil {
+				return err
+			}
+		}
+		delete(hostif.trapEntries, oid)
+		delete(hostif.traps, oid)
+	}
+	return nil
+}
+
+// trapConfig is the configuration of a trap that punts packets from the trap table.
+type trapConfig struct {
+	trapType saipb.HostifTrapType
+	action   saipb.PacketAction
+	dstPort  uint64 // dstPort is the port trapped packets are transmitted to.
+	group    uint64 // group is the trap group the trap is a member of.
+	priority uint32 // priority is the priority of the trap's entries, see trapFlowPriority.
+	// actions are the actions of each of the trap's entries, they are kept to reprogram the entries.
+	actions    []*fwdpb.ActionDesc
+	exclusions []trapExclusion // exclusions are the sources excluded from the trap, in the order they were added.
+}
+
+// trapExclusion is a source that is excluded from a trap, see excludeTrapSource.
+type trapExclusion struct {
+	src     *fwdpb.PacketFieldMaskedBytes
+	actions []*fwdpb.ActionDesc
+}
+
+// trapGroupPolicerTable returns the ID of the action table that rate limits the packets punted by the traps of the group.
+// The entries of all the group's traps look it up, so they share the policer's token bucket.
+func trapGroupPolicerTable(group uint64) string {
+	return fmt.Sprintf("trap-group-policer-%d", group)
+}
+
+// policerAction returns the action that rate limits packets with the policer.
+func (hostif *hostif) policerAction(policer uint64) (*fwdconfig.RateActionBuilder, error) {
+	if hostif.mgr.GetType(fmt.Sprint(policer)) != saipb.ObjectType_OBJECT_TYPE_POLICER {
+		return nil, status.Errorf(codes.NotFound, "unknown policer: %d", policer)
+	}
+	attr := &saipb.PolicerAttribute{}
+	if err := hostif.mgr.PopulateAllAttributes(fmt.Sprint(policer), attr); err != nil {
+		return nil, err
+	}
+	return fwdconfig.RateAction(clampInt32(attr.GetCbs()), clampInt32(attr.GetCir())), nil
+}
+
+// setTrapGroupPolicer replaces the policer of the trap group, or removes it if the new policer is 0.
+// Packets punted by the group's traps that exceed the policer's rate are dropped.
+func (hostif *hostif) setTrapGroupPolicer(ctx context.Context, group, oldPolicer, newPolicer uint64) error {
+	entry := fwdconfig.EntryDesc(fwdconfig.ActionEntry(trapGroupPolicerEntry, fwdpb.ActionEntryDesc_INSERT_METHOD_PREPEND))
+	if newPolicer == 0 {
+		if oldPolicer == 0 {
+			return nil
+		}
+		_, err := hostif.dataplane.TableEntryRemove(ctx, fwdconfig.TableEntryRemoveRequest(hostif.dataplane.ID(), trapGroupPolicerTable(group)).
+			AppendEntry(entry).
+			Build())
+		return err
+	}
+	rate, err := hostif.policerAction(newPolicer)
+	if err != nil {
+		return err
+	}
+	// Adding the entry replaces the entry of the old policer.
+	_, err = hostif.dataplane.TableEntryAdd(ctx, fwdconfig.TableEntryAddRequest(hostif.dataplane.ID(), trapGroupPolicerTable(group)).
+		AppendEntry(entry, fwdconfig.Action(rate)).
+		Build())
+	return err
+}
+
+// clampInt32 converts v to an int32, saturating at the maximum value.
+func clampInt32(v uint64) int32 {
+	if v > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(v)
+}
+
+// trapCounterID returns the ID of the flow counter of the packets matched by the trap.
+func trapCounterID(oid uint64) string {
+	return fmt.Sprintf("%d-trap-counter", oid)
+}
+
+// deleteTrapCounter deletes the flow counter of the trap.
+func (hostif *hostif) deleteTrapCounter(ctx context.Context, oid uint64) error {
+	_, err := hostif.dataplane.ObjectDelete(ctx, &fwdpb.ObjectDeleteRequest{
+		ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+		ObjectId:  &fwdpb.ObjectId{Id: trapCounterID(oid)},
+	})
+	return err
+}
+
+const (
+	// cpuPuntTrappedCounter counts the packets punted to the CPU port by all traps, before the policers of their trap groups.
+	cpuPuntTrappedCounter = "cpu-punt-trapped"
+	// cpuPuntAdmittedCounter counts the packets punted to the CPU port by all traps that are admitted by the policers.
+	cpuPuntAdmittedCounter = "cpu-punt-admitted"
+)
+
+// createPuntCounters creates the aggregate counters of the packets punted to the CPU port by traps.
+func (hostif *hostif) createPuntCounters(ctx context.Context) error {
+	for _, id := range []string{cpuPuntTrappedCounter, cpuPuntAdmittedCounter} {
+		_, err := hostif.dataplane.FlowCounterCreate(ctx, &fwdpb.FlowCounterCreateRequest{
+			ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+			Id:        &fwdpb.FlowCounterId{ObjectId: &fwdpb.ObjectId{Id: id}},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cpuPuntStats returns the aggregate stats of the packets punted to the CPU port by traps.
+// Queue drops are counted by the CPU port, so they include the packets punted by IP2ME routes, which aren't trapped.
+func (hostif *hostif) cpuPuntStats(ctx context.Context) (*diagpb.GetCPUPuntStatsResponse, error) {
+	cpuPortID, err := hostif.cpuPort()
+	if err != nil {
+		return nil, err
+	}
+	counters, err := hostif.dataplane.FlowCounterQuery(ctx, &fwdpb.FlowCounterQueryRequest{
+		ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+		Ids: []*fwdpb.FlowCounterId{
+			{ObjectId: &fwdpb.ObjectId{Id: cpuPuntTrappedCounter}},
+			{ObjectId: &fwdpb.ObjectId{Id: cpuPuntAdmittedCounter}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(counters.GetCounters()) != 2 {
+		return nil, status.Errorf(codes.Internal, "got %d punt counters, want 2", len(counters.GetCounters()))
+	}
+	trapped, admitted := counters.GetCounters()[0].GetPackets(), counters.GetCounters()[1].GetPackets()
+	portCounters, err := hostif.dataplane.ObjectCounters(ctx, &fwdpb.ObjectCountersRequest{
+		ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+		ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(cpuPortID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	stats := &diagpb.GetCPUPuntStatsResponse{
+		Trapped:      trapped,
+		PolicerDrops: trapped - admitted,
+	}
+	for _, c := range portCounters.GetCounters() {
+		switch c.GetId() {
+		case fwdpb.CounterId_COUNTER_ID_TX_ERROR_PACKETS, fwdpb.CounterId_COUNTER_ID_TX_DROP_PACKETS:
+			stats.QueueDrops += c.GetValue()
+		}
+	}
+	if stats.QueueDrops < admitted {
+		stats.Delivered = admitted - stats.QueueDrops
+	}
+	return stats, nil
+}
+
+// defaultTrapGroup returns the trap group of the traps created without one, or 0 if the switch has none.
+func (hostif *hostif) defaultTrapGroup() uint64 {
+	attr := &saipb.GetSwitchAttributeResponse{}
+	err := hostif.mgr.PopulateAttributes(&saipb.GetSwitchAttributeRequest{
+		Oid:      hostif.switchID.Load(),
+		AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_DEFAULT_TRAP_GROUP},
+	}, attr)
+	if err != nil {
+		return 0
+	}
+	return attr.GetAttr().GetDefaultTrapGroup()
+}
+
+// trapStats returns the stats of the trap.
+func (hostif *hostif) trapStats(ctx context.Context, trap uint64) (*diagpb.TrapStats, error) {
+	if _, ok := hostif.trapEntries[trap]; !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown trap: %d", trap)
+	}
+	return hostif.sumTrapStats(ctx, []uint64{trap})
+}
+
+// sumTrapStats returns the sum of the stats of the traps.
+func (hostif *hostif) sumTrapStats(ctx context.Context, traps []uint64) (*diagpb.TrapStats, error) {
+	req := &fwdpb.FlowCounterQueryRequest{
+		ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+	}
+	for _, id := range traps {
+		// IP2ME traps are applied by routes to the CPU port, so they don't have entries to count.
+		if _, ok := hostif.traps[id]; ok {
+			req.Ids = append(req.Ids, &fwdpb.FlowCounterId{ObjectId: &fwdpb.ObjectId{Id: trapCounterID(id)}})
+		}
+	}
+	stats := &diagpb.TrapStats{}
+	if len(req.Ids) == 0 {
+		return stats, nil
+	}
+	counters, err := hostif.dataplane.FlowCounterQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range counters.GetCounters() {
+		stats.Packets += c.GetPackets()
+		stats.Bytes += c.GetOctets()
+	}
+	return stats, nil
+}
+
+// trapGroupStats returns the aggregate stats of the traps that are members of the trap group.
+// Only the current members are counted, the packets matched by removed traps are not included.
+func (hostif *hostif) trapGroupStats(ctx context.Context, group uint64) (*diagpb.TrapStats, error) {
+	if _, ok := hostif.groupIDToQueue[group]; !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown trap group: %d", group)
+	}
+	var members []uint64
+	for id, trap := range hostif.traps {
+		if trap.group == group {
+			members = append(members, id)
+		}
+	}
+	slices.Sort(members)
+	return hostif.sumTrapStats(ctx, members)
+}
+
+// addPipelineHostif records a netdev hostif of the CPU port, and warns about the traps that now loop or bypass forwarding.
+func (hostif *hostif) addPipelineHostif(id uint64) {
+	hostif.pipelineHostifs[id] = true
+	for _, w := range hostif.validateTraps() {
+		log.Warning(w)
+	}
+}
+
+// validateTraps returns a warning for each trap configuration that shadows the forwarding pipeline or creates a loop.
+func (hostif *hostif) validateTraps() []string {
+	cpuPortID, err := hostif.cpuPort()
+	if err != nil {
+		return nil
+	}
+	ids := make([]uint64, 0, len(hostif.traps))
+	for id := range hostif.traps {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	var warnings []string
+	for _, id := range ids {
+		warnings = append(warnings, hostif.trapWarnings(id, cpuPortID)...)
+	}
+	return warnings
+}
+
+// trapWarnings returns the warnings for the trap with the given ID.
+//
+// Packets sent by the host on a netdev hostif of the CPU port run the forwarding pipeline, including the trap table.
+// So a trap matching them punts them straight back to the host, or redirects them without forwarding them.
+func (hostif *hostif) trapWarnings(id, cpuPortID uint64) []string {
+	trap, ok := hostif.traps[id]
+	if !ok {
+		return nil
+	}
+	var warnings []string
+	hostifs := make([]uint64, 0, len(hostif.pipelineHostifs))
+	for hostifID := range hostif.pipelineHostifs {
+		hostifs = append(hostifs, hostifID)
+	}
+	slices.Sort(hostifs)
+	for _, hostifID := range hostifs {
+		switch {
+		case trap.dstPort == cpuPortID:
+			warnings = append(warnings, fmt.Sprintf("trap %d (%v): punts packets sent by hostif %d back to the CPU port, looping them to the host", id, trap.trapType, hostifID))
+		case trap.action == saipb.PacketAction_PACKET_ACTION_COPY:
+			warnings = append(warnings, fmt.Sprintf("trap %d (%v): copies packets sent by hostif %d to port %d, in addition to forwarding them", id, trap.trapType, hostifID, trap.dstPort))
+		default:
+			warnings = append(warnings, fmt.Sprintf("trap %d (%v): redirects packets sent by hostif %d to port %d, bypassing forwarding", id, trap.trapType, hostifID, trap.dstPort))
+		}
+	}
+	return warnings
+}
+
+// excludeTrapSource adds entries to the trap table that match the same packets as the trap, but only
+// from sources in src, and forward or drop them instead. Exclusions are removed along with the trap.
+func (hostif *hostif) excludeTrapSource(ctx context.Context, trap uint64, src *saipb.IpPrefix, action saipb.PacketAction) error {
+	if _, ok := hostif.trapEntries[trap]; !ok {
+		return status.Errorf(codes.NotFound, "unknown trap: %d", trap)
+	}
+	cfg, ok := hostif.traps[trap]
+	if !ok {
+		return status.Errorf(codes.FailedPrecondition, "trap %d is applied by routes and can't exclude sources", trap)
+	}
+	addr, ok := netip.AddrFromSlice(src.GetAddr())
+	ones, bits := net.IPMask(src.GetMask()).Size()
+	if !ok || bits != addr.BitLen() {
+		return status.Errorf(codes.InvalidArgument, "invalid source prefix: %v", src)
+	}
+	var actions []*fwdpb.ActionDesc
+	switch action {
+	case saipb.PacketAction_PACKET_ACTION_FORWARD:
+		actions = []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_CONTINUE}}
+	case saipb.PacketAction_PACKET_ACTION_DROP:
+		actions = []*fwdpb.ActionDesc{fwdconfig.Action(fwdconfig.DropAction()).Build()}
+	default:
+		return status.Errorf(codes.InvalidArgument, "unsupported exclusion action: %v", action)
+	}
+
+	excl := trapExclusion{
+		src: fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_SRC).
+			WithBytes(netip.PrefixFrom(addr, ones).Masked().Addr().AsSlice(), net.CIDRMask(ones, bits)).Build(),
+		actions: actions,
+	}
+	req := &fwdpb.TableEntryAddRequest{
+		ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+		TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: trapTable(cfg.trapType)}},
+		Entries:   exclusionEntries(hostif.trapEntries[trap], cfg.priority, excl),
+	}
+	if len(req.GetEntries()) > 0 {
+		if _, err := hostif.dataplane.TableEntryAdd(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, entry := range req.GetEntries() {
+		hostif.trapEntries[trap] = append(hostif.trapEntries[trap], entry.GetEntryDesc())
+	}
+	cfg.exclusions = append(cfg.exclusions, excl)
+	hostif.traps[trap] = cfg
+	return nil
+}
+
+// exclusionEntries returns the entries that exclude the source from the trap entries, which have the priority.
+// Only the trap's own entries are excluded from, not its previous exclusions.
+func exclusionEntries(entries []*fwdpb.EntryDesc, priority uint32, excl trapExclusion) []*fwdpb.TableEntryAddRequest_Entry {
+	var excls []*fwdpb.TableEntryAddRequest_Entry
+	for _, entry := range entries {
+		if entry.GetFlow().GetPriority() != priority {
+			continue
+		}
+		ed := proto.Clone(entry).(*fwdpb.EntryDesc)
+		ed.GetFlow().Priority = priority - 1
+		ed.GetFlow().Fields = append(ed.GetFlow().Fields, excl.src)
+		excls = append(excls, &fwdpb.TableEntryAddRequest_Entry{EntryDesc: ed, Actions: excl.actions})
+	}
+	return excls
+}
+
+// bgpTrapEntries returns the entries of a BGP trap of the trap type: they match BGP packets to each local address of its IP version.
+func (hostif *hostif) bgpTrapEntries(trapType saipb.HostifTrapType) []*fwdconfig.EntryDescBuilder {
+	if hostif.localPrefixes == nil {
+		return nil
+	}
+	addrLen := net.IPv4len
+	if trapType == saipb.HostifTrapType_HOSTIF_TRAP_TYPE_BGPV6 {
+		addrLen = net.IPv6len
+	}
+	var entries []*fwdconfig.EntryDescBuilder
+	for _, prefix := range hostif.localPrefixes() {
+		if len(prefix.GetAddr()) != addrLen {
+			continue
+		}
+		for _, port := range []fwdpb.PacketFieldNum{fwdpb.PacketFieldNum_PACKET_FIELD_NUM_L4_PORT_SRC, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_L4_PORT_DST} {
+			entries = append(entries, fwdconfig.EntryDesc(fwdconfig.FlowEntry(
+				fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_DST).WithBytes(prefix.GetAddr(), prefix.GetMask()),
+				fwdconfig.PacketFieldMaskedBytes(port).WithUint16(bgpPort))))
+		}
+	}
+	return entries
+}
+
+// updateBGPTraps reprograms the entries of the BGP traps, and their exclusions, for the current local addresses.
+func (hostif *hostif) updateBGPTraps(ctx context.Context) error {
+	var ids []uint64
+	for id, trap := range hostif.traps {
+		if trap.trapType == saipb.HostifTrapType_HOSTIF_TRAP_TYPE_BGP || trap.trapType == saipb.HostifTrapType_HOSTIF_TRAP_TYPE_BGPV6 {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		trap := hostif.traps[id]
+		if entries := hostif.trapEntries[id]; len(entries) > 0 {
+			_, err := hostif.dataplane.TableEntryRemove(ctx, &fwdpb.TableEntryRemoveRequest{
+				ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+				TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: trapTableID}},
+				Entries:   entries,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		hostif.trapEntries[id] = nil
+		req := &fwdpb.TableEntryAddRequest{
+			ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: trapTableID}},
+		}
+		var entries []*fwdpb.EntryDesc
+		for _, ed := range hostif.bgpTrapEntries(trap.trapType) {
+			entry := ed.Build()
+			entry.GetFlow().Priority = trap.priority
+			entries = append(entries, entry)
+			req.Entries = append(req.Entries, &fwdpb.TableEntryAddRequest_Entry{EntryDesc: entry, Actions: trap.actions})
+		}
+		for _, excl := range trap.exclusions {
+			req.Entries = append(req.Entries, exclusionEntries(entries, trap.priority, excl)...)
+		}
+		if len(req.GetEntries()) == 0 {
+			continue
+		}
+		if _, err := hostif.dataplane.TableEntryAdd(ctx, req); err != nil {
+			return err
+		}
+		for _, entry := range req.GetEntries() {
+			hostif.trapEntries[id] = append(hostif.trapEntries[id], entry.GetEntryDesc())
+		}
+	}
+	return nil
+}
+
+func (hostif *hostif) CreateHostifTrapGroup(ctx context.Context, req *saipb.CreateHostifTrapGroupRequest) (*saipb.CreateHostifTrapGroupResponse, error) {
+	if _, err := hostif.cpuPort(); err != nil {
+		return nil, err
+	}
+	id := hostif.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_HOSTIF_TRAP_GROUP)
+	tReq := &fwdpb.TableCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+		Desc: &fwdpb.TableDesc{
+			TableType: fwdpb.TableType_TABLE_TYPE_ACTION,
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: trapGroupPolicerTable(id)}},
+			Actions:   []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_CONTINUE}},
+			Table: &fwdpb.TableDesc_Action{
+				Action: &fwdpb.ActionTableDesc{},
+			},
+		},
+	}
+	if _, err := hostif.dataplane.TableCreate(ctx, tReq); err != nil {
+		return nil, err
+	}
+	if req.GetPolicer() != 0 {
+		if err := hostif.setTrapGroupPolicer(ctx, id, 0, req.GetPolicer()); err != nil {
+			return nil, err
+		}
+	}
 	hostif.groupIDToQueue[id] = req.GetQueue()
+	// Store the defaults, the attributes set in the request are stored over them.
+	hostif.mgr.StoreAttributes(id, &saipb.HostifTrapGroupAttribute{
+		Queue:      proto.Uint32(req.GetQueue()),
+		AdminState: proto.Bool(true),
+		Policer:    proto.Uint64(0),
+	})
 	return &saipb.CreateHostifTrapGroupResponse{Oid: id}, nil
 }
 
+// RemoveHostifTrapGroup removes a trap group, which must not have any member traps.
+func (hostif *hostif) RemoveHostifTrapGroup(ctx context.Context, req *saipb.RemoveHostifTrapGroupRequest) (*saipb.RemoveHostifTrapGroupResponse, error) {
+	if _, ok := hostif.groupIDToQueue[req.GetOid()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown trap group: %d", req.GetOid())
+	}
+	for id, trap := range hostif.traps {
+		if trap.group == req.GetOid() {
+			return nil, status.Errorf(codes.FailedPrecondition, "trap group %d is used by trap %d", req.GetOid(), id)
+		}
+	}
+	_, err := hostif.dataplane.ObjectDelete(ctx, &fwdpb.ObjectDeleteRequest{
+		ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+		ObjectId:  &fwdpb.ObjectId{Id: trapGroupPolicerTable(req.GetOid())},
+	})
+	if err != nil {
+		return nil, err
+	}
+	delete(hostif.groupIDToQueue, req.GetOid())
+	return &saipb.RemoveHostifTrapGroupResponse{}, nil
+}
+
 func (hostif *hostif) CreateHostifUserDefinedTrap(_ context.Context, req *saipb.CreateHostifUserDefinedTrapRequest) (*saipb.CreateHostifUserDefinedTrapResponse, error) {
+	if _, err := hostif.cpuPort(); err != nil {
+		return nil, err
+	}
 	if req.GetType() != saipb.HostifUserDefinedTrapType_HOSTIF_USER_DEFINED_TRAP_TYPE_ACL {
 		return nil, status.Errorf(codes.InvalidArgument, "unsupported trap type: %v", req.GetType())
 	}
-	return &saipb.CreateHostifUserDefinedTrapResponse{Oid: hostif.mgr.NextID()}, nil
+	return &saipb.CreateHostifUserDefinedTrapResponse{Oid: hostif.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_HOSTIF_USER_DEFINED_TRAP)}, nil
 }
 
 const (
 	trapIDToHostifTable = "hostiftable"
+	// wildcardHostifTable is looked up by the packets whose trap ID has no entry in trapIDToHostifTable.
+	wildcardHostifTable = "hostiftable-wildcard"
+	// wildcardHostifEntry is the entry of the wildcard hostif in wildcardHostifTable.
+	wildcardHostifEntry = "wildcard"
 )
 
 func (hostif *hostif) CreateHostifTableEntry(ctx context.Context, req *saipb.CreateHostifTableEntryRequest) (*saipb.CreateHostifTableEntryResponse, error) {
+	if _, err := hostif.cpuPort(); err != nil {
+		return nil, err
+	}
+	trapEntry := fwdconfig.EntryDesc(fwdconfig.ExactEntry(
+		fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_TRAP_ID).WithUint64(req.GetTrapId())))
 	switch entryType := req.GetType(); entryType {
-	case saipb.HostifTableEntryType_HOSTIF_TABLE_ENTRY_TYPE_TRAP_ID:
-		hostif.trapIDToHostifID[req.GetTrapId()] = req.GetHostIf()
-		_, err := hostif.dataplane.TableEntryAdd(ctx, fwdconfig.TableEntryAddRequest(hostif.dataplane.ID(), trapIDToHostifTable).
-			AppendEntry(
-				fwdconfig.EntryDesc(fwdconfig.ExactEntry(
-					fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_TRAP_ID).WithUint64(req.GetTrapId()))),
-				fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_HOST_PORT_ID).WithUint64Value(req.GetHostIf()))).
+	case saipb.HostifTableEntryType_HOSTIF_TABLE_ENTRY_TYPE_TRAP_ID, saipb.HostifTableEntryType_HOSTIF_TABLE_ENTRY_TYPE_WILDCARD:
+		// Re-creating the entry for a trap ID updates it in place, remove the existing dataplane entry first.
+		if prev, ok := hostif.trapIDToHostifID[req.GetTrapId()]; ok && prev != wildcardPortID {
+			log.V(hostifLogLevel).Infof("replacing hostif table entry for trap %d: hostif %d -> %d", req.GetTrapId(), prev, req.GetHostIf())
+			_, err := hostif.dataplane.TableEntryRemove(ctx, fwdconfig.TableEntryRemoveRequest(hostif.dataplane.ID(), trapIDToHostifTable).
+				AppendEntry(trapEntry).
+				Build())
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported entry type: %v", entryType)
+	}
+
+	if req.GetType() == saipb.HostifTableEntryType_HOSTIF_TABLE_ENTRY_TYPE_WILDCARD {
+		hostif.trapIDToHostifID[req.GetTrapId()] = wildcardPortID
+		// Without a hostif, the packets of the trap are delivered to the hostif of their input port.
+		if req.GetHostIf() == 0 {
+			return nil, nil
+		}
+		_, err := hostif.dataplane.TableEntryAdd(ctx, fwdconfig.TableEntryAddRequest(hostif.dataplane.ID(), wildcardHostifTable).
+			AppendEntry(fwdconfig.EntryDesc(fwdconfig.ActionEntry(wildcardHostifEntry, fwdpb.ActionEntryDesc_INSERT_METHOD_PREPEND)),
+				hostif.hostifTableEntryActions(req.GetHostIf())...).
 			Build())
+		return nil, err
+	}
+	hostif.trapIDToHostifID[req.GetTrapId()] = req.GetHostIf()
+	_, err := hostif.dataplane.TableEntryAdd(ctx, fwdconfig.TableEntryAddRequest(hostif.dataplane.ID(), trapIDToHostifTable).
+		AppendEntry(trapEntry, hostif.hostifTableEntryActions(req.GetHostIf())...).
+		Build())
+	return nil, err
+}
+
+// hostifTableEntryActions returns the actions of a hostif table entry that delivers packets to the hostif.
+func (hostif *hostif) hostifTableEntryActions(id uint64) []*fwdconfig.ActionBuilder {
+	actions := []*fwdconfig.ActionBuilder{
+		fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_HOST_PORT_ID).WithUint64Value(id)),
+	}
+	// Punts to a hostif with a queue carry the queue, so they can be prioritized.
+	if queue, ok := hostif.hostifQueues[id]; ok {
+		actions = append(actions, fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_ATTRIBUTE_32).
+			WithFieldIDInstance(cpuQueueAttrInstance).WithValue(binary.BigEndian.AppendUint32(nil, queue))))
+	}
+	return actions
+}
+
+// hostifStats are the packets and bytes a hostif received from and delivered to the host.
+type hostifStats struct {
+	inPackets, inBytes   uint64
+	outPackets, outBytes uint64
+}
+
+// countRemotePacket counts a packet of n bytes sent on the CPU packet stream by the remote hostif id,
+// if in is set, or delivered to it. Packets of unknown hostifs are ignored.
+func (hostif *hostif) countRemotePacket(id uint64, in bool, n int) {
+	hostif.statsMu.Lock()
+	defer hostif.statsMu.Unlock()
+	stats, ok := hostif.remoteStats[id]
+	if !ok {
+		return
+	}
+	if in {
+		stats.inPackets++
+		stats.inBytes += uint64(n)
+	} else {
+		stats.outPackets++
+		stats.outBytes += uint64(n)
+	}
+}
+
+// hostifStats returns the values of the counters of the hostif. Without a remote CPU port,
+// these are the counters of the dataplane port created for the hostif.
+func (hostif *hostif) hostifStats(ctx context.Context, id uint64, counterIDs []diagpb.HostifStat) ([]uint64, error) {
+	var stats hostifStats
+	if hostif.opts.RemoteCPUPort {
+		hostif.statsMu.Lock()
+		s, ok := hostif.remoteStats[id]
+		if ok {
+			stats = *s
+		}
+		hostif.statsMu.Unlock()
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "unknown hostif %d", id)
+		}
+	} else {
+		if _, ok := hostif.localHostifs[id]; !ok {
+			return nil, status.Errorf(codes.NotFound, "unknown hostif %d", id)
+		}
+		counters, err := hostif.dataplane.ObjectCounters(ctx, &fwdpb.ObjectCountersRequest{
+			ContextId: &fwdpb.ContextId{Id: hostif.dataplane.ID()},
+			ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(id)},
+		})
 		if err != nil {
 			return nil, err
 		}
-	case saipb.HostifTableEntryType_HOSTIF_TABLE_ENTRY_TYPE_WILDCARD:
-		hostif.trapIDToHostifID[req.GetTrapId()] = wildcardPortID
-	default:
-		return nil, status.Errorf(codes.InvalidArgument, "unsupported entry type: %v", entryType)
+		// The packets received from the host are the input of the port, the packets delivered to it are its output.
+		for _, c := range counters.GetCounters() {
+			switch c.GetId() {
+			case fwdpb.CounterId_COUNTER_ID_RX_PACKETS:
+				stats.inPackets = c.GetValue()
+			case fwdpb.CounterId_COUNTER_ID_RX_OCTETS:
+				stats.inBytes = c.GetValue()
+			case fwdpb.CounterId_COUNTER_ID_TX_PACKETS:
+				stats.outPackets = c.GetValue()
+			case fwdpb.CounterId_COUNTER_ID_TX_OCTETS:
+				stats.outBytes = c.GetValue()
+			}
+		}
 	}
 
-	return nil, nil
+	values := make([]uint64, 0, len(counterIDs))
+	for _, c := range counterIDs {
+		switch c {
+		case diagpb.HostifStat_HOSTIF_STAT_IN_PACKETS:
+			values = append(values, stats.inPackets)
+		case diagpb.HostifStat_HOSTIF_STAT_OUT_PACKETS:
+			values = append(values, stats.outPackets)
+		case diagpb.HostifStat_HOSTIF_STAT_IN_BYTES:
+			values = append(values, stats.inBytes)
+		case diagpb.HostifStat_HOSTIF_STAT_OUT_BYTES:
+			values = append(values, stats.outBytes)
+		default:
+			values = append(values, 0)
+		}
+	}
+	return values, nil
+}
+
+// trapHostif returns the hostif of the trap ID set by its hostif table entry.
+func (hostif *hostif) trapHostif(trapID uint64) (*diagpb.LookupHostifTableEntryResponse, error) {
+	id, ok := hostif.trapIDToHostifID[trapID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no hostif table entry for trap id %d", trapID)
+	}
+	if id == wildcardPortID {
+		return &diagpb.LookupHostifTableEntryResponse{Wildcard: true}, nil
+	}
+	return &diagpb.LookupHostifTableEntryResponse{Hostif: id}, nil
+}
+
+// Kinds of the streams opened by the remote agent.
+const (
+	cpuPacketStreamKind = "cpu-packet"
+	hostPortControlKind = "host-port-control"
+)
+
+// activeStream is an open stream and the context that cancels its goroutines.
+type activeStream struct {
+	info    *diagpb.HostifStream
+	ctx     context.Context
+	dropped *atomic.Uint64 // dropped counts the packets dropped by a CPU packet stream, it is nil for other streams.
+}
+
+// trackStream records the stream as open, until the returned function is called.
+func (hostif *hostif) trackStream(ctx context.Context, kind string, id uint64, dropped *atomic.Uint64) func() {
+	key := fmt.Sprintf("%s/%d", kind, id)
+	hostif.streamsMu.Lock()
+	hostif.streams[key] = activeStream{info: &diagpb.HostifStream{Kind: kind, Id: id, Started: timestamppb.Now()}, ctx: ctx, dropped: dropped}
+	hostif.streamsMu.Unlock()
+	return func() {
+		hostif.streamsMu.Lock()
+		delete(hostif.streams, key)
+		hostif.streamsMu.Unlock()
+	}
+}
+
+// activeStreams returns the streams that haven't returned yet, ordered by kind and ID.
+func (hostif *hostif) activeStreams() []*diagpb.HostifStream {
+	hostif.streamsMu.Lock()
+	defer hostif.streamsMu.Unlock()
+	streams := make([]*diagpb.HostifStream, 0, len(hostif.streams))
+	for _, s := range hostif.streams {
+		info := proto.Clone(s.info).(*diagpb.HostifStream)
+		info.Alive = s.ctx.Err() == nil
+		if s.dropped != nil {
+			info.Dropped = s.dropped.Load()
+		}
+		streams = append(streams, info)
+	}
+	slices.SortFunc(streams, func(a, b *diagpb.HostifStream) int {
+		if a.GetKind() != b.GetKind() {
+			return strings.Compare(a.GetKind(), b.GetKind())
+		}
+		return cmp.Compare(a.GetId(), b.GetId())
+	})
+	return streams
 }
 
 func (hostif *hostif) CPUPacketStream(srv pktiopb.PacketIO_CPUPacketStreamServer) error {
@@ -557,34 +2000,75 @@ func (hostif *hostif) CPUPacketStream(srv pktiopb.PacketIO_CPUPacketStreamServer
 		return fmt.Errorf("couldn't find cpu port")
 	}
 
-	packetCh := make(chan *pktiopb.PacketIn)
+	packetCh := make(chan *pktiopb.PacketIn, max(hostif.opts.CPUPacketQueueDepth, 1))
 	ctx, cancel := context.WithCancel(srv.Context())
 
 	// Since Recv() is blocking and we want this func to return immediately on cancel.
-	// Run the Recv in a seperate goroutine.
+	// Run the Recv in a seperate goroutine, it returns once the stream is done.
+	// Packets are queued while earlier ones are injected, once the queue is full the newly received packets
+	// are dropped: the queued packets are injected in order, and the client is never stalled by a slow dataplane.
+	var dropped atomic.Uint64
+	recvDone := make(chan struct{})
 	go func() {
+		defer close(recvDone)
 		for {
 			pkt, err := srv.Recv()
 			if err != nil {
-				continue
+				cancel()
+				return
+			}
+			select {
+			case packetCh <- pkt:
+			default:
+				dropped.Add(1)
 			}
-			packetCh <- pkt
 		}
 	}()
 
+	// Packets are punted concurrently, but only one goroutine may send on the stream at a time,
+	// and none once the stream is done.
+	var sendMu sync.Mutex
+	closed := false
 	fn := func(po *pktiopb.PacketOut) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		if closed {
+			return status.Error(codes.Unavailable, "cpu packet stream closed")
+		}
 		return srv.Send(po)
 	}
 
 	fwdCtx.Lock()
-	fwdCtx.SetCPUPortSink(fn, cancel)
+	hostif.cpuStreams++
+	stream := hostif.cpuStreams
+	hostif.addCPUSink(fwdCtx, stream, cpuSink{send: fn, cancel: cancel})
 	fwdCtx.Unlock()
+	// The stream stays tracked until the Recv goroutine exits too, which happens after the handler returns.
+	untrack := hostif.trackStream(ctx, cpuPacketStreamKind, stream, &dropped)
+	defer func() {
+		go func() {
+			<-recvDone
+			untrack()
+		}()
+	}()
+
+	defer func() {
+		// Remove the stream's sink, then wait for in-flight sends.
+		fwdCtx.Lock()
+		hostif.removeCPUSink(fwdCtx, stream)
+		fwdCtx.Unlock()
+		sendMu.Lock()
+		closed = true
+		sendMu.Unlock()
+		log.V(hostifLogLevel).Info("cleared cpu packet stream")
+	}()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 		case pkt := <-packetCh:
+			hostif.countRemotePacket(pkt.GetPacket().GetHostPort(), true, len(pkt.GetPacket().GetFrame()))
 			acts := []*fwdpb.ActionDesc{fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_HOST_PORT_ID).
 				WithUint64Value(pkt.GetPacket().GetHostPort())).Build()}
 			err = hostif.dataplane.InjectPacket(&fwdpb.ContextId{Id: hostif.dataplane.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: cpuPortID}}, fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET,
@@ -597,59 +2081,350 @@ func (hostif *hostif) CPUPacketStream(srv pktiopb.PacketIO_CPUPacketStreamServer
 	}
 }
 
+// cpuSink is the sink of a CPU packet stream.
+type cpuSink struct {
+	send   fwdcontext.CPUPortSink
+	cancel func() // cancel closes the stream.
+}
+
+// addCPUSink registers the sink of a CPU packet stream and sets the CPU port sink to fan out to all streams.
+// fwdCtx must be locked.
+func (hostif *hostif) addCPUSink(fwdCtx *fwdcontext.Context, id uint64, sink cpuSink) {
+	hostif.cpuSinksMu.Lock()
+	hostif.cpuSinks[id] = sink
+	hostif.cpuSinksMu.Unlock()
+	fwdCtx.SetCPUPortSink(hostif.puntToStreams, hostif.cancelCPUStreams)
+}
+
+// removeCPUSink unregisters the sink of a CPU packet stream, and clears the CPU port sink once no stream is left.
+// fwdCtx must be locked.
+func (hostif *hostif) removeCPUSink(fwdCtx *fwdcontext.Context, id uint64) {
+	hostif.cpuSinksMu.Lock()
+	delete(hostif.cpuSinks, id)
+	empty := len(hostif.cpuSinks) == 0
+	hostif.cpuSinksMu.Unlock()
+	if empty {
+		fwdCtx.SetCPUPortSink(nil, nil)
+	}
+}
+
+// cancelCPUStreams closes all CPU packet streams.
+func (hostif *hostif) cancelCPUStreams() {
+	hostif.cpuSinksMu.Lock()
+	defer hostif.cpuSinksMu.Unlock()
+	for _, sink := range hostif.cpuSinks {
+		sink.cancel()
+	}
+}
+
+// puntToStreams sends a packet punted to the CPU port to every CPU packet stream, in the order the streams were opened.
+// It returns an error if any stream fails to send the packet.
+func (hostif *hostif) puntToStreams(po *pktiopb.PacketOut) error {
+	// Packets too big for their output port are answered by the switch, they are not delivered to the host.
+	// The answer is injected from another goroutine, since the punt is processed while holding the forwarding context's
+	// read lock, which injecting the answer takes again.
+	if portID, ok := mtuErrorPort(po.GetPacket().GetHostPort()); ok {
+		go hostif.answerPacketTooBig(po.GetPacket(), portID)
+		return nil
+	}
+	hostif.cpuSinksMu.Lock()
+	ids := make([]uint64, 0, len(hostif.cpuSinks))
+	for id := range hostif.cpuSinks {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	sinks := make([]cpuSink, 0, len(ids))
+	for _, id := range ids {
+		sinks = append(sinks, hostif.cpuSinks[id])
+	}
+	hostif.cpuSinksMu.Unlock()
+
+	var errs []error
+	for _, sink := range sinks {
+		if err := sink.send(po); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) < len(sinks) {
+		hostif.countRemotePacket(po.GetPacket().GetHostPort(), false, len(po.GetPacket().GetFrame()))
+	}
+	return errors.Join(errs...)
+}
+
+// portControlStreamError is an error sending or receiving on the host port control stream.
+// Once the stream fails, it can't be used again.
+type portControlStreamError struct {
+	err error
+}
+
+func (e *portControlStreamError) Error() string {
+	return e.err.Error()
+}
+
+// sendRemotePortReq sends the port control message to the remote agent and returns its reply. If the agent fails the request,
+// it is retried up to opts.RemotePortRetries times with exponential backoff, since the failure may be transient.
+// Failures of the stream itself are not retried. remoteMu must be held, so the retries stop once the next backoff would
+// end after opts.RemotePortTimeout, to bound how long other hostif operations wait for the lock.
+// In dry run mode, the message is not sent and an empty reply is returned.
+func (hostif *hostif) sendRemotePortReq(ctx context.Context, msg *pktiopb.HostPortControlMessage) (*pktiopb.HostPortControlRequest, error) {
+	if hostif.opts.DryRun {
+		log.Infof("dry run, not sending port control message: %v", msg)
+		return &pktiopb.HostPortControlRequest{}, nil
+	}
+	backoff := hostif.opts.RemotePortRetryBackoff
+	var deadline time.Time
+	if hostif.opts.RemotePortTimeout > 0 {
+		deadline = time.Now().Add(hostif.opts.RemotePortTimeout)
+	}
+	for attempt := 0; ; attempt++ {
+		if hostif.remotePortReq == nil {
+			return nil, status.Error(codes.FailedPrecondition, "remote port control not configured")
+		}
+		resp, err := hostif.remotePortReq(msg)
+		if streamErr := (*portControlStreamError)(nil); errors.As(err, &streamErr) {
+			return nil, streamErr.err
+		}
+		if err == nil || attempt >= hostif.opts.RemotePortRetries {
+			return resp, err
+		}
+		if !deadline.IsZero() && time.Now().Add(backoff).After(deadline) {
+			log.Warningf("remote port control failed for hostif %d, not retrying past %v: %v", msg.GetPortId(), hostif.opts.RemotePortTimeout, err)
+			return resp, err
+		}
+		log.Warningf("remote port control failed for hostif %d, retrying in %v: %v", msg.GetPortId(), backoff, err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// recordGenetlinkIDs records the family and group IDs the agent resolved when creating a genetlink hostif.
+// Agents that don't report them leave the IDs unknown. remoteMu must be held.
+func (hostif *hostif) recordGenetlinkIDs(id uint64, msg *pktiopb.HostPortControlMessage, resp *pktiopb.HostPortControlRequest) {
+	if msg.GetGenetlink() == nil {
+		return
+	}
+	if ids := resp.GetGenetlinkIds(); ids != nil {
+		hostif.genetlinkIDs[id] = ids
+		return
+	}
+	delete(hostif.genetlinkIDs, id)
+}
+
+// genetlinkHostifIDs returns the family and group IDs resolved for a remote genetlink hostif.
+func (hostif *hostif) genetlinkHostifIDs(id uint64) (*pktiopb.GenetlinkPortIds, error) {
+	hostif.remoteMu.Lock()
+	defer hostif.remoteMu.Unlock()
+	if hostif.remoteHostifs[id].GetGenetlink() == nil {
+		return nil, status.Errorf(codes.NotFound, "unknown genetlink hostif: %d", id)
+	}
+	ids, ok := hostif.genetlinkIDs[id]
+	if !ok {
+		return nil, status.Errorf(codes.Unavailable, "genetlink ids of hostif %d not reported by the agent", id)
+	}
+	return proto.Clone(ids).(*pktiopb.GenetlinkPortIds), nil
+}
+
+// replayRemoteHostifs sends all existing hostifs to a newly connected agent, in the order they were created so parent ports
+// precede their sub-interfaces. Requests are sent in batches of opts.RemotePortReplayBatch, waiting for the replies to a batch
+// before sending the next, so a large replay doesn't overwhelm the agent. The agent replies in order, the requests it fails
+// are retried after the rest of their batch. Nothing is replayed in dry run mode. remoteMu must be held.
+func (hostif *hostif) replayRemoteHostifs(ctx context.Context, send func(*pktiopb.HostPortControlMessage) error, recv func() (*pktiopb.HostPortControlRequest, error)) error {
+	if hostif.opts.DryRun {
+		return nil
+	}
+	ids := make([]uint64, 0, len(hostif.remoteHostifs))
+	for id := range hostif.remoteHostifs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	batchSize := max(hostif.opts.RemotePortReplayBatch, 1)
+	for start := 0; start < len(ids); start += batchSize {
+		batch := ids[start:min(start+batchSize, len(ids))]
+		sent := time.Now()
+		for _, id := range batch {
+			if err := send(hostif.remoteHostifs[id]); err != nil {
+				hostif.observePortReq(hostif.remoteHostifs[id], sent, err)
+				if streamErr := (*portControlStreamError)(nil); errors.As(err, &streamErr) {
+					return streamErr.err
+				}
+				return err
+			}
+		}
+		var failed []uint64
+		for _, id := range batch {
+			resp, err := recv()
+			hostif.observePortReq(hostif.remoteHostifs[id], sent, err)
+			if streamErr := (*portControlStreamError)(nil); errors.As(err, &streamErr) {
+				return streamErr.err
+			}
+			if err != nil {
+				log.Warningf("remote port control failed for hostif %d during replay, retrying: %v", id, err)
+				failed = append(failed, id)
+				continue
+			}
+			// The new agent may have resolved the genetlink families differently.
+			hostif.recordGenetlinkIDs(id, hostif.remoteHostifs[id], resp)
+		}
+		for _, id := range failed {
+			resp, err := hostif.sendRemotePortReq(ctx, hostif.remoteHostifs[id])
+			if err != nil {
+				return err
+			}
+			hostif.recordGenetlinkIDs(id, hostif.remoteHostifs[id], resp)
+		}
+	}
+	return nil
+}
+
+// observePortReq reports a host port control message sent at start to the metrics, if configured. remoteMu must be held.
+func (hostif *hostif) observePortReq(msg *pktiopb.HostPortControlMessage, start time.Time, err error) {
+	if hostif.opts.HostPortControlMetrics == nil {
+		return
+	}
+	if streamErr := (*portControlStreamError)(nil); errors.As(err, &streamErr) {
+		err = streamErr.err
+	}
+	hostif.opts.HostPortControlMetrics.RequestDone(msg, time.Since(start), err)
+}
+
+// closeRemoteStreams cancels the open host port control stream, if any. remoteMu must be held.
+func (hostif *hostif) closeRemoteStreams() {
+	for _, closeFn := range hostif.remoteClosers {
+		closeFn()
+	}
+	hostif.remoteClosers = nil
+}
+
 func (hostif *hostif) HostPortControl(srv pktiopb.PacketIO_HostPortControlServer) error {
-	log.Info("started host port control channel")
+	log.V(hostifLogLevel).Info("started host port control channel")
 	_, err := srv.Recv()
 	if err != nil {
 		return err
 	}
-	log.Info("received init port control channel")
+	log.V(hostifLogLevel).Info("received init port control channel")
 
 	hostif.remoteMu.Lock()
+	// A reconnecting agent replaces the previous stream, which may not have noticed the drop yet.
+	// It is canceled, so it doesn't linger, and all hostifs are replayed on the new stream.
+	hostif.closeRemoteStreams()
 	ctx, cancelFn := context.WithCancel(srv.Context())
 	hostif.remoteClosers = append(hostif.remoteClosers, func() {
-		log.Info("canceling host port control")
+		log.V(hostifLogLevel).Info("canceling host port control")
 		cancelFn()
 	})
 
-	errCh := make(chan error)
+	// errCh only holds the first error, the stream is torn down after it.
+	errCh := make(chan error, 1)
+	streamErr := func(err error) error {
+		select {
+		case errCh <- err:
+		default:
+		}
+		return &portControlStreamError{err: err}
+	}
 
-	hostif.remotePortReq = func(msg *pktiopb.HostPortControlMessage) error {
+	hostif.remoteStreams++
+	stream := hostif.remoteStreams
+	defer hostif.trackStream(ctx, hostPortControlKind, stream, nil)()
+	// timedOut is set once the agent fails to reply in time, guarded by remoteMu like every call of remotePortReq.
+	// The stream is torn down then: a late reply could be taken for the reply to the next request.
+	timedOut := false
+	withTimeout := func(fn func() (*pktiopb.HostPortControlRequest, error)) (*pktiopb.HostPortControlRequest, error) {
+		if timedOut {
+			return nil, &portControlStreamError{err: status.Error(codes.Unavailable, "host port control stream timed out")}
+		}
+		if hostif.opts.RemotePortTimeout <= 0 {
+			return fn()
+		}
+		type result struct {
+			resp *pktiopb.HostPortControlRequest
+			err  error
+		}
+		// Send and Recv can't be interrupted, the goroutine returns once the stream is torn down.
+		resCh := make(chan result, 1)
+		go func() {
+			resp, err := fn()
+			resCh <- result{resp: resp, err: err}
+		}()
+		timeoutCtx, cancel := context.WithTimeout(ctx, hostif.opts.RemotePortTimeout)
+		defer cancel()
+		select {
+		case res := <-resCh:
+			return res.resp, res.err
+		case <-timeoutCtx.Done():
+			timedOut = true
+			if ctx.Err() != nil {
+				return nil, streamErr(ctx.Err())
+			}
+			return nil, streamErr(status.Errorf(codes.DeadlineExceeded, "remote agent didn't reply within %v", hostif.opts.RemotePortTimeout))
+		}
+	}
+	sendMsg := func(msg *pktiopb.HostPortControlMessage) error {
 		if err := srv.Send(msg); err != nil {
-			errCh <- err
-			return err
+			return streamErr(err)
 		}
+		return nil
+	}
+	recvReply := func() (*pktiopb.HostPortControlRequest, error) {
 		resp, err := srv.Recv()
 		if err != nil {
-			errCh <- err
-			return err
+			return nil, streamErr(err)
 		}
-		return status.FromProto(resp.GetStatus()).Err()
+		return resp, status.FromProto(resp.GetStatus()).Err()
+	}
+	send := func(msg *pktiopb.HostPortControlMessage) error {
+		_, err := withTimeout(func() (*pktiopb.HostPortControlRequest, error) {
+			return nil, sendMsg(msg)
+		})
+		return err
+	}
+	recv := func() (*pktiopb.HostPortControlRequest, error) {
+		return withTimeout(recvReply)
+	}
+	hostif.remotePortReq = func(msg *pktiopb.HostPortControlMessage) (*pktiopb.HostPortControlRequest, error) {
+		start := time.Now()
+		resp, err := withTimeout(func() (*pktiopb.HostPortControlRequest, error) {
+			if err := sendMsg(msg); err != nil {
+				return nil, err
+			}
+			return recvReply()
+		})
+		hostif.observePortReq(msg, start, err)
+		return resp, err
 	}
 
-	// Send all existing hostif.
-	for _, msg := range hostif.remoteHostifs {
-		if err := hostif.remotePortReq(msg); err != nil {
-			hostif.remoteMu.Unlock()
-			return err
-		}
+	if err := hostif.replayRemoteHostifs(ctx, send, recv); err != nil {
+		// The stream is still the latest one, since remoteMu was held since it was set up.
+		hostif.remotePortReq = nil
+		hostif.closeRemoteStreams()
+		hostif.remoteMu.Unlock()
+		return err
 	}
 	hostif.remoteMu.Unlock()
 
-	log.Info("initialized host port control channel")
+	log.V(hostifLogLevel).Info("initialized host port control channel")
 
 	// The HostPortControls exits in two cases: context cancels or RPC errors.
 	err = nil
 	select {
 	case <-ctx.Done():
-		log.Info("host port control done")
+		log.V(hostifLogLevel).Info("host port control done")
 	case err = <-errCh:
-		log.Info("host port control err: %v", err)
+		log.Warningf("host port control err: %v", err)
 	}
 
+	// remotePortReq is only called with remoteMu held, so no request is sent on the stream once it is cleared.
+	// It is left alone if a newer stream replaced it.
 	hostif.remoteMu.Lock()
-	hostif.remotePortReq = nil
+	if hostif.remoteStreams == stream {
+		hostif.remotePortReq = nil
+		hostif.closeRemoteStreams()
+	}
 	hostif.remoteMu.Unlock()
-	log.Info("cleared host port control channel")
+	log.V(hostifLogLevel).Info("cleared host port control channel")
 	return err
 }
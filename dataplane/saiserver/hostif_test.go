@@ -15,24 +15,42 @@
 package saiserver
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"io"
 	"net"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
 	"github.com/openconfig/gnmi/errdiff"
+	"golang.org/x/sys/unix"
 	"google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 
 	"github.com/openconfig/lemming/dataplane/dplaneopts"
+	"github.com/openconfig/lemming/dataplane/forwarding/attributes"
+	"github.com/openconfig/lemming/dataplane/forwarding/fwdconfig"
+	"github.com/openconfig/lemming/dataplane/forwarding/fwdtable"
 	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdcontext"
+	diagpb "github.com/openconfig/lemming/dataplane/proto/diag"
 	pktiopb "github.com/openconfig/lemming/dataplane/proto/packetio"
 	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
 	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
+	"github.com/openconfig/lemming/internal/packetutil"
 	fwdpb "github.com/openconfig/lemming/proto/forwarding"
 )
 
@@ -96,6 +114,7 @@ func TestCreateHostif(t *testing.T) {
 			mgr.StoreAttributes(mgr.NextID(), &saipb.PortAttribute{
 				OperStatus: saipb.PortOperStatus_PORT_OPER_STATUS_DOWN.Enum(),
 			})
+			c.srv.initSwitch(switchID, 10)
 
 			defer stopFn()
 			got, gotErr := c.CreateHostif(context.TODO(), tt.req)
@@ -119,12 +138,135 @@ func TestCreateHostif(t *testing.T) {
 	}
 }
 
+func TestCreateHostifCleanup(t *testing.T) {
+	tests := []struct {
+		desc       string
+		method     string
+		skip       int
+		wantErr    string
+		wantUnlink bool
+	}{{
+		desc:    "first attribute update",
+		method:  "AttributeUpdate",
+		wantErr: "injected",
+	}, {
+		desc:    "second attribute update",
+		method:  "AttributeUpdate",
+		skip:    1,
+		wantErr: "injected",
+	}, {
+		desc:       "port update",
+		method:     "PortUpdate",
+		wantErr:    "injected",
+		wantUnlink: true,
+	}, {
+		desc:       "packet sink notification",
+		method:     "FindContext",
+		wantErr:    "injected",
+		wantUnlink: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			fake := &fakeSwitchDataplane{
+				ctx: fwdcontext.New("foo", "foo"),
+			}
+			fake.ctx.SetPacketSink(func(*fwdpb.PacketSinkResponse) error { return nil })
+			dplane := newFaultyDataplane(fake)
+			dplane.fail(tt.method, tt.skip, fmt.Errorf("injected"))
+			c, mgr, stopFn := newTestHostif(t, dplane, false)
+			defer stopFn()
+			mgr.StoreAttributes(mgr.NextID(), &saipb.SwitchAttribute{
+				CpuPort: proto.Uint64(10),
+			})
+			mgr.StoreAttributes(10, &saipb.PortAttribute{
+				OperStatus: saipb.PortOperStatus_PORT_OPER_STATUS_UP.Enum(),
+			})
+			c.srv.initSwitch(switchID, 10)
+
+			_, gotErr := c.CreateHostif(context.TODO(), &saipb.CreateHostifRequest{
+				Type:  saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+				ObjId: proto.Uint64(10),
+			})
+			if diff := errdiff.Check(gotErr, tt.wantErr); diff != "" {
+				t.Fatalf("CreateHostif() unexpected err: %s", diff)
+			}
+			if len(fake.gotPortCreateReqs) != 1 {
+				t.Fatalf("CreateHostif() got %d port create requests, want 1", len(fake.gotPortCreateReqs))
+			}
+			want := []*fwdpb.ObjectDeleteRequest{{
+				ContextId: &fwdpb.ContextId{Id: fake.ID()},
+				ObjectId:  fake.gotPortCreateReqs[0].GetPort().GetPortId().GetObjectId(),
+			}}
+			if d := cmp.Diff(fake.gotObjectDeleteReqs, want, protocmp.Transform()); d != "" {
+				t.Errorf("CreateHostif() failed: diff(-got,+want)\n:%s", d)
+			}
+			// The port is unlinked from the hostif after it was linked to it.
+			unlink := &fwdpb.AttributeUpdateRequest{
+				ContextId: &fwdpb.ContextId{Id: fake.ID()},
+				ObjectId:  &fwdpb.ObjectId{Id: "10"},
+				AttrId:    attributes.SwapActionRelatedPort,
+			}
+			gotUnlink := len(fake.gotAttributeUpdateReqs) == 3 && proto.Equal(fake.gotAttributeUpdateReqs[2], unlink)
+			if gotUnlink != tt.wantUnlink {
+				t.Errorf("CreateHostif() unlinked port: got %v, want %v, attribute updates: %v", gotUnlink, tt.wantUnlink, fake.gotAttributeUpdateReqs)
+			}
+			if len(c.srv.localHostifs) != 0 {
+				t.Errorf("CreateHostif() recorded the failed hostif: %v", c.srv.localHostifs)
+			}
+		})
+	}
+}
+
+func TestCreateHostifNetDevPortType(t *testing.T) {
+	tests := []struct {
+		desc     string
+		portType fwdpb.PortType
+		wantErr  string
+	}{{
+		desc:     "unknown",
+		portType: fwdpb.PortType(1000),
+		wantErr:  "unknown netdev hostif port type",
+	}, {
+		desc:     "unsupported",
+		portType: fwdpb.PortType_PORT_TYPE_CPU_PORT,
+		wantErr:  "unsupported netdev hostif port type",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dplane := &fakeSwitchDataplane{}
+			conn, mgr, stopFn := newTestServer(t, func(mgr *attrmgr.AttrMgr, srv *grpc.Server) {
+				h := newHostif(mgr, dplane, srv, &dplaneopts.Options{
+					HostifNetDevType: tt.portType,
+				})
+				h.initSwitch(switchID, 10)
+			})
+			defer stopFn()
+			mgr.StoreAttributes(mgr.NextID(), &saipb.SwitchAttribute{
+				CpuPort: proto.Uint64(10),
+			})
+
+			_, gotErr := saipb.NewHostifClient(conn).CreateHostif(context.TODO(), &saipb.CreateHostifRequest{
+				Type:  saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+				ObjId: proto.Uint64(2),
+			})
+			if diff := errdiff.Check(gotErr, tt.wantErr); diff != "" {
+				t.Fatalf("CreateHostif() unexpected err: %s", diff)
+			}
+			if got, want := grpcstatus.Code(gotErr), codes.InvalidArgument; got != want {
+				t.Errorf("CreateHostif() got code %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func TestRemoveHostif(t *testing.T) {
 	tests := []struct {
-		desc    string
-		req     *saipb.RemoveHostifRequest
-		want    *pktiopb.HostPortControlMessage
-		wantErr string
+		desc               string
+		remote             *pktiopb.HostPortControlMessage
+		req                *saipb.RemoveHostifRequest
+		want               *pktiopb.HostPortControlMessage
+		wantRemovedEntries int
+		wantErr            string
 	}{{
 		desc: "sucess",
 		req: &saipb.RemoveHostifRequest{
@@ -133,6 +275,22 @@ func TestRemoveHostif(t *testing.T) {
 		want: &pktiopb.HostPortControlMessage{
 			PortId: 1,
 		},
+		wantRemovedEntries: 2,
+	}, {
+		desc: "genetlink",
+		remote: &pktiopb.HostPortControlMessage{
+			Create: true,
+			PortId: 1,
+			Port: &pktiopb.HostPortControlMessage_Genetlink{
+				Genetlink: &pktiopb.GenetlinkPort{Family: "psample", Group: "packets"},
+			},
+		},
+		req: &saipb.RemoveHostifRequest{
+			Oid: 1,
+		},
+		want: &pktiopb.HostPortControlMessage{
+			PortId: 1,
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -157,6 +315,16 @@ func TestRemoveHostif(t *testing.T) {
 				t.Fatal(err)
 			}
 			time.Sleep(time.Millisecond)
+			// The hostif is recorded once the stream is set up, so it isn't replayed.
+			if tt.remote != nil {
+				for ready := false; !ready; time.Sleep(time.Millisecond) {
+					c.srv.remoteMu.Lock()
+					if ready = c.srv.remotePortReq != nil; ready {
+						c.srv.remoteHostifs[1] = tt.remote
+					}
+					c.srv.remoteMu.Unlock()
+				}
+			}
 			go func() {
 				msg, _ := pc.Recv()
 				msgCh <- msg
@@ -181,76 +349,4645 @@ func TestRemoveHostif(t *testing.T) {
 			if d := cmp.Diff(got, tt.want, protocmp.Transform()); d != "" {
 				t.Errorf("RemoveHostif() failed: diff(-got,+want)\n:%s", d)
 			}
+			if got := len(dplane.gotEntryRemoveReqs); got != tt.wantRemovedEntries {
+				t.Errorf("RemoveHostif() removed %d table entries, want %d", got, tt.wantRemovedEntries)
+			}
+			if _, ok := c.srv.remoteHostifs[1]; ok {
+				t.Errorf("RemoveHostif() hostif still recorded for replay")
+			}
 		})
 	}
 }
 
-func TestSetHostifAttribute(t *testing.T) {
+func TestRemoveLocalHostif(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithHostifNetDevPortType(fwdpb.PortType_PORT_TYPE_GENETLINK))
+
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	port := createTestPort(t, conn, 1)
+	hc := saipb.NewHostifClient(conn)
+	hif, err := hc.CreateHostif(ctx, &saipb.CreateHostifRequest{
+		Type:  saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+		ObjId: proto.Uint64(port),
+		Name:  []byte("Ethernet1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	swapPort := func() (string, bool) {
+		fwdCtx.RLock()
+		defer fwdCtx.RUnlock()
+		obj, err := fwdCtx.Objects.FindID(&fwdpb.ObjectId{Id: fmt.Sprint(port)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return obj.Attributes().Get(attributes.SwapActionRelatedPort)
+	}
+	if got, ok := swapPort(); !ok || got != fmt.Sprint(hif.GetOid()) {
+		t.Fatalf("port swapped with %q, want %d", got, hif.GetOid())
+	}
+
+	if _, err := hc.RemoveHostif(ctx, &saipb.RemoveHostifRequest{Oid: hif.GetOid()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ObjectNID(ctx, &fwdpb.ObjectNIDRequest{
+		ContextId: &fwdpb.ContextId{Id: s.ID()},
+		ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(hif.GetOid())},
+	}); err == nil {
+		t.Errorf("ObjectNID() of the removed hostif's port succeeded, want error")
+	}
+	if got, ok := swapPort(); ok {
+		t.Errorf("port still swapped with %q after hostif removal", got)
+	}
+	_, err = hc.GetHostifAttribute(ctx, &saipb.GetHostifAttributeRequest{
+		Oid:      hif.GetOid(),
+		AttrType: []saipb.HostifAttr{saipb.HostifAttr_HOSTIF_ATTR_OPER_STATUS},
+	})
+	if got, want := grpcstatus.Code(err), codes.NotFound; got != want {
+		t.Errorf("GetHostifAttribute() of removed hostif got code %v, want %v", got, want)
+	}
+	if _, err := hc.RemoveHostif(ctx, &saipb.RemoveHostifRequest{Oid: hif.GetOid()}); grpcstatus.Code(err) != codes.NotFound {
+		t.Errorf("RemoveHostif() of removed hostif got err %v, want NotFound", err)
+	}
+}
+
+func TestGetHostifActions(t *testing.T) {
+	ctx := context.Background()
+	s, conn, _ := newTestSaiServer(t, dplaneopts.WithHostifNetDevPortType(fwdpb.PortType_PORT_TYPE_GENETLINK))
+	mgr := s.mgr
+
+	sc := saipb.NewSwitchClient(conn)
+	sw, err := sc.CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	swAttr, err := sc.GetSwitchAttribute(ctx, &saipb.GetSwitchAttributeRequest{
+		Oid:      sw.GetOid(),
+		AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_CPU_PORT},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The CPU port created by the switch has no oper status, which the hostif requires.
+	mgr.StoreAttributes(swAttr.GetAttr().GetCpuPort(), &saipb.PortAttribute{
+		OperStatus: saipb.PortOperStatus_PORT_OPER_STATUS_UP.Enum(),
+	})
+	port := createTestPort(t, conn, 1)
+	hc := saipb.NewHostifClient(conn)
+	dc := diagpb.NewDiagClient(conn)
+
 	tests := []struct {
-		desc            string
-		req             *saipb.SetHostifAttributeRequest
-		getInterfaceErr error
-		wantAttr        *saipb.HostifAttribute
-		wantReq         *fwdpb.PortStateRequest
-		wantErr         string
+		desc  string
+		objID uint64
+		want  []*fwdpb.ActionDesc
 	}{{
-		desc: "oper status",
-		req: &saipb.SetHostifAttributeRequest{
-			Oid:        1,
-			OperStatus: proto.Bool(false),
-		},
-		wantReq: &fwdpb.PortStateRequest{
-			PortId:    &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: "1"}},
-			Operation: &fwdpb.PortInfo{AdminStatus: fwdpb.PortState_PORT_STATE_DISABLED_DOWN},
-			ContextId: &fwdpb.ContextId{Id: "foo"},
-		},
-		wantAttr: &saipb.HostifAttribute{
-			OperStatus: proto.Bool(false),
-		},
+		desc:  "port",
+		objID: port,
+		want: []*fwdpb.ActionDesc{{
+			ActionType: fwdpb.ActionType_ACTION_TYPE_SWAP_OUTPUT_INTERNAL_EXTERNAL,
+		}, {
+			ActionType: fwdpb.ActionType_ACTION_TYPE_OUTPUT,
+		}},
+	}, {
+		desc:  "cpu port",
+		objID: swAttr.GetAttr().GetCpuPort(),
+		want:  getForwardingPipeline(),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
-			getInterface = func(string) (*net.Interface, error) {
-				return nil, tt.getInterfaceErr
+			hif, err := hc.CreateHostif(ctx, &saipb.CreateHostifRequest{
+				Type:  saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+				ObjId: proto.Uint64(tt.objID),
+				Name:  []byte(tt.desc),
+			})
+			if err != nil {
+				t.Fatal(err)
 			}
-			dplane := &fakeSwitchDataplane{}
-			c, mgr, stopFn := newTestHostif(t, dplane, false)
-			defer stopFn()
-			_, gotErr := c.SetHostifAttribute(context.TODO(), tt.req)
-			if diff := errdiff.Check(gotErr, tt.wantErr); diff != "" {
-				t.Fatalf("SetHostifAttribute() unexpected err: %s", diff)
+			got, err := dc.GetHostifActions(ctx, &diagpb.GetHostifActionsRequest{Oid: hif.GetOid()})
+			if err != nil {
+				t.Fatalf("GetHostifActions() unexpected error: %v", err)
 			}
-			if gotErr != nil {
-				return
+			if d := cmp.Diff(got.GetInputs(), tt.want, protocmp.Transform()); d != "" {
+				t.Errorf("GetHostifActions() unexpected diff (-got,+want):\n%s", d)
 			}
-			if d := cmp.Diff(dplane.gotPortStateReq[0], tt.wantReq, protocmp.Transform()); d != "" {
-				t.Errorf("SetHostifAttribute() failed: diff(-got,+want)\n:%s", d)
+			if _, err := hc.RemoveHostif(ctx, &saipb.RemoveHostifRequest{Oid: hif.GetOid()}); err != nil {
+				t.Fatal(err)
 			}
-			attr := &saipb.HostifAttribute{}
-			if err := mgr.PopulateAllAttributes("1", attr); err != nil {
+			if _, err := dc.GetHostifActions(ctx, &diagpb.GetHostifActionsRequest{Oid: hif.GetOid()}); grpcstatus.Code(err) != codes.NotFound {
+				t.Errorf("GetHostifActions() of removed hostif got err %v, want NotFound", err)
+			}
+		})
+	}
+}
+
+func TestGenetlinkHostifLifecycle(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []dplaneopts.Option
+		// programmed is set when the hostif's port is created and the sink is told about it.
+		programmed bool
+	}{{
+		desc:       "programmed",
+		programmed: true,
+	}, {
+		desc: "dry run",
+		opts: []dplaneopts.Option{dplaneopts.WithDryRun(true)},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ctx := context.Background()
+			s, conn, fwdCtx := newTestSaiServer(t, tt.opts...)
+			var (
+				mu        sync.Mutex
+				sinkResps []*fwdpb.PacketSinkResponse
+			)
+			fwdCtx.SetPacketSink(func(resp *fwdpb.PacketSinkResponse) error {
+				mu.Lock()
+				defer mu.Unlock()
+				sinkResps = append(sinkResps, resp)
+				return nil
+			})
+
+			if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
 				t.Fatal(err)
 			}
-			if d := cmp.Diff(attr, tt.wantAttr, protocmp.Transform()); d != "" {
-				t.Errorf("SetHostifAttribute() failed: diff(-got,+want)\n:%s", d)
+			hc := saipb.NewHostifClient(conn)
+			hif, err := hc.CreateHostif(ctx, &saipb.CreateHostifRequest{
+				Type:               saipb.HostifType_HOSTIF_TYPE_GENETLINK.Enum(),
+				Name:               []byte("psample"),
+				GenetlinkMcgrpName: []byte("packets"),
+			})
+			if err != nil {
+				t.Fatalf("CreateHostif() unexpected err: %v", err)
+			}
+			if hif.GetOid() == 0 {
+				t.Errorf("CreateHostif() got oid 0, want an allocated oid")
+			}
+			portExists := func() bool {
+				_, err := s.ObjectNID(ctx, &fwdpb.ObjectNIDRequest{
+					ContextId: &fwdpb.ContextId{Id: s.ID()},
+					ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(hif.GetOid())},
+				})
+				return err == nil
+			}
+			if got := portExists(); got != tt.programmed {
+				t.Errorf("hostif's port exists got %v, want %v", got, tt.programmed)
+			}
+			if _, err := hc.CreateHostif(ctx, &saipb.CreateHostifRequest{}); err == nil {
+				t.Errorf("CreateHostif() with an unknown type succeeded, want error")
+			}
+			if _, err := hc.RemoveHostif(ctx, &saipb.RemoveHostifRequest{Oid: hif.GetOid()}); err != nil {
+				t.Fatalf("RemoveHostif() unexpected err: %v", err)
+			}
+			if portExists() {
+				t.Errorf("hostif's port exists after the hostif is removed")
+			}
+
+			// The sink is told about the port, then about its removal.
+			var want []*fwdpb.PacketSinkResponse
+			if tt.programmed {
+				portID := &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(hif.GetOid())}}
+				want = []*fwdpb.PacketSinkResponse{{
+					Resp: &fwdpb.PacketSinkResponse_Port{
+						Port: &fwdpb.PacketSinkPortInfo{
+							Port: &fwdpb.PortDesc{
+								PortId:   portID,
+								PortType: fwdpb.PortType_PORT_TYPE_GENETLINK,
+								Port: &fwdpb.PortDesc_Genetlink{
+									Genetlink: &fwdpb.GenetlinkPortDesc{FamilyName: "psample", GroupName: "packets"},
+								},
+							},
+						},
+					},
+				}, {
+					Resp: &fwdpb.PacketSinkResponse_PortRemoval{
+						PortRemoval: &fwdpb.PacketSinkPortRemoval{PortId: portID},
+					},
+				}}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if d := cmp.Diff(sinkResps, want, protocmp.Transform()); d != "" {
+				t.Errorf("packet sink notifications unexpected diff (-got,+want):\n%s", d)
 			}
 		})
 	}
 }
 
+func TestFDHostif(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t)
+	fwdCtx.SetPacketSink(func(*fwdpb.PacketSinkResponse) error { return nil })
+
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	port := createTestPort(t, conn, 1)
+	hc := saipb.NewHostifClient(conn)
+	hif, err := hc.CreateHostif(ctx, &saipb.CreateHostifRequest{
+		Type:  saipb.HostifType_HOSTIF_TYPE_FD.Enum(),
+		Name:  []byte("fd1"),
+		ObjId: proto.Uint64(port),
+	})
+	if err != nil {
+		t.Fatalf("CreateHostif() unexpected err: %v", err)
+	}
+	if _, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+		TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP.Enum(),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	frames, err := s.HostifFrames(hif.GetOid())
+	if err != nil {
+		t.Fatalf("HostifFrames() unexpected err: %v", err)
+	}
+	if _, err := s.HostifFrames(port); err == nil {
+		t.Errorf("HostifFrames() of a port succeeded, want error")
+	}
+
+	frame := lldpFrame(t, 0)
+	inject := func() {
+		t.Helper()
+		err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+			fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, frame, nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	inject()
+	select {
+	case got := <-frames:
+		checkLLDP(t, packetutil.Decode(got))
+	case <-time.After(time.Second):
+		t.Fatal("HostifFrames() got no frame, want the trapped frame")
+	}
+
+	// Frames aren't delivered while the hostif is down.
+	if _, err := hc.SetHostifAttribute(ctx, &saipb.SetHostifAttributeRequest{Oid: hif.GetOid(), OperStatus: proto.Bool(false)}); err != nil {
+		t.Fatal(err)
+	}
+	inject()
+	select {
+	case got := <-frames:
+		t.Fatalf("HostifFrames() got frame %x while the hostif is down, want none", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if _, err := hc.SetHostifAttribute(ctx, &saipb.SetHostifAttributeRequest{Oid: hif.GetOid(), OperStatus: proto.Bool(true)}); err != nil {
+		t.Fatal(err)
+	}
+	inject()
+	select {
+	case <-frames:
+	case <-time.After(time.Second):
+		t.Fatal("HostifFrames() got no frame after the hostif is up, want the trapped frame")
+	}
+
+	if _, err := hc.RemoveHostif(ctx, &saipb.RemoveHostifRequest{Oid: hif.GetOid()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.HostifFrames(hif.GetOid()); grpcstatus.Code(err) != codes.NotFound {
+		t.Errorf("HostifFrames() of a removed hostif got err %v, want NotFound", err)
+	}
+}
+
+func TestListHostifStreams(t *testing.T) {
+	ctx := context.Background()
+	_, conn, _ := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	dc := diagpb.NewDiagClient(conn)
+	// waitStreams waits for the diagnostic to report the streams of the kinds, in order, all alive.
+	waitStreams := func(t *testing.T, want ...string) {
+		t.Helper()
+		var got []*diagpb.HostifStream
+		for start := time.Now(); time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+			resp, err := dc.ListHostifStreams(ctx, &diagpb.ListHostifStreamsRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = resp.GetStreams()
+			if len(got) != len(want) {
+				continue
+			}
+			matched := true
+			for i, stream := range got {
+				if stream.GetKind() != want[i] || !stream.GetAlive() {
+					matched = false
+				}
+			}
+			if matched {
+				return
+			}
+		}
+		t.Fatalf("ListHostifStreams() got %v, want alive streams of kinds %v", got, want)
+	}
+
+	pc := pktiopb.NewPacketIOClient(conn)
+	for i := 0; i < 3; i++ {
+		streamCtx, cancel := context.WithCancel(ctx)
+		cpu, err := pc.CPUPacketStream(streamCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cpu.Send(&pktiopb.PacketIn{Msg: &pktiopb.PacketIn_Init{}}); err != nil {
+			t.Fatal(err)
+		}
+		ctrl, err := pc.HostPortControl(streamCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ctrl.Send(&pktiopb.HostPortControlRequest{Msg: &pktiopb.HostPortControlRequest_Init{}}); err != nil {
+			t.Fatal(err)
+		}
+		waitStreams(t, "cpu-packet", "host-port-control")
+
+		cancel()
+		waitStreams(t)
+	}
+}
+
+func TestHostPortControlReplay(t *testing.T) {
+	c, _, stopFn := newTestHostif(t, &fakeSwitchDataplane{}, true)
+	defer stopFn()
+
+	ids := []uint64{7, 2, 12, 5, 3}
+	c.srv.remoteMu.Lock()
+	for _, id := range ids {
+		c.srv.remoteHostifs[id] = &pktiopb.HostPortControlMessage{
+			Create: true,
+			PortId: id,
+		}
+	}
+	c.srv.remoteMu.Unlock()
+	want := []uint64{2, 3, 5, 7, 12}
+
+	// Every connection replays the hostifs in ascending order.
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		pc, err := c.HostPortControl(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := pc.Send(&pktiopb.HostPortControlRequest{Msg: &pktiopb.HostPortControlRequest_Init{}}); err != nil {
+			t.Fatal(err)
+		}
+		var got []uint64
+		for range ids {
+			msg, err := pc.Recv()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, msg.GetPortId())
+			if err := pc.Send(&pktiopb.HostPortControlRequest{
+				Msg: &pktiopb.HostPortControlRequest_Status{
+					Status: &status.Status{Code: int32(codes.OK)},
+				},
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		cancel()
+		if d := cmp.Diff(got, want); d != "" {
+			t.Errorf("HostPortControl() connection %d replay order unexpected diff (-got,+want):\n%s", i, d)
+		}
+	}
+}
+
+func TestHostPortControlReconnect(t *testing.T) {
+	c, _, stopFn := newTestHostif(t, &fakeSwitchDataplane{}, true)
+	defer stopFn()
+
+	ids := []uint64{1, 2, 3}
+	c.srv.remoteMu.Lock()
+	for _, id := range ids {
+		c.srv.remoteHostifs[id] = &pktiopb.HostPortControlMessage{
+			Create: true,
+			PortId: id,
+		}
+	}
+	c.srv.remoteMu.Unlock()
+
+	// connect opens a stream, answers the replay, and returns the replayed hostifs and a channel that receives
+	// the error ending the stream, once the server closes it.
+	connect := func(t *testing.T, ctx context.Context) ([]uint64, chan error) {
+		t.Helper()
+		pc, err := c.HostPortControl(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := pc.Send(&pktiopb.HostPortControlRequest{Msg: &pktiopb.HostPortControlRequest_Init{}}); err != nil {
+			t.Fatal(err)
+		}
+		var got []uint64
+		for range ids {
+			msg, err := pc.Recv()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, msg.GetPortId())
+			if err := pc.Send(&pktiopb.HostPortControlRequest{
+				Msg: &pktiopb.HostPortControlRequest_Status{
+					Status: &status.Status{Code: int32(codes.OK)},
+				},
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// Nothing is sent after the replay.
+		errCh := make(chan error, 1)
+		go func() {
+			msg, err := pc.Recv()
+			if err == nil {
+				err = fmt.Errorf("unexpected message after replay: %v", msg)
+			}
+			errCh <- err
+		}()
+		return got, errCh
+	}
+	// waitClosers waits for the number of closers of open streams to be want.
+	waitClosers := func(t *testing.T, want int) {
+		t.Helper()
+		var got int
+		for start := time.Now(); time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+			c.srv.remoteMu.Lock()
+			got = len(c.srv.remoteClosers)
+			c.srv.remoteMu.Unlock()
+			if got == want {
+				return
+			}
+		}
+		t.Fatalf("got %d host port control closers, want %d", got, want)
+	}
+
+	ctx := context.Background()
+	firstCtx, cancelFirst := context.WithCancel(ctx)
+	defer cancelFirst()
+	got, firstErr := connect(t, firstCtx)
+	if d := cmp.Diff(got, ids); d != "" {
+		t.Errorf("HostPortControl() first connection replay unexpected diff (-got,+want):\n%s", d)
+	}
+	waitClosers(t, 1)
+
+	// The agent reconnects before the server notices the first stream dropped: the first stream is closed.
+	secondCtx, cancelSecond := context.WithCancel(ctx)
+	got, secondErr := connect(t, secondCtx)
+	if d := cmp.Diff(got, ids); d != "" {
+		t.Errorf("HostPortControl() reconnection replay unexpected diff (-got,+want):\n%s", d)
+	}
+	select {
+	case err := <-firstErr:
+		if err != io.EOF {
+			t.Errorf("first stream got err %v after reconnection, want it closed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("first stream not closed after reconnection")
+	}
+	waitClosers(t, 1)
+
+	// The stream drops, then the agent reconnects.
+	cancelSecond()
+	<-secondErr
+	waitClosers(t, 0)
+	c.srv.remoteMu.Lock()
+	_, err := c.srv.sendRemotePortReq(ctx, &pktiopb.HostPortControlMessage{Create: true, PortId: 4})
+	c.srv.remoteMu.Unlock()
+	if grpcstatus.Code(err) != codes.FailedPrecondition {
+		t.Errorf("sendRemotePortReq() after the stream dropped got err %v, want FailedPrecondition", err)
+	}
+	thirdCtx, cancelThird := context.WithCancel(ctx)
+	defer cancelThird()
+	got, thirdErr := connect(t, thirdCtx)
+	if d := cmp.Diff(got, ids); d != "" {
+		t.Errorf("HostPortControl() reconnection after drop replay unexpected diff (-got,+want):\n%s", d)
+	}
+	select {
+	case err := <-thirdErr:
+		t.Errorf("stream closed after replay: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	waitClosers(t, 1)
+}
+
+func TestHostPortControlReplayBatch(t *testing.T) {
+	c, _, stopFn := newTestHostif(t, &fakeSwitchDataplane{}, true)
+	defer stopFn()
+	const batch = 16
+	c.srv.opts.RemotePortReplayBatch = batch
+
+	const hostifs = 5000
+	c.srv.remoteMu.Lock()
+	for id := uint64(1); id <= hostifs; id++ {
+		c.srv.remoteHostifs[id] = &pktiopb.HostPortControlMessage{
+			Create: true,
+			PortId: id,
+		}
+	}
+	c.srv.remoteMu.Unlock()
+
+	// Every connection replays all hostifs in order, with at most a batch of requests in flight.
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		pc, err := c.HostPortControl(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := pc.Send(&pktiopb.HostPortControlRequest{Msg: &pktiopb.HostPortControlRequest_Init{}}); err != nil {
+			t.Fatal(err)
+		}
+		msgCh := make(chan *pktiopb.HostPortControlMessage, hostifs)
+		go func() {
+			defer close(msgCh)
+			for {
+				msg, err := pc.Recv()
+				if err != nil {
+					return
+				}
+				msgCh <- msg
+			}
+		}()
+		reply := func() {
+			if err := pc.Send(&pktiopb.HostPortControlRequest{
+				Msg: &pktiopb.HostPortControlRequest_Status{
+					Status: &status.Status{Code: int32(codes.OK)},
+				},
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		var got []uint64
+		recv := func() {
+			msg, ok := <-msgCh
+			if !ok {
+				t.Fatalf("HostPortControl() connection %d closed after replaying %d hostifs", i, len(got))
+			}
+			got = append(got, msg.GetPortId())
+		}
+
+		// The agent holds its replies to the first batch, the switch must wait for them.
+		for j := 0; j < batch; j++ {
+			recv()
+		}
+		time.Sleep(50 * time.Millisecond)
+		if n := len(msgCh); n != 0 {
+			t.Fatalf("HostPortControl() connection %d sent %d requests beyond the first batch before it was answered", i, n)
+		}
+		for j := 0; j < batch; j++ {
+			reply()
+		}
+		for len(got) < hostifs {
+			recv()
+			reply()
+		}
+		cancel()
+
+		for j, id := range got {
+			if id != uint64(j+1) {
+				t.Fatalf("HostPortControl() connection %d replayed hostif %d at position %d, want %d", i, id, j, j+1)
+			}
+		}
+	}
+}
+
+func TestCreateRemoteHostifRetry(t *testing.T) {
+	tests := []struct {
+		desc     string
+		failures int
+		backoff  time.Duration
+		// maxAttempts is the number of attempts allowed, the backoff may end the retries before opts.RemotePortRetries.
+		maxAttempts int
+		wantErr     string
+	}{{
+		desc:        "transient failure",
+		failures:    1,
+		backoff:     time.Millisecond,
+		maxAttempts: 3,
+	}, {
+		desc:        "retries exhausted",
+		failures:    3,
+		backoff:     time.Millisecond,
+		maxAttempts: 3,
+		wantErr:     "agent unavailable",
+	}, {
+		desc:        "backoff exceeds timeout",
+		failures:    3,
+		backoff:     time.Hour,
+		maxAttempts: 1,
+		wantErr:     "agent unavailable",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c, _, stopFn := newTestHostif(t, &fakeSwitchDataplane{}, true)
+			defer stopFn()
+			c.srv.opts.RemotePortRetries = 2
+			c.srv.opts.RemotePortRetryBackoff = tt.backoff
+			c.srv.opts.RemotePortTimeout = time.Second
+			c.srv.initSwitch(switchID, 10)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			pc, err := c.HostPortControl(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := pc.Send(&pktiopb.HostPortControlRequest{Msg: &pktiopb.HostPortControlRequest_Init{}}); err != nil {
+				t.Fatal(err)
+			}
+			time.Sleep(time.Millisecond)
+			// The remote agent fails the first requests, then accepts them.
+			msgCh := make(chan *pktiopb.HostPortControlMessage, 10)
+			go func() {
+				for i := 0; ; i++ {
+					msg, err := pc.Recv()
+					if err != nil {
+						return
+					}
+					msgCh <- msg
+					st := &status.Status{Code: int32(codes.OK)}
+					if i < tt.failures {
+						st = &status.Status{Code: int32(codes.Unavailable), Message: "agent unavailable"}
+					}
+					pc.Send(&pktiopb.HostPortControlRequest{Msg: &pktiopb.HostPortControlRequest_Status{Status: st}})
+				}
+			}()
+
+			_, gotErr := c.CreateHostif(ctx, &saipb.CreateHostifRequest{
+				Type:               saipb.HostifType_HOSTIF_TYPE_GENETLINK.Enum(),
+				ObjId:              proto.Uint64(10),
+				Name:               []byte("psample"),
+				GenetlinkMcgrpName: []byte("packets"),
+			})
+			if diff := errdiff.Check(gotErr, tt.wantErr); diff != "" {
+				t.Fatalf("CreateHostif() unexpected err: %s", diff)
+			}
+			wantAttempts := min(tt.failures+1, tt.maxAttempts)
+			if got := len(msgCh); got != wantAttempts {
+				t.Errorf("CreateHostif() sent %d port control requests, want %d", got, wantAttempts)
+			}
+			if _, ok := c.srv.remoteHostifs[1]; ok != (tt.wantErr == "") {
+				t.Errorf("CreateHostif() hostif recorded for replay %v, want %v", ok, tt.wantErr == "")
+			}
+		})
+	}
+}
+
+// fakePortControlMetrics records the host port control requests reported to it.
+type fakePortControlMetrics struct {
+	mu        sync.Mutex
+	msgs      []*pktiopb.HostPortControlMessage
+	errs      []error
+	latencies []time.Duration
+}
+
+func (m *fakePortControlMetrics) RequestDone(msg *pktiopb.HostPortControlMessage, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.msgs = append(m.msgs, msg)
+	m.errs = append(m.errs, err)
+	m.latencies = append(m.latencies, latency)
+}
+
+func TestHostPortControlMetrics(t *testing.T) {
+	c, _, stopFn := newTestHostif(t, &fakeSwitchDataplane{}, true)
+	defer stopFn()
+	metrics := &fakePortControlMetrics{}
+	c.srv.opts.HostPortControlMetrics = metrics
+	c.srv.opts.RemotePortRetries = 1
+	c.srv.opts.RemotePortRetryBackoff = time.Millisecond
+	c.srv.initSwitch(switchID, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pc, err := c.HostPortControl(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pc.Send(&pktiopb.HostPortControlRequest{Msg: &pktiopb.HostPortControlRequest_Init{}}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	// The remote agent takes a while to reply, and fails the second request once.
+	const delay = 5 * time.Millisecond
+	go func() {
+		for i := 0; ; i++ {
+			if _, err := pc.Recv(); err != nil {
+				return
+			}
+			time.Sleep(delay)
+			st := &status.Status{Code: int32(codes.OK)}
+			if i == 1 {
+				st = &status.Status{Code: int32(codes.Unavailable), Message: "agent unavailable"}
+			}
+			pc.Send(&pktiopb.HostPortControlRequest{Msg: &pktiopb.HostPortControlRequest_Status{Status: st}})
+		}
+	}()
+
+	var oids []uint64
+	for _, name := range []string{"psample", "dropmon"} {
+		resp, err := c.CreateHostif(ctx, &saipb.CreateHostifRequest{
+			Type:               saipb.HostifType_HOSTIF_TYPE_GENETLINK.Enum(),
+			ObjId:              proto.Uint64(10),
+			Name:               []byte(name),
+			GenetlinkMcgrpName: []byte("packets"),
+		})
+		if err != nil {
+			t.Fatalf("CreateHostif() unexpected err: %v", err)
+		}
+		oids = append(oids, resp.GetOid())
+	}
+	if _, err := c.RemoveHostif(ctx, &saipb.RemoveHostifRequest{Oid: oids[0]}); err != nil {
+		t.Fatalf("RemoveHostif() unexpected err: %v", err)
+	}
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	// The failed request is retried, so it is reported twice.
+	wantIDs := []uint64{oids[0], oids[1], oids[1], oids[0]}
+	var gotIDs []uint64
+	for _, msg := range metrics.msgs {
+		gotIDs = append(gotIDs, msg.GetPortId())
+	}
+	if d := cmp.Diff(gotIDs, wantIDs); d != "" {
+		t.Errorf("RequestDone() port ids unexpected diff (-got,+want):\n%s", d)
+	}
+	for i, err := range metrics.errs {
+		if wantErr := i == 1; (err != nil) != wantErr {
+			t.Errorf("RequestDone() request %d got err %v, want err %v", i, err, wantErr)
+		}
+	}
+	if got, want := grpcstatus.Code(metrics.errs[1]), codes.Unavailable; got != want {
+		t.Errorf("RequestDone() failed request got code %v, want %v", got, want)
+	}
+	for i, latency := range metrics.latencies {
+		if latency < delay {
+			t.Errorf("RequestDone() request %d got latency %v, want at least %v", i, latency, delay)
+		}
+	}
+}
+
+func TestCreateRemoteHostifTimeout(t *testing.T) {
+	c, _, stopFn := newTestHostif(t, &fakeSwitchDataplane{}, true)
+	defer stopFn()
+	const timeout = 100 * time.Millisecond
+	c.srv.opts.RemotePortTimeout = timeout
+	c.srv.initSwitch(switchID, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pc, err := c.HostPortControl(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pc.Send(&pktiopb.HostPortControlRequest{Msg: &pktiopb.HostPortControlRequest_Init{}}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	// The remote agent receives the requests but never replies.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, err := pc.Recv(); err != nil {
+				return
+			}
+		}
+	}()
+
+	req := &saipb.CreateHostifRequest{
+		Type:               saipb.HostifType_HOSTIF_TYPE_GENETLINK.Enum(),
+		ObjId:              proto.Uint64(10),
+		Name:               []byte("psample"),
+		GenetlinkMcgrpName: []byte("packets"),
+	}
+	start := time.Now()
+	_, err = c.CreateHostif(ctx, req)
+	if got := grpcstatus.Code(err); got != codes.DeadlineExceeded {
+		t.Fatalf("CreateHostif() got err %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*timeout {
+		t.Errorf("CreateHostif() failed after %v, want about %v", elapsed, timeout)
+	}
+	// The stream is torn down, and the hostif isn't recorded for replay.
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("HostPortControl() stream not closed after the agent timed out")
+	}
+	if _, ok := c.srv.remoteHostifs[1]; ok {
+		t.Error("CreateHostif() hostif recorded for replay after timing out")
+	}
+
+	// remoteMu is released, later requests fail without waiting.
+	start = time.Now()
+	if _, err := c.CreateHostif(ctx, req); err == nil {
+		t.Error("CreateHostif() without a remote agent unexpectedly succeeded")
+	}
+	if elapsed := time.Since(start); elapsed > timeout {
+		t.Errorf("CreateHostif() without a remote agent took %v, want less than %v", elapsed, timeout)
+	}
+}
+
+func TestGenetlinkHostifIDs(t *testing.T) {
+	c, _, stopFn := newTestHostif(t, &fakeSwitchDataplane{}, true)
+	defer stopFn()
+	c.srv.initSwitch(switchID, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pc, err := c.HostPortControl(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pc.Send(&pktiopb.HostPortControlRequest{Msg: &pktiopb.HostPortControlRequest_Init{}}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	// The remote agent reports the IDs it resolved for genetlink ports.
+	wantIDs := &pktiopb.GenetlinkPortIds{FamilyId: 30, GroupId: 7}
+	go func() {
+		for {
+			msg, err := pc.Recv()
+			if err != nil {
+				return
+			}
+			resp := &pktiopb.HostPortControlRequest{Msg: &pktiopb.HostPortControlRequest_Status{Status: &status.Status{Code: int32(codes.OK)}}}
+			if msg.GetGenetlink() != nil {
+				resp.GenetlinkIds = wantIDs
+			}
+			pc.Send(resp)
+		}
+	}()
+
+	genl, err := c.CreateHostif(ctx, &saipb.CreateHostifRequest{
+		Type:               saipb.HostifType_HOSTIF_TYPE_GENETLINK.Enum(),
+		ObjId:              proto.Uint64(10),
+		Name:               []byte("psample"),
+		GenetlinkMcgrpName: []byte("packets"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.GetGenetlinkHostifIds(ctx, &diagpb.GetGenetlinkHostifIdsRequest{Oid: genl.GetOid()})
+	if err != nil {
+		t.Fatalf("GetGenetlinkHostifIds() unexpected error: %v", err)
+	}
+	if d := cmp.Diff(got.GetIds(), wantIDs, protocmp.Transform()); d != "" {
+		t.Errorf("GetGenetlinkHostifIds() unexpected diff (-got,+want):\n%s", d)
+	}
+
+	netdev, err := c.CreateHostif(ctx, &saipb.CreateHostifRequest{
+		Type:  saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+		ObjId: proto.Uint64(10),
+		Name:  []byte("eth1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetGenetlinkHostifIds(ctx, &diagpb.GetGenetlinkHostifIdsRequest{Oid: netdev.GetOid()}); grpcstatus.Code(err) != codes.NotFound {
+		t.Errorf("GetGenetlinkHostifIds() of netdev hostif got err %v, want NotFound", err)
+	}
+}
+
+func TestCPUPacketStreamTeardown(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	swAttr, err := saipb.NewSwitchClient(conn).GetSwitchAttribute(ctx, &saipb.GetSwitchAttributeRequest{
+		Oid:      sw.GetOid(),
+		AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_CPU_PORT},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpuPortID := &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(swAttr.GetAttr().GetCpuPort())}}
+	nid, err := s.ObjectNID(ctx, &fwdpb.ObjectNIDRequest{
+		ContextId: &fwdpb.ContextId{Id: s.ID()},
+		ObjectId:  cpuPortID.GetObjectId(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acts := []*fwdpb.ActionDesc{
+		fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT).WithUint64Value(nid.GetNid())).Build(),
+		fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_HOST_PORT_ID).WithUint64Value(1)).Build(),
+	}
+
+	// Punt packets concurrently for the whole test.
+	injectCtx, stopInject := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for injectCtx.Err() == nil {
+				s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, cpuPortID, fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET,
+					make([]byte, 64), acts, false, fwdpb.PortAction_PORT_ACTION_OUTPUT)
+			}
+		}()
+	}
+	defer func() {
+		stopInject()
+		wg.Wait()
+	}()
+
+	pc := pktiopb.NewPacketIOClient(conn)
+	for i := 0; i < 5; i++ {
+		streamCtx, cancel := context.WithCancel(ctx)
+		stream, err := pc.CPUPacketStream(streamCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := stream.Send(&pktiopb.PacketIn{Msg: &pktiopb.PacketIn_Init{}}); err != nil {
+			t.Fatal(err)
+		}
+		for j := 0; j < 10; j++ {
+			if _, err := stream.Recv(); err != nil {
+				t.Fatalf("stream %d: Recv() unexpected err: %v", i, err)
+			}
+		}
+		// Close the stream while packets are being punted.
+		cancel()
+		start := time.Now()
+		for {
+			fwdCtx.RLock()
+			sink := fwdCtx.CPUPortSink()
+			fwdCtx.RUnlock()
+			if sink == nil {
+				break
+			}
+			if time.Since(start) > time.Second {
+				t.Fatalf("stream %d: CPU port sink not cleared after the stream closed", i)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+func TestCPUPacketStreamFanOut(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	swAttr, err := saipb.NewSwitchClient(conn).GetSwitchAttribute(ctx, &saipb.GetSwitchAttributeRequest{
+		Oid:      sw.GetOid(),
+		AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_CPU_PORT},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpuPortID := &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(swAttr.GetAttr().GetCpuPort())}}
+	nid, err := s.ObjectNID(ctx, &fwdpb.ObjectNIDRequest{
+		ContextId: &fwdpb.ContextId{Id: s.ID()},
+		ObjectId:  cpuPortID.GetObjectId(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	punt := func(frame []byte) {
+		t.Helper()
+		acts := []*fwdpb.ActionDesc{
+			fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT).WithUint64Value(nid.GetNid())).Build(),
+			fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_HOST_PORT_ID).WithUint64Value(1)).Build(),
+		}
+		if err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, cpuPortID, fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET,
+			frame, acts, false, fwdpb.PortAction_PORT_ACTION_OUTPUT); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// waitSinks waits for the number of registered CPU packet stream sinks.
+	waitSinks := func(want int) {
+		t.Helper()
+		for start := time.Now(); time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+			s.saiSwitch.hostif.cpuSinksMu.Lock()
+			got := len(s.saiSwitch.hostif.cpuSinks)
+			s.saiSwitch.hostif.cpuSinksMu.Unlock()
+			if got == want {
+				return
+			}
+		}
+		t.Fatalf("CPU packet stream sinks not %d after a second", want)
+	}
+
+	pc := pktiopb.NewPacketIOClient(conn)
+	var streams []pktiopb.PacketIO_CPUPacketStreamClient
+	var cancels []context.CancelFunc
+	for i := 0; i < 2; i++ {
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream, err := pc.CPUPacketStream(streamCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := stream.Send(&pktiopb.PacketIn{Msg: &pktiopb.PacketIn_Init{}}); err != nil {
+			t.Fatal(err)
+		}
+		streams = append(streams, stream)
+		cancels = append(cancels, cancel)
+	}
+	waitSinks(2)
+
+	want := []byte{0x01, 0x02, 0x03}
+	punt(append(want, make([]byte, 61)...))
+	for i, stream := range streams {
+		got, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("stream %d: Recv() unexpected err: %v", i, err)
+		}
+		if !bytes.HasPrefix(got.GetPacket().GetFrame(), want) {
+			t.Errorf("stream %d: Recv() got frame %x, want prefix %x", i, got.GetPacket().GetFrame(), want)
+		}
+	}
+
+	// Closing a stream doesn't stop the delivery to the other one.
+	cancels[0]()
+	waitSinks(1)
+	want = []byte{0x04, 0x05, 0x06}
+	punt(append(want, make([]byte, 61)...))
+	got, err := streams[1].Recv()
+	if err != nil {
+		t.Fatalf("Recv() unexpected err after closing the other stream: %v", err)
+	}
+	if !bytes.HasPrefix(got.GetPacket().GetFrame(), want) {
+		t.Errorf("Recv() got frame %x after closing the other stream, want prefix %x", got.GetPacket().GetFrame(), want)
+	}
+
+	cancels[1]()
+	waitSinks(0)
+	fwdCtx.RLock()
+	sink := fwdCtx.CPUPortSink()
+	fwdCtx.RUnlock()
+	if sink != nil {
+		t.Errorf("CPU port sink not cleared after all streams closed")
+	}
+}
+
+// blockingInjectDataplane holds packets injected into the dataplane until release is closed.
+type blockingInjectDataplane struct {
+	switchDataplaneAPI
+	injected chan []byte
+	release  chan struct{}
+}
+
+func (d *blockingInjectDataplane) InjectPacket(_ *fwdpb.ContextId, _ *fwdpb.PortId, _ fwdpb.PacketHeaderId, frame []byte, _ []*fwdpb.ActionDesc, _ bool, _ fwdpb.PortAction) error {
+	d.injected <- frame
+	<-d.release
+	return nil
+}
+
+func TestCPUPacketStreamQueue(t *testing.T) {
+	ctx := context.Background()
+	const depth = 4
+	s, conn, _ := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true), dplaneopts.WithCPUPacketQueueDepth(depth))
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	dplane := &blockingInjectDataplane{
+		switchDataplaneAPI: s.saiSwitch.hostif.dataplane,
+		injected:           make(chan []byte, 2*depth),
+		release:            make(chan struct{}),
+	}
+	s.saiSwitch.hostif.dataplane = dplane
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := pktiopb.NewPacketIOClient(conn).CPUPacketStream(streamCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&pktiopb.PacketIn{Msg: &pktiopb.PacketIn_Init{}}); err != nil {
+		t.Fatal(err)
+	}
+	send := func(seq byte) {
+		t.Helper()
+		if err := stream.Send(&pktiopb.PacketIn{Msg: &pktiopb.PacketIn_Packet{Packet: &pktiopb.Packet{HostPort: 1, Frame: []byte{seq}}}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dc := diagpb.NewDiagClient(conn)
+	// cpuStream returns the CPU packet stream, or nil if it isn't listed.
+	cpuStream := func() *diagpb.HostifStream {
+		t.Helper()
+		resp, err := dc.ListHostifStreams(ctx, &diagpb.ListHostifStreamsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, stream := range resp.GetStreams() {
+			if stream.GetKind() == cpuPacketStreamKind {
+				return stream
+			}
+		}
+		return nil
+	}
+
+	// The first packet blocks the injection, the next ones fill the queue and the newest are dropped.
+	send(0)
+	select {
+	case <-dplane.injected:
+	case <-time.After(time.Second):
+		t.Fatal("first packet not injected")
+	}
+	const extra = 3
+	for seq := byte(1); seq <= depth+extra; seq++ {
+		send(seq)
+	}
+	var dropped uint64
+	for start := time.Now(); time.Since(start) < time.Second && dropped != extra; time.Sleep(time.Millisecond) {
+		dropped = cpuStream().GetDropped()
+	}
+	if dropped != extra {
+		t.Fatalf("ListHostifStreams() got %d dropped packets, want %d", dropped, extra)
+	}
+
+	close(dplane.release)
+	var got []byte
+	for len(got) < depth {
+		select {
+		case frame := <-dplane.injected:
+			got = append(got, frame[0])
+		case <-time.After(time.Second):
+			t.Fatalf("got injected packets %v, want %d queued packets", got, depth)
+		}
+	}
+	if d := cmp.Diff(got, []byte{1, 2, 3, 4}); d != "" {
+		t.Errorf("injected packets unexpected diff, want the oldest packets kept (-got,+want):\n%s", d)
+	}
+	select {
+	case frame := <-dplane.injected:
+		t.Errorf("dropped packet %d injected", frame[0])
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Once the client cancels the stream, the Recv goroutine exits and the stream is no longer listed.
+	cancel()
+	for start := time.Now(); cpuStream() != nil; time.Sleep(time.Millisecond) {
+		if time.Since(start) > time.Second {
+			t.Fatal("CPU packet stream still listed after it was canceled")
+		}
+	}
+}
+
+func TestSetHostifAttribute(t *testing.T) {
+	tests := []struct {
+		desc            string
+		req             *saipb.SetHostifAttributeRequest
+		getInterfaceErr error
+		wantAttr        *saipb.HostifAttribute
+		wantReq         *fwdpb.PortStateRequest
+		wantErr         string
+	}{{
+		desc: "oper status",
+		req: &saipb.SetHostifAttributeRequest{
+			Oid:        1,
+			OperStatus: proto.Bool(false),
+		},
+		wantReq: &fwdpb.PortStateRequest{
+			PortId:    &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: "1"}},
+			Operation: &fwdpb.PortInfo{AdminStatus: fwdpb.PortState_PORT_STATE_DISABLED_DOWN},
+			ContextId: &fwdpb.ContextId{Id: "foo"},
+		},
+		wantAttr: &saipb.HostifAttribute{
+			OperStatus: proto.Bool(false),
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			getInterface = func(string) (*net.Interface, error) {
+				return nil, tt.getInterfaceErr
+			}
+			dplane := &fakeSwitchDataplane{}
+			c, mgr, stopFn := newTestHostif(t, dplane, false)
+			defer stopFn()
+			_, gotErr := c.SetHostifAttribute(context.TODO(), tt.req)
+			if diff := errdiff.Check(gotErr, tt.wantErr); diff != "" {
+				t.Fatalf("SetHostifAttribute() unexpected err: %s", diff)
+			}
+			if gotErr != nil {
+				return
+			}
+			if d := cmp.Diff(dplane.gotPortStateReq[0], tt.wantReq, protocmp.Transform()); d != "" {
+				t.Errorf("SetHostifAttribute() failed: diff(-got,+want)\n:%s", d)
+			}
+			attr := &saipb.HostifAttribute{}
+			if err := mgr.PopulateAllAttributes("1", attr); err != nil {
+				t.Fatal(err)
+			}
+			if d := cmp.Diff(attr, tt.wantAttr, protocmp.Transform()); d != "" {
+				t.Errorf("SetHostifAttribute() failed: diff(-got,+want)\n:%s", d)
+			}
+		})
+	}
+}
+
+func TestGetHostifAttribute(t *testing.T) {
+	attrTypes := []saipb.HostifAttr{
+		saipb.HostifAttr_HOSTIF_ATTR_OPER_STATUS,
+		saipb.HostifAttr_HOSTIF_ATTR_TYPE,
+		saipb.HostifAttr_HOSTIF_ATTR_OBJ_ID,
+		saipb.HostifAttr_HOSTIF_ATTR_NAME,
+	}
+	ctx := context.Background()
+
+	t.Run("netdev", func(t *testing.T) {
+		dplane := &fakeSwitchDataplane{
+			ctx: fwdcontext.New("foo", "foo"),
+		}
+		c, mgr, stopFn := newTestHostif(t, dplane, false)
+		defer stopFn()
+		mgr.StoreAttributes(mgr.NextID(), &saipb.SwitchAttribute{
+			CpuPort: proto.Uint64(10),
+		})
+		mgr.StoreAttributes(mgr.NextID(), &saipb.PortAttribute{
+			OperStatus: saipb.PortOperStatus_PORT_OPER_STATUS_UP.Enum(),
+		})
+		c.srv.initSwitch(switchID, 10)
+
+		hif, err := c.CreateHostif(ctx, &saipb.CreateHostifRequest{
+			Type:  saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+			ObjId: proto.Uint64(2),
+			Name:  []byte("Ethernet1"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := c.GetHostifAttribute(ctx, &saipb.GetHostifAttributeRequest{Oid: hif.GetOid(), AttrType: attrTypes})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &saipb.HostifAttribute{
+			OperStatus: proto.Bool(true),
+			Type:       saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+			ObjId:      proto.Uint64(2),
+			Name:       []byte("Ethernet1"),
+		}
+		if d := cmp.Diff(got.GetAttr(), want, protocmp.Transform()); d != "" {
+			t.Errorf("GetHostifAttribute() failed: diff(-got,+want)\n:%s", d)
+		}
+	})
+
+	t.Run("remote", func(t *testing.T) {
+		c, mgr, stopFn := newTestHostif(t, &fakeSwitchDataplane{}, true)
+		defer stopFn()
+		// Only the oper status is stored, the rest is recovered from the host port control message.
+		mgr.StoreAttributes(5, &saipb.HostifAttribute{OperStatus: proto.Bool(true)})
+		c.srv.remoteHostifs[5] = &pktiopb.HostPortControlMessage{
+			Create:        true,
+			DataplanePort: 2,
+			PortId:        5,
+			Port: &pktiopb.HostPortControlMessage_Netdev{
+				Netdev: &pktiopb.NetdevPort{Name: "Ethernet2"},
+			},
+		}
+		got, err := c.GetHostifAttribute(ctx, &saipb.GetHostifAttributeRequest{Oid: 5, AttrType: attrTypes})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &saipb.HostifAttribute{
+			OperStatus: proto.Bool(true),
+			Type:       saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+			ObjId:      proto.Uint64(2),
+			Name:       []byte("Ethernet2"),
+		}
+		if d := cmp.Diff(got.GetAttr(), want, protocmp.Transform()); d != "" {
+			t.Errorf("GetHostifAttribute() failed: diff(-got,+want)\n:%s", d)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		c, _, stopFn := newTestHostif(t, &fakeSwitchDataplane{}, false)
+		defer stopFn()
+		_, err := c.GetHostifAttribute(ctx, &saipb.GetHostifAttributeRequest{Oid: 5, AttrType: attrTypes})
+		if got, want := grpcstatus.Code(err), codes.NotFound; got != want {
+			t.Errorf("GetHostifAttribute() of unknown hostif got code %v, want %v", got, want)
+		}
+	})
+}
+
+func TestCPUPortPrecondition(t *testing.T) {
+	hostifReq := &saipb.CreateHostifRequest{
+		Type:  saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+		ObjId: proto.Uint64(10),
+	}
+	trapReq := &saipb.CreateHostifTrapRequest{
+		TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP.Enum(),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+	}
+	tests := []struct {
+		desc     string
+		init     bool
+		trapLast bool
+		wantErr  string
+	}{{
+		desc:    "not initialized",
+		wantErr: "cpu port not initialized",
+	}, {
+		desc: "hostif then trap",
+		init: true,
+	}, {
+		desc:     "trap then hostif",
+		init:     true,
+		trapLast: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dplane := &fakeSwitchDataplane{
+				ctx: fwdcontext.New("foo", "foo"),
+			}
+			c, mgr, stopFn := newTestHostif(t, dplane, false)
+			defer stopFn()
+			mgr.StoreAttributes(10, &saipb.PortAttribute{
+				OperStatus: saipb.PortOperStatus_PORT_OPER_STATUS_UP.Enum(),
+			})
+			if tt.init {
+				c.srv.initSwitch(switchID, 10)
+			}
+			createHostif := func() error {
+				_, err := c.CreateHostif(context.TODO(), hostifReq)
+				return err
+			}
+			createTrap := func() error {
+				_, err := c.CreateHostifTrap(context.TODO(), trapReq)
+				return err
+			}
+			calls := []func() error{createHostif, createTrap}
+			if tt.trapLast {
+				calls = []func() error{createTrap, createHostif}
+			}
+			for _, call := range calls {
+				if diff := errdiff.Check(call(), tt.wantErr); diff != "" {
+					t.Fatalf("create unexpected err: %s", diff)
+				}
+			}
+			if tt.wantErr != "" {
+				if len(dplane.gotEntryAddReqs) != 0 || len(dplane.gotPortCreateReqs) != 0 {
+					t.Fatalf("dataplane modified before cpu port initialized")
+				}
+				return
+			}
+			if len(dplane.gotEntryAddReqs) != 1 {
+				t.Fatalf("CreateHostifTrap() got %d entry add requests, want 1", len(dplane.gotEntryAddReqs))
+			}
+			want := fwdconfig.Action(fwdconfig.TransmitAction("10").WithImmediate(true)).Build()
+			acts := dplane.gotEntryAddReqs[0].GetEntries()[0].GetActions()
+			if d := cmp.Diff(acts[len(acts)-1], want, protocmp.Transform()); d != "" {
+				t.Errorf("CreateHostifTrap() failed: diff(-got,+want)\n:%s", d)
+			}
+		})
+	}
+}
+
+func TestCreateHostifTrapRedirect(t *testing.T) {
+	tests := []struct {
+		desc     string
+		req      *saipb.CreateHostifTrapRequest
+		wantPort string
+		wantErr  string
+	}{{
+		desc: "not redirected",
+		req: &saipb.CreateHostifTrapRequest{
+			TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LACP.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+		},
+		wantPort: "10",
+	}, {
+		desc: "redirected",
+		req: &saipb.CreateHostifTrapRequest{
+			TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+			RedirectPort: proto.Uint64(5),
+		},
+		wantPort: "5",
+	}, {
+		desc: "redirect to non-port",
+		req: &saipb.CreateHostifTrapRequest{
+			TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+			RedirectPort: proto.Uint64(6),
+		},
+		wantErr: "not a port",
+	}, {
+		desc: "redirect IP2ME",
+		req: &saipb.CreateHostifTrapRequest{
+			TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IP2ME.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+			RedirectPort: proto.Uint64(5),
+		},
+		wantErr: "can't be redirected",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dplane := &fakeSwitchDataplane{}
+			c, mgr, stopFn := newTestHostif(t, dplane, false)
+			defer stopFn()
+			c.srv.initSwitch(switchID, 10)
+			mgr.SetType("5", saipb.ObjectType_OBJECT_TYPE_PORT)
+			mgr.SetType("6", saipb.ObjectType_OBJECT_TYPE_VLAN)
+			_, err := c.CreateHostifTrap(context.TODO(), tt.req)
+			if diff := errdiff.Check(err, tt.wantErr); diff != "" {
+				t.Fatalf("CreateHostifTrap() unexpected err: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			want := fwdconfig.Action(fwdconfig.TransmitAction(tt.wantPort).WithImmediate(true)).Build()
+			acts := dplane.gotEntryAddReqs[0].GetEntries()[0].GetActions()
+			if d := cmp.Diff(acts[len(acts)-1], want, protocmp.Transform()); d != "" {
+				t.Errorf("CreateHostifTrap() failed: diff(-got,+want)\n:%s", d)
+			}
+		})
+	}
+}
+
+func TestSetHostifTrapRedirect(t *testing.T) {
+	dplane := &fakeSwitchDataplane{}
+	c, mgr, stopFn := newTestHostif(t, dplane, false)
+	defer stopFn()
+	c.srv.initSwitch(switchID, 10)
+	mgr.SetType("5", saipb.ObjectType_OBJECT_TYPE_PORT)
+	mgr.SetType("6", saipb.ObjectType_OBJECT_TYPE_PORT)
+	mgr.SetType("7", saipb.ObjectType_OBJECT_TYPE_VLAN)
+	ctx := context.Background()
+	resp, err := c.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+		TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP.Enum(),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+		RedirectPort: proto.Uint64(5),
+	})
+	if err != nil {
+		t.Fatalf("CreateHostifTrap() unexpected err: %v", err)
+	}
+	if err := c.srv.excludeTrapSource(ctx, resp.GetOid(), &saipb.IpPrefix{Addr: []byte{192, 0, 2, 0}, Mask: []byte{255, 255, 255, 0}}, saipb.PacketAction_PACKET_ACTION_FORWARD); err != nil {
+		t.Fatalf("excludeTrapSource() unexpected err: %v", err)
+	}
+
+	tests := []struct {
+		desc     string
+		req      *saipb.SetHostifTrapAttributeRequest
+		wantPort string
+		wantErr  string
+	}{{
+		desc:     "change port",
+		req:      &saipb.SetHostifTrapAttributeRequest{RedirectPort: proto.Uint64(6)},
+		wantPort: "6",
+	}, {
+		desc:    "non-port",
+		req:     &saipb.SetHostifTrapAttributeRequest{RedirectPort: proto.Uint64(7)},
+		wantErr: "not a port",
+	}, {
+		desc:    "other attribute",
+		req:     &saipb.SetHostifTrapAttributeRequest{TrapPriority: proto.Uint32(1)},
+		wantErr: "only the redirect port",
+	}, {
+		desc:     "remove redirect",
+		req:      &saipb.SetHostifTrapAttributeRequest{RedirectPort: proto.Uint64(0)},
+		wantPort: "10",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dplane.gotEntryAddReqs = nil
+			tt.req.Oid = resp.GetOid()
+			_, err := c.SetHostifTrapAttribute(ctx, tt.req)
+			if diff := errdiff.Check(err, tt.wantErr); diff != "" {
+				t.Fatalf("SetHostifTrapAttribute() unexpected err: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			// Only the trap's entry is reprogrammed, the exclusion keeps forwarding packets.
+			if got := len(dplane.gotEntryAddReqs); got != 1 {
+				t.Fatalf("SetHostifTrapAttribute() got %d entry add requests, want 1", got)
+			}
+			entries := dplane.gotEntryAddReqs[0].GetEntries()
+			if len(entries) != 1 {
+				t.Fatalf("SetHostifTrapAttribute() reprogrammed %d entries, want 1", len(entries))
+			}
+			want := fwdconfig.Action(fwdconfig.TransmitAction(tt.wantPort).WithImmediate(true)).Build()
+			acts := entries[0].GetActions()
+			if d := cmp.Diff(acts[len(acts)-1], want, protocmp.Transform()); d != "" {
+				t.Errorf("SetHostifTrapAttribute() failed: diff(-got,+want)\n:%s", d)
+			}
+			attr, err := c.GetHostifTrapAttribute(ctx, &saipb.GetHostifTrapAttributeRequest{
+				Oid:      resp.GetOid(),
+				AttrType: []saipb.HostifTrapAttr{saipb.HostifTrapAttr_HOSTIF_TRAP_ATTR_REDIRECT_PORT},
+			})
+			if err != nil {
+				t.Fatalf("GetHostifTrapAttribute() unexpected err: %v", err)
+			}
+			if got, want := attr.GetAttr().GetRedirectPort(), tt.req.GetRedirectPort(); got != want {
+				t.Errorf("GetHostifTrapAttribute() got redirect port %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestCreateHostifTrapEntries(t *testing.T) {
+	dhcpEntry := func(ipVersion byte, port uint16) *fwdpb.EntryDesc {
+		ed := fwdconfig.EntryDesc(fwdconfig.FlowEntry(
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_VERSION).WithBytes([]byte{ipVersion}, []byte{0xFF}),
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_PROTO).WithBytes([]byte{ipProtoUDP}, []byte{0xFF}),
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_L4_PORT_DST).WithUint16(port))).Build()
+		ed.GetFlow().Priority = trapFlowPriority(0, 0)
+		return ed
+	}
+	ospfEntry := func(ipVersion byte, dstMAC []byte) *fwdpb.EntryDesc {
+		var fields []*fwdconfig.PacketFieldMaskedBytesBuilder
+		if dstMAC != nil {
+			fields = append(fields, fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST).
+				WithBytes(dstMAC, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}))
+		}
+		fields = append(fields,
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_VERSION).WithBytes([]byte{ipVersion}, []byte{0xFF}),
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_PROTO).WithBytes([]byte{89}, []byte{0xFF}))
+		ed := fwdconfig.EntryDesc(fwdconfig.FlowEntry(fields...)).Build()
+		ed.GetFlow().Priority = trapFlowPriority(0, 0)
+		return ed
+	}
+	igmpEntry := func(msgType byte) *fwdpb.EntryDesc {
+		ed := fwdconfig.EntryDesc(fwdconfig.FlowEntry(
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_VERSION).WithBytes([]byte{4}, []byte{0xFF}),
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_PROTO).WithBytes([]byte{2}, []byte{0xFF}),
+			fwdconfig.PacketUDFMaskedBytes(fwdpb.PacketHeaderGroup_PACKET_HEADER_GROUP_PAYLOAD, 0, 1).WithBytes([]byte{msgType}, []byte{0xFF}))).Build()
+		ed.GetFlow().Priority = trapFlowPriority(0, 0)
+		return ed
+	}
+	macEntry := func(dstMAC []byte) *fwdpb.EntryDesc {
+		ed := fwdconfig.EntryDesc(fwdconfig.FlowEntry(
+			fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST).
+				WithBytes(dstMAC, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}))).Build()
+		ed.GetFlow().Priority = trapFlowPriority(0, 0)
+		return ed
+	}
+	tests := []struct {
+		desc        string
+		trapType    saipb.HostifTrapType
+		wantEntries []*fwdpb.EntryDesc
+	}{{
+		desc:        "dhcp",
+		trapType:    saipb.HostifTrapType_HOSTIF_TRAP_TYPE_DHCP,
+		wantEntries: []*fwdpb.EntryDesc{dhcpEntry(4, 67), dhcpEntry(4, 68)},
+	}, {
+		desc:        "dhcpv6",
+		trapType:    saipb.HostifTrapType_HOSTIF_TRAP_TYPE_DHCPV6,
+		wantEntries: []*fwdpb.EntryDesc{dhcpEntry(6, 546), dhcpEntry(6, 547)},
+	}, {
+		desc:     "ospf",
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_OSPF,
+		wantEntries: []*fwdpb.EntryDesc{
+			ospfEntry(4, []byte{0x01, 0x00, 0x5E, 0x00, 0x00, 0x05}),
+			ospfEntry(4, []byte{0x01, 0x00, 0x5E, 0x00, 0x00, 0x06}),
+		},
+	}, {
+		desc:        "ospfv3",
+		trapType:    saipb.HostifTrapType_HOSTIF_TRAP_TYPE_OSPFV6,
+		wantEntries: []*fwdpb.EntryDesc{ospfEntry(6, nil)},
+	}, {
+		desc:        "igmp query",
+		trapType:    saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_QUERY,
+		wantEntries: []*fwdpb.EntryDesc{igmpEntry(0x11)},
+	}, {
+		desc:        "igmpv1 report",
+		trapType:    saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_V1_REPORT,
+		wantEntries: []*fwdpb.EntryDesc{igmpEntry(0x12)},
+	}, {
+		desc:        "igmpv2 report",
+		trapType:    saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_V2_REPORT,
+		wantEntries: []*fwdpb.EntryDesc{igmpEntry(0x16)},
+	}, {
+		desc:        "igmp leave",
+		trapType:    saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_LEAVE,
+		wantEntries: []*fwdpb.EntryDesc{igmpEntry(0x17)},
+	}, {
+		desc:        "igmpv3 report",
+		trapType:    saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_V3_REPORT,
+		wantEntries: []*fwdpb.EntryDesc{igmpEntry(0x22)},
+	}, {
+		desc:     "pim",
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_PIM,
+		wantEntries: func() []*fwdpb.EntryDesc {
+			ed := fwdconfig.EntryDesc(fwdconfig.FlowEntry(
+				fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_PROTO).WithBytes([]byte{103}, []byte{0xFF}))).Build()
+			ed.GetFlow().Priority = trapFlowPriority(0, 0)
+			return []*fwdpb.EntryDesc{ed}
+		}(),
+	}, {
+		desc:        "stp",
+		trapType:    saipb.HostifTrapType_HOSTIF_TRAP_TYPE_STP,
+		wantEntries: []*fwdpb.EntryDesc{macEntry([]byte{0x01, 0x80, 0xC2, 0x00, 0x00, 0x00})},
+	}, {
+		desc:        "pvrst",
+		trapType:    saipb.HostifTrapType_HOSTIF_TRAP_TYPE_PVRST,
+		wantEntries: []*fwdpb.EntryDesc{macEntry([]byte{0x01, 0x00, 0x0C, 0xCC, 0xCC, 0xCD})},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dplane := &fakeSwitchDataplane{}
+			c, _, stopFn := newTestHostif(t, dplane, false)
+			defer stopFn()
+			c.srv.initSwitch(switchID, 10)
+			_, err := c.CreateHostifTrap(context.TODO(), &saipb.CreateHostifTrapRequest{
+				TrapType:     tt.trapType.Enum(),
+				PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+			})
+			if err != nil {
+				t.Fatalf("CreateHostifTrap() unexpected err: %v", err)
+			}
+			if len(dplane.gotEntryAddReqs) != 1 {
+				t.Fatalf("CreateHostifTrap() got %d TableEntryAdd requests, want 1", len(dplane.gotEntryAddReqs))
+			}
+			req := dplane.gotEntryAddReqs[0]
+			if got := req.GetTableId().GetObjectId().GetId(); got != trapTableID {
+				t.Errorf("CreateHostifTrap() got table %q, want %q", got, trapTableID)
+			}
+			var gotEntries []*fwdpb.EntryDesc
+			wantTransmit := fwdconfig.Action(fwdconfig.TransmitAction("10").WithImmediate(true)).Build()
+			for _, entry := range req.GetEntries() {
+				gotEntries = append(gotEntries, entry.GetEntryDesc())
+				acts := entry.GetActions()
+				if d := cmp.Diff(acts[len(acts)-1], wantTransmit, protocmp.Transform()); d != "" {
+					t.Errorf("CreateHostifTrap() transmit action unexpected diff (-got,+want):\n%s", d)
+				}
+			}
+			if d := cmp.Diff(gotEntries, tt.wantEntries, protocmp.Transform()); d != "" {
+				t.Errorf("CreateHostifTrap() entries unexpected diff (-got,+want):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestValidateTraps(t *testing.T) {
+	tests := []struct {
+		desc         string
+		hostifPort   uint64
+		redirect     uint64
+		action       saipb.PacketAction
+		removeTrap   bool
+		wantWarnings []string
+	}{{
+		desc:       "hostif of front panel port",
+		hostifPort: 5,
+		action:     saipb.PacketAction_PACKET_ACTION_TRAP,
+	}, {
+		desc:       "hostif of cpu port",
+		hostifPort: 10,
+		action:     saipb.PacketAction_PACKET_ACTION_TRAP,
+		wantWarnings: []string{
+			"trap 3 (HOSTIF_TRAP_TYPE_LLDP): punts packets sent by hostif 2 back to the CPU port, looping them to the host",
+		},
+	}, {
+		desc:       "redirected trap",
+		hostifPort: 10,
+		redirect:   5,
+		action:     saipb.PacketAction_PACKET_ACTION_TRAP,
+		wantWarnings: []string{
+			"trap 3 (HOSTIF_TRAP_TYPE_LLDP): redirects packets sent by hostif 2 to port 5, bypassing forwarding",
+		},
+	}, {
+		desc:       "copy",
+		hostifPort: 5,
+		action:     saipb.PacketAction_PACKET_ACTION_COPY,
+	}, {
+		desc:       "redirected copy",
+		hostifPort: 10,
+		redirect:   5,
+		action:     saipb.PacketAction_PACKET_ACTION_COPY,
+		wantWarnings: []string{
+			"trap 3 (HOSTIF_TRAP_TYPE_LLDP): copies packets sent by hostif 2 to port 5, in addition to forwarding them",
+		},
+	}, {
+		desc:       "removed trap",
+		hostifPort: 10,
+		action:     saipb.PacketAction_PACKET_ACTION_TRAP,
+		removeTrap: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dplane := &fakeSwitchDataplane{
+				ctx: fwdcontext.New("foo", "foo"),
+			}
+			dplane.ctx.SetPacketSink(func(*fwdpb.PacketSinkResponse) error { return nil })
+			c, mgr, stopFn := newTestHostif(t, dplane, false)
+			defer stopFn()
+			mgr.StoreAttributes(mgr.NextID(), &saipb.SwitchAttribute{
+				CpuPort: proto.Uint64(10),
+			})
+			for _, port := range []uint64{5, 10} {
+				mgr.StoreAttributes(port, &saipb.PortAttribute{
+					OperStatus: saipb.PortOperStatus_PORT_OPER_STATUS_UP.Enum(),
+				})
+			}
+			mgr.SetType("5", saipb.ObjectType_OBJECT_TYPE_PORT)
+			c.srv.initSwitch(switchID, 10)
+
+			if _, err := c.CreateHostif(context.TODO(), &saipb.CreateHostifRequest{
+				Type:  saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+				ObjId: proto.Uint64(tt.hostifPort),
+			}); err != nil {
+				t.Fatalf("CreateHostif() unexpected err: %v", err)
+			}
+			trap, err := c.CreateHostifTrap(context.TODO(), &saipb.CreateHostifTrapRequest{
+				TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP.Enum(),
+				PacketAction: tt.action.Enum(),
+				RedirectPort: proto.Uint64(tt.redirect),
+			})
+			if err != nil {
+				t.Fatalf("CreateHostifTrap() unexpected err: %v", err)
+			}
+			if tt.removeTrap {
+				if _, err := c.RemoveHostifTrap(context.TODO(), &saipb.RemoveHostifTrapRequest{Oid: trap.GetOid()}); err != nil {
+					t.Fatalf("RemoveHostifTrap() unexpected err: %v", err)
+				}
+			}
+			if d := cmp.Diff(c.srv.validateTraps(), tt.wantWarnings); d != "" {
+				t.Errorf("validateTraps() failed: diff(-got,+want)\n:%s", d)
+			}
+		})
+	}
+}
+
+// nopPortManager creates fake ports that never receive packets and discard written packets.
+type nopPortManager struct{}
+
+func (nopPortManager) CreatePort(string) (fwdcontext.Port, error) {
+	return nopPort{}, nil
+}
+
+type nopPort struct{}
+
+func (nopPort) ReadPacketData() ([]byte, gopacket.CaptureInfo, error) {
+	select {}
+}
+
+func (nopPort) WritePacketData([]byte) error {
+	return nil
+}
+
+// testSrcMAC is the source MAC address of the frames injected by the trap tests.
+var testSrcMAC = net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+
+// serializeFrame serializes the layers into a frame, padded with zeros to size bytes if it is shorter.
+func serializeFrame(t testing.TB, size int, l ...gopacket.SerializableLayer) []byte {
+	t.Helper()
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true}, l...); err != nil {
+		t.Fatal(err)
+	}
+	frame := buf.Bytes()
+	if len(frame) < size {
+		frame = append(frame, make([]byte, size-len(frame))...)
+	}
+	return frame
+}
+
+// arpRequestFrame returns a broadcast ARP request from 192.0.2.1 for 192.0.2.2.
+func arpRequestFrame(t testing.TB, size int) []byte {
+	t.Helper()
+	return serializeFrame(t, size, &layers.Ethernet{
+		SrcMAC:       testSrcMAC,
+		DstMAC:       layers.EthernetBroadcast,
+		EthernetType: layers.EthernetTypeARP,
+	}, &layers.ARP{
+		AddrType:          layers.LinkTypeEthernet,
+		Protocol:          layers.EthernetTypeIPv4,
+		HwAddressSize:     6,
+		ProtAddressSize:   4,
+		Operation:         layers.ARPRequest,
+		SourceHwAddress:   testSrcMAC,
+		SourceProtAddress: net.IPv4(192, 0, 2, 1).To4(),
+		DstHwAddress:      make([]byte, 6),
+		DstProtAddress:    net.IPv4(192, 0, 2, 2).To4(),
+	})
+}
+
+// lldpFrame returns an LLDPDU for port e1 sent to the nearest bridge group.
+func lldpFrame(t testing.TB, size int) []byte {
+	t.Helper()
+	tlvs := []byte{
+		0x02, 0x07, 0x04, 0x02, 0x00, 0x00, 0x00, 0x00, 0x01, // Chassis ID: MAC.
+		0x04, 0x03, 0x05, 'e', '1', // Port ID: interface name.
+		0x06, 0x02, 0x00, 0x78, // TTL.
+		0x00, 0x00, // End.
+	}
+	return serializeFrame(t, size, &layers.Ethernet{
+		SrcMAC:       testSrcMAC,
+		DstMAC:       net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x0e},
+		EthernetType: layers.EthernetTypeLinkLayerDiscovery,
+	}, gopacket.Payload(tlvs))
+}
+
+// lacpFrame returns a frame to the slow protocols group, gopacket doesn't decode LACP so the payload is empty.
+func lacpFrame(t testing.TB, size int) []byte {
+	t.Helper()
+	return serializeFrame(t, size, &layers.Ethernet{
+		SrcMAC:       testSrcMAC,
+		DstMAC:       lacpDstMAC,
+		EthernetType: layers.EthernetType(0x8809),
+	})
+}
+
+// checkARPRequest checks that pkt is the ARP request of arpRequestFrame.
+func checkARPRequest(t testing.TB, pkt *packetutil.Packet) {
+	t.Helper()
+	arp := pkt.ARP()
+	if arp == nil {
+		t.Fatalf("punted packet is not ARP: %v", pkt)
+	}
+	if arp.Operation != layers.ARPRequest || !net.IP(arp.DstProtAddress).Equal(net.IPv4(192, 0, 2, 2)) {
+		t.Errorf("punted ARP got op %d, target %v, want request for 192.0.2.2", arp.Operation, net.IP(arp.DstProtAddress))
+	}
+}
+
+// checkLLDP checks that pkt is the LLDPDU of lldpFrame.
+func checkLLDP(t testing.TB, pkt *packetutil.Packet) {
+	t.Helper()
+	lldp := pkt.LLDP()
+	if lldp == nil {
+		t.Fatalf("punted packet is not LLDP: %v", pkt)
+	}
+	if got := string(lldp.PortID.ID); got != "e1" {
+		t.Errorf("punted LLDP port id got %q, want %q", got, "e1")
+	}
+}
+
+func TestHostifTrapPunt(t *testing.T) {
+	vrrpSrcMAC := net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x01, 0x01}
+	// VRRPv3 advertisement for virtual router 1 with priority 100 and a single address.
+	vrrpAdvert := []byte{0x31, 0x01, 0x64, 0x01, 0x00, 0x64, 0x00, 0x00, 192, 0, 2, 254}
+	tests := []struct {
+		desc      string
+		trapType  saipb.HostifTrapType
+		frame     func(*testing.T) []byte
+		checkFunc func(*testing.T, *packetutil.Packet)
+		// notTrapped is set when the packet must not be punted by the trap.
+		notTrapped bool
+	}{{
+		desc:     "igmp membership report",
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_V2_REPORT,
+		frame: func(t *testing.T) []byte {
+			return serializeFrame(t, 0, &layers.Ethernet{
+				SrcMAC:       testSrcMAC,
+				DstMAC:       net.HardwareAddr{0x01, 0x00, 0x5e, 0x01, 0x01, 0x01},
+				EthernetType: layers.EthernetTypeIPv4,
+			}, &layers.IPv4{
+				Version:  4,
+				TTL:      1,
+				Protocol: layers.IPProtocolIGMP,
+				SrcIP:    net.IPv4(192, 0, 2, 1).To4(),
+				DstIP:    net.IPv4(239, 1, 1, 1).To4(),
+				Options:  []layers.IPv4Option{{OptionType: 148, OptionLength: 4, OptionData: []byte{0, 0}}}, // Router alert.
+			}, gopacket.Payload{0x16, 0x00, 0xfa, 0xfd, 239, 1, 1, 1}) // IGMPv2 membership report for 239.1.1.1.
+		},
+		checkFunc: func(t *testing.T, pkt *packetutil.Packet) {
+			ip := pkt.IPv4()
+			if ip == nil || ip.Protocol != layers.IPProtocolIGMP {
+				t.Fatalf("punted packet is not IGMP: %v", pkt)
+			}
+		},
+	}, {
+		desc:     "igmp query to the report trap",
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_V2_REPORT,
+		frame: func(t *testing.T) []byte {
+			return serializeFrame(t, 0, &layers.Ethernet{
+				SrcMAC:       testSrcMAC,
+				DstMAC:       net.HardwareAddr{0x01, 0x00, 0x5e, 0x00, 0x00, 0x01},
+				EthernetType: layers.EthernetTypeIPv4,
+			}, &layers.IPv4{
+				Version:  4,
+				TTL:      1,
+				Protocol: layers.IPProtocolIGMP,
+				SrcIP:    net.IPv4(192, 0, 2, 1).To4(),
+				DstIP:    net.IPv4(224, 0, 0, 1).To4(),
+				Options:  []layers.IPv4Option{{OptionType: 148, OptionLength: 4, OptionData: []byte{0, 0}}}, // Router alert.
+			}, gopacket.Payload{0x11, 0x64, 0xee, 0x9b, 0, 0, 0, 0}) // IGMPv2 general query.
+		},
+		notTrapped: true,
+	}, {
+		desc:     "igmp query",
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_QUERY,
+		frame: func(t *testing.T) []byte {
+			return serializeFrame(t, 0, &layers.Ethernet{
+				SrcMAC:       testSrcMAC,
+				DstMAC:       net.HardwareAddr{0x01, 0x00, 0x5e, 0x00, 0x00, 0x01},
+				EthernetType: layers.EthernetTypeIPv4,
+			}, &layers.IPv4{
+				Version:  4,
+				TTL:      1,
+				Protocol: layers.IPProtocolIGMP,
+				SrcIP:    net.IPv4(192, 0, 2, 1).To4(),
+				DstIP:    net.IPv4(224, 0, 0, 1).To4(),
+			}, gopacket.Payload{0x11, 0x64, 0xee, 0x9b, 0, 0, 0, 0}) // IGMPv2 general query.
+		},
+		checkFunc: func(t *testing.T, pkt *packetutil.Packet) {
+			ip := pkt.IPv4()
+			if ip == nil || ip.Protocol != layers.IPProtocolIGMP {
+				t.Fatalf("punted packet is not IGMP: %v", pkt)
+			}
+		},
+	}, {
+		desc:     "pim hello",
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_PIM,
+		frame: func(t *testing.T) []byte {
+			return serializeFrame(t, 0, &layers.Ethernet{
+				SrcMAC:       testSrcMAC,
+				DstMAC:       net.HardwareAddr{0x01, 0x00, 0x5e, 0x00, 0x00, 0x0d},
+				EthernetType: layers.EthernetTypeIPv4,
+			}, &layers.IPv4{
+				Version:  4,
+				TTL:      1,
+				Protocol: layers.IPProtocol(103),
+				SrcIP:    net.IPv4(192, 0, 2, 1).To4(),
+				DstIP:    net.IPv4(224, 0, 0, 13).To4(),
+			}, gopacket.Payload{0x20, 0x00, 0xf6, 0x56, 0x00, 0x01, 0x00, 0x02, 0x00, 0x69}) // Hello with a 105s holdtime.
+		},
+		checkFunc: func(t *testing.T, pkt *packetutil.Packet) {
+			ip := pkt.IPv4()
+			if ip == nil || ip.Protocol != 103 {
+				t.Fatalf("punted packet is not PIM: %v", pkt)
+			}
+		},
+	}, {
+		desc:     "multicast data",
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IGMP_TYPE_V2_REPORT,
+		frame: func(t *testing.T) []byte {
+			return serializeFrame(t, 0, &layers.Ethernet{
+				SrcMAC:       testSrcMAC,
+				DstMAC:       net.HardwareAddr{0x01, 0x00, 0x5e, 0x01, 0x01, 0x01},
+				EthernetType: layers.EthernetTypeIPv4,
+			}, &layers.IPv4{
+				Version:  4,
+				TTL:      64,
+				Protocol: layers.IPProtocolUDP,
+				SrcIP:    net.IPv4(192, 0, 2, 1).To4(),
+				DstIP:    net.IPv4(239, 1, 1, 1).To4(),
+			}, &layers.UDP{SrcPort: 5000, DstPort: 5000}, gopacket.Payload("data"))
+		},
+		notTrapped: true,
+	}, {
+		desc:     "mldv2 report",
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_MLD_V2_REPORT,
+		frame: func(t *testing.T) []byte {
+			return serializeFrame(t, 0, &layers.Ethernet{
+				SrcMAC:       testSrcMAC,
+				DstMAC:       net.HardwareAddr{0x33, 0x33, 0x00, 0x00, 0x00, 0x16},
+				EthernetType: layers.EthernetTypeIPv6,
+			}, &layers.IPv6{
+				Version:    6,
+				HopLimit:   1,
+				NextHeader: layers.IPProtocolIPv6HopByHop,
+				SrcIP:      net.ParseIP("fe80::1"),
+				DstIP:      net.ParseIP("ff02::16"),
+			}, gopacket.Payload{
+				0x3a, 0x00, 0x05, 0x02, 0x00, 0x00, 0x01, 0x00, // Hop-by-hop options with router alert.
+				0x8f, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // MLDv2 report without any records.
+			})
+		},
+		checkFunc: func(t *testing.T, pkt *packetutil.Packet) {
+			ip := pkt.IPv6()
+			if ip == nil || ip.NextHeader != layers.IPProtocolIPv6HopByHop {
+				t.Fatalf("punted packet is not MLD: %v", pkt)
+			}
+		},
+	}, {
+		desc:     "vrrp advertisement",
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_VRRP,
+		frame: func(t *testing.T) []byte {
+			return serializeFrame(t, 0, &layers.Ethernet{
+				SrcMAC:       vrrpSrcMAC,
+				DstMAC:       net.HardwareAddr{0x01, 0x00, 0x5e, 0x00, 0x00, 0x12},
+				EthernetType: layers.EthernetTypeIPv4,
+			}, &layers.IPv4{
+				Version:  4,
+				TTL:      255,
+				Protocol: layers.IPProtocolVRRP,
+				SrcIP:    net.IPv4(192, 0, 2, 1).To4(),
+				DstIP:    net.IPv4(224, 0, 0, 18).To4(),
+			}, gopacket.Payload(vrrpAdvert))
+		},
+		checkFunc: func(t *testing.T, pkt *packetutil.Packet) {
+			ip := pkt.IPv4()
+			if ip == nil || ip.Protocol != layers.IPProtocolVRRP {
+				t.Fatalf("punted packet is not VRRP: %v", pkt)
+			}
+		},
+	}, {
+		desc:     "vrrpv6 advertisement",
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_VRRPV6,
+		frame: func(t *testing.T) []byte {
+			return serializeFrame(t, 0, &layers.Ethernet{
+				SrcMAC:       net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x02, 0x01},
+				DstMAC:       net.HardwareAddr{0x33, 0x33, 0x00, 0x00, 0x00, 0x12},
+				EthernetType: layers.EthernetTypeIPv6,
+			}, &layers.IPv6{
+				Version:    6,
+				HopLimit:   255,
+				NextHeader: layers.IPProtocolVRRP,
+				SrcIP:      net.ParseIP("fe80::1"),
+				DstIP:      net.ParseIP("ff02::12"),
+			}, gopacket.Payload(vrrpAdvert))
+		},
+		checkFunc: func(t *testing.T, pkt *packetutil.Packet) {
+			ip := pkt.IPv6()
+			if ip == nil || ip.NextHeader != layers.IPProtocolVRRP {
+				t.Fatalf("punted packet is not VRRP: %v", pkt)
+			}
+		},
+	}, {
+		desc:     "vrrp to another link-local group",
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_VRRP,
+		frame: func(t *testing.T) []byte {
+			return serializeFrame(t, 0, &layers.Ethernet{
+				SrcMAC:       vrrpSrcMAC,
+				DstMAC:       net.HardwareAddr{0x01, 0x00, 0x5e, 0x00, 0x00, 0x13},
+				EthernetType: layers.EthernetTypeIPv4,
+			}, &layers.IPv4{
+				Version:  4,
+				TTL:      255,
+				Protocol: layers.IPProtocolVRRP,
+				SrcIP:    net.IPv4(192, 0, 2, 1).To4(),
+				DstIP:    net.IPv4(224, 0, 0, 19).To4(),
+			}, gopacket.Payload(vrrpAdvert))
+		},
+		notTrapped: true,
+	}, {
+		desc:     "udp to the vrrp group",
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_VRRP,
+		frame: func(t *testing.T) []byte {
+			return serializeFrame(t, 0, &layers.Ethernet{
+				SrcMAC:       testSrcMAC,
+				DstMAC:       net.HardwareAddr{0x01, 0x00, 0x5e, 0x00, 0x00, 0x12},
+				EthernetType: layers.EthernetTypeIPv4,
+			}, &layers.IPv4{
+				Version:  4,
+				TTL:      1,
+				Protocol: layers.IPProtocolUDP,
+				SrcIP:    net.IPv4(192, 0, 2, 1).To4(),
+				DstIP:    net.IPv4(224, 0, 0, 18).To4(),
+			}, &layers.UDP{SrcPort: 5000, DstPort: 5000}, gopacket.Payload("data"))
+		},
+		notTrapped: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ctx := context.Background()
+			s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+			sink := packetutil.NewSink(1)
+			fwdCtx.FakePortManager = nopPortManager{}
+			fwdCtx.SetCPUPortSink(sink.CPUPortSink, func() {})
+
+			if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+				t.Fatal(err)
+			}
+			acceptAnyDstMAC(t, conn)
+			port := createTestPort(t, conn, 1)
+			if _, err := saipb.NewHostifClient(conn).CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+				TrapType:     tt.trapType.Enum(),
+				PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+				fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, tt.frame(t), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.notTrapped {
+				if pkt, err := sink.Next(100 * time.Millisecond); err == nil {
+					t.Fatalf("packet unexpectedly punted: %v", pkt)
+				}
+				return
+			}
+			pkt, err := sink.Next(time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.checkFunc(t, pkt)
+		})
+	}
+}
+
+func TestHostifTrapExcludeSource(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sink := packetutil.NewSink(1)
+	fwdCtx.FakePortManager = nopPortManager{}
+	fwdCtx.SetCPUPortSink(sink.CPUPortSink, func() {})
+
+	sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	port := createTestPort(t, conn, 1)
+	hc := saipb.NewHostifClient(conn)
+	ip2me, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+		TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IP2ME.Enum(),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The BGP trap only matches packets to local addresses.
+	swAttr, err := saipb.NewSwitchClient(conn).GetSwitchAttribute(ctx, &saipb.GetSwitchAttributeRequest{
+		Oid:      sw.GetOid(),
+		AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_CPU_PORT, saipb.SwitchAttr_SWITCH_ATTR_DEFAULT_VIRTUAL_ROUTER_ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := saipb.NewRouteClient(conn).CreateRouteEntry(ctx, &saipb.CreateRouteEntryRequest{
+		Entry: &saipb.RouteEntry{
+			SwitchId:    sw.GetOid(),
+			VrId:        swAttr.GetAttr().GetDefaultVirtualRouterId(),
+			Destination: &saipb.IpPrefix{Addr: []byte{198, 51, 100, 2}, Mask: []byte{255, 255, 255, 255}},
+		},
+		NextHopId:    proto.Uint64(swAttr.GetAttr().GetCpuPort()),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_FORWARD.Enum(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	tbl, err := fwdCtx.Objects.FindID(&fwdpb.ObjectId{Id: trapTableID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantEntries := len(tbl.(fwdtable.Table).Entries())
+	trap, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+		TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_BGP.Enum(),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	excludeTests := []struct {
+		desc     string
+		trap     uint64
+		src      *saipb.IpPrefix
+		action   saipb.PacketAction
+		wantCode codes.Code
+	}{{
+		desc:   "noisy peer subnet",
+		trap:   trap.GetOid(),
+		src:    &saipb.IpPrefix{Addr: []byte{192, 0, 2, 0}, Mask: []byte{255, 255, 255, 0}},
+		action: saipb.PacketAction_PACKET_ACTION_FORWARD,
+	}, {
+		desc:   "noisy peer address",
+		trap:   trap.GetOid(),
+		src:    &saipb.IpPrefix{Addr: []byte{203, 0, 113, 9}, Mask: []byte{255, 255, 255, 255}},
+		action: saipb.PacketAction_PACKET_ACTION_DROP,
+	}, {
+		desc:     "unknown trap",
+		trap:     12345,
+		src:      &saipb.IpPrefix{Addr: []byte{192, 0, 2, 0}, Mask: []byte{255, 255, 255, 0}},
+		action:   saipb.PacketAction_PACKET_ACTION_FORWARD,
+		wantCode: codes.NotFound,
+	}, {
+		desc:     "ip2me trap",
+		trap:     ip2me.GetOid(),
+		src:      &saipb.IpPrefix{Addr: []byte{192, 0, 2, 0}, Mask: []byte{255, 255, 255, 0}},
+		action:   saipb.PacketAction_PACKET_ACTION_FORWARD,
+		wantCode: codes.FailedPrecondition,
+	}, {
+		desc:     "unsupported action",
+		trap:     trap.GetOid(),
+		src:      &saipb.IpPrefix{Addr: []byte{192, 0, 2, 0}, Mask: []byte{255, 255, 255, 0}},
+		action:   saipb.PacketAction_PACKET_ACTION_TRAP,
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "mask length mismatch",
+		trap:     trap.GetOid(),
+		src:      &saipb.IpPrefix{Addr: []byte{192, 0, 2, 0}, Mask: []byte{255, 255, 255, 0, 0, 0}},
+		action:   saipb.PacketAction_PACKET_ACTION_FORWARD,
+		wantCode: codes.InvalidArgument,
+	}}
+	dc := diagpb.NewDiagClient(conn)
+	for _, tt := range excludeTests {
+		_, err := dc.ExcludeHostifTrapSource(ctx, &diagpb.ExcludeHostifTrapSourceRequest{Oid: tt.trap, Src: tt.src, PacketAction: tt.action})
+		if got := grpcstatus.Code(err); got != tt.wantCode {
+			t.Fatalf("%s: ExcludeHostifTrapSource() got code %v (err %v), want %v", tt.desc, got, err, tt.wantCode)
+		}
+	}
+
+	bgpFrame := func(t *testing.T, src string) []byte {
+		t.Helper()
+		ip := &layers.IPv4{
+			Version:  4,
+			TTL:      64,
+			Protocol: layers.IPProtocolTCP,
+			SrcIP:    net.ParseIP(src).To4(),
+			DstIP:    net.IPv4(198, 51, 100, 2).To4(),
+		}
+		tcp := &layers.TCP{SrcPort: 50000, DstPort: 179, SYN: true, Window: 1024}
+		if err := tcp.SetNetworkLayerForChecksum(ip); err != nil {
+			t.Fatal(err)
+		}
+		buf := gopacket.NewSerializeBuffer()
+		if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true},
+			&layers.Ethernet{
+				SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+				DstMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x02},
+				EthernetType: layers.EthernetTypeIPv4,
+			}, ip, tcp); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	puntTests := []struct {
+		desc       string
+		src        string
+		wantPunted bool
+	}{{
+		desc: "excluded subnet",
+		src:  "192.0.2.7",
+	}, {
+		desc: "excluded address",
+		src:  "203.0.113.9",
+	}, {
+		desc:       "other peer",
+		src:        "198.51.100.1",
+		wantPunted: true,
+	}, {
+		desc:       "address next to excluded address",
+		src:        "203.0.113.10",
+		wantPunted: true,
+	}}
+	for _, tt := range puntTests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+				fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, bgpFrame(t, tt.src), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.wantPunted {
+				if pkt, err := sink.Next(100 * time.Millisecond); err == nil {
+					t.Fatalf("BGP packet from %s unexpectedly punted: %v", tt.src, pkt)
+				}
+				return
+			}
+			pkt, err := sink.Next(time.Second)
+			if err != nil {
+				t.Fatalf("BGP packet from %s not punted: %v", tt.src, err)
+			}
+			if ip := pkt.IPv4(); ip == nil || !ip.SrcIP.Equal(net.ParseIP(tt.src)) {
+				t.Errorf("punted packet got %v, want BGP from %s", pkt, tt.src)
+			}
+		})
+	}
+
+	// The exclusions are removed with the trap.
+	if _, err := hc.RemoveHostifTrap(ctx, &saipb.RemoveHostifTrapRequest{Oid: trap.GetOid()}); err != nil {
+		t.Fatalf("RemoveHostifTrap() unexpected err: %v", err)
+	}
+	if got := len(tbl.(fwdtable.Table).Entries()); got != wantEntries {
+		t.Errorf("trap table got %d entries after removing the trap, want %d", got, wantEntries)
+	}
+}
+
+// trapStats returns the stats of the trap from the Diag service.
+func trapStats(ctx context.Context, conn grpc.ClientConnInterface, trap uint64) (*diagpb.TrapStats, error) {
+	resp, err := diagpb.NewDiagClient(conn).GetHostifTrapStats(ctx, &diagpb.GetHostifTrapStatsRequest{Oid: trap})
+	return resp.GetStats(), err
+}
+
+func TestHostifTrapGroupStats(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sink := packetutil.NewSink(10)
+	fwdCtx.FakePortManager = nopPortManager{}
+	fwdCtx.SetCPUPortSink(sink.CPUPortSink, func() {})
+
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	port := createTestPort(t, conn, 1)
+	hc := saipb.NewHostifClient(conn)
+	group, err := hc.CreateHostifTrapGroup(ctx, &saipb.CreateHostifTrapGroupRequest{Queue: proto.Uint32(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var traps []uint64
+	for _, trapType := range []saipb.HostifTrapType{saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP, saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LACP} {
+		trap, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+			TrapType:     trapType.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+			TrapGroup:    proto.Uint64(group.GetOid()),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		traps = append(traps, trap.GetOid())
+	}
+	// The ARP trap is in the default trap group, so it must not be counted in the group.
+	if _, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+		TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_ARP_REQUEST.Enum(),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	frames := []struct {
+		frame []byte
+		layer gopacket.LayerType
+	}{
+		{lldpFrame(t, 64), layers.LayerTypeLinkLayerDiscovery},
+		{lldpFrame(t, 100), layers.LayerTypeLinkLayerDiscovery},
+		{lacpFrame(t, 128), layers.LayerTypeEthernet},
+		{arpRequestFrame(t, 64), layers.LayerTypeARP},
+	}
+	for _, f := range frames {
+		err = s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+			fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, f.frame, nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkt, err := sink.Next(time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pkt.Layer(f.layer) == nil {
+			t.Errorf("punted packet has no %v layer: %v", f.layer, pkt)
+		}
+	}
+
+	want := &diagpb.TrapStats{}
+	for _, trap := range traps {
+		stats, err := trapStats(ctx, conn, trap)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want.Packets += stats.Packets
+		want.Bytes += stats.Bytes
+	}
+	if want.Packets != 3 || want.Bytes != 64+100+128 {
+		t.Errorf("GetGetHostifTrapStats() got total %+v, want 3 packets and %d bytes", want, 64+100+128)
+	}
+	dc := diagpb.NewDiagClient(conn)
+	got, err := dc.GetHostifTrapGroupStats(ctx, &diagpb.GetHostifTrapGroupStatsRequest{Oid: group.GetOid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(got.GetStats(), want, protocmp.Transform()); d != "" {
+		t.Errorf("GetHostifTrapGroupStats() unexpected diff (-got,+want):\n%s", d)
+	}
+	if _, err := dc.GetHostifTrapGroupStats(ctx, &diagpb.GetHostifTrapGroupStatsRequest{Oid: 1000}); grpcstatus.Code(err) != codes.NotFound {
+		t.Errorf("GetHostifTrapGroupStats() of unknown group got err %v, want NotFound", err)
+	}
+
+	// The counter is deleted with the trap.
+	if _, err := hc.RemoveHostifTrap(ctx, &saipb.RemoveHostifTrapRequest{Oid: traps[0]}); err != nil {
+		t.Fatalf("RemoveHostifTrap() unexpected err: %v", err)
+	}
+	if _, err := fwdCtx.Objects.FindID(&fwdpb.ObjectId{Id: trapCounterID(traps[0])}); err == nil {
+		t.Errorf("counter of removed trap %d still exists", traps[0])
+	}
+}
+
+func TestHostifTrapGroupPolicerShared(t *testing.T) {
+	const (
+		frameSize = 64
+		// The policer's burst only fits 3 frames and its rate is too low to refill during the test.
+		wantPunts = 3
+	)
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sink := packetutil.NewSink(10)
+	fwdCtx.FakePortManager = nopPortManager{}
+	fwdCtx.SetCPUPortSink(sink.CPUPortSink, func() {})
+
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	port := createTestPort(t, conn, 1)
+	policer, err := saipb.NewPolicerClient(conn).CreatePolicer(ctx, &saipb.CreatePolicerRequest{
+		MeterType: saipb.MeterType_METER_TYPE_BYTES.Enum(),
+		Mode:      saipb.PolicerMode_POLICER_MODE_SR_TCM.Enum(),
+		Cbs:       proto.Uint64(wantPunts * frameSize),
+		Cir:       proto.Uint64(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc := saipb.NewHostifClient(conn)
+	group, err := hc.CreateHostifTrapGroup(ctx, &saipb.CreateHostifTrapGroupRequest{Policer: proto.Uint64(policer.GetOid())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, trapType := range []saipb.HostifTrapType{saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP, saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LACP} {
+		if _, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+			TrapType:     trapType.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+			TrapGroup:    proto.Uint64(group.GetOid()),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Each trap matches as many frames as the policer admits, together they exceed it.
+	for i := 0; i < wantPunts; i++ {
+		for _, f := range [][]byte{lldpFrame(t, frameSize), lacpFrame(t, frameSize)} {
+			err = s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+				fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, f, nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	for i := 0; i < wantPunts; i++ {
+		if _, err := sink.Next(time.Second); err != nil {
+			t.Fatalf("got %d punted packets, want %d: %v", i, wantPunts, err)
+		}
+	}
+	if pkt, err := sink.Next(100 * time.Millisecond); err == nil {
+		t.Errorf("packet above the trap group's policer rate punted: %v", pkt)
+	}
+}
+
+func TestSetHostifTrapGroupPolicer(t *testing.T) {
+	const (
+		frameSize = 64
+		// The policer's burst only fits 3 frames and its rate is too low to refill during the test.
+		wantPunts = 3
+		arpFrames = 2 * wantPunts
+	)
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sink := packetutil.NewSink(arpFrames)
+	fwdCtx.FakePortManager = nopPortManager{}
+	fwdCtx.SetCPUPortSink(sink.CPUPortSink, func() {})
+
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	port := createTestPort(t, conn, 1)
+	hc := saipb.NewHostifClient(conn)
+	group, err := hc.CreateHostifTrapGroup(ctx, &saipb.CreateHostifTrapGroupRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+		TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_ARP_REQUEST.Enum(),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+		TrapGroup:    proto.Uint64(group.GetOid()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	f := arpRequestFrame(t, frameSize)
+	// punts injects ARP frames, each from its own copy of f, and returns how many of them are punted.
+	punts := func() int {
+		t.Helper()
+		for i := 0; i < arpFrames; i++ {
+			err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+				fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, bytes.Clone(f), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		n := 0
+		for ; ; n++ {
+			pkt, err := sink.Next(200 * time.Millisecond)
+			if err != nil {
+				return n
+			}
+			checkARPRequest(t, pkt)
+		}
+	}
+
+	// The trap is created before the group has a policer, setting the policer rate limits it.
+	policer, err := saipb.NewPolicerClient(conn).CreatePolicer(ctx, &saipb.CreatePolicerRequest{
+		MeterType: saipb.MeterType_METER_TYPE_BYTES.Enum(),
+		Mode:      saipb.PolicerMode_POLICER_MODE_SR_TCM.Enum(),
+		Cbs:       proto.Uint64(wantPunts * frameSize),
+		Cir:       proto.Uint64(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hc.SetHostifTrapGroupAttribute(ctx, &saipb.SetHostifTrapGroupAttributeRequest{Oid: group.GetOid(), Policer: proto.Uint64(policer.GetOid())}); err != nil {
+		t.Fatal(err)
+	}
+	if got := punts(); got != wantPunts {
+		t.Errorf("got %d punted ARP packets with the group's policer, want %d", got, wantPunts)
+	}
+	resp, err := hc.GetHostifTrapGroupAttribute(ctx, &saipb.GetHostifTrapGroupAttributeRequest{
+		Oid:      group.GetOid(),
+		AttrType: []saipb.HostifTrapGroupAttr{saipb.HostifTrapGroupAttr_HOSTIF_TRAP_GROUP_ATTR_POLICER},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.GetAttr().GetPolicer(); got != policer.GetOid() {
+		t.Errorf("GetHostifTrapGroupAttribute() got policer %d, want %d", got, policer.GetOid())
+	}
+
+	// Removing the policer stops rate limiting the trap.
+	if _, err := hc.SetHostifTrapGroupAttribute(ctx, &saipb.SetHostifTrapGroupAttributeRequest{Oid: group.GetOid(), Policer: proto.Uint64(0)}); err != nil {
+		t.Fatal(err)
+	}
+	if got := punts(); got != arpFrames {
+		t.Errorf("got %d punted ARP packets without a policer, want %d", got, arpFrames)
+	}
+
+	_, err = hc.SetHostifTrapGroupAttribute(ctx, &saipb.SetHostifTrapGroupAttributeRequest{Oid: group.GetOid(), Policer: proto.Uint64(1000)})
+	if grpcstatus.Code(err) != codes.NotFound {
+		t.Errorf("SetHostifTrapGroupAttribute() with unknown policer got err %v, want NotFound", err)
+	}
+}
+
+func TestSpanningTreeTraps(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sink := packetutil.NewSink(10)
+	fwdCtx.FakePortManager = nopPortManager{}
+	fwdCtx.SetCPUPortSink(sink.CPUPortSink, func() {})
+
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	port := createTestPort(t, conn, 1)
+	hc := saipb.NewHostifClient(conn)
+	for _, trapType := range []saipb.HostifTrapType{saipb.HostifTrapType_HOSTIF_TRAP_TYPE_STP, saipb.HostifTrapType_HOSTIF_TRAP_TYPE_PVRST} {
+		if _, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+			TrapType:     trapType.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// BPDUs are 802.3 frames with an LLC header, the EtherType field holds the length of the payload.
+	bpdu := func(dstMAC []byte) []byte {
+		f := make([]byte, 64)
+		copy(f, dstMAC)
+		copy(f[6:], []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x01})
+		copy(f[12:], []byte{0x00, 0x26, 0x42, 0x42, 0x03})
+		return f
+	}
+	tests := []struct {
+		desc     string
+		dstMAC   []byte
+		wantPunt bool
+	}{{
+		desc:     "stp",
+		dstMAC:   []byte{0x01, 0x80, 0xC2, 0x00, 0x00, 0x00},
+		wantPunt: true,
+	}, {
+		desc:     "pvrst",
+		dstMAC:   []byte{0x01, 0x00, 0x0C, 0xCC, 0xCC, 0xCD},
+		wantPunt: true,
+	}, {
+		desc:   "other link-local",
+		dstMAC: []byte{0x01, 0x80, 0xC2, 0x00, 0x00, 0x01},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			frame := bpdu(tt.dstMAC)
+			err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+				fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, frame, nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkt, err := sink.Next(100 * time.Millisecond)
+			if !tt.wantPunt {
+				if err == nil {
+					t.Errorf("frame to %x unexpectedly transmitted to the CPU port: %v", tt.dstMAC, pkt)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("frame to %x not transmitted to the CPU port: %v", tt.dstMAC, err)
+			}
+			if d := cmp.Diff(pkt.Out.GetPacket().GetFrame(), frame); d != "" {
+				t.Errorf("CPU port got unexpected frame diff (-got,+want):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestGetCPUPuntStats(t *testing.T) {
+	const (
+		arpFrames  = 10
+		lldpFrames = 2
+		// The policer's burst only fits 3 ARP frames and its rate is too low to refill during the test.
+		arpAdmitted = 3
+		// The CPU sink only fits 4 of the 5 admitted frames.
+		sinkSize = 4
+	)
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sink := packetutil.NewSink(sinkSize)
+	fwdCtx.FakePortManager = nopPortManager{}
+	fwdCtx.SetCPUPortSink(sink.CPUPortSink, func() {})
+
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	port := createTestPort(t, conn, 1)
+	const frameSize = 64
+	policer, err := saipb.NewPolicerClient(conn).CreatePolicer(ctx, &saipb.CreatePolicerRequest{
+		MeterType: saipb.MeterType_METER_TYPE_BYTES.Enum(),
+		Mode:      saipb.PolicerMode_POLICER_MODE_SR_TCM.Enum(),
+		Cbs:       proto.Uint64(arpAdmitted * frameSize),
+		Cir:       proto.Uint64(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc := saipb.NewHostifClient(conn)
+	group, err := hc.CreateHostifTrapGroup(ctx, &saipb.CreateHostifTrapGroupRequest{
+		Policer: proto.Uint64(policer.GetOid()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var traps []uint64
+	for _, req := range []*saipb.CreateHostifTrapRequest{{
+		TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_ARP_REQUEST.Enum(),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+		TrapGroup:    proto.Uint64(group.GetOid()),
+	}, {
+		// The LLDP trap is in the default trap group, which has no policer.
+		TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP.Enum(),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+	}} {
+		trap, err := hc.CreateHostifTrap(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		traps = append(traps, trap.GetOid())
+	}
+
+	var frames [][]byte
+	for i := 0; i < arpFrames; i++ {
+		frames = append(frames, arpRequestFrame(t, frameSize))
+	}
+	for i := 0; i < lldpFrames; i++ {
+		frames = append(frames, lldpFrame(t, frameSize))
+	}
+	for _, f := range frames {
+		err = s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+			fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, f, nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var trapped uint64
+	for _, trap := range traps {
+		stats, err := trapStats(ctx, conn, trap)
+		if err != nil {
+			t.Fatal(err)
+		}
+		trapped += stats.Packets
+	}
+	got, err := diagpb.NewDiagClient(conn).GetCPUPuntStats(ctx, &diagpb.GetCPUPuntStatsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &diagpb.GetCPUPuntStatsResponse{
+		Trapped:      arpFrames + lldpFrames,
+		PolicerDrops: arpFrames - arpAdmitted,
+		QueueDrops:   arpAdmitted + lldpFrames - sinkSize,
+		Delivered:    sinkSize,
+	}
+	if d := cmp.Diff(got, want, protocmp.Transform()); d != "" {
+		t.Errorf("GetCPUPuntStats() unexpected diff (-got,+want):\n%s", d)
+	}
+	if got.Trapped != trapped {
+		t.Errorf("GetCPUPuntStats() got %d trapped packets, want the sum of the trap stats %d", got.Trapped, trapped)
+	}
+	if got.Delivered != trapped-got.PolicerDrops-got.QueueDrops {
+		t.Errorf("GetCPUPuntStats() got %d delivered packets, want %d trapped - %d policer drops - %d queue drops", got.Delivered, trapped, got.PolicerDrops, got.QueueDrops)
+	}
+	if sink.Len() != int(got.Delivered) {
+		t.Errorf("CPU sink got %d packets, want %d delivered", sink.Len(), got.Delivered)
+	}
+}
+
+func TestHostifTrapGroupQueue(t *testing.T) {
+	dplane := &fakeSwitchDataplane{}
+	c, _, stopFn := newTestHostif(t, dplane, false)
+	defer stopFn()
+	c.srv.initSwitch(switchID, 10)
+	ctx := context.Background()
+
+	groups := map[uint32]uint64{}
+	for _, queue := range []uint32{1, 2, 3} {
+		group, err := c.CreateHostifTrapGroup(ctx, &saipb.CreateHostifTrapGroupRequest{Queue: proto.Uint32(queue)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		groups[queue] = group.GetOid()
+	}
+	tests := []struct {
+		desc      string
+		trapType  saipb.HostifTrapType
+		group     uint64
+		wantQueue uint32
+	}{{
+		desc:      "dhcp",
+		trapType:  saipb.HostifTrapType_HOSTIF_TRAP_TYPE_DHCP,
+		group:     groups[1],
+		wantQueue: 1,
+	}, {
+		desc:      "lldp",
+		trapType:  saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP,
+		group:     groups[2],
+		wantQueue: 2,
+	}, {
+		desc:      "arp",
+		trapType:  saipb.HostifTrapType_HOSTIF_TRAP_TYPE_ARP_REQUEST,
+		group:     groups[3],
+		wantQueue: 3,
+	}, {
+		desc:      "no group",
+		trapType:  saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LACP,
+		wantQueue: 0,
+	}, {
+		desc:      "unknown group",
+		trapType:  saipb.HostifTrapType_HOSTIF_TRAP_TYPE_UDLD,
+		group:     1000,
+		wantQueue: 0,
+	}}
+	traps := map[string]uint64{}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dplane.gotEntryAddReqs = nil
+			trap, err := c.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+				TrapType:     tt.trapType.Enum(),
+				PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+				TrapGroup:    proto.Uint64(tt.group),
+			})
+			if err != nil {
+				t.Fatalf("CreateHostifTrap() unexpected err: %v", err)
+			}
+			traps[tt.desc] = trap.GetOid()
+			want := fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_ATTRIBUTE_32).
+				WithFieldIDInstance(cpuQueueAttrInstance).WithValue(binary.BigEndian.AppendUint32(nil, tt.wantQueue))).Build()
+			for _, entry := range dplane.gotEntryAddReqs[0].GetEntries() {
+				// The queue is set before the packet is transmitted to the CPU port.
+				acts := entry.GetActions()
+				if d := cmp.Diff(acts[len(acts)-2], want, protocmp.Transform()); d != "" {
+					t.Errorf("CreateHostifTrap() queue action unexpected diff (-got,+want):\n%s", d)
+				}
+			}
+		})
+	}
+
+	// A trap group can only be removed once none of its traps remain.
+	if _, err := c.RemoveHostifTrapGroup(ctx, &saipb.RemoveHostifTrapGroupRequest{Oid: groups[1]}); grpcstatus.Code(err) != codes.FailedPrecondition {
+		t.Errorf("RemoveHostifTrapGroup() of group with traps got err %v, want FailedPrecondition", err)
+	}
+	if _, err := c.RemoveHostifTrap(ctx, &saipb.RemoveHostifTrapRequest{Oid: traps["dhcp"]}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.RemoveHostifTrapGroup(ctx, &saipb.RemoveHostifTrapGroupRequest{Oid: groups[1]}); err != nil {
+		t.Errorf("RemoveHostifTrapGroup() of group without traps unexpected err: %v", err)
+	}
+	if _, err := c.RemoveHostifTrapGroup(ctx, &saipb.RemoveHostifTrapGroupRequest{Oid: groups[1]}); grpcstatus.Code(err) != codes.NotFound {
+		t.Errorf("RemoveHostifTrapGroup() of removed group got err %v, want NotFound", err)
+	}
+}
+
+func TestGetHostifTrapGroupAttribute(t *testing.T) {
+	c, _, stopFn := newTestHostif(t, &fakeSwitchDataplane{}, false)
+	defer stopFn()
+	c.srv.initSwitch(switchID, 10)
+	ctx := context.Background()
+
+	group, err := c.CreateHostifTrapGroup(ctx, &saipb.CreateHostifTrapGroupRequest{Queue: proto.Uint32(4)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	attrTypes := []saipb.HostifTrapGroupAttr{
+		saipb.HostifTrapGroupAttr_HOSTIF_TRAP_GROUP_ATTR_QUEUE,
+		saipb.HostifTrapGroupAttr_HOSTIF_TRAP_GROUP_ATTR_ADMIN_STATE,
+		saipb.HostifTrapGroupAttr_HOSTIF_TRAP_GROUP_ATTR_POLICER,
+	}
+	get := func() *saipb.HostifTrapGroupAttribute {
+		t.Helper()
+		resp, err := c.GetHostifTrapGroupAttribute(ctx, &saipb.GetHostifTrapGroupAttributeRequest{Oid: group.GetOid(), AttrType: attrTypes})
+		if err != nil {
+			t.Fatalf("GetHostifTrapGroupAttribute() unexpected err: %v", err)
+		}
+		return resp.GetAttr()
+	}
+
+	want := &saipb.HostifTrapGroupAttribute{
+		Queue:      proto.Uint32(4),
+		AdminState: proto.Bool(true),
+		Policer:    proto.Uint64(0),
+	}
+	if d := cmp.Diff(get(), want, protocmp.Transform()); d != "" {
+		t.Errorf("GetHostifTrapGroupAttribute() unexpected diff (-got,+want):\n%s", d)
+	}
+
+	if _, err := c.SetHostifTrapGroupAttribute(ctx, &saipb.SetHostifTrapGroupAttributeRequest{Oid: group.GetOid(), Queue: proto.Uint32(5)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.SetHostifTrapGroupAttribute(ctx, &saipb.SetHostifTrapGroupAttributeRequest{Oid: group.GetOid(), AdminState: proto.Bool(false)}); err != nil {
+		t.Fatal(err)
+	}
+	want = &saipb.HostifTrapGroupAttribute{
+		Queue:      proto.Uint32(5),
+		AdminState: proto.Bool(false),
+		Policer:    proto.Uint64(0),
+	}
+	if d := cmp.Diff(get(), want, protocmp.Transform()); d != "" {
+		t.Errorf("GetHostifTrapGroupAttribute() after set unexpected diff (-got,+want):\n%s", d)
+	}
+
+	_, err = c.GetHostifTrapGroupAttribute(ctx, &saipb.GetHostifTrapGroupAttributeRequest{Oid: 1000, AttrType: attrTypes})
+	if grpcstatus.Code(err) != codes.NotFound {
+		t.Errorf("GetHostifTrapGroupAttribute() of unknown group got err %v, want NotFound", err)
+	}
+}
+
+func TestGetHostifTrapAttribute(t *testing.T) {
+	c, mgr, stopFn := newTestHostif(t, &fakeSwitchDataplane{}, false)
+	defer stopFn()
+	c.srv.initSwitch(switchID, 10)
+	ctx := context.Background()
+
+	defaultGroup, err := c.CreateHostifTrapGroup(ctx, &saipb.CreateHostifTrapGroupRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mgr.StoreAttributes(switchID, &saipb.SwitchAttribute{DefaultTrapGroup: proto.Uint64(defaultGroup.GetOid())})
+	mgr.SetType("100", saipb.ObjectType_OBJECT_TYPE_POLICER)
+	group, err := c.CreateHostifTrapGroup(ctx, &saipb.CreateHostifTrapGroupRequest{Queue: proto.Uint32(3), Policer: proto.Uint64(100)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	groupResp, err := c.GetHostifTrapGroupAttribute(ctx, &saipb.GetHostifTrapGroupAttributeRequest{
+		Oid: group.GetOid(),
+		AttrType: []saipb.HostifTrapGroupAttr{
+			saipb.HostifTrapGroupAttr_HOSTIF_TRAP_GROUP_ATTR_QUEUE,
+			saipb.HostifTrapGroupAttr_HOSTIF_TRAP_GROUP_ATTR_ADMIN_STATE,
+			saipb.HostifTrapGroupAttr_HOSTIF_TRAP_GROUP_ATTR_POLICER,
+		},
+	})
+	if err != nil {
+		t.Fatalf("GetHostifTrapGroupAttribute() unexpected err: %v", err)
+	}
+	wantGroup := &saipb.HostifTrapGroupAttribute{
+		Queue:      proto.Uint32(3),
+		AdminState: proto.Bool(true),
+		Policer:    proto.Uint64(100),
+	}
+	if d := cmp.Diff(groupResp.GetAttr(), wantGroup, protocmp.Transform()); d != "" {
+		t.Errorf("GetHostifTrapGroupAttribute() unexpected diff (-got,+want):\n%s", d)
+	}
+
+	attrTypes := []saipb.HostifTrapAttr{
+		saipb.HostifTrapAttr_HOSTIF_TRAP_ATTR_TRAP_TYPE,
+		saipb.HostifTrapAttr_HOSTIF_TRAP_ATTR_PACKET_ACTION,
+		saipb.HostifTrapAttr_HOSTIF_TRAP_ATTR_TRAP_GROUP,
+		saipb.HostifTrapAttr_HOSTIF_TRAP_ATTR_TRAP_PRIORITY,
+	}
+	tests := []struct {
+		desc string
+		req  *saipb.CreateHostifTrapRequest
+		want *saipb.HostifTrapAttribute
+	}{{
+		desc: "all attributes",
+		req: &saipb.CreateHostifTrapRequest{
+			TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_COPY.Enum(),
+			TrapGroup:    proto.Uint64(group.GetOid()),
+			TrapPriority: proto.Uint32(5),
+		},
+		want: &saipb.HostifTrapAttribute{
+			TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_COPY.Enum(),
+			TrapGroup:    proto.Uint64(group.GetOid()),
+			TrapPriority: proto.Uint32(5),
+		},
+	}, {
+		desc: "default group",
+		req: &saipb.CreateHostifTrapRequest{
+			TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LACP.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+		},
+		want: &saipb.HostifTrapAttribute{
+			TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LACP.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+			TrapGroup:    proto.Uint64(defaultGroup.GetOid()),
+			TrapPriority: proto.Uint32(0),
+		},
+	}, {
+		desc: "ip2me",
+		req: &saipb.CreateHostifTrapRequest{
+			TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IP2ME.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+			TrapGroup:    proto.Uint64(group.GetOid()),
+		},
+		want: &saipb.HostifTrapAttribute{
+			TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IP2ME.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+			TrapGroup:    proto.Uint64(group.GetOid()),
+			TrapPriority: proto.Uint32(0),
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			trap, err := c.CreateHostifTrap(ctx, tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := c.GetHostifTrapAttribute(ctx, &saipb.GetHostifTrapAttributeRequest{Oid: trap.GetOid(), AttrType: attrTypes})
+			if err != nil {
+				t.Fatalf("GetHostifTrapAttribute() unexpected err: %v", err)
+			}
+			if d := cmp.Diff(resp.GetAttr(), tt.want, protocmp.Transform()); d != "" {
+				t.Errorf("GetHostifTrapAttribute() unexpected diff (-got,+want):\n%s", d)
+			}
+		})
+	}
+
+	_, err = c.GetHostifTrapAttribute(ctx, &saipb.GetHostifTrapAttributeRequest{Oid: 1000, AttrType: attrTypes})
+	if grpcstatus.Code(err) != codes.NotFound {
+		t.Errorf("GetHostifTrapAttribute() of unknown trap got err %v, want NotFound", err)
+	}
+}
+
+func TestHostifTrapPriority(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sink := packetutil.NewSink(1)
+	fwdCtx.FakePortManager = nopPortManager{}
+	fwdCtx.SetCPUPortSink(sink.CPUPortSink, func() {})
+
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	port := createTestPort(t, conn, 1)
+
+	// OSPFv3 packets to AllSPFRouters match both the ND trap, by their IPv6 multicast MAC, and the OSPFv3 trap.
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		&layers.Ethernet{
+			SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+			DstMAC:       net.HardwareAddr{0x33, 0x33, 0x00, 0x00, 0x00, 0x05},
+			EthernetType: layers.EthernetTypeIPv6,
+		}, &layers.IPv6{
+			Version:    6,
+			HopLimit:   1,
+			NextHeader: layers.IPProtocol(ipProtoOSPF),
+			SrcIP:      net.ParseIP("fe80::1"),
+			DstIP:      net.ParseIP("ff02::5"),
+		}, gopacket.Payload(make([]byte, 16))); err != nil {
+		t.Fatal(err)
+	}
+
+	type trap struct {
+		trapType saipb.HostifTrapType
+		priority uint32
+	}
+	nd := saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IPV6_NEIGHBOR_DISCOVERY
+	ospf := saipb.HostifTrapType_HOSTIF_TRAP_TYPE_OSPFV6
+	tests := []struct {
+		desc  string
+		traps []trap // traps are created in order.
+		want  saipb.HostifTrapType
+	}{{
+		desc:  "higher priority created last",
+		traps: []trap{{nd, 1}, {ospf, 10}},
+		want:  ospf,
+	}, {
+		desc:  "higher priority created first",
+		traps: []trap{{ospf, 10}, {nd, 1}},
+		want:  ospf,
+	}, {
+		desc:  "higher priority broader trap",
+		traps: []trap{{nd, 10}, {ospf, 1}},
+		want:  nd,
+	}, {
+		desc:  "equal priorities",
+		traps: []trap{{ospf, 5}, {nd, 5}},
+		want:  ospf,
+	}, {
+		desc:  "equal priorities reversed",
+		traps: []trap{{nd, 5}, {ospf, 5}},
+		want:  nd,
+	}, {
+		desc:  "equal default priorities",
+		traps: []trap{{ospf, 0}, {nd, 0}},
+		want:  ospf,
+	}}
+	hc := saipb.NewHostifClient(conn)
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ids := map[saipb.HostifTrapType]uint64{}
+			for _, tr := range tt.traps {
+				resp, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+					TrapType:     tr.trapType.Enum(),
+					PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+					TrapPriority: proto.Uint32(tr.priority),
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				ids[tr.trapType] = resp.GetOid()
+			}
+			defer func() {
+				for _, id := range ids {
+					if _, err := hc.RemoveHostifTrap(ctx, &saipb.RemoveHostifTrapRequest{Oid: id}); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}()
+
+			err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+				fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, buf.Bytes(), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := sink.Next(time.Second); err != nil {
+				t.Fatalf("packet not punted: %v", err)
+			}
+			for trapType, id := range ids {
+				stats, err := trapStats(ctx, conn, id)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := uint64(0)
+				if trapType == tt.want {
+					want = 1
+				}
+				if stats.Packets != want {
+					t.Errorf("GetHostifTrapStats(%v) got %d packets, want %d", trapType, stats.Packets, want)
+				}
+			}
+		})
+	}
+}
+
+func TestIP2METrapOrder(t *testing.T) {
+	tests := []struct {
+		desc      string
+		trapFirst bool
+	}{{
+		desc:      "trap before route",
+		trapFirst: true,
+	}, {
+		desc: "route before trap",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ctx := context.Background()
+			s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+			sink := packetutil.NewSink(1)
+			fwdCtx.FakePortManager = nopPortManager{}
+			fwdCtx.SetCPUPortSink(sink.CPUPortSink, func() {})
+
+			sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			swAttr, err := saipb.NewSwitchClient(conn).GetSwitchAttribute(ctx, &saipb.GetSwitchAttributeRequest{
+				Oid:      sw.GetOid(),
+				AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_CPU_PORT, saipb.SwitchAttr_SWITCH_ATTR_DEFAULT_VIRTUAL_ROUTER_ID},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			acceptAnyDstMAC(t, conn)
+			port := createTestPort(t, conn, 1)
+			if _, err := saipb.NewRouterInterfaceClient(conn).CreateRouterInterface(ctx, &saipb.CreateRouterInterfaceRequest{
+				Type:            saipb.RouterInterfaceType_ROUTER_INTERFACE_TYPE_PORT.Enum(),
+				PortId:          proto.Uint64(port),
+				VirtualRouterId: proto.Uint64(swAttr.GetAttr().GetDefaultVirtualRouterId()),
+				SrcMacAddress:   []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x02},
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			createTrap := func() {
+				if _, err := saipb.NewHostifClient(conn).CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+					TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IP2ME.Enum(),
+					PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			createRoute := func() {
+				if _, err := saipb.NewRouteClient(conn).CreateRouteEntry(ctx, &saipb.CreateRouteEntryRequest{
+					Entry: &saipb.RouteEntry{
+						SwitchId: sw.GetOid(),
+						VrId:     swAttr.GetAttr().GetDefaultVirtualRouterId(),
+						Destination: &saipb.IpPrefix{
+							Addr: []byte{192, 0, 2, 1},
+							Mask: []byte{255, 255, 255, 255},
+						},
+					},
+					NextHopId:    proto.Uint64(swAttr.GetAttr().GetCpuPort()),
+					PacketAction: saipb.PacketAction_PACKET_ACTION_FORWARD.Enum(),
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.trapFirst {
+				createTrap()
+				createRoute()
+			} else {
+				createRoute()
+				createTrap()
+			}
+
+			buf := gopacket.NewSerializeBuffer()
+			if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+				&layers.Ethernet{
+					SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+					DstMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x02},
+					EthernetType: layers.EthernetTypeIPv4,
+				}, &layers.IPv4{
+					Version:  4,
+					TTL:      64,
+					Protocol: layers.IPProtocolUDP,
+					SrcIP:    net.IPv4(192, 0, 2, 2).To4(),
+					DstIP:    net.IPv4(192, 0, 2, 1).To4(),
+				}, &layers.UDP{SrcPort: 5000, DstPort: 5000}, gopacket.Payload("data")); err != nil {
+				t.Fatal(err)
+			}
+			err = s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+				fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, buf.Bytes(), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkt, err := sink.Next(time.Second)
+			if err != nil {
+				t.Fatalf("packet to local address not punted: %v", err)
+			}
+			if ip := pkt.IPv4(); ip == nil || !ip.DstIP.Equal(net.IPv4(192, 0, 2, 1)) {
+				t.Errorf("punted packet got %v, want packet to 192.0.2.1", pkt)
+			}
+		})
+	}
+}
+
+func TestBGPTrapLocalAddress(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sink := packetutil.NewSink(1)
+	fwdCtx.FakePortManager = nopPortManager{}
+	fwdCtx.SetCPUPortSink(sink.CPUPortSink, func() {})
+
+	sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	swAttr, err := saipb.NewSwitchClient(conn).GetSwitchAttribute(ctx, &saipb.GetSwitchAttributeRequest{
+		Oid:      sw.GetOid(),
+		AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_CPU_PORT, saipb.SwitchAttr_SWITCH_ATTR_DEFAULT_VIRTUAL_ROUTER_ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	port := createTestPort(t, conn, 1)
+	if _, err := saipb.NewRouterInterfaceClient(conn).CreateRouterInterface(ctx, &saipb.CreateRouterInterfaceRequest{
+		Type:            saipb.RouterInterfaceType_ROUTER_INTERFACE_TYPE_PORT.Enum(),
+		PortId:          proto.Uint64(port),
+		VirtualRouterId: proto.Uint64(swAttr.GetAttr().GetDefaultVirtualRouterId()),
+		SrcMacAddress:   []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x02},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	hc := saipb.NewHostifClient(conn)
+	traps := map[saipb.HostifTrapType]uint64{}
+	for _, trapType := range []saipb.HostifTrapType{saipb.HostifTrapType_HOSTIF_TRAP_TYPE_BGP, saipb.HostifTrapType_HOSTIF_TRAP_TYPE_BGPV6} {
+		trap, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+			TrapType:     trapType.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		traps[trapType] = trap.GetOid()
+	}
+	// The local addresses are added after the traps, the traps are reprogrammed to match them.
+	localRoute := func(addr netip.Addr) *saipb.RouteEntry {
+		return &saipb.RouteEntry{
+			SwitchId: sw.GetOid(),
+			VrId:     swAttr.GetAttr().GetDefaultVirtualRouterId(),
+			Destination: &saipb.IpPrefix{
+				Addr: addr.AsSlice(),
+				Mask: net.CIDRMask(addr.BitLen(), addr.BitLen()),
+			},
+		}
+	}
+	rc := saipb.NewRouteClient(conn)
+	for _, addr := range []netip.Addr{netip.MustParseAddr("192.0.2.1"), netip.MustParseAddr("2001:db8::1")} {
+		if _, err := rc.CreateRouteEntry(ctx, &saipb.CreateRouteEntryRequest{
+			Entry:        localRoute(addr),
+			NextHopId:    proto.Uint64(swAttr.GetAttr().GetCpuPort()),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_FORWARD.Enum(),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bgpFrame := func(t *testing.T, dst netip.Addr) []byte {
+		t.Helper()
+		tcp := &layers.TCP{SrcPort: 50000, DstPort: 179, SYN: true, Window: 1024}
+		var ip gopacket.SerializableLayer
+		etherType := layers.EthernetTypeIPv4
+		if dst.Is4() {
+			ipv4 := &layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolTCP, SrcIP: net.IPv4(192, 0, 2, 2).To4(), DstIP: dst.AsSlice()}
+			if err := tcp.SetNetworkLayerForChecksum(ipv4); err != nil {
+				t.Fatal(err)
+			}
+			ip = ipv4
+		} else {
+			etherType = layers.EthernetTypeIPv6
+			ipv6 := &layers.IPv6{Version: 6, HopLimit: 64, NextHeader: layers.IPProtocolTCP, SrcIP: net.ParseIP("2001:db8::2"), DstIP: dst.AsSlice()}
+			if err := tcp.SetNetworkLayerForChecksum(ipv6); err != nil {
+				t.Fatal(err)
+			}
+			ip = ipv6
+		}
+		buf := gopacket.NewSerializeBuffer()
+		if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true},
+			&layers.Ethernet{
+				SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+				DstMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x02},
+				EthernetType: etherType,
+			}, ip, tcp); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	tests := []struct {
+		desc        string
+		dst         netip.Addr
+		trapType    saipb.HostifTrapType
+		wantTrapped bool
+	}{{
+		desc:        "local ipv4 address",
+		dst:         netip.MustParseAddr("192.0.2.1"),
+		trapType:    saipb.HostifTrapType_HOSTIF_TRAP_TYPE_BGP,
+		wantTrapped: true,
+	}, {
+		desc:     "third-party ipv4 address",
+		dst:      netip.MustParseAddr("198.51.100.9"),
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_BGP,
+	}, {
+		desc:        "local ipv6 address",
+		dst:         netip.MustParseAddr("2001:db8::1"),
+		trapType:    saipb.HostifTrapType_HOSTIF_TRAP_TYPE_BGPV6,
+		wantTrapped: true,
+	}, {
+		desc:     "third-party ipv6 address",
+		dst:      netip.MustParseAddr("2001:db8:ffff::9"),
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_BGPV6,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			before, err := trapStats(ctx, conn, traps[tt.trapType])
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+				fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, bgpFrame(t, tt.dst), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.wantTrapped {
+				if pkt, err := sink.Next(100 * time.Millisecond); err == nil {
+					t.Fatalf("BGP packet to %v unexpectedly punted: %v", tt.dst, pkt)
+				}
+			} else if _, err := sink.Next(time.Second); err != nil {
+				t.Fatalf("BGP packet to %v not punted: %v", tt.dst, err)
+			}
+			after, err := trapStats(ctx, conn, traps[tt.trapType])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if trapped := after.Packets > before.Packets; trapped != tt.wantTrapped {
+				t.Errorf("BGP packet to %v matched trap %v, want %v", tt.dst, trapped, tt.wantTrapped)
+			}
+		})
+	}
+
+	// Removing the local address removes the trap's entries for it.
+	if _, err := rc.RemoveRouteEntry(ctx, &saipb.RemoveRouteEntryRequest{Entry: localRoute(netip.MustParseAddr("192.0.2.1"))}); err != nil {
+		t.Fatal(err)
+	}
+	err = s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+		fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, bgpFrame(t, netip.MustParseAddr("192.0.2.1")), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkt, err := sink.Next(100 * time.Millisecond); err == nil {
+		t.Errorf("BGP packet to removed local address unexpectedly punted: %v", pkt)
+	}
+}
+
+func TestOSPFTrapVLANTagged(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sink := packetutil.NewSink(1)
+	fwdCtx.FakePortManager = nopPortManager{}
+	fwdCtx.SetCPUPortSink(sink.CPUPortSink, func() {})
+
+	sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	swAttr, err := saipb.NewSwitchClient(conn).GetSwitchAttribute(ctx, &saipb.GetSwitchAttributeRequest{
+		Oid:      sw.GetOid(),
+		AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_DEFAULT_VIRTUAL_ROUTER_ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	port := createTestPort(t, conn, 1)
+	if _, err := saipb.NewRouterInterfaceClient(conn).CreateRouterInterface(ctx, &saipb.CreateRouterInterfaceRequest{
+		Type:            saipb.RouterInterfaceType_ROUTER_INTERFACE_TYPE_PORT.Enum(),
+		PortId:          proto.Uint64(port),
+		VirtualRouterId: proto.Uint64(swAttr.GetAttr().GetDefaultVirtualRouterId()),
+		SrcMacAddress:   []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x02},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	hc := saipb.NewHostifClient(conn)
+	traps := map[saipb.HostifTrapType]uint64{}
+	for _, trapType := range []saipb.HostifTrapType{saipb.HostifTrapType_HOSTIF_TRAP_TYPE_OSPF, saipb.HostifTrapType_HOSTIF_TRAP_TYPE_OSPFV6} {
+		trap, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+			TrapType:     trapType.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		traps[trapType] = trap.GetOid()
+	}
+
+	// ospfFrame returns an OSPF hello to AllSPFRouters, optionally with an 802.1Q tag.
+	ospfFrame := func(t *testing.T, v6, tagged bool) []byte {
+		t.Helper()
+		eth := &layers.Ethernet{
+			SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+			DstMAC:       net.HardwareAddr{0x01, 0x00, 0x5E, 0x00, 0x00, 0x05},
+			EthernetType: layers.EthernetTypeIPv4,
+		}
+		var ip gopacket.SerializableLayer = &layers.IPv4{Version: 4, TTL: 1, Protocol: layers.IPProtocolOSPF, SrcIP: net.IPv4(192, 0, 2, 2).To4(), DstIP: net.IPv4(224, 0, 0, 5).To4()}
+		if v6 {
+			eth.DstMAC = net.HardwareAddr{0x33, 0x33, 0x00, 0x00, 0x00, 0x05}
+			eth.EthernetType = layers.EthernetTypeIPv6
+			ip = &layers.IPv6{Version: 6, HopLimit: 1, NextHeader: layers.IPProtocolOSPF, SrcIP: net.ParseIP("fe80::2"), DstIP: net.ParseIP("ff02::5")}
+		}
+		ls := []gopacket.SerializableLayer{eth}
+		if tagged {
+			ls = append(ls, &layers.Dot1Q{VLANIdentifier: 100, Type: eth.EthernetType})
+			eth.EthernetType = layers.EthernetTypeDot1Q
+		}
+		ls = append(ls, ip, gopacket.Payload(make([]byte, 44)))
+		buf := gopacket.NewSerializeBuffer()
+		if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}, ls...); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	tests := []struct {
+		desc     string
+		v6       bool
+		tagged   bool
+		trapType saipb.HostifTrapType
+	}{{
+		desc:     "ospf untagged",
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_OSPF,
+	}, {
+		desc:     "ospf tagged",
+		tagged:   true,
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_OSPF,
+	}, {
+		desc:     "ospfv3 untagged",
+		v6:       true,
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_OSPFV6,
+	}, {
+		desc:     "ospfv3 tagged",
+		v6:       true,
+		tagged:   true,
+		trapType: saipb.HostifTrapType_HOSTIF_TRAP_TYPE_OSPFV6,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			before, err := trapStats(ctx, conn, traps[tt.trapType])
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+				fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, ospfFrame(t, tt.v6, tt.tagged), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := sink.Next(time.Second); err != nil {
+				t.Fatalf("OSPF packet not punted: %v", err)
+			}
+			after, err := trapStats(ctx, conn, traps[tt.trapType])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := after.Packets - before.Packets; got != 1 {
+				t.Errorf("trap %v matched %d packets, want 1", tt.trapType, got)
+			}
+		})
+	}
+}
+
+func TestMultipleSwitchesTrapIsolation(t *testing.T) {
+	ctx := context.Background()
+	type asic struct {
+		s    *Server
+		conn grpc.ClientConnInterface
+		sink *packetutil.Sink
+		port uint64
+	}
+	var asics []*asic
+	for _, id := range []string{"asic0", "asic1"} {
+		s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithContextID(id), dplaneopts.WithRemoteCPUPort(true))
+		if got := s.ID(); got != id {
+			t.Fatalf("ID() got %q, want %q", got, id)
+		}
+		a := &asic{s: s, conn: conn, sink: packetutil.NewSink(10)}
+		fwdCtx.SetCPUPortSink(a.sink.CPUPortSink, func() {})
+
+		if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+			t.Fatal(err)
+		}
+		acceptAnyDstMAC(t, conn)
+		a.port = createTestPort(t, conn, 1)
+		asics = append(asics, a)
+	}
+
+	// Each switch traps a different protocol.
+	trapTypes := []saipb.HostifTrapType{saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP, saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LACP}
+	var traps []uint64
+	for i, a := range asics {
+		trap, err := saipb.NewHostifClient(a.conn).CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+			TrapType:     trapTypes[i].Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		traps = append(traps, trap.GetOid())
+	}
+
+	frames := [][]byte{lldpFrame(t, 64), lacpFrame(t, 64)}
+	for i, a := range asics {
+		for j, f := range frames {
+			err := a.s.InjectPacket(&fwdpb.ContextId{Id: a.s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(a.port)}},
+				fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, f, nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = a.sink.Next(100 * time.Millisecond)
+			if punted := err == nil; punted != (i == j) {
+				t.Errorf("switch %s: %v punted %v, want %v", a.s.ID(), trapTypes[j], punted, i == j)
+			}
+		}
+		stats, err := trapStats(ctx, a.conn, traps[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.Packets != 1 {
+			t.Errorf("switch %s: GetHostifTrapStats() got %d packets, want 1", a.s.ID(), stats.Packets)
+		}
+	}
+
+	// Removing the trap from one switch leaves the other's trap in place.
+	if _, err := saipb.NewHostifClient(asics[0].conn).RemoveHostifTrap(ctx, &saipb.RemoveHostifTrapRequest{Oid: traps[0]}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := trapStats(ctx, asics[1].conn, traps[1]); err != nil {
+		t.Errorf("GetHostifTrapStats() of the other switch's trap got err %v, want nil", err)
+	}
+}
+
+// floodTest is a switch with three ports in a VLAN that floods broadcasts to all of its ports.
+type floodTest struct {
+	s     *Server
+	conn  grpc.ClientConnInterface
+	ports []uint64
+	// sinks are the sinks of the ports, by lane.
+	sinks   map[string]*packetutil.Sink
+	cpuSink *packetutil.Sink
+}
+
+// newFloodTest returns a floodTest whose sinks hold up to size packets.
+func newFloodTest(t *testing.T, size int) *floodTest {
+	t.Helper()
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	ft := &floodTest{s: s, conn: conn, sinks: map[string]*packetutil.Sink{}, cpuSink: packetutil.NewSink(size)}
+	for _, lane := range []string{"1", "2", "3"} {
+		ft.sinks[lane] = packetutil.NewSink(size)
+	}
+	fwdCtx.FakePortManager = lanePortManager{sinks: ft.sinks}
+	fwdCtx.SetCPUPortSink(ft.cpuSink.CPUPortSink, func() {})
+
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	vc := saipb.NewVlanClient(conn)
+	vlan, err := vc.CreateVlan(ctx, &saipb.CreateVlanRequest{
+		VlanId: proto.Uint32(100),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	group, err := saipb.NewL2McGroupClient(conn).CreateL2McGroup(ctx, &saipb.CreateL2McGroupRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := uint32(1); i <= 3; i++ {
+		port := createTestPort(t, conn, i)
+		ft.ports = append(ft.ports, port)
+		bp, err := saipb.NewBridgeClient(conn).CreateBridgePort(ctx, &saipb.CreateBridgePortRequest{
+			Type:   saipb.BridgePortType_BRIDGE_PORT_TYPE_PORT.Enum(),
+			PortId: proto.Uint64(port),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := vc.CreateVlanMember(ctx, &saipb.CreateVlanMemberRequest{
+			VlanId:          proto.Uint64(vlan.GetOid()),
+			BridgePortId:    proto.Uint64(bp.GetOid()),
+			VlanTaggingMode: saipb.VlanTaggingMode_VLAN_TAGGING_MODE_UNTAGGED.Enum(),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := saipb.NewL2McGroupClient(conn).CreateL2McGroupMember(ctx, &saipb.CreateL2McGroupMemberRequest{
+			L2McGroupId:  proto.Uint64(group.GetOid()),
+			L2McOutputId: proto.Uint64(bp.GetOid()),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := vc.SetVlanAttribute(ctx, &saipb.SetVlanAttributeRequest{
+		Oid:                       vlan.GetOid(),
+		BroadcastFloodControlType: saipb.VlanFloodControlType_VLAN_FLOOD_CONTROL_TYPE_L2MC_GROUP.Enum(),
+		BroadcastFloodGroup:       proto.Uint64(group.GetOid()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return ft
+}
+
+// injectARPRequest injects a broadcast ARP request on the first port.
+func (ft *floodTest) injectARPRequest(t *testing.T) {
+	t.Helper()
+	srcMAC := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		&layers.Ethernet{
+			SrcMAC:       srcMAC,
+			DstMAC:       layers.EthernetBroadcast,
+			EthernetType: layers.EthernetTypeARP,
+		}, &layers.ARP{
+			AddrType:          layers.LinkTypeEthernet,
+			Protocol:          layers.EthernetTypeIPv4,
+			HwAddressSize:     6,
+			ProtAddressSize:   4,
+			Operation:         layers.ARPRequest,
+			SourceHwAddress:   srcMAC,
+			SourceProtAddress: net.IPv4(192, 0, 2, 1).To4(),
+			DstHwAddress:      make([]byte, 6),
+			DstProtAddress:    net.IPv4(192, 0, 2, 2).To4(),
+		}); err != nil {
+		t.Fatal(err)
+	}
+	err := ft.s.InjectPacket(&fwdpb.ContextId{Id: ft.s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(ft.ports[0])}},
+		fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, buf.Bytes(), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHostifTrapCopy(t *testing.T) {
+	tests := []struct {
+		desc        string
+		action      saipb.PacketAction
+		wantFlooded bool
+	}{{
+		desc:        "copy",
+		action:      saipb.PacketAction_PACKET_ACTION_COPY,
+		wantFlooded: true,
+	}, {
+		desc:   "trap",
+		action: saipb.PacketAction_PACKET_ACTION_TRAP,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ft := newFloodTest(t, 1)
+			if _, err := saipb.NewHostifClient(ft.conn).CreateHostifTrap(context.Background(), &saipb.CreateHostifTrapRequest{
+				TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_ARP_REQUEST.Enum(),
+				PacketAction: tt.action.Enum(),
+			}); err != nil {
+				t.Fatal(err)
+			}
+			ft.injectARPRequest(t)
+
+			// Both actions punt the packet to the CPU.
+			pkt, err := ft.cpuSink.Next(time.Second)
+			if err != nil {
+				t.Fatalf("packet not punted: %v", err)
+			}
+			if pkt.ARP() == nil {
+				t.Errorf("punted packet is not ARP: %v", pkt)
+			}
+			// Only the original of a copied packet continues through the pipeline and is flooded.
+			for _, lane := range []string{"2", "3"} {
+				pkt, err := ft.sinks[lane].Next(100 * time.Millisecond)
+				if flooded := err == nil; flooded != tt.wantFlooded {
+					t.Errorf("lane %s: got flooded %v (pkt %v), want %v", lane, flooded, pkt, tt.wantFlooded)
+				}
+			}
+		})
+	}
+}
+
+func TestHostifTrapCopyPolicer(t *testing.T) {
+	const (
+		burst = 10
+		// frameSize is the size of an unpadded ARP request frame.
+		frameSize = 42
+		// The policer's burst only fits 3 frames and its rate is too low to refill during the test.
+		wantCopies = 3
+	)
+	ctx := context.Background()
+	ft := newFloodTest(t, burst)
+
+	policer, err := saipb.NewPolicerClient(ft.conn).CreatePolicer(ctx, &saipb.CreatePolicerRequest{
+		MeterType: saipb.MeterType_METER_TYPE_BYTES.Enum(),
+		Mode:      saipb.PolicerMode_POLICER_MODE_SR_TCM.Enum(),
+		Cbs:       proto.Uint64(wantCopies * frameSize),
+		Cir:       proto.Uint64(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc := saipb.NewHostifClient(ft.conn)
+	trapGroup, err := hc.CreateHostifTrapGroup(ctx, &saipb.CreateHostifTrapGroupRequest{
+		Policer: proto.Uint64(policer.GetOid()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+		TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_ARP_REQUEST.Enum(),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_COPY.Enum(),
+		TrapGroup:    proto.Uint64(trapGroup.GetOid()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < burst; i++ {
+		ft.injectARPRequest(t)
+	}
+
+	// Every ARP request is flooded, regardless of the policer.
+	for _, lane := range []string{"2", "3"} {
+		for i := 0; i < burst; i++ {
+			pkt, err := ft.sinks[lane].Next(time.Second)
+			if err != nil {
+				t.Fatalf("lane %s: got %d flooded frames, want %d: %v", lane, i, burst, err)
+			}
+			if pkt.ARP() == nil {
+				t.Errorf("lane %s: flooded frame is not ARP: %v", lane, pkt)
+			}
+		}
+	}
+	// Only the copies within the policer's burst are punted.
+	for i := 0; i < wantCopies; i++ {
+		pkt, err := ft.cpuSink.Next(time.Second)
+		if err != nil {
+			t.Fatalf("got %d punted copies, want %d: %v", i, wantCopies, err)
+		}
+		if pkt.ARP() == nil {
+			t.Errorf("punted copy is not ARP: %v", pkt)
+		}
+	}
+	if pkt, err := ft.cpuSink.Next(100 * time.Millisecond); err == nil {
+		t.Errorf("copy above the policer's rate punted: %v", pkt)
+	}
+}
+
+func TestGenetlinkHostifQueue(t *testing.T) {
+	tests := []struct {
+		desc      string
+		queue     *uint32
+		wantQueue uint32
+	}{{
+		desc:      "non-default queue",
+		queue:     proto.Uint32(3),
+		wantQueue: 3,
+	}, {
+		desc:      "no queue",
+		wantQueue: 0,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ctx := context.Background()
+			s, conn, fwdCtx := newTestSaiServer(t)
+			sink := packetutil.NewSink(1)
+			fwdCtx.SetPacketSink(sink.PacketSink)
+
+			sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			swAttr, err := saipb.NewSwitchClient(conn).GetSwitchAttribute(ctx, &saipb.GetSwitchAttributeRequest{
+				Oid:      sw.GetOid(),
+				AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_CPU_PORT},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			hc := saipb.NewHostifClient(conn)
+			hif, err := hc.CreateHostif(ctx, &saipb.CreateHostifRequest{
+				Type:               saipb.HostifType_HOSTIF_TYPE_GENETLINK.Enum(),
+				Name:               []byte("psample"),
+				GenetlinkMcgrpName: []byte("packets"),
+				Queue:              tt.queue,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			trap, err := hc.CreateHostifUserDefinedTrap(ctx, &saipb.CreateHostifUserDefinedTrapRequest{
+				Type: saipb.HostifUserDefinedTrapType_HOSTIF_USER_DEFINED_TRAP_TYPE_ACL.Enum(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := hc.CreateHostifTableEntry(ctx, &saipb.CreateHostifTableEntryRequest{
+				Type:   saipb.HostifTableEntryType_HOSTIF_TABLE_ENTRY_TYPE_TRAP_ID.Enum(),
+				TrapId: proto.Uint64(trap.GetOid()),
+				HostIf: proto.Uint64(hif.GetOid()),
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			// Punt the packet the same way an ACL with a user defined trap does: set the trap ID and output it to the CPU port.
+			preActions := []*fwdpb.ActionDesc{
+				fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_TRAP_ID).WithUint64Value(trap.GetOid())).Build(),
+			}
+			frame := make([]byte, 64)
+			copy(frame, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02, 0x00, 0x00, 0x00, 0x00, 0x01, 0x88, 0xb5})
+			err = s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(swAttr.GetAttr().GetCpuPort())}},
+				fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, frame, preActions, false, fwdpb.PortAction_PORT_ACTION_OUTPUT)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkt, err := sink.Next(time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotQueue uint32
+			for _, f := range pkt.Info.GetParsedFields() {
+				if proto.Equal(f.GetFieldId(), cpuQueueFieldID()) {
+					gotQueue = binary.BigEndian.Uint32(f.GetBytes())
+				}
+			}
+			if gotQueue != tt.wantQueue {
+				t.Errorf("punted packet got queue %d, want %d", gotQueue, tt.wantQueue)
+			}
+		})
+	}
+}
+
+func TestGenetlinkNetDevHostif(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithHostifNetDevGenetlink("lucius", "packets"))
+	var portInfos []*fwdpb.PortDesc
+	sink := packetutil.NewSink(3)
+	fwdCtx.FakePortManager = nopPortManager{}
+	fwdCtx.SetPacketSink(func(resp *fwdpb.PacketSinkResponse) error {
+		if port := resp.GetPort(); port != nil {
+			portInfos = append(portInfos, port.GetPort())
+		}
+		return sink.PacketSink(resp)
+	})
+
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	hc := saipb.NewHostifClient(conn)
+	portToHostif := map[uint64]uint64{}
+	var ports []uint64
+	for i := 1; i <= 3; i++ {
+		port := createTestPort(t, conn, uint32(i))
+		hif, err := hc.CreateHostif(ctx, &saipb.CreateHostifRequest{
+			Type:  saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+			ObjId: proto.Uint64(port),
+			Name:  []byte(fmt.Sprintf("Ethernet%d", i)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ports = append(ports, port)
+		portToHostif[port] = hif.GetOid()
+	}
+	// All the hostifs share the same genetlink family and group.
+	for _, desc := range portInfos {
+		if desc.GetPortType() != fwdpb.PortType_PORT_TYPE_GENETLINK {
+			t.Errorf("port info for %v got type %v, want %v", desc.GetPortId(), desc.GetPortType(), fwdpb.PortType_PORT_TYPE_GENETLINK)
+		}
+		want := &fwdpb.GenetlinkPortDesc{FamilyName: "lucius", GroupName: "packets"}
+		if got := desc.GetGenetlink(); !proto.Equal(got, want) {
+			t.Errorf("port info for %v got genetlink %v, want %v", desc.GetPortId(), got, want)
+		}
+	}
+	if _, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+		TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP.Enum(),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	frame := lldpFrame(t, 64)
+	for _, port := range ports {
+		err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+			fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, frame, nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkt, err := sink.Next(time.Second)
+		if err != nil {
+			t.Fatalf("packet received on port %d not punted: %v", port, err)
+		}
+		checkLLDP(t, pkt)
+		if got, want := pkt.Info.GetEgress().GetObjectId().GetId(), fmt.Sprint(portToHostif[port]); got != want {
+			t.Errorf("packet received on port %d punted to hostif %s, want %s", port, got, want)
+		}
+		if got, want := pkt.Info.GetIngress().GetObjectId().GetId(), fmt.Sprint(port); got != want {
+			t.Errorf("packet received on port %d got ingress %s, want %s", port, got, want)
+		}
+	}
+}
+
+// capturePortManager creates fake ports that never receive packets and buffer written packets in the sink.
+type capturePortManager struct {
+	sink *packetutil.Sink
+}
+
+func (m capturePortManager) CreatePort(string) (fwdcontext.Port, error) {
+	return capturePort{sink: m.sink}, nil
+}
+
+type capturePort struct {
+	nopPort
+	sink *packetutil.Sink
+}
+
+func (p capturePort) WritePacketData(data []byte) error {
+	return p.sink.WritePacketData(data)
+}
+
+func TestSubPortHostif(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	cpuSink := packetutil.NewSink(1)
+	portSink := packetutil.NewSink(1)
+	fwdCtx.FakePortManager = capturePortManager{sink: portSink}
+	fwdCtx.SetCPUPortSink(cpuSink.CPUPortSink, func() {})
+	s.saiSwitch.hostif.remotePortReq = func(*pktiopb.HostPortControlMessage) (*pktiopb.HostPortControlRequest, error) {
+		return &pktiopb.HostPortControlRequest{}, nil
+	}
+
+	sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	swAttr, err := saipb.NewSwitchClient(conn).GetSwitchAttribute(ctx, &saipb.GetSwitchAttributeRequest{
+		Oid:      sw.GetOid(),
+		AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_CPU_PORT},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpuPortID := &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(swAttr.GetAttr().GetCpuPort())}}
+	port := createTestPort(t, conn, 1)
+	rif, err := saipb.NewRouterInterfaceClient(conn).CreateRouterInterface(ctx, &saipb.CreateRouterInterfaceRequest{
+		Type:        saipb.RouterInterfaceType_ROUTER_INTERFACE_TYPE_SUB_PORT.Enum(),
+		PortId:      proto.Uint64(port),
+		OuterVlanId: proto.Uint32(100),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hif, err := saipb.NewHostifClient(conn).CreateHostif(ctx, &saipb.CreateHostifRequest{
+		Type:  saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+		ObjId: proto.Uint64(rif.GetOid()),
+		Name:  []byte("eth1.100"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nid, err := s.ObjectNID(ctx, &fwdpb.ObjectNIDRequest{
+		ContextId: &fwdpb.ContextId{Id: s.ID()},
+		ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(port)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serialize := func(t *testing.T, l ...gopacket.SerializableLayer) []byte {
+		t.Helper()
+		buf := gopacket.NewSerializeBuffer()
+		if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true}, l...); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	eth := &layers.Ethernet{
+		SrcMAC: net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+		DstMAC: net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x02},
+	}
+	payload := gopacket.Payload(make([]byte, 46))
+	experimental := layers.EthernetType(0x88b5)
+
+	t.Run("tx", func(t *testing.T) {
+		eth.EthernetType = experimental
+		// Inject the frame the same way CPUPacketStream does for a packet from the hostif.
+		acts := []*fwdpb.ActionDesc{fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_HOST_PORT_ID).
+			WithUint64Value(hif.GetOid())).Build()}
+		if err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, cpuPortID, fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET,
+			serialize(t, eth, payload), acts, true, fwdpb.PortAction_PORT_ACTION_INPUT); err != nil {
+			t.Fatal(err)
+		}
+		pkt, err := portSink.Next(time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tag := pkt.Dot1Q()
+		if tag == nil {
+			t.Fatalf("transmitted packet is untagged: %v", pkt)
+		}
+		if tag.VLANIdentifier != 100 || tag.Type != experimental {
+			t.Errorf("transmitted packet got vlan %d, type %v, want vlan 100, type %v", tag.VLANIdentifier, tag.Type, experimental)
+		}
+	})
+	// punt sends a frame tagged with VLAN 100 to the CPU port as if it was received on the parent port.
+	punt := func(t *testing.T) *packetutil.Packet {
+		t.Helper()
+		eth.EthernetType = layers.EthernetTypeDot1Q
+		frame := serialize(t, eth, &layers.Dot1Q{VLANIdentifier: 100, Type: experimental}, payload)
+		acts := []*fwdpb.ActionDesc{fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT).
+			WithUint64Value(nid.GetNid())).Build()}
+		if err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, cpuPortID, fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET,
+			frame, acts, false, fwdpb.PortAction_PORT_ACTION_OUTPUT); err != nil {
+			t.Fatal(err)
+		}
+		pkt, err := cpuSink.Next(time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pkt
+	}
+	t.Run("rx", func(t *testing.T) {
+		pkt := punt(t)
+		if got := pkt.Out.GetPacket().GetHostPort(); got != hif.GetOid() {
+			t.Errorf("punted packet got host port %d, want %d", got, hif.GetOid())
+		}
+		if pkt.Dot1Q() != nil {
+			t.Errorf("punted packet is tagged, want untagged: %v", pkt)
+		}
+		if got := pkt.Ethernet().EthernetType; got != experimental {
+			t.Errorf("punted packet got ethertype %v, want %v", got, experimental)
+		}
+	})
+	t.Run("removed", func(t *testing.T) {
+		if _, err := saipb.NewHostifClient(conn).RemoveHostif(ctx, &saipb.RemoveHostifRequest{Oid: hif.GetOid()}); err != nil {
+			t.Fatal(err)
+		}
+		pkt := punt(t)
+		if got := pkt.Out.GetPacket().GetHostPort(); got == hif.GetOid() {
+			t.Errorf("punted packet got removed host port %d", got)
+		}
+		if pkt.Dot1Q() == nil {
+			t.Errorf("punted packet is untagged, want tagged: %v", pkt)
+		}
+	})
+}
+
+func TestHostifVlanTag(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	cpuSink := packetutil.NewSink(1)
+	fwdCtx.FakePortManager = nopPortManager{}
+	fwdCtx.SetCPUPortSink(cpuSink.CPUPortSink, func() {})
+	s.saiSwitch.hostif.remotePortReq = func(*pktiopb.HostPortControlMessage) (*pktiopb.HostPortControlRequest, error) {
+		return &pktiopb.HostPortControlRequest{}, nil
+	}
+
+	sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	swAttr, err := saipb.NewSwitchClient(conn).GetSwitchAttribute(ctx, &saipb.GetSwitchAttributeRequest{
+		Oid:      sw.GetOid(),
+		AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_CPU_PORT},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpuPortID := &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(swAttr.GetAttr().GetCpuPort())}}
+	port := createTestPort(t, conn, 1)
+	hc := saipb.NewHostifClient(conn)
+	hif, err := hc.CreateHostif(ctx, &saipb.CreateHostifRequest{
+		Type:    saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+		ObjId:   proto.Uint64(port),
+		Name:    []byte("eth1"),
+		VlanTag: saipb.HostifVlanTag_HOSTIF_VLAN_TAG_STRIP.Enum(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nid, err := s.ObjectNID(ctx, &fwdpb.ObjectNIDRequest{
+		ContextId: &fwdpb.ContextId{Id: s.ID()},
+		ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(port)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	experimental := layers.EthernetType(0x88b5)
+	// punt sends a frame to the CPU port as if it was received on the port, and returns the punted frame.
+	punt := func(t *testing.T, tagged bool) *packetutil.Packet {
+		t.Helper()
+		eth := &layers.Ethernet{
+			SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+			DstMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x02},
+			EthernetType: experimental,
+		}
+		l := []gopacket.SerializableLayer{eth}
+		if tagged {
+			eth.EthernetType = layers.EthernetTypeDot1Q
+			l = append(l, &layers.Dot1Q{VLANIdentifier: 100, Type: experimental})
+		}
+		l = append(l, gopacket.Payload(make([]byte, 46)))
+		buf := gopacket.NewSerializeBuffer()
+		if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true}, l...); err != nil {
+			t.Fatal(err)
+		}
+		acts := []*fwdpb.ActionDesc{fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT).
+			WithUint64Value(nid.GetNid())).Build()}
+		if err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, cpuPortID, fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET,
+			buf.Bytes(), acts, false, fwdpb.PortAction_PORT_ACTION_OUTPUT); err != nil {
+			t.Fatal(err)
+		}
+		pkt, err := cpuSink.Next(time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := pkt.Out.GetPacket().GetHostPort(); got != hif.GetOid() {
+			t.Errorf("punted packet got host port %d, want %d", got, hif.GetOid())
+		}
+		if got := pkt.Ethernet().EthernetType; got != experimental && pkt.Dot1Q() == nil {
+			t.Errorf("punted packet got ethertype %v, want %v", got, experimental)
+		}
+		return pkt
+	}
+
+	tests := []struct {
+		desc       string
+		vlanTag    saipb.HostifVlanTag
+		tagged     bool
+		wantTagged bool
+	}{{
+		desc:       "strip tagged",
+		vlanTag:    saipb.HostifVlanTag_HOSTIF_VLAN_TAG_STRIP,
+		tagged:     true,
+		wantTagged: false,
+	}, {
+		desc:       "strip untagged",
+		vlanTag:    saipb.HostifVlanTag_HOSTIF_VLAN_TAG_STRIP,
+		tagged:     false,
+		wantTagged: false,
+	}, {
+		desc:       "original tagged",
+		vlanTag:    saipb.HostifVlanTag_HOSTIF_VLAN_TAG_ORIGINAL,
+		tagged:     true,
+		wantTagged: true,
+	}, {
+		desc:       "original untagged",
+		vlanTag:    saipb.HostifVlanTag_HOSTIF_VLAN_TAG_ORIGINAL,
+		tagged:     false,
+		wantTagged: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if _, err := hc.SetHostifAttribute(ctx, &saipb.SetHostifAttributeRequest{
+				Oid:     hif.GetOid(),
+				VlanTag: tt.vlanTag.Enum(),
+			}); err != nil {
+				t.Fatal(err)
+			}
+			attr, err := hc.GetHostifAttribute(ctx, &saipb.GetHostifAttributeRequest{
+				Oid:      hif.GetOid(),
+				AttrType: []saipb.HostifAttr{saipb.HostifAttr_HOSTIF_ATTR_VLAN_TAG},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := attr.GetAttr().GetVlanTag(); got != tt.vlanTag {
+				t.Errorf("GetHostifAttribute() got VLAN tag mode %v, want %v", got, tt.vlanTag)
+			}
+			pkt := punt(t, tt.tagged)
+			if tagged := pkt.Dot1Q() != nil; tagged != tt.wantTagged {
+				t.Errorf("punted packet tagged %v, want %v: %v", tagged, tt.wantTagged, pkt)
+			}
+		})
+	}
+}
+
+func TestCreateHostifTableEntryReplace(t *testing.T) {
+	dplane := &fakeSwitchDataplane{}
+	c, _, stopFn := newTestHostif(t, dplane, false)
+	defer stopFn()
+	c.srv.initSwitch(switchID, 10)
+	ctx := context.Background()
+
+	const trapID = 5
+	for _, hostifID := range []uint64{10, 11} {
+		if _, err := c.CreateHostifTableEntry(ctx, &saipb.CreateHostifTableEntryRequest{
+			Type:   saipb.HostifTableEntryType_HOSTIF_TABLE_ENTRY_TYPE_TRAP_ID.Enum(),
+			TrapId: proto.Uint64(trapID),
+			HostIf: proto.Uint64(hostifID),
+		}); err != nil {
+			t.Fatalf("CreateHostifTableEntry(hostif %d) unexpected err: %v", hostifID, err)
+		}
+	}
+
+	var adds []*fwdpb.TableEntryAddRequest_Entry
+	for _, req := range dplane.gotEntryAddReqs {
+		if req.GetTableId().GetObjectId().GetId() == trapIDToHostifTable {
+			adds = append(adds, req.GetEntries()...)
+		}
+	}
+	var removes []*fwdpb.EntryDesc
+	for _, req := range dplane.gotEntryRemoveReqs {
+		if req.GetTableId().GetObjectId().GetId() == trapIDToHostifTable {
+			removes = append(removes, req.GetEntries()...)
+		}
+	}
+	// The first entry must be removed before the replacement is added, leaving only one entry.
+	if len(adds) != 2 || len(removes) != 1 {
+		t.Fatalf("got %d adds and %d removes of trap id entries, want 2 adds and 1 remove", len(adds), len(removes))
+	}
+	if d := cmp.Diff(removes[0], adds[0].GetEntryDesc(), protocmp.Transform()); d != "" {
+		t.Errorf("removed entry is not the original entry: diff(-got,+want)\n:%s", d)
+	}
+	if got := binary.BigEndian.Uint64(adds[1].GetActions()[0].GetUpdate().GetValue()); got != 11 {
+		t.Errorf("trap id entry sets hostif %d, want 11", got)
+	}
+	if got := c.srv.trapIDToHostifID[trapID]; got != 11 {
+		t.Errorf("trap %d mapped to hostif %d, want 11", trapID, got)
+	}
+}
+
+func TestHostifTableEntryWildcard(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sink := packetutil.NewSink(2)
+	fwdCtx.FakePortManager = nopPortManager{}
+	fwdCtx.SetCPUPortSink(sink.CPUPortSink, func() {})
+
+	sc := saipb.NewSwitchClient(conn)
+	sw, err := sc.CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	port := createTestPort(t, conn, 1)
+
+	// The mapped trap is delivered to its hostif, the unmapped trap to the wildcard hostif.
+	const (
+		mappedHostif   = 100
+		wildcardHostif = 200
+	)
+	hc := saipb.NewHostifClient(conn)
+	var traps []uint64
+	for i := 0; i < 2; i++ {
+		trap, err := hc.CreateHostifUserDefinedTrap(ctx, &saipb.CreateHostifUserDefinedTrapRequest{
+			Type: saipb.HostifUserDefinedTrapType_HOSTIF_USER_DEFINED_TRAP_TYPE_ACL.Enum(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		traps = append(traps, trap.GetOid())
+	}
+	mappedTrap, unmappedTrap := traps[0], traps[1]
+	for _, req := range []*saipb.CreateHostifTableEntryRequest{{
+		Type:   saipb.HostifTableEntryType_HOSTIF_TABLE_ENTRY_TYPE_TRAP_ID.Enum(),
+		TrapId: proto.Uint64(mappedTrap),
+		HostIf: proto.Uint64(mappedHostif),
+	}, {
+		Type:   saipb.HostifTableEntryType_HOSTIF_TABLE_ENTRY_TYPE_WILDCARD.Enum(),
+		HostIf: proto.Uint64(wildcardHostif),
+	}} {
+		if _, err := hc.CreateHostifTableEntry(ctx, req); err != nil {
+			t.Fatalf("CreateHostifTableEntry(%v) unexpected err: %v", req, err)
+		}
+	}
+
+	ac := saipb.NewAclClient(conn)
+	group, err := ac.CreateAclTableGroup(ctx, &saipb.CreateAclTableGroupRequest{
+		AclStage: saipb.AclStage_ACL_STAGE_PRE_INGRESS.Enum(),
+		Type:     saipb.AclTableGroupType_ACL_TABLE_GROUP_TYPE_PARALLEL.Enum(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := ac.CreateAclTable(ctx, &saipb.CreateAclTableRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ac.CreateAclTableGroupMember(ctx, &saipb.CreateAclTableGroupMemberRequest{
+		AclTableGroupId: proto.Uint64(group.GetOid()),
+		AclTableId:      proto.Uint64(table.GetOid()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	trapEtherTypes := map[uint64]uint64{mappedTrap: 0x88b5, unmappedTrap: 0x88b6}
+	for trap, etherType := range trapEtherTypes {
+		if _, err := ac.CreateAclEntry(ctx, &saipb.CreateAclEntryRequest{
+			TableId:  proto.Uint64(table.GetOid()),
+			Priority: proto.Uint32(1),
+			FieldEtherType: &saipb.AclFieldData{
+				Data: &saipb.AclFieldData_DataUint{DataUint: etherType},
+				Mask: &saipb.AclFieldData_MaskUint{MaskUint: 0xffff},
+			},
+			ActionSetUserTrapId: &saipb.AclActionData{
+				Parameter: &saipb.AclActionData_Oid{Oid: trap},
+			},
+			ActionPacketAction: &saipb.AclActionData{
+				Parameter: &saipb.AclActionData_PacketAction{PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP},
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := sc.SetSwitchAttribute(ctx, &saipb.SetSwitchAttributeRequest{
+		Oid:           sw.GetOid(),
+		PreIngressAcl: proto.Uint64(group.GetOid()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		trap       uint64
+		wantHostif uint64
+	}{{
+		trap:       mappedTrap,
+		wantHostif: mappedHostif,
+	}, {
+		trap:       unmappedTrap,
+		wantHostif: wildcardHostif,
+	}} {
+		frame := make([]byte, 64)
+		copy(frame, []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x02, 0x02, 0x00, 0x00, 0x00, 0x00, 0x01})
+		binary.BigEndian.PutUint16(frame[12:], uint16(trapEtherTypes[tt.trap]))
+		err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+			fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, frame, nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkt, err := sink.Next(time.Second)
+		if err != nil {
+			t.Fatalf("packet of trap %d not punted: %v", tt.trap, err)
+		}
+		if got := pkt.Out.GetPacket().GetHostPort(); got != tt.wantHostif {
+			t.Errorf("packet of trap %d delivered to hostif %d, want %d", tt.trap, got, tt.wantHostif)
+		}
+	}
+}
+
+func TestLookupHostifTableEntry(t *testing.T) {
+	ctx := context.Background()
+	_, conn, _ := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	hc := saipb.NewHostifClient(conn)
+	for _, req := range []*saipb.CreateHostifTableEntryRequest{{
+		Type:   saipb.HostifTableEntryType_HOSTIF_TABLE_ENTRY_TYPE_TRAP_ID.Enum(),
+		TrapId: proto.Uint64(5),
+		HostIf: proto.Uint64(10),
+	}, {
+		Type:   saipb.HostifTableEntryType_HOSTIF_TABLE_ENTRY_TYPE_WILDCARD.Enum(),
+		TrapId: proto.Uint64(6),
+	}} {
+		if _, err := hc.CreateHostifTableEntry(ctx, req); err != nil {
+			t.Fatalf("CreateHostifTableEntry(%v) unexpected err: %v", req, err)
+		}
+	}
+
+	tests := []struct {
+		desc     string
+		trapID   uint64
+		want     *diagpb.LookupHostifTableEntryResponse
+		wantCode codes.Code
+	}{{
+		desc:   "trap id entry",
+		trapID: 5,
+		want:   &diagpb.LookupHostifTableEntryResponse{Hostif: 10},
+	}, {
+		desc:   "wildcard entry",
+		trapID: 6,
+		want:   &diagpb.LookupHostifTableEntryResponse{Wildcard: true},
+	}, {
+		desc:     "no entry",
+		trapID:   7,
+		wantCode: codes.NotFound,
+	}}
+	dc := diagpb.NewDiagClient(conn)
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := dc.LookupHostifTableEntry(ctx, &diagpb.LookupHostifTableEntryRequest{TrapId: tt.trapID})
+			if code := grpcstatus.Code(err); code != tt.wantCode {
+				t.Fatalf("LookupHostifTableEntry(%d) got code %v (err %v), want %v", tt.trapID, code, err, tt.wantCode)
+			}
+			if d := cmp.Diff(got, tt.want, protocmp.Transform()); d != "" {
+				t.Errorf("LookupHostifTableEntry(%d) unexpected result: diff(-got,+want)\n:%s", tt.trapID, d)
+			}
+		})
+	}
+}
+
+// waitHostifStats waits for the hostif counters to reach want.
+func waitHostifStats(t *testing.T, dc diagpb.DiagClient, hif uint64, want []uint64) {
+	t.Helper()
+	req := &diagpb.GetHostifStatsRequest{
+		Oid: hif,
+		CounterIds: []diagpb.HostifStat{
+			diagpb.HostifStat_HOSTIF_STAT_IN_PACKETS,
+			diagpb.HostifStat_HOSTIF_STAT_IN_BYTES,
+			diagpb.HostifStat_HOSTIF_STAT_OUT_PACKETS,
+			diagpb.HostifStat_HOSTIF_STAT_OUT_BYTES,
+		},
+	}
+	var got []uint64
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+		resp, err := dc.GetHostifStats(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = resp.GetValues()
+		if d := cmp.Diff(got, want); d == "" {
+			return
+		}
+	}
+	t.Fatalf("GetHostifStats() got in packets, in bytes, out packets, out bytes %v, want %v", got, want)
+}
+
+func TestGetHostifStats(t *testing.T) {
+	ctx := context.Background()
+	s, conn, _ := newTestSaiServer(t, dplaneopts.WithHostifNetDevPortType(fwdpb.PortType_PORT_TYPE_GENETLINK))
+
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	port := createTestPort(t, conn, 1)
+	hif, err := saipb.NewHostifClient(conn).CreateHostif(ctx, &saipb.CreateHostifRequest{
+		Type:  saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+		ObjId: proto.Uint64(port),
+		Name:  []byte("Ethernet1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dc := diagpb.NewDiagClient(conn)
+	waitHostifStats(t, dc, hif.GetOid(), []uint64{0, 0, 0, 0})
+
+	// Packets written by the host to the hostif are received by its port.
+	const packets = 5
+	frame := make([]byte, 64)
+	copy(frame, []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x02, 0x02, 0x00, 0x00, 0x00, 0x00, 0x01, 0x88, 0xb5})
+	for i := 0; i < packets; i++ {
+		err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(hif.GetOid())}},
+			fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, frame, nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitHostifStats(t, dc, hif.GetOid(), []uint64{packets, packets * uint64(len(frame)), 0, 0})
+
+	if _, err := dc.GetHostifStats(ctx, &diagpb.GetHostifStatsRequest{Oid: port}); grpcstatus.Code(err) != codes.NotFound {
+		t.Errorf("GetHostifStats() of a port got err %v, want NotFound", err)
+	}
+}
+
+func TestGetRemoteHostifStats(t *testing.T) {
+	ctx := context.Background()
+	s, conn, _ := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	s.saiSwitch.hostif.remotePortReq = func(*pktiopb.HostPortControlMessage) (*pktiopb.HostPortControlRequest, error) {
+		return &pktiopb.HostPortControlRequest{}, nil
+	}
+
+	sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	swAttr, err := saipb.NewSwitchClient(conn).GetSwitchAttribute(ctx, &saipb.GetSwitchAttributeRequest{
+		Oid:      sw.GetOid(),
+		AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_CPU_PORT},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := createTestPort(t, conn, 1)
+	hc := saipb.NewHostifClient(conn)
+	hif, err := hc.CreateHostif(ctx, &saipb.CreateHostifRequest{
+		Type:  saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+		ObjId: proto.Uint64(port),
+		Name:  []byte("eth1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	cpu, err := pktiopb.NewPacketIOClient(conn).CPUPacketStream(streamCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cpu.Send(&pktiopb.PacketIn{Msg: &pktiopb.PacketIn_Init{}}); err != nil {
+		t.Fatal(err)
+	}
+	dc := diagpb.NewDiagClient(conn)
+
+	// Packets sent by the host on the hostif are counted as they are received on the stream.
+	const packets = 5
+	frame := make([]byte, 64)
+	copy(frame, []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x02, 0x02, 0x00, 0x00, 0x00, 0x00, 0x01, 0x88, 0xb5})
+	for i := 0; i < packets; i++ {
+		if err := cpu.Send(&pktiopb.PacketIn{Msg: &pktiopb.PacketIn_Packet{Packet: &pktiopb.Packet{HostPort: hif.GetOid(), Frame: frame}}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitHostifStats(t, dc, hif.GetOid(), []uint64{packets, packets * uint64(len(frame)), 0, 0})
+
+	// Packets punted from the hostif's port are counted once they are delivered on the stream.
+	nid, err := s.ObjectNID(ctx, &fwdpb.ObjectNIDRequest{
+		ContextId: &fwdpb.ContextId{Id: s.ID()},
+		ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(port)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acts := []*fwdpb.ActionDesc{fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT).
+		WithUint64Value(nid.GetNid())).Build()}
+	if err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(swAttr.GetAttr().GetCpuPort())}},
+		fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, frame, acts, false, fwdpb.PortAction_PORT_ACTION_OUTPUT); err != nil {
+		t.Fatal(err)
+	}
+	out, err := cpu.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out.GetPacket().GetHostPort(); got != hif.GetOid() {
+		t.Fatalf("punted packet got host port %d, want %d", got, hif.GetOid())
+	}
+	waitHostifStats(t, dc, hif.GetOid(), []uint64{packets, packets * uint64(len(frame)), 1, uint64(len(out.GetPacket().GetFrame()))})
+
+	if _, err := hc.RemoveHostif(ctx, &saipb.RemoveHostifRequest{Oid: hif.GetOid()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dc.GetHostifStats(ctx, &diagpb.GetHostifStatsRequest{Oid: hif.GetOid()}); grpcstatus.Code(err) != codes.NotFound {
+		t.Errorf("GetHostifStats() of removed hostif got err %v, want NotFound", err)
+	}
+}
+
+func TestHostifLogging(t *testing.T) {
+	wantLogs := []string{
+		"replacing hostif table entry for trap 5: hostif 10 -> 11",
+		"resetting hostif",
+	}
+	tests := []struct {
+		desc       string
+		verbosity  string
+		wantLogged bool
+	}{{
+		desc:      "default verbosity",
+		verbosity: "0",
+	}, {
+		desc:       "hostif verbosity",
+		verbosity:  fmt.Sprint(hostifLogLevel),
+		wantLogged: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := captureLogs(t, tt.verbosity, func() {
+				c, _, stopFn := newTestHostif(t, &fakeSwitchDataplane{}, false)
+				defer stopFn()
+				c.srv.initSwitch(switchID, 10)
+				for _, hostifID := range []uint64{10, 11} {
+					if _, err := c.CreateHostifTableEntry(context.Background(), &saipb.CreateHostifTableEntryRequest{
+						Type:   saipb.HostifTableEntryType_HOSTIF_TABLE_ENTRY_TYPE_TRAP_ID.Enum(),
+						TrapId: proto.Uint64(5),
+						HostIf: proto.Uint64(hostifID),
+					}); err != nil {
+						t.Fatalf("CreateHostifTableEntry(hostif %d) unexpected err: %v", hostifID, err)
+					}
+				}
+				c.srv.Reset()
+			})
+			for _, want := range wantLogs {
+				var line string
+				for _, l := range strings.Split(got, "\n") {
+					if strings.HasSuffix(l, "] "+want) {
+						line = l
+					}
+				}
+				if logged := line != ""; logged != tt.wantLogged {
+					t.Errorf("log %q at verbosity %s: got logged %v, want %v", want, tt.verbosity, logged, tt.wantLogged)
+				}
+				if line != "" && !strings.HasPrefix(line, "I") {
+					t.Errorf("log %q got severity of line %q, want info", want, line)
+				}
+			}
+		})
+	}
+}
+
+// captureLogs returns the logs written to stderr by fn with the verbosity v.
+// The stderr file descriptor is redirected rather than os.Stderr, which the logger reads without synchronization.
+func captureLogs(t *testing.T, v string, fn func()) string {
+	t.Helper()
+	for name, val := range map[string]string{"logtostderr": "true", "v": v} {
+		prev := flag.Lookup(name).Value.String()
+		if err := flag.Set(name, val); err != nil {
+			t.Fatal(err)
+		}
+		defer flag.Set(name, prev)
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr, err := unix.Dup(unix.Stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unix.Close(stderr)
+	if err := unix.Dup2(int(w.Fd()), unix.Stderr); err != nil {
+		t.Fatal(err)
+	}
+	out := make(chan []byte)
+	go func() {
+		b, _ := io.ReadAll(r)
+		out <- b
+	}()
+	fn()
+	if err := unix.Dup2(stderr, unix.Stderr); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	return string(<-out)
+}
+
 type hostifClient struct {
 	saipb.HostifClient
 	pktiopb.PacketIOClient
+	diagpb.DiagClient
+	srv *hostif
 }
 
 func newTestHostif(t testing.TB, api switchDataplaneAPI, remotePort bool) (*hostifClient, *attrmgr.AttrMgr, func()) {
+	var h *hostif
 	conn, mgr, stopFn := newTestServer(t, func(mgr *attrmgr.AttrMgr, srv *grpc.Server) {
-		newHostif(mgr, api, srv, &dplaneopts.Options{
+		h = newHostif(mgr, api, srv, &dplaneopts.Options{
 			HostifNetDevType: fwdpb.PortType_PORT_TYPE_KERNEL,
 			RemoteCPUPort:    remotePort,
 		})
+		diagpb.RegisterDiagServer(srv, &Server{mgr: mgr, saiSwitch: &saiSwitch{hostif: h}})
 	})
 	return &hostifClient{
 		HostifClient:   saipb.NewHostifClient(conn),
 		PacketIOClient: pktiopb.NewPacketIOClient(conn),
+		DiagClient:     diagpb.NewDiagClient(conn),
+		srv:            h,
 	}, mgr, stopFn
 }
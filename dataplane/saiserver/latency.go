@@ -0,0 +1,126 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saiserver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	log "github.com/golang/glog"
+
+	fwdpb "github.com/openconfig/lemming/proto/forwarding"
+)
+
+// delayedDataplane emulates the programming latency of an ASIC.
+// Table entry adds and removes and attribute updates return immediately,
+// and are applied to the underlying dataplane in order, each after a delay.
+type delayedDataplane struct {
+	switchDataplaneAPI
+	delay time.Duration
+
+	mu sync.Mutex
+	// queue contains the operations not yet programmed, including the one in progress.
+	queue []func(context.Context) error
+	wake  chan struct{}
+}
+
+// newDelayedDataplane returns a dataplane that programs dplane with the given delay,
+// until ctx is cancelled.
+func newDelayedDataplane(ctx context.Context, dplane switchDataplaneAPI, delay time.Duration) *delayedDataplane {
+	d := &delayedDataplane{
+		switchDataplaneAPI: dplane,
+		delay:              delay,
+		wake:               make(chan struct{}, 1),
+	}
+	go d.run(ctx)
+	return d
+}
+
+// pending returns the number of operations that are not yet programmed.
+func (d *delayedDataplane) pending() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.queue)
+}
+
+func (d *delayedDataplane) enqueue(op func(context.Context) error) {
+	d.mu.Lock()
+	d.queue = append(d.queue, op)
+	d.mu.Unlock()
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (d *delayedDataplane) run(ctx context.Context) {
+	for {
+		d.mu.Lock()
+		var op func(context.Context) error
+		if len(d.queue) > 0 {
+			op = d.queue[0]
+		}
+		d.mu.Unlock()
+		if op == nil {
+			select {
+			case <-d.wake:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+		select {
+		case <-time.After(d.delay):
+		case <-ctx.Done():
+			return
+		}
+		if err := op(ctx); err != nil {
+			log.Warningf("failed to program delayed operation: %v", err)
+		}
+		d.mu.Lock()
+		d.queue = d.queue[1:]
+		d.mu.Unlock()
+	}
+}
+
+func (d *delayedDataplane) TableEntryAdd(_ context.Context, req *fwdpb.TableEntryAddRequest) (*fwdpb.TableEntryAddReply, error) {
+	req = proto.Clone(req).(*fwdpb.TableEntryAddRequest)
+	d.enqueue(func(ctx context.Context) error {
+		_, err := d.switchDataplaneAPI.TableEntryAdd(ctx, req)
+		return err
+	})
+	return &fwdpb.TableEntryAddReply{}, nil
+}
+
+func (d *delayedDataplane) TableEntryRemove(_ context.Context, req *fwdpb.TableEntryRemoveRequest) (*fwdpb.TableEntryRemoveReply, error) {
+	req = proto.Clone(req).(*fwdpb.TableEntryRemoveRequest)
+	d.enqueue(func(ctx context.Context) error {
+		_, err := d.switchDataplaneAPI.TableEntryRemove(ctx, req)
+		return err
+	})
+	return &fwdpb.TableEntryRemoveReply{}, nil
+}
+
+func (d *delayedDataplane) AttributeUpdate(_ context.Context, req *fwdpb.AttributeUpdateRequest) (*fwdpb.AttributeUpdateReply, error) {
+	req = proto.Clone(req).(*fwdpb.AttributeUpdateRequest)
+	d.enqueue(func(ctx context.Context) error {
+		_, err := d.switchDataplaneAPI.AttributeUpdate(ctx, req)
+		return err
+	})
+	return &fwdpb.AttributeUpdateReply{}, nil
+}
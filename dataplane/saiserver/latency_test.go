@@ -0,0 +1,63 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saiserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	fwdpb "github.com/openconfig/lemming/proto/forwarding"
+)
+
+func TestDelayedDataplane(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dplane := &fakeSwitchDataplane{}
+	d := newDelayedDataplane(ctx, dplane, 10*time.Millisecond)
+
+	addReq := &fwdpb.TableEntryAddRequest{TableId: &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: FIBV4Table}}}
+	removeReq := &fwdpb.TableEntryRemoveRequest{TableId: &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: FIBV4Table}}}
+	if _, err := d.TableEntryAdd(ctx, addReq); err != nil {
+		t.Fatalf("TableEntryAdd() unexpected err: %v", err)
+	}
+	if _, err := d.AttributeUpdate(ctx, &fwdpb.AttributeUpdateRequest{}); err != nil {
+		t.Fatalf("AttributeUpdate() unexpected err: %v", err)
+	}
+	if _, err := d.TableEntryRemove(ctx, removeReq); err != nil {
+		t.Fatalf("TableEntryRemove() unexpected err: %v", err)
+	}
+	// Nothing is programmed immediately after the writes.
+	if got, want := d.pending(), 3; got != want {
+		t.Fatalf("pending() immediately after writes got %d, want %d", got, want)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for d.pending() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("operations not programmed after 5s, %d pending", d.pending())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if d := cmp.Diff(dplane.gotEntryAddReqs, []*fwdpb.TableEntryAddRequest{addReq}, protocmp.Transform()); d != "" {
+		t.Errorf("TableEntryAdd() failed: diff(-got,+want)\n:%s", d)
+	}
+	if d := cmp.Diff(dplane.gotEntryRemoveReqs, []*fwdpb.TableEntryRemoveRequest{removeReq}, protocmp.Transform()); d != "" {
+		t.Errorf("TableEntryRemove() failed: diff(-got,+want)\n:%s", d)
+	}
+}
@@ -0,0 +1,509 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saiserver
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openconfig/lemming/dataplane/forwarding/fwdconfig"
+	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
+
+	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
+	fwdpb "github.com/openconfig/lemming/proto/forwarding"
+)
+
+// Priorities of IPMC entries in the ipmc table, (S,G) entries are preferred over (*,G).
+const (
+	ipmcSGPriority = 1
+	ipmcXGPriority = 2
+)
+
+func rpfGroupTable(oid uint64) string {
+	return fmt.Sprintf("rpf-group-%d", oid)
+}
+
+func ipmcGroupTable(oid uint64) string {
+	return fmt.Sprintf("ipmc-group-%d", oid)
+}
+
+func l2mcGroupTable(oid uint64) string {
+	return fmt.Sprintf("l2mc-group-%d", oid)
+}
+
+// multicastMAC returns the destination MAC address of packets sent to the IPv4 or IPv6 multicast group.
+func multicastMAC(group []byte) []byte {
+	if len(group) == 4 {
+		return []byte{0x01, 0x00, 0x5e, group[1] & 0x7f, group[2], group[3]}
+	}
+	return append([]byte{0x33, 0x33}, group[len(group)-4:]...)
+}
+
+type rpfGroupMember struct {
+	group uint64
+	rif   uint64
+}
+
+// rpfGroup implements RPF groups as exact tables of the router interfaces from which multicast packets are accepted.
+type rpfGroup struct {
+	saipb.UnimplementedRpfGroupServer
+	mgr       *attrmgr.AttrMgr
+	dataplane switchDataplaneAPI
+	members   map[uint64]*rpfGroupMember
+}
+
+func newRPFGroup(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server) *rpfGroup {
+	r := &rpfGroup{
+		mgr:       mgr,
+		dataplane: dataplane,
+		members:   map[uint64]*rpfGroupMember{},
+	}
+	saipb.RegisterRpfGroupServer(s, r)
+	return r
+}
+
+// CreateRpfGroup creates a table that drops packets not received on one of the group's interfaces.
+func (r *rpfGroup) CreateRpfGroup(ctx context.Context, _ *saipb.CreateRpfGroupRequest) (*saipb.CreateRpfGroupResponse, error) {
+	id := r.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_RPF_GROUP)
+	tReq := &fwdpb.TableCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: r.dataplane.ID()},
+		Desc: &fwdpb.TableDesc{
+			TableType: fwdpb.TableType_TABLE_TYPE_EXACT,
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: rpfGroupTable(id)}},
+			Actions:   []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_DROP}},
+			Table: &fwdpb.TableDesc_Exact{
+				Exact: &fwdpb.ExactTableDesc{
+					FieldIds: []*fwdpb.PacketFieldId{{
+						Field: &fwdpb.PacketField{
+							FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_INPUT_IFACE,
+						},
+					}},
+				},
+			},
+		},
+	}
+	if _, err := r.dataplane.TableCreate(ctx, tReq); err != nil {
+		return nil, err
+	}
+	return &saipb.CreateRpfGroupResponse{Oid: id}, nil
+}
+
+// RemoveRpfGroup removes the group's table.
+func (r *rpfGroup) RemoveRpfGroup(ctx context.Context, req *saipb.RemoveRpfGroupRequest) (*saipb.RemoveRpfGroupResponse, error) {
+	_, err := r.dataplane.ObjectDelete(ctx, &fwdpb.ObjectDeleteRequest{
+		ContextId: &fwdpb.ContextId{Id: r.dataplane.ID()},
+		ObjectId:  &fwdpb.ObjectId{Id: rpfGroupTable(req.GetOid())},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &saipb.RemoveRpfGroupResponse{}, nil
+}
+
+// CreateRpfGroupMember accepts packets received on the member's router interface.
+func (r *rpfGroup) CreateRpfGroupMember(ctx context.Context, req *saipb.CreateRpfGroupMemberRequest) (*saipb.CreateRpfGroupMemberResponse, error) {
+	id := r.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_RPF_GROUP_MEMBER)
+	m := &rpfGroupMember{
+		group: req.GetRpfGroupId(),
+		rif:   req.GetRpfInterfaceId(),
+	}
+	_, err := r.dataplane.TableEntryAdd(ctx, &fwdpb.TableEntryAddRequest{
+		ContextId: &fwdpb.ContextId{Id: r.dataplane.ID()},
+		TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: rpfGroupTable(m.group)}},
+		Entries: []*fwdpb.TableEntryAddRequest_Entry{{
+			EntryDesc: fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_INPUT_IFACE).WithUint64(m.rif))).Build(),
+			Actions:   []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_CONTINUE}},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.members[id] = m
+	return &saipb.CreateRpfGroupMemberResponse{Oid: id}, nil
+}
+
+// RemoveRpfGroupMember stops accepting packets received on the member's router interface.
+func (r *rpfGroup) RemoveRpfGroupMember(ctx context.Context, req *saipb.RemoveRpfGroupMemberRequest) (*saipb.RemoveRpfGroupMemberResponse, error) {
+	m, ok := r.members[req.GetOid()]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "rpf group member %d does not exist", req.GetOid())
+	}
+	_, err := r.dataplane.TableEntryRemove(ctx, fwdconfig.TableEntryRemoveRequest(r.dataplane.ID(), rpfGroupTable(m.group)).
+		AppendEntry(
+			fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_INPUT_IFACE).WithUint64(m.rif))),
+		).Build())
+	if err != nil {
+		return nil, err
+	}
+	delete(r.members, req.GetOid())
+	return &saipb.RemoveRpfGroupMemberResponse{}, nil
+}
+
+// ipmcGroup implements IPMC groups as action tables, each member sends a copy of the packet out of its router interface.
+type ipmcGroup struct {
+	saipb.UnimplementedIpmcGroupServer
+	mgr       *attrmgr.AttrMgr
+	dataplane switchDataplaneAPI
+	members   map[uint64]uint64 // map from member id to group id
+}
+
+func newIPMCGroup(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server) *ipmcGroup {
+	g := &ipmcGroup{
+		mgr:       mgr,
+		dataplane: dataplane,
+		members:   map[uint64]uint64{},
+	}
+	saipb.RegisterIpmcGroupServer(s, g)
+	return g
+}
+
+// CreateIpmcGroup creates an empty replication group.
+func (g *ipmcGroup) CreateIpmcGroup(ctx context.Context, _ *saipb.CreateIpmcGroupRequest) (*saipb.CreateIpmcGroupResponse, error) {
+	id := g.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_IPMC_GROUP)
+	tReq := &fwdpb.TableCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: g.dataplane.ID()},
+		Desc: &fwdpb.TableDesc{
+			TableType: fwdpb.TableType_TABLE_TYPE_ACTION,
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: ipmcGroupTable(id)}},
+			Actions:   []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_CONTINUE}},
+			Table: &fwdpb.TableDesc_Action{
+				Action: &fwdpb.ActionTableDesc{},
+			},
+		},
+	}
+	if _, err := g.dataplane.TableCreate(ctx, tReq); err != nil {
+		return nil, err
+	}
+	return &saipb.CreateIpmcGroupResponse{Oid: id}, nil
+}
+
+// RemoveIpmcGroup removes the group's table.
+func (g *ipmcGroup) RemoveIpmcGroup(ctx context.Context, req *saipb.RemoveIpmcGroupRequest) (*saipb.RemoveIpmcGroupResponse, error) {
+	_, err := g.dataplane.ObjectDelete(ctx, &fwdpb.ObjectDeleteRequest{
+		ContextId: &fwdpb.ContextId{Id: g.dataplane.ID()},
+		ObjectId:  &fwdpb.ObjectId{Id: ipmcGroupTable(req.GetOid())},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &saipb.RemoveIpmcGroupResponse{}, nil
+}
+
+// CreateIpmcGroupMember adds a router interface to the group.
+// The packet is mirrored and the copy runs through the egress stages of the forwarding pipeline.
+func (g *ipmcGroup) CreateIpmcGroupMember(ctx context.Context, req *saipb.CreateIpmcGroupMemberRequest) (*saipb.CreateIpmcGroupMemberResponse, error) {
+	id := g.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_IPMC_GROUP_MEMBER)
+	mirror := &fwdpb.ActionDesc{
+		ActionType: fwdpb.ActionType_ACTION_TYPE_MIRROR,
+		Action: &fwdpb.ActionDesc_Mirror{
+			Mirror: &fwdpb.MirrorActionDesc{
+				Actions: []*fwdpb.ActionDesc{
+					fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_OUTPUT_IFACE).WithUint64Value(req.GetIpmcOutputId())).Build(),
+					fwdconfig.Action(fwdconfig.LookupAction(outputIfaceTable)).Build(),
+					fwdconfig.Action(fwdconfig.LookupAction(EgressActionTable)).Build(),
+					fwdconfig.Action(fwdconfig.LookupAction(portEgressACLTable)).Build(),
+					fwdconfig.Action(fwdconfig.LookupAction(SRCMACTable)).Build(),
+					fwdconfig.Action(fwdconfig.LookupAction(egressMTUTable)).Build(),
+					{ActionType: fwdpb.ActionType_ACTION_TYPE_OUTPUT},
+				},
+			},
+		},
+	}
+	_, err := g.dataplane.TableEntryAdd(ctx, &fwdpb.TableEntryAddRequest{
+		ContextId: &fwdpb.ContextId{Id: g.dataplane.ID()},
+		TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: ipmcGroupTable(req.GetIpmcGroupId())}},
+		Entries: []*fwdpb.TableEntryAddRequest_Entry{{
+			EntryDesc: fwdconfig.EntryDesc(fwdconfig.ActionEntry(fmt.Sprint(id), fwdpb.ActionEntryDesc_INSERT_METHOD_APPEND)).Build(),
+			Actions:   []*fwdpb.ActionDesc{mirror},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	g.members[id] = req.GetIpmcGroupId()
+	return &saipb.CreateIpmcGroupMemberResponse{Oid: id}, nil
+}
+
+// RemoveIpmcGroupMember removes a router interface from the group.
+func (g *ipmcGroup) RemoveIpmcGroupMember(ctx context.Context, req *saipb.RemoveIpmcGroupMemberRequest) (*saipb.RemoveIpmcGroupMemberResponse, error) {
+	group, ok := g.members[req.GetOid()]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "ipmc group member %d does not exist", req.GetOid())
+	}
+	_, err := g.dataplane.TableEntryRemove(ctx, fwdconfig.TableEntryRemoveRequest(g.dataplane.ID(), ipmcGroupTable(group)).
+		AppendEntry(fwdconfig.EntryDesc(fwdconfig.ActionEntry(fmt.Sprint(req.GetOid()), fwdpb.ActionEntryDesc_INSERT_METHOD_APPEND))).Build())
+	if err != nil {
+		return nil, err
+	}
+	delete(g.members, req.GetOid())
+	return &saipb.RemoveIpmcGroupMemberResponse{}, nil
+}
+
+type ipmc struct {
+	saipb.UnimplementedIpmcServer
+	mgr       *attrmgr.AttrMgr
+	dataplane switchDataplaneAPI
+}
+
+func newIPMC(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server) *ipmc {
+	i := &ipmc{
+		mgr:       mgr,
+		dataplane: dataplane,
+	}
+	saipb.RegisterIpmcServer(s, i)
+	return i
+}
+
+// ipmcEntryDesc returns the flow entry matching packets of the IPMC entry.
+func ipmcEntryDesc(entry *saipb.IpmcEntry) (*fwdpb.EntryDesc, error) {
+	dst := entry.GetDestination()
+	if len(dst) != 4 && len(dst) != 16 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid destination: %v", dst)
+	}
+	ipVersion := byte(4)
+	if len(dst) == 16 {
+		ipVersion = 6
+	}
+	allOnes := func(n int) []byte {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = 0xff
+		}
+		return b
+	}
+
+	var priority uint32
+	src, srcMask := make([]byte, len(dst)), make([]byte, len(dst))
+	switch entry.GetType() {
+	case saipb.IpmcEntryType_IPMC_ENTRY_TYPE_SG:
+		if len(entry.GetSource()) != len(dst) {
+			return nil, status.Errorf(codes.InvalidArgument, "source %v and destination %v have different address families", entry.GetSource(), dst)
+		}
+		priority = ipmcSGPriority
+		src, srcMask = entry.GetSource(), allOnes(len(dst))
+	case saipb.IpmcEntryType_IPMC_ENTRY_TYPE_XG:
+		priority = ipmcXGPriority
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported ipmc entry type: %v", entry.GetType())
+	}
+
+	ed := fwdconfig.EntryDesc(fwdconfig.FlowEntry(
+		fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_VRF).WithUint64(entry.GetVrId()),
+		fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_VERSION).WithBytes([]byte{ipVersion}, []byte{0xff}),
+		fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_DST).WithBytes(dst, allOnes(len(dst))),
+		fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_SRC).WithBytes(src, srcMask),
+	)).Build()
+	ed.GetFlow().Priority = priority
+	return ed, nil
+}
+
+// CreateIpmcEntry creates a multicast route. Packets that pass the RPF check are replicated to each member of the output group.
+func (i *ipmc) CreateIpmcEntry(ctx context.Context, req *saipb.CreateIpmcEntryRequest) (*saipb.CreateIpmcEntryResponse, error) {
+	ed, err := ipmcEntryDesc(req.GetEntry())
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []*fwdpb.ActionDesc
+	switch req.GetPacketAction() {
+	case saipb.PacketAction_PACKET_ACTION_UNSPECIFIED, saipb.PacketAction_PACKET_ACTION_FORWARD:
+		if req.RpfGroupId != nil {
+			actions = append(actions, fwdconfig.Action(fwdconfig.LookupAction(rpfGroupTable(req.GetRpfGroupId()))).Build())
+		}
+		actions = append(actions,
+			fwdconfig.Action(fwdconfig.EncapAction(fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET)).Build(),
+			fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST).WithValue(multicastMAC(req.GetEntry().GetDestination()))).Build(),
+		)
+		if req.OutputGroupId != nil {
+			actions = append(actions, fwdconfig.Action(fwdconfig.LookupAction(ipmcGroupTable(req.GetOutputGroupId()))).Build())
+		}
+		// The original packet is dropped once it has been replicated.
+		actions = append(actions, fwdconfig.Action(fwdconfig.DropAction()).Build())
+	case saipb.PacketAction_PACKET_ACTION_DROP:
+		actions = append(actions, fwdconfig.Action(fwdconfig.DropAction()).Build())
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported packet action: %v", req.GetPacketAction())
+	}
+
+	_, err = i.dataplane.TableEntryAdd(ctx, &fwdpb.TableEntryAddRequest{
+		ContextId: &fwdpb.ContextId{Id: i.dataplane.ID()},
+		TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: ipmcTable}},
+		Entries: []*fwdpb.TableEntryAddRequest_Entry{{
+			EntryDesc: ed,
+			Actions:   actions,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &saipb.CreateIpmcEntryResponse{}, nil
+}
+
+// RemoveIpmcEntry removes a multicast route.
+func (i *ipmc) RemoveIpmcEntry(ctx context.Context, req *saipb.RemoveIpmcEntryRequest) (*saipb.RemoveIpmcEntryResponse, error) {
+	ed, err := ipmcEntryDesc(req.GetEntry())
+	if err != nil {
+		return nil, err
+	}
+	_, err = i.dataplane.TableEntryRemove(ctx, &fwdpb.TableEntryRemoveRequest{
+		ContextId: &fwdpb.ContextId{Id: i.dataplane.ID()},
+		TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: ipmcTable}},
+		EntryDesc: ed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &saipb.RemoveIpmcEntryResponse{}, nil
+}
+
+type l2mcGroupMember struct {
+	group uint64
+	port  uint64 // NID of the member's port.
+}
+
+// l2mcGroup implements L2MC groups as action tables, each member sends a copy of the frame out of its bridge port.
+type l2mcGroup struct {
+	saipb.UnimplementedL2McGroupServer
+	mgr       *attrmgr.AttrMgr
+	dataplane switchDataplaneAPI
+	members   map[uint64]*l2mcGroupMember
+	ports     map[uint64]int // map from port NID to the number of members using the port
+}
+
+func newL2MCGroup(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server) *l2mcGroup {
+	g := &l2mcGroup{
+		mgr:       mgr,
+		dataplane: dataplane,
+		members:   map[uint64]*l2mcGroupMember{},
+		ports:     map[uint64]int{},
+	}
+	saipb.RegisterL2McGroupServer(s, g)
+	return g
+}
+
+// CreateL2McGroup creates an empty replication group.
+func (g *l2mcGroup) CreateL2McGroup(ctx context.Context, _ *saipb.CreateL2McGroupRequest) (*saipb.CreateL2McGroupResponse, error) {
+	id := g.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_L2MC_GROUP)
+	tReq := &fwdpb.TableCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: g.dataplane.ID()},
+		Desc: &fwdpb.TableDesc{
+			TableType: fwdpb.TableType_TABLE_TYPE_ACTION,
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: l2mcGroupTable(id)}},
+			Actions:   []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_CONTINUE}},
+			Table: &fwdpb.TableDesc_Action{
+				Action: &fwdpb.ActionTableDesc{},
+			},
+		},
+	}
+	if _, err := g.dataplane.TableCreate(ctx, tReq); err != nil {
+		return nil, err
+	}
+	return &saipb.CreateL2McGroupResponse{Oid: id}, nil
+}
+
+// RemoveL2McGroup removes the group's table.
+func (g *l2mcGroup) RemoveL2McGroup(ctx context.Context, req *saipb.RemoveL2McGroupRequest) (*saipb.RemoveL2McGroupResponse, error) {
+	_, err := g.dataplane.ObjectDelete(ctx, &fwdpb.ObjectDeleteRequest{
+		ContextId: &fwdpb.ContextId{Id: g.dataplane.ID()},
+		ObjectId:  &fwdpb.ObjectId{Id: l2mcGroupTable(req.GetOid())},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &saipb.RemoveL2McGroupResponse{}, nil
+}
+
+func floodEgressEntry(nid uint64) *fwdconfig.EntryDescBuilder {
+	return fwdconfig.EntryDesc(fwdconfig.ExactEntry(
+		fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT).WithUint64(nid),
+		fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_OUTPUT).WithUint64(nid),
+	))
+}
+
+// CreateL2McGroupMember adds a bridge port to the group.
+// The frame is mirrored and the copy is transmitted unless the bridge port is the frame's input port.
+func (g *l2mcGroup) CreateL2McGroupMember(ctx context.Context, req *saipb.CreateL2McGroupMemberRequest) (*saipb.CreateL2McGroupMemberResponse, error) {
+	id := g.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_L2MC_GROUP_MEMBER)
+	port, nid, err := lookupBridgePort(ctx, g.mgr, g.dataplane, req.GetL2McOutputId())
+	if err != nil {
+		return nil, err
+	}
+	if g.ports[nid] == 0 {
+		_, err := g.dataplane.TableEntryAdd(ctx, fwdconfig.TableEntryAddRequest(g.dataplane.ID(), floodEgressTable).
+			AppendEntry(floodEgressEntry(nid), fwdconfig.Action(fwdconfig.DropAction())).Build())
+		if err != nil {
+			return nil, err
+		}
+	}
+	g.ports[nid]++
+
+	mirror := &fwdpb.ActionDesc{
+		ActionType: fwdpb.ActionType_ACTION_TYPE_MIRROR,
+		Action: &fwdpb.ActionDesc_Mirror{
+			Mirror: &fwdpb.MirrorActionDesc{
+				Actions: []*fwdpb.ActionDesc{
+					fwdconfig.Action(fwdconfig.TransmitAction(fmt.Sprint(port))).Build(),
+					fwdconfig.Action(fwdconfig.LookupAction(floodEgressTable)).Build(),
+					fwdconfig.Action(fwdconfig.LookupAction(egressMTUTable)).Build(),
+					{ActionType: fwdpb.ActionType_ACTION_TYPE_OUTPUT},
+				},
+			},
+		},
+	}
+	_, err = g.dataplane.TableEntryAdd(ctx, &fwdpb.TableEntryAddRequest{
+		ContextId: &fwdpb.ContextId{Id: g.dataplane.ID()},
+		TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: l2mcGroupTable(req.GetL2McGroupId())}},
+		Entries: []*fwdpb.TableEntryAddRequest_Entry{{
+			EntryDesc: fwdconfig.EntryDesc(fwdconfig.ActionEntry(fmt.Sprint(id), fwdpb.ActionEntryDesc_INSERT_METHOD_APPEND)).Build(),
+			Actions:   []*fwdpb.ActionDesc{mirror},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	g.members[id] = &l2mcGroupMember{
+		group: req.GetL2McGroupId(),
+		port:  nid,
+	}
+	return &saipb.CreateL2McGroupMemberResponse{Oid: id}, nil
+}
+
+// RemoveL2McGroupMember removes a bridge port from the group.
+func (g *l2mcGroup) RemoveL2McGroupMember(ctx context.Context, req *saipb.RemoveL2McGroupMemberRequest) (*saipb.RemoveL2McGroupMemberResponse, error) {
+	m, ok := g.members[req.GetOid()]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "l2mc group member %d does not exist", req.GetOid())
+	}
+	_, err := g.dataplane.TableEntryRemove(ctx, fwdconfig.TableEntryRemoveRequest(g.dataplane.ID(), l2mcGroupTable(m.group)).
+		AppendEntry(fwdconfig.EntryDesc(fwdconfig.ActionEntry(fmt.Sprint(req.GetOid()), fwdpb.ActionEntryDesc_INSERT_METHOD_APPEND))).Build())
+	if err != nil {
+		return nil, err
+	}
+	delete(g.members, req.GetOid())
+	g.ports[m.port]--
+	if g.ports[m.port] == 0 {
+		delete(g.ports, m.port)
+		_, err := g.dataplane.TableEntryRemove(ctx, fwdconfig.TableEntryRemoveRequest(g.dataplane.ID(), floodEgressTable).
+			AppendEntry(floodEgressEntry(m.port)).Build())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &saipb.RemoveL2McGroupMemberResponse{}, nil
+}
@@ -0,0 +1,286 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saiserver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/openconfig/lemming/dataplane/dplaneopts"
+	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdcontext"
+	"github.com/openconfig/lemming/internal/packetutil"
+
+	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
+	fwdpb "github.com/openconfig/lemming/proto/forwarding"
+)
+
+func TestMulticastMAC(t *testing.T) {
+	tests := []struct {
+		desc  string
+		group net.IP
+		want  net.HardwareAddr
+	}{{
+		desc:  "ipv4",
+		group: net.IPv4(239, 129, 1, 2).To4(),
+		want:  net.HardwareAddr{0x01, 0x00, 0x5e, 0x01, 0x01, 0x02},
+	}, {
+		desc:  "ipv6",
+		group: net.ParseIP("ff0e::1:2:3"),
+		want:  net.HardwareAddr{0x33, 0x33, 0x00, 0x02, 0x00, 0x03},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := multicastMAC(tt.group); !bytes.Equal(got, tt.want) {
+				t.Errorf("multicastMAC(%v) got %v, want %v", tt.group, net.HardwareAddr(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestIPMCForwarding(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sink := packetutil.NewSink(3)
+	fwdCtx.FakePortManager = capturePortManager{sink: sink}
+	fwdCtx.SetCPUPortSink(packetutil.NewSink(1).CPUPortSink, func() {})
+
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	// Each router interface has a distinct source MAC, so the replicas can be told apart.
+	var ports, rifs []uint64
+	for i := uint32(1); i <= 3; i++ {
+		port := createTestPort(t, conn, i)
+		rif, err := saipb.NewRouterInterfaceClient(conn).CreateRouterInterface(ctx, &saipb.CreateRouterInterfaceRequest{
+			Type:          saipb.RouterInterfaceType_ROUTER_INTERFACE_TYPE_PORT.Enum(),
+			PortId:        proto.Uint64(port),
+			SrcMacAddress: []byte{0x02, 0x00, 0x00, 0x00, 0x00, byte(i)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ports = append(ports, port)
+		rifs = append(rifs, rif.GetOid())
+	}
+
+	// Packets from 192.0.2.1 to 239.1.1.1 are accepted on the first interface and replicated to the others.
+	rc := saipb.NewRpfGroupClient(conn)
+	rpf, err := rc.CreateRpfGroup(ctx, &saipb.CreateRpfGroupRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rc.CreateRpfGroupMember(ctx, &saipb.CreateRpfGroupMemberRequest{
+		RpfGroupId:     proto.Uint64(rpf.GetOid()),
+		RpfInterfaceId: proto.Uint64(rifs[0]),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	gc := saipb.NewIpmcGroupClient(conn)
+	group, err := gc.CreateIpmcGroup(ctx, &saipb.CreateIpmcGroupRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rif := range rifs[1:] {
+		if _, err := gc.CreateIpmcGroupMember(ctx, &saipb.CreateIpmcGroupMemberRequest{
+			IpmcGroupId:  proto.Uint64(group.GetOid()),
+			IpmcOutputId: proto.Uint64(rif),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := saipb.NewIpmcClient(conn).CreateIpmcEntry(ctx, &saipb.CreateIpmcEntryRequest{
+		Entry: &saipb.IpmcEntry{
+			Type:        saipb.IpmcEntryType_IPMC_ENTRY_TYPE_SG,
+			Destination: []byte{239, 1, 1, 1},
+			Source:      []byte{192, 0, 2, 1},
+		},
+		PacketAction:  saipb.PacketAction_PACKET_ACTION_FORWARD.Enum(),
+		OutputGroupId: proto.Uint64(group.GetOid()),
+		RpfGroupId:    proto.Uint64(rpf.GetOid()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		&layers.Ethernet{
+			SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x01, 0x01},
+			DstMAC:       net.HardwareAddr{0x01, 0x00, 0x5e, 0x01, 0x01, 0x01},
+			EthernetType: layers.EthernetTypeIPv4,
+		}, &layers.IPv4{
+			Version:  4,
+			TTL:      64,
+			Protocol: layers.IPProtocolUDP,
+			SrcIP:    net.IPv4(192, 0, 2, 1).To4(),
+			DstIP:    net.IPv4(239, 1, 1, 1).To4(),
+		}, &layers.UDP{SrcPort: 5000, DstPort: 5000}, gopacket.Payload("data")); err != nil {
+		t.Fatal(err)
+	}
+	inject := func(t *testing.T, port uint64) {
+		t.Helper()
+		err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+			fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, buf.Bytes(), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("replication", func(t *testing.T) {
+		inject(t, ports[0])
+		got := map[string]bool{}
+		for range rifs[1:] {
+			pkt, err := sink.Next(time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			eth := pkt.Ethernet()
+			if eth == nil || pkt.UDP() == nil {
+				t.Fatalf("replicated packet is not UDP over ethernet: %v", pkt)
+			}
+			if want := (net.HardwareAddr{0x01, 0x00, 0x5e, 0x01, 0x01, 0x01}); !bytes.Equal(eth.DstMAC, want) {
+				t.Errorf("replicated packet got dst MAC %v, want %v", eth.DstMAC, want)
+			}
+			got[eth.SrcMAC.String()] = true
+		}
+		for _, want := range []string{"02:00:00:00:00:02", "02:00:00:00:00:03"} {
+			if !got[want] {
+				t.Errorf("no replica sent with src MAC %s, got %v", want, got)
+			}
+		}
+		if pkt, err := sink.Next(100 * time.Millisecond); err == nil {
+			t.Errorf("got unexpected packet: %v", pkt)
+		}
+	})
+	t.Run("rpf failure", func(t *testing.T) {
+		inject(t, ports[1])
+		if pkt, err := sink.Next(100 * time.Millisecond); err == nil {
+			t.Errorf("packet received on the wrong interface was forwarded: %v", pkt)
+		}
+	})
+}
+
+// lanePortManager creates fake ports that buffer written packets in the sink of their hardware lane.
+type lanePortManager struct {
+	sinks map[string]*packetutil.Sink
+}
+
+func (m lanePortManager) CreatePort(lane string) (fwdcontext.Port, error) {
+	sink, ok := m.sinks[lane]
+	if !ok {
+		return nil, fmt.Errorf("no sink for lane %s", lane)
+	}
+	return capturePort{sink: sink}, nil
+}
+
+func TestL2MCFlooding(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sinks := map[string]*packetutil.Sink{}
+	for _, lane := range []string{"1", "2", "3", "4"} {
+		sinks[lane] = packetutil.NewSink(2)
+	}
+	fwdCtx.FakePortManager = lanePortManager{sinks: sinks}
+	fwdCtx.SetCPUPortSink(packetutil.NewSink(1).CPUPortSink, func() {})
+
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	vc := saipb.NewVlanClient(conn)
+	vlan, err := vc.CreateVlan(ctx, &saipb.CreateVlanRequest{
+		VlanId: proto.Uint32(100),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	group, err := saipb.NewL2McGroupClient(conn).CreateL2McGroup(ctx, &saipb.CreateL2McGroupRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Ports on lanes 1 to 3 are members of the VLAN, the port on lane 4 isn't.
+	var ports []uint64
+	for i := uint32(1); i <= 4; i++ {
+		port := createTestPort(t, conn, i)
+		ports = append(ports, port)
+		if i == 4 {
+			continue
+		}
+		bp, err := saipb.NewBridgeClient(conn).CreateBridgePort(ctx, &saipb.CreateBridgePortRequest{
+			Type:   saipb.BridgePortType_BRIDGE_PORT_TYPE_PORT.Enum(),
+			PortId: proto.Uint64(port),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := vc.CreateVlanMember(ctx, &saipb.CreateVlanMemberRequest{
+			VlanId:          proto.Uint64(vlan.GetOid()),
+			BridgePortId:    proto.Uint64(bp.GetOid()),
+			VlanTaggingMode: saipb.VlanTaggingMode_VLAN_TAGGING_MODE_UNTAGGED.Enum(),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := saipb.NewL2McGroupClient(conn).CreateL2McGroupMember(ctx, &saipb.CreateL2McGroupMemberRequest{
+			L2McGroupId:  proto.Uint64(group.GetOid()),
+			L2McOutputId: proto.Uint64(bp.GetOid()),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := vc.SetVlanAttribute(ctx, &saipb.SetVlanAttributeRequest{
+		Oid:                       vlan.GetOid(),
+		BroadcastFloodControlType: saipb.VlanFloodControlType_VLAN_FLOOD_CONTROL_TYPE_L2MC_GROUP.Enum(),
+		BroadcastFloodGroup:       proto.Uint64(group.GetOid()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		&layers.Ethernet{
+			SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+			DstMAC:       layers.EthernetBroadcast,
+			EthernetType: layers.EthernetType(0x88b5),
+		}, gopacket.Payload(make([]byte, 46))); err != nil {
+		t.Fatal(err)
+	}
+	err = s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(ports[0])}},
+		fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, buf.Bytes(), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, lane := range []string{"2", "3"} {
+		pkt, err := sinks[lane].Next(time.Second)
+		if err != nil {
+			t.Fatalf("lane %s: %v", lane, err)
+		}
+		if !bytes.Equal(pkt.Ethernet().DstMAC, layers.EthernetBroadcast) {
+			t.Errorf("lane %s: flooded frame got dst MAC %v, want broadcast", lane, pkt.Ethernet().DstMAC)
+		}
+	}
+	for _, lane := range []string{"1", "4"} {
+		if pkt, err := sinks[lane].Next(100 * time.Millisecond); err == nil {
+			t.Errorf("lane %s: frame unexpectedly flooded: %v", lane, pkt)
+		}
+	}
+}
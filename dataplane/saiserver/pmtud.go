@@ -0,0 +1,210 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saiserver
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+
+	log "github.com/golang/glog"
+
+	pktiopb "github.com/openconfig/lemming/dataplane/proto/packetio"
+	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
+	fwdpb "github.com/openconfig/lemming/proto/forwarding"
+)
+
+const (
+	// ethernetHeaderLen is the length of an untagged Ethernet header. The port MTU limits the length of the frame,
+	// the path MTU advertised to the source is the length of the largest IP packet that fits in it.
+	ethernetHeaderLen = 14
+	// icmpErrorTTL is the TTL, or hop limit, of the ICMP errors sent by the switch.
+	icmpErrorTTL = 64
+	// ICMP errors quote as much of the invoking packet as fits in the minimum MTU of the IP version (RFC 1812 and RFC 4443),
+	// after the IP header and the ICMP header of the error, which includes the MTU.
+	icmpv4MaxQuote = 576 - 20 - 8
+	icmpv6MaxQuote = 1280 - 40 - 8
+)
+
+// localAddressesChanged records the switch's local addresses, the sources of the ICMP errors it sends,
+// then reprograms the BGP traps that match them.
+func (hostif *hostif) localAddressesChanged(ctx context.Context) error {
+	var addrs []net.IP
+	if hostif.localPrefixes != nil {
+		for _, prefix := range hostif.localPrefixes() {
+			addrs = append(addrs, net.IP(prefix.GetAddr()))
+		}
+	}
+	hostif.localAddrs.Store(&addrs)
+	return hostif.updateBGPTraps(ctx)
+}
+
+// icmpSource returns the local address of the same IP version as dst to send ICMP errors from.
+// Link-local addresses are only used if there are no others.
+func (hostif *hostif) icmpSource(dst net.IP) net.IP {
+	addrs := hostif.localAddrs.Load()
+	if addrs == nil {
+		return nil
+	}
+	var src net.IP
+	for _, addr := range *addrs {
+		if (addr.To4() == nil) != (dst.To4() == nil) {
+			continue
+		}
+		if !addr.IsLinkLocalUnicast() {
+			return addr
+		}
+		if src == nil {
+			src = addr
+		}
+	}
+	return src
+}
+
+// answerPacketTooBig sends an ICMP Packet Too Big error to the source of a packet punted for exceeding the MTU of the port.
+// The error is injected on the port the packet was received on, and is routed back toward the source.
+func (hostif *hostif) answerPacketTooBig(pkt *pktiopb.Packet, portID uint64) {
+	attrs := &saipb.GetPortAttributeResponse{}
+	err := hostif.mgr.PopulateAttributes(&saipb.GetPortAttributeRequest{
+		Oid:      portID,
+		AttrType: []saipb.PortAttr{saipb.PortAttr_PORT_ATTR_MTU},
+	}, attrs)
+	if err != nil {
+		log.Warningf("failed to get MTU of port %d: %v", portID, err)
+		return
+	}
+	mtu := attrs.GetAttr().GetMtu()
+	if mtu <= ethernetHeaderLen {
+		return
+	}
+	frame, err := packetTooBig(pkt.GetFrame(), mtu-ethernetHeaderLen, hostif.icmpSource)
+	if err != nil {
+		log.Warningf("failed to build packet too big error for port %d: %v", portID, err)
+		return
+	}
+	if frame == nil {
+		return
+	}
+	err = hostif.dataplane.InjectPacket(&fwdpb.ContextId{Id: hostif.dataplane.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(pkt.GetInputPort())}},
+		fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, frame, nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+	if err != nil {
+		log.Warningf("failed to inject packet too big error on port %d: %v", pkt.GetInputPort(), err)
+	}
+}
+
+// packetTooBig returns the ICMP Packet Too Big error for the IP packet in frame, which is too big for mtu.
+// The error is addressed to the router that sent the frame, so it is routed like a packet received from it.
+// It returns nil if no error is sent: for IPv4 packets that may be fragmented, ICMP errors, and packets without a valid source.
+func packetTooBig(frame []byte, mtu uint32, source func(dst net.IP) net.IP) ([]byte, error) {
+	pkt := gopacket.NewPacket(frame, layers.LayerTypeEthernet, gopacket.Default)
+	eth, ok := pkt.Layer(layers.LayerTypeEthernet).(*layers.Ethernet)
+	if !ok {
+		return nil, fmt.Errorf("frame is not ethernet")
+	}
+	reply := &layers.Ethernet{
+		SrcMAC: eth.DstMAC,
+		DstMAC: eth.SrcMAC,
+	}
+	var ip, icmp gopacket.SerializableLayer
+	var body []byte
+	switch l := pkt.NetworkLayer().(type) {
+	case *layers.IPv4:
+		if l.Flags&layers.IPv4DontFragment == 0 || isICMPError(pkt) || !validSource(l.SrcIP) {
+			return nil, nil
+		}
+		src := source(l.SrcIP)
+		if src == nil {
+			return nil, fmt.Errorf("no local IPv4 address")
+		}
+		reply.EthernetType = layers.EthernetTypeIPv4
+		ip = &layers.IPv4{
+			Version:  4,
+			TTL:      icmpErrorTTL,
+			Protocol: layers.IPProtocolICMPv4,
+			SrcIP:    src,
+			DstIP:    l.SrcIP,
+		}
+		// The next-hop MTU is in the lower half of the second word of the header.
+		icmp = &layers.ICMPv4{
+			TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeDestinationUnreachable, layers.ICMPv4CodeFragmentationNeeded),
+			Seq:      uint16(mtu),
+		}
+		body = quote(l.Contents, l.Payload, icmpv4MaxQuote)
+	case *layers.IPv6:
+		if isICMPError(pkt) || !validSource(l.SrcIP) {
+			return nil, nil
+		}
+		src := source(l.SrcIP)
+		if src == nil {
+			return nil, fmt.Errorf("no local IPv6 address")
+		}
+		reply.EthernetType = layers.EthernetTypeIPv6
+		ipv6 := &layers.IPv6{
+			Version:    6,
+			HopLimit:   icmpErrorTTL,
+			NextHeader: layers.IPProtocolICMPv6,
+			SrcIP:      src,
+			DstIP:      l.SrcIP,
+		}
+		icmpv6 := &layers.ICMPv6{
+			TypeCode: layers.CreateICMPv6TypeCode(layers.ICMPv6TypePacketTooBig, 0),
+		}
+		if err := icmpv6.SetNetworkLayerForChecksum(ipv6); err != nil {
+			return nil, err
+		}
+		ip, icmp = ipv6, icmpv6
+		// The MTU is the first word of the message body.
+		body = append(binary.BigEndian.AppendUint32(nil, mtu), quote(l.Contents, l.Payload, icmpv6MaxQuote)...)
+	default:
+		return nil, nil
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	if err := gopacket.SerializeLayers(buf, opts, reply, ip, icmp, gopacket.Payload(body)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// quote returns the first maxLen bytes of the IP packet with the header and payload.
+func quote(header, payload []byte, maxLen int) []byte {
+	b := append(append([]byte{}, header...), payload...)
+	return b[:min(len(b), maxLen)]
+}
+
+// isICMPError returns whether the packet is an ICMP error message, which must not be answered with another error.
+func isICMPError(pkt gopacket.Packet) bool {
+	if l, ok := pkt.Layer(layers.LayerTypeICMPv4).(*layers.ICMPv4); ok {
+		switch l.TypeCode.Type() {
+		case layers.ICMPv4TypeDestinationUnreachable, layers.ICMPv4TypeSourceQuench, layers.ICMPv4TypeRedirect,
+			layers.ICMPv4TypeTimeExceeded, layers.ICMPv4TypeParameterProblem:
+			return true
+		}
+	}
+	if l, ok := pkt.Layer(layers.LayerTypeICMPv6).(*layers.ICMPv6); ok {
+		return l.TypeCode.Type() < layers.ICMPv6TypeEchoRequest
+	}
+	return false
+}
+
+// validSource returns whether an ICMP error may be sent to the source address of a packet.
+func validSource(ip net.IP) bool {
+	return !ip.IsUnspecified() && !ip.IsMulticast() && !ip.Equal(net.IPv4bcast)
+}
@@ -0,0 +1,368 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saiserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/openconfig/lemming/dataplane/dplaneopts"
+	"github.com/openconfig/lemming/internal/packetutil"
+
+	diagpb "github.com/openconfig/lemming/dataplane/proto/diag"
+	pktiopb "github.com/openconfig/lemming/dataplane/proto/packetio"
+	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
+	fwdpb "github.com/openconfig/lemming/proto/forwarding"
+)
+
+// checksum returns the one's complement sum of the data, it is 0xFFFF if the data includes a valid checksum.
+func checksum(data ...[]byte) uint16 {
+	var sum uint32
+	b := bytes.Join(data, nil)
+	if len(b)%2 == 1 {
+		b = append(b, 0)
+	}
+	for i := 0; i < len(b); i += 2 {
+		sum += uint32(binary.BigEndian.Uint16(b[i:]))
+	}
+	for sum > 0xFFFF {
+		sum = sum>>16 + sum&0xFFFF
+	}
+	return uint16(sum)
+}
+
+// oversizeFrame returns a frame routed by the switch with an IP packet of length bytes from src to dst.
+func oversizeFrame(t *testing.T, src, dst net.IP, length int, dontFragment bool) []byte {
+	t.Helper()
+	eth := &layers.Ethernet{
+		SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x02},
+		DstMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x01, 0x02},
+		EthernetType: layers.EthernetTypeIPv4,
+	}
+	udp := &layers.UDP{SrcPort: 50000, DstPort: 50001}
+	var ip gopacket.SerializableLayer
+	headerLen := 20
+	if src.To4() != nil {
+		ipv4 := &layers.IPv4{Version: 4, TTL: 63, Protocol: layers.IPProtocolUDP, SrcIP: src.To4(), DstIP: dst.To4()}
+		if dontFragment {
+			ipv4.Flags = layers.IPv4DontFragment
+		}
+		udp.SetNetworkLayerForChecksum(ipv4)
+		ip = ipv4
+	} else {
+		eth.EthernetType = layers.EthernetTypeIPv6
+		ipv6 := &layers.IPv6{Version: 6, HopLimit: 63, NextHeader: layers.IPProtocolUDP, SrcIP: src, DstIP: dst}
+		udp.SetNetworkLayerForChecksum(ipv6)
+		ip = ipv6
+		headerLen = 40
+	}
+	payload := make([]byte, length-headerLen-8)
+	for i := range payload {
+		payload[i] = byte(i)
+	}
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}, eth, ip, udp, gopacket.Payload(payload)); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestPacketTooBig(t *testing.T) {
+	source := func(dst net.IP) net.IP {
+		if dst.To4() != nil {
+			return net.IPv4(192, 0, 2, 1).To4()
+		}
+		return net.ParseIP("2001:db8::1")
+	}
+	v4Src, v4Dst := net.IPv4(192, 0, 2, 100).To4(), net.IPv4(198, 51, 100, 1).To4()
+	v6Src, v6Dst := net.ParseIP("2001:db8::100"), net.ParseIP("2001:db8:1::1")
+
+	t.Run("ipv4", func(t *testing.T) {
+		frame := oversizeFrame(t, v4Src, v4Dst, 2000, true)
+		got, err := packetTooBig(frame, 1500, source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkt := packetutil.Decode(got)
+		if got, want := pkt.Ethernet().DstMAC, (net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x02}); !bytes.Equal(got, want) {
+			t.Errorf("packetTooBig() got destination MAC %v, want the router's %v", got, want)
+		}
+		ip := pkt.IPv4()
+		if ip == nil {
+			t.Fatalf("packetTooBig() got %v, want IPv4 packet", pkt)
+		}
+		if !ip.SrcIP.Equal(source(v4Src)) || !ip.DstIP.Equal(v4Src) {
+			t.Errorf("packetTooBig() got %v -> %v, want %v -> %v", ip.SrcIP, ip.DstIP, source(v4Src), v4Src)
+		}
+		if got := checksum(ip.Contents); got != 0xFFFF {
+			t.Errorf("packetTooBig() got invalid IPv4 header checksum %#x", ip.Checksum)
+		}
+		icmp := ip.Payload
+		if got := checksum(icmp); got != 0xFFFF {
+			t.Errorf("packetTooBig() got invalid ICMP checksum %#x", binary.BigEndian.Uint16(icmp[2:]))
+		}
+		if icmp[0] != 3 || icmp[1] != 4 {
+			t.Errorf("packetTooBig() got ICMP type %d code %d, want type 3 code 4", icmp[0], icmp[1])
+		}
+		if got := binary.BigEndian.Uint16(icmp[6:]); got != 1500 {
+			t.Errorf("packetTooBig() got next-hop MTU %d, want 1500", got)
+		}
+		if len(ip.Contents)+len(icmp) != 576 {
+			t.Errorf("packetTooBig() got %d byte IPv4 packet, want 576", len(ip.Contents)+len(icmp))
+		}
+		if d := cmp.Diff(icmp[8:], frame[14:14+576-28]); d != "" {
+			t.Errorf("packetTooBig() quoted packet unexpected diff (-got,+want):\n%s", d)
+		}
+		quoted := gopacket.NewPacket(icmp[8:], layers.LayerTypeIPv4, gopacket.Default).Layer(layers.LayerTypeIPv4).(*layers.IPv4)
+		if !quoted.SrcIP.Equal(v4Src) || !quoted.DstIP.Equal(v4Dst) || quoted.Length != 2000 {
+			t.Errorf("packetTooBig() got quoted header %v -> %v length %d, want %v -> %v length 2000", quoted.SrcIP, quoted.DstIP, quoted.Length, v4Src, v4Dst)
+		}
+	})
+	t.Run("ipv6", func(t *testing.T) {
+		frame := oversizeFrame(t, v6Src, v6Dst, 2000, false)
+		got, err := packetTooBig(frame, 1500, source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ip := packetutil.Decode(got).IPv6()
+		if ip == nil {
+			t.Fatalf("packetTooBig() got %v, want IPv6 packet", packetutil.Decode(got))
+		}
+		if !ip.SrcIP.Equal(source(v6Src)) || !ip.DstIP.Equal(v6Src) {
+			t.Errorf("packetTooBig() got %v -> %v, want %v -> %v", ip.SrcIP, ip.DstIP, source(v6Src), v6Src)
+		}
+		icmp := ip.Payload
+		pseudo := append(append([]byte{}, ip.SrcIP...), ip.DstIP...)
+		pseudo = binary.BigEndian.AppendUint32(pseudo, uint32(len(icmp)))
+		pseudo = append(pseudo, 0, 0, 0, byte(layers.IPProtocolICMPv6))
+		if got := checksum(pseudo, icmp); got != 0xFFFF {
+			t.Errorf("packetTooBig() got invalid ICMPv6 checksum %#x", binary.BigEndian.Uint16(icmp[2:]))
+		}
+		if icmp[0] != 2 || icmp[1] != 0 {
+			t.Errorf("packetTooBig() got ICMPv6 type %d code %d, want type 2 code 0", icmp[0], icmp[1])
+		}
+		if got := binary.BigEndian.Uint32(icmp[4:]); got != 1500 {
+			t.Errorf("packetTooBig() got MTU %d, want 1500", got)
+		}
+		if len(icmp)+40 != 1280 {
+			t.Errorf("packetTooBig() got %d byte IPv6 packet, want 1280", len(icmp)+40)
+		}
+		if d := cmp.Diff(icmp[8:], frame[14:14+1280-48]); d != "" {
+			t.Errorf("packetTooBig() quoted packet unexpected diff (-got,+want):\n%s", d)
+		}
+	})
+
+	icmpError := func(t *testing.T) []byte {
+		t.Helper()
+		buf := gopacket.NewSerializeBuffer()
+		if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true},
+			&layers.Ethernet{SrcMAC: make(net.HardwareAddr, 6), DstMAC: make(net.HardwareAddr, 6), EthernetType: layers.EthernetTypeIPv4},
+			&layers.IPv4{Version: 4, TTL: 63, Flags: layers.IPv4DontFragment, Protocol: layers.IPProtocolICMPv4, SrcIP: v4Src, DstIP: v4Dst},
+			&layers.ICMPv4{TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeTimeExceeded, 0)},
+			gopacket.Payload(make([]byte, 2000))); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	for _, tt := range []struct {
+		desc  string
+		frame func(*testing.T) []byte
+	}{{
+		desc:  "ipv4-may-fragment",
+		frame: func(t *testing.T) []byte { return oversizeFrame(t, v4Src, v4Dst, 2000, false) },
+	}, {
+		desc:  "icmp-error",
+		frame: icmpError,
+	}, {
+		desc:  "multicast-source",
+		frame: func(t *testing.T) []byte { return oversizeFrame(t, net.ParseIP("ff02::1"), v6Dst, 2000, false) },
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := packetTooBig(tt.frame(t), 1500, source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != nil {
+				t.Errorf("packetTooBig() got %v, want no error sent", packetutil.Decode(got))
+			}
+		})
+	}
+}
+
+func TestPacketTooBigResponder(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sinks := map[string]*packetutil.Sink{"1": packetutil.NewSink(1), "2": packetutil.NewSink(1)}
+	fwdCtx.FakePortManager = lanePortManager{sinks: sinks}
+
+	sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	swAttr, err := saipb.NewSwitchClient(conn).GetSwitchAttribute(ctx, &saipb.GetSwitchAttributeRequest{
+		Oid:      sw.GetOid(),
+		AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_CPU_PORT, saipb.SwitchAttr_SWITCH_ATTR_DEFAULT_VIRTUAL_ROUTER_ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vrf := swAttr.GetAttr().GetDefaultVirtualRouterId()
+	acceptAnyDstMAC(t, conn)
+	// The sources are behind the port on lane 1, the destinations behind the port on lane 2, which has a smaller MTU.
+	var ports []uint64
+	for i := uint32(1); i <= 2; i++ {
+		req := &saipb.CreatePortRequest{
+			HwLaneList: []uint32{i},
+			AdminState: proto.Bool(true),
+		}
+		if i == 2 {
+			req.Mtu = proto.Uint32(1514)
+		}
+		port, err := saipb.NewPortClient(conn).CreatePort(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ports = append(ports, port.GetOid())
+		rif, err := saipb.NewRouterInterfaceClient(conn).CreateRouterInterface(ctx, &saipb.CreateRouterInterfaceRequest{
+			Type:            saipb.RouterInterfaceType_ROUTER_INTERFACE_TYPE_PORT.Enum(),
+			PortId:          proto.Uint64(port.GetOid()),
+			VirtualRouterId: proto.Uint64(vrf),
+			SrcMacAddress:   []byte{0x02, 0x00, 0x00, 0x00, 0x00, byte(i)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		nhIP := []byte{192, 0, 2, byte(i + 1)}
+		if _, err := saipb.NewNeighborClient(conn).CreateNeighborEntry(ctx, &saipb.CreateNeighborEntryRequest{
+			Entry:         &saipb.NeighborEntry{SwitchId: sw.GetOid(), RifId: rif.GetOid(), IpAddress: nhIP},
+			DstMacAddress: []byte{0x02, 0x00, 0x00, 0x00, 0x01, byte(i)},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		nh, err := saipb.NewNextHopClient(conn).CreateNextHop(ctx, &saipb.CreateNextHopRequest{
+			Switch:            sw.GetOid(),
+			Type:              saipb.NextHopType_NEXT_HOP_TYPE_IP.Enum(),
+			Ip:                nhIP,
+			RouterInterfaceId: proto.Uint64(rif.GetOid()),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := []byte{203, 0, 113, 0}
+		if i == 2 {
+			dst = []byte{198, 51, 100, 0}
+		}
+		if _, err := saipb.NewRouteClient(conn).CreateRouteEntry(ctx, &saipb.CreateRouteEntryRequest{
+			Entry:        &saipb.RouteEntry{SwitchId: sw.GetOid(), VrId: vrf, Destination: &saipb.IpPrefix{Addr: dst, Mask: []byte{255, 255, 255, 0}}},
+			NextHopId:    proto.Uint64(nh.GetOid()),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_FORWARD.Enum(),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The switch's local address is the source of the ICMP errors.
+	if _, err := saipb.NewRouteClient(conn).CreateRouteEntry(ctx, &saipb.CreateRouteEntryRequest{
+		Entry:        &saipb.RouteEntry{SwitchId: sw.GetOid(), VrId: vrf, Destination: &saipb.IpPrefix{Addr: []byte{192, 0, 2, 1}, Mask: []byte{255, 255, 255, 255}}},
+		NextHopId:    proto.Uint64(swAttr.GetAttr().GetCpuPort()),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_FORWARD.Enum(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	cpu, err := pktiopb.NewPacketIOClient(conn).CPUPacketStream(streamCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cpu.Send(&pktiopb.PacketIn{Msg: &pktiopb.PacketIn_Init{}}); err != nil {
+		t.Fatal(err)
+	}
+	dc := diagpb.NewDiagClient(conn)
+	for start := time.Now(); ; time.Sleep(time.Millisecond) {
+		resp, err := dc.ListHostifStreams(ctx, &diagpb.ListHostifStreamsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.GetStreams()) > 0 {
+			break
+		}
+		if time.Since(start) > time.Second {
+			t.Fatal("CPU packet stream not opened")
+		}
+	}
+
+	src, dst := net.IPv4(203, 0, 113, 1).To4(), net.IPv4(198, 51, 100, 1).To4()
+	frame := oversizeFrame(t, src, dst, 1600, true)
+	copy(frame, []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 0x00, 0x00, 0x00, 0x01, 0x01})
+	if err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(ports[0])}},
+		fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, frame, nil, false, fwdpb.PortAction_PORT_ACTION_INPUT); err != nil {
+		t.Fatal(err)
+	}
+
+	// The packet is dropped, and the ICMP error is routed back out of the port it was received on.
+	if pkt, err := sinks["2"].Next(100 * time.Millisecond); err == nil {
+		t.Errorf("oversize packet unexpectedly transmitted: %v", pkt)
+	}
+	pkt, err := sinks["1"].Next(time.Second)
+	if err != nil {
+		t.Fatalf("no ICMP error transmitted: %v", err)
+	}
+	ip := pkt.IPv4()
+	if ip == nil {
+		t.Fatalf("got %v, want IPv4 packet", pkt)
+	}
+	if want := net.IPv4(192, 0, 2, 1).To4(); !ip.SrcIP.Equal(want) || !ip.DstIP.Equal(src) {
+		t.Errorf("ICMP error got %v -> %v, want %v -> %v", ip.SrcIP, ip.DstIP, want, src)
+	}
+	icmp := ip.Payload
+	if icmp[0] != 3 || icmp[1] != 4 {
+		t.Errorf("ICMP error got type %d code %d, want type 3 code 4", icmp[0], icmp[1])
+	}
+	if got := checksum(icmp); got != 0xFFFF {
+		t.Errorf("ICMP error got invalid checksum %#x", binary.BigEndian.Uint16(icmp[2:]))
+	}
+	if got := binary.BigEndian.Uint16(icmp[6:]); got != 1500 {
+		t.Errorf("ICMP error got next-hop MTU %d, want 1500", got)
+	}
+	quoted := gopacket.NewPacket(icmp[8:], layers.LayerTypeIPv4, gopacket.Default).Layer(layers.LayerTypeIPv4).(*layers.IPv4)
+	if !quoted.SrcIP.Equal(src) || !quoted.DstIP.Equal(dst) || quoted.Length != 1600 {
+		t.Errorf("ICMP error got quoted header %v -> %v length %d, want %v -> %v length 1600", quoted.SrcIP, quoted.DstIP, quoted.Length, src, dst)
+	}
+	if got := checksum(quoted.Contents); got != 0xFFFF {
+		t.Errorf("ICMP error got quoted header with invalid checksum %#x", quoted.Checksum)
+	}
+
+	stats, err := saipb.NewPortClient(conn).GetPortStats(ctx, &saipb.GetPortStatsRequest{
+		Oid:        ports[1],
+		CounterIds: []saipb.PortStat{saipb.PortStat_PORT_STAT_ETHER_TX_OVERSIZE_PKTS},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(stats.GetValues(), []uint64{1}); d != "" {
+		t.Errorf("GetPortStats() failed: diff(-got,+want)\n:%s", d)
+	}
+}
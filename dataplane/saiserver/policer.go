@@ -18,6 +18,8 @@ import (
 	"context"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
 
@@ -39,8 +41,12 @@ func newPolicer(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Serv
 	return p
 }
 
-// CreatePolicer return policer.
-func (p *policer) CreatePolicer(context.Context, *saipb.CreatePolicerRequest) (*saipb.CreatePolicerResponse, error) {
-	// TODO: provide implementation.
-	return &saipb.CreatePolicerResponse{}, nil
+// CreatePolicer creates a policer. Policers only apply to the traps of the trap groups they are attached to.
+// TODO: Support policers for ports and ACLs.
+func (p *policer) CreatePolicer(_ context.Context, req *saipb.CreatePolicerRequest) (*saipb.CreatePolicerResponse, error) {
+	// TODO: Support packet meters, the rate limit action only counts bytes.
+	if req.GetMeterType() != saipb.MeterType_METER_TYPE_BYTES {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported meter type: %v", req.GetMeterType())
+	}
+	return &saipb.CreatePolicerResponse{Oid: p.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_POLICER)}, nil
 }
@@ -0,0 +1,70 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saiserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
+	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
+)
+
+func TestCreatePolicer(t *testing.T) {
+	tests := []struct {
+		desc    string
+		req     *saipb.CreatePolicerRequest
+		wantErr string
+	}{{
+		desc: "byte meter",
+		req: &saipb.CreatePolicerRequest{
+			MeterType: saipb.MeterType_METER_TYPE_BYTES.Enum(),
+			Mode:      saipb.PolicerMode_POLICER_MODE_SR_TCM.Enum(),
+			Cbs:       proto.Uint64(1000),
+			Cir:       proto.Uint64(100),
+		},
+	}, {
+		desc: "packet meter",
+		req: &saipb.CreatePolicerRequest{
+			MeterType: saipb.MeterType_METER_TYPE_PACKETS.Enum(),
+			Mode:      saipb.PolicerMode_POLICER_MODE_SR_TCM.Enum(),
+			Cbs:       proto.Uint64(10),
+			Cir:       proto.Uint64(1),
+		},
+		wantErr: "unsupported meter type",
+	}, {
+		desc: "no meter type",
+		req: &saipb.CreatePolicerRequest{
+			Mode: saipb.PolicerMode_POLICER_MODE_SR_TCM.Enum(),
+		},
+		wantErr: "unsupported meter type",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			conn, _, stopFn := newTestServer(t, func(mgr *attrmgr.AttrMgr, srv *grpc.Server) {
+				newPolicer(mgr, &fakeSwitchDataplane{}, srv)
+			})
+			defer stopFn()
+			_, gotErr := saipb.NewPolicerClient(conn).CreatePolicer(context.Background(), tt.req)
+			if d := errdiff.Check(gotErr, tt.wantErr); d != "" {
+				t.Fatalf("CreatePolicer() unexpected err: %s", d)
+			}
+		})
+	}
+}
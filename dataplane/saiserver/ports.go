@@ -16,18 +16,24 @@ package saiserver
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net"
 	"os"
+	"slices"
 	"strings"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/openconfig/lemming/dataplane/cpusink"
 	"github.com/openconfig/lemming/dataplane/dplaneopts"
 	"github.com/openconfig/lemming/dataplane/forwarding/fwdconfig"
+	"github.com/openconfig/lemming/dataplane/forwarding/fwdport"
 	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
 
 	log "github.com/golang/glog"
@@ -43,7 +49,10 @@ func newPort(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server,
 		portToEth: make(map[uint64]string),
 		nextEth:   1, // Start at eth1
 		opts:      opts,
+		mtus:      make(map[uint64]*portMTU),
+		speeds:    make(map[uint64]*portSpeed),
 	}
+	p.resetACLs()
 	if opts.PortConfigFile != "" {
 		data, err := os.ReadFile(opts.PortConfigFile)
 		if err != nil {
@@ -67,13 +76,62 @@ type port struct {
 	portToEth map[uint64]string
 	opts      *dplaneopts.Options
 	config    *dplaneopts.PortConfig
+	mtus      map[uint64]*portMTU    // Enforced MTUs by port id.
+	speeds    map[uint64]*portSpeed  // Speed settings by port id.
+	cpuPort   func() (uint64, error) // cpuPort returns the ID of the CPU port.
+	switchID  func() uint64          // switchID returns the ID of the switch.
+	// ingressACLs and egressACLs are the ACL table groups bound to the ports.
+	ingressACLs *portACLs
+	egressACLs  *portACLs
 }
 
+// portACLs are the ACL table groups bound to ports at one stage.
+type portACLs struct {
+	stage  saipb.AclStage
+	table  string               // Table matching the port to its group.
+	field  fwdpb.PacketFieldNum // Field holding the port's NID.
+	limit  int                  // Maximum number of distinct groups bound.
+	groups map[uint64]uint64    // Bound group by port id.
+	nids   map[uint64]uint64    // NID by port id, for the ports with a bound group.
+}
+
+// groupsExcept returns the distinct groups bound to the ports other than id.
+func (a *portACLs) groupsExcept(id uint64) map[uint64]struct{} {
+	groups := map[uint64]struct{}{}
+	for p, g := range a.groups {
+		if p != id {
+			groups[g] = struct{}{}
+		}
+	}
+	return groups
+}
+
+// portMTU is the MTU enforced on the frames received and transmitted by a port.
+type portMTU struct {
+	nid uint64
+	mtu uint32
+}
+
+// portSpeed is the speed configuration of a port.
+type portSpeed struct {
+	speed      uint32   // Speed used unless auto-negotiation succeeds.
+	autoNeg    bool     // Whether to auto-negotiate the speed with the link partner.
+	advertised []uint32 // Speeds advertised to the link partner.
+}
+
+const defaultPortSpeed = 40000
+
+// supportedPortSpeeds are the speeds supported by every port, in Mbps.
+var supportedPortSpeeds = []uint32{1000, 10000, 40000, 50000, 100000, 200000, 400000, 800000}
+
 // stub for testing
 var getInterface = net.InterfaceByName
 
 func getForwardingPipeline() []*fwdpb.ActionDesc {
 	return []*fwdpb.ActionDesc{
+		fwdconfig.Action(fwdconfig.LookupAction(ingressMTUTable)).Build(),                               // Drop frames larger than the port MTU.
+		fwdconfig.Action(fwdconfig.LookupAction(bridgePortTable)).Build(),                               // Learn and switch frames received on bridge ports.
+		fwdconfig.Action(fwdconfig.LookupAction(vlanFloodTable)).Build(),                                // Flood broadcast frames within their VLAN.
 		fwdconfig.Action(fwdconfig.LookupAction(MyMacTable)).Build(),                                    // Decide whether to process the packet.
 		fwdconfig.Action(fwdconfig.LookupAction(inputIfaceTable)).Build(),                               // Match packet to interface.
 		fwdconfig.Action(fwdconfig.LookupAction(IngressVRFTable)).Build(),                               // Match interface to VRF.
@@ -81,12 +139,15 @@ func getForwardingPipeline() []*fwdpb.ActionDesc {
 		fwdconfig.Action(fwdconfig.DecapAction(fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET)).Build(), // Decap L2 header.
 		fwdconfig.Action(fwdconfig.LookupAction(tunTermTable)).Build(),                                  // Decap the packet if we have a tunnel.
 		fwdconfig.Action(fwdconfig.LookupAction(IngressActionTable)).Build(),                            // Run ingress action.
+		fwdconfig.Action(fwdconfig.LookupAction(portIngressACLTable)).Build(),                           // Run the ingress ACL bound to the input port.
 		fwdconfig.Action(fwdconfig.LookupAction(FIBSelectorTable)).Build(),                              // Lookup in FIB.
 		fwdconfig.Action(fwdconfig.EncapAction(fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET)).Build(), // Encap L2 header.
 		fwdconfig.Action(fwdconfig.LookupAction(outputIfaceTable)).Build(),                              // Match interface to port
 		fwdconfig.Action(fwdconfig.LookupAction(NeighborTable)).Build(),                                 // Lookup in the neighbor table.
 		fwdconfig.Action(fwdconfig.LookupAction(EgressActionTable)).Build(),                             // Run egress actions
+		fwdconfig.Action(fwdconfig.LookupAction(portEgressACLTable)).Build(),                            // Run the egress ACL bound to the output port.
 		fwdconfig.Action(fwdconfig.LookupAction(SRCMACTable)).Build(),                                   // Lookup interface's MAC addr.
+		fwdconfig.Action(fwdconfig.LookupAction(egressMTUTable)).Build(),                                // Drop frames larger than the output port MTU.
 		{
 			ActionType: fwdpb.ActionType_ACTION_TYPE_OUTPUT,
 		},
@@ -96,7 +157,7 @@ func getForwardingPipeline() []*fwdpb.ActionDesc {
 // CreatePort creates a new port, mapping the port to ethX, where X is assigned sequentially from 1 to n.
 // Note: If more ports are created than eth devices, no error is returned, but the OperStatus is set to NOT_PRESENT.
 func (port *port) CreatePort(ctx context.Context, req *saipb.CreatePortRequest) (*saipb.CreatePortResponse, error) {
-	id := port.mgr.NextID()
+	id := port.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_PORT)
 	// Set the type early, otherwise the initial port notifs won't send.
 	port.mgr.SetType(fmt.Sprint(id), saipb.ObjectType_OBJECT_TYPE_PORT)
 
@@ -185,8 +246,9 @@ func (port *port) CreatePort(ctx context.Context, req *saipb.CreatePortRequest)
 		QosDscpToForwardingClassMap:      proto.Uint64(0),
 		QosMplsExpToForwardingClassMap:   proto.Uint64(0),
 		IpsecPort:                        proto.Uint64(0),
-		SupportedSpeed:                   []uint32{1000, 10000, 40000, 50000, 100000, 200000, 400000, 800000},
-		OperSpeed:                        proto.Uint32(40000),
+		SupportedSpeed:                   slices.Clone(supportedPortSpeeds),
+		AdvertisedSpeed:                  slices.Clone(supportedPortSpeeds),
+		OperSpeed:                        proto.Uint32(defaultPortSpeed),
 		SupportedFecMode:                 []saipb.PortFecMode{saipb.PortFecMode_PORT_FEC_MODE_NONE},
 		NumberOfIngressPriorityGroups:    proto.Uint32(0),
 		QosMaximumHeadroomSize:           proto.Uint32(0),
@@ -250,7 +312,6 @@ func (port *port) CreatePort(ctx context.Context, req *saipb.CreatePortRequest)
 				Oid: id,
 			}, nil
 		}
-		port.portToEth[id] = dev
 
 	case fwdpb.PortType_PORT_TYPE_FAKE:
 		fwdPort.Port.Port = &fwdpb.PortDesc_Fake{
@@ -283,6 +344,16 @@ func (port *port) CreatePort(ctx context.Context, req *saipb.CreatePortRequest)
 	if _, err := port.dataplane.PortUpdate(ctx, update); err != nil {
 		return nil, err
 	}
+	if req.Mtu != nil {
+		if err := port.setMTU(ctx, id, req.GetMtu()); err != nil {
+			return nil, err
+		}
+	}
+	if req.InternalLoopbackMode != nil {
+		if err := port.setLoopback(ctx, id, req.GetInternalLoopbackMode()); err != nil {
+			return nil, err
+		}
+	}
 	attrs.OperStatus = saipb.PortOperStatus_PORT_OPER_STATUS_UP.Enum()
 	if req.AdminState == nil || req.GetAdminState() == false {
 		stateReq := &fwdpb.PortStateRequest{
@@ -302,11 +373,97 @@ func (port *port) CreatePort(ctx context.Context, req *saipb.CreatePortRequest)
 
 	port.mgr.StoreAttributes(id, attrs)
 
+	port.portToEth[id] = dev
+	ps := &portSpeed{
+		speed:      defaultPortSpeed,
+		autoNeg:    req.AutoNegMode == nil || req.GetAutoNegMode(),
+		advertised: slices.Clone(supportedPortSpeeds),
+	}
+	if req.Speed != nil {
+		ps.speed = req.GetSpeed()
+	}
+	if req.AdvertisedSpeed != nil {
+		ps.advertised = req.GetAdvertisedSpeed()
+	}
+	port.speeds[id] = ps
+	port.negotiateSpeed(id)
+
 	return &saipb.CreatePortResponse{
 		Oid: id,
 	}, nil
 }
 
+// linkPartner returns the port linked to the port, if it exists.
+func (port *port) linkPartner(id uint64) (uint64, bool) {
+	dev, ok := port.portToEth[id]
+	if !ok {
+		return 0, false
+	}
+	peerDev := port.opts.PortLinks[dev]
+	for a, b := range port.opts.PortLinks {
+		if b == dev {
+			peerDev = a
+		}
+	}
+	if peerDev == "" {
+		return 0, false
+	}
+	for peer, peerEth := range port.portToEth {
+		if _, ok := port.speeds[peer]; ok && peerEth == peerDev {
+			return peer, true
+		}
+	}
+	return 0, false
+}
+
+// negotiateSpeed updates the operational speed of the port and its link partner.
+// If both auto-negotiate, they use the highest speed both advertise, otherwise each uses its configured speed.
+func (port *port) negotiateSpeed(id uint64) {
+	ps, ok := port.speeds[id]
+	if !ok {
+		return
+	}
+	attrs := &saipb.PortAttribute{
+		OperSpeed:             proto.Uint32(ps.speed),
+		AutoNegStatus:         proto.Bool(false),
+		RemoteAdvertisedSpeed: []uint32{},
+	}
+	peer, linked := port.linkPartner(id)
+	if !linked {
+		port.mgr.StoreAttributes(id, attrs)
+		return
+	}
+	peerPS := port.speeds[peer]
+	peerAttrs := &saipb.PortAttribute{
+		OperSpeed:             proto.Uint32(peerPS.speed),
+		AutoNegStatus:         proto.Bool(false),
+		RemoteAdvertisedSpeed: []uint32{},
+	}
+	if ps.autoNeg && peerPS.autoNeg {
+		attrs.RemoteAdvertisedSpeed = slices.Clone(peerPS.advertised)
+		peerAttrs.RemoteAdvertisedSpeed = slices.Clone(ps.advertised)
+		if speed := highestCommonSpeed(ps.advertised, peerPS.advertised); speed != 0 {
+			attrs.OperSpeed, peerAttrs.OperSpeed = proto.Uint32(speed), proto.Uint32(speed)
+			attrs.AutoNegStatus, peerAttrs.AutoNegStatus = proto.Bool(true), proto.Bool(true)
+		} else {
+			log.Warningf("ports %d and %d advertise no common speed", id, peer)
+		}
+	}
+	port.mgr.StoreAttributes(id, attrs)
+	port.mgr.StoreAttributes(peer, peerAttrs)
+}
+
+// highestCommonSpeed returns the highest speed in both a and b, or 0 if there is none.
+func highestCommonSpeed(a, b []uint32) uint32 {
+	var highest uint32
+	for _, speed := range a {
+		if speed > highest && slices.Contains(b, speed) {
+			highest = speed
+		}
+	}
+	return highest
+}
+
 // CreatePorts creates multiple ports.
 func (port *port) CreatePorts(ctx context.Context, re *saipb.CreatePortsRequest) (*saipb.CreatePortsResponse, error) {
 	resp := &saipb.CreatePortsResponse{}
@@ -321,7 +478,7 @@ func (port *port) CreatePorts(ctx context.Context, re *saipb.CreatePortsRequest)
 }
 
 func (port *port) createCPUPort(ctx context.Context) (uint64, error) {
-	id := port.mgr.NextID()
+	id := port.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_PORT)
 
 	_, err := port.dataplane.PortCreate(ctx, &fwdpb.PortCreateRequest{
 		ContextId: &fwdpb.ContextId{Id: port.dataplane.ID()},
@@ -330,7 +487,8 @@ func (port *port) createCPUPort(ctx context.Context) (uint64, error) {
 			PortId:   &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(id)}},
 			Port: &fwdpb.PortDesc_Cpu{
 				Cpu: &fwdpb.CPUPortDesc{
-					RemotePort: port.opts.RemoteCPUPort,
+					RemotePort:     port.opts.RemoteCPUPort,
+					ExportFieldIds: []*fwdpb.PacketFieldId{cpuQueueFieldID()},
 				},
 			},
 		},
@@ -366,6 +524,8 @@ func (port *port) createCPUPort(ctx context.Context) (uint64, error) {
 						Outputs: []*fwdpb.ActionDesc{
 							fwdconfig.Action(fwdconfig.LookupAction(trapIDToHostifTable)).Build(),
 							fwdconfig.Action(fwdconfig.LookupAction(portToHostifTable)).Build(),
+							fwdconfig.Action(fwdconfig.LookupAction(portVlanToHostifTable)).Build(),
+							fwdconfig.Action(fwdconfig.LookupAction(mtuErrorTable)).Build(),
 						},
 					},
 				},
@@ -441,6 +601,38 @@ func (port *port) createCPUPort(ctx context.Context) (uint64, error) {
 
 // SetPortAttributes sets the attributes in the request.
 func (port *port) SetPortAttribute(ctx context.Context, req *saipb.SetPortAttributeRequest) (*saipb.SetPortAttributeResponse, error) {
+	if ps, ok := port.speeds[req.GetOid()]; ok && (req.Speed != nil || req.AutoNegMode != nil || req.AdvertisedSpeed != nil) {
+		if req.Speed != nil {
+			ps.speed = req.GetSpeed()
+		}
+		if req.AutoNegMode != nil {
+			ps.autoNeg = req.GetAutoNegMode()
+		}
+		if req.AdvertisedSpeed != nil {
+			ps.advertised = req.GetAdvertisedSpeed()
+		}
+		port.negotiateSpeed(req.GetOid())
+	}
+	if req.Mtu != nil {
+		if err := port.setMTU(ctx, req.GetOid(), req.GetMtu()); err != nil {
+			return nil, err
+		}
+	}
+	if req.InternalLoopbackMode != nil {
+		if err := port.setLoopback(ctx, req.GetOid(), req.GetInternalLoopbackMode()); err != nil {
+			return nil, err
+		}
+	}
+	if req.IngressAcl != nil {
+		if err := port.bindACL(ctx, req.GetOid(), port.ingressACLs, req.GetIngressAcl()); err != nil {
+			return nil, err
+		}
+	}
+	if req.EgressAcl != nil {
+		if err := port.bindACL(ctx, req.GetOid(), port.egressACLs, req.GetEgressAcl()); err != nil {
+			return nil, err
+		}
+	}
 	if req.AdminState != nil {
 		// Skip ports that don't exsit.
 		attrReq := &saipb.GetPortAttributeRequest{Oid: req.GetOid(), AttrType: []saipb.PortAttr{saipb.PortAttr_PORT_ATTR_OPER_STATUS}}
@@ -470,6 +662,313 @@ func (port *port) SetPortAttribute(ctx context.Context, req *saipb.SetPortAttrib
 	return &saipb.SetPortAttributeResponse{}, nil
 }
 
+// bindACL binds the ACL table group to the port at the stage of acls, replacing the previously bound group.
+// A group of 0 unbinds it. Binding a group not already bound to another port fails if the stage's limit is reached.
+func (port *port) bindACL(ctx context.Context, id uint64, acls *portACLs, group uint64) error {
+	cur, bound := acls.groups[id]
+	if bound && cur == group || !bound && group == 0 {
+		return nil
+	}
+	if group != 0 {
+		if t := port.mgr.GetType(fmt.Sprint(group)); t != saipb.ObjectType_OBJECT_TYPE_ACL_TABLE_GROUP {
+			return status.Errorf(codes.InvalidArgument, "only ACL table groups can be bound to ports, got %v", t)
+		}
+		attrReq := &saipb.GetAclTableGroupAttributeRequest{Oid: group, AttrType: []saipb.AclTableGroupAttr{saipb.AclTableGroupAttr_ACL_TABLE_GROUP_ATTR_ACL_STAGE}}
+		attrResp := &saipb.GetAclTableGroupAttributeResponse{}
+		if err := port.mgr.PopulateAttributes(attrReq, attrResp); err != nil {
+			return err
+		}
+		if stage := attrResp.GetAttr().GetAclStage(); stage != acls.stage {
+			return status.Errorf(codes.InvalidArgument, "ACL table group %d has stage %v, want %v", group, stage, acls.stage)
+		}
+		if groups := acls.groupsExcept(id); len(groups) >= acls.limit {
+			if _, ok := groups[group]; !ok {
+				return status.Errorf(codes.ResourceExhausted, "%d ACL table groups already bound to ports at stage %v", len(groups), acls.stage)
+			}
+		}
+	}
+
+	if bound {
+		_, err := port.dataplane.TableEntryRemove(ctx, fwdconfig.TableEntryRemoveRequest(port.dataplane.ID(), acls.table).
+			AppendEntry(fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(acls.field).WithUint64(acls.nids[id])))).Build())
+		if err != nil {
+			return err
+		}
+		delete(acls.groups, id)
+		delete(acls.nids, id)
+	}
+	if group != 0 {
+		nid, err := port.dataplane.ObjectNID(ctx, &fwdpb.ObjectNIDRequest{
+			ContextId: &fwdpb.ContextId{Id: port.dataplane.ID()},
+			ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(id)},
+		})
+		if err != nil {
+			return err
+		}
+		_, err = port.dataplane.TableEntryAdd(ctx, fwdconfig.TableEntryAddRequest(port.dataplane.ID(), acls.table).
+			AppendEntry(
+				fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(acls.field).WithUint64(nid.GetNid()))),
+				fwdconfig.Action(fwdconfig.LookupAction(fmt.Sprint(group))),
+			).Build())
+		if err != nil {
+			return err
+		}
+		acls.groups[id] = group
+		acls.nids[id] = nid.GetNid()
+	}
+	port.mgr.StoreAttributes(port.switchID(), &saipb.SwitchAttribute{
+		AvailableAclTableGroup: port.availableACLGroups(),
+	})
+	return nil
+}
+
+// availableACLGroups returns the number of ACL table groups that can still be bound to ports at each stage.
+func (port *port) availableACLGroups() []*saipb.ACLResource {
+	var res []*saipb.ACLResource
+	for _, acls := range []*portACLs{port.ingressACLs, port.egressACLs} {
+		res = append(res, &saipb.ACLResource{
+			Stage:     acls.stage,
+			BindPoint: saipb.AclBindPointType_ACL_BIND_POINT_TYPE_PORT,
+			AvailNum:  uint32(max(acls.limit-len(acls.groupsExcept(0)), 0)), // Port ids are never 0.
+		})
+	}
+	return res
+}
+
+// resetACLs unbinds all the ACL table groups from the ports.
+func (port *port) resetACLs() {
+	port.ingressACLs = &portACLs{
+		stage:  saipb.AclStage_ACL_STAGE_INGRESS,
+		table:  portIngressACLTable,
+		field:  fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT,
+		limit:  port.opts.PortIngressACLGroups,
+		groups: make(map[uint64]uint64),
+		nids:   make(map[uint64]uint64),
+	}
+	port.egressACLs = &portACLs{
+		stage:  saipb.AclStage_ACL_STAGE_EGRESS,
+		table:  portEgressACLTable,
+		field:  fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_OUTPUT,
+		limit:  port.opts.PortEgressACLGroups,
+		groups: make(map[uint64]uint64),
+		nids:   make(map[uint64]uint64),
+	}
+}
+
+// portMTUTable returns the name of the table that drops the frames of the port larger than its MTU.
+func portMTUTable(oid uint64, ingress bool) string {
+	if ingress {
+		return fmt.Sprintf("%d-ingress-mtu", oid)
+	}
+	return fmt.Sprintf("%d-egress-mtu", oid)
+}
+
+// oversizeCounterID returns the id of the counter of frames dropped for exceeding the MTU of the port.
+func oversizeCounterID(oid uint64, ingress bool) string {
+	if ingress {
+		return fmt.Sprintf("%d-in-oversize-counter", oid)
+	}
+	return fmt.Sprintf("%d-out-oversize-counter", oid)
+}
+
+// lengthsAbove returns masked packet lengths that together match every frame longer than mtu.
+// There is one match for each unset bit of mtu: the bits above it are equal to mtu's and the bit is set.
+// Frames are never longer than 64KiB, so only the lower 16 bits are considered.
+func lengthsAbove(mtu uint32) []*fwdconfig.PacketFieldMaskedBytesBuilder {
+	if mtu >= math.MaxUint16 {
+		return nil
+	}
+	var lengths []*fwdconfig.PacketFieldMaskedBytesBuilder
+	for i := 0; i < 16; i++ {
+		if mtu&(1<<i) != 0 {
+			continue
+		}
+		value := (uint64(mtu)>>i | 1) << i
+		mask := uint64(math.MaxUint64) << i
+		lengths = append(lengths, fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_LENGTH).
+			WithBytes(binary.BigEndian.AppendUint64(nil, value), binary.BigEndian.AppendUint64(nil, mask)))
+	}
+	return lengths
+}
+
+// Entries in the MTU tables are matched in order of priority, lower values first.
+// IP packets that may be answered with an ICMP error take precedence over the other oversize frames.
+const (
+	mtuErrorPriority = 0
+	mtuDropPriority  = 1
+)
+
+// mtuErrorFlag marks the trap and host port IDs of the packets punted for exceeding the MTU of their output port.
+// Object IDs never have the top bit set.
+const mtuErrorFlag = 1 << 63
+
+// mtuErrorID returns the trap and host port ID of the packets punted for exceeding the MTU of the port.
+func mtuErrorID(portID uint64) uint64 {
+	return mtuErrorFlag | portID
+}
+
+// mtuErrorPort returns the port whose MTU the packet punted to the host port exceeded, if it was punted for that reason.
+func mtuErrorPort(hostPort uint64) (uint64, bool) {
+	return hostPort &^ mtuErrorFlag, hostPort&mtuErrorFlag != 0
+}
+
+// mtuEntries returns the request adding the entries of the MTU table of the port that match the frames longer than mtu.
+// Oversize frames are counted and dropped. With a remote CPU port, the oversize IP packets transmitted by the port
+// are punted instead, so that they are answered with an ICMP Packet Too Big error.
+func (port *port) mtuEntries(id uint64, ingress bool, mtu uint32) (*fwdpb.TableEntryAddRequest, error) {
+	req := fwdconfig.TableEntryAddRequest(port.dataplane.ID(), portMTUTable(id, ingress))
+	lengths := lengthsAbove(mtu)
+	for _, l := range lengths {
+		req.AppendEntry(fwdconfig.EntryDesc(fwdconfig.FlowEntry(l)),
+			fwdconfig.Action(fwdconfig.FlowCounterAction(oversizeCounterID(id, ingress))),
+			fwdconfig.Action(fwdconfig.DropAction()),
+		)
+	}
+	if !ingress && port.opts.RemoteCPUPort && port.cpuPort != nil {
+		cpuPortID, err := port.cpuPort()
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range lengths {
+			for _, version := range []byte{4, 6} {
+				req.AppendEntry(fwdconfig.EntryDesc(fwdconfig.FlowEntry(l,
+					fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_VERSION).WithBytes([]byte{version}, []byte{0xFF}))),
+					fwdconfig.Action(fwdconfig.FlowCounterAction(oversizeCounterID(id, ingress))),
+					fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_TRAP_ID).WithUint64Value(mtuErrorID(id))),
+					fwdconfig.Action(fwdconfig.TransmitAction(fmt.Sprint(cpuPortID)).WithImmediate(true)),
+				)
+			}
+		}
+	}
+	built := req.Build()
+	for i, entry := range built.GetEntries() {
+		entry.GetEntryDesc().GetFlow().Priority = mtuDropPriority
+		if i >= len(lengths) {
+			entry.GetEntryDesc().GetFlow().Priority = mtuErrorPriority
+		}
+	}
+	return built, nil
+}
+
+// setMTU drops and counts the frames received or transmitted by the port that are longer than mtu.
+// The default MTU isn't enforced until it is explicitly set.
+// setLoopback sets the internal loopback mode of the port. With PHY or MAC loopback,
+// packets output on the port are received back on it instead of being transmitted.
+func (port *port) setLoopback(ctx context.Context, id uint64, mode saipb.PortInternalLoopbackMode) error {
+	req := &fwdpb.AttributeUpdateRequest{
+		ContextId: &fwdpb.ContextId{Id: port.dataplane.ID()},
+		ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(id)},
+		AttrId:    string(fwdport.AttrLoopback),
+	}
+	switch mode {
+	case saipb.PortInternalLoopbackMode_PORT_INTERNAL_LOOPBACK_MODE_NONE:
+	case saipb.PortInternalLoopbackMode_PORT_INTERNAL_LOOPBACK_MODE_PHY, saipb.PortInternalLoopbackMode_PORT_INTERNAL_LOOPBACK_MODE_MAC:
+		req.AttrValue = "true"
+	default:
+		return status.Errorf(codes.InvalidArgument, "unsupported internal loopback mode %v", mode)
+	}
+	_, err := port.dataplane.AttributeUpdate(ctx, req)
+	return err
+}
+
+func (port *port) setMTU(ctx context.Context, id uint64, mtu uint32) error {
+	pm, ok := port.mtus[id]
+	if !ok {
+		nid, err := port.dataplane.ObjectNID(ctx, &fwdpb.ObjectNIDRequest{
+			ContextId: &fwdpb.ContextId{Id: port.dataplane.ID()},
+			ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(id)},
+		})
+		if err != nil {
+			return err
+		}
+		pm = &portMTU{nid: nid.GetNid()}
+		if err := port.createMTUTables(ctx, id, pm.nid); err != nil {
+			return err
+		}
+		port.mtus[id] = pm
+	} else if pm.mtu == mtu {
+		return nil
+	}
+
+	for _, ingress := range []bool{true, false} {
+		if ok {
+			prev, err := port.mtuEntries(id, ingress, pm.mtu)
+			if err != nil {
+				return err
+			}
+			remove := &fwdpb.TableEntryRemoveRequest{
+				ContextId: prev.GetContextId(),
+				TableId:   prev.GetTableId(),
+			}
+			for _, entry := range prev.GetEntries() {
+				remove.Entries = append(remove.Entries, entry.GetEntryDesc())
+			}
+			if _, err := port.dataplane.TableEntryRemove(ctx, remove); err != nil {
+				return err
+			}
+		}
+		add, err := port.mtuEntries(id, ingress, mtu)
+		if err != nil {
+			return err
+		}
+		if _, err := port.dataplane.TableEntryAdd(ctx, add); err != nil {
+			return err
+		}
+	}
+	pm.mtu = mtu
+	return nil
+}
+
+// createMTUTables creates the oversize counters and MTU tables of the port and looks them up from the pipeline.
+func (port *port) createMTUTables(ctx context.Context, id, nid uint64) error {
+	for _, ingress := range []bool{true, false} {
+		_, err := port.dataplane.FlowCounterCreate(ctx, &fwdpb.FlowCounterCreateRequest{
+			ContextId: &fwdpb.ContextId{Id: port.dataplane.ID()},
+			Id:        &fwdpb.FlowCounterId{ObjectId: &fwdpb.ObjectId{Id: oversizeCounterID(id, ingress)}},
+		})
+		if err != nil {
+			return err
+		}
+		_, err = port.dataplane.TableCreate(ctx, &fwdpb.TableCreateRequest{
+			ContextId: &fwdpb.ContextId{Id: port.dataplane.ID()},
+			Desc: &fwdpb.TableDesc{
+				TableType: fwdpb.TableType_TABLE_TYPE_FLOW,
+				TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: portMTUTable(id, ingress)}},
+				Actions:   []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_CONTINUE}},
+				Table: &fwdpb.TableDesc_Flow{
+					Flow: &fwdpb.FlowTableDesc{
+						BankCount: 1,
+					},
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		table, field := egressMTUTable, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_OUTPUT
+		if ingress {
+			table, field = ingressMTUTable, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT
+		}
+		_, err = port.dataplane.TableEntryAdd(ctx, fwdconfig.TableEntryAddRequest(port.dataplane.ID(), table).
+			AppendEntry(
+				fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(field).WithUint64(nid))),
+				fwdconfig.Action(fwdconfig.LookupAction(portMTUTable(id, ingress))),
+			).Build())
+		if err != nil {
+			return err
+		}
+	}
+	if !port.opts.RemoteCPUPort {
+		return nil
+	}
+	_, err := port.dataplane.TableEntryAdd(ctx, fwdconfig.TableEntryAddRequest(port.dataplane.ID(), mtuErrorTable).
+		AppendEntry(
+			fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_TRAP_ID).WithUint64(mtuErrorID(id)))),
+			fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_HOST_PORT_ID).WithUint64Value(mtuErrorID(id))),
+		).Build())
+	return err
+}
+
 // GetPortStats returns the stats for a port.
 func (port *port) GetPortStats(ctx context.Context, req *saipb.GetPortStatsRequest) (*saipb.GetPortStatsResponse, error) {
 	resp := &saipb.GetPortStatsResponse{}
@@ -484,6 +983,21 @@ func (port *port) GetPortStats(ctx context.Context, req *saipb.GetPortStatsReque
 	for _, c := range counters.GetCounters() {
 		counterMap[c.GetId()] = c.GetValue()
 	}
+	var rxOversize, txOversize uint64
+	if _, ok := port.mtus[req.GetOid()]; ok {
+		oversize, err := port.dataplane.FlowCounterQuery(ctx, &fwdpb.FlowCounterQueryRequest{
+			ContextId: &fwdpb.ContextId{Id: port.dataplane.ID()},
+			Ids: []*fwdpb.FlowCounterId{{
+				ObjectId: &fwdpb.ObjectId{Id: oversizeCounterID(req.GetOid(), true)},
+			}, {
+				ObjectId: &fwdpb.ObjectId{Id: oversizeCounterID(req.GetOid(), false)},
+			}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		rxOversize, txOversize = oversize.GetCounters()[0].GetPackets(), oversize.GetCounters()[1].GetPackets()
+	}
 
 	for _, id := range req.GetCounterIds() {
 		switch id {
@@ -507,6 +1021,10 @@ func (port *port) GetPortStats(ctx context.Context, req *saipb.GetPortStatsReque
 			resp.Values = append(resp.Values, counterMap[fwdpb.CounterId_COUNTER_ID_RX_DROP_PACKETS])
 		case saipb.PortStat_PORT_STAT_IF_OUT_DISCARDS:
 			resp.Values = append(resp.Values, counterMap[fwdpb.CounterId_COUNTER_ID_TX_DROP_PACKETS])
+		case saipb.PortStat_PORT_STAT_ETHER_RX_OVERSIZE_PKTS, saipb.PortStat_PORT_STAT_ETHER_STATS_OVERSIZE_PKTS:
+			resp.Values = append(resp.Values, rxOversize)
+		case saipb.PortStat_PORT_STAT_ETHER_TX_OVERSIZE_PKTS:
+			resp.Values = append(resp.Values, txOversize)
 		default:
 			resp.Values = append(resp.Values, 0)
 		}
@@ -519,6 +1037,38 @@ func (port *port) RemovePort(ctx context.Context, req *saipb.RemovePortRequest)
 		ContextId: &fwdpb.ContextId{Id: port.dataplane.ID()},
 		ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(req.GetOid())},
 	}
+	if pm, ok := port.mtus[req.GetOid()]; ok {
+		for _, ingress := range []bool{true, false} {
+			table, field := egressMTUTable, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_OUTPUT
+			if ingress {
+				table, field = ingressMTUTable, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT
+			}
+			_, err := port.dataplane.TableEntryRemove(ctx, fwdconfig.TableEntryRemoveRequest(port.dataplane.ID(), table).
+				AppendEntry(fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(field).WithUint64(pm.nid)))).Build())
+			if err != nil {
+				return nil, err
+			}
+		}
+		if port.opts.RemoteCPUPort {
+			_, err := port.dataplane.TableEntryRemove(ctx, fwdconfig.TableEntryRemoveRequest(port.dataplane.ID(), mtuErrorTable).
+				AppendEntry(fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_TRAP_ID).WithUint64(mtuErrorID(req.GetOid()))))).Build())
+			if err != nil {
+				return nil, err
+			}
+		}
+		delete(port.mtus, req.GetOid())
+	}
+	for _, acls := range []*portACLs{port.ingressACLs, port.egressACLs} {
+		if err := port.bindACL(ctx, req.GetOid(), acls, 0); err != nil {
+			return nil, err
+		}
+	}
+	peer, linked := port.linkPartner(req.GetOid())
+	delete(port.speeds, req.GetOid())
+	delete(port.portToEth, req.GetOid())
+	if linked {
+		port.negotiateSpeed(peer)
+	}
 	_, err := port.dataplane.ObjectDelete(ctx, deleteReq)
 	return &saipb.RemovePortResponse{}, err
 }
@@ -527,6 +1077,9 @@ func (port *port) Reset() {
 	log.Info("reseting port")
 	port.portToEth = make(map[uint64]string)
 	port.nextEth = 1
+	port.mtus = make(map[uint64]*portMTU)
+	port.speeds = make(map[uint64]*portSpeed)
+	port.resetACLs()
 }
 
 type lagMember struct {
@@ -534,11 +1087,26 @@ type lagMember struct {
 	portID uint64
 }
 
+// defaultLAGHashFields are the fields LAGs hash on until a hash with a non-empty field list is bound to them.
+var defaultLAGHashFields = []*fwdpb.PacketFieldId{
+	{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_SRC}},
+	{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST}},
+	{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_SRC}},
+	{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_DST}},
+	{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_L4_PORT_SRC}},
+	{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_L4_PORT_DST}},
+}
+
 type lag struct {
 	saipb.UnimplementedLagServer
 	mgr         *attrmgr.AttrMgr
 	dataplane   switchDataplaneAPI
 	memberships map[uint64]*lagMember
+	lags        map[uint64]struct{}
+	// hashID is the hash bound to the LAGs. Aggregate ports hash every packet
+	// on the same fields, so the IPv4 and IPv6 LAG hashes can't be programmed
+	// independently and the most recently bound one applies to all packets.
+	hashID uint64
 }
 
 func newLAG(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server) *lag {
@@ -546,6 +1114,7 @@ func newLAG(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server)
 		mgr:         mgr,
 		dataplane:   dataplane,
 		memberships: map[uint64]*lagMember{},
+		lags:        map[uint64]struct{}{},
 	}
 	saipb.RegisterLagServer(s, l)
 	return l
@@ -553,52 +1122,87 @@ func newLAG(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server)
 
 func (l *lag) Reset() {
 	l.memberships = make(map[uint64]*lagMember)
+	l.lags = make(map[uint64]struct{})
+	l.hashID = 0
 }
 
-func (l *lag) CreateLag(ctx context.Context, _ *saipb.CreateLagRequest) (*saipb.CreateLagResponse, error) {
-	id := l.mgr.NextID()
-
-	pReq := &fwdpb.PortCreateRequest{
-		ContextId: &fwdpb.ContextId{Id: l.dataplane.ID()},
-		Port: &fwdpb.PortDesc{
-			PortType: fwdpb.PortType_PORT_TYPE_AGGREGATE_PORT,
-			PortId:   &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(id)}},
-		},
+// hashFields returns the fields of the hash bound to the LAGs.
+func (l *lag) hashFields() ([]*fwdpb.PacketFieldId, error) {
+	if l.hashID == 0 {
+		return defaultLAGHashFields, nil
 	}
-	_, err := l.dataplane.PortCreate(ctx, pReq)
+	fields, err := hashFields(l.mgr, l.hashID)
 	if err != nil {
 		return nil, err
 	}
+	if len(fields) == 0 {
+		return defaultLAGHashFields, nil
+	}
+	return fields, nil
+}
 
-	upd := &fwdpb.PortUpdateRequest{
+// updateHashFields programs the aggregate port of the LAG to hash on the fields of the bound hash.
+func (l *lag) updateHashFields(ctx context.Context, id uint64) error {
+	fields, err := l.hashFields()
+	if err != nil {
+		return err
+	}
+	_, err = l.dataplane.PortUpdate(ctx, &fwdpb.PortUpdateRequest{
 		ContextId: &fwdpb.ContextId{Id: l.dataplane.ID()},
 		PortId:    &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(id)}},
 		Update: &fwdpb.PortUpdateDesc{
 			Port: &fwdpb.PortUpdateDesc_AggregateAlgo{
 				AggregateAlgo: &fwdpb.AggregatePortAlgorithmUpdateDesc{
-					Hash: fwdpb.AggregateHashAlgorithm_AGGREGATE_HASH_ALGORITHM_CRC32,
-					FieldIds: []*fwdpb.PacketFieldId{
-						{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_SRC}},
-						{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST}},
-						{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_SRC}},
-						{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_DST}},
-						{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_L4_PORT_SRC}},
-						{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_L4_PORT_DST}},
-					},
+					Hash:     fwdpb.AggregateHashAlgorithm_AGGREGATE_HASH_ALGORITHM_CRC32,
+					FieldIds: fields,
 				},
 			},
 		},
+	})
+	return err
+}
+
+// setHash binds the hash to all LAGs, reprogramming the existing ones.
+func (l *lag) setHash(ctx context.Context, hashID uint64) error {
+	prev := l.hashID
+	l.hashID = hashID
+	if _, err := l.hashFields(); err != nil {
+		l.hashID = prev
+		return err
+	}
+	for id := range l.lags {
+		if err := l.updateHashFields(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *lag) CreateLag(ctx context.Context, _ *saipb.CreateLagRequest) (*saipb.CreateLagResponse, error) {
+	id := l.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_LAG)
+
+	pReq := &fwdpb.PortCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: l.dataplane.ID()},
+		Port: &fwdpb.PortDesc{
+			PortType: fwdpb.PortType_PORT_TYPE_AGGREGATE_PORT,
+			PortId:   &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(id)}},
+		},
 	}
-	_, err = l.dataplane.PortUpdate(ctx, upd)
+	_, err := l.dataplane.PortCreate(ctx, pReq)
 	if err != nil {
 		return nil, err
 	}
 
-	return &saipb.CreateLagResponse{Oid: id}, err
+	if err := l.updateHashFields(ctx, id); err != nil {
+		return nil, err
+	}
+	l.lags[id] = struct{}{}
+
+	return &saipb.CreateLagResponse{Oid: id}, nil
 }
 
 func (l *lag) CreateLagMember(ctx context.Context, req *saipb.CreateLagMemberRequest) (*saipb.CreateLagMemberResponse, error) {
-	id := l.mgr.NextID()
+	id := l.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_LAG_MEMBER)
 
 	pReq := &fwdpb.PortUpdateRequest{
 		ContextId: &fwdpb.ContextId{Id: l.dataplane.ID()},
@@ -16,19 +16,26 @@ package saiserver
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
 	"github.com/openconfig/gnmi/errdiff"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 
 	"github.com/openconfig/lemming/dataplane/dplaneopts"
 	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
 	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
+	"github.com/openconfig/lemming/internal/packetutil"
 	fwdpb "github.com/openconfig/lemming/proto/forwarding"
 )
 
@@ -95,6 +102,7 @@ func TestCreatePort(t *testing.T) {
 			QosMplsExpToForwardingClassMap:   proto.Uint64(0),
 			IpsecPort:                        proto.Uint64(0),
 			SupportedSpeed:                   []uint32{1000, 10000, 40000, 50000, 100000, 200000, 400000, 800000},
+			AdvertisedSpeed:                  []uint32{1000, 10000, 40000, 50000, 100000, 200000, 400000, 800000},
 			OperSpeed:                        proto.Uint32(40000),
 			SupportedFecMode:                 []saipb.PortFecMode{saipb.PortFecMode_PORT_FEC_MODE_NONE},
 			NumberOfIngressPriorityGroups:    proto.Uint32(0),
@@ -157,12 +165,14 @@ func TestCreatePort(t *testing.T) {
 			QosMplsExpToForwardingClassMap:   proto.Uint64(0),
 			IpsecPort:                        proto.Uint64(0),
 			SupportedSpeed:                   []uint32{1000, 10000, 40000, 50000, 100000, 200000, 400000, 800000},
+			AdvertisedSpeed:                  []uint32{1000, 10000, 40000, 50000, 100000, 200000, 400000, 800000},
 			OperSpeed:                        proto.Uint32(40000),
 			SupportedFecMode:                 []saipb.PortFecMode{saipb.PortFecMode_PORT_FEC_MODE_NONE},
 			NumberOfIngressPriorityGroups:    proto.Uint32(0),
 			QosMaximumHeadroomSize:           proto.Uint32(0),
 			AdminState:                       proto.Bool(false),
 			AutoNegMode:                      proto.Bool(true),
+			AutoNegStatus:                    proto.Bool(false),
 			Mtu:                              proto.Uint32(1514),
 		},
 	}}
@@ -259,12 +269,14 @@ func TestCreatePorts(t *testing.T) {
 			QosMplsExpToForwardingClassMap:   proto.Uint64(0),
 			IpsecPort:                        proto.Uint64(0),
 			SupportedSpeed:                   []uint32{1000, 10000, 40000, 50000, 100000, 200000, 400000, 800000},
+			AdvertisedSpeed:                  []uint32{1000, 10000, 40000, 50000, 100000, 200000, 400000, 800000},
 			OperSpeed:                        proto.Uint32(40000),
 			SupportedFecMode:                 []saipb.PortFecMode{saipb.PortFecMode_PORT_FEC_MODE_NONE},
 			NumberOfIngressPriorityGroups:    proto.Uint32(0),
 			QosMaximumHeadroomSize:           proto.Uint32(0),
 			AdminState:                       proto.Bool(false),
 			AutoNegMode:                      proto.Bool(true),
+			AutoNegStatus:                    proto.Bool(false),
 			Mtu:                              proto.Uint32(1514),
 		},
 	}}
@@ -472,7 +484,8 @@ func TestRemovePort(t *testing.T) {
 
 func newTestPort(t testing.TB, api switchDataplaneAPI) (saipb.PortClient, *attrmgr.AttrMgr, func()) {
 	conn, mgr, stopFn := newTestServer(t, func(mgr *attrmgr.AttrMgr, srv *grpc.Server) {
-		newPort(mgr, api, srv, &dplaneopts.Options{PortType: fwdpb.PortType_PORT_TYPE_KERNEL})
+		p, _ := newPort(mgr, api, srv, &dplaneopts.Options{PortType: fwdpb.PortType_PORT_TYPE_KERNEL})
+		p.switchID = func() uint64 { return switchID }
 	})
 	return saipb.NewPortClient(conn), mgr, stopFn
 }
@@ -484,6 +497,527 @@ func newTestLAG(t testing.TB, api switchDataplaneAPI) (saipb.LagClient, func())
 	return saipb.NewLagClient(conn), stopFn
 }
 
+func TestLengthsAbove(t *testing.T) {
+	tests := []struct {
+		desc        string
+		mtu         uint32
+		wantMatches []uint64
+		wantMisses  []uint64
+	}{{
+		desc:        "standard",
+		mtu:         1514,
+		wantMatches: []uint64{1515, 1516, 1536, 2048, 9000, 65535},
+		wantMisses:  []uint64{0, 64, 1500, 1513, 1514},
+	}, {
+		desc:        "jumbo",
+		mtu:         9216,
+		wantMatches: []uint64{9217, 16384, 65535},
+		wantMisses:  []uint64{1514, 9000, 9216},
+	}, {
+		desc:       "max",
+		mtu:        65535,
+		wantMisses: []uint64{1514, 65535},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			lengths := lengthsAbove(tt.mtu)
+			matches := func(l uint64) bool {
+				for _, b := range lengths {
+					f := b.Build()
+					value, mask := binary.BigEndian.Uint64(f.GetBytes()), binary.BigEndian.Uint64(f.GetMasks())
+					if l&mask == value {
+						return true
+					}
+				}
+				return false
+			}
+			for _, l := range tt.wantMatches {
+				if !matches(l) {
+					t.Errorf("lengthsAbove(%d) doesn't match length %d", tt.mtu, l)
+				}
+			}
+			for _, l := range tt.wantMisses {
+				if matches(l) {
+					t.Errorf("lengthsAbove(%d) matches length %d", tt.mtu, l)
+				}
+			}
+		})
+	}
+}
+
+func TestPortMTU(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sinks := map[string]*packetutil.Sink{}
+	for _, lane := range []string{"1", "2", "3"} {
+		sinks[lane] = packetutil.NewSink(2)
+	}
+	fwdCtx.FakePortManager = lanePortManager{sinks: sinks}
+	fwdCtx.SetCPUPortSink(packetutil.NewSink(1).CPUPortSink, func() {})
+
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	vc := saipb.NewVlanClient(conn)
+	vlan, err := vc.CreateVlan(ctx, &saipb.CreateVlanRequest{
+		VlanId: proto.Uint32(100),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	group, err := saipb.NewL2McGroupClient(conn).CreateL2McGroup(ctx, &saipb.CreateL2McGroupRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Ports on lanes 1 and 2 accept jumbo frames, the port on lane 3 uses the default MTU.
+	pc := saipb.NewPortClient(conn)
+	var ports []uint64
+	for i := uint32(1); i <= 3; i++ {
+		req := &saipb.CreatePortRequest{
+			HwLaneList: []uint32{i},
+			AdminState: proto.Bool(true),
+		}
+		if i != 3 {
+			req.Mtu = proto.Uint32(9216)
+		}
+		port, err := pc.CreatePort(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ports = append(ports, port.GetOid())
+		bp, err := saipb.NewBridgeClient(conn).CreateBridgePort(ctx, &saipb.CreateBridgePortRequest{
+			Type:   saipb.BridgePortType_BRIDGE_PORT_TYPE_PORT.Enum(),
+			PortId: proto.Uint64(port.GetOid()),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := vc.CreateVlanMember(ctx, &saipb.CreateVlanMemberRequest{
+			VlanId:          proto.Uint64(vlan.GetOid()),
+			BridgePortId:    proto.Uint64(bp.GetOid()),
+			VlanTaggingMode: saipb.VlanTaggingMode_VLAN_TAGGING_MODE_UNTAGGED.Enum(),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := saipb.NewL2McGroupClient(conn).CreateL2McGroupMember(ctx, &saipb.CreateL2McGroupMemberRequest{
+			L2McGroupId:  proto.Uint64(group.GetOid()),
+			L2McOutputId: proto.Uint64(bp.GetOid()),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := pc.SetPortAttribute(ctx, &saipb.SetPortAttributeRequest{
+		Oid: ports[2],
+		Mtu: proto.Uint32(1500),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vc.SetVlanAttribute(ctx, &saipb.SetVlanAttributeRequest{
+		Oid:                       vlan.GetOid(),
+		BroadcastFloodControlType: saipb.VlanFloodControlType_VLAN_FLOOD_CONTROL_TYPE_L2MC_GROUP.Enum(),
+		BroadcastFloodGroup:       proto.Uint64(group.GetOid()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		&layers.Ethernet{
+			SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+			DstMAC:       layers.EthernetBroadcast,
+			EthernetType: layers.EthernetType(0x88b5),
+		}, gopacket.Payload(make([]byte, 9000-14))); err != nil {
+		t.Fatal(err)
+	}
+	inject := func(port uint64) {
+		t.Helper()
+		err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+			fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, buf.Bytes(), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	wantFrames := func(lanes ...string) {
+		t.Helper()
+		for _, lane := range lanes {
+			pkt, err := sinks[lane].Next(time.Second)
+			if err != nil {
+				t.Fatalf("lane %s: %v", lane, err)
+			}
+			if got := len(pkt.Data()); got != 9000 {
+				t.Errorf("lane %s: got frame of %d bytes, want 9000", lane, got)
+			}
+		}
+	}
+	wantNoFrames := func(lanes ...string) {
+		t.Helper()
+		for _, lane := range lanes {
+			if pkt, err := sinks[lane].Next(100 * time.Millisecond); err == nil {
+				t.Errorf("lane %s: frame unexpectedly transmitted: %v", lane, pkt)
+			}
+		}
+	}
+
+	// The jumbo frame is accepted on lane 1 and transmitted on lane 2, but it exceeds the MTU of lane 3.
+	inject(ports[0])
+	wantFrames("2")
+	wantNoFrames("1", "3")
+
+	// The jumbo frame is dropped on receipt on lane 3.
+	inject(ports[2])
+	wantNoFrames("1", "2", "3")
+
+	stats, err := pc.GetPortStats(ctx, &saipb.GetPortStatsRequest{
+		Oid: ports[2],
+		CounterIds: []saipb.PortStat{
+			saipb.PortStat_PORT_STAT_ETHER_RX_OVERSIZE_PKTS,
+			saipb.PortStat_PORT_STAT_ETHER_TX_OVERSIZE_PKTS,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(stats.GetValues(), []uint64{1, 1}); d != "" {
+		t.Errorf("GetPortStats() failed: diff(-got,+want)\n:%s", d)
+	}
+
+	// Raising the MTU of lane 3 lets the jumbo frame through.
+	if _, err := pc.SetPortAttribute(ctx, &saipb.SetPortAttributeRequest{
+		Oid: ports[2],
+		Mtu: proto.Uint32(9216),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	inject(ports[0])
+	wantFrames("2", "3")
+}
+
+func TestPortInternalLoopback(t *testing.T) {
+	ctx := context.Background()
+	ft := newFloodTest(t, 2)
+	pc := saipb.NewPortClient(ft.conn)
+	if _, err := pc.SetPortAttribute(ctx, &saipb.SetPortAttributeRequest{
+		Oid:                  ft.ports[1],
+		InternalLoopbackMode: saipb.PortInternalLoopbackMode_PORT_INTERNAL_LOOPBACK_MODE_MAC.Enum(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		&layers.Ethernet{
+			SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+			DstMAC:       layers.EthernetBroadcast,
+			EthernetType: layers.EthernetType(0x88b5),
+		}, gopacket.Payload(make([]byte, 64))); err != nil {
+		t.Fatal(err)
+	}
+	output := func() {
+		t.Helper()
+		err := ft.s.InjectPacket(&fwdpb.ContextId{Id: ft.s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(ft.ports[1])}},
+			fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, buf.Bytes(), nil, false, fwdpb.PortAction_PORT_ACTION_OUTPUT)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	wantFrames := func(lanes ...string) {
+		t.Helper()
+		for _, lane := range lanes {
+			if _, err := ft.sinks[lane].Next(time.Second); err != nil {
+				t.Fatalf("lane %s: %v", lane, err)
+			}
+		}
+	}
+	wantNoFrames := func(lanes ...string) {
+		t.Helper()
+		for _, lane := range lanes {
+			if pkt, err := ft.sinks[lane].Next(100 * time.Millisecond); err == nil {
+				t.Errorf("lane %s: frame unexpectedly transmitted: %v", lane, pkt)
+			}
+		}
+	}
+
+	// The broadcast output on lane 2 is received back on it and flooded to the other ports.
+	output()
+	wantFrames("1", "3")
+	wantNoFrames("2")
+	stats, err := pc.GetPortStats(ctx, &saipb.GetPortStatsRequest{
+		Oid:        ft.ports[1],
+		CounterIds: []saipb.PortStat{saipb.PortStat_PORT_STAT_IF_IN_NON_UCAST_PKTS},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(stats.GetValues(), []uint64{1}); d != "" {
+		t.Errorf("GetPortStats() failed: diff(-got,+want)\n:%s", d)
+	}
+
+	// Without loopback, the broadcast is transmitted on lane 2.
+	if _, err := pc.SetPortAttribute(ctx, &saipb.SetPortAttributeRequest{
+		Oid:                  ft.ports[1],
+		InternalLoopbackMode: saipb.PortInternalLoopbackMode_PORT_INTERNAL_LOOPBACK_MODE_NONE.Enum(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	output()
+	wantFrames("2")
+	wantNoFrames("1", "3")
+}
+
+func TestInjectPacketFlowOrder(t *testing.T) {
+	const packets = 200
+	ft := newFloodTest(t, packets)
+
+	// A single flow of numbered frames received on lane 1 is flooded to lane 2 in order.
+	for i := 0; i < packets; i++ {
+		payload := make([]byte, 64)
+		binary.BigEndian.PutUint32(payload, uint32(i))
+		buf := gopacket.NewSerializeBuffer()
+		if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+			&layers.Ethernet{
+				SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+				DstMAC:       layers.EthernetBroadcast,
+				EthernetType: layers.EthernetType(0x88b5),
+			}, gopacket.Payload(payload)); err != nil {
+			t.Fatal(err)
+		}
+		err := ft.s.InjectPacket(&fwdpb.ContextId{Id: ft.s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(ft.ports[0])}},
+			fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, buf.Bytes(), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < packets; i++ {
+		pkt, err := ft.sinks["2"].Next(time.Second)
+		if err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+		if got := binary.BigEndian.Uint32(pkt.Data()[14:]); got != uint32(i) {
+			t.Fatalf("got frame %d at position %d, want frames in order", got, i)
+		}
+	}
+}
+
+func TestPortAutoNegotiation(t *testing.T) {
+	ctx := context.Background()
+	_, conn, fwdCtx := newTestSaiServer(t,
+		dplaneopts.WithRemoteCPUPort(true),
+		dplaneopts.WithPortLinks(map[string]string{"eth1": "eth2"}),
+	)
+	fwdCtx.SetCPUPortSink(packetutil.NewSink(1).CPUPortSink, func() {})
+
+	sc := saipb.NewSwitchClient(conn)
+	if _, err := sc.CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	pc := saipb.NewPortClient(conn)
+	var ports []uint64
+	for i, advertised := range [][]uint32{{10000, 40000, 100000}, {40000, 100000, 400000}, {10000, 40000}} {
+		port, err := pc.CreatePort(ctx, &saipb.CreatePortRequest{
+			HwLaneList:      []uint32{uint32(i + 1)},
+			AdvertisedSpeed: advertised,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ports = append(ports, port.GetOid())
+	}
+
+	wantState := func(desc string, id uint64, want *saipb.PortAttribute) {
+		t.Helper()
+		resp, err := pc.GetPortAttribute(ctx, &saipb.GetPortAttributeRequest{
+			Oid: id,
+			AttrType: []saipb.PortAttr{
+				saipb.PortAttr_PORT_ATTR_ADVERTISED_SPEED,
+				saipb.PortAttr_PORT_ATTR_REMOTE_ADVERTISED_SPEED,
+				saipb.PortAttr_PORT_ATTR_AUTO_NEG_STATUS,
+				saipb.PortAttr_PORT_ATTR_OPER_SPEED,
+			},
+		})
+		if err != nil {
+			t.Fatalf("%s: GetPortAttribute(%d) unexpected err: %v", desc, id, err)
+		}
+		if d := cmp.Diff(want, resp.GetAttr(), protocmp.Transform()); d != "" {
+			t.Errorf("%s: port %d attributes (-want, +got):\n%s", desc, id, d)
+		}
+	}
+
+	// The linked ports negotiate the highest speed they both advertise.
+	wantState("linked", ports[0], &saipb.PortAttribute{
+		AdvertisedSpeed:       []uint32{10000, 40000, 100000},
+		RemoteAdvertisedSpeed: []uint32{40000, 100000, 400000},
+		AutoNegStatus:         proto.Bool(true),
+		OperSpeed:             proto.Uint32(100000),
+	})
+	wantState("linked", ports[1], &saipb.PortAttribute{
+		AdvertisedSpeed:       []uint32{40000, 100000, 400000},
+		RemoteAdvertisedSpeed: []uint32{10000, 40000, 100000},
+		AutoNegStatus:         proto.Bool(true),
+		OperSpeed:             proto.Uint32(100000),
+	})
+	// The unlinked port has nothing to negotiate with.
+	wantState("unlinked", ports[2], &saipb.PortAttribute{
+		AdvertisedSpeed: []uint32{10000, 40000},
+		AutoNegStatus:   proto.Bool(false),
+		OperSpeed:       proto.Uint32(40000),
+	})
+
+	// Changing the advertised speeds renegotiates the link.
+	if _, err := pc.SetPortAttribute(ctx, &saipb.SetPortAttributeRequest{Oid: ports[1], AdvertisedSpeed: []uint32{10000, 40000}}); err != nil {
+		t.Fatal(err)
+	}
+	wantState("readvertised", ports[0], &saipb.PortAttribute{
+		AdvertisedSpeed:       []uint32{10000, 40000, 100000},
+		RemoteAdvertisedSpeed: []uint32{10000, 40000},
+		AutoNegStatus:         proto.Bool(true),
+		OperSpeed:             proto.Uint32(40000),
+	})
+
+	// Without auto-negotiation on both ends, each port uses its configured speed.
+	if _, err := pc.SetPortAttribute(ctx, &saipb.SetPortAttributeRequest{Oid: ports[1], AutoNegMode: proto.Bool(false), Speed: proto.Uint32(10000)}); err != nil {
+		t.Fatal(err)
+	}
+	wantState("auto-neg disabled", ports[0], &saipb.PortAttribute{
+		AdvertisedSpeed: []uint32{10000, 40000, 100000},
+		AutoNegStatus:   proto.Bool(false),
+		OperSpeed:       proto.Uint32(40000),
+	})
+	wantState("auto-neg disabled", ports[1], &saipb.PortAttribute{
+		AdvertisedSpeed: []uint32{10000, 40000},
+		AutoNegStatus:   proto.Bool(false),
+		OperSpeed:       proto.Uint32(10000),
+	})
+}
+
+func TestPortACLGroupLimits(t *testing.T) {
+	ctx := context.Background()
+	_, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true), dplaneopts.WithPortACLGroupLimits(2, 1))
+	fwdCtx.SetCPUPortSink(packetutil.NewSink(1).CPUPortSink, func() {})
+
+	sc := saipb.NewSwitchClient(conn)
+	if _, err := sc.CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	pc := saipb.NewPortClient(conn)
+	var ports []uint64
+	for i := uint32(1); i <= 3; i++ {
+		port, err := pc.CreatePort(ctx, &saipb.CreatePortRequest{HwLaneList: []uint32{i}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ports = append(ports, port.GetOid())
+	}
+	ac := saipb.NewAclClient(conn)
+	createGroup := func(stage saipb.AclStage) uint64 {
+		t.Helper()
+		group, err := ac.CreateAclTableGroup(ctx, &saipb.CreateAclTableGroupRequest{
+			AclStage: stage.Enum(),
+			Type:     saipb.AclTableGroupType_ACL_TABLE_GROUP_TYPE_PARALLEL.Enum(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return group.GetOid()
+	}
+	in1, in2, in3 := createGroup(saipb.AclStage_ACL_STAGE_INGRESS), createGroup(saipb.AclStage_ACL_STAGE_INGRESS), createGroup(saipb.AclStage_ACL_STAGE_INGRESS)
+	out1, out2 := createGroup(saipb.AclStage_ACL_STAGE_EGRESS), createGroup(saipb.AclStage_ACL_STAGE_EGRESS)
+
+	available := func(ingress, egress uint32) []*saipb.ACLResource {
+		return []*saipb.ACLResource{{
+			Stage:     saipb.AclStage_ACL_STAGE_INGRESS,
+			BindPoint: saipb.AclBindPointType_ACL_BIND_POINT_TYPE_PORT,
+			AvailNum:  ingress,
+		}, {
+			Stage:     saipb.AclStage_ACL_STAGE_EGRESS,
+			BindPoint: saipb.AclBindPointType_ACL_BIND_POINT_TYPE_PORT,
+			AvailNum:  egress,
+		}}
+	}
+	// Each step runs on the state left by the previous ones.
+	tests := []struct {
+		desc          string
+		req           *saipb.SetPortAttributeRequest
+		wantCode      codes.Code
+		wantAvailable []*saipb.ACLResource
+	}{{
+		desc:          "first ingress group",
+		req:           &saipb.SetPortAttributeRequest{Oid: ports[0], IngressAcl: proto.Uint64(in1)},
+		wantAvailable: available(1, 1),
+	}, {
+		desc:          "second ingress group",
+		req:           &saipb.SetPortAttributeRequest{Oid: ports[1], IngressAcl: proto.Uint64(in2)},
+		wantAvailable: available(0, 1),
+	}, {
+		desc:          "ingress group past the limit",
+		req:           &saipb.SetPortAttributeRequest{Oid: ports[2], IngressAcl: proto.Uint64(in3)},
+		wantCode:      codes.ResourceExhausted,
+		wantAvailable: available(0, 1),
+	}, {
+		desc:          "bound ingress group on another port",
+		req:           &saipb.SetPortAttributeRequest{Oid: ports[2], IngressAcl: proto.Uint64(in1)},
+		wantAvailable: available(0, 1),
+	}, {
+		desc:          "replace the only binding of an ingress group",
+		req:           &saipb.SetPortAttributeRequest{Oid: ports[1], IngressAcl: proto.Uint64(in3)},
+		wantAvailable: available(0, 1),
+	}, {
+		desc:          "egress group",
+		req:           &saipb.SetPortAttributeRequest{Oid: ports[0], EgressAcl: proto.Uint64(out1)},
+		wantAvailable: available(0, 0),
+	}, {
+		desc:          "egress group past the limit",
+		req:           &saipb.SetPortAttributeRequest{Oid: ports[1], EgressAcl: proto.Uint64(out2)},
+		wantCode:      codes.ResourceExhausted,
+		wantAvailable: available(0, 0),
+	}, {
+		desc:          "ingress group bound at egress",
+		req:           &saipb.SetPortAttributeRequest{Oid: ports[1], EgressAcl: proto.Uint64(in1)},
+		wantCode:      codes.InvalidArgument,
+		wantAvailable: available(0, 0),
+	}, {
+		desc:          "unbind egress group",
+		req:           &saipb.SetPortAttributeRequest{Oid: ports[0], EgressAcl: proto.Uint64(0)},
+		wantAvailable: available(0, 1),
+	}, {
+		desc:          "egress group after unbinding",
+		req:           &saipb.SetPortAttributeRequest{Oid: ports[1], EgressAcl: proto.Uint64(out2)},
+		wantAvailable: available(0, 0),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := pc.SetPortAttribute(ctx, tt.req)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("SetPortAttribute() got code %v (err %v), want %v", got, err, tt.wantCode)
+			}
+			resp, err := sc.GetSwitchAttribute(ctx, &saipb.GetSwitchAttributeRequest{
+				Oid:      switchID,
+				AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_AVAILABLE_ACL_TABLE_GROUP},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := cmp.Diff(resp.GetAttr().GetAvailableAclTableGroup(), tt.wantAvailable, protocmp.Transform()); d != "" {
+				t.Errorf("GetSwitchAttribute() unexpected available ACL table groups: diff(-got,+want)\n:%s", d)
+			}
+		})
+	}
+
+	// Removing a port releases its bindings.
+	if _, err := pc.RemovePort(ctx, &saipb.RemovePortRequest{Oid: ports[1]}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := sc.GetSwitchAttribute(ctx, &saipb.GetSwitchAttributeRequest{
+		Oid:      switchID,
+		AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_AVAILABLE_ACL_TABLE_GROUP},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(resp.GetAttr().GetAvailableAclTableGroup(), available(1, 1), protocmp.Transform()); d != "" {
+		t.Errorf("GetSwitchAttribute() unexpected available ACL table groups after RemovePort: diff(-got,+want)\n:%s", d)
+	}
+}
+
 func TestCreateLag(t *testing.T) {
 	tests := []struct {
 		desc            string
@@ -0,0 +1,96 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saiserver
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
+
+	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
+)
+
+type queue struct {
+	saipb.UnimplementedQueueServer
+	mgr       *attrmgr.AttrMgr
+	dataplane switchDataplaneAPI
+}
+
+func newQueue(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server) *queue {
+	q := &queue{
+		mgr:       mgr,
+		dataplane: dataplane,
+	}
+	saipb.RegisterQueueServer(s, q)
+	return q
+}
+
+// CreateQueue creates a queue. Queues only hold their attributes and stats,
+// ports transmit packets as soon as they are processed.
+// The parent scheduler node of a queue is either its port or one of the port's scheduler groups.
+func (q *queue) CreateQueue(_ context.Context, req *saipb.CreateQueueRequest) (*saipb.CreateQueueResponse, error) {
+	if req.ParentSchedulerNode != nil {
+		parent := req.GetParentSchedulerNode()
+		switch q.mgr.GetType(fmt.Sprint(parent)) {
+		case saipb.ObjectType_OBJECT_TYPE_PORT:
+			if parent != req.GetPort() {
+				return nil, status.Errorf(codes.InvalidArgument, "parent scheduler node %d is not the queue's port %d", parent, req.GetPort())
+			}
+		case saipb.ObjectType_OBJECT_TYPE_SCHEDULER_GROUP:
+			attr, err := schedulerGroupAttrs(q.mgr, parent)
+			if err != nil {
+				return nil, err
+			}
+			if attr.GetPortId() != req.GetPort() {
+				return nil, status.Errorf(codes.InvalidArgument, "parent scheduler group %d belongs to port %d, not %d", parent, attr.GetPortId(), req.GetPort())
+			}
+			if err := checkSchedulerParent(q.mgr, parent); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "invalid parent scheduler node: %d", parent)
+		}
+	}
+	if err := checkSchedulerProfile(q.mgr, req.GetSchedulerProfileId()); err != nil {
+		return nil, err
+	}
+	return &saipb.CreateQueueResponse{Oid: q.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_QUEUE)}, nil
+}
+
+// RemoveQueue removes a queue, its attributes are removed by the attribute manager.
+func (q *queue) RemoveQueue(_ context.Context, req *saipb.RemoveQueueRequest) (*saipb.RemoveQueueResponse, error) {
+	if q.mgr.GetType(fmt.Sprint(req.GetOid())) != saipb.ObjectType_OBJECT_TYPE_QUEUE {
+		return nil, status.Errorf(codes.NotFound, "unknown queue: %d", req.GetOid())
+	}
+	return &saipb.RemoveQueueResponse{}, nil
+}
+
+// GetQueueStats returns the stats of a queue. No stats are supported yet:
+// ports transmit packets as soon as they are processed, so queues don't count packets,
+// and without WRED profiles packets are never dropped or ECN marked.
+func (q *queue) GetQueueStats(_ context.Context, req *saipb.GetQueueStatsRequest) (*saipb.GetQueueStatsResponse, error) {
+	if q.mgr.GetType(fmt.Sprint(req.GetOid())) != saipb.ObjectType_OBJECT_TYPE_QUEUE {
+		return nil, status.Errorf(codes.NotFound, "unknown queue: %d", req.GetOid())
+	}
+	if ids := req.GetCounterIds(); len(ids) > 0 {
+		return nil, status.Errorf(codes.Unimplemented, "queue stats %v not supported", ids)
+	}
+	return &saipb.GetQueueStatsResponse{}, nil
+}
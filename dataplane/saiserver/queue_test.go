@@ -0,0 +1,105 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saiserver
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
+	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
+)
+
+func TestGetQueueStats(t *testing.T) {
+	tests := []struct {
+		desc       string
+		unknown    bool
+		counterIDs []saipb.QueueStat
+		wantCode   codes.Code
+	}{{
+		desc:       "ecn marked",
+		counterIDs: []saipb.QueueStat{saipb.QueueStat_QUEUE_STAT_WRED_ECN_MARKED_PACKETS},
+		wantCode:   codes.Unimplemented,
+	}, {
+		desc:       "wred dropped",
+		counterIDs: []saipb.QueueStat{saipb.QueueStat_QUEUE_STAT_WRED_DROPPED_PACKETS},
+		wantCode:   codes.Unimplemented,
+	}, {
+		desc: "no stats",
+	}, {
+		desc:       "unknown queue",
+		unknown:    true,
+		counterIDs: []saipb.QueueStat{saipb.QueueStat_QUEUE_STAT_WRED_ECN_MARKED_PACKETS},
+		wantCode:   codes.NotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c, qs, stopFn := newTestQueue(t)
+			defer stopFn()
+			ctx := context.Background()
+			q, err := c.CreateQueue(ctx, &saipb.CreateQueueRequest{
+				Type:  saipb.QueueType_QUEUE_TYPE_UNICAST.Enum(),
+				Index: proto.Uint32(0),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			oid := q.GetOid()
+			if tt.unknown {
+				oid++
+			}
+			// Call the server directly, the attribute manager turns unimplemented Get errors into empty responses.
+			_, gotErr := qs.GetQueueStats(ctx, &saipb.GetQueueStatsRequest{Oid: oid, CounterIds: tt.counterIDs})
+			if code := status.Code(gotErr); code != tt.wantCode {
+				t.Errorf("GetQueueStats() got code %v, want %v: %v", code, tt.wantCode, gotErr)
+			}
+		})
+	}
+}
+
+func TestRemoveQueue(t *testing.T) {
+	c, _, stopFn := newTestQueue(t)
+	defer stopFn()
+	ctx := context.Background()
+	q, err := c.CreateQueue(ctx, &saipb.CreateQueueRequest{
+		Type:  saipb.QueueType_QUEUE_TYPE_UNICAST.Enum(),
+		Index: proto.Uint32(0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.RemoveQueue(ctx, &saipb.RemoveQueueRequest{Oid: q.GetOid()}); err != nil {
+		t.Fatalf("RemoveQueue() unexpected err: %v", err)
+	}
+	if _, err := c.GetQueueStats(ctx, &saipb.GetQueueStatsRequest{Oid: q.GetOid()}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetQueueStats() of removed queue got err %v, want NotFound", err)
+	}
+	if _, err := c.RemoveQueue(ctx, &saipb.RemoveQueueRequest{Oid: q.GetOid()}); status.Code(err) != codes.NotFound {
+		t.Errorf("RemoveQueue() of removed queue got err %v, want NotFound", err)
+	}
+}
+
+func newTestQueue(t testing.TB) (saipb.QueueClient, *queue, func()) {
+	var q *queue
+	conn, _, stopFn := newTestServer(t, func(mgr *attrmgr.AttrMgr, srv *grpc.Server) {
+		q = newQueue(mgr, &fakeSwitchDataplane{}, srv)
+	})
+	return saipb.NewQueueClient(conn), q, stopFn
+}
@@ -15,9 +15,13 @@
 package saiserver
 
 import (
+	"cmp"
 	"context"
 	"encoding/binary"
 	"fmt"
+	"net"
+	"slices"
+	"sync"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -64,23 +68,40 @@ func (n *neighbor) CreateNeighborEntry(ctx context.Context, req *saipb.CreateNei
 	return &saipb.CreateNeighborEntryResponse{}, nil
 }
 
-// CreateNeighborEntry adds a neighbor to the neighbor table.
+// RemoveNeighborEntry removes a neighbor from the neighbor table.
 func (n *neighbor) RemoveNeighborEntry(ctx context.Context, req *saipb.RemoveNeighborEntryRequest) (*saipb.RemoveNeighborEntryResponse, error) {
-	entry := fwdconfig.EntryDesc(fwdconfig.ExactEntry(
-		fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_OUTPUT_IFACE).WithUint64(req.GetEntry().GetRifId()),
-		fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_NEXT_HOP_IP).WithBytes(req.GetEntry().GetIpAddress()),
-	)).Build()
-
-	if _, err := n.dataplane.TableEntryRemove(ctx, &fwdpb.TableEntryRemoveRequest{
-		ContextId: &fwdpb.ContextId{Id: n.dataplane.ID()},
-		TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: NeighborTable}},
-		EntryDesc: entry,
-	}); err != nil {
+	if err := n.removeNeighborEntries(ctx, []*saipb.NeighborEntry{req.GetEntry()}); err != nil {
 		return nil, err
 	}
 	return &saipb.RemoveNeighborEntryResponse{}, nil
 }
 
+// removeNeighborEntries removes the neighbors from the neighbor table with a single dataplane call.
+func (n *neighbor) removeNeighborEntries(ctx context.Context, entries []*saipb.NeighborEntry) error {
+	descs := make([]*fwdpb.EntryDesc, 0, len(entries))
+	for _, entry := range entries {
+		descs = append(descs, fwdconfig.EntryDesc(fwdconfig.ExactEntry(
+			fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_OUTPUT_IFACE).WithUint64(entry.GetRifId()),
+			fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_NEXT_HOP_IP).WithBytes(entry.GetIpAddress()),
+		)).Build())
+	}
+	return removeTableEntries(ctx, n.dataplane, NeighborTable, descs)
+}
+
+// removeTableEntries removes the entries from the table with a single dataplane call.
+// The entries are removed in order, and those removed before a failure aren't restored.
+func removeTableEntries(ctx context.Context, dataplane switchDataplaneAPI, table string, entries []*fwdpb.EntryDesc) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	_, err := dataplane.TableEntryRemove(ctx, &fwdpb.TableEntryRemoveRequest{
+		ContextId: &fwdpb.ContextId{Id: dataplane.ID()},
+		TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: table}},
+		Entries:   entries,
+	})
+	return err
+}
+
 // CreateNeighborEntries adds multiple neighbors to the neighbor table.
 func (n *neighbor) CreateNeighborEntries(ctx context.Context, re *saipb.CreateNeighborEntriesRequest) (*saipb.CreateNeighborEntriesResponse, error) {
 	resp := &saipb.CreateNeighborEntriesResponse{}
@@ -95,16 +116,28 @@ func (n *neighbor) CreateNeighborEntries(ctx context.Context, re *saipb.CreateNe
 }
 
 type groupMember struct {
-	nextHop uint64 // ID of the next hop
-	weight  uint32
+	nextHop    uint64 // ID of the next hop
+	weight     uint32
+	sequenceID uint32 // position of the member in an ordered group
 }
 
+// nhgBucketCount is the number of hash buckets in an unordered ECMP group,
+// and the default number of hash buckets in a fine grain ECMP group.
+// The number is fixed for the lifetime of the group so that adding or removing a member only moves the buckets
+// needed to rebalance the group, and flows hashed to the other buckets keep their next hop.
+const nhgBucketCount = 128
+
 type nextHopGroup struct {
 	saipb.UnimplementedNextHopGroupServer
 	mgr       *attrmgr.AttrMgr
 	dataplane switchDataplaneAPI
+	mu        sync.Mutex                         // mu protects the groups and their hashing state below.
 	groups    map[uint64]map[uint64]*groupMember // groups is map of next hop groups to a map of next hops
 	groupIsV4 map[uint64]bool                    // map from group id to IP protocol version
+	buckets   map[uint64][]uint64                // map from group id to the member id of each hash bucket
+	actLists  map[uint64][]*fwdpb.ActionList     // map from group id to the action lists selected by the hash
+	bucketCnt map[uint64]int                     // map from unordered and fine grain group id to its number of hash buckets
+	switchID  func() uint64                      // switchID returns the ID of the switch.
 }
 
 func newNextHopGroup(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server) *nextHopGroup {
@@ -113,16 +146,37 @@ func newNextHopGroup(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc
 		dataplane: dataplane,
 		groups:    map[uint64]map[uint64]*groupMember{},
 		groupIsV4: map[uint64]bool{},
+		buckets:   map[uint64][]uint64{},
+		actLists:  map[uint64][]*fwdpb.ActionList{},
+		bucketCnt: map[uint64]int{},
 	}
 	saipb.RegisterNextHopGroupServer(s, n)
 	return n
 }
 
 // CreateNextHopGroup creates a next hop group.
+// Unordered and fine grain ECMP groups use consistent hashing: flows are hashed to a fixed number of buckets,
+// so a member change only remaps the flows of the buckets that move. Fine grain groups take the number of buckets
+// from the configured size.
+// Ordered ECMP groups select the member by the hash modulo the total weight of the members in sequence order,
+// so a member change can remap flows between any of the members.
 func (nhg *nextHopGroup) CreateNextHopGroup(_ context.Context, req *saipb.CreateNextHopGroupRequest) (*saipb.CreateNextHopGroupResponse, error) {
-	id := nhg.mgr.NextID()
+	id := nhg.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_NEXT_HOP_GROUP)
 
-	if req.GetType() != saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_DYNAMIC_UNORDERED_ECMP {
+	nhg.mu.Lock()
+	defer nhg.mu.Unlock()
+	switch req.GetType() {
+	case saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_DYNAMIC_UNORDERED_ECMP:
+		nhg.bucketCnt[id] = nhgBucketCount
+	case saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_DYNAMIC_ORDERED_ECMP:
+	case saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_FINE_GRAIN_ECMP:
+		size := req.GetConfiguredSize()
+		if size == 0 {
+			size = nhgBucketCount
+		}
+		nhg.bucketCnt[id] = int(size)
+		nhg.mgr.StoreAttributes(id, &saipb.NextHopGroupAttribute{RealSize: proto.Uint32(size)})
+	default:
 		return nil, status.Errorf(codes.InvalidArgument, "unsupported req type: %v", req.GetType())
 	}
 
@@ -134,6 +188,7 @@ func (nhg *nextHopGroup) CreateNextHopGroup(_ context.Context, req *saipb.Create
 
 // updateNextHopGroupMember updates the next hop group.
 // If m is nil, remove mid from the group(key: nhgid), otherwise add m to group with mid as the key.
+// The caller must hold nhg.mu.
 func (nhg *nextHopGroup) updateNextHopGroupMember(ctx context.Context, nhgid, mid uint64, m *groupMember) error {
 	group := nhg.groups[nhgid]
 	if group == nil {
@@ -155,39 +210,57 @@ func (nhg *nextHopGroup) updateNextHopGroupMember(ctx context.Context, nhgid, mi
 	} else {
 		delete(group, mid)
 	}
-	var actLists []*fwdpb.ActionList
-	for _, member := range group {
-		action := fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_NEXT_HOP_ID).WithUint64Value(member.nextHop))
-		actLists = append(actLists, &fwdpb.ActionList{
-			Weight:  uint64(member.weight),
-			Actions: []*fwdpb.ActionDesc{action.Build()},
-		})
+	if size, ok := nhg.bucketCnt[nhgid]; ok {
+		buckets := rebalanceBuckets(nhg.buckets[nhgid], size, group)
+		nhg.buckets[nhgid] = buckets
+		nhg.actLists[nhgid] = bucketActionLists(buckets, group)
+	} else {
+		nhg.actLists[nhgid] = moduloActionLists(group)
 	}
 
+	hashID, err := nhg.ecmpHashID(nhgid)
+	if err != nil {
+		return err
+	}
+	return nhg.programGroup(ctx, nhgid, hashID)
+}
+
+// ecmpHashID returns the id of the hash bound to the ECMP groups of the same IP protocol version as the group.
+func (nhg *nextHopGroup) ecmpHashID(nhgid uint64) (uint64, error) {
 	swAttr := &saipb.GetSwitchAttributeResponse{}
 	err := nhg.mgr.PopulateAttributes(&saipb.GetSwitchAttributeRequest{
-		Oid:      switchID,
+		Oid:      nhg.switchID(),
 		AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_ECMP_HASH_IPV4, saipb.SwitchAttr_SWITCH_ATTR_ECMP_HASH_IPV6},
 	}, swAttr)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve hash id: %v", err)
+		return 0, fmt.Errorf("failed to retrieve hash id: %v", err)
 	}
-	hashID := swAttr.GetAttr().GetEcmpHashIpv6()
 	if nhg.groupIsV4[nhgid] {
-		hashID = swAttr.GetAttr().GetEcmpHashIpv4()
+		return swAttr.GetAttr().GetEcmpHashIpv4(), nil
 	}
-	hashAttr := &saipb.GetHashAttributeResponse{}
-	err = nhg.mgr.PopulateAttributes(&saipb.GetHashAttributeRequest{
-		Oid:      hashID,
-		AttrType: []saipb.HashAttr{saipb.HashAttr_HASH_ATTR_NATIVE_HASH_FIELD_LIST},
-	}, hashAttr)
-	if err != nil {
-		return fmt.Errorf("failed to retrieve hash field: %v", err)
+	return swAttr.GetAttr().GetEcmpHashIpv6(), nil
+}
+
+// setHash reprograms the groups of the given IP protocol version to hash on the fields of the hash object.
+func (nhg *nextHopGroup) setHash(ctx context.Context, isV4 bool, hashID uint64) error {
+	nhg.mu.Lock()
+	defer nhg.mu.Unlock()
+	for nhgid, group := range nhg.groups {
+		if len(group) == 0 || nhg.groupIsV4[nhgid] != isV4 {
+			continue
+		}
+		if err := nhg.programGroup(ctx, nhgid, hashID); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	fieldsID, err := convertHashFields(hashAttr.GetAttr().GetNativeHashFieldList())
+// programGroup writes the group's entry in the next hop group table, selecting the bucket using the fields of the hash object.
+func (nhg *nextHopGroup) programGroup(ctx context.Context, nhgid, hashID uint64) error {
+	fieldsID, err := hashFields(nhg.mgr, hashID)
 	if err != nil {
-		return fmt.Errorf("failed to compute hash fields: %v", err)
+		return err
 	}
 
 	actions := []*fwdpb.ActionDesc{{
@@ -196,7 +269,7 @@ func (nhg *nextHopGroup) updateNextHopGroupMember(ctx context.Context, nhgid, mi
 			Select: &fwdpb.SelectActionListActionDesc{
 				SelectAlgorithm: fwdpb.SelectActionListActionDesc_SELECT_ALGORITHM_CRC32, // TODO: should algo + hash be configurable?
 				FieldIds:        fieldsID,
-				ActionLists:     actLists,
+				ActionLists:     nhg.actLists[nhgid],
 			},
 		},
 	}, {
@@ -239,13 +312,132 @@ func (nhg *nextHopGroup) updateNextHopGroupMember(ctx context.Context, nhgid, mi
 	return err
 }
 
+// rebalanceBuckets assigns the size hash buckets of a group to its members in proportion to their weights.
+// Buckets owned by removed members or by members above their share are reassigned to members below their share,
+// all other buckets keep their member.
+func rebalanceBuckets(buckets []uint64, size int, members map[uint64]*groupMember) []uint64 {
+	if len(members) == 0 {
+		return nil
+	}
+	ids := make([]uint64, 0, len(members))
+	var totalWeight uint64
+	for id, m := range members {
+		ids = append(ids, id)
+		totalWeight += uint64(memberWeight(m))
+	}
+	slices.Sort(ids)
+
+	// Each member gets its proportional share, the remainder is handed out in member order.
+	share := map[uint64]int{}
+	assigned := 0
+	for _, id := range ids {
+		share[id] = int(uint64(size) * uint64(memberWeight(members[id])) / totalWeight)
+		assigned += share[id]
+	}
+	for i := 0; assigned < size; i++ {
+		share[ids[i%len(ids)]]++
+		assigned++
+	}
+
+	if buckets == nil {
+		buckets = make([]uint64, size)
+	} else {
+		buckets = slices.Clone(buckets)
+	}
+	var free []int
+	for i, id := range buckets {
+		if _, ok := members[id]; !ok || share[id] == 0 {
+			free = append(free, i)
+			continue
+		}
+		share[id]--
+	}
+	for _, id := range ids {
+		for ; share[id] > 0; share[id]-- {
+			buckets[free[0]] = id
+			free = free[1:]
+		}
+	}
+	return buckets
+}
+
+// bucketActionLists returns the action lists setting the next hop of the member of each hash bucket.
+func bucketActionLists(buckets []uint64, members map[uint64]*groupMember) []*fwdpb.ActionList {
+	// Adjacent buckets with the same member are merged into a single action list,
+	// the select action maps hashes to action lists by cumulative weight so the mapping is unchanged.
+	var actLists []*fwdpb.ActionList
+	for i := 0; i < len(buckets); {
+		j := i
+		for j < len(buckets) && buckets[j] == buckets[i] {
+			j++
+		}
+		actLists = append(actLists, nextHopActionList(members[buckets[i]].nextHop, uint64(j-i)))
+		i = j
+	}
+	return actLists
+}
+
+// moduloActionLists returns an action list per member weighted by the member weight,
+// ordered by sequence id and then member id.
+func moduloActionLists(members map[uint64]*groupMember) []*fwdpb.ActionList {
+	ids := make([]uint64, 0, len(members))
+	for id := range members {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b uint64) int {
+		if c := cmp.Compare(members[a].sequenceID, members[b].sequenceID); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	var actLists []*fwdpb.ActionList
+	for _, id := range ids {
+		actLists = append(actLists, nextHopActionList(members[id].nextHop, uint64(memberWeight(members[id]))))
+	}
+	return actLists
+}
+
+// nextHopActionList returns an action list setting the next hop id, selected with the given weight.
+func nextHopActionList(nextHop, weight uint64) *fwdpb.ActionList {
+	action := fwdconfig.Action(fwdconfig.UpdateAction(fwdpb.UpdateType_UPDATE_TYPE_SET, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_NEXT_HOP_ID).WithUint64Value(nextHop))
+	return &fwdpb.ActionList{
+		Weight:  weight,
+		Actions: []*fwdpb.ActionDesc{action.Build()},
+	}
+}
+
+// memberWeight returns the weight of the member, an unset weight defaults to 1.
+func memberWeight(m *groupMember) uint32 {
+	if m.weight == 0 {
+		return 1
+	}
+	return m.weight
+}
+
+// firstNextHop returns the next hop of the first action list selected by the hash of the group,
+// or false if the group has no members.
+func (nhg *nextHopGroup) firstNextHop(nhgid uint64) (uint64, bool) {
+	nhg.mu.Lock()
+	defer nhg.mu.Unlock()
+	actLists := nhg.actLists[nhgid]
+	if len(actLists) == 0 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(actLists[0].GetActions()[0].GetUpdate().GetValue()), true
+}
+
 // RemoveNextHopGroup removes the next hop group specified in the OID.
 func (nhg *nextHopGroup) RemoveNextHopGroup(_ context.Context, req *saipb.RemoveNextHopGroupRequest) (*saipb.RemoveNextHopGroupResponse, error) {
 	oid := req.GetOid()
+	nhg.mu.Lock()
+	defer nhg.mu.Unlock()
 	if _, ok := nhg.groups[oid]; !ok {
 		return nil, status.Errorf(codes.FailedPrecondition, "group %d does not exist", oid)
 	}
 	delete(nhg.groups, oid)
+	delete(nhg.buckets, oid)
+	delete(nhg.actLists, oid)
+	delete(nhg.bucketCnt, oid)
 
 	entry := fwdconfig.EntryDesc(fwdconfig.ExactEntry(
 		fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_NEXT_HOP_GROUP_ID).WithUint64(oid))).Build()
@@ -264,11 +456,14 @@ func (nhg *nextHopGroup) RemoveNextHopGroup(_ context.Context, req *saipb.Remove
 // CreateNextHopGroupMember adds a next hop to a next hop group.
 func (nhg *nextHopGroup) CreateNextHopGroupMember(ctx context.Context, req *saipb.CreateNextHopGroupMemberRequest) (*saipb.CreateNextHopGroupMemberResponse, error) {
 	nhgid := req.GetNextHopGroupId()
-	mid := nhg.mgr.NextID()
+	mid := nhg.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_NEXT_HOP_GROUP_MEMBER)
 	m := &groupMember{
-		nextHop: req.GetNextHopId(),
-		weight:  req.GetWeight(),
+		nextHop:    req.GetNextHopId(),
+		weight:     req.GetWeight(),
+		sequenceID: req.GetSequenceId(),
 	}
+	nhg.mu.Lock()
+	defer nhg.mu.Unlock()
 	if err := nhg.updateNextHopGroupMember(ctx, nhgid, mid, m); err != nil {
 		return nil, err
 	}
@@ -288,6 +483,8 @@ func (nhg *nextHopGroup) RemoveNextHopGroupMember(ctx context.Context, req *saip
 		}
 		return 0, 0, fmt.Errorf("cannot find member with id=%d", oid)
 	}
+	nhg.mu.Lock()
+	defer nhg.mu.Unlock()
 	nhgid, mid, err := locateMember(req.GetOid())
 	if err != nil {
 		return nil, err
@@ -316,7 +513,7 @@ func newNextHop(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Serv
 
 // CreateNextHop creates a new next hop.
 func (nh *nextHop) CreateNextHop(ctx context.Context, req *saipb.CreateNextHopRequest) (*saipb.CreateNextHopResponse, error) {
-	id := nh.mgr.NextID()
+	id := nh.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_NEXT_HOP)
 
 	var actions []*fwdpb.ActionDesc
 
@@ -396,20 +593,22 @@ func (nh *nextHop) CreateNextHop(ctx context.Context, req *saipb.CreateNextHopRe
 
 // RemoveNextHop removes the next hop with the OID specified.
 func (nh *nextHop) RemoveNextHop(ctx context.Context, r *saipb.RemoveNextHopRequest) (*saipb.RemoveNextHopResponse, error) {
-	entry := fwdconfig.EntryDesc(fwdconfig.ExactEntry(
-		fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_NEXT_HOP_ID).WithUint64(r.GetOid()))).Build()
-	nhReq := &fwdpb.TableEntryRemoveRequest{
-		ContextId: &fwdpb.ContextId{Id: nh.dataplane.ID()},
-		TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: NHTable}},
-		EntryDesc: entry,
-	}
-
-	if _, err := nh.dataplane.TableEntryRemove(ctx, nhReq); err != nil {
+	if err := nh.removeNextHops(ctx, []uint64{r.GetOid()}); err != nil {
 		return nil, err
 	}
 	return &saipb.RemoveNextHopResponse{}, nil
 }
 
+// removeNextHops removes the next hops from the next hop table with a single dataplane call.
+func (nh *nextHop) removeNextHops(ctx context.Context, oids []uint64) error {
+	entries := make([]*fwdpb.EntryDesc, 0, len(oids))
+	for _, oid := range oids {
+		entries = append(entries, fwdconfig.EntryDesc(fwdconfig.ExactEntry(
+			fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_NEXT_HOP_ID).WithUint64(oid))).Build())
+	}
+	return removeTableEntries(ctx, nh.dataplane, NHTable, entries)
+}
+
 func (nh *nextHop) CreateNextHops(ctx context.Context, r *saipb.CreateNextHopsRequest) (*saipb.CreateNextHopsResponse, error) {
 	resp := &saipb.CreateNextHopsResponse{}
 	for _, req := range r.GetReqs() {
@@ -424,14 +623,23 @@ func (nh *nextHop) CreateNextHops(ctx context.Context, r *saipb.CreateNextHopsRe
 
 type route struct {
 	saipb.UnimplementedRouteServer
-	mgr       *attrmgr.AttrMgr
-	dataplane switchDataplaneAPI
+	mgr          *attrmgr.AttrMgr
+	dataplane    switchDataplaneAPI
+	ip2meRoutes  map[string]ip2meRoute       // ip2meRoutes maps the keys of IP2ME routes to the routes.
+	ip2meChanged func(context.Context) error // ip2meChanged is called after an IP2ME route is added or removed.
+}
+
+// ip2meRoute is a route that punts packets to the CPU port.
+type ip2meRoute struct {
+	dst   *saipb.IpPrefix
+	entry *fwdpb.EntryDesc // entry is the route's entry in the trap table.
 }
 
 func newRoute(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server) *route {
 	r := &route{
-		mgr:       mgr,
-		dataplane: dataplane,
+		mgr:         mgr,
+		dataplane:   dataplane,
+		ip2meRoutes: map[string]ip2meRoute{},
 	}
 	saipb.RegisterRouteServer(s, r)
 	return r
@@ -492,18 +700,21 @@ func (r *route) CreateRouteEntry(ctx context.Context, req *saipb.CreateRouteEntr
 				return nil, err
 			}
 			if req.GetNextHopId() == *resp.Attr.CpuPort {
-				_, err := r.dataplane.TableEntryAdd(ctx, fwdconfig.TableEntryAddRequest(r.dataplane.ID(), trapTableID).
-					AppendEntry(
-						fwdconfig.EntryDesc(fwdconfig.FlowEntry(
-							fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_DST).WithBytes(
-								req.GetEntry().GetDestination().GetAddr(),
-								req.GetEntry().GetDestination().GetMask()),
-							fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_VRF).WithUint64(req.GetEntry().GetVrId()))),
-						fwdconfig.Action(fwdconfig.TransmitAction(fmt.Sprint(req.GetNextHopId())).WithImmediate(true))).
-					Build())
-				if err != nil {
+				// IP2ME routes punt packets regardless of whether the IP2ME trap has been created yet.
+				trapEntry := fwdconfig.EntryDesc(fwdconfig.FlowEntry(
+					fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_DST).WithBytes(
+						req.GetEntry().GetDestination().GetAddr(),
+						req.GetEntry().GetDestination().GetMask()),
+					fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_VRF).WithUint64(req.GetEntry().GetVrId())))
+				trapReq := fwdconfig.TableEntryAddRequest(r.dataplane.ID(), trapTableID).
+					AppendEntry(trapEntry, fwdconfig.Action(fwdconfig.TransmitAction(fmt.Sprint(req.GetNextHopId())).WithImmediate(true))).
+					Build()
+				trapReq.GetEntries()[0].GetEntryDesc().GetFlow().Priority = trapPriority
+				if _, err := r.dataplane.TableEntryAdd(ctx, trapReq); err != nil {
 					return nil, status.Errorf(codes.Internal, "failed to add next IP2ME route: %v", nextType)
 				}
+				r.ip2meRoutes[routeKey(req.GetEntry())] = ip2meRoute{dst: req.GetEntry().GetDestination(), entry: trapReq.GetEntries()[0].GetEntryDesc()}
+				r.notifyIP2MEChanged(ctx)
 				return &saipb.CreateRouteEntryResponse{}, nil
 			}
 			entry.AppendActions(
@@ -537,26 +748,128 @@ func (r *route) CreateRouteEntries(ctx context.Context, re *saipb.CreateRouteEnt
 	return resp, errs.Err()
 }
 
+func (r *route) Reset() {
+	r.ip2meRoutes = map[string]ip2meRoute{}
+}
+
+// routeKey returns a string that identifies the route entry.
+func routeKey(entry *saipb.RouteEntry) string {
+	return fmt.Sprintf("%d-%x-%x", entry.GetVrId(), entry.GetDestination().GetAddr(), entry.GetDestination().GetMask())
+}
+
+// hasIP2MERoutes returns whether any route punts packets to the CPU port.
+func (r *route) hasIP2MERoutes() bool {
+	return len(r.ip2meRoutes) > 0
+}
+
+// localPrefixes returns the distinct destinations of the IP2ME routes, which are the switch's local addresses.
+func (r *route) localPrefixes() []*saipb.IpPrefix {
+	byAddr := map[string]*saipb.IpPrefix{}
+	for _, route := range r.ip2meRoutes {
+		byAddr[fmt.Sprintf("%x-%x", route.dst.GetAddr(), route.dst.GetMask())] = route.dst
+	}
+	keys := make([]string, 0, len(byAddr))
+	for key := range byAddr {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	prefixes := make([]*saipb.IpPrefix, 0, len(keys))
+	for _, key := range keys {
+		prefixes = append(prefixes, byAddr[key])
+	}
+	return prefixes
+}
+
+// notifyIP2MEChanged calls ip2meChanged, failing to apply the change elsewhere doesn't fail the route operation.
+func (r *route) notifyIP2MEChanged(ctx context.Context) {
+	if r.ip2meChanged == nil {
+		return
+	}
+	if err := r.ip2meChanged(ctx); err != nil {
+		log.Warningf("failed to apply IP2ME route change: %v", err)
+	}
+}
+
 func (r *route) RemoveRouteEntry(ctx context.Context, req *saipb.RemoveRouteEntryRequest) (*saipb.RemoveRouteEntryResponse, error) {
-	fib := FIBV6Table
-	if len(req.GetEntry().GetDestination().GetAddr()) == 4 {
-		fib = FIBV4Table
+	if err := r.removeRouteEntries(ctx, []*saipb.RouteEntry{req.GetEntry()}); err != nil {
+		return nil, err
 	}
+	return &saipb.RemoveRouteEntryResponse{}, nil
+}
 
-	_, err := r.dataplane.TableEntryRemove(ctx, &fwdpb.TableEntryRemoveRequest{
-		ContextId: &fwdpb.ContextId{Id: r.dataplane.ID()},
-		TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: fib}},
-		EntryDesc: fwdconfig.EntryDesc(
+// removeRouteEntries removes the routes with a single dataplane call for each of the FIB and trap tables.
+func (r *route) removeRouteEntries(ctx context.Context, entries []*saipb.RouteEntry) error {
+	var v4, v6, ip2me []*fwdpb.EntryDesc
+	var ip2meKeys []string
+	for _, entry := range entries {
+		if route, ok := r.ip2meRoutes[routeKey(entry)]; ok {
+			ip2me = append(ip2me, route.entry)
+			ip2meKeys = append(ip2meKeys, routeKey(entry))
+			continue
+		}
+		desc := fwdconfig.EntryDesc(
 			fwdconfig.PrefixEntry(
-				fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_VRF).WithUint64(req.GetEntry().GetVrId()),
+				fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_VRF).WithUint64(entry.GetVrId()),
 				fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_DST).WithBytes(
-					req.GetEntry().GetDestination().GetAddr(),
-					req.GetEntry().GetDestination().GetMask(),
+					entry.GetDestination().GetAddr(),
+					entry.GetDestination().GetMask(),
 				),
 			),
-		).Build(),
-	})
-	return &saipb.RemoveRouteEntryResponse{}, err
+		).Build()
+		if len(entry.GetDestination().GetAddr()) == 4 {
+			v4 = append(v4, desc)
+		} else {
+			v6 = append(v6, desc)
+		}
+	}
+	if err := removeTableEntries(ctx, r.dataplane, trapTableID, ip2me); err != nil {
+		return err
+	}
+	for _, key := range ip2meKeys {
+		delete(r.ip2meRoutes, key)
+	}
+	if len(ip2meKeys) > 0 {
+		r.notifyIP2MEChanged(ctx)
+	}
+	if err := removeTableEntries(ctx, r.dataplane, FIBV4Table, v4); err != nil {
+		return err
+	}
+	return removeTableEntries(ctx, r.dataplane, FIBV6Table, v6)
+}
+
+// virtualRouter is a VRF. VRFs have no state in the dataplane: packets are bound to the VRF of the
+// router interface of their input port (ingress VRF table), and routes are looked up by VRF and destination.
+type virtualRouter struct {
+	saipb.UnimplementedVirtualRouterServer
+	mgr *attrmgr.AttrMgr
+}
+
+func newVirtualRouter(mgr *attrmgr.AttrMgr, s *grpc.Server) *virtualRouter {
+	vr := &virtualRouter{
+		mgr: mgr,
+	}
+	saipb.RegisterVirtualRouterServer(s, vr)
+	return vr
+}
+
+// CreateVirtualRouter creates a VRF, router interfaces created in it bind their port to it.
+func (vr *virtualRouter) CreateVirtualRouter(context.Context, *saipb.CreateVirtualRouterRequest) (*saipb.CreateVirtualRouterResponse, error) {
+	id := vr.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_VIRTUAL_ROUTER)
+	return &saipb.CreateVirtualRouterResponse{Oid: id}, nil
+}
+
+// RemoveVirtualRouter removes a VRF that has no router interfaces.
+func (vr *virtualRouter) RemoveVirtualRouter(_ context.Context, req *saipb.RemoveVirtualRouterRequest) (*saipb.RemoveVirtualRouterResponse, error) {
+	for _, id := range vr.mgr.GetObjectsOfType(saipb.ObjectType_OBJECT_TYPE_ROUTER_INTERFACE) {
+		attr := &saipb.RouterInterfaceAttribute{}
+		if err := vr.mgr.PopulateAllAttributes(id, attr); err != nil {
+			return nil, err
+		}
+		if attr.GetVirtualRouterId() == req.GetOid() {
+			return nil, status.Errorf(codes.FailedPrecondition, "virtual router %d is used by router interface %s", req.GetOid(), id)
+		}
+	}
+	return &saipb.RemoveVirtualRouterResponse{}, nil
 }
 
 type routerInterface struct {
@@ -583,12 +896,19 @@ func ifaceCounterID(oid uint64, input bool) string {
 
 // CreateRouterInterfaces creates a new router interface.
 func (ri *routerInterface) CreateRouterInterface(ctx context.Context, req *saipb.CreateRouterInterfaceRequest) (*saipb.CreateRouterInterfaceResponse, error) {
-	id := ri.mgr.NextID()
+	id := ri.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_ROUTER_INTERFACE)
 	switch req.GetType() {
 	case saipb.RouterInterfaceType_ROUTER_INTERFACE_TYPE_PORT:
 	case saipb.RouterInterfaceType_ROUTER_INTERFACE_TYPE_LOOPBACK: // TODO: Support loopback interfaces
 		log.Warning("loopback interfaces not supported")
 		return &saipb.CreateRouterInterfaceResponse{Oid: id}, nil
+	case saipb.RouterInterfaceType_ROUTER_INTERFACE_TYPE_SUB_PORT: // TODO: Support routing on sub-port interfaces.
+		// Sub-port interfaces only carry the port and VLAN of sub-interface hostifs.
+		if req.PortId == nil || req.OuterVlanId == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "sub-port interface requires port id and outer vlan id")
+		}
+		log.Warning("routing on sub-port interfaces not supported")
+		return &saipb.CreateRouterInterfaceResponse{Oid: id}, nil
 	default:
 		return nil, status.Errorf(codes.InvalidArgument, "unknown interface type: %v", req.GetType())
 	}
@@ -753,23 +1073,34 @@ func (ri *routerInterface) GetRouterInterfaceStats(ctx context.Context, req *sai
 	return &saipb.GetRouterInterfaceStatsResponse{Values: vals}, nil
 }
 
+// vlanMember is a port that is a member of a VLAN.
+type vlanMember struct {
+	vlan uint64
+	port uint64 // NID of the member's port.
+	tag  uint16 // VLAN tag of the member's frames, 0 for untagged members.
+}
+
 type vlan struct {
 	saipb.UnimplementedVlanServer
-	mgr       *attrmgr.AttrMgr
-	dataplane switchDataplaneAPI
+	mgr             *attrmgr.AttrMgr
+	dataplane       switchDataplaneAPI
+	members         map[uint64]*vlanMember
+	broadcastGroups map[uint64]uint64 // map from VLAN id to the L2MC group its broadcast frames are flooded to
 }
 
 func newVlan(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server) *vlan {
 	v := &vlan{
-		mgr:       mgr,
-		dataplane: dataplane,
+		mgr:             mgr,
+		dataplane:       dataplane,
+		members:         map[uint64]*vlanMember{},
+		broadcastGroups: map[uint64]uint64{},
 	}
 	saipb.RegisterVlanServer(s, v)
 	return v
 }
 
-func (vlan *vlan) CreateVlan(context.Context, *saipb.CreateVlanRequest) (*saipb.CreateVlanResponse, error) {
-	id := vlan.mgr.NextID()
+func (vlan *vlan) CreateVlan(_ context.Context, vReq *saipb.CreateVlanRequest) (*saipb.CreateVlanResponse, error) {
+	id := vlan.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_VLAN)
 
 	req := &saipb.GetSwitchAttributeRequest{Oid: 1, AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_DEFAULT_STP_INST_ID}}
 	resp := &saipb.GetSwitchAttributeResponse{}
@@ -787,21 +1118,181 @@ func (vlan *vlan) CreateVlan(context.Context, *saipb.CreateVlanRequest) (*saipb.
 		UnknownLinklocalMcastOutputGroupId: proto.Uint64(0),
 		IngressAcl:                         proto.Uint64(0),
 		EgressAcl:                          proto.Uint64(0),
+		UnknownUnicastFloodControlType:     saipb.VlanFloodControlType_VLAN_FLOOD_CONTROL_TYPE_ALL.Enum(),
 		UnknownUnicastFloodGroup:           proto.Uint64(0),
+		UnknownMulticastFloodControlType:   saipb.VlanFloodControlType_VLAN_FLOOD_CONTROL_TYPE_ALL.Enum(),
 		UnknownMulticastFloodGroup:         proto.Uint64(0),
+		BroadcastFloodControlType:          saipb.VlanFloodControlType_VLAN_FLOOD_CONTROL_TYPE_ALL.Enum(),
 		BroadcastFloodGroup:                proto.Uint64(0),
 		TamObject:                          []uint64{},
 	}
 	vlan.mgr.StoreAttributes(id, attrs)
+	if vReq.GetBroadcastFloodControlType() == saipb.VlanFloodControlType_VLAN_FLOOD_CONTROL_TYPE_L2MC_GROUP && vReq.GetBroadcastFloodGroup() != 0 {
+		vlan.broadcastGroups[id] = vReq.GetBroadcastFloodGroup()
+	}
 	return &saipb.CreateVlanResponse{
 		Oid: id,
 	}, nil
 }
 
+// SetVlanAttribute sets the attributes of a VLAN, only the broadcast flood group affects forwarding.
+func (vlan *vlan) SetVlanAttribute(ctx context.Context, req *saipb.SetVlanAttributeRequest) (*saipb.SetVlanAttributeResponse, error) {
+	if req.BroadcastFloodControlType == nil && req.BroadcastFloodGroup == nil {
+		return &saipb.SetVlanAttributeResponse{}, nil
+	}
+	attrReq := &saipb.GetVlanAttributeRequest{
+		Oid:      req.GetOid(),
+		AttrType: []saipb.VlanAttr{saipb.VlanAttr_VLAN_ATTR_BROADCAST_FLOOD_CONTROL_TYPE, saipb.VlanAttr_VLAN_ATTR_BROADCAST_FLOOD_GROUP},
+	}
+	attrResp := &saipb.GetVlanAttributeResponse{}
+	if err := vlan.mgr.PopulateAttributes(attrReq, attrResp); err != nil {
+		return nil, err
+	}
+	floodType, group := attrResp.GetAttr().GetBroadcastFloodControlType(), attrResp.GetAttr().GetBroadcastFloodGroup()
+	if req.BroadcastFloodControlType != nil {
+		floodType = req.GetBroadcastFloodControlType()
+	}
+	if req.BroadcastFloodGroup != nil {
+		group = req.GetBroadcastFloodGroup()
+	}
+	if floodType != saipb.VlanFloodControlType_VLAN_FLOOD_CONTROL_TYPE_L2MC_GROUP {
+		group = 0
+	}
+	if err := vlan.updateBroadcastGroup(ctx, req.GetOid(), group); err != nil {
+		return nil, err
+	}
+	return &saipb.SetVlanAttributeResponse{}, nil
+}
+
+// updateBroadcastGroup floods the broadcast frames of the VLAN to the L2MC group, or stops flooding them if group is 0.
+func (vlan *vlan) updateBroadcastGroup(ctx context.Context, vid, group uint64) error {
+	if vlan.broadcastGroups[vid] == group {
+		return nil
+	}
+	for _, m := range vlan.members {
+		if m.vlan != vid {
+			continue
+		}
+		var err error
+		if group == 0 {
+			err = vlan.removeFloodEntry(ctx, m)
+		} else {
+			err = vlan.addFloodEntry(ctx, m, group)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if group == 0 {
+		delete(vlan.broadcastGroups, vid)
+	} else {
+		vlan.broadcastGroups[vid] = group
+	}
+	return nil
+}
+
+var broadcastMAC = []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+func vlanFloodEntry(m *vlanMember) *fwdconfig.EntryDescBuilder {
+	return fwdconfig.EntryDesc(fwdconfig.ExactEntry(
+		fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT).WithUint64(m.port),
+		fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_VLAN_TAG).WithUint16(m.tag),
+		fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST).WithBytes(broadcastMAC),
+	))
+}
+
+// addFloodEntry replicates broadcast frames received from the member to the L2MC group.
+// The trap table is looked up first, so trapped frames are punted instead of flooded and
+// copied frames are flooded as well as punted.
+// TODO: Support routing on VLANs, the original frame is always dropped.
+func (vlan *vlan) addFloodEntry(ctx context.Context, m *vlanMember, group uint64) error {
+	_, err := vlan.dataplane.TableEntryAdd(ctx, fwdconfig.TableEntryAddRequest(vlan.dataplane.ID(), vlanFloodTable).
+		AppendEntry(vlanFloodEntry(m),
+			fwdconfig.Action(fwdconfig.LookupAction(trapTableID)),
+			fwdconfig.Action(fwdconfig.LookupAction(l2mcGroupTable(group))),
+			fwdconfig.Action(fwdconfig.DropAction()),
+		).Build())
+	return err
+}
+
+func (vlan *vlan) removeFloodEntry(ctx context.Context, m *vlanMember) error {
+	_, err := vlan.dataplane.TableEntryRemove(ctx, fwdconfig.TableEntryRemoveRequest(vlan.dataplane.ID(), vlanFloodTable).
+		AppendEntry(vlanFloodEntry(m)).Build())
+	return err
+}
+
+// CreateVlanMember adds a bridge port to a VLAN.
+// TODO: Support translating between tagged and untagged members, frames are flooded unmodified.
+func (vlan *vlan) CreateVlanMember(ctx context.Context, req *saipb.CreateVlanMemberRequest) (*saipb.CreateVlanMemberResponse, error) {
+	id := vlan.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_VLAN_MEMBER)
+	vlanAttr := &saipb.GetVlanAttributeResponse{}
+	err := vlan.mgr.PopulateAttributes(&saipb.GetVlanAttributeRequest{
+		Oid:      req.GetVlanId(),
+		AttrType: []saipb.VlanAttr{saipb.VlanAttr_VLAN_ATTR_VLAN_ID},
+	}, vlanAttr)
+	if err != nil {
+		return nil, err
+	}
+	_, nid, err := lookupBridgePort(ctx, vlan.mgr, vlan.dataplane, req.GetBridgePortId())
+	if err != nil {
+		return nil, err
+	}
+	m := &vlanMember{
+		vlan: req.GetVlanId(),
+		port: nid,
+	}
+	if req.GetVlanTaggingMode() == saipb.VlanTaggingMode_VLAN_TAGGING_MODE_TAGGED {
+		m.tag = uint16(vlanAttr.GetAttr().GetVlanId())
+	}
+	if group, ok := vlan.broadcastGroups[m.vlan]; ok {
+		if err := vlan.addFloodEntry(ctx, m, group); err != nil {
+			return nil, err
+		}
+	}
+	vlan.members[id] = m
+	return &saipb.CreateVlanMemberResponse{Oid: id}, nil
+}
+
+// RemoveVlanMember removes a bridge port from a VLAN.
+func (vlan *vlan) RemoveVlanMember(ctx context.Context, req *saipb.RemoveVlanMemberRequest) (*saipb.RemoveVlanMemberResponse, error) {
+	m, ok := vlan.members[req.GetOid()]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "vlan member %d does not exist", req.GetOid())
+	}
+	if _, ok := vlan.broadcastGroups[m.vlan]; ok {
+		if err := vlan.removeFloodEntry(ctx, m); err != nil {
+			return nil, err
+		}
+	}
+	delete(vlan.members, req.GetOid())
+	return &saipb.RemoveVlanMemberResponse{}, nil
+}
+
 type bridge struct {
 	saipb.UnimplementedBridgeServer
 	mgr       *attrmgr.AttrMgr
 	dataplane switchDataplaneAPI
+	ports     map[uint64]*bridgePort // Bridge ports by id.
+	// staticFDB are the FDB entries created by the client, learned entries are only in the dataplane.
+	staticFDB map[string]*fdbEntry
+}
+
+// bridgePort terminates L2 traffic on a port, a VLAN of a port (sub-port), or a tunnel.
+// Frames received on port and sub-port bridge ports are switched using the FDB, other frames are only routed.
+type bridgePort struct {
+	typ    saipb.BridgePortType
+	port   uint64 // Port of port and sub-port bridge ports.
+	nid    uint64 // NID of the port.
+	vlan   uint16 // VLAN of sub-port bridge ports.
+	tunnel uint64 // Tunnel of tunnel bridge ports.
+	learn  bool   // Whether the source MACs of the frames received on the bridge port are learned.
+}
+
+// fdbEntry is an FDB entry that forwards frames to the port of a bridge port or drops them.
+type fdbEntry struct {
+	mac        []byte
+	bridgePort uint64
+	drop       bool
 }
 
 func newBridge(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server) *bridge {
@@ -809,12 +1300,18 @@ func newBridge(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Serve
 		mgr:       mgr,
 		dataplane: dataplane,
 	}
+	b.Reset()
 	saipb.RegisterBridgeServer(s, b)
 	return b
 }
 
+func (br *bridge) Reset() {
+	br.ports = map[uint64]*bridgePort{}
+	br.staticFDB = map[string]*fdbEntry{}
+}
+
 func (br *bridge) CreateBridge(context.Context, *saipb.CreateBridgeRequest) (*saipb.CreateBridgeResponse, error) {
-	id := br.mgr.NextID()
+	id := br.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_BRIDGE)
 	attrs := &saipb.BridgeAttribute{
 		PortList:                   []uint64{},
 		UnknownUnicastFloodGroup:   proto.Uint64(0),
@@ -827,10 +1324,225 @@ func (br *bridge) CreateBridge(context.Context, *saipb.CreateBridgeRequest) (*sa
 	}, nil
 }
 
+// bridgePortEntry returns the entry in the bridge port table matching the frames received on the bridge port.
+// Sub-port bridge ports take precedence over the port bridge port of their port.
+func bridgePortEntry(bp *bridgePort) *fwdpb.EntryDesc {
+	fields := []*fwdconfig.PacketFieldMaskedBytesBuilder{
+		fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT).WithUint64(bp.nid),
+	}
+	priority := uint32(1)
+	if bp.typ == saipb.BridgePortType_BRIDGE_PORT_TYPE_SUB_PORT {
+		fields = append(fields, fwdconfig.PacketFieldMaskedBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_VLAN_TAG).WithUint16(bp.vlan))
+		priority = 0
+	}
+	ed := fwdconfig.EntryDesc(fwdconfig.FlowEntry(fields...)).Build()
+	ed.GetFlow().Priority = priority
+	return ed
+}
+
+// CreateBridgePort creates a bridge port, only port and sub-port bridge ports can be members of VLANs and L2MC groups.
+// Frames received on port and sub-port bridge ports are forwarded by the FDB, and their source MACs are learned
+// unless learning is disabled. Tunnel bridge ports are modeled, but don't switch frames yet.
+func (br *bridge) CreateBridgePort(ctx context.Context, req *saipb.CreateBridgePortRequest) (*saipb.CreateBridgePortResponse, error) {
+	bp := &bridgePort{
+		typ:    req.GetType(),
+		port:   req.GetPortId(),
+		vlan:   uint16(req.GetVlanId()),
+		tunnel: req.GetTunnelId(),
+	}
+	switch mode := req.GetFdbLearningMode(); mode {
+	case saipb.BridgePortFdbLearningMode_BRIDGE_PORT_FDB_LEARNING_MODE_UNSPECIFIED, saipb.BridgePortFdbLearningMode_BRIDGE_PORT_FDB_LEARNING_MODE_HW:
+		bp.learn = true
+	case saipb.BridgePortFdbLearningMode_BRIDGE_PORT_FDB_LEARNING_MODE_DISABLE:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported FDB learning mode: %v", mode)
+	}
+	switch bp.typ {
+	case saipb.BridgePortType_BRIDGE_PORT_TYPE_PORT:
+		if req.PortId == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "port bridge port requires port id")
+		}
+	case saipb.BridgePortType_BRIDGE_PORT_TYPE_SUB_PORT:
+		if req.PortId == nil || req.VlanId == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "sub-port bridge port requires port id and vlan id")
+		}
+	case saipb.BridgePortType_BRIDGE_PORT_TYPE_TUNNEL:
+		if req.TunnelId == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "tunnel bridge port requires tunnel id")
+		}
+	}
+	if bp.typ == saipb.BridgePortType_BRIDGE_PORT_TYPE_PORT || bp.typ == saipb.BridgePortType_BRIDGE_PORT_TYPE_SUB_PORT {
+		for id, other := range br.ports {
+			if other.typ == bp.typ && other.port == bp.port && other.vlan == bp.vlan {
+				return nil, status.Errorf(codes.AlreadyExists, "bridge port %d already terminates port %d vlan %d", id, bp.port, bp.vlan)
+			}
+		}
+		nid, err := br.dataplane.ObjectNID(ctx, &fwdpb.ObjectNIDRequest{
+			ContextId: &fwdpb.ContextId{Id: br.dataplane.ID()},
+			ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(bp.port)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		bp.nid = nid.GetNid()
+		var actions []*fwdpb.ActionDesc
+		if bp.learn {
+			actions = append(actions, fwdconfig.Action(fwdconfig.BridgeLearnAction(fdbTable)).Build())
+		}
+		actions = append(actions, fwdconfig.Action(fwdconfig.LookupAction(fdbTable)).Build())
+		_, err = br.dataplane.TableEntryAdd(ctx, &fwdpb.TableEntryAddRequest{
+			ContextId: &fwdpb.ContextId{Id: br.dataplane.ID()},
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: bridgePortTable}},
+			Entries:   []*fwdpb.TableEntryAddRequest_Entry{{EntryDesc: bridgePortEntry(bp), Actions: actions}},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	id := br.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_BRIDGE_PORT)
+	br.ports[id] = bp
+	return &saipb.CreateBridgePortResponse{Oid: id}, nil
+}
+
+// RemoveBridgePort removes a bridge port that isn't used by any static FDB entry.
+// Learned FDB entries are flushed, as some of them may forward to the bridge port.
+func (br *bridge) RemoveBridgePort(ctx context.Context, req *saipb.RemoveBridgePortRequest) (*saipb.RemoveBridgePortResponse, error) {
+	bp, ok := br.ports[req.GetOid()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "bridge port %d does not exist", req.GetOid())
+	}
+	for _, e := range br.staticFDB {
+		if e.bridgePort == req.GetOid() {
+			return nil, status.Errorf(codes.FailedPrecondition, "bridge port %d is used by FDB entry %v", req.GetOid(), net.HardwareAddr(e.mac))
+		}
+	}
+	if bp.typ == saipb.BridgePortType_BRIDGE_PORT_TYPE_PORT || bp.typ == saipb.BridgePortType_BRIDGE_PORT_TYPE_SUB_PORT {
+		_, err := br.dataplane.TableEntryRemove(ctx, &fwdpb.TableEntryRemoveRequest{
+			ContextId: &fwdpb.ContextId{Id: br.dataplane.ID()},
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: bridgePortTable}},
+			Entries:   []*fwdpb.EntryDesc{bridgePortEntry(bp)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := br.flushLearned(ctx); err != nil {
+			return nil, err
+		}
+	}
+	delete(br.ports, req.GetOid())
+	return &saipb.RemoveBridgePortResponse{}, nil
+}
+
+// fdbEntryActions returns the actions of the dataplane entry of the FDB entry.
+func (br *bridge) fdbEntryActions(e *fdbEntry) []*fwdconfig.ActionBuilder {
+	if e.drop {
+		return []*fwdconfig.ActionBuilder{fwdconfig.Action(fwdconfig.DropAction())}
+	}
+	return []*fwdconfig.ActionBuilder{fwdconfig.Action(fwdconfig.TransmitAction(fmt.Sprint(br.ports[e.bridgePort].port)).WithImmediate(true))}
+}
+
+func fdbEntryDesc(mac []byte) *fwdconfig.EntryDescBuilder {
+	return fwdconfig.EntryDesc(fwdconfig.ExactEntry(fwdconfig.PacketFieldBytes(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST).WithBytes(mac)))
+}
+
+// flushLearned removes the learned entries from the FDB, keeping the static ones.
+func (br *bridge) flushLearned(ctx context.Context) error {
+	req := fwdconfig.TableEntryAddRequest(br.dataplane.ID(), fdbTable)
+	for _, e := range br.staticFDB {
+		req.AppendEntry(fdbEntryDesc(e.mac), br.fdbEntryActions(e)...)
+	}
+	add := req.Build()
+	add.ClearBeforeAdd = true
+	_, err := br.dataplane.TableEntryAdd(ctx, add)
+	return err
+}
+
+type fdb struct {
+	saipb.UnimplementedFdbServer
+	bridge *bridge
+}
+
+func newFDB(br *bridge, s *grpc.Server) *fdb {
+	f := &fdb{bridge: br}
+	saipb.RegisterFdbServer(s, f)
+	return f
+}
+
+// CreateFdbEntry creates a static FDB entry, forwarding frames to the MAC to the port of a port or sub-port bridge port.
+// The FDB isn't VLAN aware yet, so entries for the same MAC in different VLANs can't coexist.
+func (f *fdb) CreateFdbEntry(ctx context.Context, req *saipb.CreateFdbEntryRequest) (*saipb.CreateFdbEntryResponse, error) {
+	mac := req.GetEntry().GetMacAddress()
+	if len(mac) != 6 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid MAC address: %x", mac)
+	}
+	if _, ok := f.bridge.staticFDB[string(mac)]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "FDB entry for %v already exists", net.HardwareAddr(mac))
+	}
+	e := &fdbEntry{mac: mac, bridgePort: req.GetBridgePortId()}
+	switch action := req.GetPacketAction(); action {
+	case saipb.PacketAction_PACKET_ACTION_UNSPECIFIED, saipb.PacketAction_PACKET_ACTION_FORWARD:
+		bp, ok := f.bridge.ports[e.bridgePort]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "bridge port %d does not exist", e.bridgePort)
+		}
+		if bp.typ != saipb.BridgePortType_BRIDGE_PORT_TYPE_PORT && bp.typ != saipb.BridgePortType_BRIDGE_PORT_TYPE_SUB_PORT {
+			return nil, status.Errorf(codes.InvalidArgument, "FDB entries can only forward to port and sub-port bridge ports, got %v", bp.typ)
+		}
+	case saipb.PacketAction_PACKET_ACTION_DROP:
+		e.drop = true
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported packet action: %v", action)
+	}
+	_, err := f.bridge.dataplane.TableEntryAdd(ctx, fwdconfig.TableEntryAddRequest(f.bridge.dataplane.ID(), fdbTable).
+		AppendEntry(fdbEntryDesc(mac), f.bridge.fdbEntryActions(e)...).Build())
+	if err != nil {
+		return nil, err
+	}
+	f.bridge.staticFDB[string(mac)] = e
+	return &saipb.CreateFdbEntryResponse{}, nil
+}
+
+// RemoveFdbEntry removes a static FDB entry.
+func (f *fdb) RemoveFdbEntry(ctx context.Context, req *saipb.RemoveFdbEntryRequest) (*saipb.RemoveFdbEntryResponse, error) {
+	mac := req.GetEntry().GetMacAddress()
+	if _, ok := f.bridge.staticFDB[string(mac)]; !ok {
+		return nil, status.Errorf(codes.NotFound, "FDB entry for %v does not exist", net.HardwareAddr(mac))
+	}
+	_, err := f.bridge.dataplane.TableEntryRemove(ctx, fwdconfig.TableEntryRemoveRequest(f.bridge.dataplane.ID(), fdbTable).
+		AppendEntry(fdbEntryDesc(mac)).Build())
+	if err != nil {
+		return nil, err
+	}
+	delete(f.bridge.staticFDB, string(mac))
+	return &saipb.RemoveFdbEntryResponse{}, nil
+}
+
+// lookupBridgePort returns the ID and NID of the port of a port bridge port.
+func lookupBridgePort(ctx context.Context, mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, bridgePort uint64) (uint64, uint64, error) {
+	attr := &saipb.GetBridgePortAttributeResponse{}
+	err := mgr.PopulateAttributes(&saipb.GetBridgePortAttributeRequest{
+		Oid:      bridgePort,
+		AttrType: []saipb.BridgePortAttr{saipb.BridgePortAttr_BRIDGE_PORT_ATTR_PORT_ID},
+	}, attr)
+	if err != nil {
+		return 0, 0, status.Errorf(codes.FailedPrecondition, "bridge port %d has no port: %v", bridgePort, err)
+	}
+	port := attr.GetAttr().GetPortId()
+	nid, err := dataplane.ObjectNID(ctx, &fwdpb.ObjectNIDRequest{
+		ContextId: &fwdpb.ContextId{Id: dataplane.ID()},
+		ObjectId:  &fwdpb.ObjectId{Id: fmt.Sprint(port)},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return port, nid.GetNid(), nil
+}
+
 type hash struct {
 	saipb.UnimplementedHashServer
 	mgr       *attrmgr.AttrMgr
 	dataplane switchDataplaneAPI
+	rebind    func(ctx context.Context, hashID uint64) error // rebind reprograms the next hop groups and LAGs the hash is bound to.
 }
 
 func newHash(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Server) *hash {
@@ -856,6 +1568,14 @@ func convertHashFields(list []saipb.NativeHashField) ([]*fwdpb.PacketFieldId, er
 			fields = append(fields, &fwdpb.PacketFieldId{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_L4_PORT_DST}})
 		case saipb.NativeHashField_NATIVE_HASH_FIELD_IPV6_FLOW_LABEL:
 			fields = append(fields, &fwdpb.PacketFieldId{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP6_FLOW}})
+		case saipb.NativeHashField_NATIVE_HASH_FIELD_IP_PROTOCOL:
+			fields = append(fields, &fwdpb.PacketFieldId{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_PROTO}})
+		case saipb.NativeHashField_NATIVE_HASH_FIELD_SRC_MAC:
+			fields = append(fields, &fwdpb.PacketFieldId{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_SRC}})
+		case saipb.NativeHashField_NATIVE_HASH_FIELD_DST_MAC:
+			fields = append(fields, &fwdpb.PacketFieldId{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST}})
+		case saipb.NativeHashField_NATIVE_HASH_FIELD_ETHERTYPE:
+			fields = append(fields, &fwdpb.PacketFieldId{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_TYPE}})
 		default:
 			return nil, fmt.Errorf("unsupported hash field: %v", field)
 		}
@@ -863,8 +1583,25 @@ func convertHashFields(list []saipb.NativeHashField) ([]*fwdpb.PacketFieldId, er
 	return fields, nil
 }
 
+// hashFields returns the packet fields of the native hash field list of the hash object.
+func hashFields(mgr *attrmgr.AttrMgr, hashID uint64) ([]*fwdpb.PacketFieldId, error) {
+	hashAttr := &saipb.GetHashAttributeResponse{}
+	err := mgr.PopulateAttributes(&saipb.GetHashAttributeRequest{
+		Oid:      hashID,
+		AttrType: []saipb.HashAttr{saipb.HashAttr_HASH_ATTR_NATIVE_HASH_FIELD_LIST},
+	}, hashAttr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve hash field: %v", err)
+	}
+	fields, err := convertHashFields(hashAttr.GetAttr().GetNativeHashFieldList())
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute hash fields: %v", err)
+	}
+	return fields, nil
+}
+
 func (h *hash) CreateHash(_ context.Context, req *saipb.CreateHashRequest) (*saipb.CreateHashResponse, error) {
-	id := h.mgr.NextID()
+	id := h.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_HASH)
 
 	// Creating a hash doesn't affect the forwarding pipeline, just validate the arguments.
 	_, err := convertHashFields(req.GetNativeHashFieldList())
@@ -874,3 +1611,18 @@ func (h *hash) CreateHash(_ context.Context, req *saipb.CreateHashRequest) (*sai
 
 	return &saipb.CreateHashResponse{Oid: id}, nil
 }
+
+// SetHashAttribute updates the fields of the hash, e.g. to include the IPv6 flow label, and reprograms the objects it's bound to.
+func (h *hash) SetHashAttribute(ctx context.Context, req *saipb.SetHashAttributeRequest) (*saipb.SetHashAttributeResponse, error) {
+	if _, err := convertHashFields(req.GetNativeHashFieldList()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// The objects are reprogrammed from the stored attributes, so the new fields must be stored first.
+	h.mgr.StoreAttributes(req.GetOid(), req)
+	if h.rebind != nil {
+		if err := h.rebind(ctx, req.GetOid()); err != nil {
+			return nil, err
+		}
+	}
+	return &saipb.SetHashAttributeResponse{}, nil
+}
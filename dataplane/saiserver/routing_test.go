@@ -15,18 +15,28 @@
 package saiserver
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
 	"github.com/openconfig/gnmi/errdiff"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 
+	"github.com/openconfig/lemming/dataplane/dplaneopts"
 	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdcontext"
 	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdobject"
 	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
+	"github.com/openconfig/lemming/internal/packetutil"
 
 	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
 	fwdpb "github.com/openconfig/lemming/proto/forwarding"
@@ -89,6 +99,26 @@ func TestCreateNextHopGroup(t *testing.T) {
 		wantAttr: &saipb.NextHopGroupAttribute{
 			Type: saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_DYNAMIC_UNORDERED_ECMP.Enum(),
 		},
+	}, {
+		desc: "fine grain default size",
+		req: &saipb.CreateNextHopGroupRequest{
+			Type: saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_FINE_GRAIN_ECMP.Enum(),
+		},
+		wantAttr: &saipb.NextHopGroupAttribute{
+			Type:     saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_FINE_GRAIN_ECMP.Enum(),
+			RealSize: proto.Uint32(nhgBucketCount),
+		},
+	}, {
+		desc: "fine grain configured size",
+		req: &saipb.CreateNextHopGroupRequest{
+			Type:           saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_FINE_GRAIN_ECMP.Enum(),
+			ConfiguredSize: proto.Uint32(16),
+		},
+		wantAttr: &saipb.NextHopGroupAttribute{
+			Type:           saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_FINE_GRAIN_ECMP.Enum(),
+			ConfiguredSize: proto.Uint32(16),
+			RealSize:       proto.Uint32(16),
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -196,7 +226,7 @@ func TestCreateNextHopGroupMember(t *testing.T) {
 								{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_DST}},
 							},
 							ActionLists: []*fwdpb.ActionList{{
-								Weight: 3,
+								Weight: nhgBucketCount,
 								Actions: []*fwdpb.ActionDesc{{
 									ActionType: fwdpb.ActionType_ACTION_TYPE_UPDATE,
 									Action: &fwdpb.ActionDesc_Update{
@@ -299,7 +329,7 @@ func TestRemoveNextHopGroupMember(t *testing.T) {
 								{Field: &fwdpb.PacketField{FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_DST}},
 							},
 							ActionLists: []*fwdpb.ActionList{{
-								Weight: 66,
+								Weight: nhgBucketCount,
 								Actions: []*fwdpb.ActionDesc{{
 									ActionType: fwdpb.ActionType_ACTION_TYPE_UPDATE,
 									Action: &fwdpb.ActionDesc_Update{
@@ -396,6 +426,214 @@ func TestRemoveNextHopGroupMember(t *testing.T) {
 	}
 }
 
+// nhgBuckets expands the action lists of a next hop group entry into the next hop of each hash bucket.
+func nhgBuckets(t *testing.T, req *fwdpb.TableEntryAddRequest) []uint64 {
+	t.Helper()
+	var buckets []uint64
+	for _, al := range req.GetEntries()[0].GetActions()[0].GetSelect().GetActionLists() {
+		nh := binary.BigEndian.Uint64(al.GetActions()[0].GetUpdate().GetValue())
+		for i := uint64(0); i < al.GetWeight(); i++ {
+			buckets = append(buckets, nh)
+		}
+	}
+	return buckets
+}
+
+func TestNextHopGroupMemberRemap(t *testing.T) {
+	dplane := &fakeSwitchDataplane{}
+	c, mgr, stopFn := newTestNextHopGroup(t, dplane)
+	defer stopFn()
+	mgr.StoreAttributes(mgr.NextID(), &saipb.SwitchAttribute{EcmpHashIpv4: proto.Uint64(10), EcmpHashIpv6: proto.Uint64(10)})
+	mgr.StoreAttributes(10, &saipb.CreateHashRequest{
+		NativeHashFieldList: []saipb.NativeHashField{saipb.NativeHashField_NATIVE_HASH_FIELD_DST_IP},
+	})
+	for nh := uint64(11); nh <= 15; nh++ {
+		mgr.StoreAttributes(nh, &saipb.CreateNextHopRequest{Ip: []byte{10, 0, 0, byte(nh)}})
+	}
+
+	ctx := context.Background()
+	r, err := c.CreateNextHopGroup(ctx, &saipb.CreateNextHopGroupRequest{Type: saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_DYNAMIC_UNORDERED_ECMP.Enum()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var firstMember uint64
+	for nh := uint64(11); nh <= 14; nh++ {
+		resp, err := c.CreateNextHopGroupMember(ctx, &saipb.CreateNextHopGroupMemberRequest{
+			NextHopGroupId: proto.Uint64(r.GetOid()),
+			NextHopId:      proto.Uint64(nh),
+			Weight:         proto.Uint32(1),
+		})
+		if err != nil {
+			t.Fatalf("CreateNextHopGroupMember() unexpected err: %v", err)
+		}
+		if firstMember == 0 {
+			firstMember = resp.GetOid()
+		}
+	}
+	before := nhgBuckets(t, dplane.gotEntryAddReqs[len(dplane.gotEntryAddReqs)-1])
+	if len(before) != nhgBucketCount {
+		t.Fatalf("got %d buckets, want %d", len(before), nhgBucketCount)
+	}
+
+	if _, err := c.CreateNextHopGroupMember(ctx, &saipb.CreateNextHopGroupMemberRequest{
+		NextHopGroupId: proto.Uint64(r.GetOid()),
+		NextHopId:      proto.Uint64(15),
+		Weight:         proto.Uint32(1),
+	}); err != nil {
+		t.Fatalf("CreateNextHopGroupMember() unexpected err: %v", err)
+	}
+	after := nhgBuckets(t, dplane.gotEntryAddReqs[len(dplane.gotEntryAddReqs)-1])
+
+	counts := map[uint64]int{}
+	for i := range after {
+		counts[after[i]]++
+		if before[i] != after[i] && after[i] != 15 {
+			t.Errorf("bucket %d remapped from next hop %d to %d, want only remaps to new next hop 15", i, before[i], after[i])
+		}
+	}
+	// 128 buckets over 5 equal members is 26 buckets for the first 3 and 25 for the others.
+	if want := nhgBucketCount / 5; counts[15] != want {
+		t.Errorf("new next hop got %d buckets, want %d", counts[15], want)
+	}
+
+	// Removing a member only remaps the buckets of the removed member.
+	if _, err := c.RemoveNextHopGroupMember(ctx, &saipb.RemoveNextHopGroupMemberRequest{Oid: firstMember}); err != nil {
+		t.Fatalf("RemoveNextHopGroupMember() unexpected err: %v", err)
+	}
+	removed := nhgBuckets(t, dplane.gotEntryAddReqs[len(dplane.gotEntryAddReqs)-1])
+	for i := range removed {
+		if removed[i] == 11 {
+			t.Errorf("bucket %d still maps to removed next hop 11", i)
+		}
+		if after[i] != 11 && removed[i] != after[i] {
+			t.Errorf("bucket %d remapped from next hop %d to %d, want unchanged", i, after[i], removed[i])
+		}
+	}
+}
+
+func TestNextHopGroupHashModeRemap(t *testing.T) {
+	const flows = 1000
+	// remappedFlows returns the number of flows that move to another next hop when a member is removed from a group of the type.
+	remappedFlows := func(t *testing.T, groupType saipb.NextHopGroupType) int {
+		t.Helper()
+		dplane := &fakeSwitchDataplane{}
+		c, mgr, stopFn := newTestNextHopGroup(t, dplane)
+		defer stopFn()
+		mgr.StoreAttributes(mgr.NextID(), &saipb.SwitchAttribute{EcmpHashIpv4: proto.Uint64(10), EcmpHashIpv6: proto.Uint64(10)})
+		mgr.StoreAttributes(10, &saipb.CreateHashRequest{
+			NativeHashFieldList: []saipb.NativeHashField{saipb.NativeHashField_NATIVE_HASH_FIELD_DST_IP},
+		})
+		for nh := uint64(11); nh <= 15; nh++ {
+			mgr.StoreAttributes(nh, &saipb.CreateNextHopRequest{Ip: []byte{10, 0, 0, byte(nh)}})
+		}
+
+		ctx := context.Background()
+		r, err := c.CreateNextHopGroup(ctx, &saipb.CreateNextHopGroupRequest{Type: groupType.Enum()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var removedMember uint64
+		for nh := uint64(11); nh <= 15; nh++ {
+			resp, err := c.CreateNextHopGroupMember(ctx, &saipb.CreateNextHopGroupMemberRequest{
+				NextHopGroupId: proto.Uint64(r.GetOid()),
+				NextHopId:      proto.Uint64(nh),
+				SequenceId:     proto.Uint32(uint32(nh)),
+			})
+			if err != nil {
+				t.Fatalf("CreateNextHopGroupMember() unexpected err: %v", err)
+			}
+			if nh == 13 {
+				removedMember = resp.GetOid()
+			}
+		}
+		before := nhgBuckets(t, dplane.gotEntryAddReqs[len(dplane.gotEntryAddReqs)-1])
+		if _, err := c.RemoveNextHopGroupMember(ctx, &saipb.RemoveNextHopGroupMemberRequest{Oid: removedMember}); err != nil {
+			t.Fatalf("RemoveNextHopGroupMember() unexpected err: %v", err)
+		}
+		after := nhgBuckets(t, dplane.gotEntryAddReqs[len(dplane.gotEntryAddReqs)-1])
+
+		// The select action maps a flow's hash to the action list of the bucket at the hash modulo the total weight.
+		remapped := 0
+		for i := uint32(0); i < flows; i++ {
+			hash := uint64(crc32.ChecksumIEEE(binary.BigEndian.AppendUint32(nil, i)))
+			from, to := before[hash%uint64(len(before))], after[hash%uint64(len(after))]
+			if to == 13 {
+				t.Errorf("flow %d still maps to removed next hop 13", i)
+			}
+			if from != to {
+				remapped++
+			}
+		}
+		return remapped
+	}
+
+	modulo := remappedFlows(t, saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_DYNAMIC_ORDERED_ECMP)
+	consistent := remappedFlows(t, saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_DYNAMIC_UNORDERED_ECMP)
+	t.Logf("flows remapped out of %d: modulo %d, consistent %d", flows, modulo, consistent)
+	if consistent >= modulo {
+		t.Errorf("consistent hashing remapped %d flows, want fewer than modulo hashing's %d", consistent, modulo)
+	}
+	// Consistent hashing only remaps the flows of the removed member, about a fifth of them.
+	if consistent > flows/4 {
+		t.Errorf("consistent hashing remapped %d flows, want at most %d", consistent, flows/4)
+	}
+}
+
+func TestNextHopGroupBuckets(t *testing.T) {
+	tests := []struct {
+		desc        string
+		req         *saipb.CreateNextHopGroupRequest
+		wantBuckets []uint64
+	}{{
+		desc: "ordered",
+		req: &saipb.CreateNextHopGroupRequest{
+			Type: saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_DYNAMIC_ORDERED_ECMP.Enum(),
+		},
+		wantBuckets: []uint64{12, 12, 11},
+	}, {
+		desc: "fine grain",
+		req: &saipb.CreateNextHopGroupRequest{
+			Type:           saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_FINE_GRAIN_ECMP.Enum(),
+			ConfiguredSize: proto.Uint32(6),
+		},
+		wantBuckets: []uint64{11, 11, 12, 12, 12, 12},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dplane := &fakeSwitchDataplane{}
+			c, mgr, stopFn := newTestNextHopGroup(t, dplane)
+			defer stopFn()
+			mgr.StoreAttributes(mgr.NextID(), &saipb.SwitchAttribute{EcmpHashIpv4: proto.Uint64(10), EcmpHashIpv6: proto.Uint64(10)})
+			mgr.StoreAttributes(10, &saipb.CreateHashRequest{
+				NativeHashFieldList: []saipb.NativeHashField{saipb.NativeHashField_NATIVE_HASH_FIELD_DST_IP},
+			})
+			mgr.StoreAttributes(11, &saipb.CreateNextHopRequest{Ip: []byte{10, 0, 0, 11}})
+			mgr.StoreAttributes(12, &saipb.CreateNextHopRequest{Ip: []byte{10, 0, 0, 12}})
+
+			ctx := context.Background()
+			r, err := c.CreateNextHopGroup(ctx, tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The second member is first in sequence order.
+			for _, m := range []struct{ nh, weight, seq uint32 }{{11, 1, 2}, {12, 2, 1}} {
+				if _, err := c.CreateNextHopGroupMember(ctx, &saipb.CreateNextHopGroupMemberRequest{
+					NextHopGroupId: proto.Uint64(r.GetOid()),
+					NextHopId:      proto.Uint64(uint64(m.nh)),
+					Weight:         proto.Uint32(m.weight),
+					SequenceId:     proto.Uint32(m.seq),
+				}); err != nil {
+					t.Fatalf("CreateNextHopGroupMember() unexpected err: %v", err)
+				}
+			}
+			got := nhgBuckets(t, dplane.gotEntryAddReqs[len(dplane.gotEntryAddReqs)-1])
+			if d := cmp.Diff(got, tt.wantBuckets); d != "" {
+				t.Errorf("CreateNextHopGroupMember() unexpected buckets: diff(-got,+want)\n:%s", d)
+			}
+		})
+	}
+}
+
 func TestCreateNextHop(t *testing.T) {
 	tests := []struct {
 		desc     string
@@ -902,7 +1140,8 @@ func newTestNeighbor(t testing.TB, api switchDataplaneAPI) (saipb.NeighborClient
 
 func newTestNextHopGroup(t testing.TB, api switchDataplaneAPI) (saipb.NextHopGroupClient, *attrmgr.AttrMgr, func()) {
 	conn, mgr, stopFn := newTestServer(t, func(mgr *attrmgr.AttrMgr, srv *grpc.Server) {
-		newNextHopGroup(mgr, api, srv)
+		nhg := newNextHopGroup(mgr, api, srv)
+		nhg.switchID = func() uint64 { return switchID }
 	})
 	return saipb.NewNextHopGroupClient(conn), mgr, stopFn
 }
@@ -934,3 +1173,739 @@ func newTestHash(t testing.TB, api switchDataplaneAPI) (saipb.HashClient, *attrm
 	})
 	return saipb.NewHashClient(conn), mgr, stopFn
 }
+
+func TestRoutedChecksums(t *testing.T) {
+	tests := []struct {
+		desc          string
+		validate      bool
+		corrupt       bool
+		wantForwarded bool
+	}{{
+		desc:          "valid checksum",
+		validate:      true,
+		wantForwarded: true,
+	}, {
+		desc:          "stale checksum recomputed",
+		corrupt:       true,
+		wantForwarded: true,
+	}, {
+		desc:     "bad checksum dropped",
+		validate: true,
+		corrupt:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ctx := context.Background()
+			s, conn, fwdCtx := newTestSaiServer(t,
+				dplaneopts.WithRemoteCPUPort(true),
+				dplaneopts.WithChecksumValidation(tt.validate),
+			)
+			sinks := map[string]*packetutil.Sink{"1": packetutil.NewSink(1), "2": packetutil.NewSink(1)}
+			fwdCtx.FakePortManager = lanePortManager{sinks: sinks}
+			fwdCtx.SetCPUPortSink(packetutil.NewSink(1).CPUPortSink, func() {})
+
+			sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			swAttr, err := saipb.NewSwitchClient(conn).GetSwitchAttribute(ctx, &saipb.GetSwitchAttributeRequest{
+				Oid:      sw.GetOid(),
+				AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_DEFAULT_VIRTUAL_ROUTER_ID},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			vrID := swAttr.GetAttr().GetDefaultVirtualRouterId()
+			acceptAnyDstMAC(t, conn)
+			var ports, rifs []uint64
+			for i := uint32(1); i <= 2; i++ {
+				port := createTestPort(t, conn, i)
+				rif, err := saipb.NewRouterInterfaceClient(conn).CreateRouterInterface(ctx, &saipb.CreateRouterInterfaceRequest{
+					Type:            saipb.RouterInterfaceType_ROUTER_INTERFACE_TYPE_PORT.Enum(),
+					PortId:          proto.Uint64(port),
+					VirtualRouterId: proto.Uint64(vrID),
+					SrcMacAddress:   []byte{0x02, 0x00, 0x00, 0x00, 0x00, byte(i)},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				ports = append(ports, port)
+				rifs = append(rifs, rif.GetOid())
+			}
+			// Packets to 198.51.100.0/24 are routed out of the second interface.
+			if _, err := saipb.NewNeighborClient(conn).CreateNeighborEntry(ctx, &saipb.CreateNeighborEntryRequest{
+				Entry:         &saipb.NeighborEntry{SwitchId: sw.GetOid(), RifId: rifs[1], IpAddress: []byte{192, 0, 2, 2}},
+				DstMacAddress: []byte{0x02, 0x00, 0x00, 0x00, 0x01, 0x02},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			nh, err := saipb.NewNextHopClient(conn).CreateNextHop(ctx, &saipb.CreateNextHopRequest{
+				Switch:            sw.GetOid(),
+				Type:              saipb.NextHopType_NEXT_HOP_TYPE_IP.Enum(),
+				Ip:                []byte{192, 0, 2, 2},
+				RouterInterfaceId: proto.Uint64(rifs[1]),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := saipb.NewRouteClient(conn).CreateRouteEntry(ctx, &saipb.CreateRouteEntryRequest{
+				Entry: &saipb.RouteEntry{
+					SwitchId:    sw.GetOid(),
+					VrId:        vrID,
+					Destination: &saipb.IpPrefix{Addr: []byte{198, 51, 100, 0}, Mask: []byte{255, 255, 255, 0}},
+				},
+				NextHopId:    proto.Uint64(nh.GetOid()),
+				PacketAction: saipb.PacketAction_PACKET_ACTION_FORWARD.Enum(),
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			ip4 := &layers.IPv4{
+				Version:  4,
+				TTL:      64,
+				Protocol: layers.IPProtocolUDP,
+				SrcIP:    net.IPv4(192, 0, 2, 100).To4(),
+				DstIP:    net.IPv4(198, 51, 100, 1).To4(),
+			}
+			udp := &layers.UDP{SrcPort: 5000, DstPort: 5000}
+			if err := udp.SetNetworkLayerForChecksum(ip4); err != nil {
+				t.Fatal(err)
+			}
+			buf := gopacket.NewSerializeBuffer()
+			if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true},
+				&layers.Ethernet{
+					SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x01, 0x01},
+					DstMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+					EthernetType: layers.EthernetTypeIPv4,
+				}, ip4, udp, gopacket.Payload("data")); err != nil {
+				t.Fatal(err)
+			}
+			frame := buf.Bytes()
+			if tt.corrupt {
+				// Flip the IPv4 header checksum.
+				frame[24] ^= 0xff
+			}
+			err = s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(ports[0])}},
+				fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, frame, nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pkt, err := sinks["2"].Next(time.Second)
+			if !tt.wantForwarded {
+				if err == nil {
+					t.Errorf("packet with bad checksum forwarded: %v", pkt)
+				}
+				stats, err := saipb.NewPortClient(conn).GetPortStats(ctx, &saipb.GetPortStatsRequest{
+					Oid:        ports[0],
+					CounterIds: []saipb.PortStat{saipb.PortStat_PORT_STAT_IF_IN_DISCARDS},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if d := cmp.Diff(stats.GetValues(), []uint64{1}); d != "" {
+					t.Errorf("GetPortStats() failed: diff(-got,+want)\n:%s", d)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("packet not routed: %v", err)
+			}
+			data := pkt.Data()
+			ip := pkt.IPv4()
+			if ip == nil {
+				t.Fatalf("routed packet is not IPv4: %v", pkt)
+			}
+			if ip.TTL != 64 {
+				t.Errorf("routed packet got TTL %d, want 64", ip.TTL)
+			}
+			// The one's complement sum of a header with a correct checksum is 0xffff.
+			var sum uint32
+			for i := 14; i < 14+int(ip.IHL)*4; i += 2 {
+				sum += uint32(binary.BigEndian.Uint16(data[i:]))
+			}
+			for sum > 0xffff {
+				sum = sum&0xffff + sum>>16
+			}
+			if sum != 0xffff {
+				t.Errorf("routed packet has incorrect IPv4 header checksum %#x", ip.Checksum)
+			}
+		})
+	}
+}
+
+func TestBridgePortLearning(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sinks := map[string]*packetutil.Sink{}
+	for _, lane := range []string{"1", "2", "3"} {
+		sinks[lane] = packetutil.NewSink(16)
+	}
+	fwdCtx.FakePortManager = lanePortManager{sinks: sinks}
+	fwdCtx.SetCPUPortSink(packetutil.NewSink(16).CPUPortSink, func() {})
+
+	if _, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	// Ports on lanes 1 and 3 are bridge ports, the port on lane 2 isn't.
+	bc := saipb.NewBridgeClient(conn)
+	var ports, bridgePorts []uint64
+	for i := uint32(1); i <= 3; i++ {
+		port := createTestPort(t, conn, i)
+		ports = append(ports, port)
+		if i == 2 {
+			continue
+		}
+		bp, err := bc.CreateBridgePort(ctx, &saipb.CreateBridgePortRequest{
+			Type:   saipb.BridgePortType_BRIDGE_PORT_TYPE_PORT.Enum(),
+			PortId: proto.Uint64(port),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		bridgePorts = append(bridgePorts, bp.GetOid())
+	}
+	if _, err := bc.CreateBridgePort(ctx, &saipb.CreateBridgePortRequest{
+		Type:   saipb.BridgePortType_BRIDGE_PORT_TYPE_PORT.Enum(),
+		PortId: proto.Uint64(ports[0]),
+	}); err == nil {
+		t.Errorf("CreateBridgePort() on port with a bridge port got nil error, want error")
+	}
+	if _, err := bc.CreateBridgePort(ctx, &saipb.CreateBridgePortRequest{
+		Type:   saipb.BridgePortType_BRIDGE_PORT_TYPE_SUB_PORT.Enum(),
+		PortId: proto.Uint64(ports[1]),
+	}); err == nil {
+		t.Errorf("CreateBridgePort() of sub-port without VLAN got nil error, want error")
+	}
+
+	macA := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x0a}
+	macB := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x0b}
+	macC := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x0c}
+	inject := func(port uint64, src, dst net.HardwareAddr) {
+		t.Helper()
+		buf := gopacket.NewSerializeBuffer()
+		if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+			&layers.Ethernet{
+				SrcMAC:       src,
+				DstMAC:       dst,
+				EthernetType: layers.EthernetType(0x88b5),
+			}, gopacket.Payload(make([]byte, 46))); err != nil {
+			t.Fatal(err)
+		}
+		err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(port)}},
+			fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, buf.Bytes(), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// MAC A is learned on the bridge port of lane 1, MAC B is received on a
+	// port without a bridge port so it must not be learned.
+	inject(ports[0], macA, macC)
+	inject(ports[1], macB, macC)
+
+	// Learning is asynchronous, retry until the frame is switched.
+	var switched bool
+	for i := 0; i < 10 && !switched; i++ {
+		inject(ports[2], macC, macA)
+		pkt, err := sinks["1"].Next(100 * time.Millisecond)
+		if err != nil {
+			continue
+		}
+		if got := pkt.Ethernet().DstMAC; !bytes.Equal(got, macA) {
+			t.Fatalf("switched frame got dst MAC %v, want %v", got, macA)
+		}
+		switched = true
+	}
+	if !switched {
+		t.Fatalf("frame to MAC %v not switched to the bridge port it was learned on", macA)
+	}
+
+	inject(ports[2], macC, macB)
+	for _, lane := range []string{"2", "3"} {
+		if pkt, err := sinks[lane].Next(100 * time.Millisecond); err == nil {
+			t.Errorf("lane %s: frame to MAC %v unexpectedly forwarded: %v", lane, macB, pkt)
+		}
+	}
+
+	// A static FDB entry pins its bridge port until it is removed.
+	fc := saipb.NewFdbClient(conn)
+	entry := &saipb.FdbEntry{SwitchId: switchID, MacAddress: macB}
+	if _, err := fc.CreateFdbEntry(ctx, &saipb.CreateFdbEntryRequest{
+		Entry:        entry,
+		Type:         saipb.FdbEntryType_FDB_ENTRY_TYPE_STATIC.Enum(),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_FORWARD.Enum(),
+		BridgePortId: proto.Uint64(bridgePorts[1]),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	inject(ports[0], macA, macB)
+	if _, err := sinks["3"].Next(time.Second); err != nil {
+		t.Errorf("frame to static FDB entry not forwarded: %v", err)
+	}
+	if _, err := bc.RemoveBridgePort(ctx, &saipb.RemoveBridgePortRequest{Oid: bridgePorts[1]}); err == nil {
+		t.Errorf("RemoveBridgePort() referenced by FDB entry got nil error, want error")
+	}
+	if _, err := fc.RemoveFdbEntry(ctx, &saipb.RemoveFdbEntryRequest{Entry: entry}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bc.RemoveBridgePort(ctx, &saipb.RemoveBridgePortRequest{Oid: bridgePorts[1]}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVRFRouting(t *testing.T) {
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sinks := map[string]*packetutil.Sink{}
+	for _, lane := range []string{"1", "2", "3", "4"} {
+		sinks[lane] = packetutil.NewSink(1)
+	}
+	fwdCtx.FakePortManager = lanePortManager{sinks: sinks}
+	fwdCtx.SetCPUPortSink(packetutil.NewSink(1).CPUPortSink, func() {})
+
+	sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	vrc := saipb.NewVirtualRouterClient(conn)
+	var vrfs []uint64
+	for i := 0; i < 2; i++ {
+		vr, err := vrc.CreateVirtualRouter(ctx, &saipb.CreateVirtualRouterRequest{Switch: sw.GetOid()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		vrfs = append(vrfs, vr.GetOid())
+	}
+	// The ports on lanes 1 and 3 are in the first VRF, the ports on lanes 2 and 4 in the second.
+	var ports, rifs []uint64
+	for i := uint32(1); i <= 4; i++ {
+		port := createTestPort(t, conn, i)
+		rif, err := saipb.NewRouterInterfaceClient(conn).CreateRouterInterface(ctx, &saipb.CreateRouterInterfaceRequest{
+			Type:            saipb.RouterInterfaceType_ROUTER_INTERFACE_TYPE_PORT.Enum(),
+			PortId:          proto.Uint64(port),
+			VirtualRouterId: proto.Uint64(vrfs[(i-1)%2]),
+			SrcMacAddress:   []byte{0x02, 0x00, 0x00, 0x00, 0x00, byte(i)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ports = append(ports, port)
+		rifs = append(rifs, rif.GetOid())
+	}
+	// In both VRFs, packets to 198.51.100.0/24 are routed out of the VRF's interface on lane 3 or 4.
+	for i, vrf := range vrfs {
+		rif := rifs[i+2]
+		nhIP := []byte{192, 0, 2, byte(i + 3)}
+		if _, err := saipb.NewNeighborClient(conn).CreateNeighborEntry(ctx, &saipb.CreateNeighborEntryRequest{
+			Entry:         &saipb.NeighborEntry{SwitchId: sw.GetOid(), RifId: rif, IpAddress: nhIP},
+			DstMacAddress: []byte{0x02, 0x00, 0x00, 0x00, 0x01, byte(i + 3)},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		nh, err := saipb.NewNextHopClient(conn).CreateNextHop(ctx, &saipb.CreateNextHopRequest{
+			Switch:            sw.GetOid(),
+			Type:              saipb.NextHopType_NEXT_HOP_TYPE_IP.Enum(),
+			Ip:                nhIP,
+			RouterInterfaceId: proto.Uint64(rif),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := saipb.NewRouteClient(conn).CreateRouteEntry(ctx, &saipb.CreateRouteEntryRequest{
+			Entry: &saipb.RouteEntry{
+				SwitchId:    sw.GetOid(),
+				VrId:        vrf,
+				Destination: &saipb.IpPrefix{Addr: []byte{198, 51, 100, 0}, Mask: []byte{255, 255, 255, 0}},
+			},
+			NextHopId:    proto.Uint64(nh.GetOid()),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_FORWARD.Enum(),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		desc     string
+		inPort   int
+		wantLane string
+	}{{
+		desc:     "first vrf",
+		inPort:   0,
+		wantLane: "3",
+	}, {
+		desc:     "second vrf",
+		inPort:   1,
+		wantLane: "4",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf := gopacket.NewSerializeBuffer()
+			if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true},
+				&layers.Ethernet{
+					SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x01, 0x01},
+					DstMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, byte(tt.inPort + 1)},
+					EthernetType: layers.EthernetTypeIPv4,
+				}, &layers.IPv4{
+					Version:  4,
+					TTL:      64,
+					Protocol: layers.IPProtocolUDP,
+					SrcIP:    net.IPv4(192, 0, 2, 100).To4(),
+					DstIP:    net.IPv4(198, 51, 100, 1).To4(),
+				}, gopacket.Payload("data")); err != nil {
+				t.Fatal(err)
+			}
+			err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(ports[tt.inPort])}},
+				fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, buf.Bytes(), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := sinks[tt.wantLane].Next(time.Second); err != nil {
+				t.Fatalf("lane %s: %v", tt.wantLane, err)
+			}
+			for lane, sink := range sinks {
+				if pkt, err := sink.Next(10 * time.Millisecond); err == nil {
+					t.Errorf("lane %s: packet unexpectedly routed: %v", lane, pkt)
+				}
+			}
+		})
+	}
+
+	if _, err := vrc.RemoveVirtualRouter(ctx, &saipb.RemoveVirtualRouterRequest{Oid: vrfs[0]}); err == nil {
+		t.Errorf("RemoveVirtualRouter() with router interfaces got nil error, want error")
+	}
+}
+
+func TestGleanTrap(t *testing.T) {
+	const (
+		burst = 10
+		// The policer's burst only fits 3 frames and its rate is too low to refill during the test.
+		wantPunts = 3
+	)
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sinks := map[string]*packetutil.Sink{}
+	for _, lane := range []string{"1", "2"} {
+		sinks[lane] = packetutil.NewSink(burst)
+	}
+	cpuSink := packetutil.NewSink(burst)
+	fwdCtx.FakePortManager = lanePortManager{sinks: sinks}
+	fwdCtx.SetCPUPortSink(cpuSink.CPUPortSink, func() {})
+
+	sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	vr, err := saipb.NewVirtualRouterClient(conn).CreateVirtualRouter(ctx, &saipb.CreateVirtualRouterRequest{Switch: sw.GetOid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ports, rifs []uint64
+	for i := uint32(1); i <= 2; i++ {
+		port := createTestPort(t, conn, i)
+		rif, err := saipb.NewRouterInterfaceClient(conn).CreateRouterInterface(ctx, &saipb.CreateRouterInterfaceRequest{
+			Type:            saipb.RouterInterfaceType_ROUTER_INTERFACE_TYPE_PORT.Enum(),
+			PortId:          proto.Uint64(port),
+			VirtualRouterId: proto.Uint64(vr.GetOid()),
+			SrcMacAddress:   []byte{0x02, 0x00, 0x00, 0x00, 0x00, byte(i)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ports = append(ports, port)
+		rifs = append(rifs, rif.GetOid())
+	}
+	// Packets to 198.51.100.0/24 are routed to 192.0.2.2 out of lane 2, which has no neighbor entry yet.
+	nhIP := []byte{192, 0, 2, 2}
+	nh, err := saipb.NewNextHopClient(conn).CreateNextHop(ctx, &saipb.CreateNextHopRequest{
+		Switch:            sw.GetOid(),
+		Type:              saipb.NextHopType_NEXT_HOP_TYPE_IP.Enum(),
+		Ip:                nhIP,
+		RouterInterfaceId: proto.Uint64(rifs[1]),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := saipb.NewRouteClient(conn).CreateRouteEntry(ctx, &saipb.CreateRouteEntryRequest{
+		Entry: &saipb.RouteEntry{
+			SwitchId:    sw.GetOid(),
+			VrId:        vr.GetOid(),
+			Destination: &saipb.IpPrefix{Addr: []byte{198, 51, 100, 0}, Mask: []byte{255, 255, 255, 0}},
+		},
+		NextHopId:    proto.Uint64(nh.GetOid()),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_FORWARD.Enum(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true},
+		&layers.Ethernet{
+			SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x01, 0x01},
+			DstMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+			EthernetType: layers.EthernetTypeIPv4,
+		}, &layers.IPv4{
+			Version:  4,
+			TTL:      64,
+			Protocol: layers.IPProtocolUDP,
+			SrcIP:    net.IPv4(192, 0, 2, 100).To4(),
+			DstIP:    net.IPv4(198, 51, 100, 1).To4(),
+		}, gopacket.Payload("data")); err != nil {
+		t.Fatal(err)
+	}
+	frame := buf.Bytes()
+	// Each packet gets its own copy of the frame, since the dataplane processes it after InjectPacket returns.
+	send := func(n int) {
+		t.Helper()
+		for i := 0; i < n; i++ {
+			err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(ports[0])}},
+				fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, bytes.Clone(frame), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// Without a glean trap, packets to the unresolved next hop are dropped.
+	send(1)
+	if pkt, err := cpuSink.Next(100 * time.Millisecond); err == nil {
+		t.Errorf("packet punted without a glean trap: %v", pkt)
+	}
+
+	policer, err := saipb.NewPolicerClient(conn).CreatePolicer(ctx, &saipb.CreatePolicerRequest{
+		MeterType: saipb.MeterType_METER_TYPE_BYTES.Enum(),
+		Mode:      saipb.PolicerMode_POLICER_MODE_SR_TCM.Enum(),
+		Cbs:       proto.Uint64(uint64(wantPunts * len(frame))),
+		Cir:       proto.Uint64(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc := saipb.NewHostifClient(conn)
+	trapGroup, err := hc.CreateHostifTrapGroup(ctx, &saipb.CreateHostifTrapGroupRequest{
+		Policer: proto.Uint64(policer.GetOid()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+		TrapType:     gleanTrapType.Enum(),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_COPY.Enum(),
+		TrapGroup:    proto.Uint64(trapGroup.GetOid()),
+	}); err == nil {
+		t.Errorf("CreateHostifTrap() with COPY glean trap got nil error, want error")
+	}
+	if _, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+		TrapType:     gleanTrapType.Enum(),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+		TrapGroup:    proto.Uint64(trapGroup.GetOid()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the packets within the policer's burst are punted, the rest are dropped.
+	send(burst)
+	for i := 0; i < wantPunts; i++ {
+		pkt, err := cpuSink.Next(time.Second)
+		if err != nil {
+			t.Fatalf("got %d glean punts, want %d: %v", i, wantPunts, err)
+		}
+		if pkt.IPv4() == nil || !pkt.IPv4().DstIP.Equal(net.IPv4(198, 51, 100, 1)) {
+			t.Errorf("glean punt is not the routed packet: %v", pkt)
+		}
+	}
+	if pkt, err := cpuSink.Next(100 * time.Millisecond); err == nil {
+		t.Errorf("glean punt above the policer's rate: %v", pkt)
+	}
+	if pkt, err := sinks["2"].Next(10 * time.Millisecond); err == nil {
+		t.Errorf("packet to unresolved next hop forwarded: %v", pkt)
+	}
+
+	// Once the neighbor is resolved, packets are forwarded instead of punted.
+	dstMAC := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x01, 0x02}
+	if _, err := saipb.NewNeighborClient(conn).CreateNeighborEntry(ctx, &saipb.CreateNeighborEntryRequest{
+		Entry:         &saipb.NeighborEntry{SwitchId: sw.GetOid(), RifId: rifs[1], IpAddress: nhIP},
+		DstMacAddress: dstMAC,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	send(1)
+	pkt, err := sinks["2"].Next(time.Second)
+	if err != nil {
+		t.Fatalf("packet to resolved next hop not forwarded: %v", err)
+	}
+	if got := pkt.Ethernet().DstMAC; !bytes.Equal(got, dstMAC) {
+		t.Errorf("forwarded packet dst MAC got %v, want %v", got, dstMAC)
+	}
+	if pkt, err := cpuSink.Next(100 * time.Millisecond); err == nil {
+		t.Errorf("packet to resolved next hop punted: %v", pkt)
+	}
+}
+
+func TestECMPFlowLabelHash(t *testing.T) {
+	const (
+		members = 4
+		flows   = 32
+	)
+	ctx := context.Background()
+	s, conn, fwdCtx := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sinks := map[string]*packetutil.Sink{}
+	for i := 1; i <= members+1; i++ {
+		sinks[fmt.Sprint(i)] = packetutil.NewSink(flows)
+	}
+	fwdCtx.FakePortManager = lanePortManager{sinks: sinks}
+	fwdCtx.SetCPUPortSink(packetutil.NewSink(1).CPUPortSink, func() {})
+
+	sw, err := saipb.NewSwitchClient(conn).CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acceptAnyDstMAC(t, conn)
+	vr, err := saipb.NewVirtualRouterClient(conn).CreateVirtualRouter(ctx, &saipb.CreateVirtualRouterRequest{Switch: sw.GetOid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc := saipb.NewHashClient(conn)
+	hash, err := hc.CreateHash(ctx, &saipb.CreateHashRequest{
+		NativeHashFieldList: []saipb.NativeHashField{
+			saipb.NativeHashField_NATIVE_HASH_FIELD_SRC_IP,
+			saipb.NativeHashField_NATIVE_HASH_FIELD_DST_IP,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := saipb.NewSwitchClient(conn).SetSwitchAttribute(ctx, &saipb.SetSwitchAttributeRequest{
+		Oid:          sw.GetOid(),
+		EcmpHashIpv6: proto.Uint64(hash.GetOid()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	group, err := saipb.NewNextHopGroupClient(conn).CreateNextHopGroup(ctx, &saipb.CreateNextHopGroupRequest{
+		Type: saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_DYNAMIC_UNORDERED_ECMP.Enum(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Packets are received on lane 1, and routed to one of the group's members on lanes 2 to 5.
+	var inPort uint64
+	for i := 1; i <= members+1; i++ {
+		port := createTestPort(t, conn, uint32(i))
+		rif, err := saipb.NewRouterInterfaceClient(conn).CreateRouterInterface(ctx, &saipb.CreateRouterInterfaceRequest{
+			Type:            saipb.RouterInterfaceType_ROUTER_INTERFACE_TYPE_PORT.Enum(),
+			PortId:          proto.Uint64(port),
+			VirtualRouterId: proto.Uint64(vr.GetOid()),
+			SrcMacAddress:   []byte{0x02, 0x00, 0x00, 0x00, 0x00, byte(i)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 1 {
+			inPort = port
+			continue
+		}
+		nhIP := net.ParseIP(fmt.Sprintf("2001:db8::%d", i))
+		if _, err := saipb.NewNeighborClient(conn).CreateNeighborEntry(ctx, &saipb.CreateNeighborEntryRequest{
+			Entry:         &saipb.NeighborEntry{SwitchId: sw.GetOid(), RifId: rif.GetOid(), IpAddress: nhIP},
+			DstMacAddress: []byte{0x02, 0x00, 0x00, 0x00, 0x01, byte(i)},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		nh, err := saipb.NewNextHopClient(conn).CreateNextHop(ctx, &saipb.CreateNextHopRequest{
+			Switch:            sw.GetOid(),
+			Type:              saipb.NextHopType_NEXT_HOP_TYPE_IP.Enum(),
+			Ip:                nhIP,
+			RouterInterfaceId: proto.Uint64(rif.GetOid()),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := saipb.NewNextHopGroupClient(conn).CreateNextHopGroupMember(ctx, &saipb.CreateNextHopGroupMemberRequest{
+			NextHopGroupId: proto.Uint64(group.GetOid()),
+			NextHopId:      proto.Uint64(nh.GetOid()),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := saipb.NewRouteClient(conn).CreateRouteEntry(ctx, &saipb.CreateRouteEntryRequest{
+		Entry: &saipb.RouteEntry{
+			SwitchId:    sw.GetOid(),
+			VrId:        vr.GetOid(),
+			Destination: &saipb.IpPrefix{Addr: net.ParseIP("2001:db8:1::"), Mask: net.CIDRMask(64, 128)},
+		},
+		NextHopId:    proto.Uint64(group.GetOid()),
+		PacketAction: saipb.PacketAction_PACKET_ACTION_FORWARD.Enum(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// usedMembers sends flows that differ only in their flow label, and returns the number of members they're routed to.
+	usedMembers := func(t *testing.T) int {
+		t.Helper()
+		for label := uint32(1); label <= flows; label++ {
+			buf := gopacket.NewSerializeBuffer()
+			if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+				&layers.Ethernet{
+					SrcMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x01, 0x01},
+					DstMAC:       net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+					EthernetType: layers.EthernetTypeIPv6,
+				}, &layers.IPv6{
+					Version:    6,
+					FlowLabel:  label,
+					HopLimit:   64,
+					NextHeader: layers.IPProtocolNoNextHeader,
+					SrcIP:      net.ParseIP("2001:db8::100"),
+					DstIP:      net.ParseIP("2001:db8:1::1"),
+				}, gopacket.Payload("data")); err != nil {
+				t.Fatal(err)
+			}
+			err := s.InjectPacket(&fwdpb.ContextId{Id: s.ID()}, &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(inPort)}},
+				fwdpb.PacketHeaderId_PACKET_HEADER_ID_ETHERNET, buf.Bytes(), nil, false, fwdpb.PortAction_PORT_ACTION_INPUT)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		var received, used int
+		for i := 2; i <= members+1; i++ {
+			n := 0
+			for ; ; n++ {
+				if _, err := sinks[fmt.Sprint(i)].Next(50 * time.Millisecond); err != nil {
+					break
+				}
+			}
+			received += n
+			if n > 0 {
+				used++
+			}
+		}
+		if received != flows {
+			t.Fatalf("got %d routed packets, want %d", received, flows)
+		}
+		return used
+	}
+
+	if got := usedMembers(t); got != 1 {
+		t.Errorf("without the flow label in the hash, flows routed to %d members, want 1", got)
+	}
+	if _, err := hc.SetHashAttribute(ctx, &saipb.SetHashAttributeRequest{
+		Oid: hash.GetOid(),
+		NativeHashFieldList: []saipb.NativeHashField{
+			saipb.NativeHashField_NATIVE_HASH_FIELD_SRC_IP,
+			saipb.NativeHashField_NATIVE_HASH_FIELD_DST_IP,
+			saipb.NativeHashField_NATIVE_HASH_FIELD_IPV6_FLOW_LABEL,
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := usedMembers(t); got < 2 {
+		t.Errorf("with the flow label in the hash, flows routed to %d members, want at least 2", got)
+	}
+	if _, err := hc.SetHashAttribute(ctx, &saipb.SetHashAttributeRequest{
+		Oid:                 hash.GetOid(),
+		NativeHashFieldList: []saipb.NativeHashField{saipb.NativeHashField_NATIVE_HASH_FIELD_UNSPECIFIED},
+	}); err == nil {
+		t.Errorf("SetHashAttribute() with unknown field got nil error, want error")
+	}
+}
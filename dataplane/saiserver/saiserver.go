@@ -23,9 +23,13 @@ import (
 	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	log "github.com/golang/glog"
 
+	diagpb "github.com/openconfig/lemming/dataplane/proto/diag"
 	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
 	fwdpb "github.com/openconfig/lemming/proto/forwarding"
 )
@@ -50,26 +54,10 @@ type dtel struct {
 	saipb.UnimplementedDtelServer
 }
 
-type fdb struct {
-	saipb.UnimplementedFdbServer
-}
-
-type ipmcGroup struct {
-	saipb.UnimplementedIpmcGroupServer
-}
-
-type ipmc struct {
-	saipb.UnimplementedIpmcServer
-}
-
 type ipsec struct {
 	saipb.UnimplementedIpsecServer
 }
 
-type l2mcGroup struct {
-	saipb.UnimplementedL2McGroupServer
-}
-
 type l2mc struct {
 	saipb.UnimplementedL2McServer
 }
@@ -98,26 +86,10 @@ type qosMap struct {
 	saipb.UnimplementedQosMapServer
 }
 
-type queue struct {
-	saipb.UnimplementedQueueServer
-}
-
-type rpfGroup struct {
-	saipb.UnimplementedRpfGroupServer
-}
-
 type samplePacket struct {
 	saipb.UnimplementedSamplepacketServer
 }
 
-type schedulerGroup struct {
-	saipb.UnimplementedSchedulerGroupServer
-}
-
-type scheduler struct {
-	saipb.UnimplementedSchedulerServer
-}
-
 type srv6 struct {
 	saipb.UnimplementedSrv6Server
 }
@@ -138,10 +110,9 @@ type udf struct {
 	saipb.UnimplementedUdfServer
 }
 
-type virtualRouter struct {
-	saipb.UnimplementedVirtualRouterServer
-}
-
+// TODO: Support WRED profiles and ECN marking. Ports transmit packets as soon
+// as they are processed, so there is no congestion to drop or mark packets on
+// yet, and the per-queue WRED stats are not supported.
 type wred struct {
 	saipb.UnimplementedWredServer
 }
@@ -157,37 +128,30 @@ func (fc *forwardingContext) ID() string {
 
 type Server struct {
 	saipb.UnimplementedEntrypointServer
+	diagpb.UnimplementedDiagServer
 	*forwardingContext
-	mgr            *attrmgr.AttrMgr
-	initialized    bool
-	bfd            *bfd
-	buffer         *buffer
-	counter        *counter
-	debugCounter   *debugCounter
-	dtel           *dtel
-	fdb            *fdb
-	ipmcGroup      *ipmcGroup
-	ipmc           *ipmc
-	ipsec          *ipsec
-	l2mcGroup      *l2mcGroup
-	l2mc           *l2mc
-	macsec         *macsec
-	mcastFdb       *mcastFdb
-	mirror         *mirror
-	mpls           *mpls
-	nat            *nat
-	qosMap         *qosMap
-	queue          *queue
-	rpfGroup       *rpfGroup
-	samplePacket   *samplePacket
-	schedulerGroup *schedulerGroup
-	scheduler      *scheduler
-	srv6           *srv6
-	saiSwitch      *saiSwitch
-	systemPort     *systemPort
-	tam            *tam
-	udf            *udf
-	wred           *wred
+	mgr          *attrmgr.AttrMgr
+	initialized  bool
+	bfd          *bfd
+	buffer       *buffer
+	counter      *counter
+	debugCounter *debugCounter
+	dtel         *dtel
+	ipsec        *ipsec
+	l2mc         *l2mc
+	macsec       *macsec
+	mcastFdb     *mcastFdb
+	mirror       *mirror
+	mpls         *mpls
+	nat          *nat
+	qosMap       *qosMap
+	samplePacket *samplePacket
+	srv6         *srv6
+	saiSwitch    *saiSwitch
+	systemPort   *systemPort
+	tam          *tam
+	udf          *udf
+	wred         *wred
 }
 
 func (s *Server) ObjectTypeQuery(_ context.Context, req *saipb.ObjectTypeQueryRequest) (*saipb.ObjectTypeQueryResponse, error) {
@@ -200,6 +164,127 @@ func (s *Server) ObjectTypeQuery(_ context.Context, req *saipb.ObjectTypeQueryRe
 	}, nil
 }
 
+// ExcludeHostifTrapSource stops the trap from matching packets with a source address in src.
+// Instead of being trapped, these packets are forwarded or dropped depending on action.
+// Excluded packets aren't matched by other traps either, since only one trap table entry applies to a packet.
+// Exclusions are removed along with the trap. IP2ME traps, which are applied by routes, can't exclude sources.
+func (s *Server) ExcludeHostifTrapSource(ctx context.Context, req *diagpb.ExcludeHostifTrapSourceRequest) (*diagpb.ExcludeHostifTrapSourceResponse, error) {
+	if err := s.saiSwitch.hostif.excludeTrapSource(ctx, req.GetOid(), req.GetSrc(), req.GetPacketAction()); err != nil {
+		return nil, err
+	}
+	return &diagpb.ExcludeHostifTrapSourceResponse{}, nil
+}
+
+// GetHostifStats returns the packet and byte counters of the hostif.
+// The counters of remote hostifs are the packets sent and received on the CPU packet stream.
+func (s *Server) GetHostifStats(ctx context.Context, req *diagpb.GetHostifStatsRequest) (*diagpb.GetHostifStatsResponse, error) {
+	values, err := s.saiSwitch.hostif.hostifStats(ctx, req.GetOid(), req.GetCounterIds())
+	if err != nil {
+		return nil, err
+	}
+	return &diagpb.GetHostifStatsResponse{Values: values}, nil
+}
+
+// GetHostifTrapStats returns the number of packets and bytes matched by the trap.
+// IP2ME traps are applied by routes and aren't counted.
+func (s *Server) GetHostifTrapStats(ctx context.Context, req *diagpb.GetHostifTrapStatsRequest) (*diagpb.GetHostifTrapStatsResponse, error) {
+	stats, err := s.saiSwitch.hostif.trapStats(ctx, req.GetOid())
+	if err != nil {
+		return nil, err
+	}
+	return &diagpb.GetHostifTrapStatsResponse{Stats: stats}, nil
+}
+
+// GetHostifTrapGroupStats returns the number of packets and bytes matched by all the traps in the trap group.
+func (s *Server) GetHostifTrapGroupStats(ctx context.Context, req *diagpb.GetHostifTrapGroupStatsRequest) (*diagpb.GetHostifTrapGroupStatsResponse, error) {
+	stats, err := s.saiSwitch.hostif.trapGroupStats(ctx, req.GetOid())
+	if err != nil {
+		return nil, err
+	}
+	return &diagpb.GetHostifTrapGroupStatsResponse{Stats: stats}, nil
+}
+
+// GetCPUPuntStats returns the aggregate number of packets punted to the CPU port by all traps,
+// and the number dropped by the policers and the CPU port before they are delivered.
+func (s *Server) GetCPUPuntStats(ctx context.Context, _ *diagpb.GetCPUPuntStatsRequest) (*diagpb.GetCPUPuntStatsResponse, error) {
+	return s.saiSwitch.hostif.cpuPuntStats(ctx)
+}
+
+// LookupHostifTableEntry returns the hostif the packets of the trap ID are delivered to, as set by the hostif table entry for it.
+// It returns a NotFound error if there is no entry for the trap ID.
+func (s *Server) LookupHostifTableEntry(_ context.Context, req *diagpb.LookupHostifTableEntryRequest) (*diagpb.LookupHostifTableEntryResponse, error) {
+	return s.saiSwitch.hostif.trapHostif(req.GetTrapId())
+}
+
+// GetGenetlinkHostifIds returns the family and multicast group IDs the remote agent resolved for a genetlink hostif,
+// which it needs to match the messages it receives. It returns a NotFound error if the hostif isn't a remote genetlink hostif.
+func (s *Server) GetGenetlinkHostifIds(_ context.Context, req *diagpb.GetGenetlinkHostifIdsRequest) (*diagpb.GetGenetlinkHostifIdsResponse, error) {
+	ids, err := s.saiSwitch.hostif.genetlinkHostifIDs(req.GetOid())
+	if err != nil {
+		return nil, err
+	}
+	return &diagpb.GetGenetlinkHostifIdsResponse{Ids: ids}, nil
+}
+
+// ListHostifStreams returns the CPU packet and host port control streams whose handlers haven't returned yet.
+// A stream that stays listed after its context is done has leaked goroutines.
+func (s *Server) ListHostifStreams(context.Context, *diagpb.ListHostifStreamsRequest) (*diagpb.ListHostifStreamsResponse, error) {
+	return &diagpb.ListHostifStreamsResponse{Streams: s.saiSwitch.hostif.activeStreams()}, nil
+}
+
+// GetHostifActions returns the input actions installed on the port of a local hostif.
+// It returns a NotFound error if the hostif isn't a local hostif.
+func (s *Server) GetHostifActions(_ context.Context, req *diagpb.GetHostifActionsRequest) (*diagpb.GetHostifActionsResponse, error) {
+	return s.saiSwitch.hostif.hostifActions(req.GetOid())
+}
+
+// HostifFrames returns the channel that the frames sent to a file descriptor hostif are written to,
+// frames are dropped while the channel is full. It returns a NotFound error if the hostif doesn't exist.
+func (s *Server) HostifFrames(oid uint64) (<-chan []byte, error) {
+	return s.saiSwitch.hostif.fdHostifFrames(oid)
+}
+
+// RemoveAll removes all objects of the type and their dataplane entries.
+// Supported types: HOSTIF_TRAP, NEXT_HOP, ROUTE_ENTRY, NEIGHBOR_ENTRY.
+func (s *Server) RemoveAll(ctx context.Context, req *diagpb.RemoveAllRequest) (*diagpb.RemoveAllResponse, error) {
+	if err := s.saiSwitch.removeAll(ctx, req.GetType()); err != nil {
+		return nil, err
+	}
+	return &diagpb.RemoveAllResponse{}, nil
+}
+
+// SetAttributes applies a batch of Set*Attribute requests, for any number of objects, in a single call.
+// Requests for the same object are merged and applied together by the object's handler, and share its status.
+// The object's attributes are stored only if the handler accepts all of them, but dataplane changes the handler
+// made before failing aren't rolled back. A failure on one object doesn't stop the rest of the batch.
+// Supported requests: SetHostifAttributeRequest, SetHostifTrapAttributeRequest, SetHostifTrapGroupAttributeRequest,
+// SetPortAttributeRequest, SetRouterInterfaceAttributeRequest, SetSwitchAttributeRequest.
+func (s *Server) SetAttributes(ctx context.Context, req *saipb.SetAttributesRequest) (*saipb.SetAttributesResponse, error) {
+	reqs := make([]proto.Message, len(req.GetReqs()))
+	for i, a := range req.GetReqs() {
+		msg, err := a.UnmarshalNew()
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "request %d: %v", i, err)
+		}
+		reqs[i] = msg
+	}
+	resp := &saipb.SetAttributesResponse{}
+	for _, err := range s.saiSwitch.setAttributes(ctx, reqs) {
+		st := status.New(codes.OK, "")
+		if err != nil {
+			st = status.Convert(err)
+		}
+		resp.Statuses = append(resp.Statuses, st.Proto())
+	}
+	return resp, nil
+}
+
+// LookupRoute resolves the next hop, egress port, and rewrite MAC for the destination without sending a packet.
+// It returns a NotFound error if the destination is unreachable.
+func (s *Server) LookupRoute(_ context.Context, req *diagpb.LookupRouteRequest) (*diagpb.LookupRouteResponse, error) {
+	return s.saiSwitch.lookupRoute(req.GetVrf(), req.GetDst())
+}
+
 func (s *Server) Initialize(ctx context.Context, _ *saipb.InitializeRequest) (*saipb.InitializeResponse, error) {
 	if s.initialized {
 		log.Info("dataplane already intialized, reseting")
@@ -233,14 +318,22 @@ func (s *Server) Reset(ctx context.Context) error {
 }
 
 func New(ctx context.Context, mgr *attrmgr.AttrMgr, s *grpc.Server, opts *dplaneopts.Options) (*Server, error) {
-	fwdCtx := &forwardingContext{Server: forwarding.New("engine"), id: "lucius"}
+	id := opts.ContextID
+	if id == "" {
+		id = dplaneopts.DefaultContextID
+	}
+	fwdCtx := &forwardingContext{Server: forwarding.New("engine"), id: id}
 	_, err := fwdCtx.ContextCreate(ctx, &fwdpb.ContextCreateRequest{
 		ContextId: &fwdpb.ContextId{Id: fwdCtx.id},
 	})
 	if err != nil {
 		return nil, err
 	}
-	sw, err := newSwitch(mgr, fwdCtx, s, opts)
+	var engine switchDataplaneAPI = fwdCtx
+	if opts.ProgrammingDelay > 0 {
+		engine = newDelayedDataplane(ctx, fwdCtx, opts.ProgrammingDelay)
+	}
+	sw, err := newSwitch(mgr, engine, s, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -253,11 +346,7 @@ func New(ctx context.Context, mgr *attrmgr.AttrMgr, s *grpc.Server, opts *dplane
 		counter:           &counter{},
 		debugCounter:      &debugCounter{},
 		dtel:              &dtel{},
-		fdb:               &fdb{},
-		ipmcGroup:         &ipmcGroup{},
-		ipmc:              &ipmc{},
 		ipsec:             &ipsec{},
-		l2mcGroup:         &l2mcGroup{},
 		l2mc:              &l2mc{},
 		macsec:            &macsec{},
 		mcastFdb:          &mcastFdb{},
@@ -265,11 +354,7 @@ func New(ctx context.Context, mgr *attrmgr.AttrMgr, s *grpc.Server, opts *dplane
 		mpls:              &mpls{},
 		nat:               &nat{},
 		qosMap:            &qosMap{},
-		queue:             &queue{},
-		rpfGroup:          &rpfGroup{},
 		samplePacket:      &samplePacket{},
-		schedulerGroup:    &schedulerGroup{},
-		scheduler:         &scheduler{},
 		srv6:              &srv6{},
 		saiSwitch:         sw,
 		systemPort:        &systemPort{},
@@ -277,18 +362,16 @@ func New(ctx context.Context, mgr *attrmgr.AttrMgr, s *grpc.Server, opts *dplane
 		udf:               &udf{},
 		wred:              &wred{},
 	}
+	sw.resetDataplane = srv.Reset
 	fwdpb.RegisterForwardingServer(s, fwdCtx)
 	fwdpb.RegisterInfoServer(s, fwdCtx)
 	saipb.RegisterEntrypointServer(s, srv)
+	diagpb.RegisterDiagServer(s, srv)
 	saipb.RegisterBfdServer(s, srv.bfd)
 	saipb.RegisterCounterServer(s, srv.counter)
 	saipb.RegisterDebugCounterServer(s, srv.debugCounter)
 	saipb.RegisterDtelServer(s, srv.dtel)
-	saipb.RegisterFdbServer(s, srv.fdb)
-	saipb.RegisterIpmcGroupServer(s, srv.ipmcGroup)
-	saipb.RegisterIpmcServer(s, srv.ipmc)
 	saipb.RegisterIpsecServer(s, srv.ipsec)
-	saipb.RegisterL2McGroupServer(s, srv.l2mcGroup)
 	saipb.RegisterL2McServer(s, srv.l2mc)
 	saipb.RegisterMacsecServer(s, srv.macsec)
 	saipb.RegisterMcastFdbServer(s, srv.mcastFdb)
@@ -296,11 +379,7 @@ func New(ctx context.Context, mgr *attrmgr.AttrMgr, s *grpc.Server, opts *dplane
 	saipb.RegisterMplsServer(s, srv.mpls)
 	saipb.RegisterNatServer(s, srv.nat)
 	saipb.RegisterQosMapServer(s, srv.qosMap)
-	saipb.RegisterQueueServer(s, srv.queue)
-	saipb.RegisterRpfGroupServer(s, srv.rpfGroup)
 	saipb.RegisterSamplepacketServer(s, srv.samplePacket)
-	saipb.RegisterSchedulerGroupServer(s, srv.schedulerGroup)
-	saipb.RegisterSchedulerServer(s, srv.scheduler)
 	saipb.RegisterSrv6Server(s, srv.srv6)
 	saipb.RegisterSystemPortServer(s, srv.systemPort)
 	saipb.RegisterTamServer(s, srv.tam)
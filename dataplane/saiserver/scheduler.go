@@ -0,0 +1,457 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saiserver
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
+
+	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
+)
+
+// maxSchedulingWeight is the largest weight of a WRR or DWRR scheduler.
+const maxSchedulingWeight = 100
+
+// scheduler serves scheduler profiles, which set how a port, scheduler group or queue is scheduled and shaped.
+type scheduler struct {
+	saipb.UnimplementedSchedulerServer
+	mgr *attrmgr.AttrMgr
+}
+
+func newScheduler(mgr *attrmgr.AttrMgr, s *grpc.Server) *scheduler {
+	sched := &scheduler{
+		mgr: mgr,
+	}
+	saipb.RegisterSchedulerServer(s, sched)
+	return sched
+}
+
+// CreateScheduler creates a scheduler profile.
+func (sched *scheduler) CreateScheduler(_ context.Context, req *saipb.CreateSchedulerRequest) (*saipb.CreateSchedulerResponse, error) {
+	attr := &saipb.SchedulerAttribute{
+		SchedulingType:   req.SchedulingType,
+		SchedulingWeight: req.SchedulingWeight,
+		MeterType:        req.MeterType,
+		MinBandwidthRate: req.MinBandwidthRate,
+		MaxBandwidthRate: req.MaxBandwidthRate,
+	}
+	if err := validateScheduler(attr); err != nil {
+		return nil, err
+	}
+	return &saipb.CreateSchedulerResponse{Oid: sched.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_SCHEDULER)}, nil
+}
+
+// SetSchedulerAttribute updates a scheduler profile, the nodes using it are scheduled by the updated profile.
+func (sched *scheduler) SetSchedulerAttribute(_ context.Context, req *saipb.SetSchedulerAttributeRequest) (*saipb.SetSchedulerAttributeResponse, error) {
+	attr, err := schedulerProfile(sched.mgr, req.GetOid())
+	if err != nil {
+		return nil, err
+	}
+	if req.SchedulingType != nil {
+		attr.SchedulingType = req.SchedulingType
+	}
+	if req.SchedulingWeight != nil {
+		attr.SchedulingWeight = req.SchedulingWeight
+	}
+	if req.MeterType != nil {
+		attr.MeterType = req.MeterType
+	}
+	if req.MinBandwidthRate != nil {
+		attr.MinBandwidthRate = req.MinBandwidthRate
+	}
+	if req.MaxBandwidthRate != nil {
+		attr.MaxBandwidthRate = req.MaxBandwidthRate
+	}
+	if err := validateScheduler(attr); err != nil {
+		return nil, err
+	}
+	return &saipb.SetSchedulerAttributeResponse{}, nil
+}
+
+// validateScheduler returns an error if the scheduler profile is not supported.
+// TODO: Support packet meters and minimum bandwidth guarantees.
+func validateScheduler(attr *saipb.SchedulerAttribute) error {
+	switch attr.GetSchedulingType() {
+	case saipb.SchedulingType_SCHEDULING_TYPE_UNSPECIFIED, saipb.SchedulingType_SCHEDULING_TYPE_STRICT,
+		saipb.SchedulingType_SCHEDULING_TYPE_WRR, saipb.SchedulingType_SCHEDULING_TYPE_DWRR:
+	default:
+		return status.Errorf(codes.InvalidArgument, "unsupported scheduling type: %v", attr.GetSchedulingType())
+	}
+	if attr.SchedulingWeight != nil && (attr.GetSchedulingWeight() == 0 || attr.GetSchedulingWeight() > maxSchedulingWeight) {
+		return status.Errorf(codes.InvalidArgument, "scheduling weight %d not in range [1, %d]", attr.GetSchedulingWeight(), maxSchedulingWeight)
+	}
+	switch attr.GetMeterType() {
+	case saipb.MeterType_METER_TYPE_UNSPECIFIED, saipb.MeterType_METER_TYPE_BYTES:
+	default:
+		return status.Errorf(codes.InvalidArgument, "unsupported meter type: %v", attr.GetMeterType())
+	}
+	if attr.GetMinBandwidthRate() != 0 {
+		return status.Errorf(codes.InvalidArgument, "unsupported min bandwidth rate: %d", attr.GetMinBandwidthRate())
+	}
+	return nil
+}
+
+// schedulerProfile returns the attributes of a scheduler profile.
+func schedulerProfile(mgr *attrmgr.AttrMgr, id uint64) (*saipb.SchedulerAttribute, error) {
+	if mgr.GetType(fmt.Sprint(id)) != saipb.ObjectType_OBJECT_TYPE_SCHEDULER {
+		return nil, status.Errorf(codes.NotFound, "unknown scheduler: %d", id)
+	}
+	attr := &saipb.SchedulerAttribute{}
+	if err := mgr.PopulateAllAttributes(fmt.Sprint(id), attr); err != nil {
+		return nil, err
+	}
+	return attr, nil
+}
+
+// schedulerGroup serves scheduler groups. The scheduler groups of a port form a tree rooted at the port,
+// the queues of the port are its leaves.
+type schedulerGroup struct {
+	saipb.UnimplementedSchedulerGroupServer
+	mgr *attrmgr.AttrMgr
+}
+
+func newSchedulerGroup(mgr *attrmgr.AttrMgr, s *grpc.Server) *schedulerGroup {
+	sg := &schedulerGroup{
+		mgr: mgr,
+	}
+	saipb.RegisterSchedulerGroupServer(s, sg)
+	return sg
+}
+
+// CreateSchedulerGroup creates a scheduler group of a port. Its parent is either the port, for a group at level 0,
+// or a scheduler group of the same port one level up.
+func (sg *schedulerGroup) CreateSchedulerGroup(_ context.Context, req *saipb.CreateSchedulerGroupRequest) (*saipb.CreateSchedulerGroupResponse, error) {
+	port := req.GetPortId()
+	if sg.mgr.GetType(fmt.Sprint(port)) != saipb.ObjectType_OBJECT_TYPE_PORT {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown port: %d", port)
+	}
+	parent := req.GetParentNode()
+	wantLevel := uint32(0)
+	if parent != port {
+		parentAttr, err := schedulerGroupAttrs(sg.mgr, parent)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid parent node: %v", err)
+		}
+		if parentAttr.GetPortId() != port {
+			return nil, status.Errorf(codes.InvalidArgument, "parent scheduler group %d belongs to port %d, not %d", parent, parentAttr.GetPortId(), port)
+		}
+		wantLevel = parentAttr.GetLevel() + 1
+	}
+	if req.Level != nil && req.GetLevel() != wantLevel {
+		return nil, status.Errorf(codes.InvalidArgument, "scheduler group level %d, want %d under parent %d", req.GetLevel(), wantLevel, parent)
+	}
+	if err := checkSchedulerParent(sg.mgr, parent); err != nil {
+		return nil, err
+	}
+	if err := checkSchedulerProfile(sg.mgr, req.GetSchedulerProfileId()); err != nil {
+		return nil, err
+	}
+	id := sg.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_SCHEDULER_GROUP)
+	sg.mgr.StoreAttributes(id, &saipb.SchedulerGroupAttribute{
+		Level: &wantLevel,
+	})
+	return &saipb.CreateSchedulerGroupResponse{Oid: id}, nil
+}
+
+// RemoveSchedulerGroup removes a scheduler group, which must not have any children.
+func (sg *schedulerGroup) RemoveSchedulerGroup(_ context.Context, req *saipb.RemoveSchedulerGroupRequest) (*saipb.RemoveSchedulerGroupResponse, error) {
+	if _, err := schedulerGroupAttrs(sg.mgr, req.GetOid()); err != nil {
+		return nil, err
+	}
+	if children := schedulerChildren(sg.mgr, req.GetOid()); len(children) != 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "scheduler group %d still has children: %v", req.GetOid(), children)
+	}
+	return &saipb.RemoveSchedulerGroupResponse{}, nil
+}
+
+// SetSchedulerGroupAttribute sets the scheduler profile of a scheduler group.
+// TODO: Support moving a scheduler group to another parent.
+func (sg *schedulerGroup) SetSchedulerGroupAttribute(_ context.Context, req *saipb.SetSchedulerGroupAttributeRequest) (*saipb.SetSchedulerGroupAttributeResponse, error) {
+	attr, err := schedulerGroupAttrs(sg.mgr, req.GetOid())
+	if err != nil {
+		return nil, err
+	}
+	if req.ParentNode != nil && req.GetParentNode() != attr.GetParentNode() {
+		return nil, status.Errorf(codes.InvalidArgument, "changing the parent of scheduler group %d is not supported", req.GetOid())
+	}
+	if err := checkSchedulerProfile(sg.mgr, req.GetSchedulerProfileId()); err != nil {
+		return nil, err
+	}
+	return &saipb.SetSchedulerGroupAttributeResponse{}, nil
+}
+
+// GetSchedulerGroupAttribute returns the scheduler group attributes, the children are the scheduler groups and
+// queues whose parent node is the group.
+func (sg *schedulerGroup) GetSchedulerGroupAttribute(_ context.Context, req *saipb.GetSchedulerGroupAttributeRequest) (*saipb.GetSchedulerGroupAttributeResponse, error) {
+	if _, err := schedulerGroupAttrs(sg.mgr, req.GetOid()); err != nil {
+		return nil, err
+	}
+	children := schedulerChildren(sg.mgr, req.GetOid())
+	sg.mgr.StoreAttributes(req.GetOid(), &saipb.SchedulerGroupAttribute{
+		ChildCount: proto.Uint32(uint32(len(children))),
+		ChildList:  children,
+	})
+	return &saipb.GetSchedulerGroupAttributeResponse{}, nil
+}
+
+// schedulerGroupAttrs returns the attributes of a scheduler group.
+func schedulerGroupAttrs(mgr *attrmgr.AttrMgr, id uint64) (*saipb.SchedulerGroupAttribute, error) {
+	if mgr.GetType(fmt.Sprint(id)) != saipb.ObjectType_OBJECT_TYPE_SCHEDULER_GROUP {
+		return nil, status.Errorf(codes.NotFound, "unknown scheduler group: %d", id)
+	}
+	attr := &saipb.SchedulerGroupAttribute{}
+	if err := mgr.PopulateAllAttributes(fmt.Sprint(id), attr); err != nil {
+		return nil, err
+	}
+	return attr, nil
+}
+
+// checkSchedulerParent returns an error if a scheduler group or queue can't be added under the parent node,
+// because the parent is a scheduler group that already has its maximum number of children.
+func checkSchedulerParent(mgr *attrmgr.AttrMgr, parent uint64) error {
+	if mgr.GetType(fmt.Sprint(parent)) != saipb.ObjectType_OBJECT_TYPE_SCHEDULER_GROUP {
+		return nil
+	}
+	attr, err := schedulerGroupAttrs(mgr, parent)
+	if err != nil {
+		return err
+	}
+	if maxChilds := attr.GetMaxChilds(); maxChilds != 0 && len(schedulerChildren(mgr, parent)) >= int(maxChilds) {
+		return status.Errorf(codes.ResourceExhausted, "scheduler group %d already has %d children", parent, maxChilds)
+	}
+	return nil
+}
+
+// checkSchedulerProfile returns an error if the ID is neither 0, for no profile, nor a scheduler profile.
+func checkSchedulerProfile(mgr *attrmgr.AttrMgr, id uint64) error {
+	if id == 0 {
+		return nil
+	}
+	_, err := schedulerProfile(mgr, id)
+	return err
+}
+
+// schedulerChildren returns the IDs of the scheduler groups and queues whose parent node is the port or scheduler group,
+// in increasing order.
+func schedulerChildren(mgr *attrmgr.AttrMgr, parent uint64) []uint64 {
+	children := []uint64{}
+	for _, id := range mgr.GetObjectsOfType(saipb.ObjectType_OBJECT_TYPE_SCHEDULER_GROUP) {
+		attr := &saipb.SchedulerGroupAttribute{}
+		if err := mgr.PopulateAllAttributes(id, attr); err != nil || attr.GetParentNode() != parent {
+			continue
+		}
+		if oid, err := strconv.ParseUint(id, 10, 64); err == nil {
+			children = append(children, oid)
+		}
+	}
+	for _, id := range mgr.GetObjectsOfType(saipb.ObjectType_OBJECT_TYPE_QUEUE) {
+		attr := &saipb.QueueAttribute{}
+		if err := mgr.PopulateAllAttributes(id, attr); err != nil || attr.GetParentSchedulerNode() != parent {
+			continue
+		}
+		if oid, err := strconv.ParseUint(id, 10, 64); err == nil {
+			children = append(children, oid)
+		}
+	}
+	slices.Sort(children)
+	return children
+}
+
+// schedulerNode is a node of the scheduling hierarchy of a port: the port, a scheduler group or a queue.
+type schedulerNode struct {
+	id       uint64
+	queue    bool
+	index    uint32                    // index is the index of a queue.
+	profile  *saipb.SchedulerAttribute // profile is the scheduler profile of the node, it is empty if the node has none.
+	children []*schedulerNode
+}
+
+// schedulerTree returns the scheduling hierarchy of a port, the port's scheduler groups and queues.
+func schedulerTree(mgr *attrmgr.AttrMgr, port uint64) (*schedulerNode, error) {
+	if mgr.GetType(fmt.Sprint(port)) != saipb.ObjectType_OBJECT_TYPE_PORT {
+		return nil, status.Errorf(codes.NotFound, "unknown port: %d", port)
+	}
+	attr := &saipb.PortAttribute{}
+	if err := mgr.PopulateAllAttributes(fmt.Sprint(port), attr); err != nil {
+		return nil, err
+	}
+	root, err := newSchedulerNode(mgr, port, attr.GetQosSchedulerProfileId())
+	if err != nil {
+		return nil, err
+	}
+	// The port is shaped to its speed, in Mbps, unless its scheduler profile sets a lower rate.
+	if speed := uint64(attr.GetSpeed()) * 1e6 / 8; speed != 0 && (root.profile.MaxBandwidthRate == nil || speed < root.profile.GetMaxBandwidthRate()) {
+		root.profile.MaxBandwidthRate = &speed
+	}
+	if err := root.addChildren(mgr); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// newSchedulerNode returns a node scheduled by the profile, if any.
+func newSchedulerNode(mgr *attrmgr.AttrMgr, id, profileID uint64) (*schedulerNode, error) {
+	n := &schedulerNode{id: id, profile: &saipb.SchedulerAttribute{}}
+	if profileID == 0 {
+		return n, nil
+	}
+	profile, err := schedulerProfile(mgr, profileID)
+	if err != nil {
+		return nil, err
+	}
+	n.profile = profile
+	return n, nil
+}
+
+// addChildren adds the children of the node to the tree, recursively.
+func (n *schedulerNode) addChildren(mgr *attrmgr.AttrMgr) error {
+	for _, id := range schedulerChildren(mgr, n.id) {
+		var child *schedulerNode
+		var err error
+		switch mgr.GetType(fmt.Sprint(id)) {
+		case saipb.ObjectType_OBJECT_TYPE_QUEUE:
+			attr := &saipb.QueueAttribute{}
+			if err := mgr.PopulateAllAttributes(fmt.Sprint(id), attr); err != nil {
+				return err
+			}
+			if child, err = newSchedulerNode(mgr, id, attr.GetSchedulerProfileId()); err != nil {
+				return err
+			}
+			child.queue = true
+			child.index = attr.GetIndex()
+		default:
+			attr := &saipb.SchedulerGroupAttribute{}
+			if err := mgr.PopulateAllAttributes(fmt.Sprint(id), attr); err != nil {
+				return err
+			}
+			if child, err = newSchedulerNode(mgr, id, attr.GetSchedulerProfileId()); err != nil {
+				return err
+			}
+			if err := child.addChildren(mgr); err != nil {
+				return err
+			}
+		}
+		n.children = append(n.children, child)
+	}
+	return nil
+}
+
+// shape returns the rate, in bytes per second, limited by the node's shaper.
+func (n *schedulerNode) shape(rate uint64) uint64 {
+	if n.profile.MaxBandwidthRate != nil {
+		return min(rate, n.profile.GetMaxBandwidthRate())
+	}
+	return rate
+}
+
+// demand returns the rate, in bytes per second, the node would transmit at if it were not limited by its parent,
+// given the rate offered to each queue.
+func (n *schedulerNode) demand(offered map[uint64]uint64) uint64 {
+	if n.queue {
+		return n.shape(offered[n.id])
+	}
+	var sum uint64
+	for _, c := range n.children {
+		sum += c.demand(offered)
+	}
+	return n.shape(sum)
+}
+
+// weight returns the weight of a WRR or DWRR node, SAI defaults it to 1.
+func (n *schedulerNode) weight() uint64 {
+	if n.profile.SchedulingWeight == nil {
+		return 1
+	}
+	return uint64(n.profile.GetSchedulingWeight())
+}
+
+// drain sets the rate, in bytes per second, each queue below the node is drained at when the node may transmit up
+// to rate, given the rate offered to each queue. Strict priority children are served first, queues with a higher
+// index before the others, then the remaining rate is shared by the WRR and DWRR children in proportion to their
+// weights. A child that demands less than its share gets its demand, the rest is shared among the other children.
+func (n *schedulerNode) drain(rate uint64, offered, rates map[uint64]uint64) {
+	rate = n.shape(rate)
+	if n.queue {
+		rates[n.id] = min(rate, offered[n.id])
+		return
+	}
+	var strict, weighted []*schedulerNode
+	for _, c := range n.children {
+		if c.profile.GetSchedulingType() == saipb.SchedulingType_SCHEDULING_TYPE_STRICT {
+			strict = append(strict, c)
+		} else {
+			weighted = append(weighted, c)
+		}
+	}
+	slices.SortStableFunc(strict, func(a, b *schedulerNode) int {
+		return cmp.Compare(b.index, a.index)
+	})
+	for _, c := range strict {
+		share := min(rate, c.demand(offered))
+		c.drain(share, offered, rates)
+		rate -= share
+	}
+
+	shares := map[*schedulerNode]uint64{}
+	for len(weighted) > 0 {
+		var totalWeight uint64
+		for _, c := range weighted {
+			totalWeight += c.weight()
+		}
+		var unsatisfied []*schedulerNode
+		remaining := rate
+		for _, c := range weighted {
+			if d := c.demand(offered); d <= rate*c.weight()/totalWeight {
+				shares[c] = d
+				remaining -= d
+			} else {
+				unsatisfied = append(unsatisfied, c)
+			}
+		}
+		if len(unsatisfied) == len(weighted) {
+			for _, c := range weighted {
+				shares[c] = rate * c.weight() / totalWeight
+			}
+			break
+		}
+		weighted, rate = unsatisfied, remaining
+	}
+	for c, share := range shares {
+		c.drain(share, offered, rates)
+	}
+}
+
+// drainRates returns the rate, in bytes per second, each queue of a port is drained at, given the rate offered to each
+// queue, when shaping and scheduling the port's hierarchy of scheduler groups and queues.
+// TODO: Apply the hierarchy to the dataplane, ports transmit packets as soon as they are processed.
+func drainRates(mgr *attrmgr.AttrMgr, port uint64, offered map[uint64]uint64) (map[uint64]uint64, error) {
+	root, err := schedulerTree(mgr, port)
+	if err != nil {
+		return nil, err
+	}
+	rates := map[uint64]uint64{}
+	root.drain(root.demand(offered), offered, rates)
+	return rates, nil
+}
@@ -0,0 +1,256 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saiserver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
+	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
+
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+func TestSchedulerHierarchy(t *testing.T) {
+	ctx := context.Background()
+	var mgr *attrmgr.AttrMgr
+	conn, _, stopFn := newTestServer(t, func(m *attrmgr.AttrMgr, srv *grpc.Server) {
+		mgr = m
+		newScheduler(m, srv)
+		newSchedulerGroup(m, srv)
+		newQueue(m, &fakeSwitchDataplane{}, srv)
+	})
+	defer stopFn()
+	sc := saipb.NewSchedulerClient(conn)
+	gc := saipb.NewSchedulerGroupClient(conn)
+	qc := saipb.NewQueueClient(conn)
+
+	profile := func(req *saipb.CreateSchedulerRequest) uint64 {
+		t.Helper()
+		resp, err := sc.CreateScheduler(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.GetOid()
+	}
+	portShaper := profile(&saipb.CreateSchedulerRequest{MaxBandwidthRate: proto.Uint64(1000)})
+	port := mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_PORT)
+	mgr.SetType(fmt.Sprint(port), saipb.ObjectType_OBJECT_TYPE_PORT)
+	mgr.StoreAttributes(port, &saipb.PortAttribute{QosSchedulerProfileId: proto.Uint64(portShaper)})
+
+	group := func(parent uint64, profileID uint64) uint64 {
+		t.Helper()
+		resp, err := gc.CreateSchedulerGroup(ctx, &saipb.CreateSchedulerGroupRequest{
+			PortId:             proto.Uint64(port),
+			ParentNode:         proto.Uint64(parent),
+			SchedulerProfileId: proto.Uint64(profileID),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.GetOid()
+	}
+	queue := func(parent uint64, index uint32, profileID uint64) uint64 {
+		t.Helper()
+		resp, err := qc.CreateQueue(ctx, &saipb.CreateQueueRequest{
+			Type:                saipb.QueueType_QUEUE_TYPE_UNICAST.Enum(),
+			Port:                proto.Uint64(port),
+			Index:               proto.Uint32(index),
+			ParentSchedulerNode: proto.Uint64(parent),
+			SchedulerProfileId:  proto.Uint64(profileID),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.GetOid()
+	}
+
+	// The port is shaped to 1000 B/s and shares it between two level 1 groups in a 3:1 ratio.
+	// The first group is shaped to 500 B/s, its strict priority queue is served before its other queue,
+	// the second group shares its rate equally between its queues.
+	heavyShaper := profile(&saipb.CreateSchedulerRequest{
+		SchedulingType:   saipb.SchedulingType_SCHEDULING_TYPE_DWRR.Enum(),
+		SchedulingWeight: proto.Uint32(3),
+		MaxBandwidthRate: proto.Uint64(500),
+	})
+	light := profile(&saipb.CreateSchedulerRequest{
+		SchedulingType:   saipb.SchedulingType_SCHEDULING_TYPE_DWRR.Enum(),
+		SchedulingWeight: proto.Uint32(1),
+	})
+	strict := profile(&saipb.CreateSchedulerRequest{
+		SchedulingType: saipb.SchedulingType_SCHEDULING_TYPE_STRICT.Enum(),
+	})
+	root := group(port, 0)
+	heavy := group(root, heavyShaper)
+	lightGroup := group(root, light)
+	q1 := queue(heavy, 7, strict)
+	q2 := queue(heavy, 0, 0)
+	q3 := queue(lightGroup, 1, 0)
+	q4 := queue(lightGroup, 2, 0)
+
+	got, err := gc.GetSchedulerGroupAttribute(ctx, &saipb.GetSchedulerGroupAttributeRequest{
+		Oid: root,
+		AttrType: []saipb.SchedulerGroupAttr{
+			saipb.SchedulerGroupAttr_SCHEDULER_GROUP_ATTR_CHILD_COUNT,
+			saipb.SchedulerGroupAttr_SCHEDULER_GROUP_ATTR_CHILD_LIST,
+			saipb.SchedulerGroupAttr_SCHEDULER_GROUP_ATTR_LEVEL,
+		},
+	})
+	if err != nil {
+		t.Fatalf("GetSchedulerGroupAttribute() unexpected err: %v", err)
+	}
+	if got.GetAttr().GetChildCount() != 2 || !cmp.Equal(got.GetAttr().GetChildList(), []uint64{heavy, lightGroup}) || got.GetAttr().GetLevel() != 0 {
+		t.Errorf("GetSchedulerGroupAttribute() got %v, want 2 children [%d %d] at level 0", got.GetAttr(), heavy, lightGroup)
+	}
+	got, err = gc.GetSchedulerGroupAttribute(ctx, &saipb.GetSchedulerGroupAttributeRequest{
+		Oid:      heavy,
+		AttrType: []saipb.SchedulerGroupAttr{saipb.SchedulerGroupAttr_SCHEDULER_GROUP_ATTR_CHILD_LIST, saipb.SchedulerGroupAttr_SCHEDULER_GROUP_ATTR_LEVEL},
+	})
+	if err != nil {
+		t.Fatalf("GetSchedulerGroupAttribute() unexpected err: %v", err)
+	}
+	if !cmp.Equal(got.GetAttr().GetChildList(), []uint64{q1, q2}) || got.GetAttr().GetLevel() != 1 {
+		t.Errorf("GetSchedulerGroupAttribute() got %v, want children [%d %d] at level 1", got.GetAttr(), q1, q2)
+	}
+
+	tests := []struct {
+		desc    string
+		offered map[uint64]uint64
+		want    map[uint64]uint64
+	}{{
+		desc:    "undersubscribed",
+		offered: map[uint64]uint64{q1: 100, q2: 100, q3: 100, q4: 100},
+		want:    map[uint64]uint64{q1: 100, q2: 100, q3: 100, q4: 100},
+	}, {
+		desc:    "oversubscribed",
+		offered: map[uint64]uint64{q1: 200, q2: 1000, q3: 1000, q4: 1000},
+		want:    map[uint64]uint64{q1: 200, q2: 300, q3: 250, q4: 250},
+	}, {
+		desc:    "strict priority starves",
+		offered: map[uint64]uint64{q1: 1000, q2: 1000, q3: 1000, q4: 1000},
+		want:    map[uint64]uint64{q1: 500, q2: 0, q3: 250, q4: 250},
+	}, {
+		desc:    "unused share",
+		offered: map[uint64]uint64{q1: 0, q2: 0, q3: 1000, q4: 100},
+		want:    map[uint64]uint64{q1: 0, q2: 0, q3: 900, q4: 100},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := drainRates(mgr, port, tt.offered)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := cmp.Diff(got, tt.want); d != "" {
+				t.Errorf("drainRates() unexpected diff (-got,+want):\n%s", d)
+			}
+		})
+	}
+
+	// Raising the shaper of the first group lets it use its whole share.
+	if _, err := sc.SetSchedulerAttribute(ctx, &saipb.SetSchedulerAttributeRequest{Oid: heavyShaper, MaxBandwidthRate: proto.Uint64(1000)}); err != nil {
+		t.Fatal(err)
+	}
+	rates, err := drainRates(mgr, port, map[uint64]uint64{q1: 200, q2: 1000, q3: 1000, q4: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(rates, map[uint64]uint64{q1: 200, q2: 550, q3: 125, q4: 125}); d != "" {
+		t.Errorf("drainRates() after raising the shaper unexpected diff (-got,+want):\n%s", d)
+	}
+
+	if _, err := gc.RemoveSchedulerGroup(ctx, &saipb.RemoveSchedulerGroupRequest{Oid: root}); grpcstatus.Code(err) != codes.FailedPrecondition {
+		t.Errorf("RemoveSchedulerGroup() of a group with children got err %v, want FailedPrecondition", err)
+	}
+}
+
+func TestCreateSchedulerGroup(t *testing.T) {
+	tests := []struct {
+		desc    string
+		fill    bool // fill adds a child to the group, which can only have one, before the request.
+		req     func(port, group uint64) *saipb.CreateSchedulerGroupRequest
+		wantErr string
+	}{{
+		desc: "unknown port",
+		req: func(port, _ uint64) *saipb.CreateSchedulerGroupRequest {
+			return &saipb.CreateSchedulerGroupRequest{PortId: proto.Uint64(port + 100), ParentNode: proto.Uint64(port)}
+		},
+		wantErr: "unknown port",
+	}, {
+		desc: "no parent",
+		req: func(port, _ uint64) *saipb.CreateSchedulerGroupRequest {
+			return &saipb.CreateSchedulerGroupRequest{PortId: proto.Uint64(port)}
+		},
+		wantErr: "invalid parent node",
+	}, {
+		desc: "wrong level",
+		req: func(port, group uint64) *saipb.CreateSchedulerGroupRequest {
+			return &saipb.CreateSchedulerGroupRequest{PortId: proto.Uint64(port), ParentNode: proto.Uint64(group), Level: proto.Uint32(0)}
+		},
+		wantErr: "want 1",
+	}, {
+		desc: "parent full",
+		fill: true,
+		req: func(port, group uint64) *saipb.CreateSchedulerGroupRequest {
+			return &saipb.CreateSchedulerGroupRequest{PortId: proto.Uint64(port), ParentNode: proto.Uint64(group)}
+		},
+		wantErr: "already has 1 children",
+	}, {
+		desc: "unknown profile",
+		req: func(port, _ uint64) *saipb.CreateSchedulerGroupRequest {
+			return &saipb.CreateSchedulerGroupRequest{PortId: proto.Uint64(port), ParentNode: proto.Uint64(port), SchedulerProfileId: proto.Uint64(port)}
+		},
+		wantErr: "unknown scheduler",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var mgr *attrmgr.AttrMgr
+			conn, _, stopFn := newTestServer(t, func(m *attrmgr.AttrMgr, srv *grpc.Server) {
+				mgr = m
+				newScheduler(m, srv)
+				newSchedulerGroup(m, srv)
+			})
+			defer stopFn()
+			ctx := context.Background()
+			c := saipb.NewSchedulerGroupClient(conn)
+			port := mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_PORT)
+			mgr.SetType(fmt.Sprint(port), saipb.ObjectType_OBJECT_TYPE_PORT)
+			group, err := c.CreateSchedulerGroup(ctx, &saipb.CreateSchedulerGroupRequest{
+				PortId:     proto.Uint64(port),
+				ParentNode: proto.Uint64(port),
+				MaxChilds:  proto.Uint32(1),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.fill {
+				if _, err := c.CreateSchedulerGroup(ctx, &saipb.CreateSchedulerGroupRequest{PortId: proto.Uint64(port), ParentNode: proto.Uint64(group.GetOid())}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			_, gotErr := c.CreateSchedulerGroup(ctx, tt.req(port, group.GetOid()))
+			if d := errdiff.Check(gotErr, tt.wantErr); d != "" {
+				t.Errorf("CreateSchedulerGroup() unexpected err: %s", d)
+			}
+		})
+	}
+}
@@ -17,10 +17,15 @@ package saiserver
 import (
 	"context"
 	"fmt"
+	"net"
 	"strconv"
+	"sync/atomic"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	log "github.com/golang/glog"
 
@@ -28,8 +33,10 @@ import (
 	"github.com/openconfig/lemming/dataplane/dplaneopts"
 	"github.com/openconfig/lemming/dataplane/forwarding/fwdconfig"
 	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdcontext"
+	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdpacket"
 	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
 
+	diagpb "github.com/openconfig/lemming/dataplane/proto/diag"
 	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
 	fwdpb "github.com/openconfig/lemming/proto/forwarding"
 )
@@ -43,6 +50,7 @@ type saiSwitch struct {
 	stp             *stp
 	vr              *virtualRouter
 	bridge          *bridge
+	fdb             *fdb
 	hostif          *hostif
 	hash            *hash
 	isolationGroup  *isolationGroup
@@ -51,11 +59,24 @@ type saiSwitch struct {
 	nextHopGroup    *nextHopGroup
 	nextHop         *nextHop
 	policer         *policer
+	queue           *queue
+	scheduler       *scheduler
+	schedulerGroup  *schedulerGroup
 	route           *route
 	lag             *lag
 	tunnel          *tunnel
 	routerInterface *routerInterface
+	ipmc            *ipmc
+	ipmcGroup       *ipmcGroup
+	l2mcGroup       *l2mcGroup
+	rpfGroup        *rpfGroup
 	mgr             *attrmgr.AttrMgr
+	// created is true between CreateSwitch and RemoveSwitch.
+	created atomic.Bool
+	// id is the ID of the switch, it is 0 until the switch is created.
+	id atomic.Uint64
+	// resetDataplane deletes and recreates the forwarding context, removing all its tables and ports.
+	resetDataplane func(context.Context) error
 }
 
 type switchDataplaneAPI interface {
@@ -75,6 +96,8 @@ type switchDataplaneAPI interface {
 	ObjectDelete(context.Context, *fwdpb.ObjectDeleteRequest) (*fwdpb.ObjectDeleteReply, error)
 	FlowCounterCreate(_ context.Context, request *fwdpb.FlowCounterCreateRequest) (*fwdpb.FlowCounterCreateReply, error)
 	FlowCounterQuery(_ context.Context, request *fwdpb.FlowCounterQueryRequest) (*fwdpb.FlowCounterQueryReply, error)
+	SetCreate(context.Context, *fwdpb.SetCreateRequest) (*fwdpb.SetCreateReply, error)
+	SetUpdate(context.Context, *fwdpb.SetUpdateRequest) (*fwdpb.SetUpdateReply, error)
 }
 
 const (
@@ -99,7 +122,19 @@ const (
 	MyMacTable            = "my-mac-table"
 	hostifToPortTable     = "cpu-input"
 	portToHostifTable     = "cpu-output"
+	portVlanToHostifTable = "cpu-output-vlan"
+	mtuErrorTable         = "cpu-output-mtu-error"
 	tunTermTable          = "tun-term"
+	ipmcTable             = "ipmc"
+	vlanFloodTable        = "vlan-flood"
+	floodEgressTable      = "flood-egress"
+	ingressMTUTable       = "ingress-mtu"
+	egressMTUTable        = "egress-mtu"
+	portIngressACLTable   = "port-ingress-acl"
+	portEgressACLTable    = "port-egress-acl"
+	bridgePortTable       = "bridge-port"
+	fdbTable              = "fdb"
+	gleanTable            = "glean"
 )
 
 func newSwitch(mgr *attrmgr.AttrMgr, engine switchDataplaneAPI, s *grpc.Server, opts *dplaneopts.Options) (*saiSwitch, error) {
@@ -107,15 +142,20 @@ func newSwitch(mgr *attrmgr.AttrMgr, engine switchDataplaneAPI, s *grpc.Server,
 	if err != nil {
 		return nil, err
 	}
+	br := newBridge(mgr, engine, s)
 	sw := &saiSwitch{
 		dataplane:       engine,
 		acl:             newACL(mgr, engine, s),
 		policer:         newPolicer(mgr, engine, s),
+		queue:           newQueue(mgr, engine, s),
+		scheduler:       newScheduler(mgr, s),
+		schedulerGroup:  newSchedulerGroup(mgr, s),
 		port:            port,
 		vlan:            newVlan(mgr, engine, s),
 		stp:             &stp{},
-		vr:              &virtualRouter{},
-		bridge:          newBridge(mgr, engine, s),
+		vr:              newVirtualRouter(mgr, s),
+		bridge:          br,
+		fdb:             newFDB(br, s),
 		hostif:          newHostif(mgr, engine, s, opts),
 		hash:            newHash(mgr, engine, s),
 		isolationGroup:  newIsolationGroup(mgr, engine, s),
@@ -127,17 +167,78 @@ func newSwitch(mgr *attrmgr.AttrMgr, engine switchDataplaneAPI, s *grpc.Server,
 		routerInterface: newRouterInterface(mgr, engine, s),
 		lag:             newLAG(mgr, engine, s),
 		tunnel:          newTunnel(mgr, engine, s),
+		ipmc:            newIPMC(mgr, engine, s),
+		ipmcGroup:       newIPMCGroup(mgr, engine, s),
+		l2mcGroup:       newL2MCGroup(mgr, engine, s),
+		rpfGroup:        newRPFGroup(mgr, engine, s),
 		mgr:             mgr,
 	}
+	sw.hostif.hasIP2MERoutes = sw.route.hasIP2MERoutes
+	sw.hostif.localPrefixes = sw.route.localPrefixes
+	sw.route.ip2meChanged = sw.hostif.localAddressesChanged
+	sw.port.cpuPort = sw.hostif.cpuPort
+	sw.acl.cpuPort = sw.hostif.cpuPort
+	sw.port.switchID = sw.id.Load
+	sw.myMac.switchID = sw.id.Load
+	sw.nextHopGroup.switchID = sw.id.Load
+	sw.hash.rebind = sw.rebindHash
 	saipb.RegisterSwitchServer(s, sw)
 	saipb.RegisterStpServer(s, sw.stp)
-	saipb.RegisterVirtualRouterServer(s, sw.vr)
 	return sw, nil
 }
 
-// CreateSwitch a creates a new switch and populates its default values.
-func (sw *saiSwitch) CreateSwitch(ctx context.Context, _ *saipb.CreateSwitchRequest) (*saipb.CreateSwitchResponse, error) {
-	swID := sw.mgr.NextID()
+// CreateSwitch creates the switch: it sets up the forwarding tables, the CPU port, and the default objects and attributes.
+// Each server has a single switch in its own forwarding context, creating it again fails until it is removed.
+// Multiple switches in one process are separate servers, see dplaneopts.WithContextID.
+// Hostifs and traps can only be created once the switch exists.
+func (sw *saiSwitch) CreateSwitch(ctx context.Context, req *saipb.CreateSwitchRequest) (*saipb.CreateSwitchResponse, error) {
+	if !sw.created.CompareAndSwap(false, true) {
+		return nil, status.Errorf(codes.AlreadyExists, "switch %d already created", sw.id.Load())
+	}
+	resp, err := sw.createSwitch(ctx, req)
+	if err != nil {
+		// Undo the partial setup, so creating the switch can be retried.
+		if terr := sw.teardown(ctx); terr != nil {
+			log.Warningf("failed to tear down partially created switch: %v", terr)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RemoveSwitch removes the switch and every object created on it, and resets the forwarding context.
+// The switch can be created again afterwards.
+func (sw *saiSwitch) RemoveSwitch(ctx context.Context, req *saipb.RemoveSwitchRequest) (*saipb.RemoveSwitchResponse, error) {
+	swID := sw.id.Load()
+	if !sw.created.Load() || req.GetOid() != swID {
+		return nil, status.Errorf(codes.NotFound, "switch %d not found", req.GetOid())
+	}
+	// The attribute manager removes the switch's attributes once the request succeeds, so keep them until then.
+	attrs := &saipb.SwitchAttribute{}
+	if err := sw.mgr.PopulateAllAttributes(fmt.Sprint(swID), attrs); err != nil {
+		return nil, err
+	}
+	if err := sw.teardown(ctx); err != nil {
+		return nil, err
+	}
+	sw.mgr.StoreAttributes(swID, attrs)
+	return &saipb.RemoveSwitchResponse{}, nil
+}
+
+// teardown removes all the objects and their state, including the forwarding context.
+func (sw *saiSwitch) teardown(ctx context.Context) error {
+	sw.Reset()
+	sw.mgr.Reset()
+	if sw.resetDataplane == nil {
+		return nil
+	}
+	return sw.resetDataplane(ctx)
+}
+
+// createSwitch creates a new switch and populates its default values.
+func (sw *saiSwitch) createSwitch(ctx context.Context, _ *saipb.CreateSwitchRequest) (*saipb.CreateSwitchResponse, error) {
+	swID := sw.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_SWITCH)
+	sw.id.Store(swID)
 
 	// Setup forwarding tables.
 	ingressVRF := &fwdpb.TableCreateRequest{
@@ -245,12 +346,29 @@ func (sw *saiSwitch) CreateSwitch(ctx context.Context, _ *saipb.CreateSwitchRequ
 	if _, err := sw.dataplane.TableCreate(ctx, myMAC); err != nil {
 		return nil, err
 	}
+	// Packets to unresolved neighbors are looked up in the glean table, which is empty, dropping them, until a glean trap is created.
+	glean := &fwdpb.TableCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: sw.dataplane.ID()},
+		Desc: &fwdpb.TableDesc{
+			TableType: fwdpb.TableType_TABLE_TYPE_FLOW,
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: gleanTable}},
+			Actions:   []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_DROP}},
+			Table: &fwdpb.TableDesc_Flow{
+				Flow: &fwdpb.FlowTableDesc{
+					BankCount: 1,
+				},
+			},
+		},
+	}
+	if _, err := sw.dataplane.TableCreate(ctx, glean); err != nil {
+		return nil, err
+	}
 	neighbor := &fwdpb.TableCreateRequest{
 		ContextId: &fwdpb.ContextId{Id: sw.dataplane.ID()},
 		Desc: &fwdpb.TableDesc{
 			TableType: fwdpb.TableType_TABLE_TYPE_EXACT,
 			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: NeighborTable}},
-			Actions:   []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_DROP}},
+			Actions:   []*fwdpb.ActionDesc{fwdconfig.Action(fwdconfig.LookupAction(gleanTable)).Build()},
 			Table: &fwdpb.TableDesc_Exact{
 				Exact: &fwdpb.ExactTableDesc{
 					FieldIds: []*fwdpb.PacketFieldId{{
@@ -309,6 +427,140 @@ func (sw *saiSwitch) CreateSwitch(ctx context.Context, _ *saipb.CreateSwitchRequ
 	if _, err := sw.dataplane.TableCreate(ctx, nhg); err != nil {
 		return nil, err
 	}
+	ipmc := &fwdpb.TableCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: sw.dataplane.ID()},
+		Desc: &fwdpb.TableDesc{
+			TableType: fwdpb.TableType_TABLE_TYPE_FLOW,
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: ipmcTable}},
+			Actions:   []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_CONTINUE}},
+			Table: &fwdpb.TableDesc_Flow{
+				Flow: &fwdpb.FlowTableDesc{
+					BankCount: 1,
+				},
+			},
+		},
+	}
+	if _, err := sw.dataplane.TableCreate(ctx, ipmc); err != nil {
+		return nil, err
+	}
+	// Frames received on bridge ports are switched by the FDB, port entries
+	// learn source MACs into it unless learning is disabled on the bridge port.
+	bridgePorts := &fwdpb.TableCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: sw.dataplane.ID()},
+		Desc: &fwdpb.TableDesc{
+			TableType: fwdpb.TableType_TABLE_TYPE_FLOW,
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: bridgePortTable}},
+			Actions:   []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_CONTINUE}},
+			Table: &fwdpb.TableDesc_Flow{
+				Flow: &fwdpb.FlowTableDesc{
+					BankCount: 1,
+				},
+			},
+		},
+	}
+	if _, err := sw.dataplane.TableCreate(ctx, bridgePorts); err != nil {
+		return nil, err
+	}
+	fdb := &fwdpb.TableCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: sw.dataplane.ID()},
+		Desc: &fwdpb.TableDesc{
+			TableType: fwdpb.TableType_TABLE_TYPE_BRIDGE,
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: fdbTable}},
+			Actions:   []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_CONTINUE}},
+			Table: &fwdpb.TableDesc_Bridge{
+				Bridge: &fwdpb.BridgeTableDesc{},
+			},
+		},
+	}
+	if _, err := sw.dataplane.TableCreate(ctx, fdb); err != nil {
+		return nil, err
+	}
+	// Broadcast frames are flooded by the VLAN of their input port and VLAN tag.
+	vlanFlood := &fwdpb.TableCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: sw.dataplane.ID()},
+		Desc: &fwdpb.TableDesc{
+			TableType: fwdpb.TableType_TABLE_TYPE_EXACT,
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: vlanFloodTable}},
+			Actions:   []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_CONTINUE}},
+			Table: &fwdpb.TableDesc_Exact{
+				Exact: &fwdpb.ExactTableDesc{
+					FieldIds: []*fwdpb.PacketFieldId{{
+						Field: &fwdpb.PacketField{
+							FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT,
+						},
+					}, {
+						Field: &fwdpb.PacketField{
+							FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_VLAN_TAG,
+						},
+					}, {
+						Field: &fwdpb.PacketField{
+							FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST,
+						},
+					}},
+				},
+			},
+		},
+	}
+	if _, err := sw.dataplane.TableCreate(ctx, vlanFlood); err != nil {
+		return nil, err
+	}
+	// Flooded copies are never sent back out of their input port.
+	floodEgress := &fwdpb.TableCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: sw.dataplane.ID()},
+		Desc: &fwdpb.TableDesc{
+			TableType: fwdpb.TableType_TABLE_TYPE_EXACT,
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: floodEgressTable}},
+			Actions:   []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_CONTINUE}},
+			Table: &fwdpb.TableDesc_Exact{
+				Exact: &fwdpb.ExactTableDesc{
+					FieldIds: []*fwdpb.PacketFieldId{{
+						Field: &fwdpb.PacketField{
+							FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT,
+						},
+					}, {
+						Field: &fwdpb.PacketField{
+							FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_OUTPUT,
+						},
+					}},
+				},
+			},
+		},
+	}
+	if _, err := sw.dataplane.TableCreate(ctx, floodEgress); err != nil {
+		return nil, err
+	}
+	// Ports with a configured MTU look up the frame length in a table of their own,
+	// and ports with a bound ACL table group look up the packet in the group's table.
+	for _, mtu := range []struct {
+		table string
+		field fwdpb.PacketFieldNum
+	}{
+		{ingressMTUTable, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT},
+		{egressMTUTable, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_OUTPUT},
+		{portIngressACLTable, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT},
+		{portEgressACLTable, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_OUTPUT},
+	} {
+		req := &fwdpb.TableCreateRequest{
+			ContextId: &fwdpb.ContextId{Id: sw.dataplane.ID()},
+			Desc: &fwdpb.TableDesc{
+				TableType: fwdpb.TableType_TABLE_TYPE_EXACT,
+				TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: mtu.table}},
+				Actions:   []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_CONTINUE}},
+				Table: &fwdpb.TableDesc_Exact{
+					Exact: &fwdpb.ExactTableDesc{
+						FieldIds: []*fwdpb.PacketFieldId{{
+							Field: &fwdpb.PacketField{
+								FieldNum: mtu.field,
+							},
+						}},
+					},
+				},
+			},
+		}
+		if _, err := sw.dataplane.TableCreate(ctx, req); err != nil {
+			return nil, err
+		}
+	}
 	if err := createFIBSelector(ctx, sw.dataplane.ID(), sw.dataplane); err != nil {
 		return nil, err
 	}
@@ -362,7 +614,8 @@ func (sw *saiSwitch) CreateSwitch(ctx context.Context, _ *saipb.CreateSwitchRequ
 	// Once a packet is sent to the CPU port, it must be matched to a hostif:
 	//   1. ip2me: a table maps IP DST to hostif port. (populated by the CPU port).
 	//   2. hostif table: a table the maps TRAP IP to the hostif. (trap id is set by the ACL actions).
-	//   3. default/wildcard: each hostif is created with a corresponding port, use that mapping to determine correct hostif.
+	//   3. wildcard: the hostif table entry for the trap IDs without their own entry, if it has a hostif.
+	//   4. default: each hostif is created with a corresponding port, use that mapping to determine correct hostif.
 	// Once the output port is determined, based on the hostif type:
 	//   1. For genetlink: send the packets using the CPU port gRPC connection.
 	//   2. For netdev (lucius kernel/tap): write the packets directly to the hostif.
@@ -423,6 +676,25 @@ func (sw *saiSwitch) CreateSwitch(ctx context.Context, _ *saipb.CreateSwitchRequ
 		return nil, err
 	}
 
+	// Packets with trap IDs that have no hostif table entry are delivered to the wildcard hostif, if there is one.
+	wildcardHostifTableReq := &fwdpb.TableCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: sw.dataplane.ID()},
+		Desc: &fwdpb.TableDesc{
+			TableType: fwdpb.TableType_TABLE_TYPE_ACTION,
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: wildcardHostifTable}},
+			Actions:   []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_CONTINUE}},
+			Table: &fwdpb.TableDesc_Action{
+				Action: &fwdpb.ActionTableDesc{},
+			},
+		},
+	}
+	if !sw.port.opts.RemoteCPUPort {
+		wildcardHostifTableReq.Desc.Actions = []*fwdpb.ActionDesc{{ActionType: fwdpb.ActionType_ACTION_TYPE_SWAP_OUTPUT_INTERNAL_EXTERNAL}}
+	}
+	if _, err = sw.dataplane.TableCreate(ctx, wildcardHostifTableReq); err != nil {
+		return nil, err
+	}
+
 	trapToHostifTableReq := &fwdpb.TableCreateRequest{
 		ContextId: &fwdpb.ContextId{Id: sw.dataplane.ID()},
 		Desc: &fwdpb.TableDesc{
@@ -437,13 +709,9 @@ func (sw *saiSwitch) CreateSwitch(ctx context.Context, _ *saipb.CreateSwitchRequ
 					}},
 				},
 			},
+			Actions: []*fwdpb.ActionDesc{fwdconfig.Action(fwdconfig.LookupAction(wildcardHostifTable)).Build()},
 		},
 	}
-
-	if !sw.port.opts.RemoteCPUPort {
-		trapToHostifTableReq.Desc.Actions = append(trapToHostifTableReq.Desc.Actions, &fwdpb.ActionDesc{ActionType: fwdpb.ActionType_ACTION_TYPE_SWAP_OUTPUT_INTERNAL_EXTERNAL})
-	}
-
 	if _, err = sw.dataplane.TableCreate(ctx, trapToHostifTableReq); err != nil {
 		return nil, err
 	}
@@ -545,6 +813,51 @@ func (sw *saiSwitch) CreateSwitch(ctx context.Context, _ *saipb.CreateSwitchRequ
 	if err != nil {
 		return nil, err
 	}
+	// Sub-interface hostifs are matched by both the input port and the VLAN tag.
+	_, err = sw.dataplane.TableCreate(ctx, &fwdpb.TableCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: sw.dataplane.ID()},
+		Desc: &fwdpb.TableDesc{
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: portVlanToHostifTable}},
+			TableType: fwdpb.TableType_TABLE_TYPE_EXACT,
+			Table: &fwdpb.TableDesc_Exact{
+				Exact: &fwdpb.ExactTableDesc{
+					FieldIds: []*fwdpb.PacketFieldId{{
+						Field: &fwdpb.PacketField{
+							FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_PACKET_PORT_INPUT,
+						},
+					}, {
+						Field: &fwdpb.PacketField{
+							FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_VLAN_TAG,
+						},
+					}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Packets punted for exceeding the MTU of their output port are marked for the switch, overriding the hostif
+	// of their input port, so that they are answered with an ICMP error instead of being delivered to the host.
+	_, err = sw.dataplane.TableCreate(ctx, &fwdpb.TableCreateRequest{
+		ContextId: &fwdpb.ContextId{Id: sw.dataplane.ID()},
+		Desc: &fwdpb.TableDesc{
+			TableId:   &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: mtuErrorTable}},
+			TableType: fwdpb.TableType_TABLE_TYPE_EXACT,
+			Table: &fwdpb.TableDesc_Exact{
+				Exact: &fwdpb.ExactTableDesc{
+					FieldIds: []*fwdpb.PacketFieldId{{
+						Field: &fwdpb.PacketField{
+							FieldNum: fwdpb.PacketFieldNum_PACKET_FIELD_NUM_TRAP_ID,
+						},
+					}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
 	_, err = sw.dataplane.TableCreate(ctx, &fwdpb.TableCreateRequest{
 		ContextId: &fwdpb.ContextId{Id: sw.dataplane.ID()},
 		Desc: &fwdpb.TableDesc{
@@ -562,10 +875,26 @@ func (sw *saiSwitch) CreateSwitch(ctx context.Context, _ *saipb.CreateSwitchRequ
 		return nil, err
 	}
 
+	if sw.port.opts.ChecksumValidation {
+		_, err := sw.dataplane.AttributeUpdate(ctx, &fwdpb.AttributeUpdateRequest{
+			ContextId: &fwdpb.ContextId{Id: sw.dataplane.ID()},
+			AttrId:    string(fwdpacket.AttrChecksumValidation),
+			AttrValue: "true",
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	cpuPortID, err := sw.port.createCPUPort(ctx)
 	if err != nil {
 		return nil, err
 	}
+	// The CPU port must exist before any hostif or trap is created.
+	sw.hostif.initSwitch(swID, cpuPortID)
+	if err := sw.hostif.createPuntCounters(ctx); err != nil {
+		return nil, err
+	}
 
 	stpResp, err := attrmgr.InvokeAndSave(ctx, sw.mgr, sw.stp.CreateStp, &saipb.CreateStpRequest{
 		Switch: swID,
@@ -630,6 +959,7 @@ func (sw *saiSwitch) CreateSwitch(ctx context.Context, _ *saipb.CreateSwitchRequ
 		IngressAcl:                       proto.Uint64(0),
 		EgressAcl:                        proto.Uint64(0),
 		PreIngressAcl:                    proto.Uint64(0),
+		AvailableAclTableGroup:           sw.port.availableACLGroups(),
 		AvailableIpv4RouteEntry:          proto.Uint32(1024),
 		AvailableIpv6RouteEntry:          proto.Uint32(1024),
 		AvailableIpv4NexthopEntry:        proto.Uint32(1024),
@@ -713,10 +1043,52 @@ func (sw *saiSwitch) SetSwitchAttribute(ctx context.Context, req *saipb.SetSwitc
 		if err := sw.bindACLTable(ctx, fmt.Sprint(req.GetEgressAcl()), EgressActionTable); err != nil {
 			return nil, err
 		}
+	case req.EcmpHashIpv4 != nil:
+		if err := sw.nextHopGroup.setHash(ctx, true, req.GetEcmpHashIpv4()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to bind ECMP hash: %v", err)
+		}
+	case req.EcmpHashIpv6 != nil:
+		if err := sw.nextHopGroup.setHash(ctx, false, req.GetEcmpHashIpv6()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to bind ECMP hash: %v", err)
+		}
+	case req.LagHashIpv4 != nil:
+		if err := sw.lag.setHash(ctx, req.GetLagHashIpv4()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to bind LAG hash: %v", err)
+		}
+	case req.LagHashIpv6 != nil:
+		if err := sw.lag.setHash(ctx, req.GetLagHashIpv6()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to bind LAG hash: %v", err)
+		}
 	}
 	return &saipb.SetSwitchAttributeResponse{}, nil
 }
 
+// rebindHash reprograms the ECMP groups and LAGs bound to the hash, after its fields change.
+func (sw *saiSwitch) rebindHash(ctx context.Context, hashID uint64) error {
+	swAttr := &saipb.GetSwitchAttributeResponse{}
+	err := sw.mgr.PopulateAttributes(&saipb.GetSwitchAttributeRequest{
+		Oid:      sw.id.Load(),
+		AttrType: []saipb.SwitchAttr{saipb.SwitchAttr_SWITCH_ATTR_ECMP_HASH_IPV4, saipb.SwitchAttr_SWITCH_ATTR_ECMP_HASH_IPV6},
+	}, swAttr)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve hash ids: %v", err)
+	}
+	if swAttr.GetAttr().GetEcmpHashIpv4() == hashID {
+		if err := sw.nextHopGroup.setHash(ctx, true, hashID); err != nil {
+			return err
+		}
+	}
+	if swAttr.GetAttr().GetEcmpHashIpv6() == hashID {
+		if err := sw.nextHopGroup.setHash(ctx, false, hashID); err != nil {
+			return err
+		}
+	}
+	if sw.lag.hashID == hashID {
+		return sw.lag.setHash(ctx, hashID)
+	}
+	return nil
+}
+
 func (sw *saiSwitch) bindACLTable(ctx context.Context, aclTableID, stageID string) error {
 	_, err := sw.dataplane.TableEntryAdd(ctx, &fwdpb.TableEntryAddRequest{
 		ContextId: &fwdpb.ContextId{Id: sw.dataplane.ID()},
@@ -790,12 +1162,248 @@ func (sw *saiSwitch) PortStateChangeNotification(_ *saipb.PortStateChangeNotific
 	}
 }
 
+// removeAll removes all objects of type t and their dataplane entries.
+// The entries are removed in batches, with a single dataplane call per table.
+func (sw *saiSwitch) removeAll(ctx context.Context, t saipb.ObjectType) error {
+	ids := sw.mgr.GetObjectsOfType(t)
+	switch t {
+	case saipb.ObjectType_OBJECT_TYPE_HOSTIF_TRAP:
+		oids, err := parseOIDs(ids)
+		if err != nil {
+			return err
+		}
+		if err := sw.hostif.removeHostifTraps(ctx, oids); err != nil {
+			return err
+		}
+	case saipb.ObjectType_OBJECT_TYPE_NEXT_HOP:
+		oids, err := parseOIDs(ids)
+		if err != nil {
+			return err
+		}
+		if err := sw.nextHop.removeNextHops(ctx, oids); err != nil {
+			return err
+		}
+	case saipb.ObjectType_OBJECT_TYPE_ROUTE_ENTRY:
+		entries, err := unmarshalEntries[saipb.RouteEntry](ids)
+		if err != nil {
+			return err
+		}
+		if err := sw.route.removeRouteEntries(ctx, entries); err != nil {
+			return err
+		}
+	case saipb.ObjectType_OBJECT_TYPE_NEIGHBOR_ENTRY:
+		entries, err := unmarshalEntries[saipb.NeighborEntry](ids)
+		if err != nil {
+			return err
+		}
+		if err := sw.neighbor.removeNeighborEntries(ctx, entries); err != nil {
+			return err
+		}
+	default:
+		return status.Errorf(codes.Unimplemented, "remove all of object type %v not supported", t)
+	}
+	for _, id := range ids {
+		sw.mgr.DeleteObject(id)
+	}
+	return nil
+}
+
+// parseOIDs parses the ids of OID objects.
+func parseOIDs(ids []string) ([]uint64, error) {
+	oids := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		oid, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		oids = append(oids, oid)
+	}
+	return oids, nil
+}
+
+// unmarshalEntries parses the ids of entry objects, which are keyed by their marshalled entry.
+func unmarshalEntries[T any, PT interface {
+	*T
+	proto.Message
+}](ids []string) ([]PT, error) {
+	entries := make([]PT, 0, len(ids))
+	for _, id := range ids {
+		entry := PT(new(T))
+		if err := proto.Unmarshal([]byte(id), entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// setAttributes applies the set attribute requests in order and returns the error of each request.
+// Requests for the same object are merged, later values replacing earlier ones, and applied with a single call
+// to the object's handler, so they share its error. The attributes are stored only if the handler succeeds,
+// but changes the handler made before failing aren't rolled back.
+// A failure on one object doesn't prevent the others from being set.
+func (sw *saiSwitch) setAttributes(ctx context.Context, reqs []proto.Message) []error {
+	type objectKey struct {
+		msg protoreflect.FullName
+		oid uint64
+	}
+	reqErrs := make([]error, len(reqs))
+	reqKeys := make([]*objectKey, len(reqs))
+	var keys []objectKey
+	objects := map[objectKey]proto.Message{}
+	for i, req := range reqs {
+		o, ok := req.(interface{ GetOid() uint64 })
+		if !ok {
+			reqErrs[i] = status.Errorf(codes.InvalidArgument, "set request %T has no object id", req)
+			continue
+		}
+		key := objectKey{msg: req.ProtoReflect().Descriptor().FullName(), oid: o.GetOid()}
+		reqKeys[i] = &key
+		merged, ok := objects[key]
+		if !ok {
+			objects[key] = proto.Clone(req)
+			keys = append(keys, key)
+			continue
+		}
+		req.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			merged.ProtoReflect().Set(fd, v)
+			return true
+		})
+	}
+
+	objErrs := map[objectKey]error{}
+	for _, key := range keys {
+		var err error
+		switch req := objects[key].(type) {
+		case *saipb.SetHostifAttributeRequest:
+			_, err = attrmgr.InvokeAndSave(ctx, sw.mgr, sw.hostif.SetHostifAttribute, req)
+		case *saipb.SetHostifTrapAttributeRequest:
+			_, err = attrmgr.InvokeAndSave(ctx, sw.mgr, sw.hostif.SetHostifTrapAttribute, req)
+		case *saipb.SetHostifTrapGroupAttributeRequest:
+			_, err = attrmgr.InvokeAndSave(ctx, sw.mgr, sw.hostif.SetHostifTrapGroupAttribute, req)
+		case *saipb.SetPortAttributeRequest:
+			_, err = attrmgr.InvokeAndSave(ctx, sw.mgr, sw.port.SetPortAttribute, req)
+		case *saipb.SetRouterInterfaceAttributeRequest:
+			_, err = attrmgr.InvokeAndSave(ctx, sw.mgr, sw.routerInterface.SetRouterInterfaceAttribute, req)
+		case *saipb.SetSwitchAttributeRequest:
+			_, err = attrmgr.InvokeAndSave(ctx, sw.mgr, sw.SetSwitchAttribute, req)
+		default:
+			err = status.Errorf(codes.Unimplemented, "bulk set of %v not supported", key.msg)
+		}
+		if err != nil {
+			objErrs[key] = fmt.Errorf("object %d: %w", key.oid, err)
+		}
+	}
+	for i, key := range reqKeys {
+		if key != nil {
+			reqErrs[i] = objErrs[*key]
+		}
+	}
+	return reqErrs
+}
+
+// lookupRoute resolves the egress of dst in the virtual router vrf from the programmed routes, next hops and neighbors.
+// Next hop groups resolve to the next hop of the first member selected by the hash.
+func (sw *saiSwitch) lookupRoute(vrf uint64, dst net.IP) (*diagpb.LookupRouteResponse, error) {
+	if v4 := dst.To4(); v4 != nil {
+		dst = v4
+	}
+	var (
+		best     *saipb.RouteEntry
+		bestID   string
+		bestBits = -1
+	)
+	for _, id := range sw.mgr.GetObjectsOfType(saipb.ObjectType_OBJECT_TYPE_ROUTE_ENTRY) {
+		entry := &saipb.RouteEntry{}
+		if err := proto.Unmarshal([]byte(id), entry); err != nil {
+			return nil, err
+		}
+		prefix := &net.IPNet{IP: entry.GetDestination().GetAddr(), Mask: entry.GetDestination().GetMask()}
+		if entry.GetVrId() != vrf || len(prefix.IP) != len(dst) || !prefix.Contains(dst) {
+			continue
+		}
+		if bits, _ := prefix.Mask.Size(); bits > bestBits {
+			best, bestID, bestBits = entry, id, bits
+		}
+	}
+	if best == nil {
+		return nil, status.Errorf(codes.NotFound, "no route to %v in vrf %d", dst, vrf)
+	}
+	routeAttr := &saipb.RouteEntryAttribute{}
+	if err := sw.mgr.PopulateAllAttributes(bestID, routeAttr); err != nil {
+		return nil, err
+	}
+	switch routeAttr.GetPacketAction() {
+	case saipb.PacketAction_PACKET_ACTION_DROP, saipb.PacketAction_PACKET_ACTION_TRAP, saipb.PacketAction_PACKET_ACTION_DENY:
+		return nil, status.Errorf(codes.NotFound, "route to %v in vrf %d is not forwarded, action %v", dst, vrf, routeAttr.GetPacketAction())
+	}
+
+	res := &diagpb.LookupRouteResponse{
+		Route:     best,
+		NextHopIp: dst,
+	}
+	nextID := routeAttr.GetNextHopId()
+	switch t := sw.mgr.GetType(fmt.Sprint(nextID)); t {
+	case saipb.ObjectType_OBJECT_TYPE_NEXT_HOP_GROUP:
+		nh, ok := sw.nextHopGroup.firstNextHop(nextID)
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "next hop group %d has no members", nextID)
+		}
+		nextID = nh
+		fallthrough
+	case saipb.ObjectType_OBJECT_TYPE_NEXT_HOP:
+		nhAttr := &saipb.NextHopAttribute{}
+		if err := sw.mgr.PopulateAllAttributes(fmt.Sprint(nextID), nhAttr); err != nil {
+			return nil, err
+		}
+		if nhAttr.GetType() != saipb.NextHopType_NEXT_HOP_TYPE_IP {
+			return nil, status.Errorf(codes.Unimplemented, "lookup through next hop type %v not supported", nhAttr.GetType())
+		}
+		res.NextHop = nextID
+		res.NextHopIp = nhAttr.GetIp()
+		res.RouterInterface = nhAttr.GetRouterInterfaceId()
+	case saipb.ObjectType_OBJECT_TYPE_ROUTER_INTERFACE:
+		res.RouterInterface = nextID
+	default:
+		return nil, status.Errorf(codes.Unimplemented, "lookup through next hop object type %v not supported", t)
+	}
+
+	rifAttr := &saipb.RouterInterfaceAttribute{}
+	if err := sw.mgr.PopulateAllAttributes(fmt.Sprint(res.RouterInterface), rifAttr); err != nil {
+		return nil, err
+	}
+	res.Port = rifAttr.GetPortId()
+
+	for _, id := range sw.mgr.GetObjectsOfType(saipb.ObjectType_OBJECT_TYPE_NEIGHBOR_ENTRY) {
+		entry := &saipb.NeighborEntry{}
+		if err := proto.Unmarshal([]byte(id), entry); err != nil {
+			return nil, err
+		}
+		if entry.GetRifId() != res.RouterInterface || !net.IP(entry.GetIpAddress()).Equal(res.NextHopIp) {
+			continue
+		}
+		nbrAttr := &saipb.NeighborEntryAttribute{}
+		if err := sw.mgr.PopulateAllAttributes(id, nbrAttr); err != nil {
+			return nil, err
+		}
+		res.DstMac = nbrAttr.GetDstMacAddress()
+		return res, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "no neighbor for next hop %v on router interface %d", net.IP(res.NextHopIp), res.RouterInterface)
+}
+
 func (sw *saiSwitch) Reset() {
+	sw.created.Store(false)
+	sw.id.Store(0)
 	sw.port.Reset()
 	sw.hostif.Reset()
+	sw.route.Reset()
+	sw.lag.Reset()
+	sw.bridge.Reset()
 }
 
 // createFIBSelector creates a table that controls which forwarding table is used.
+// Multicast routes are looked up before the unicast FIB of each IP version.
 func createFIBSelector(ctx context.Context, id string, c switchDataplaneAPI) error {
 	fieldID := &fwdpb.PacketFieldId{
 		Field: &fwdpb.PacketField{
@@ -838,6 +1446,13 @@ func createFIBSelector(ctx context.Context, id string, c switchDataplaneAPI) err
 				},
 			},
 			Actions: []*fwdpb.ActionDesc{{
+				ActionType: fwdpb.ActionType_ACTION_TYPE_LOOKUP,
+				Action: &fwdpb.ActionDesc_Lookup{
+					Lookup: &fwdpb.LookupActionDesc{
+						TableId: &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: ipmcTable}},
+					},
+				},
+			}, {
 				ActionType: fwdpb.ActionType_ACTION_TYPE_LOOKUP,
 				Action: &fwdpb.ActionDesc_Lookup{
 					Lookup: &fwdpb.LookupActionDesc{
@@ -857,6 +1472,13 @@ func createFIBSelector(ctx context.Context, id string, c switchDataplaneAPI) err
 				},
 			},
 			Actions: []*fwdpb.ActionDesc{{
+				ActionType: fwdpb.ActionType_ACTION_TYPE_LOOKUP,
+				Action: &fwdpb.ActionDesc_Lookup{
+					Lookup: &fwdpb.LookupActionDesc{
+						TableId: &fwdpb.TableId{ObjectId: &fwdpb.ObjectId{Id: ipmcTable}},
+					},
+				},
+			}, {
 				ActionType: fwdpb.ActionType_ACTION_TYPE_LOOKUP,
 				Action: &fwdpb.ActionDesc_Lookup{
 					Lookup: &fwdpb.LookupActionDesc{
@@ -16,23 +16,29 @@ package saiserver
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log"
 	"net"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/gnmi/errdiff"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/openconfig/lemming/dataplane/dplaneopts"
 	"github.com/openconfig/lemming/dataplane/forwarding/infra/fwdcontext"
 	"github.com/openconfig/lemming/dataplane/saiserver/attrmgr"
 	fwdpb "github.com/openconfig/lemming/proto/forwarding"
 
+	diagpb "github.com/openconfig/lemming/dataplane/proto/diag"
 	saipb "github.com/openconfig/lemming/dataplane/proto/sai"
 )
 
@@ -50,25 +56,32 @@ func TestCreateSwitch(t *testing.T) {
 		t.Fatalf("CreateSwitch() failed: diff(-got,+want)\n:%s", d)
 	}
 	wantAttr := &saipb.SwitchAttribute{
-		CpuPort:                          proto.Uint64(2),
-		NumberOfActivePorts:              proto.Uint32(0),
-		AclEntryMinimumPriority:          proto.Uint32(1),
-		AclEntryMaximumPriority:          proto.Uint32(100),
-		AclTableMinimumPriority:          proto.Uint32(1),
-		AclTableMaximumPriority:          proto.Uint32(100),
-		MaxAclActionCount:                proto.Uint32(50),
-		NumberOfEcmpGroups:               proto.Uint32(1024),
-		PortList:                         []uint64{2},
-		SwitchHardwareInfo:               []int32{},
-		DefaultStpInstId:                 proto.Uint64(3),
-		DefaultVlanId:                    proto.Uint64(4),
-		DefaultVirtualRouterId:           proto.Uint64(5),
-		DefaultOverrideVirtualRouterId:   proto.Uint64(5),
-		Default_1QBridgeId:               proto.Uint64(6),
-		DefaultTrapGroup:                 proto.Uint64(7),
-		IngressAcl:                       proto.Uint64(0),
-		EgressAcl:                        proto.Uint64(0),
-		PreIngressAcl:                    proto.Uint64(0),
+		CpuPort:                        proto.Uint64(2),
+		NumberOfActivePorts:            proto.Uint32(0),
+		AclEntryMinimumPriority:        proto.Uint32(1),
+		AclEntryMaximumPriority:        proto.Uint32(100),
+		AclTableMinimumPriority:        proto.Uint32(1),
+		AclTableMaximumPriority:        proto.Uint32(100),
+		MaxAclActionCount:              proto.Uint32(50),
+		NumberOfEcmpGroups:             proto.Uint32(1024),
+		PortList:                       []uint64{2},
+		SwitchHardwareInfo:             []int32{},
+		DefaultStpInstId:               proto.Uint64(3),
+		DefaultVlanId:                  proto.Uint64(4),
+		DefaultVirtualRouterId:         proto.Uint64(5),
+		DefaultOverrideVirtualRouterId: proto.Uint64(5),
+		Default_1QBridgeId:             proto.Uint64(6),
+		DefaultTrapGroup:               proto.Uint64(7),
+		IngressAcl:                     proto.Uint64(0),
+		EgressAcl:                      proto.Uint64(0),
+		PreIngressAcl:                  proto.Uint64(0),
+		AvailableAclTableGroup: []*saipb.ACLResource{{
+			Stage:     saipb.AclStage_ACL_STAGE_INGRESS,
+			BindPoint: saipb.AclBindPointType_ACL_BIND_POINT_TYPE_PORT,
+		}, {
+			Stage:     saipb.AclStage_ACL_STAGE_EGRESS,
+			BindPoint: saipb.AclBindPointType_ACL_BIND_POINT_TYPE_PORT,
+		}},
 		AvailableIpv4RouteEntry:          proto.Uint32(1024),
 		AvailableIpv6RouteEntry:          proto.Uint32(1024),
 		AvailableIpv4NexthopEntry:        proto.Uint32(1024),
@@ -140,6 +153,61 @@ func TestCreateSwitch(t *testing.T) {
 	}
 }
 
+func TestSwitchLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s, conn, _ := newTestSaiServer(t, dplaneopts.WithRemoteCPUPort(true))
+	sc := saipb.NewSwitchClient(conn)
+	hc := saipb.NewHostifClient(conn)
+
+	createTrap := func() error {
+		_, err := hc.CreateHostifTrap(ctx, &saipb.CreateHostifTrapRequest{
+			TrapType:     saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+		})
+		return err
+	}
+	wantCode := func(desc string, err error, want codes.Code) {
+		t.Helper()
+		if got := status.Code(err); got != want {
+			t.Fatalf("%s: got code %v (err %v), want %v", desc, got, err, want)
+		}
+	}
+
+	_, err := hc.CreateHostif(ctx, &saipb.CreateHostifRequest{
+		Type:  saipb.HostifType_HOSTIF_TYPE_NETDEV.Enum(),
+		Name:  []byte("Ethernet1"),
+		ObjId: proto.Uint64(2),
+	})
+	wantCode("CreateHostif() before CreateSwitch()", err, codes.FailedPrecondition)
+	wantCode("CreateHostifTrap() before CreateSwitch()", createTrap(), codes.FailedPrecondition)
+	_, err = hc.CreateHostifTrapGroup(ctx, &saipb.CreateHostifTrapGroupRequest{})
+	wantCode("CreateHostifTrapGroup() before CreateSwitch()", err, codes.FailedPrecondition)
+	_, err = sc.RemoveSwitch(ctx, &saipb.RemoveSwitchRequest{Oid: switchID})
+	wantCode("RemoveSwitch() before CreateSwitch()", err, codes.NotFound)
+
+	for i := 0; i < 2; i++ {
+		resp, err := sc.CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+		if err != nil {
+			t.Fatalf("CreateSwitch() #%d unexpected err: %v", i, err)
+		}
+		if resp.GetOid() != switchID {
+			t.Fatalf("CreateSwitch() #%d got oid %d, want %d", i, resp.GetOid(), switchID)
+		}
+		_, err = sc.CreateSwitch(ctx, &saipb.CreateSwitchRequest{})
+		wantCode("CreateSwitch() of a created switch", err, codes.AlreadyExists)
+		wantCode("CreateHostifTrap() after CreateSwitch()", createTrap(), codes.OK)
+		_, err = sc.RemoveSwitch(ctx, &saipb.RemoveSwitchRequest{Oid: switchID + 1})
+		wantCode("RemoveSwitch() of another switch", err, codes.NotFound)
+
+		_, err = sc.RemoveSwitch(ctx, &saipb.RemoveSwitchRequest{Oid: switchID})
+		wantCode("RemoveSwitch()", err, codes.OK)
+		wantCode("CreateHostifTrap() after RemoveSwitch()", createTrap(), codes.FailedPrecondition)
+		if got := s.mgr.GetObjectsOfType(saipb.ObjectType_OBJECT_TYPE_HOSTIF_TRAP); len(got) != 0 {
+			t.Errorf("RemoveSwitch() left traps %v", got)
+		}
+	}
+}
+
 func TestSwitchPortStateChangeNotification(t *testing.T) {
 	tests := []struct {
 		desc    string
@@ -211,9 +279,429 @@ func TestSwitchPortStateChangeNotification(t *testing.T) {
 	}
 }
 
+func TestRemoveAll(t *testing.T) {
+	dplane := &fakeSwitchDataplane{}
+	var sw *saiSwitch
+	conn, mgr, stopFn := newTestServer(t, func(mgr *attrmgr.AttrMgr, srv *grpc.Server) {
+		sw, _ = newSwitch(mgr, dplane, srv, &dplaneopts.Options{})
+		diagpb.RegisterDiagServer(srv, &Server{mgr: mgr, saiSwitch: sw})
+	})
+	defer stopFn()
+	sw.hostif.initSwitch(switchID, 10)
+	hc := saipb.NewHostifClient(conn)
+	rc := saipb.NewRouteClient(conn)
+	dc := diagpb.NewDiagClient(conn)
+	removeAll := func(ty saipb.ObjectType) error {
+		_, err := dc.RemoveAll(context.Background(), &diagpb.RemoveAllRequest{Type: ty})
+		return err
+	}
+
+	trapTypes := []saipb.HostifTrapType{
+		saipb.HostifTrapType_HOSTIF_TRAP_TYPE_ARP_REQUEST,
+		saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LLDP,
+		saipb.HostifTrapType_HOSTIF_TRAP_TYPE_LACP,
+		saipb.HostifTrapType_HOSTIF_TRAP_TYPE_DHCP,
+		saipb.HostifTrapType_HOSTIF_TRAP_TYPE_IP2ME,
+	}
+	for _, tt := range trapTypes {
+		if _, err := hc.CreateHostifTrap(context.Background(), &saipb.CreateHostifTrapRequest{
+			TrapType:     tt.Enum(),
+			PacketAction: saipb.PacketAction_PACKET_ACTION_TRAP.Enum(),
+		}); err != nil {
+			t.Fatalf("CreateHostifTrap(%v) unexpected err: %v", tt, err)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		if _, err := rc.CreateRouteEntry(context.Background(), &saipb.CreateRouteEntryRequest{
+			Entry: &saipb.RouteEntry{
+				Destination: &saipb.IpPrefix{Addr: []byte{10, 0, byte(i), 0}, Mask: []byte{255, 255, 255, 0}},
+			},
+			PacketAction: saipb.PacketAction_PACKET_ACTION_DROP.Enum(),
+		}); err != nil {
+			t.Fatalf("CreateRouteEntry() unexpected err: %v", err)
+		}
+	}
+
+	// remaining returns the number of entries added minus removed for each table.
+	remaining := func() map[string]int {
+		got := map[string]int{}
+		for _, req := range dplane.gotEntryAddReqs {
+			got[req.GetTableId().GetObjectId().GetId()] += len(req.GetEntries())
+		}
+		for _, req := range dplane.gotEntryRemoveReqs {
+			got[req.GetTableId().GetObjectId().GetId()] -= len(req.GetEntries())
+			if req.GetEntryDesc() != nil {
+				got[req.GetTableId().GetObjectId().GetId()]--
+			}
+		}
+		return got
+	}
+	if d := cmp.Diff(remaining(), map[string]int{trapTableID: 5, FIBV4Table: 10}); d != "" {
+		t.Fatalf("entries after create: diff(-got,+want)\n:%s", d)
+	}
+
+	removeReqs := len(dplane.gotEntryRemoveReqs)
+	if err := removeAll(saipb.ObjectType_OBJECT_TYPE_HOSTIF_TRAP); err != nil {
+		t.Fatalf("RemoveAll(HOSTIF_TRAP) unexpected err: %v", err)
+	}
+	if d := cmp.Diff(remaining(), map[string]int{trapTableID: 0, FIBV4Table: 10}); d != "" {
+		t.Errorf("entries after trap remove all: diff(-got,+want)\n:%s", d)
+	}
+	if got := len(dplane.gotEntryRemoveReqs) - removeReqs; got != 1 {
+		t.Errorf("RemoveAll(HOSTIF_TRAP) sent %d entry remove requests, want 1", got)
+	}
+	removeReqs = len(dplane.gotEntryRemoveReqs)
+	if err := removeAll(saipb.ObjectType_OBJECT_TYPE_ROUTE_ENTRY); err != nil {
+		t.Fatalf("RemoveAll(ROUTE_ENTRY) unexpected err: %v", err)
+	}
+	if d := cmp.Diff(remaining(), map[string]int{trapTableID: 0, FIBV4Table: 0}); d != "" {
+		t.Errorf("entries after route remove all: diff(-got,+want)\n:%s", d)
+	}
+	if got := len(dplane.gotEntryRemoveReqs) - removeReqs; got != 1 {
+		t.Errorf("RemoveAll(ROUTE_ENTRY) sent %d entry remove requests, want 1", got)
+	}
+	for _, ty := range []saipb.ObjectType{saipb.ObjectType_OBJECT_TYPE_HOSTIF_TRAP, saipb.ObjectType_OBJECT_TYPE_ROUTE_ENTRY} {
+		if got := mgr.GetObjectsOfType(ty); len(got) != 0 {
+			t.Errorf("GetObjectsOfType(%v) got %d objects after remove all, want 0", ty, len(got))
+		}
+	}
+	if err := removeAll(saipb.ObjectType_OBJECT_TYPE_PORT); status.Code(err) != codes.Unimplemented {
+		t.Errorf("RemoveAll(PORT) got err %v, want unimplemented", err)
+	}
+}
+
+func TestSetAttributes(t *testing.T) {
+	dplane := &fakeSwitchDataplane{}
+	conn, mgr, stopFn := newTestServer(t, func(mgr *attrmgr.AttrMgr, srv *grpc.Server) {
+		sw, _ := newSwitch(mgr, dplane, srv, &dplaneopts.Options{})
+		saipb.RegisterEntrypointServer(srv, &Server{mgr: mgr, saiSwitch: sw})
+	})
+	defer stopFn()
+	c := saipb.NewEntrypointClient(conn)
+
+	const numHostifs = 50
+	req := &saipb.SetAttributesRequest{}
+	addReq := func(msg proto.Message) {
+		a, err := anypb.New(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Reqs = append(req.Reqs, a)
+	}
+	for i := uint64(1); i <= numHostifs; i++ {
+		addReq(&saipb.SetHostifAttributeRequest{
+			Oid:        i,
+			OperStatus: proto.Bool(false),
+		})
+	}
+	// A later request for the same object is merged with the earlier one.
+	addReq(&saipb.SetHostifAttributeRequest{
+		Oid:        1,
+		OperStatus: proto.Bool(true),
+	})
+	addReq(&saipb.SetNextHopAttributeRequest{Oid: 100})
+	resp, err := c.SetAttributes(context.Background(), req)
+	if err != nil {
+		t.Fatalf("SetAttributes() unexpected err: %v", err)
+	}
+	if got, want := len(resp.GetStatuses()), numHostifs+2; got != want {
+		t.Fatalf("SetAttributes() got %d statuses, want %d", got, want)
+	}
+	for i, st := range resp.GetStatuses()[:numHostifs+1] {
+		if codes.Code(st.GetCode()) != codes.OK {
+			t.Errorf("SetAttributes() request %d got status %v, want OK", i, st)
+		}
+	}
+	if st := resp.GetStatuses()[numHostifs+1]; codes.Code(st.GetCode()) != codes.Unimplemented {
+		t.Errorf("SetAttributes(SetNextHopAttributeRequest) got status %v, want Unimplemented", st)
+	}
+
+	if got := len(dplane.gotPortStateReq); got != numHostifs {
+		t.Fatalf("SetAttributes() got %d port state requests, want %d", got, numHostifs)
+	}
+	for i, req := range dplane.gotPortStateReq {
+		oid := uint64(i + 1)
+		want := &fwdpb.PortStateRequest{
+			ContextId: &fwdpb.ContextId{Id: "foo"},
+			PortId:    &fwdpb.PortId{ObjectId: &fwdpb.ObjectId{Id: fmt.Sprint(oid)}},
+			Operation: &fwdpb.PortInfo{AdminStatus: fwdpb.PortState_PORT_STATE_DISABLED_DOWN},
+		}
+		if oid == 1 {
+			want.Operation.AdminStatus = fwdpb.PortState_PORT_STATE_ENABLED_UP
+		}
+		if d := cmp.Diff(req, want, protocmp.Transform()); d != "" {
+			t.Errorf("SetAttributes() port state request %d: diff(-got,+want)\n:%s", i, d)
+		}
+		attr := &saipb.HostifAttribute{}
+		if err := mgr.PopulateAllAttributes(fmt.Sprint(oid), attr); err != nil {
+			t.Fatal(err)
+		}
+		if d := cmp.Diff(attr, &saipb.HostifAttribute{OperStatus: proto.Bool(oid == 1)}, protocmp.Transform()); d != "" {
+			t.Errorf("SetAttributes() hostif %d attributes: diff(-got,+want)\n:%s", oid, d)
+		}
+	}
+}
+
+func TestSetSwitchHashes(t *testing.T) {
+	dplane := &fakeSwitchDataplane{}
+	conn, mgr, stopFn := newTestServer(t, func(mgr *attrmgr.AttrMgr, srv *grpc.Server) {
+		sw, _ := newSwitch(mgr, dplane, srv, &dplaneopts.Options{})
+		sw.id.Store(mgr.NextID())
+	})
+	defer stopFn()
+	mgr.StoreAttributes(switchID, &saipb.SwitchAttribute{EcmpHashIpv4: proto.Uint64(10), EcmpHashIpv6: proto.Uint64(10)})
+	mgr.StoreAttributes(10, &saipb.CreateHashRequest{
+		NativeHashFieldList: []saipb.NativeHashField{saipb.NativeHashField_NATIVE_HASH_FIELD_SRC_MAC, saipb.NativeHashField_NATIVE_HASH_FIELD_DST_MAC},
+	})
+	mgr.StoreAttributes(11, &saipb.CreateHashRequest{
+		NativeHashFieldList: []saipb.NativeHashField{
+			saipb.NativeHashField_NATIVE_HASH_FIELD_SRC_IP,
+			saipb.NativeHashField_NATIVE_HASH_FIELD_DST_IP,
+			saipb.NativeHashField_NATIVE_HASH_FIELD_IP_PROTOCOL,
+			saipb.NativeHashField_NATIVE_HASH_FIELD_L4_SRC_PORT,
+			saipb.NativeHashField_NATIVE_HASH_FIELD_L4_DST_PORT,
+		},
+	})
+	mgr.StoreAttributes(12, &saipb.CreateNextHopRequest{Ip: []byte{10, 0, 0, 1}})
+	fieldIDs := func(nums ...fwdpb.PacketFieldNum) []*fwdpb.PacketFieldId {
+		var ids []*fwdpb.PacketFieldId
+		for _, num := range nums {
+			ids = append(ids, &fwdpb.PacketFieldId{Field: &fwdpb.PacketField{FieldNum: num}})
+		}
+		return ids
+	}
+	wantL2 := fieldIDs(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_SRC, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_ETHER_MAC_DST)
+	wantL3 := fieldIDs(fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_SRC, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_ADDR_DST,
+		fwdpb.PacketFieldNum_PACKET_FIELD_NUM_IP_PROTO, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_L4_PORT_SRC, fwdpb.PacketFieldNum_PACKET_FIELD_NUM_L4_PORT_DST)
+
+	ctx := context.Background()
+	sw := saipb.NewSwitchClient(conn)
+	lag := saipb.NewLagClient(conn)
+	nhg := saipb.NewNextHopGroupClient(conn)
+
+	// Existing LAGs are reprogrammed when the LAG hash is bound.
+	lagResp, err := lag.CreateLag(ctx, &saipb.CreateLagRequest{})
+	if err != nil {
+		t.Fatalf("CreateLag() unexpected err: %v", err)
+	}
+	if _, err := sw.SetSwitchAttribute(ctx, &saipb.SetSwitchAttributeRequest{Oid: 1, LagHashIpv4: proto.Uint64(10)}); err != nil {
+		t.Fatalf("SetSwitchAttribute(LagHashIpv4) unexpected err: %v", err)
+	}
+	if _, err := sw.SetSwitchAttribute(ctx, &saipb.SetSwitchAttributeRequest{Oid: 1, EcmpHashIpv4: proto.Uint64(11)}); err != nil {
+		t.Fatalf("SetSwitchAttribute(EcmpHashIpv4) unexpected err: %v", err)
+	}
+	upd := dplane.gotPortUpdateReqs[len(dplane.gotPortUpdateReqs)-1]
+	if got := upd.GetPortId().GetObjectId().GetId(); got != fmt.Sprint(lagResp.GetOid()) {
+		t.Fatalf("last port update got port %s, want LAG %d", got, lagResp.GetOid())
+	}
+	if d := cmp.Diff(upd.GetUpdate().GetAggregateAlgo().GetFieldIds(), wantL2, protocmp.Transform()); d != "" {
+		t.Errorf("LAG hash fields: diff(-got,+want)\n:%s", d)
+	}
+
+	groupResp, err := nhg.CreateNextHopGroup(ctx, &saipb.CreateNextHopGroupRequest{Type: saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_DYNAMIC_UNORDERED_ECMP.Enum()})
+	if err != nil {
+		t.Fatalf("CreateNextHopGroup() unexpected err: %v", err)
+	}
+	if _, err := nhg.CreateNextHopGroupMember(ctx, &saipb.CreateNextHopGroupMemberRequest{
+		NextHopGroupId: proto.Uint64(groupResp.GetOid()),
+		NextHopId:      proto.Uint64(12),
+	}); err != nil {
+		t.Fatalf("CreateNextHopGroupMember() unexpected err: %v", err)
+	}
+	entry := dplane.gotEntryAddReqs[len(dplane.gotEntryAddReqs)-1]
+	if d := cmp.Diff(entry.GetEntries()[0].GetActions()[0].GetSelect().GetFieldIds(), wantL3, protocmp.Transform()); d != "" {
+		t.Errorf("ECMP hash fields: diff(-got,+want)\n:%s", d)
+	}
+
+	// Rebinding the ECMP hash doesn't affect the LAGs.
+	numUpdates := len(dplane.gotPortUpdateReqs)
+	if _, err := sw.SetSwitchAttribute(ctx, &saipb.SetSwitchAttributeRequest{Oid: 1, EcmpHashIpv4: proto.Uint64(10)}); err != nil {
+		t.Fatalf("SetSwitchAttribute(EcmpHashIpv4) unexpected err: %v", err)
+	}
+	if got := len(dplane.gotPortUpdateReqs); got != numUpdates {
+		t.Errorf("binding ECMP hash sent %d port updates, want 0", got-numUpdates)
+	}
+	entry = dplane.gotEntryAddReqs[len(dplane.gotEntryAddReqs)-1]
+	if d := cmp.Diff(entry.GetEntries()[0].GetActions()[0].GetSelect().GetFieldIds(), wantL2, protocmp.Transform()); d != "" {
+		t.Errorf("rebound ECMP hash fields: diff(-got,+want)\n:%s", d)
+	}
+
+	if _, err := sw.SetSwitchAttribute(ctx, &saipb.SetSwitchAttributeRequest{Oid: 1, LagHashIpv4: proto.Uint64(99)}); err == nil {
+		t.Errorf("SetSwitchAttribute(LagHashIpv4) with unknown hash got nil err, want error")
+	}
+}
+
+func TestLookupRoute(t *testing.T) {
+	dplane := &fakeSwitchDataplane{}
+	conn, mgr, stopFn := newTestServer(t, func(mgr *attrmgr.AttrMgr, srv *grpc.Server) {
+		sw, _ := newSwitch(mgr, dplane, srv, &dplaneopts.Options{})
+		sw.id.Store(switchID)
+		diagpb.RegisterDiagServer(srv, &Server{mgr: mgr, saiSwitch: sw})
+	})
+	defer stopFn()
+	ctx := context.Background()
+	dc := diagpb.NewDiagClient(conn)
+	nc := saipb.NewNextHopClient(conn)
+	rc := saipb.NewRouteClient(conn)
+	nbrc := saipb.NewNeighborClient(conn)
+	nhgc := saipb.NewNextHopGroupClient(conn)
+
+	const (
+		rifID  = 100
+		portID = 5
+		hashID = 101
+	)
+	mgr.StoreAttributes(rifID, &saipb.CreateRouterInterfaceRequest{PortId: proto.Uint64(portID)})
+	mgr.SetType(fmt.Sprint(rifID), saipb.ObjectType_OBJECT_TYPE_ROUTER_INTERFACE)
+	mgr.StoreAttributes(switchID, &saipb.SwitchAttribute{EcmpHashIpv4: proto.Uint64(hashID), EcmpHashIpv6: proto.Uint64(hashID)})
+	mgr.StoreAttributes(hashID, &saipb.CreateHashRequest{
+		NativeHashFieldList: []saipb.NativeHashField{saipb.NativeHashField_NATIVE_HASH_FIELD_DST_IP},
+	})
+
+	nh, err := nc.CreateNextHop(ctx, &saipb.CreateNextHopRequest{
+		Type:              saipb.NextHopType_NEXT_HOP_TYPE_IP.Enum(),
+		Ip:                []byte{192, 168, 0, 2},
+		RouterInterfaceId: proto.Uint64(rifID),
+	})
+	if err != nil {
+		t.Fatalf("CreateNextHop() unexpected err: %v", err)
+	}
+	nhg, err := nhgc.CreateNextHopGroup(ctx, &saipb.CreateNextHopGroupRequest{
+		Type: saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_DYNAMIC_UNORDERED_ECMP.Enum(),
+	})
+	if err != nil {
+		t.Fatalf("CreateNextHopGroup() unexpected err: %v", err)
+	}
+	if _, err := nhgc.CreateNextHopGroupMember(ctx, &saipb.CreateNextHopGroupMemberRequest{
+		NextHopGroupId: proto.Uint64(nhg.GetOid()),
+		NextHopId:      proto.Uint64(nh.GetOid()),
+	}); err != nil {
+		t.Fatalf("CreateNextHopGroupMember() unexpected err: %v", err)
+	}
+	emptyNHG, err := nhgc.CreateNextHopGroup(ctx, &saipb.CreateNextHopGroupRequest{
+		Type: saipb.NextHopGroupType_NEXT_HOP_GROUP_TYPE_DYNAMIC_UNORDERED_ECMP.Enum(),
+	})
+	if err != nil {
+		t.Fatalf("CreateNextHopGroup() unexpected err: %v", err)
+	}
+	routes := []*saipb.CreateRouteEntryRequest{{
+		Entry:     &saipb.RouteEntry{VrId: 1, Destination: &saipb.IpPrefix{Addr: []byte{10, 0, 0, 0}, Mask: []byte{255, 0, 0, 0}}},
+		NextHopId: proto.Uint64(nh.GetOid()),
+	}, {
+		Entry:     &saipb.RouteEntry{VrId: 1, Destination: &saipb.IpPrefix{Addr: []byte{10, 1, 0, 0}, Mask: []byte{255, 255, 0, 0}}},
+		NextHopId: proto.Uint64(rifID),
+	}, {
+		Entry:        &saipb.RouteEntry{VrId: 1, Destination: &saipb.IpPrefix{Addr: []byte{10, 2, 0, 0}, Mask: []byte{255, 255, 0, 0}}},
+		PacketAction: saipb.PacketAction_PACKET_ACTION_DROP.Enum(),
+	}, {
+		Entry:     &saipb.RouteEntry{VrId: 1, Destination: &saipb.IpPrefix{Addr: []byte{10, 3, 0, 0}, Mask: []byte{255, 255, 0, 0}}},
+		NextHopId: proto.Uint64(nhg.GetOid()),
+	}, {
+		Entry:     &saipb.RouteEntry{VrId: 1, Destination: &saipb.IpPrefix{Addr: []byte{10, 4, 0, 0}, Mask: []byte{255, 255, 0, 0}}},
+		NextHopId: proto.Uint64(emptyNHG.GetOid()),
+	}}
+	for _, req := range routes {
+		if _, err := rc.CreateRouteEntry(ctx, req); err != nil {
+			t.Fatalf("CreateRouteEntry() unexpected err: %v", err)
+		}
+	}
+	nbrs := []*saipb.CreateNeighborEntryRequest{{
+		Entry:         &saipb.NeighborEntry{RifId: rifID, IpAddress: []byte{192, 168, 0, 2}},
+		DstMacAddress: []byte{0, 0, 0, 0, 0, 0x2},
+	}, {
+		Entry:         &saipb.NeighborEntry{RifId: rifID, IpAddress: []byte{10, 1, 0, 9}},
+		DstMacAddress: []byte{0, 0, 0, 0, 0, 0x9},
+	}}
+	for _, req := range nbrs {
+		if _, err := nbrc.CreateNeighborEntry(ctx, req); err != nil {
+			t.Fatalf("CreateNeighborEntry() unexpected err: %v", err)
+		}
+	}
+
+	tests := []struct {
+		desc     string
+		vrf      uint64
+		dst      string
+		want     *diagpb.LookupRouteResponse
+		wantCode codes.Code
+	}{{
+		desc: "via next hop",
+		vrf:  1,
+		dst:  "10.5.4.5",
+		want: &diagpb.LookupRouteResponse{
+			Route:           routes[0].GetEntry(),
+			NextHop:         nh.GetOid(),
+			NextHopIp:       []byte{192, 168, 0, 2},
+			RouterInterface: rifID,
+			Port:            portID,
+			DstMac:          []byte{0, 0, 0, 0, 0, 0x2},
+		},
+	}, {
+		desc: "longest prefix connected",
+		vrf:  1,
+		dst:  "10.1.0.9",
+		want: &diagpb.LookupRouteResponse{
+			Route:           routes[1].GetEntry(),
+			NextHopIp:       []byte{10, 1, 0, 9},
+			RouterInterface: rifID,
+			Port:            portID,
+			DstMac:          []byte{0, 0, 0, 0, 0, 0x9},
+		},
+	}, {
+		desc: "via next hop group",
+		vrf:  1,
+		dst:  "10.3.4.5",
+		want: &diagpb.LookupRouteResponse{
+			Route:           routes[3].GetEntry(),
+			NextHop:         nh.GetOid(),
+			NextHopIp:       []byte{192, 168, 0, 2},
+			RouterInterface: rifID,
+			Port:            portID,
+			DstMac:          []byte{0, 0, 0, 0, 0, 0x2},
+		},
+	}, {
+		desc:     "next hop group without members",
+		vrf:      1,
+		dst:      "10.4.0.1",
+		wantCode: codes.NotFound,
+	}, {
+		desc:     "connected without neighbor",
+		vrf:      1,
+		dst:      "10.1.0.10",
+		wantCode: codes.NotFound,
+	}, {
+		desc:     "dropped",
+		vrf:      1,
+		dst:      "10.2.0.1",
+		wantCode: codes.NotFound,
+	}, {
+		desc:     "no route",
+		vrf:      1,
+		dst:      "11.0.0.1",
+		wantCode: codes.NotFound,
+	}, {
+		desc:     "other vrf",
+		vrf:      2,
+		dst:      "10.5.4.5",
+		wantCode: codes.NotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := dc.LookupRoute(ctx, &diagpb.LookupRouteRequest{Vrf: tt.vrf, Dst: net.ParseIP(tt.dst)})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("LookupRoute(%v, %v) got code %v, want %v: %v", tt.vrf, tt.dst, code, tt.wantCode, err)
+			}
+			if d := cmp.Diff(got, tt.want, protocmp.Transform()); d != "" {
+				t.Errorf("LookupRoute(%v, %v) failed: diff(-got,+want)\n:%s", tt.vrf, tt.dst, d)
+			}
+		})
+	}
+}
+
 type fakeSwitchDataplane struct {
 	events                   []*fwdpb.EventDesc
 	gotEntryAddReqs          []*fwdpb.TableEntryAddRequest
+	gotEntryRemoveReqs       []*fwdpb.TableEntryRemoveRequest
 	gotPortStateReq          []*fwdpb.PortStateRequest
 	counterReplies           []*fwdpb.ObjectCountersReply
 	gotPortCreateReqs        []*fwdpb.PortCreateRequest
@@ -221,6 +709,8 @@ type fakeSwitchDataplane struct {
 	gotObjectDeleteReqs      []*fwdpb.ObjectDeleteRequest
 	gotFlowCounterCreateReqs []*fwdpb.FlowCounterCreateRequest
 	gotFlowCounterQueryReqs  []*fwdpb.FlowCounterQueryRequest
+	gotSetUpdateReqs         []*fwdpb.SetUpdateRequest
+	gotAttributeUpdateReqs   []*fwdpb.AttributeUpdateRequest
 	portIDToNID              map[string]uint64
 	counterRepliesIdx        int
 	flowQueryReplies         []*fwdpb.FlowCounterQueryReply
@@ -244,7 +734,8 @@ func (f *fakeSwitchDataplane) TableEntryAdd(_ context.Context, req *fwdpb.TableE
 	return nil, nil
 }
 
-func (f *fakeSwitchDataplane) TableEntryRemove(context.Context, *fwdpb.TableEntryRemoveRequest) (*fwdpb.TableEntryRemoveReply, error) {
+func (f *fakeSwitchDataplane) TableEntryRemove(_ context.Context, req *fwdpb.TableEntryRemoveRequest) (*fwdpb.TableEntryRemoveReply, error) {
+	f.gotEntryRemoveReqs = append(f.gotEntryRemoveReqs, req)
 	return nil, nil
 }
 
@@ -285,7 +776,8 @@ func (f *fakeSwitchDataplane) PortUpdate(_ context.Context, req *fwdpb.PortUpdat
 	return nil, nil
 }
 
-func (f *fakeSwitchDataplane) AttributeUpdate(context.Context, *fwdpb.AttributeUpdateRequest) (*fwdpb.AttributeUpdateReply, error) {
+func (f *fakeSwitchDataplane) AttributeUpdate(_ context.Context, req *fwdpb.AttributeUpdateRequest) (*fwdpb.AttributeUpdateReply, error) {
+	f.gotAttributeUpdateReqs = append(f.gotAttributeUpdateReqs, req)
 	return nil, nil
 }
 
@@ -317,6 +809,97 @@ func (f *fakeSwitchDataplane) FlowCounterQuery(_ context.Context, req *fwdpb.Flo
 	return r, nil
 }
 
+func (f *fakeSwitchDataplane) SetCreate(context.Context, *fwdpb.SetCreateRequest) (*fwdpb.SetCreateReply, error) {
+	return nil, nil
+}
+
+func (f *fakeSwitchDataplane) SetUpdate(_ context.Context, req *fwdpb.SetUpdateRequest) (*fwdpb.SetUpdateReply, error) {
+	f.gotSetUpdateReqs = append(f.gotSetUpdateReqs, req)
+	return nil, nil
+}
+
+// fault is an error returned by a call to the dataplane after skip successful calls.
+type fault struct {
+	skip int
+	err  error
+}
+
+// faultyDataplane wraps a switchDataplaneAPI and fails calls to it on demand.
+// Calls that have no pending fault are passed to the wrapped dataplane.
+type faultyDataplane struct {
+	switchDataplaneAPI
+	mu     sync.Mutex
+	faults map[string]*fault
+}
+
+func newFaultyDataplane(api switchDataplaneAPI) *faultyDataplane {
+	return &faultyDataplane{
+		switchDataplaneAPI: api,
+		faults:             map[string]*fault{},
+	}
+}
+
+// fail makes the call to method after skip successful calls return err.
+func (f *faultyDataplane) fail(method string, skip int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults[method] = &fault{skip: skip, err: err}
+}
+
+// inject returns the pending fault of method, if it is due.
+func (f *faultyDataplane) inject(method string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ft, ok := f.faults[method]
+	if !ok {
+		return nil
+	}
+	if ft.skip > 0 {
+		ft.skip--
+		return nil
+	}
+	delete(f.faults, method)
+	return ft.err
+}
+
+func (f *faultyDataplane) PortCreate(ctx context.Context, req *fwdpb.PortCreateRequest) (*fwdpb.PortCreateReply, error) {
+	if err := f.inject("PortCreate"); err != nil {
+		return nil, err
+	}
+	return f.switchDataplaneAPI.PortCreate(ctx, req)
+}
+
+func (f *faultyDataplane) PortUpdate(ctx context.Context, req *fwdpb.PortUpdateRequest) (*fwdpb.PortUpdateReply, error) {
+	if err := f.inject("PortUpdate"); err != nil {
+		return nil, err
+	}
+	return f.switchDataplaneAPI.PortUpdate(ctx, req)
+}
+
+func (f *faultyDataplane) TableEntryAdd(ctx context.Context, req *fwdpb.TableEntryAddRequest) (*fwdpb.TableEntryAddReply, error) {
+	if err := f.inject("TableEntryAdd"); err != nil {
+		return nil, err
+	}
+	return f.switchDataplaneAPI.TableEntryAdd(ctx, req)
+}
+
+func (f *faultyDataplane) FindContext(id *fwdpb.ContextId) (*fwdcontext.Context, error) {
+	if err := f.inject("FindContext"); err != nil {
+		return nil, err
+	}
+	return f.switchDataplaneAPI.FindContext(id)
+}
+
+func (f *faultyDataplane) AttributeUpdate(ctx context.Context, req *fwdpb.AttributeUpdateRequest) (*fwdpb.AttributeUpdateReply, error) {
+	if err := f.inject("AttributeUpdate"); err != nil {
+		return nil, err
+	}
+	return f.switchDataplaneAPI.AttributeUpdate(ctx, req)
+}
+
+// switchID is the ID of the switch in tests, the switch is the first object created.
+const switchID = 1
+
 func newTestServer(t testing.TB, newSrvFn func(mgr *attrmgr.AttrMgr, srv *grpc.Server)) (grpc.ClientConnInterface, *attrmgr.AttrMgr, func()) {
 	t.Helper()
 	mgr := attrmgr.New()
@@ -340,6 +923,55 @@ func newTestServer(t testing.TB, newSrvFn func(mgr *attrmgr.AttrMgr, srv *grpc.S
 	return conn, mgr, srv.Stop
 }
 
+// newTestSaiServer starts a server with a fake dataplane configured with opts, and returns it with a
+// connection to it and its forwarding context. The server's ports don't receive packets and discard the
+// packets written to them, unless the test replaces the context's FakePortManager.
+// The server is stopped when the test ends.
+func newTestSaiServer(t testing.TB, opts ...dplaneopts.Option) (*Server, grpc.ClientConnInterface, *fwdcontext.Context) {
+	t.Helper()
+	opts = append([]dplaneopts.Option{dplaneopts.WithPortType(fwdpb.PortType_PORT_TYPE_FAKE)}, opts...)
+	var s *Server
+	conn, _, stopFn := newTestServer(t, func(mgr *attrmgr.AttrMgr, srv *grpc.Server) {
+		var err error
+		s, err = New(context.Background(), mgr, srv, dplaneopts.ResolveOpts(opts...))
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Cleanup(stopFn)
+	fwdCtx, err := s.FindContext(&fwdpb.ContextId{Id: s.ID()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fwdCtx.FakePortManager = nopPortManager{}
+	return s, conn, fwdCtx
+}
+
+// acceptAnyDstMAC creates a my MAC entry that accepts packets with any destination MAC.
+func acceptAnyDstMAC(t testing.TB, conn grpc.ClientConnInterface) {
+	t.Helper()
+	if _, err := saipb.NewMyMacClient(conn).CreateMyMac(context.Background(), &saipb.CreateMyMacRequest{
+		MacAddress:     make([]byte, 6),
+		MacAddressMask: make([]byte, 6),
+		Priority:       proto.Uint32(1),
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// createTestPort creates an admin up port on the lane and returns its id.
+func createTestPort(t testing.TB, conn grpc.ClientConnInterface, lane uint32) uint64 {
+	t.Helper()
+	port, err := saipb.NewPortClient(conn).CreatePort(context.Background(), &saipb.CreatePortRequest{
+		HwLaneList: []uint32{lane},
+		AdminState: proto.Bool(true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return port.GetOid()
+}
+
 func newTestSwitch(t testing.TB, dplane switchDataplaneAPI) (saipb.SwitchClient, *attrmgr.AttrMgr, func()) {
 	conn, mgr, stopFn := newTestServer(t, func(mgr *attrmgr.AttrMgr, srv *grpc.Server) {
 		newSwitch(mgr, dplane, srv, &dplaneopts.Options{})
@@ -45,7 +45,7 @@ func newTunnel(mgr *attrmgr.AttrMgr, dataplane switchDataplaneAPI, s *grpc.Serve
 }
 
 func (t *tunnel) CreateTunnel(ctx context.Context, req *saipb.CreateTunnelRequest) (*saipb.CreateTunnelResponse, error) {
-	id := t.mgr.NextID()
+	id := t.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_TUNNEL)
 
 	tunType := req.GetType()
 	switch tunType {
@@ -180,7 +180,7 @@ func termFieldsFromReq(req *saipb.CreateTunnelTermTableEntryRequest) ([]*fwdpb.P
 }
 
 func (t *tunnel) CreateTunnelTermTableEntry(ctx context.Context, req *saipb.CreateTunnelTermTableEntryRequest) (*saipb.CreateTunnelTermTableEntryResponse, error) {
-	id := t.mgr.NextID()
+	id := t.mgr.NextTypedID(saipb.ObjectType_OBJECT_TYPE_TUNNEL_TERM_TABLE_ENTRY)
 
 	fields, headerID, err := termFieldsFromReq(req)
 	if err != nil {
@@ -60,7 +60,14 @@ func New(ctx context.Context, opts ...dplaneopts.Option) (*Dataplane, error) {
 		return nil, fmt.Errorf("failed to listen: %w", err)
 	}
 
-	mgr := attrmgr.New()
+	var mgrOpts []attrmgr.Option
+	if data.opt.DeterministicOIDs {
+		mgrOpts = append(mgrOpts, attrmgr.WithTypedIDs())
+	}
+	if data.opt.StrictSerialization {
+		mgrOpts = append(mgrOpts, attrmgr.WithSerializedMutations())
+	}
+	mgr := attrmgr.New(mgrOpts...)
 	srv := grpc.NewServer(grpc.Creds(local.NewCredentials()), grpc.ChainUnaryInterceptor(mgr.Interceptor))
 	reflection.Register(srv)
 
@@ -180,7 +187,7 @@ func (d *Dataplane) Start(ctx context.Context, c gpb.GNMIClient, target string)
 	go h.StreamPackets(packet)
 
 	if d.opt.Reconcilation {
-		d.reconcilers = append(d.reconcilers, getReconcilers(conn, swResp.Oid, *swAttrs.GetAttr().CpuPort, "lucius")...)
+		d.reconcilers = append(d.reconcilers, getReconcilers(conn, swResp.Oid, *swAttrs.GetAttr().CpuPort, d.opt.ContextID)...)
 
 		for _, rec := range d.reconcilers {
 			if err := rec.Start(ctx, c, target); err != nil {
@@ -69,6 +69,13 @@ type portIO interface {
 	Read([]byte) (int, error)
 }
 
+// genetlinkPortIO is a port writing to a genetlink family and multicast group, whose IDs are resolved by the kernel.
+type genetlinkPortIO interface {
+	portIO
+	FamilyID() uint16
+	GroupID() uint32
+}
+
 // StreamPackets sends and receives packets from a lucius CPU port.
 func (m *PacketIOMgr) StreamPackets(c pktiopb.PacketIO_CPUPacketStreamClient) error {
 	if err := c.Send(&pktiopb.PacketIn{Msg: &pktiopb.PacketIn_Init{}}); err != nil {
@@ -153,15 +160,19 @@ func (m *PacketIOMgr) ManagePorts(c pktiopb.PacketIO_HostPortControlClient) erro
 			st := &status.Status{
 				Code: int32(codes.OK),
 			}
-			if err := m.createPort(resp); err != nil {
+			ids, err := m.createPort(resp)
+			if err != nil {
 				st = &status.Status{
 					Code:    int32(codes.Internal),
 					Message: err.Error(),
 				}
 			}
-			sendErr := c.Send(&pktiopb.HostPortControlRequest{Msg: &pktiopb.HostPortControlRequest_Status{
-				Status: st,
-			}})
+			sendErr := c.Send(&pktiopb.HostPortControlRequest{
+				Msg: &pktiopb.HostPortControlRequest_Status{
+					Status: st,
+				},
+				GenetlinkIds: ids,
+			})
 			if sendErr != nil {
 				return sendErr
 			}
@@ -213,31 +224,43 @@ func (m *PacketIOMgr) ManagePorts(c pktiopb.PacketIO_HostPortControlClient) erro
 	}
 }
 
-var createTAPFunc = kernel.NewTap
+var (
+	createTAPFunc       = kernel.NewTap
+	createGenetlinkFunc = func(family, group string) (genetlinkPortIO, error) {
+		return kernel.NewGenetlinkPort(family, group)
+	}
+)
 
-func (m *PacketIOMgr) createPort(msg *pktiopb.HostPortControlMessage) error {
+// createPort creates the port described by msg. For genetlink ports, it returns the IDs the kernel resolved
+// for the family and group, so the server can report them.
+func (m *PacketIOMgr) createPort(msg *pktiopb.HostPortControlMessage) (*pktiopb.GenetlinkPortIds, error) {
 	var p portIO
+	var ids *pktiopb.GenetlinkPortIds
 	switch msg.GetPort().(type) {
 	case *pktiopb.HostPortControlMessage_Genetlink:
 		portDesc := msg.GetGenetlink()
-		var err error
-		p, err = kernel.NewGenetlinkPort(portDesc.Family, portDesc.Group)
+		gp, err := createGenetlinkFunc(portDesc.Family, portDesc.Group)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		p = gp
+		ids = &pktiopb.GenetlinkPortIds{
+			FamilyId: uint32(gp.FamilyID()),
+			GroupId:  gp.GroupID(),
 		}
-		log.Infof("add to new genetlink port: %v %v", portDesc.Family, portDesc.Group)
+		log.Infof("add to new genetlink port: %v (%d) %v (%d)", portDesc.Family, ids.GetFamilyId(), portDesc.Group, ids.GetGroupId())
 	case *pktiopb.HostPortControlMessage_Netdev:
 		name := msg.GetNetdev().GetName()
 		var err error
 		kp, err := createTAPFunc(name)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		p = kp
 		m.dplanePortIfIndex[msg.GetDataplanePort()] = kp.IfIndex()
 		log.Infof("add to new netdev port: %v", name)
 	default:
-		return fmt.Errorf("unsupported port type: %v", msg.GetPort())
+		return nil, fmt.Errorf("unsupported port type: %v", msg.GetPort())
 	}
 
 	doneCh := make(chan struct{})
@@ -249,7 +272,7 @@ func (m *PacketIOMgr) createPort(msg *pktiopb.HostPortControlMessage) error {
 
 	m.queueRead(msg.GetPortId(), doneCh)
 
-	return nil
+	return ids, nil
 }
 
 func (m *PacketIOMgr) queueRead(id uint64, done chan struct{}) {
@@ -107,6 +107,7 @@ func TestManagePorts(t *testing.T) {
 		msgs    []*pktiopb.HostPortControlMessage
 		wantErr string
 		want    codes.Code
+		wantIDs *pktiopb.GenetlinkPortIds
 	}{{
 		desc: "create",
 		msgs: []*pktiopb.HostPortControlMessage{{
@@ -120,6 +121,21 @@ func TestManagePorts(t *testing.T) {
 			Create:        true,
 		}},
 		want: codes.OK,
+	}, {
+		desc: "create genetlink",
+		msgs: []*pktiopb.HostPortControlMessage{{
+			Port: &pktiopb.HostPortControlMessage_Genetlink{
+				Genetlink: &pktiopb.GenetlinkPort{
+					Family: "genl_packet",
+					Group:  "packets",
+				},
+			},
+			PortId:        1,
+			DataplanePort: 2,
+			Create:        true,
+		}},
+		want:    codes.OK,
+		wantIDs: &pktiopb.GenetlinkPortIds{FamilyId: 30, GroupId: 7},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -130,6 +146,9 @@ func TestManagePorts(t *testing.T) {
 			createTAPFunc = func(string) (*kernel.TapInterface, error) {
 				return &kernel.TapInterface{}, nil
 			}
+			createGenetlinkFunc = func(string, string) (genetlinkPortIO, error) {
+				return &fakeGenetlinkPort{familyID: 30, groupID: 7}, nil
+			}
 
 			hpc := &fakeHostPortControl{
 				msg: tt.msgs,
@@ -140,6 +159,9 @@ func TestManagePorts(t *testing.T) {
 			if got := codes.Code(hpc.gotReqs[1].GetStatus().GetCode()); got != tt.want {
 				t.Fatalf("ManagePorts() unexpected result: got %v, want %v", got, tt.want)
 			}
+			if d := cmp.Diff(hpc.gotReqs[1].GetGenetlinkIds(), tt.wantIDs, protocmp.Transform()); d != "" {
+				t.Errorf("ManagePorts() unexpected genetlink ids: diff(-got,+want)\n:%s", d)
+			}
 		})
 	}
 }
@@ -159,6 +181,24 @@ func (p *fakePort) Write(frame []byte, md *kernel.PacketMetadata) (int, error) {
 	return len(frame), nil
 }
 
+type fakeGenetlinkPort struct {
+	portIO
+	familyID uint16
+	groupID  uint32
+}
+
+func (p *fakeGenetlinkPort) Read([]byte) (int, error) {
+	return 0, io.EOF
+}
+
+func (p *fakeGenetlinkPort) FamilyID() uint16 {
+	return p.familyID
+}
+
+func (p *fakeGenetlinkPort) GroupID() uint32 {
+	return p.groupID
+}
+
 type fakePacketStream struct {
 	pktiopb.PacketIO_CPUPacketStreamClient
 	sendPackets    []*pktiopb.PacketIn
@@ -0,0 +1,153 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetutil
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+
+	pktiopb "github.com/openconfig/lemming/dataplane/proto/packetio"
+	fwdpb "github.com/openconfig/lemming/proto/forwarding"
+)
+
+// Packet is a frame decoded with gopacket.
+type Packet struct {
+	gopacket.Packet
+	// Info is the metadata of a frame received by Sink.PacketSink.
+	Info *fwdpb.PacketSinkPacketInfo
+	// Out is the metadata of a frame received by Sink.CPUPortSink.
+	Out *pktiopb.PacketOut
+}
+
+// Decode decodes an Ethernet frame.
+func Decode(frame []byte) *Packet {
+	return &Packet{
+		Packet: gopacket.NewPacket(frame, layers.LayerTypeEthernet, gopacket.Default),
+	}
+}
+
+// Ethernet returns the Ethernet layer or nil if the packet doesn't have one.
+func (p *Packet) Ethernet() *layers.Ethernet {
+	l, _ := p.Layer(layers.LayerTypeEthernet).(*layers.Ethernet)
+	return l
+}
+
+// Dot1Q returns the first 802.1Q layer or nil if the packet doesn't have one.
+func (p *Packet) Dot1Q() *layers.Dot1Q {
+	l, _ := p.Layer(layers.LayerTypeDot1Q).(*layers.Dot1Q)
+	return l
+}
+
+// ARP returns the ARP layer or nil if the packet doesn't have one.
+func (p *Packet) ARP() *layers.ARP {
+	l, _ := p.Layer(layers.LayerTypeARP).(*layers.ARP)
+	return l
+}
+
+// LLDP returns the LLDP layer or nil if the packet doesn't have one.
+func (p *Packet) LLDP() *layers.LinkLayerDiscovery {
+	l, _ := p.Layer(layers.LayerTypeLinkLayerDiscovery).(*layers.LinkLayerDiscovery)
+	return l
+}
+
+// IPv4 returns the IPv4 layer or nil if the packet doesn't have one.
+func (p *Packet) IPv4() *layers.IPv4 {
+	l, _ := p.Layer(layers.LayerTypeIPv4).(*layers.IPv4)
+	return l
+}
+
+// IPv6 returns the IPv6 layer or nil if the packet doesn't have one.
+func (p *Packet) IPv6() *layers.IPv6 {
+	l, _ := p.Layer(layers.LayerTypeIPv6).(*layers.IPv6)
+	return l
+}
+
+// TCP returns the TCP layer or nil if the packet doesn't have one.
+func (p *Packet) TCP() *layers.TCP {
+	l, _ := p.Layer(layers.LayerTypeTCP).(*layers.TCP)
+	return l
+}
+
+// UDP returns the UDP layer or nil if the packet doesn't have one.
+func (p *Packet) UDP() *layers.UDP {
+	l, _ := p.Layer(layers.LayerTypeUDP).(*layers.UDP)
+	return l
+}
+
+// Sink is a forwarding packet sink that decodes the frames it receives.
+// It is intended for tests that assert on punted or egressed packets.
+type Sink struct {
+	packets chan *Packet
+}
+
+// NewSink returns a sink that buffers up to size packets.
+func NewSink(size int) *Sink {
+	return &Sink{
+		packets: make(chan *Packet, size),
+	}
+}
+
+// PacketSink decodes and buffers the packet in the response, other response types are ignored.
+// It has the signature of fwdcontext.PacketCallback.
+func (s *Sink) PacketSink(resp *fwdpb.PacketSinkResponse) error {
+	info := resp.GetPacket()
+	if info == nil {
+		return nil
+	}
+	pkt := Decode(info.GetBytes())
+	pkt.Info = info
+	return s.add(pkt)
+}
+
+// CPUPortSink decodes and buffers a packet sent by a remote CPU port.
+// It has the signature of fwdcontext.CPUPortSink.
+func (s *Sink) CPUPortSink(out *pktiopb.PacketOut) error {
+	pkt := Decode(out.GetPacket().GetFrame())
+	pkt.Out = out
+	return s.add(pkt)
+}
+
+// WritePacketData decodes and buffers a frame written to a port.
+// It has the signature of fwdcontext.Port.WritePacketData.
+func (s *Sink) WritePacketData(frame []byte) error {
+	return s.add(Decode(frame))
+}
+
+func (s *Sink) add(pkt *Packet) error {
+	select {
+	case s.packets <- pkt:
+		return nil
+	default:
+		return fmt.Errorf("packetutil: sink full, dropping packet")
+	}
+}
+
+// Next returns the next packet, waiting up to timeout for one to arrive.
+func (s *Sink) Next(timeout time.Duration) (*Packet, error) {
+	select {
+	case pkt := <-s.packets:
+		return pkt, nil
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("packetutil: no packet received after %v", timeout)
+	}
+}
+
+// Len returns the number of buffered packets.
+func (s *Sink) Len() int {
+	return len(s.packets)
+}
@@ -21,6 +21,7 @@ import (
 	"net"
 	"runtime"
 	"sync"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -85,6 +86,12 @@ type opt struct {
 	bgpPort        uint16
 	dataplane      bool
 	dataplaneOpts  []dplaneopts.Option
+	// bgpNexthopTracking rejects BGP routes whose next hop isn't resolvable.
+	bgpNexthopTracking bool
+	// bgpLLGRRestartTime is the BGP long-lived graceful restart time.
+	bgpLLGRRestartTime time.Duration
+	// bgpConditionalAdvertisements are the conditionally advertised BGP routes.
+	bgpConditionalAdvertisements []bgp.ConditionalAdvertisement
 }
 
 // resolveOpts applies all the options and returns a struct containing the result.
@@ -168,6 +175,40 @@ func WithDataplaneOpts(opts ...dplaneopts.Option) Option {
 	}
 }
 
+// WithBGPNexthopTracking specifies whether BGP routes whose next hop is not
+// resolvable in the FIB are rejected until the next hop resolves.
+// It is disabled by default because lemmings often peer over addresses that
+// have no route in the sysrib, e.g. loopback addresses in tests without a
+// dataplane, and tracking would reject all of their routes.
+// Default: false
+func WithBGPNexthopTracking(enable bool) Option {
+	return func(o *opt) {
+		o.bgpNexthopTracking = enable
+	}
+}
+
+// WithBGPLongLivedGracefulRestart specifies how long routes of a restarting
+// BGP neighbour are retained, marked with the LLGR_STALE community, after its
+// graceful restart time expires. It applies to the AFI-SAFIs that have
+// graceful restart enabled, and is disabled if zero.
+// Default: 0
+func WithBGPLongLivedGracefulRestart(restartTime time.Duration) Option {
+	return func(o *opt) {
+		o.bgpLLGRRestartTime = restartTime
+	}
+}
+
+// WithBGPConditionalAdvertisement specifies BGP routes that are only
+// advertised to a neighbour while a tracked route exists, or doesn't exist,
+// in the BGP RIB. The prefix sets and the neighbour are configured using
+// OpenConfig.
+// Default: none
+func WithBGPConditionalAdvertisement(condAdvs ...bgp.ConditionalAdvertisement) Option {
+	return func(o *opt) {
+		o.bgpConditionalAdvertisements = append(o.bgpConditionalAdvertisements, condAdvs...)
+	}
+}
+
 // WithSysribAddr specifies a unix domain socket path for sysrib.
 // Default: "/tmp/sysrib.api"
 func WithSysribAddr(sysribAddr string) Option {
@@ -219,11 +260,20 @@ func New(targetName, zapiURL string, opts ...Option) (*Device, error) {
 
 	s := grpc.NewServer(grpcOpts...)
 
+	sysribServer, err := sysrib.New(root)
+	if err != nil {
+		return nil, err
+	}
+	var nhResolver bgp.NexthopResolver
+	if resolvedOpts.bgpNexthopTracking {
+		nhResolver = sysribServer
+	}
+
 	recs = append(recs,
 		fakedevice.NewSystemBaseTask(),
 		fakedevice.NewBootTimeTask(),
 		fakedevice.NewCurrentTimeTask(),
-		bgp.NewGoBGPTask(targetName, zapiURL, resolvedOpts.bgpPort),
+		bgp.NewGoBGPTask(targetName, zapiURL, resolvedOpts.bgpPort, nhResolver, resolvedOpts.bgpLLGRRestartTime, resolvedOpts.bgpConditionalAdvertisements),
 	)
 
 	log.Info("starting gNSI")
@@ -237,10 +287,6 @@ func New(targetName, zapiURL string, opts ...Option) (*Device, error) {
 	cacheClient := gnmiServer.LocalClient()
 
 	log.Infof("starting sysrib")
-	sysribServer, err := sysrib.New(root)
-	if err != nil {
-		return nil, err
-	}
 	if err := sysribServer.Start(context.Background(), cacheClient, targetName, zapiURL, resolvedOpts.sysribAddr); err != nil {
 		return nil, fmt.Errorf("sysribServer failed to start: %v", err)
 	}
@@ -35,6 +35,11 @@ type RouteTestCase struct {
 	NextAdjRibInPreCommunities []string
 	NextLocalRibCommunities    []string
 
+	// Extended communities are in their OC string representation, e.g. "route-target:65000:100".
+	AdjRibInPreExtCommunities []string
+	// An import policy is applied here by convention.
+	AdjRibInPostExtCommunities []string
+
 	PrevAdjRibOutPreAttrs  *oc.NetworkInstance_Protocol_Bgp_Rib_AttrSet
 	PrevAdjRibOutPostAttrs *oc.NetworkInstance_Protocol_Bgp_Rib_AttrSet
 	AdjRibInPreAttrs       *oc.NetworkInstance_Protocol_Bgp_Rib_AttrSet
@@ -50,6 +55,12 @@ type RouteTestCase struct {
 // This message represents a single prefix and its associated BGP attributes.
 type TestRoute struct {
 	ReachPrefix string
+	// Communities are attached to the route when it's advertised, before
+	// the export policy applied by convention, e.g. "11111:11111". They're
+	// therefore absent from PrevAdjRibOutPreCommunities.
+	//
+	// Only the local policy tests in bgp/tests/local_tests attach them.
+	Communities []string
 }
 
 // The expected result for a RouteTestCase
@@ -627,6 +627,16 @@ func testCommunitiesAuxV4(t *testing.T, routeTest *RouteTestCase, pair1, pair2 *
 	}); diff != "" {
 		t.Errorf("DUT %v AdjRibInPost communities difference (prefix %s):\n%s", currDUT, prefix, diff)
 	}
+	if diff := RetryDiff(10, "AdjRibInPre ext-communities Check", func() string {
+		return cmp.Diff(routeTest.AdjRibInPreExtCommunities, getExtCommunities(t, currDUT, v4uni.Neighbor(port1.IPv4).AdjRibInPre().Route(prefix, 0).ExtCommunityIndex().State()))
+	}); diff != "" {
+		t.Errorf("DUT %v AdjRibInPre ext-communities difference (prefix %s):\n%s", currDUT, prefix, diff)
+	}
+	if diff := RetryDiff(10, "AdjRibInPost ext-communities Check", func() string {
+		return cmp.Diff(routeTest.AdjRibInPostExtCommunities, getExtCommunities(t, currDUT, v4uni.Neighbor(port1.IPv4).AdjRibInPost().Route(prefix, 0).ExtCommunityIndex().State()))
+	}); diff != "" {
+		t.Errorf("DUT %v AdjRibInPost ext-communities difference (prefix %s):\n%s", currDUT, prefix, diff)
+	}
 	if diff := RetryDiff(10, "LocRib communities Check", func() string {
 		return cmp.Diff(routeTest.LocalRibCommunities, getCommunities(t, currDUT, currCommMap, v4uni.LocRib().Route(prefix, oc.UnionString(port1.IPv4), 0).CommunityIndex().State()))
 	}); diff != "" {
@@ -688,6 +698,16 @@ func testCommunitiesAuxV6(t *testing.T, routeTest *RouteTestCase, pair1, pair2 *
 	}); diff != "" {
 		t.Errorf("DUT %v AdjRibInPost communities difference (prefix %s):\n%s", currDUT, prefix, diff)
 	}
+	if diff := RetryDiff(10, "AdjRibInPre ext-communities Check", func() string {
+		return cmp.Diff(routeTest.AdjRibInPreExtCommunities, getExtCommunities(t, currDUT, v6uni.Neighbor(port1.IPv6).AdjRibInPre().Route(prefix, 0).ExtCommunityIndex().State()))
+	}); diff != "" {
+		t.Errorf("DUT %v AdjRibInPre ext-communities difference (prefix %s):\n%s", currDUT, prefix, diff)
+	}
+	if diff := RetryDiff(10, "AdjRibInPost ext-communities Check", func() string {
+		return cmp.Diff(routeTest.AdjRibInPostExtCommunities, getExtCommunities(t, currDUT, v6uni.Neighbor(port1.IPv6).AdjRibInPost().Route(prefix, 0).ExtCommunityIndex().State()))
+	}); diff != "" {
+		t.Errorf("DUT %v AdjRibInPost ext-communities difference (prefix %s):\n%s", currDUT, prefix, diff)
+	}
 	if diff := RetryDiff(10, "LocRib communities Check", func() string {
 		return cmp.Diff(routeTest.LocalRibCommunities, getCommunities(t, currDUT, currCommMap, v6uni.LocRib().Route(prefix, oc.UnionString(port1.IPv6), 0).CommunityIndex().State()))
 	}); diff != "" {
@@ -741,3 +761,29 @@ func getCommunities(t *testing.T, dut *Device, commMap map[uint64]*oc.NetworkIns
 	}
 	return gotCommunities
 }
+
+// getExtCommunities gets the extended communities of the given route query to an extended community index.
+//
+// If the extended community index doesn't exist (e.g. the route doesn't exist), nil is returned.
+func getExtCommunities(t *testing.T, dut *Device, query ygnmi.SingletonQuery[uint64]) []string {
+	t.Helper()
+	commIndex, ok := gnmi.Lookup(t, dut, query).Val()
+	if !ok {
+		return nil
+	}
+	comms, ok := gnmi.Lookup(t, dut, BGPPath.Rib().ExtCommunity(commIndex).State()).Val()
+	if !ok {
+		t.Errorf("RIB ext-communities does not have expected index: %v", commIndex)
+		return nil
+	}
+	var gotCommunities []string
+	for _, comm := range comms.GetExtCommunity() {
+		c, ok := comm.(oc.UnionString)
+		if !ok {
+			t.Errorf("Unexpected ext-community type: (%T, %v)", comm, comm)
+			continue
+		}
+		gotCommunities = append(gotCommunities, string(c))
+	}
+	return gotCommunities
+}
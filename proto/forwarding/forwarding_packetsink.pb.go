@@ -334,6 +334,53 @@ func (x *PacketSinkPortInfo) GetPort() *PortDesc {
 	return nil
 }
 
+type PacketSinkPortRemoval struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PortId *PortId `protobuf:"bytes,1,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`
+}
+
+func (x *PacketSinkPortRemoval) Reset() {
+	*x = PacketSinkPortRemoval{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_forwarding_forwarding_packetsink_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PacketSinkPortRemoval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PacketSinkPortRemoval) ProtoMessage() {}
+
+func (x *PacketSinkPortRemoval) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_forwarding_forwarding_packetsink_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PacketSinkPortRemoval.ProtoReflect.Descriptor instead.
+func (*PacketSinkPortRemoval) Descriptor() ([]byte, []int) {
+	return file_proto_forwarding_forwarding_packetsink_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PacketSinkPortRemoval) GetPortId() *PortId {
+	if x != nil {
+		return x.PortId
+	}
+	return nil
+}
+
 type PacketSinkResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -343,13 +390,14 @@ type PacketSinkResponse struct {
 	//
 	//	*PacketSinkResponse_Packet
 	//	*PacketSinkResponse_Port
+	//	*PacketSinkResponse_PortRemoval
 	Resp isPacketSinkResponse_Resp `protobuf_oneof:"resp"`
 }
 
 func (x *PacketSinkResponse) Reset() {
 	*x = PacketSinkResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_packetsink_proto_msgTypes[5]
+		mi := &file_proto_forwarding_forwarding_packetsink_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -362,7 +410,7 @@ func (x *PacketSinkResponse) String() string {
 func (*PacketSinkResponse) ProtoMessage() {}
 
 func (x *PacketSinkResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_packetsink_proto_msgTypes[5]
+	mi := &file_proto_forwarding_forwarding_packetsink_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -375,7 +423,7 @@ func (x *PacketSinkResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PacketSinkResponse.ProtoReflect.Descriptor instead.
 func (*PacketSinkResponse) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_packetsink_proto_rawDescGZIP(), []int{5}
+	return file_proto_forwarding_forwarding_packetsink_proto_rawDescGZIP(), []int{6}
 }
 
 func (m *PacketSinkResponse) GetResp() isPacketSinkResponse_Resp {
@@ -399,6 +447,13 @@ func (x *PacketSinkResponse) GetPort() *PacketSinkPortInfo {
 	return nil
 }
 
+func (x *PacketSinkResponse) GetPortRemoval() *PacketSinkPortRemoval {
+	if x, ok := x.GetResp().(*PacketSinkResponse_PortRemoval); ok {
+		return x.PortRemoval
+	}
+	return nil
+}
+
 type isPacketSinkResponse_Resp interface {
 	isPacketSinkResponse_Resp()
 }
@@ -411,10 +466,16 @@ type PacketSinkResponse_Port struct {
 	Port *PacketSinkPortInfo `protobuf:"bytes,2,opt,name=port,proto3,oneof"`
 }
 
+type PacketSinkResponse_PortRemoval struct {
+	PortRemoval *PacketSinkPortRemoval `protobuf:"bytes,3,opt,name=port_removal,json=portRemoval,proto3,oneof"`
+}
+
 func (*PacketSinkResponse_Packet) isPacketSinkResponse_Resp() {}
 
 func (*PacketSinkResponse_Port) isPacketSinkResponse_Resp() {}
 
+func (*PacketSinkResponse_PortRemoval) isPacketSinkResponse_Resp() {}
+
 var File_proto_forwarding_forwarding_packetsink_proto protoreflect.FileDescriptor
 
 var file_proto_forwarding_forwarding_packetsink_proto_rawDesc = []byte{
@@ -480,20 +541,28 @@ var file_proto_forwarding_forwarding_packetsink_proto_rawDesc = []byte{
 	0x74, 0x53, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x28, 0x0a,
 	0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66, 0x6f,
 	0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73,
-	0x63, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x8e, 0x01, 0x0a, 0x12, 0x50, 0x61, 0x63, 0x6b,
-	0x65, 0x74, 0x53, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a,
-	0x0a, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x63, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x44, 0x0a, 0x15, 0x50, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x53, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x61, 0x6c,
+	0x12, 0x2b, 0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50,
+	0x6f, 0x72, 0x74, 0x49, 0x64, 0x52, 0x06, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x22, 0xd6, 0x01,
+	0x0a, 0x12, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x69, 0x6e, 0x6b, 0x50, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x12, 0x34, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
 	0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x63, 0x6b,
-	0x65, 0x74, 0x53, 0x69, 0x6e, 0x6b, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f,
-	0x48, 0x00, 0x52, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x34, 0x0a, 0x04, 0x70, 0x6f,
-	0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61,
-	0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x69, 0x6e, 0x6b,
-	0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74,
-	0x42, 0x06, 0x0a, 0x04, 0x72, 0x65, 0x73, 0x70, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x2f, 0x6c, 0x65, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
-	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x65, 0x74, 0x53, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x48, 0x00,
+	0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x46, 0x0a, 0x0c, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x72,
+	0x65, 0x6d, 0x6f, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x66,
+	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x53, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x61, 0x6c, 0x48,
+	0x00, 0x52, 0x0b, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x61, 0x6c, 0x42, 0x06,
+	0x0a, 0x04, 0x72, 0x65, 0x73, 0x70, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x6c, 0x65, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x66, 0x6f,
+	0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -508,42 +577,45 @@ func file_proto_forwarding_forwarding_packetsink_proto_rawDescGZIP() []byte {
 	return file_proto_forwarding_forwarding_packetsink_proto_rawDescData
 }
 
-var file_proto_forwarding_forwarding_packetsink_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_forwarding_forwarding_packetsink_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_proto_forwarding_forwarding_packetsink_proto_goTypes = []interface{}{
-	(*PacketInjectRequest)(nil),  // 0: forwarding.PacketInjectRequest
-	(*PacketInjectResponse)(nil), // 1: forwarding.PacketInjectResponse
-	(*PacketSinkRequest)(nil),    // 2: forwarding.PacketSinkRequest
-	(*PacketSinkPacketInfo)(nil), // 3: forwarding.PacketSinkPacketInfo
-	(*PacketSinkPortInfo)(nil),   // 4: forwarding.PacketSinkPortInfo
-	(*PacketSinkResponse)(nil),   // 5: forwarding.PacketSinkResponse
-	(*PortId)(nil),               // 6: forwarding.PortId
-	(*ContextId)(nil),            // 7: forwarding.ContextId
-	(PortAction)(0),              // 8: forwarding.PortAction
-	(*ActionDesc)(nil),           // 9: forwarding.ActionDesc
-	(PacketHeaderId)(0),          // 10: forwarding.PacketHeaderId
-	(*PacketFieldBytes)(nil),     // 11: forwarding.PacketFieldBytes
-	(*PortDesc)(nil),             // 12: forwarding.PortDesc
+	(*PacketInjectRequest)(nil),   // 0: forwarding.PacketInjectRequest
+	(*PacketInjectResponse)(nil),  // 1: forwarding.PacketInjectResponse
+	(*PacketSinkRequest)(nil),     // 2: forwarding.PacketSinkRequest
+	(*PacketSinkPacketInfo)(nil),  // 3: forwarding.PacketSinkPacketInfo
+	(*PacketSinkPortInfo)(nil),    // 4: forwarding.PacketSinkPortInfo
+	(*PacketSinkPortRemoval)(nil), // 5: forwarding.PacketSinkPortRemoval
+	(*PacketSinkResponse)(nil),    // 6: forwarding.PacketSinkResponse
+	(*PortId)(nil),                // 7: forwarding.PortId
+	(*ContextId)(nil),             // 8: forwarding.ContextId
+	(PortAction)(0),               // 9: forwarding.PortAction
+	(*ActionDesc)(nil),            // 10: forwarding.ActionDesc
+	(PacketHeaderId)(0),           // 11: forwarding.PacketHeaderId
+	(*PacketFieldBytes)(nil),      // 12: forwarding.PacketFieldBytes
+	(*PortDesc)(nil),              // 13: forwarding.PortDesc
 }
 var file_proto_forwarding_forwarding_packetsink_proto_depIdxs = []int32{
-	6,  // 0: forwarding.PacketInjectRequest.port_id:type_name -> forwarding.PortId
-	7,  // 1: forwarding.PacketInjectRequest.context_id:type_name -> forwarding.ContextId
-	8,  // 2: forwarding.PacketInjectRequest.action:type_name -> forwarding.PortAction
-	9,  // 3: forwarding.PacketInjectRequest.preprocesses:type_name -> forwarding.ActionDesc
-	10, // 4: forwarding.PacketInjectRequest.start_header:type_name -> forwarding.PacketHeaderId
-	11, // 5: forwarding.PacketInjectRequest.parsed_fields:type_name -> forwarding.PacketFieldBytes
-	7,  // 6: forwarding.PacketSinkRequest.context_id:type_name -> forwarding.ContextId
-	6,  // 7: forwarding.PacketSinkPacketInfo.port_id:type_name -> forwarding.PortId
-	6,  // 8: forwarding.PacketSinkPacketInfo.ingress:type_name -> forwarding.PortId
-	6,  // 9: forwarding.PacketSinkPacketInfo.egress:type_name -> forwarding.PortId
-	11, // 10: forwarding.PacketSinkPacketInfo.parsed_fields:type_name -> forwarding.PacketFieldBytes
-	12, // 11: forwarding.PacketSinkPortInfo.port:type_name -> forwarding.PortDesc
-	3,  // 12: forwarding.PacketSinkResponse.packet:type_name -> forwarding.PacketSinkPacketInfo
-	4,  // 13: forwarding.PacketSinkResponse.port:type_name -> forwarding.PacketSinkPortInfo
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	7,  // 0: forwarding.PacketInjectRequest.port_id:type_name -> forwarding.PortId
+	8,  // 1: forwarding.PacketInjectRequest.context_id:type_name -> forwarding.ContextId
+	9,  // 2: forwarding.PacketInjectRequest.action:type_name -> forwarding.PortAction
+	10, // 3: forwarding.PacketInjectRequest.preprocesses:type_name -> forwarding.ActionDesc
+	11, // 4: forwarding.PacketInjectRequest.start_header:type_name -> forwarding.PacketHeaderId
+	12, // 5: forwarding.PacketInjectRequest.parsed_fields:type_name -> forwarding.PacketFieldBytes
+	8,  // 6: forwarding.PacketSinkRequest.context_id:type_name -> forwarding.ContextId
+	7,  // 7: forwarding.PacketSinkPacketInfo.port_id:type_name -> forwarding.PortId
+	7,  // 8: forwarding.PacketSinkPacketInfo.ingress:type_name -> forwarding.PortId
+	7,  // 9: forwarding.PacketSinkPacketInfo.egress:type_name -> forwarding.PortId
+	12, // 10: forwarding.PacketSinkPacketInfo.parsed_fields:type_name -> forwarding.PacketFieldBytes
+	13, // 11: forwarding.PacketSinkPortInfo.port:type_name -> forwarding.PortDesc
+	7,  // 12: forwarding.PacketSinkPortRemoval.port_id:type_name -> forwarding.PortId
+	3,  // 13: forwarding.PacketSinkResponse.packet:type_name -> forwarding.PacketSinkPacketInfo
+	4,  // 14: forwarding.PacketSinkResponse.port:type_name -> forwarding.PacketSinkPortInfo
+	5,  // 15: forwarding.PacketSinkResponse.port_removal:type_name -> forwarding.PacketSinkPortRemoval
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_proto_forwarding_forwarding_packetsink_proto_init() }
@@ -616,6 +688,18 @@ func file_proto_forwarding_forwarding_packetsink_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_packetsink_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PacketSinkPortRemoval); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_forwarding_forwarding_packetsink_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PacketSinkResponse); i {
 			case 0:
 				return &v.state
@@ -628,9 +712,10 @@ func file_proto_forwarding_forwarding_packetsink_proto_init() {
 			}
 		}
 	}
-	file_proto_forwarding_forwarding_packetsink_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_proto_forwarding_forwarding_packetsink_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*PacketSinkResponse_Packet)(nil),
 		(*PacketSinkResponse_Port)(nil),
+		(*PacketSinkResponse_PortRemoval)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -638,7 +723,7 @@ func file_proto_forwarding_forwarding_packetsink_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_forwarding_forwarding_packetsink_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -31,6 +31,7 @@ const (
 	PortType_PORT_TYPE_TAP            PortType = 4
 	PortType_PORT_TYPE_FAKE           PortType = 5
 	PortType_PORT_TYPE_GENETLINK      PortType = 6
+	PortType_PORT_TYPE_CHANNEL        PortType = 7
 )
 
 // Enum value maps for PortType.
@@ -43,6 +44,7 @@ var (
 		4: "PORT_TYPE_TAP",
 		5: "PORT_TYPE_FAKE",
 		6: "PORT_TYPE_GENETLINK",
+		7: "PORT_TYPE_CHANNEL",
 	}
 	PortType_value = map[string]int32{
 		"PORT_TYPE_UNSPECIFIED":    0,
@@ -52,6 +54,7 @@ var (
 		"PORT_TYPE_TAP":            4,
 		"PORT_TYPE_FAKE":           5,
 		"PORT_TYPE_GENETLINK":      6,
+		"PORT_TYPE_CHANNEL":        7,
 	}
 )
 
@@ -246,6 +249,7 @@ type PortDesc struct {
 	//	*PortDesc_Tap
 	//	*PortDesc_Fake
 	//	*PortDesc_Genetlink
+	//	*PortDesc_Channel
 	Port isPortDesc_Port `protobuf_oneof:"port"`
 }
 
@@ -337,6 +341,13 @@ func (x *PortDesc) GetGenetlink() *GenetlinkPortDesc {
 	return nil
 }
 
+func (x *PortDesc) GetChannel() *ChannelPortDesc {
+	if x, ok := x.GetPort().(*PortDesc_Channel); ok {
+		return x.Channel
+	}
+	return nil
+}
+
 type isPortDesc_Port interface {
 	isPortDesc_Port()
 }
@@ -361,6 +372,10 @@ type PortDesc_Genetlink struct {
 	Genetlink *GenetlinkPortDesc `protobuf:"bytes,7,opt,name=genetlink,proto3,oneof"`
 }
 
+type PortDesc_Channel struct {
+	Channel *ChannelPortDesc `protobuf:"bytes,8,opt,name=channel,proto3,oneof"`
+}
+
 func (*PortDesc_Cpu) isPortDesc_Port() {}
 
 func (*PortDesc_Kernel) isPortDesc_Port() {}
@@ -371,6 +386,8 @@ func (*PortDesc_Fake) isPortDesc_Port() {}
 
 func (*PortDesc_Genetlink) isPortDesc_Port() {}
 
+func (*PortDesc_Channel) isPortDesc_Port() {}
+
 type CPUPortDesc struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -654,6 +671,53 @@ func (x *GenetlinkPortDesc) GetGroupName() string {
 	return ""
 }
 
+type ChannelPortDesc struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueueLength uint32 `protobuf:"varint,1,opt,name=queue_length,json=queueLength,proto3" json:"queue_length,omitempty"`
+}
+
+func (x *ChannelPortDesc) Reset() {
+	*x = ChannelPortDesc{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChannelPortDesc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelPortDesc) ProtoMessage() {}
+
+func (x *ChannelPortDesc) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelPortDesc.ProtoReflect.Descriptor instead.
+func (*ChannelPortDesc) Descriptor() ([]byte, []int) {
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ChannelPortDesc) GetQueueLength() uint32 {
+	if x != nil {
+		return x.QueueLength
+	}
+	return 0
+}
+
 type PortCreateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -666,7 +730,7 @@ type PortCreateRequest struct {
 func (x *PortCreateRequest) Reset() {
 	*x = PortCreateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[6]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -679,7 +743,7 @@ func (x *PortCreateRequest) String() string {
 func (*PortCreateRequest) ProtoMessage() {}
 
 func (x *PortCreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[6]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -692,7 +756,7 @@ func (x *PortCreateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PortCreateRequest.ProtoReflect.Descriptor instead.
 func (*PortCreateRequest) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{6}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *PortCreateRequest) GetPort() *PortDesc {
@@ -720,7 +784,7 @@ type PortCreateReply struct {
 func (x *PortCreateReply) Reset() {
 	*x = PortCreateReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[7]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -733,7 +797,7 @@ func (x *PortCreateReply) String() string {
 func (*PortCreateReply) ProtoMessage() {}
 
 func (x *PortCreateReply) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[7]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -746,7 +810,7 @@ func (x *PortCreateReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PortCreateReply.ProtoReflect.Descriptor instead.
 func (*PortCreateReply) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{7}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PortCreateReply) GetObjectIndex() *ObjectIndex {
@@ -770,13 +834,14 @@ type PortUpdateDesc struct {
 	//	*PortUpdateDesc_AggregateAlgo
 	//	*PortUpdateDesc_Kernel
 	//	*PortUpdateDesc_Genetlink
+	//	*PortUpdateDesc_Channel
 	Port isPortUpdateDesc_Port `protobuf_oneof:"port"`
 }
 
 func (x *PortUpdateDesc) Reset() {
 	*x = PortUpdateDesc{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[8]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -789,7 +854,7 @@ func (x *PortUpdateDesc) String() string {
 func (*PortUpdateDesc) ProtoMessage() {}
 
 func (x *PortUpdateDesc) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[8]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -802,7 +867,7 @@ func (x *PortUpdateDesc) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PortUpdateDesc.ProtoReflect.Descriptor instead.
 func (*PortUpdateDesc) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{8}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{9}
 }
 
 func (m *PortUpdateDesc) GetPort() isPortUpdateDesc_Port {
@@ -861,6 +926,13 @@ func (x *PortUpdateDesc) GetGenetlink() *GenetlinkPortUpdateDesc {
 	return nil
 }
 
+func (x *PortUpdateDesc) GetChannel() *ChannelPortUpdateDesc {
+	if x, ok := x.GetPort().(*PortUpdateDesc_Channel); ok {
+		return x.Channel
+	}
+	return nil
+}
+
 type isPortUpdateDesc_Port interface {
 	isPortUpdateDesc_Port()
 }
@@ -893,6 +965,10 @@ type PortUpdateDesc_Genetlink struct {
 	Genetlink *GenetlinkPortUpdateDesc `protobuf:"bytes,7,opt,name=genetlink,proto3,oneof"`
 }
 
+type PortUpdateDesc_Channel struct {
+	Channel *ChannelPortUpdateDesc `protobuf:"bytes,8,opt,name=channel,proto3,oneof"`
+}
+
 func (*PortUpdateDesc_Cpu) isPortUpdateDesc_Port() {}
 
 func (*PortUpdateDesc_Aggregate) isPortUpdateDesc_Port() {}
@@ -907,6 +983,8 @@ func (*PortUpdateDesc_Kernel) isPortUpdateDesc_Port() {}
 
 func (*PortUpdateDesc_Genetlink) isPortUpdateDesc_Port() {}
 
+func (*PortUpdateDesc_Channel) isPortUpdateDesc_Port() {}
+
 type PortUpdateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -920,7 +998,7 @@ type PortUpdateRequest struct {
 func (x *PortUpdateRequest) Reset() {
 	*x = PortUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[9]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -933,7 +1011,7 @@ func (x *PortUpdateRequest) String() string {
 func (*PortUpdateRequest) ProtoMessage() {}
 
 func (x *PortUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[9]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -946,7 +1024,7 @@ func (x *PortUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PortUpdateRequest.ProtoReflect.Descriptor instead.
 func (*PortUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{9}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *PortUpdateRequest) GetPortId() *PortId {
@@ -979,7 +1057,7 @@ type PortUpdateReply struct {
 func (x *PortUpdateReply) Reset() {
 	*x = PortUpdateReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[10]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -992,7 +1070,7 @@ func (x *PortUpdateReply) String() string {
 func (*PortUpdateReply) ProtoMessage() {}
 
 func (x *PortUpdateReply) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[10]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1005,7 +1083,7 @@ func (x *PortUpdateReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PortUpdateReply.ProtoReflect.Descriptor instead.
 func (*PortUpdateReply) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{10}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{11}
 }
 
 type CPUPortUpdateDesc struct {
@@ -1020,7 +1098,7 @@ type CPUPortUpdateDesc struct {
 func (x *CPUPortUpdateDesc) Reset() {
 	*x = CPUPortUpdateDesc{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[11]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1033,7 +1111,7 @@ func (x *CPUPortUpdateDesc) String() string {
 func (*CPUPortUpdateDesc) ProtoMessage() {}
 
 func (x *CPUPortUpdateDesc) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[11]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1046,7 +1124,7 @@ func (x *CPUPortUpdateDesc) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CPUPortUpdateDesc.ProtoReflect.Descriptor instead.
 func (*CPUPortUpdateDesc) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{11}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CPUPortUpdateDesc) GetInputs() []*ActionDesc {
@@ -1075,7 +1153,7 @@ type KernelPortUpdateDesc struct {
 func (x *KernelPortUpdateDesc) Reset() {
 	*x = KernelPortUpdateDesc{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[12]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1088,7 +1166,7 @@ func (x *KernelPortUpdateDesc) String() string {
 func (*KernelPortUpdateDesc) ProtoMessage() {}
 
 func (x *KernelPortUpdateDesc) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[12]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1101,7 +1179,7 @@ func (x *KernelPortUpdateDesc) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KernelPortUpdateDesc.ProtoReflect.Descriptor instead.
 func (*KernelPortUpdateDesc) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{12}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *KernelPortUpdateDesc) GetInputs() []*ActionDesc {
@@ -1130,7 +1208,7 @@ type GenetlinkPortUpdateDesc struct {
 func (x *GenetlinkPortUpdateDesc) Reset() {
 	*x = GenetlinkPortUpdateDesc{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[13]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1143,7 +1221,7 @@ func (x *GenetlinkPortUpdateDesc) String() string {
 func (*GenetlinkPortUpdateDesc) ProtoMessage() {}
 
 func (x *GenetlinkPortUpdateDesc) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[13]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1156,7 +1234,7 @@ func (x *GenetlinkPortUpdateDesc) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenetlinkPortUpdateDesc.ProtoReflect.Descriptor instead.
 func (*GenetlinkPortUpdateDesc) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{13}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GenetlinkPortUpdateDesc) GetInputs() []*ActionDesc {
@@ -1173,6 +1251,61 @@ func (x *GenetlinkPortUpdateDesc) GetOutputs() []*ActionDesc {
 	return nil
 }
 
+type ChannelPortUpdateDesc struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Inputs  []*ActionDesc `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs []*ActionDesc `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+}
+
+func (x *ChannelPortUpdateDesc) Reset() {
+	*x = ChannelPortUpdateDesc{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChannelPortUpdateDesc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelPortUpdateDesc) ProtoMessage() {}
+
+func (x *ChannelPortUpdateDesc) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelPortUpdateDesc.ProtoReflect.Descriptor instead.
+func (*ChannelPortUpdateDesc) Descriptor() ([]byte, []int) {
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ChannelPortUpdateDesc) GetInputs() []*ActionDesc {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *ChannelPortUpdateDesc) GetOutputs() []*ActionDesc {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
 type AggregateSelectAction struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1185,7 +1318,7 @@ type AggregateSelectAction struct {
 func (x *AggregateSelectAction) Reset() {
 	*x = AggregateSelectAction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[14]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1198,7 +1331,7 @@ func (x *AggregateSelectAction) String() string {
 func (*AggregateSelectAction) ProtoMessage() {}
 
 func (x *AggregateSelectAction) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[14]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1211,7 +1344,7 @@ func (x *AggregateSelectAction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AggregateSelectAction.ProtoReflect.Descriptor instead.
 func (*AggregateSelectAction) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{14}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *AggregateSelectAction) GetPortId() *PortId {
@@ -1242,7 +1375,7 @@ type AggregatePortUpdateDesc struct {
 func (x *AggregatePortUpdateDesc) Reset() {
 	*x = AggregatePortUpdateDesc{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[15]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1255,7 +1388,7 @@ func (x *AggregatePortUpdateDesc) String() string {
 func (*AggregatePortUpdateDesc) ProtoMessage() {}
 
 func (x *AggregatePortUpdateDesc) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[15]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1268,7 +1401,7 @@ func (x *AggregatePortUpdateDesc) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AggregatePortUpdateDesc.ProtoReflect.Descriptor instead.
 func (*AggregatePortUpdateDesc) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{15}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *AggregatePortUpdateDesc) GetPortIds() []*PortId {
@@ -1312,7 +1445,7 @@ type AggregatePortAddMemberUpdateDesc struct {
 func (x *AggregatePortAddMemberUpdateDesc) Reset() {
 	*x = AggregatePortAddMemberUpdateDesc{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[16]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1325,7 +1458,7 @@ func (x *AggregatePortAddMemberUpdateDesc) String() string {
 func (*AggregatePortAddMemberUpdateDesc) ProtoMessage() {}
 
 func (x *AggregatePortAddMemberUpdateDesc) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[16]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1338,7 +1471,7 @@ func (x *AggregatePortAddMemberUpdateDesc) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AggregatePortAddMemberUpdateDesc.ProtoReflect.Descriptor instead.
 func (*AggregatePortAddMemberUpdateDesc) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{16}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *AggregatePortAddMemberUpdateDesc) GetPortId() *PortId {
@@ -1373,7 +1506,7 @@ type AggregatePortRemoveMemberUpdateDesc struct {
 func (x *AggregatePortRemoveMemberUpdateDesc) Reset() {
 	*x = AggregatePortRemoveMemberUpdateDesc{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[17]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1386,7 +1519,7 @@ func (x *AggregatePortRemoveMemberUpdateDesc) String() string {
 func (*AggregatePortRemoveMemberUpdateDesc) ProtoMessage() {}
 
 func (x *AggregatePortRemoveMemberUpdateDesc) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[17]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1399,7 +1532,7 @@ func (x *AggregatePortRemoveMemberUpdateDesc) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use AggregatePortRemoveMemberUpdateDesc.ProtoReflect.Descriptor instead.
 func (*AggregatePortRemoveMemberUpdateDesc) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{17}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *AggregatePortRemoveMemberUpdateDesc) GetPortId() *PortId {
@@ -1421,7 +1554,7 @@ type AggregatePortAlgorithmUpdateDesc struct {
 func (x *AggregatePortAlgorithmUpdateDesc) Reset() {
 	*x = AggregatePortAlgorithmUpdateDesc{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[18]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1434,7 +1567,7 @@ func (x *AggregatePortAlgorithmUpdateDesc) String() string {
 func (*AggregatePortAlgorithmUpdateDesc) ProtoMessage() {}
 
 func (x *AggregatePortAlgorithmUpdateDesc) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[18]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1447,7 +1580,7 @@ func (x *AggregatePortAlgorithmUpdateDesc) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AggregatePortAlgorithmUpdateDesc.ProtoReflect.Descriptor instead.
 func (*AggregatePortAlgorithmUpdateDesc) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{18}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *AggregatePortAlgorithmUpdateDesc) GetHash() AggregateHashAlgorithm {
@@ -1476,7 +1609,7 @@ type PortSpeed struct {
 func (x *PortSpeed) Reset() {
 	*x = PortSpeed{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[19]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1489,7 +1622,7 @@ func (x *PortSpeed) String() string {
 func (*PortSpeed) ProtoMessage() {}
 
 func (x *PortSpeed) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[19]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1502,7 +1635,7 @@ func (x *PortSpeed) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PortSpeed.ProtoReflect.Descriptor instead.
 func (*PortSpeed) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{19}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *PortSpeed) GetKbps() uint64 {
@@ -1532,7 +1665,7 @@ type PortInfo struct {
 func (x *PortInfo) Reset() {
 	*x = PortInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[20]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1545,7 +1678,7 @@ func (x *PortInfo) String() string {
 func (*PortInfo) ProtoMessage() {}
 
 func (x *PortInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[20]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1558,7 +1691,7 @@ func (x *PortInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PortInfo.ProtoReflect.Descriptor instead.
 func (*PortInfo) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{20}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *PortInfo) GetOperStatus() PortState {
@@ -1595,7 +1728,7 @@ type PortStateRequest struct {
 func (x *PortStateRequest) Reset() {
 	*x = PortStateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[21]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1608,7 +1741,7 @@ func (x *PortStateRequest) String() string {
 func (*PortStateRequest) ProtoMessage() {}
 
 func (x *PortStateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[21]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1621,7 +1754,7 @@ func (x *PortStateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PortStateRequest.ProtoReflect.Descriptor instead.
 func (*PortStateRequest) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{21}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *PortStateRequest) GetPortId() *PortId {
@@ -1656,7 +1789,7 @@ type PortStateReply struct {
 func (x *PortStateReply) Reset() {
 	*x = PortStateReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[22]
+		mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1669,7 +1802,7 @@ func (x *PortStateReply) String() string {
 func (*PortStateReply) ProtoMessage() {}
 
 func (x *PortStateReply) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[22]
+	mi := &file_proto_forwarding_forwarding_port_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1682,7 +1815,7 @@ func (x *PortStateReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PortStateReply.ProtoReflect.Descriptor instead.
 func (*PortStateReply) Descriptor() ([]byte, []int) {
-	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{22}
+	return file_proto_forwarding_forwarding_port_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *PortStateReply) GetStatus() *PortInfo {
@@ -1705,7 +1838,7 @@ var file_proto_forwarding_forwarding_port_proto_rawDesc = []byte{
 	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x28, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x66,
 	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72,
 	0x64, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0xf1, 0x02, 0x0a, 0x08, 0x50, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x12, 0x31,
+	0x6f, 0x22, 0xaa, 0x03, 0x0a, 0x08, 0x50, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x12, 0x31,
 	0x0a, 0x09, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0e, 0x32, 0x14, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50,
 	0x6f, 0x72, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x79, 0x70,
@@ -1727,209 +1860,229 @@ var file_proto_forwarding_forwarding_port_proto_rawDesc = []byte{
 	0x0a, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x1d, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x47,
 	0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63,
-	0x48, 0x00, 0x52, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x42, 0x06, 0x0a,
-	0x04, 0x70, 0x6f, 0x72, 0x74, 0x22, 0xb1, 0x01, 0x0a, 0x0b, 0x43, 0x50, 0x55, 0x50, 0x6f, 0x72,
-	0x74, 0x44, 0x65, 0x73, 0x63, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x71, 0x75, 0x65, 0x75, 0x65, 0x49, 0x64,
-	0x12, 0x21, 0x0a, 0x0c, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4c, 0x65, 0x6e,
-	0x67, 0x74, 0x68, 0x12, 0x43, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x66, 0x69,
-	0x65, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
-	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65,
-	0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x49, 0x64, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74,
-	0x46, 0x69, 0x65, 0x6c, 0x64, 0x49, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x6d, 0x6f,
-	0x74, 0x65, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72,
-	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x22, 0x31, 0x0a, 0x0e, 0x4b, 0x65, 0x72,
-	0x6e, 0x65, 0x6c, 0x50, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x12, 0x1f, 0x0a, 0x0b, 0x64,
-	0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x2e, 0x0a, 0x0b,
-	0x54, 0x41, 0x50, 0x50, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x12, 0x1f, 0x0a, 0x0b, 0x64,
-	0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x5b, 0x0a, 0x0c,
-	0x46, 0x61, 0x6b, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x12, 0x17, 0x0a, 0x07,
-	0x69, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69,
-	0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x5f, 0x66, 0x69, 0x6c,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65,
-	0x12, 0x17, 0x0a, 0x07, 0x68, 0x77, 0x5f, 0x6c, 0x61, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x06, 0x68, 0x77, 0x4c, 0x61, 0x6e, 0x65, 0x22, 0x53, 0x0a, 0x11, 0x47, 0x65, 0x6e,
-	0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x12, 0x1f,
-	0x0a, 0x0b, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12,
-	0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x73,
-	0x0a, 0x11, 0x50, 0x6f, 0x72, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50,
-	0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x34, 0x0a,
-	0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x15, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x49, 0x64, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78,
-	0x74, 0x49, 0x64, 0x22, 0x4d, 0x0a, 0x0f, 0x50, 0x6f, 0x72, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3a, 0x0a, 0x0c, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
-	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x66,
-	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
-	0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x0b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x6e, 0x64,
-	0x65, 0x78, 0x22, 0x95, 0x04, 0x0a, 0x0e, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x44, 0x65, 0x73, 0x63, 0x12, 0x31, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e,
-	0x43, 0x50, 0x55, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73,
-	0x63, 0x48, 0x00, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x43, 0x0a, 0x09, 0x61, 0x67, 0x67, 0x72,
-	0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x66, 0x6f,
-	0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
-	0x74, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63,
-	0x48, 0x00, 0x52, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x53, 0x0a,
-	0x0d, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e,
-	0x67, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x41,
-	0x64, 0x64, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65,
-	0x73, 0x63, 0x48, 0x00, 0x52, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x41,
-	0x64, 0x64, 0x12, 0x56, 0x0a, 0x0d, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x5f,
-	0x64, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x66, 0x6f, 0x72, 0x77,
-	0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
-	0x50, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x48, 0x00, 0x52, 0x0c, 0x61, 0x67,
-	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x44, 0x65, 0x6c, 0x12, 0x55, 0x0a, 0x0e, 0x61, 0x67,
-	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e,
-	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x41, 0x6c, 0x67,
-	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63,
-	0x48, 0x00, 0x52, 0x0d, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x67,
-	0x6f, 0x12, 0x3a, 0x0a, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x20, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x4b,
-	0x65, 0x72, 0x6e, 0x65, 0x6c, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44,
-	0x65, 0x73, 0x63, 0x48, 0x00, 0x52, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x12, 0x43, 0x0a,
-	0x09, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x23, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x47, 0x65,
-	0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x44, 0x65, 0x73, 0x63, 0x48, 0x00, 0x52, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69,
-	0x6e, 0x6b, 0x42, 0x06, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x22, 0xaa, 0x01, 0x0a, 0x11, 0x50,
-	0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x2b, 0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50,
-	0x6f, 0x72, 0x74, 0x49, 0x64, 0x52, 0x06, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x34, 0x0a,
-	0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x15, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x49, 0x64, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78,
-	0x74, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
-	0x2e, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x52,
-	0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x11, 0x0a, 0x0f, 0x50, 0x6f, 0x72, 0x74, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x75, 0x0a, 0x11, 0x43, 0x50,
-	0x55, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x12,
+	0x48, 0x00, 0x52, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x37, 0x0a,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x50, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x48, 0x00, 0x52, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x42, 0x06, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x22, 0xb1,
+	0x01, 0x0a, 0x0b, 0x43, 0x50, 0x55, 0x50, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x12, 0x19,
+	0x0a, 0x08, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x71, 0x75, 0x65, 0x75, 0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x43, 0x0a, 0x10,
+	0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x49,
+	0x64, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x49, 0x64,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x70, 0x6f, 0x72, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x6f,
+	0x72, 0x74, 0x22, 0x31, 0x0a, 0x0e, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x50, 0x6f, 0x72, 0x74,
+	0x44, 0x65, 0x73, 0x63, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x2e, 0x0a, 0x0b, 0x54, 0x41, 0x50, 0x50, 0x6f, 0x72, 0x74,
+	0x44, 0x65, 0x73, 0x63, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x5b, 0x0a, 0x0c, 0x46, 0x61, 0x6b, 0x65, 0x50, 0x6f, 0x72,
+	0x74, 0x44, 0x65, 0x73, 0x63, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x6f, 0x75, 0x74, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6f, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x68, 0x77, 0x5f,
+	0x6c, 0x61, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x68, 0x77, 0x4c, 0x61,
+	0x6e, 0x65, 0x22, 0x53, 0x0a, 0x11, 0x47, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x50,
+	0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x61, 0x6d, 0x69, 0x6c,
+	0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x61,
+	0x6d, 0x69, 0x6c, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x34, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x50, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x12, 0x21, 0x0a, 0x0c, 0x71, 0x75,
+	0x65, 0x75, 0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x73, 0x0a,
+	0x11, 0x50, 0x6f, 0x72, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f,
+	0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x34, 0x0a, 0x0a,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x49, 0x64, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
+	0x49, 0x64, 0x22, 0x4d, 0x0a, 0x0f, 0x50, 0x6f, 0x72, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3a, 0x0a, 0x0c, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x66, 0x6f,
+	0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x52, 0x0b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x22, 0xd4, 0x04, 0x0a, 0x0e, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x44, 0x65, 0x73, 0x63, 0x12, 0x31, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x43,
+	0x50, 0x55, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63,
+	0x48, 0x00, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x43, 0x0a, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x66, 0x6f, 0x72,
+	0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x48,
+	0x00, 0x52, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x53, 0x0a, 0x0d,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x41, 0x64,
+	0x64, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73,
+	0x63, 0x48, 0x00, 0x52, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x41, 0x64,
+	0x64, 0x12, 0x56, 0x0a, 0x0d, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x5f, 0x64,
+	0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x50,
+	0x6f, 0x72, 0x74, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x48, 0x00, 0x52, 0x0c, 0x61, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x44, 0x65, 0x6c, 0x12, 0x55, 0x0a, 0x0e, 0x61, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2c, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x41, 0x6c, 0x67, 0x6f,
+	0x72, 0x69, 0x74, 0x68, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x48,
+	0x00, 0x52, 0x0d, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x67, 0x6f,
+	0x12, 0x3a, 0x0a, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x4b, 0x65,
+	0x72, 0x6e, 0x65, 0x6c, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65,
+	0x73, 0x63, 0x48, 0x00, 0x52, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x12, 0x43, 0x0a, 0x09,
+	0x67, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x23, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x47, 0x65, 0x6e,
+	0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x44, 0x65, 0x73, 0x63, 0x48, 0x00, 0x52, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e,
+	0x6b, 0x12, 0x3d, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x44, 0x65, 0x73, 0x63, 0x48, 0x00, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x42, 0x06, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x22, 0xaa, 0x01, 0x0a, 0x11, 0x50, 0x6f, 0x72,
+	0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b,
+	0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72,
+	0x74, 0x49, 0x64, 0x52, 0x06, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x0a, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x49, 0x64, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x49,
+	0x64, 0x12, 0x32, 0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50,
+	0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x52, 0x06, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x11, 0x0a, 0x0f, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x75, 0x0a, 0x11, 0x43, 0x50, 0x55, 0x50,
+	0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x12, 0x2e, 0x0a,
+	0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x30, 0x0a,
+	0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x22,
+	0x78, 0x0a, 0x14, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x12, 0x2e, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72,
+	0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52,
+	0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63,
+	0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x22, 0x7b, 0x0a, 0x17, 0x47, 0x65, 0x6e,
+	0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x44, 0x65, 0x73, 0x63, 0x12, 0x2e, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x06, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69,
+	0x6e, 0x67, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x07, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x22, 0x79, 0x0a, 0x15, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x12,
 	0x2e, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
 	0x16, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x63, 0x74,
 	0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12,
 	0x30, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
 	0x32, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x63,
 	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
-	0x73, 0x22, 0x78, 0x0a, 0x14, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x50, 0x6f, 0x72, 0x74, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x12, 0x2e, 0x0a, 0x06, 0x69, 0x6e, 0x70,
-	0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x77,
-	0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73,
-	0x63, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x07, 0x6f, 0x75, 0x74,
-	0x70, 0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6f, 0x72,
-	0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65,
-	0x73, 0x63, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x22, 0x7b, 0x0a, 0x17, 0x47,
-	0x65, 0x6e, 0x65, 0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x12, 0x2e, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
-	0x69, 0x6e, 0x67, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x06,
-	0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72,
-	0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52,
-	0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x22, 0x76, 0x0a, 0x15, 0x41, 0x67, 0x67, 0x72,
-	0x65, 0x67, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x2b, 0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e,
-	0x50, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x52, 0x06, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x30,
-	0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x16, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x22, 0x82, 0x02, 0x0a, 0x17, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x50, 0x6f,
-	0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x12, 0x2d, 0x0a, 0x08,
-	0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12,
-	0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74,
-	0x49, 0x64, 0x52, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x73, 0x12, 0x36, 0x0a, 0x04, 0x68,
-	0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x66, 0x6f, 0x72, 0x77,
-	0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
-	0x48, 0x61, 0x73, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x04, 0x68,
-	0x61, 0x73, 0x68, 0x12, 0x36, 0x0a, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x73,
-	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
-	0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x49,
-	0x64, 0x52, 0x08, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x49, 0x64, 0x73, 0x12, 0x48, 0x0a, 0x0e, 0x73,
-	0x65, 0x6c, 0x65, 0x63, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
-	0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
-	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x41, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xb5, 0x01, 0x0a, 0x20, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
-	0x61, 0x74, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x41, 0x64, 0x64, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x12, 0x2b, 0x0a, 0x07, 0x70, 0x6f,
+	0x73, 0x22, 0x76, 0x0a, 0x15, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x07, 0x70, 0x6f,
 	0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6f,
 	0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x52,
-	0x06, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x3d, 0x0a, 0x0e, 0x73, 0x65, 0x6c, 0x65, 0x63,
-	0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x16, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x52, 0x0d, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d,
-	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x52, 0x0a,
-	0x23, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x65,
-	0x6d, 0x6f, 0x76, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x44, 0x65, 0x73, 0x63, 0x12, 0x2b, 0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69,
-	0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x52, 0x06, 0x70, 0x6f, 0x72, 0x74, 0x49,
-	0x64, 0x22, 0x92, 0x01, 0x0a, 0x20, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x50,
-	0x6f, 0x72, 0x74, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x12, 0x36, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e,
-	0x67, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x48, 0x61, 0x73, 0x68, 0x41,
-	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x36,
-	0x0a, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x19, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50,
-	0x61, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x49, 0x64, 0x52, 0x08, 0x66, 0x69,
-	0x65, 0x6c, 0x64, 0x49, 0x64, 0x73, 0x22, 0x5a, 0x0a, 0x09, 0x50, 0x6f, 0x72, 0x74, 0x53, 0x70,
-	0x65, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x62, 0x70, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x04, 0x6b, 0x62, 0x70, 0x73, 0x12, 0x39, 0x0a, 0x08, 0x62, 0x65, 0x68, 0x61, 0x76,
-	0x69, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x66, 0x6f, 0x72, 0x77,
-	0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x53, 0x70, 0x65, 0x65, 0x64,
-	0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x52, 0x08, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69,
-	0x6f, 0x72, 0x22, 0xa9, 0x01, 0x0a, 0x08, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12,
-	0x36, 0x0a, 0x0b, 0x6f, 0x70, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e,
-	0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x6f, 0x70, 0x65,
-	0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x38, 0x0a, 0x0c, 0x61, 0x64, 0x6d, 0x69, 0x6e,
-	0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e,
-	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x2b, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f,
-	0x72, 0x74, 0x53, 0x70, 0x65, 0x65, 0x64, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0xa9,
-	0x01, 0x0a, 0x10, 0x50, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x06, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63,
+	0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x82, 0x02, 0x0a, 0x17, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x44, 0x65, 0x73, 0x63, 0x12, 0x2d, 0x0a, 0x08, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72,
+	0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x52, 0x07, 0x70, 0x6f, 0x72,
+	0x74, 0x49, 0x64, 0x73, 0x12, 0x36, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x22, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x48, 0x61, 0x73, 0x68, 0x41, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x36, 0x0a, 0x09,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x49, 0x64, 0x52, 0x08, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x49, 0x64, 0x73, 0x12, 0x48, 0x0a, 0x0e, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x5f, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x66,
+	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0d, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xb5,
+	0x01, 0x0a, 0x20, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x72, 0x74,
+	0x41, 0x64, 0x64, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44,
+	0x65, 0x73, 0x63, 0x12, 0x2b, 0x0a, 0x07, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e,
 	0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x52, 0x06, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64,
-	0x12, 0x34, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e,
-	0x67, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x49, 0x64, 0x52, 0x09, 0x63, 0x6f, 0x6e,
-	0x74, 0x65, 0x78, 0x74, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66, 0x6f, 0x72, 0x77,
-	0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52,
-	0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x3e, 0x0a, 0x0e, 0x50, 0x6f,
-	0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2c, 0x0a, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66,
-	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e,
-	0x66, 0x6f, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2a, 0xb1, 0x01, 0x0a, 0x08, 0x50,
-	0x6f, 0x72, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x4f, 0x52, 0x54, 0x5f,
-	0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
-	0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
-	0x43, 0x50, 0x55, 0x5f, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x4f,
-	0x52, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54,
-	0x45, 0x5f, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x4f, 0x52, 0x54,
-	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4b, 0x45, 0x52, 0x4e, 0x45, 0x4c, 0x10, 0x03, 0x12, 0x11,
-	0x0a, 0x0d, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x41, 0x50, 0x10,
-	0x04, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x46,
-	0x41, 0x4b, 0x45, 0x10, 0x05, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x54, 0x59,
-	0x50, 0x45, 0x5f, 0x47, 0x45, 0x4e, 0x45, 0x54, 0x4c, 0x49, 0x4e, 0x4b, 0x10, 0x06, 0x2a, 0xae,
+	0x12, 0x3d, 0x0a, 0x0e, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63,
+	0x52, 0x0d, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x52, 0x0a, 0x23, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x12, 0x2b, 0x0a,
+	0x07, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74,
+	0x49, 0x64, 0x52, 0x06, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x22, 0x92, 0x01, 0x0a, 0x20, 0x41,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x41, 0x6c, 0x67, 0x6f,
+	0x72, 0x69, 0x74, 0x68, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x73, 0x63, 0x12,
+	0x36, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e,
+	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x48, 0x61, 0x73, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68,
+	0x6d, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x36, 0x0a, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x6f, 0x72,
+	0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x49, 0x64, 0x52, 0x08, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x49, 0x64, 0x73, 0x22,
+	0x5a, 0x0a, 0x09, 0x50, 0x6f, 0x72, 0x74, 0x53, 0x70, 0x65, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6b, 0x62, 0x70, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x6b, 0x62, 0x70, 0x73,
+	0x12, 0x39, 0x0a, 0x08, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e,
+	0x50, 0x6f, 0x72, 0x74, 0x53, 0x70, 0x65, 0x65, 0x64, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f,
+	0x72, 0x52, 0x08, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x22, 0xa9, 0x01, 0x0a, 0x08,
+	0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x36, 0x0a, 0x0b, 0x6f, 0x70, 0x65, 0x72,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e,
+	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x38, 0x0a, 0x0c, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0b, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2b, 0x0a, 0x05, 0x73, 0x70,
+	0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x66, 0x6f, 0x72, 0x77,
+	0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x53, 0x70, 0x65, 0x65, 0x64,
+	0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0xa9, 0x01, 0x0a, 0x10, 0x50, 0x6f, 0x72, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x07,
+	0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x49,
+	0x64, 0x52, 0x06, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x0a, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x78, 0x74, 0x49, 0x64, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x49, 0x64, 0x12,
+	0x32, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e,
+	0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x3e, 0x0a, 0x0e, 0x50, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69,
+	0x6e, 0x67, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x2a, 0xc8, 0x01, 0x0a, 0x08, 0x50, 0x6f, 0x72, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x19, 0x0a, 0x15, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x50,
+	0x4f, 0x52, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x50, 0x55, 0x5f, 0x50, 0x4f, 0x52,
+	0x54, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x4f, 0x52, 0x54, 0x10,
+	0x02, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4b,
+	0x45, 0x52, 0x4e, 0x45, 0x4c, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x4f, 0x52, 0x54, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x41, 0x50, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x4f,
+	0x52, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x46, 0x41, 0x4b, 0x45, 0x10, 0x05, 0x12, 0x17,
+	0x0a, 0x13, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x45, 0x4e, 0x45,
+	0x54, 0x4c, 0x49, 0x4e, 0x4b, 0x10, 0x06, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x4f, 0x52, 0x54, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x07, 0x2a, 0xae,
 	0x01, 0x0a, 0x16, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x48, 0x61, 0x73, 0x68,
 	0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x28, 0x0a, 0x24, 0x41, 0x47, 0x47,
 	0x52, 0x45, 0x47, 0x41, 0x54, 0x45, 0x5f, 0x48, 0x41, 0x53, 0x48, 0x5f, 0x41, 0x4c, 0x47, 0x4f,
@@ -1974,7 +2127,7 @@ func file_proto_forwarding_forwarding_port_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_forwarding_forwarding_port_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_proto_forwarding_forwarding_port_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_proto_forwarding_forwarding_port_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_proto_forwarding_forwarding_port_proto_goTypes = []interface{}{
 	(PortType)(0),                               // 0: forwarding.PortType
 	(AggregateHashAlgorithm)(0),                 // 1: forwarding.AggregateHashAlgorithm
@@ -1986,81 +2139,87 @@ var file_proto_forwarding_forwarding_port_proto_goTypes = []interface{}{
 	(*TAPPortDesc)(nil),                         // 7: forwarding.TAPPortDesc
 	(*FakePortDesc)(nil),                        // 8: forwarding.FakePortDesc
 	(*GenetlinkPortDesc)(nil),                   // 9: forwarding.GenetlinkPortDesc
-	(*PortCreateRequest)(nil),                   // 10: forwarding.PortCreateRequest
-	(*PortCreateReply)(nil),                     // 11: forwarding.PortCreateReply
-	(*PortUpdateDesc)(nil),                      // 12: forwarding.PortUpdateDesc
-	(*PortUpdateRequest)(nil),                   // 13: forwarding.PortUpdateRequest
-	(*PortUpdateReply)(nil),                     // 14: forwarding.PortUpdateReply
-	(*CPUPortUpdateDesc)(nil),                   // 15: forwarding.CPUPortUpdateDesc
-	(*KernelPortUpdateDesc)(nil),                // 16: forwarding.KernelPortUpdateDesc
-	(*GenetlinkPortUpdateDesc)(nil),             // 17: forwarding.GenetlinkPortUpdateDesc
-	(*AggregateSelectAction)(nil),               // 18: forwarding.AggregateSelectAction
-	(*AggregatePortUpdateDesc)(nil),             // 19: forwarding.AggregatePortUpdateDesc
-	(*AggregatePortAddMemberUpdateDesc)(nil),    // 20: forwarding.AggregatePortAddMemberUpdateDesc
-	(*AggregatePortRemoveMemberUpdateDesc)(nil), // 21: forwarding.AggregatePortRemoveMemberUpdateDesc
-	(*AggregatePortAlgorithmUpdateDesc)(nil),    // 22: forwarding.AggregatePortAlgorithmUpdateDesc
-	(*PortSpeed)(nil),                           // 23: forwarding.PortSpeed
-	(*PortInfo)(nil),                            // 24: forwarding.PortInfo
-	(*PortStateRequest)(nil),                    // 25: forwarding.PortStateRequest
-	(*PortStateReply)(nil),                      // 26: forwarding.PortStateReply
-	(*PortId)(nil),                              // 27: forwarding.PortId
-	(*PacketFieldId)(nil),                       // 28: forwarding.PacketFieldId
-	(*ContextId)(nil),                           // 29: forwarding.ContextId
-	(*ObjectIndex)(nil),                         // 30: forwarding.ObjectIndex
-	(*ActionDesc)(nil),                          // 31: forwarding.ActionDesc
+	(*ChannelPortDesc)(nil),                     // 10: forwarding.ChannelPortDesc
+	(*PortCreateRequest)(nil),                   // 11: forwarding.PortCreateRequest
+	(*PortCreateReply)(nil),                     // 12: forwarding.PortCreateReply
+	(*PortUpdateDesc)(nil),                      // 13: forwarding.PortUpdateDesc
+	(*PortUpdateRequest)(nil),                   // 14: forwarding.PortUpdateRequest
+	(*PortUpdateReply)(nil),                     // 15: forwarding.PortUpdateReply
+	(*CPUPortUpdateDesc)(nil),                   // 16: forwarding.CPUPortUpdateDesc
+	(*KernelPortUpdateDesc)(nil),                // 17: forwarding.KernelPortUpdateDesc
+	(*GenetlinkPortUpdateDesc)(nil),             // 18: forwarding.GenetlinkPortUpdateDesc
+	(*ChannelPortUpdateDesc)(nil),               // 19: forwarding.ChannelPortUpdateDesc
+	(*AggregateSelectAction)(nil),               // 20: forwarding.AggregateSelectAction
+	(*AggregatePortUpdateDesc)(nil),             // 21: forwarding.AggregatePortUpdateDesc
+	(*AggregatePortAddMemberUpdateDesc)(nil),    // 22: forwarding.AggregatePortAddMemberUpdateDesc
+	(*AggregatePortRemoveMemberUpdateDesc)(nil), // 23: forwarding.AggregatePortRemoveMemberUpdateDesc
+	(*AggregatePortAlgorithmUpdateDesc)(nil),    // 24: forwarding.AggregatePortAlgorithmUpdateDesc
+	(*PortSpeed)(nil),                           // 25: forwarding.PortSpeed
+	(*PortInfo)(nil),                            // 26: forwarding.PortInfo
+	(*PortStateRequest)(nil),                    // 27: forwarding.PortStateRequest
+	(*PortStateReply)(nil),                      // 28: forwarding.PortStateReply
+	(*PortId)(nil),                              // 29: forwarding.PortId
+	(*PacketFieldId)(nil),                       // 30: forwarding.PacketFieldId
+	(*ContextId)(nil),                           // 31: forwarding.ContextId
+	(*ObjectIndex)(nil),                         // 32: forwarding.ObjectIndex
+	(*ActionDesc)(nil),                          // 33: forwarding.ActionDesc
 }
 var file_proto_forwarding_forwarding_port_proto_depIdxs = []int32{
 	0,  // 0: forwarding.PortDesc.port_type:type_name -> forwarding.PortType
-	27, // 1: forwarding.PortDesc.port_id:type_name -> forwarding.PortId
+	29, // 1: forwarding.PortDesc.port_id:type_name -> forwarding.PortId
 	5,  // 2: forwarding.PortDesc.cpu:type_name -> forwarding.CPUPortDesc
 	6,  // 3: forwarding.PortDesc.kernel:type_name -> forwarding.KernelPortDesc
 	7,  // 4: forwarding.PortDesc.tap:type_name -> forwarding.TAPPortDesc
 	8,  // 5: forwarding.PortDesc.fake:type_name -> forwarding.FakePortDesc
 	9,  // 6: forwarding.PortDesc.genetlink:type_name -> forwarding.GenetlinkPortDesc
-	28, // 7: forwarding.CPUPortDesc.export_field_ids:type_name -> forwarding.PacketFieldId
-	4,  // 8: forwarding.PortCreateRequest.port:type_name -> forwarding.PortDesc
-	29, // 9: forwarding.PortCreateRequest.context_id:type_name -> forwarding.ContextId
-	30, // 10: forwarding.PortCreateReply.object_index:type_name -> forwarding.ObjectIndex
-	15, // 11: forwarding.PortUpdateDesc.cpu:type_name -> forwarding.CPUPortUpdateDesc
-	19, // 12: forwarding.PortUpdateDesc.aggregate:type_name -> forwarding.AggregatePortUpdateDesc
-	20, // 13: forwarding.PortUpdateDesc.aggregate_add:type_name -> forwarding.AggregatePortAddMemberUpdateDesc
-	21, // 14: forwarding.PortUpdateDesc.aggregate_del:type_name -> forwarding.AggregatePortRemoveMemberUpdateDesc
-	22, // 15: forwarding.PortUpdateDesc.aggregate_algo:type_name -> forwarding.AggregatePortAlgorithmUpdateDesc
-	16, // 16: forwarding.PortUpdateDesc.kernel:type_name -> forwarding.KernelPortUpdateDesc
-	17, // 17: forwarding.PortUpdateDesc.genetlink:type_name -> forwarding.GenetlinkPortUpdateDesc
-	27, // 18: forwarding.PortUpdateRequest.port_id:type_name -> forwarding.PortId
-	29, // 19: forwarding.PortUpdateRequest.context_id:type_name -> forwarding.ContextId
-	12, // 20: forwarding.PortUpdateRequest.update:type_name -> forwarding.PortUpdateDesc
-	31, // 21: forwarding.CPUPortUpdateDesc.inputs:type_name -> forwarding.ActionDesc
-	31, // 22: forwarding.CPUPortUpdateDesc.outputs:type_name -> forwarding.ActionDesc
-	31, // 23: forwarding.KernelPortUpdateDesc.inputs:type_name -> forwarding.ActionDesc
-	31, // 24: forwarding.KernelPortUpdateDesc.outputs:type_name -> forwarding.ActionDesc
-	31, // 25: forwarding.GenetlinkPortUpdateDesc.inputs:type_name -> forwarding.ActionDesc
-	31, // 26: forwarding.GenetlinkPortUpdateDesc.outputs:type_name -> forwarding.ActionDesc
-	27, // 27: forwarding.AggregateSelectAction.port_id:type_name -> forwarding.PortId
-	31, // 28: forwarding.AggregateSelectAction.actions:type_name -> forwarding.ActionDesc
-	27, // 29: forwarding.AggregatePortUpdateDesc.port_ids:type_name -> forwarding.PortId
-	1,  // 30: forwarding.AggregatePortUpdateDesc.hash:type_name -> forwarding.AggregateHashAlgorithm
-	28, // 31: forwarding.AggregatePortUpdateDesc.field_ids:type_name -> forwarding.PacketFieldId
-	18, // 32: forwarding.AggregatePortUpdateDesc.select_actions:type_name -> forwarding.AggregateSelectAction
-	27, // 33: forwarding.AggregatePortAddMemberUpdateDesc.port_id:type_name -> forwarding.PortId
-	31, // 34: forwarding.AggregatePortAddMemberUpdateDesc.select_actions:type_name -> forwarding.ActionDesc
-	27, // 35: forwarding.AggregatePortRemoveMemberUpdateDesc.port_id:type_name -> forwarding.PortId
-	1,  // 36: forwarding.AggregatePortAlgorithmUpdateDesc.hash:type_name -> forwarding.AggregateHashAlgorithm
-	28, // 37: forwarding.AggregatePortAlgorithmUpdateDesc.field_ids:type_name -> forwarding.PacketFieldId
-	3,  // 38: forwarding.PortSpeed.behavior:type_name -> forwarding.PortSpeedBehavior
-	2,  // 39: forwarding.PortInfo.oper_status:type_name -> forwarding.PortState
-	2,  // 40: forwarding.PortInfo.admin_status:type_name -> forwarding.PortState
-	23, // 41: forwarding.PortInfo.speed:type_name -> forwarding.PortSpeed
-	27, // 42: forwarding.PortStateRequest.port_id:type_name -> forwarding.PortId
-	29, // 43: forwarding.PortStateRequest.context_id:type_name -> forwarding.ContextId
-	24, // 44: forwarding.PortStateRequest.operation:type_name -> forwarding.PortInfo
-	24, // 45: forwarding.PortStateReply.status:type_name -> forwarding.PortInfo
-	46, // [46:46] is the sub-list for method output_type
-	46, // [46:46] is the sub-list for method input_type
-	46, // [46:46] is the sub-list for extension type_name
-	46, // [46:46] is the sub-list for extension extendee
-	0,  // [0:46] is the sub-list for field type_name
+	10, // 7: forwarding.PortDesc.channel:type_name -> forwarding.ChannelPortDesc
+	30, // 8: forwarding.CPUPortDesc.export_field_ids:type_name -> forwarding.PacketFieldId
+	4,  // 9: forwarding.PortCreateRequest.port:type_name -> forwarding.PortDesc
+	31, // 10: forwarding.PortCreateRequest.context_id:type_name -> forwarding.ContextId
+	32, // 11: forwarding.PortCreateReply.object_index:type_name -> forwarding.ObjectIndex
+	16, // 12: forwarding.PortUpdateDesc.cpu:type_name -> forwarding.CPUPortUpdateDesc
+	21, // 13: forwarding.PortUpdateDesc.aggregate:type_name -> forwarding.AggregatePortUpdateDesc
+	22, // 14: forwarding.PortUpdateDesc.aggregate_add:type_name -> forwarding.AggregatePortAddMemberUpdateDesc
+	23, // 15: forwarding.PortUpdateDesc.aggregate_del:type_name -> forwarding.AggregatePortRemoveMemberUpdateDesc
+	24, // 16: forwarding.PortUpdateDesc.aggregate_algo:type_name -> forwarding.AggregatePortAlgorithmUpdateDesc
+	17, // 17: forwarding.PortUpdateDesc.kernel:type_name -> forwarding.KernelPortUpdateDesc
+	18, // 18: forwarding.PortUpdateDesc.genetlink:type_name -> forwarding.GenetlinkPortUpdateDesc
+	19, // 19: forwarding.PortUpdateDesc.channel:type_name -> forwarding.ChannelPortUpdateDesc
+	29, // 20: forwarding.PortUpdateRequest.port_id:type_name -> forwarding.PortId
+	31, // 21: forwarding.PortUpdateRequest.context_id:type_name -> forwarding.ContextId
+	13, // 22: forwarding.PortUpdateRequest.update:type_name -> forwarding.PortUpdateDesc
+	33, // 23: forwarding.CPUPortUpdateDesc.inputs:type_name -> forwarding.ActionDesc
+	33, // 24: forwarding.CPUPortUpdateDesc.outputs:type_name -> forwarding.ActionDesc
+	33, // 25: forwarding.KernelPortUpdateDesc.inputs:type_name -> forwarding.ActionDesc
+	33, // 26: forwarding.KernelPortUpdateDesc.outputs:type_name -> forwarding.ActionDesc
+	33, // 27: forwarding.GenetlinkPortUpdateDesc.inputs:type_name -> forwarding.ActionDesc
+	33, // 28: forwarding.GenetlinkPortUpdateDesc.outputs:type_name -> forwarding.ActionDesc
+	33, // 29: forwarding.ChannelPortUpdateDesc.inputs:type_name -> forwarding.ActionDesc
+	33, // 30: forwarding.ChannelPortUpdateDesc.outputs:type_name -> forwarding.ActionDesc
+	29, // 31: forwarding.AggregateSelectAction.port_id:type_name -> forwarding.PortId
+	33, // 32: forwarding.AggregateSelectAction.actions:type_name -> forwarding.ActionDesc
+	29, // 33: forwarding.AggregatePortUpdateDesc.port_ids:type_name -> forwarding.PortId
+	1,  // 34: forwarding.AggregatePortUpdateDesc.hash:type_name -> forwarding.AggregateHashAlgorithm
+	30, // 35: forwarding.AggregatePortUpdateDesc.field_ids:type_name -> forwarding.PacketFieldId
+	20, // 36: forwarding.AggregatePortUpdateDesc.select_actions:type_name -> forwarding.AggregateSelectAction
+	29, // 37: forwarding.AggregatePortAddMemberUpdateDesc.port_id:type_name -> forwarding.PortId
+	33, // 38: forwarding.AggregatePortAddMemberUpdateDesc.select_actions:type_name -> forwarding.ActionDesc
+	29, // 39: forwarding.AggregatePortRemoveMemberUpdateDesc.port_id:type_name -> forwarding.PortId
+	1,  // 40: forwarding.AggregatePortAlgorithmUpdateDesc.hash:type_name -> forwarding.AggregateHashAlgorithm
+	30, // 41: forwarding.AggregatePortAlgorithmUpdateDesc.field_ids:type_name -> forwarding.PacketFieldId
+	3,  // 42: forwarding.PortSpeed.behavior:type_name -> forwarding.PortSpeedBehavior
+	2,  // 43: forwarding.PortInfo.oper_status:type_name -> forwarding.PortState
+	2,  // 44: forwarding.PortInfo.admin_status:type_name -> forwarding.PortState
+	25, // 45: forwarding.PortInfo.speed:type_name -> forwarding.PortSpeed
+	29, // 46: forwarding.PortStateRequest.port_id:type_name -> forwarding.PortId
+	31, // 47: forwarding.PortStateRequest.context_id:type_name -> forwarding.ContextId
+	26, // 48: forwarding.PortStateRequest.operation:type_name -> forwarding.PortInfo
+	26, // 49: forwarding.PortStateReply.status:type_name -> forwarding.PortInfo
+	50, // [50:50] is the sub-list for method output_type
+	50, // [50:50] is the sub-list for method input_type
+	50, // [50:50] is the sub-list for extension type_name
+	50, // [50:50] is the sub-list for extension extendee
+	0,  // [0:50] is the sub-list for field type_name
 }
 
 func init() { file_proto_forwarding_forwarding_port_proto_init() }
@@ -2144,7 +2303,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PortCreateRequest); i {
+			switch v := v.(*ChannelPortDesc); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2156,7 +2315,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PortCreateReply); i {
+			switch v := v.(*PortCreateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2168,7 +2327,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PortUpdateDesc); i {
+			switch v := v.(*PortCreateReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2180,7 +2339,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PortUpdateRequest); i {
+			switch v := v.(*PortUpdateDesc); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2192,7 +2351,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PortUpdateReply); i {
+			switch v := v.(*PortUpdateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2204,7 +2363,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CPUPortUpdateDesc); i {
+			switch v := v.(*PortUpdateReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2216,7 +2375,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KernelPortUpdateDesc); i {
+			switch v := v.(*CPUPortUpdateDesc); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2228,7 +2387,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GenetlinkPortUpdateDesc); i {
+			switch v := v.(*KernelPortUpdateDesc); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2240,7 +2399,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AggregateSelectAction); i {
+			switch v := v.(*GenetlinkPortUpdateDesc); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2252,7 +2411,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AggregatePortUpdateDesc); i {
+			switch v := v.(*ChannelPortUpdateDesc); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2264,7 +2423,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AggregatePortAddMemberUpdateDesc); i {
+			switch v := v.(*AggregateSelectAction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2276,7 +2435,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AggregatePortRemoveMemberUpdateDesc); i {
+			switch v := v.(*AggregatePortUpdateDesc); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2288,7 +2447,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AggregatePortAlgorithmUpdateDesc); i {
+			switch v := v.(*AggregatePortAddMemberUpdateDesc); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2300,7 +2459,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PortSpeed); i {
+			switch v := v.(*AggregatePortRemoveMemberUpdateDesc); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2312,7 +2471,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PortInfo); i {
+			switch v := v.(*AggregatePortAlgorithmUpdateDesc); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2324,7 +2483,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PortStateRequest); i {
+			switch v := v.(*PortSpeed); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2336,6 +2495,30 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			}
 		}
 		file_proto_forwarding_forwarding_port_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PortInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_forwarding_forwarding_port_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PortStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_forwarding_forwarding_port_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PortStateReply); i {
 			case 0:
 				return &v.state
@@ -2354,8 +2537,9 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 		(*PortDesc_Tap)(nil),
 		(*PortDesc_Fake)(nil),
 		(*PortDesc_Genetlink)(nil),
+		(*PortDesc_Channel)(nil),
 	}
-	file_proto_forwarding_forwarding_port_proto_msgTypes[8].OneofWrappers = []interface{}{
+	file_proto_forwarding_forwarding_port_proto_msgTypes[9].OneofWrappers = []interface{}{
 		(*PortUpdateDesc_Cpu)(nil),
 		(*PortUpdateDesc_Aggregate)(nil),
 		(*PortUpdateDesc_AggregateAdd)(nil),
@@ -2363,6 +2547,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 		(*PortUpdateDesc_AggregateAlgo)(nil),
 		(*PortUpdateDesc_Kernel)(nil),
 		(*PortUpdateDesc_Genetlink)(nil),
+		(*PortUpdateDesc_Channel)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2370,7 +2555,7 @@ func file_proto_forwarding_forwarding_port_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_forwarding_forwarding_port_proto_rawDesc,
 			NumEnums:      4,
-			NumMessages:   23,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
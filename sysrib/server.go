@@ -23,6 +23,7 @@ import (
 	"net/netip"
 	"os"
 	"reflect"
+	"slices"
 	"strconv"
 	"sync"
 
@@ -92,6 +93,14 @@ type Server struct {
 	dataplane dplane
 
 	zServer *ZServer
+
+	fibListenersMu sync.Mutex
+	// fibListeners are called each time the RIB has been re-resolved and
+	// the forwarding plane programmed.
+	fibListeners []func()
+	// fibChanged holds a pending FIB change notification for the listeners.
+	// Changes made while a notification is pending are coalesced into it.
+	fibChanged chan struct{}
 }
 
 // dplane represents the dataplane API accessible to sysrib for programming
@@ -137,6 +146,7 @@ func New(cfg *oc.Root) (*Server, error) {
 		bgpGUEPolicies:   map[string]GUEPolicy{},
 		programmedRoutes: map[RouteKey]*ResolvedRoute{},
 		resolvedRoutes:   map[RouteKey]*Route{},
+		fibChanged:       make(chan struct{}, 1),
 	}
 	return s, nil
 }
@@ -169,6 +179,12 @@ func (s *Server) Start(ctx context.Context, gClient gpb.GNMIClient, target, zapi
 		return err
 	}
 
+	if err := s.monitorAdminDistances(ctx, yclient); err != nil {
+		return err
+	}
+
+	go s.notifyFIBListeners(ctx)
+
 	if err := os.RemoveAll(sysribAddr); err != nil {
 		return err
 	}
@@ -304,6 +320,41 @@ func (s *Server) monitorBGPGUEPolicies(ctx context.Context, yclient *ygnmi.Clien
 	return nil
 }
 
+// monitorAdminDistances starts a gothread to check for the configured admin
+// distances of eBGP and iBGP routes, and informs the sysrib server accordingly
+// to update programmed routes.
+func (s *Server) monitorAdminDistances(ctx context.Context, yclient *ygnmi.Client) error {
+	distancePath := ocpath.Root().NetworkInstance(fakedevice.DefaultNetworkInstance).
+		Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, fakedevice.BGPRoutingProtocol).
+		Bgp().Global().DefaultRouteDistance()
+
+	for protocol, path := range map[Protocol]ygnmi.ConfigQuery[uint8]{
+		ProtocolEBGP: distancePath.ExternalRouteDistance().Config(),
+		ProtocolIBGP: distancePath.InternalRouteDistance().Config(),
+	} {
+		protocol := protocol
+		distanceWatcher := ygnmi.Watch(
+			ctx,
+			yclient,
+			path,
+			func(v *ygnmi.Value[uint8]) error {
+				distance, ok := v.Val()
+				if err := s.setAdminDistance(ctx, protocol, distance, ok); err != nil {
+					log.Errorf("Failed while setting admin distance of protocol %v: %v", protocol, err)
+				}
+				return ygnmi.Continue
+			},
+		)
+
+		go func() {
+			if _, err := distanceWatcher.Await(); err != nil {
+				log.Warningf("Sysrib admin distance watcher of protocol %v has stopped: %v", protocol, err)
+			}
+		}()
+	}
+	return nil
+}
+
 // RouteKey is the unique identifier of an IP route.
 type RouteKey struct {
 	Prefix string
@@ -450,6 +501,9 @@ func resolvedRouteToRouteRequest(r *ResolvedRoute) (*dpb.Route, error) {
 // programs the forwarding plane.
 func (s *Server) ResolveAndProgramDiff(ctx context.Context) error {
 	log.Info("Recalculating resolved RIB")
+	// Listeners are notified once the RIB lock is released so that they
+	// are able to query the RIB.
+	defer s.notifyFIBChange()
 	if debug.SysRIB {
 		defer s.rib.PrintRIB()
 	}
@@ -567,6 +621,65 @@ func (s *Server) ProgrammedRoutes() map[RouteKey]*ResolvedRoute {
 	return maps.Clone(s.programmedRoutes)
 }
 
+// NexthopResolvable returns whether addr resolves to at least one egress
+// nexthop in the RIB of the network instance niName.
+func (s *Server) NexthopResolvable(niName string, addr netip.Addr) bool {
+	pfx, err := addressToPrefix(addr.String())
+	if err != nil {
+		log.Warningf("sysrib: %v", err)
+		return false
+	}
+	s.rib.mu.RLock()
+	defer s.rib.mu.RUnlock()
+	s.interfacesMu.Lock()
+	defer s.interfacesMu.Unlock()
+	nhs, _, err := s.rib.egressNexthops(niName, pfx, s.interfaces)
+	if err != nil {
+		log.Warningf("sysrib: cannot resolve nexthop %v: %v", addr, err)
+		return false
+	}
+	return len(nhs) > 0
+}
+
+// OnFIBChange registers f to be called after the RIB has been re-resolved and
+// the forwarding plane programmed. Changes made while the listeners are being
+// called are coalesced into a single call.
+//
+// f is called from the server's notifier goroutine, so it may call back into
+// the server.
+func (s *Server) OnFIBChange(f func()) {
+	s.fibListenersMu.Lock()
+	defer s.fibListenersMu.Unlock()
+	s.fibListeners = append(s.fibListeners, f)
+}
+
+// notifyFIBChange schedules a call of all listeners registered using
+// OnFIBChange, unless one is already pending.
+func (s *Server) notifyFIBChange() {
+	select {
+	case s.fibChanged <- struct{}{}:
+	default:
+	}
+}
+
+// notifyFIBListeners calls all listeners registered using OnFIBChange for
+// each pending FIB change until ctx is done.
+func (s *Server) notifyFIBListeners(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.fibChanged:
+		}
+		s.fibListenersMu.Lock()
+		listeners := slices.Clone(s.fibListeners)
+		s.fibListenersMu.Unlock()
+		for _, f := range listeners {
+			f()
+		}
+	}
+}
+
 // SetRoute implements ROUTE_ADD and ROUTE_DELETE
 func (s *Server) SetRoute(ctx context.Context, req *sysribpb.SetRouteRequest) (*sysribpb.SetRouteResponse, error) {
 	pfx, err := prefixString(req.Prefix)
@@ -644,6 +757,7 @@ func (s *Server) setConnectedRoute(ctx context.Context, connected connectedRoute
 			// Connected routes have admin-distance of 0.
 			AdminDistance: 0,
 		},
+		Protocol: ProtocolConnected,
 	}, isDelete)
 }
 
@@ -673,6 +787,19 @@ func (s *Server) setGUEPolicy(ctx context.Context, prefix string, policy GUEPoli
 	return nil
 }
 
+// setAdminDistance configures the admin distance of the protocol, or restores
+// the default if ok is false, and triggers resolved route computation and
+// programming.
+func (s *Server) setAdminDistance(ctx context.Context, protocol Protocol, distance uint8, ok bool) error {
+	if ok {
+		log.V(1).Infof("Setting admin distance of protocol %v to %d", protocol, distance)
+		s.rib.SetAdminDistance(protocol, distance)
+	} else if !s.rib.DeleteAdminDistance(protocol) {
+		return nil
+	}
+	return s.ResolveAndProgramDiff(ctx)
+}
+
 // deleteGUEPolicy adds a new GUE policy and triggers resolved route
 // computation and programming.
 func (s *Server) deleteGUEPolicy(ctx context.Context, prefix string) error {
@@ -28,14 +28,19 @@ import (
 	"github.com/openconfig/ygnmi/schemaless"
 	"github.com/openconfig/ygnmi/ygnmi"
 	"github.com/openconfig/ygot/ygot"
+	"github.com/osrg/gobgp/v3/pkg/zebra"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/local"
 	"google.golang.org/protobuf/testing/protocmp"
 
 	"github.com/openconfig/lemming/gnmi"
+	"github.com/openconfig/lemming/gnmi/fakedevice"
 	"github.com/openconfig/lemming/gnmi/gnmiclient"
 	"github.com/openconfig/lemming/gnmi/oc"
 	"github.com/openconfig/lemming/gnmi/oc/ocpath"
 
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+
 	dpb "github.com/openconfig/lemming/proto/dataplane"
 	pb "github.com/openconfig/lemming/proto/sysrib"
 )
@@ -2146,3 +2151,276 @@ func TestBGPGUEPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestNexthopResolvable(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	gnmiServer, err := gnmi.New(grpcServer, "local", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Failed to start listener: %v", err)
+	}
+	go func() {
+		grpcServer.Serve(lis)
+	}()
+
+	s, err := New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := gnmiServer.LocalClient()
+	if err := s.Start(context.Background(), client, "local", "", "/tmp/sysrib.api"); err != nil {
+		t.Fatalf("cannot start sysrib server, %v", err)
+	}
+	defer s.Stop()
+
+	fibChanged := make(chan struct{}, 100)
+	s.OnFIBChange(func() { fibChanged <- struct{}{} })
+
+	c, err := ygnmi.NewClient(client, ygnmi.WithTarget("local"))
+	if err != nil {
+		t.Fatalf("cannot create ygnmi client: %v", err)
+	}
+
+	awaitResolvable := func(addr string, want bool) {
+		t.Helper()
+		for i := 0; i != maxGNMIWaitQuanta; i++ {
+			if s.NexthopResolvable("DEFAULT", netip.MustParseAddr(addr)) == want {
+				return
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		t.Fatalf("NexthopResolvable(%s): got %v, want %v", addr, !want, want)
+	}
+
+	awaitResolvable("192.168.1.42", false)
+	configureInterface(t, &AddIntfAction{
+		name:    "eth0",
+		ifindex: 0,
+		enabled: true,
+		prefix:  "192.168.1.1/24",
+		niName:  "DEFAULT",
+	}, c)
+	awaitResolvable("192.168.1.42", true)
+	awaitResolvable("10.0.0.1", false)
+
+	// Drain notifications caused by the connected route.
+	for len(fibChanged) > 0 {
+		<-fibChanged
+	}
+
+	route := &pb.SetRouteRequest{
+		AdminDistance: 10,
+		Metric:        10,
+		Prefix: &pb.Prefix{
+			Family:     pb.Prefix_FAMILY_IPV4,
+			Address:    "10.0.0.0",
+			MaskLength: 8,
+		},
+		Nexthops: []*pb.Nexthop{{
+			Type:    pb.Nexthop_TYPE_IPV4,
+			Address: "192.168.1.42",
+			Weight:  1,
+		}},
+	}
+	if _, err := s.SetRoute(context.Background(), route); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-fibChanged:
+	case <-time.After(10 * time.Second):
+		t.Fatal("FIB change listener was not called after adding route")
+	}
+	awaitResolvable("10.0.0.1", true)
+
+	route.Delete = true
+	if _, err := s.SetRoute(context.Background(), route); err != nil {
+		t.Fatal(err)
+	}
+	awaitResolvable("10.0.0.1", false)
+
+	// A default route whose nexthop is only covered by the default route itself is unresolvable.
+	if _, err := s.SetRoute(context.Background(), &pb.SetRouteRequest{
+		AdminDistance: 20,
+		Metric:        10,
+		Prefix: &pb.Prefix{
+			Family:     pb.Prefix_FAMILY_IPV4,
+			Address:    "0.0.0.0",
+			MaskLength: 0,
+		},
+		Nexthops: []*pb.Nexthop{{
+			Type:    pb.Nexthop_TYPE_IPV4,
+			Address: "10.0.0.1",
+			Weight:  1,
+		}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	awaitResolvable("10.0.0.1", false)
+	awaitResolvable("192.168.1.42", true)
+}
+
+func TestAdminDistance(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	gnmiServer, err := gnmi.New(grpcServer, "local", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Failed to start listener: %v", err)
+	}
+	go func() {
+		grpcServer.Serve(lis)
+	}()
+
+	s, err := New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := gnmiServer.LocalClient()
+	if err := s.Start(context.Background(), client, "local", "", "/tmp/sysrib.api"); err != nil {
+		t.Fatalf("cannot start sysrib server, %v", err)
+	}
+	defer s.Stop()
+
+	stateC, err := ygnmi.NewClient(client, ygnmi.WithTarget("local"))
+	if err != nil {
+		t.Fatalf("cannot create ygnmi client: %v", err)
+	}
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(local.NewCredentials()))
+	if err != nil {
+		t.Fatalf("cannot dial gNMI server, %v", err)
+	}
+	configC, err := ygnmi.NewClient(gpb.NewGNMIClient(conn), ygnmi.WithTarget("local"))
+	if err != nil {
+		t.Fatalf("cannot create ygnmi client: %v", err)
+	}
+
+	for i, prefix := range []string{"192.168.1.1/24", "192.168.2.1/24"} {
+		configureInterface(t, &AddIntfAction{
+			name:    fmt.Sprintf("eth%d", i),
+			ifindex: int32(i),
+			enabled: true,
+			prefix:  prefix,
+			niName:  "DEFAULT",
+		}, stateC)
+	}
+
+	routesQuery := programmedRoutesQuery(t)
+	awaitRoute := func(want *dpb.Route) {
+		t.Helper()
+		var diff string
+		for i := 0; i != maxGNMIWaitQuanta; i++ {
+			routes, err := ygnmi.GetAll(context.Background(), stateC, routesQuery)
+			if err == nil {
+				var got *dpb.Route
+				for _, r := range routes {
+					if r.GetPrefix().GetCidr() == want.GetPrefix().GetCidr() {
+						got = r
+					}
+				}
+				if diff = cmp.Diff(want, got, protocmp.Transform()); diff == "" {
+					return
+				}
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		t.Fatalf("programmed route for %s not equal to want (-want, +got):\n%s", want.GetPrefix().GetCidr(), diff)
+	}
+	viaInterface := func(cidr, intf string) *dpb.Route {
+		return &dpb.Route{
+			Prefix: &dpb.RoutePrefix{NetworkInstance: "DEFAULT", Cidr: cidr},
+			Hop:    &dpb.Route_Interface{Interface: &dpb.OCInterface{Interface: intf}},
+		}
+	}
+	viaNexthop := func(cidr, nh, intf string) *dpb.Route {
+		return &dpb.Route{
+			Prefix: &dpb.RoutePrefix{NetworkInstance: "DEFAULT", Cidr: cidr},
+			Hop: &dpb.Route_NextHops{
+				NextHops: &dpb.NextHopList{
+					Weights: []uint64{0},
+					Hops: []*dpb.NextHop{{
+						NextHopIp: nh,
+						Interface: &dpb.OCInterface{Interface: intf},
+					}},
+				},
+			},
+		}
+	}
+	setRoute := func(distance uint32, addr string, length uint32, nh string) {
+		t.Helper()
+		if _, err := s.SetRoute(context.Background(), &pb.SetRouteRequest{
+			AdminDistance: distance,
+			Prefix: &pb.Prefix{
+				Family:     pb.Prefix_FAMILY_IPV4,
+				Address:    addr,
+				MaskLength: length,
+			},
+			Nexthops: []*pb.Nexthop{{
+				Type:    pb.Nexthop_TYPE_IPV4,
+				Address: nh,
+			}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	setBGPRoute := func(flags zebra.Flag, addr string, length uint8, nh string) {
+		t.Helper()
+		if err := s.setZebraRoute(context.Background(), fakedevice.DefaultNetworkInstance, &zebra.IPRouteBody{
+			Type:     zebra.RouteBGP,
+			Flags:    flags,
+			Prefix:   zebra.Prefix{Prefix: net.ParseIP(addr).To4(), PrefixLen: length},
+			Nexthops: []zebra.Nexthop{{Gate: net.ParseIP(nh)}},
+		}, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ebgp, ibgp := zebra.Flag(0), zebra.FlagIBGP
+
+	// The connected route wins over a BGP route for the same prefix.
+	awaitRoute(viaInterface("192.168.1.0/24", "eth0"))
+	setBGPRoute(ebgp, "192.168.1.0", 24, "192.168.2.42")
+	awaitRoute(viaInterface("192.168.1.0/24", "eth0"))
+
+	// A route with a lower admin distance wins over the eBGP and iBGP routes.
+	setRoute(10, "10.0.0.0", 8, "192.168.1.42")
+	setBGPRoute(ebgp, "10.0.0.0", 8, "192.168.2.42")
+	awaitRoute(viaNexthop("10.0.0.0/8", "192.168.1.42", "eth0"))
+	setRoute(10, "172.16.0.0", 12, "192.168.1.42")
+	setBGPRoute(ibgp, "172.16.0.0", 12, "192.168.2.42")
+	awaitRoute(viaNexthop("172.16.0.0/12", "192.168.1.42", "eth0"))
+
+	// Until the admin distance of eBGP is configured below it, which doesn't apply to iBGP.
+	distancePath := ocpath.Root().NetworkInstance(fakedevice.DefaultNetworkInstance).
+		Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, fakedevice.BGPRoutingProtocol).
+		Bgp().Global().DefaultRouteDistance()
+	if _, err := gnmiclient.Replace(context.Background(), configC, distancePath.ExternalRouteDistance().Config(), 5); err != nil {
+		t.Fatal(err)
+	}
+	awaitRoute(viaNexthop("10.0.0.0/8", "192.168.2.42", "eth1"))
+	awaitRoute(viaNexthop("172.16.0.0/12", "192.168.1.42", "eth0"))
+	// The connected route still wins.
+	awaitRoute(viaInterface("192.168.1.0/24", "eth0"))
+
+	// And the admin distance of iBGP is configured below it.
+	if _, err := gnmiclient.Replace(context.Background(), configC, distancePath.InternalRouteDistance().Config(), 5); err != nil {
+		t.Fatal(err)
+	}
+	awaitRoute(viaNexthop("172.16.0.0/12", "192.168.2.42", "eth1"))
+
+	if _, err := gnmiclient.Delete(context.Background(), configC, distancePath.ExternalRouteDistance().Config()); err != nil {
+		t.Fatal(err)
+	}
+	awaitRoute(viaNexthop("10.0.0.0/8", "192.168.1.42", "eth0"))
+	awaitRoute(viaNexthop("172.16.0.0/12", "192.168.2.42", "eth1"))
+	if _, err := gnmiclient.Delete(context.Background(), configC, distancePath.InternalRouteDistance().Config()); err != nil {
+		t.Fatal(err)
+	}
+	awaitRoute(viaNexthop("172.16.0.0/12", "192.168.1.42", "eth0"))
+}
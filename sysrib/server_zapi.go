@@ -111,9 +111,14 @@ func convertZebraRoute(niName string, zroute *zebra.IPRouteBody) *Route {
 		})
 	}
 	var routePref RoutePreference
+	var protocol Protocol
 	switch zroute.Type {
 	case zebra.RouteBGP:
 		routePref.AdminDistance = AdminDistanceBGP
+		protocol = ProtocolEBGP
+		if zroute.Flags&zebra.FlagIBGP.ToEach(zebra.MaxZapiVer, zebra.MaxSoftware) != 0 {
+			protocol = ProtocolIBGP
+		}
 	}
 	routePref.Metric = zroute.Metric
 	return &Route{
@@ -122,5 +127,6 @@ func convertZebraRoute(niName string, zroute *zebra.IPRouteBody) *Route {
 		// it is not a connected route.
 		NextHops:  nexthops,
 		RoutePref: routePref,
+		Protocol:  protocol,
 	}
 }
@@ -52,6 +52,7 @@ func convertStaticRoute(prefix string, sroute *oc.NetworkInstance_Protocol_Stati
 		RoutePref: RoutePreference{
 			AdminDistance: 1,
 		},
+		Protocol: ProtocolStatic,
 	}
 }
 
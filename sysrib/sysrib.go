@@ -48,6 +48,11 @@ type SysRIB struct {
 	// routes.
 	GUEPoliciesV4 *generics_tree.TreeV4[GUEPolicy]
 	GUEPoliciesV6 *generics_tree.TreeV6[GUEPolicy]
+
+	// adminDistances are the configured admin distances of protocols.
+	// Every update to this should trigger a re-computation of the
+	// resolved routes.
+	adminDistances map[Protocol]uint8
 }
 
 // NIRIB is the RIB for a single network instance.
@@ -147,6 +152,21 @@ func (sr *SysRIB) getGUEHeader(address string, payloadIsV6 bool) (GUEHeaders, bo
 	return gueHeaders, true, nil
 }
 
+// Protocol is the routing protocol that added a route.
+type Protocol int
+
+const (
+	// ProtocolUnspecified is the protocol of routes added through the
+	// SetRoute API, whose admin distance isn't configurable.
+	ProtocolUnspecified Protocol = iota
+	ProtocolConnected
+	ProtocolStatic
+	// ProtocolEBGP is the protocol of routes learnt from external BGP peers.
+	ProtocolEBGP
+	// ProtocolIBGP is the protocol of routes learnt from internal BGP peers.
+	ProtocolIBGP
+)
+
 type RoutePreference struct {
 	// AdminDistance is the admin distance of the protocol that added this
 	// route.
@@ -167,6 +187,9 @@ type Route struct {
 	// it is not a connected route.
 	NextHops  []*afthelper.NextHopSummary `json:"nexthops"`
 	RoutePref RoutePreference
+	// Protocol is the protocol that added the route, whose configured
+	// admin distance overrides the one in RoutePref.
+	Protocol Protocol `json:"protocol"`
 }
 
 func (r *Route) String() string {
@@ -198,9 +221,10 @@ func (r *Route) String() string {
 // testing.
 func NewSysRIB(initialCfg *oc.Root) (*SysRIB, error) {
 	sr := &SysRIB{
-		NI:            map[string]*NIRIB{},
-		GUEPoliciesV4: generics_tree.NewTreeV4[GUEPolicy](),
-		GUEPoliciesV6: generics_tree.NewTreeV6[GUEPolicy](),
+		NI:             map[string]*NIRIB{},
+		GUEPoliciesV4:  generics_tree.NewTreeV4[GUEPolicy](),
+		GUEPoliciesV6:  generics_tree.NewTreeV6[GUEPolicy](),
+		adminDistances: map[Protocol]uint8{},
 	}
 
 	if initialCfg != nil {
@@ -347,6 +371,35 @@ func (sr *SysRIB) DeleteGUEPolicy(prefix string) (bool, error) {
 	return count > 0, nil
 }
 
+// SetAdminDistance configures the admin distance of the routes of the
+// protocol, overriding it when selecting between the routes for a prefix.
+func (sr *SysRIB) SetAdminDistance(protocol Protocol, distance uint8) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.adminDistances[protocol] = distance
+}
+
+// DeleteAdminDistance restores the default admin distance of the routes of
+// the protocol. It returns true if a configured admin distance was deleted.
+func (sr *SysRIB) DeleteAdminDistance(protocol Protocol) bool {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	_, ok := sr.adminDistances[protocol]
+	delete(sr.adminDistances, protocol)
+	return ok
+}
+
+// routePreference returns the preference of the route used to select
+// between the routes for a prefix, with the configured admin distance of
+// its protocol. The caller must hold sr.mu.
+func (sr *SysRIB) routePreference(r *Route) RoutePreference {
+	pref := r.RoutePref
+	if distance, ok := sr.adminDistances[r.Protocol]; ok {
+		pref.AdminDistance = distance
+	}
+	return pref
+}
+
 // NewRoute returns a new route for the specified prefix.
 // Note - today this doesn't actually result in a viable
 // forwarding entry unless its a connected route :-)
@@ -481,6 +534,15 @@ func (sr *SysRIB) EgressInterface(inputNI string, ip *net.IPNet) ([]*Interface,
 //
 // NOTE: sr.mu.RLock() must be called prior to calling this function.
 func (sr *SysRIB) egressNexthops(inputNI string, ip *net.IPNet, interfaces map[Interface]bool) (map[ResolvedNexthop]bool, *Route, error) {
+	return sr.resolveNexthops(inputNI, ip, interfaces, map[string]bool{})
+}
+
+// resolveNexthops implements egressNexthops. resolving is the set of route
+// prefixes, qualified by their network instance, whose nexthops are being
+// resolved: a nexthop that resolves over one of them, such as that of a
+// default route learned from a neighbour whose address only the default
+// route covers, is unresolvable.
+func (sr *SysRIB) resolveNexthops(inputNI string, ip *net.IPNet, interfaces map[Interface]bool, resolving map[string]bool) (map[ResolvedNexthop]bool, *Route, error) {
 	// no RIB recursion currently
 	if inputNI == "" {
 		inputNI = sr.defaultNI
@@ -495,11 +557,20 @@ func (sr *SysRIB) egressNexthops(inputNI string, ip *net.IPNet, interfaces map[I
 		log.V(1).Infof("Prefix not found in RIB: %v", ip)
 		return nil, nil, nil
 	}
+	if len(routes) > 0 {
+		key := inputNI + "|" + routes[0].Prefix
+		if resolving[key] {
+			log.V(1).Infof("Prefix %v resolves over route %s, which is being resolved", ip, routes[0].Prefix)
+			return nil, nil, nil
+		}
+		resolving[key] = true
+		defer delete(resolving, key)
+	}
 
 	// For each route entry for the prefix, recursively resolve their nexthops.
 	// Then, select the set of resolved nexthops for the route entry according to the following preference:
 	//   1. Has at least one enabled & connected nexthop after resolution.
-	//   2. Lowest admin distance.
+	//   2. Lowest admin distance, as configured for the route's protocol.
 	//   3. Lowest metric.
 	// When there is a tie, use regular ECMP/WCMP rules.
 	//
@@ -508,11 +579,12 @@ func (sr *SysRIB) egressNexthops(inputNI string, ip *net.IPNet, interfaces map[I
 	resolvedRoutes := map[RoutePreference]*Route{}
 	for _, cr := range routes {
 		log.V(1).Infof("Resolving route: %v", cr)
-		if allEgressNhs[cr.RoutePref] == nil {
-			allEgressNhs[cr.RoutePref] = map[ResolvedNexthop]bool{}
-			resolvedRoutes[cr.RoutePref] = cr
+		pref := sr.routePreference(cr)
+		if allEgressNhs[pref] == nil {
+			allEgressNhs[pref] = map[ResolvedNexthop]bool{}
+			resolvedRoutes[pref] = cr
 		}
-		egressNhs := allEgressNhs[cr.RoutePref]
+		egressNhs := allEgressNhs[pref]
 		if cr.Connected != nil {
 			if interfaces[*cr.Connected] {
 				nh := ResolvedNexthop{
@@ -537,7 +609,7 @@ func (sr *SysRIB) egressNexthops(inputNI string, ip *net.IPNet, interfaces map[I
 			if err != nil {
 				return nil, nil, err
 			}
-			recursiveNHs, _, err := sr.egressNexthops(nh.NetworkInstance, nhop, interfaces)
+			recursiveNHs, _, err := sr.resolveNexthops(nh.NetworkInstance, nhop, interfaces, resolving)
 			if err != nil {
 				return nil, nil, fmt.Errorf("for nexthop %s, can't resolve: %v", nh.Address, err)
 			}